@@ -0,0 +1,108 @@
+package bookstore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterHistory_BookAt(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Options{PriceDecimals: 1, SizeDecimals: 3, SnapshotEvery: 2})
+	require.NoError(t, err)
+
+	base := time.UnixMilli(1700000000000)
+	require.NoError(t, w.Snapshot(base, 1,
+		[]Level{{Price: 100.0, Size: 1}, {Price: 99.9, Size: 2}},
+		[]Level{{Price: 100.1, Size: 0.5}, {Price: 100.2, Size: 3}}))
+	require.NoError(t, w.Update(base.Add(time.Second), 2,
+		[]Level{{Price: 100.0, Size: 0}, {Price: 99.8, Size: 4}}, nil))
+	// Second delta triggers a snapshot record
+	require.NoError(t, w.Update(base.Add(2*time.Second), 3,
+		nil, []Level{{Price: 100.1, Size: 1.25}}))
+	require.NoError(t, w.Update(base.Add(3*time.Second), 4,
+		[]Level{{Price: 100.05, Size: 7}}, nil))
+	require.NoError(t, w.Flush())
+
+	h, err := Load(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, 4, h.Len())
+	assert.Len(t, h.snapshots, 2)
+	assert.Equal(t, base, h.Start())
+
+	book, err := h.BookAt(base.Add(1500 * time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), book.Seq)
+	assert.Equal(t, []Level{{Price: 99.9, Size: 2}, {Price: 99.8, Size: 4}}, book.Bids)
+	assert.Equal(t, []Level{{Price: 100.1, Size: 0.5}, {Price: 100.2, Size: 3}}, book.Asks)
+
+	book, err = h.BookAt(base.Add(time.Hour))
+	require.NoError(t, err)
+	bid, _ := book.BestBid()
+	ask, _ := book.BestAsk()
+	assert.Equal(t, Level{Price: 100.1, Size: 7}, bid, "prices are rounded to the configured precision")
+	assert.Equal(t, Level{Price: 100.1, Size: 1.25}, ask)
+
+	_, err = h.BookAt(base.Add(-time.Second))
+	assert.Error(t, err)
+
+	var seen int
+	h.Each(func(b *Book) bool { seen++; return seen < 2 })
+	assert.Equal(t, 2, seen)
+}
+
+func TestWriter_UpdateBeforeSnapshot(t *testing.T) {
+	w, err := NewWriter(&bytes.Buffer{}, Options{})
+	require.NoError(t, err)
+	assert.Error(t, w.Update(time.Now(), 1, nil, nil))
+}
+
+func TestLoad_BadInput(t *testing.T) {
+	_, err := Load(bytes.NewReader([]byte("nope")))
+	assert.ErrorIs(t, err, ErrBadFormat)
+
+	var buf bytes.Buffer
+	w, _ := NewWriter(&buf, Options{})
+	require.NoError(t, w.Snapshot(time.Now(), 1, []Level{{Price: 1, Size: 1}}, nil))
+	require.NoError(t, w.Flush())
+	_, err = Load(bytes.NewReader(buf.Bytes()[:buf.Len()-2]))
+	assert.ErrorIs(t, err, ErrBadFormat)
+
+	// Every prefix of a valid recording is rejected or decodes to fewer records
+	for i := len(magic); i < buf.Len(); i++ {
+		h, err := Load(bytes.NewReader(buf.Bytes()[:i]))
+		if err == nil {
+			assert.Zero(t, h.Len(), "prefix %d", i)
+		} else {
+			assert.ErrorIs(t, err, ErrBadFormat, "prefix %d", i)
+		}
+	}
+}
+
+func TestLoad_CorruptCounts(t *testing.T) {
+	header := append([]byte(magic), 2, 3)
+	huge := binary.AppendUvarint(nil, 1<<62)
+
+	// Snapshot at dt=0, dseq=0 claiming 1<<62 bid levels
+	input := append(append(append([]byte{}, header...), kindSnapshot, 0, 0), huge...)
+	_, err := Load(bytes.NewReader(input))
+	assert.ErrorIs(t, err, ErrBadFormat)
+
+	// A plausible count with the levels missing
+	input = append(append([]byte{}, header...), kindSnapshot, 0, 0, 100)
+	_, err = Load(bytes.NewReader(input))
+	assert.ErrorIs(t, err, ErrBadFormat)
+
+	_, err = Load(bytes.NewReader(append([]byte(magic), huge...)))
+	assert.ErrorIs(t, err, ErrBadFormat, "impossible scale")
+}
+
+func TestFromWS(t *testing.T) {
+	levels := FromWS([]ws.OrderBookLevel{{Price: "100.5", Amount: "2"}, {Price: "bad", Amount: "1"}})
+	assert.Equal(t, []Level{{Price: 100.5, Size: 2}}, levels)
+}
@@ -0,0 +1,344 @@
+package bookstore
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// File layout:
+//
+//	header: magic "OBK1", uvarint price decimals, uvarint size decimals
+//	record: kind byte, varint time delta (ms, vs previous record), varint seq delta,
+//	        uvarint bid count, bid levels, uvarint ask count, ask levels
+//	level:  varint price delta in ticks (vs previous level on the same side), uvarint size in lots
+//
+// Prices and sizes are stored as integers scaled by the header decimals, so repeated and
+// nearby levels shrink to one or two bytes each.
+const magic = "OBK1"
+
+const (
+	kindSnapshot byte = 1
+	kindDelta    byte = 2
+)
+
+// ErrBadFormat is returned when the input is not a bookstore recording.
+var ErrBadFormat = errors.New("bookstore: bad format")
+
+// Options configures a Writer.
+type Options struct {
+	// PriceDecimals and SizeDecimals set the fixed-point precision used for encoding.
+	// Values finer than this precision are rounded.
+	PriceDecimals int
+	SizeDecimals  int
+
+	// SnapshotEvery writes a full snapshot after this many deltas. Defaults to 100.
+	SnapshotEvery int
+
+	// SnapshotInterval additionally forces a snapshot when this much time has passed
+	// since the last one. Zero disables the time-based trigger.
+	SnapshotInterval time.Duration
+}
+
+type scaledLevel struct {
+	price int64
+	size  int64
+}
+
+type scale struct {
+	price float64
+	size  float64
+}
+
+func newScale(priceDecimals, sizeDecimals int) scale {
+	return scale{price: math.Pow10(priceDecimals), size: math.Pow10(sizeDecimals)}
+}
+
+func (s scale) scaled(l Level) scaledLevel {
+	return scaledLevel{price: int64(math.Round(l.Price * s.price)), size: int64(math.Round(l.Size * s.size))}
+}
+
+func (s scale) level(l scaledLevel) Level {
+	return Level{Price: float64(l.price) / s.price, Size: float64(l.size) / s.size}
+}
+
+type record struct {
+	kind byte
+	time time.Time
+	seq  int64
+	bids []scaledLevel
+	asks []scaledLevel
+}
+
+// Writer encodes order book snapshots and deltas. It tracks the book itself so it can
+// emit periodic full snapshots. It is not safe for concurrent use.
+type Writer struct {
+	w     *bufio.Writer
+	opts  Options
+	scale scale
+	book  *state
+
+	started      bool
+	lastTime     int64
+	lastSeq      int64
+	lastSnapshot time.Time
+	deltas       int
+	buf          []byte
+}
+
+// NewWriter writes the file header to w and returns a Writer.
+func NewWriter(w io.Writer, opts Options) (*Writer, error) {
+	if opts.PriceDecimals < 0 || opts.SizeDecimals < 0 || opts.PriceDecimals > 12 || opts.SizeDecimals > 12 {
+		return nil, fmt.Errorf("decimals must be between 0 and 12")
+	}
+	if opts.SnapshotEvery <= 0 {
+		opts.SnapshotEvery = 100
+	}
+
+	bw := bufio.NewWriter(w)
+	hdr := []byte(magic)
+	hdr = binary.AppendUvarint(hdr, uint64(opts.PriceDecimals))
+	hdr = binary.AppendUvarint(hdr, uint64(opts.SizeDecimals))
+	if _, err := bw.Write(hdr); err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		w:     bw,
+		opts:  opts,
+		scale: newScale(opts.PriceDecimals, opts.SizeDecimals),
+		book:  newState(),
+	}, nil
+}
+
+// Snapshot records a full book replacing the previous state.
+func (w *Writer) Snapshot(t time.Time, seq int64, bids, asks []Level) error {
+	r := &record{kind: kindSnapshot, time: t, seq: seq, bids: w.scaleLevels(bids), asks: w.scaleLevels(asks)}
+	w.book.apply(r)
+	w.deltas = 0
+	w.lastSnapshot = t
+	return w.write(r)
+}
+
+// Update records changed levels. Levels with zero size are removals. A full snapshot is
+// written instead when the configured snapshot cadence is reached.
+func (w *Writer) Update(t time.Time, seq int64, bids, asks []Level) error {
+	if !w.started {
+		return errors.New("bookstore: update before first snapshot")
+	}
+	r := &record{kind: kindDelta, time: t, seq: seq, bids: w.scaleLevels(bids), asks: w.scaleLevels(asks)}
+	w.book.apply(r)
+	w.deltas++
+
+	due := w.deltas >= w.opts.SnapshotEvery ||
+		(w.opts.SnapshotInterval > 0 && t.Sub(w.lastSnapshot) >= w.opts.SnapshotInterval)
+	if due {
+		w.deltas = 0
+		w.lastSnapshot = t
+		r = &record{kind: kindSnapshot, time: t, seq: seq, bids: sortedSide(w.book.bids), asks: sortedSide(w.book.asks)}
+	}
+	return w.write(r)
+}
+
+// Flush writes buffered records to the underlying writer.
+func (w *Writer) Flush() error {
+	return w.w.Flush()
+}
+
+func (w *Writer) scaleLevels(levels []Level) []scaledLevel {
+	out := make([]scaledLevel, len(levels))
+	for i, l := range levels {
+		out[i] = w.scale.scaled(l)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].price < out[j].price })
+	return out
+}
+
+func (w *Writer) write(r *record) error {
+	ms := r.time.UnixMilli()
+	b := append(w.buf[:0], r.kind)
+	b = binary.AppendVarint(b, ms-w.lastTime)
+	b = binary.AppendVarint(b, r.seq-w.lastSeq)
+	b = appendLevels(b, r.bids)
+	b = appendLevels(b, r.asks)
+	w.buf = b
+	w.lastTime, w.lastSeq, w.started = ms, r.seq, true
+
+	_, err := w.w.Write(b)
+	return err
+}
+
+func appendLevels(b []byte, levels []scaledLevel) []byte {
+	b = binary.AppendUvarint(b, uint64(len(levels)))
+	var prev int64
+	for _, l := range levels {
+		b = binary.AppendVarint(b, l.price-prev)
+		b = binary.AppendUvarint(b, uint64(l.size))
+		prev = l.price
+	}
+	return b
+}
+
+// History is a decoded recording that can reconstruct the book at any timestamp.
+type History struct {
+	scale     scale
+	records   []*record
+	snapshots []int // indexes of snapshot records
+}
+
+// Load decodes a full recording from r.
+func Load(r io.Reader) (*History, error) {
+	br := bufio.NewReader(r)
+
+	hdr := make([]byte, len(magic))
+	if _, err := io.ReadFull(br, hdr); err != nil || string(hdr) != magic {
+		return nil, ErrBadFormat
+	}
+	priceDec, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, ErrBadFormat
+	}
+	sizeDec, err := binary.ReadUvarint(br)
+	if err != nil || priceDec > maxDecimals || sizeDec > maxDecimals {
+		return nil, ErrBadFormat
+	}
+
+	h := &History{scale: newScale(int(priceDec), int(sizeDec))}
+	var lastTime, lastSeq int64
+	for {
+		kind, err := br.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if kind != kindSnapshot && kind != kindDelta {
+			return nil, fmt.Errorf("%w: unknown record kind %d", ErrBadFormat, kind)
+		}
+
+		dt, err := binary.ReadVarint(br)
+		if err != nil {
+			return nil, truncated(err)
+		}
+		dseq, err := binary.ReadVarint(br)
+		if err != nil {
+			return nil, truncated(err)
+		}
+		lastTime += dt
+		lastSeq += dseq
+
+		rec := &record{kind: kind, time: time.UnixMilli(lastTime), seq: lastSeq}
+		if rec.bids, err = readLevels(br); err != nil {
+			return nil, truncated(err)
+		}
+		if rec.asks, err = readLevels(br); err != nil {
+			return nil, truncated(err)
+		}
+
+		if kind == kindSnapshot {
+			h.snapshots = append(h.snapshots, len(h.records))
+		}
+		h.records = append(h.records, rec)
+	}
+	return h, nil
+}
+
+func truncated(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return fmt.Errorf("%w: truncated record", ErrBadFormat)
+	}
+	return err
+}
+
+const (
+	// maxDecimals bounds the scales of a recording; float64 holds 17 significant digits.
+	maxDecimals = 17
+	// maxLevels bounds the levels of a record, far beyond the depth of any Bitget book.
+	maxLevels = 1 << 20
+)
+
+func readLevels(br *bufio.Reader) ([]scaledLevel, error) {
+	n, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	if n > maxLevels {
+		return nil, fmt.Errorf("%w: %d levels in a record", ErrBadFormat, n)
+	}
+	// n is untrusted until the levels have been read
+	levels := make([]scaledLevel, 0, min(n, 1024))
+	var prev int64
+	for i := uint64(0); i < n; i++ {
+		dp, err := binary.ReadVarint(br)
+		if err != nil {
+			return nil, err
+		}
+		size, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		prev += dp
+		levels = append(levels, scaledLevel{price: prev, size: int64(size)})
+	}
+	return levels, nil
+}
+
+// Len returns the number of records in the history.
+func (h *History) Len() int {
+	return len(h.records)
+}
+
+// Start returns the time of the first record.
+func (h *History) Start() time.Time {
+	if len(h.records) == 0 {
+		return time.Time{}
+	}
+	return h.records[0].time
+}
+
+// End returns the time of the last record.
+func (h *History) End() time.Time {
+	if len(h.records) == 0 {
+		return time.Time{}
+	}
+	return h.records[len(h.records)-1].time
+}
+
+// BookAt reconstructs the book as of t: the nearest preceding snapshot plus all deltas
+// recorded at or before t.
+func (h *History) BookAt(t time.Time) (*Book, error) {
+	ms := t.UnixMilli()
+	// Last snapshot at or before t
+	i := sort.Search(len(h.snapshots), func(i int) bool {
+		return h.records[h.snapshots[i]].time.UnixMilli() > ms
+	}) - 1
+	if i < 0 {
+		return nil, fmt.Errorf("no snapshot recorded before %s", t.UTC().Format(time.RFC3339Nano))
+	}
+
+	s := newState()
+	for _, r := range h.records[h.snapshots[i]:] {
+		if r.time.UnixMilli() > ms {
+			break
+		}
+		s.apply(r)
+	}
+	return s.book(h.scale), nil
+}
+
+// Each calls fn with the book after every record, stopping early if fn returns false.
+func (h *History) Each(fn func(b *Book) bool) {
+	s := newState()
+	for _, r := range h.records {
+		s.apply(r)
+		if !fn(s.book(h.scale)) {
+			return
+		}
+	}
+}
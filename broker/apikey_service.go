@@ -0,0 +1,271 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// APIKey is an API key of a sub-account. SecretKey is only returned on creation.
+type APIKey struct {
+	SubUid    string   `json:"subUid"`
+	Label     string   `json:"label"`
+	APIKey    string   `json:"apiKey"`
+	SecretKey string   `json:"secretKey"`
+	PermType  string   `json:"permType"`
+	PermList  []string `json:"permList"`
+	IPList    []string `json:"ipList"`
+}
+
+// keyParams holds the settings shared by key creation and modification.
+type keyParams struct {
+	passphrase string
+	label      string
+	permType   string
+	permList   []string
+	ipList     []string
+}
+
+func (p keyParams) apply(params map[string]interface{}) {
+	if p.passphrase != "" {
+		params["passphrase"] = p.passphrase
+	}
+	if p.label != "" {
+		params["label"] = p.label
+	}
+	if p.permType != "" {
+		params["permType"] = p.permType
+	}
+	if p.permList != nil {
+		params["permList"] = p.permList
+	}
+	if p.ipList != nil {
+		params["ipList"] = p.ipList
+	}
+}
+
+// CreateAPIKeyService creates an API key for a sub-account.
+type CreateAPIKeyService struct {
+	c ClientInterface
+
+	subUid string
+	keyParams
+}
+
+// SubUid sets the sub-account (required).
+func (s *CreateAPIKeyService) SubUid(subUid string) *CreateAPIKeyService {
+	s.subUid = subUid
+	return s
+}
+
+// Passphrase sets the passphrase of the key, 8 to 32 letters and digits (required).
+func (s *CreateAPIKeyService) Passphrase(passphrase string) *CreateAPIKeyService {
+	s.passphrase = passphrase
+	return s
+}
+
+// Label sets a note for the key (required).
+func (s *CreateAPIKeyService) Label(label string) *CreateAPIKeyService {
+	s.label = label
+	return s
+}
+
+// PermType sets PermReadOnly or PermReadWrite (required).
+func (s *CreateAPIKeyService) PermType(permType string) *CreateAPIKeyService {
+	s.permType = permType
+	return s
+}
+
+// PermList sets the permissions of a read-write key, see PermSpotTrade.
+func (s *CreateAPIKeyService) PermList(perms ...string) *CreateAPIKeyService {
+	s.permList = perms
+	return s
+}
+
+// IPList binds the key to the given IP addresses.
+func (s *CreateAPIKeyService) IPList(ips ...string) *CreateAPIKeyService {
+	s.ipList = ips
+	return s
+}
+
+// Do sends the request.
+func (s *CreateAPIKeyService) Do(ctx context.Context) (*APIKey, error) {
+	if s.subUid == "" {
+		return nil, fmt.Errorf("subUid is required")
+	}
+	if s.passphrase == "" {
+		return nil, fmt.Errorf("passphrase is required")
+	}
+	if s.label == "" {
+		return nil, fmt.Errorf("label is required")
+	}
+	if s.permType == "" {
+		return nil, fmt.Errorf("permType is required")
+	}
+	params := map[string]interface{}{"subUid": s.subUid}
+	s.apply(params)
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "POST", EndpointCreateAPIKey, nil, body, true)
+	if err != nil {
+		return nil, err
+	}
+	var result APIKey
+	if err := decodeOne(res.Data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// APIKeysService lists the API keys of a sub-account.
+type APIKeysService struct {
+	c ClientInterface
+
+	subUid string
+}
+
+// SubUid sets the sub-account (required).
+func (s *APIKeysService) SubUid(subUid string) *APIKeysService {
+	s.subUid = subUid
+	return s
+}
+
+// Do sends the request.
+func (s *APIKeysService) Do(ctx context.Context) ([]*APIKey, error) {
+	if s.subUid == "" {
+		return nil, fmt.Errorf("subUid is required")
+	}
+	params := url.Values{}
+	params.Set("subUid", s.subUid)
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointAPIKeyList, params, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	var keys []*APIKey
+	if err := json.Unmarshal(res.Data, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// ModifyAPIKeyService changes the label, permissions or IP binding of a sub-account API
+// key. Unset fields keep their value.
+type ModifyAPIKeyService struct {
+	c ClientInterface
+
+	subUid string
+	apiKey string
+	keyParams
+}
+
+// SubUid sets the sub-account (required).
+func (s *ModifyAPIKeyService) SubUid(subUid string) *ModifyAPIKeyService {
+	s.subUid = subUid
+	return s
+}
+
+// APIKey sets the key to modify (required).
+func (s *ModifyAPIKeyService) APIKey(apiKey string) *ModifyAPIKeyService {
+	s.apiKey = apiKey
+	return s
+}
+
+// Passphrase sets the passphrase of the key (required).
+func (s *ModifyAPIKeyService) Passphrase(passphrase string) *ModifyAPIKeyService {
+	s.passphrase = passphrase
+	return s
+}
+
+// Label sets a new note for the key.
+func (s *ModifyAPIKeyService) Label(label string) *ModifyAPIKeyService {
+	s.label = label
+	return s
+}
+
+// PermType sets PermReadOnly or PermReadWrite.
+func (s *ModifyAPIKeyService) PermType(permType string) *ModifyAPIKeyService {
+	s.permType = permType
+	return s
+}
+
+// PermList replaces the permissions of the key.
+func (s *ModifyAPIKeyService) PermList(perms ...string) *ModifyAPIKeyService {
+	s.permList = perms
+	return s
+}
+
+// IPList replaces the IP addresses bound to the key.
+func (s *ModifyAPIKeyService) IPList(ips ...string) *ModifyAPIKeyService {
+	s.ipList = ips
+	return s
+}
+
+// Do sends the request.
+func (s *ModifyAPIKeyService) Do(ctx context.Context) (*APIKey, error) {
+	if s.subUid == "" {
+		return nil, fmt.Errorf("subUid is required")
+	}
+	if s.apiKey == "" {
+		return nil, fmt.Errorf("apiKey is required")
+	}
+	if s.passphrase == "" {
+		return nil, fmt.Errorf("passphrase is required")
+	}
+	params := map[string]interface{}{"subUid": s.subUid, "apiKey": s.apiKey}
+	s.apply(params)
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "POST", EndpointModifyAPIKey, nil, body, true)
+	if err != nil {
+		return nil, err
+	}
+	var result APIKey
+	if err := decodeOne(res.Data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteAPIKeyService deletes an API key of a sub-account.
+type DeleteAPIKeyService struct {
+	c ClientInterface
+
+	subUid string
+	apiKey string
+}
+
+// SubUid sets the sub-account (required).
+func (s *DeleteAPIKeyService) SubUid(subUid string) *DeleteAPIKeyService {
+	s.subUid = subUid
+	return s
+}
+
+// APIKey sets the key to delete (required).
+func (s *DeleteAPIKeyService) APIKey(apiKey string) *DeleteAPIKeyService {
+	s.apiKey = apiKey
+	return s
+}
+
+// Do sends the request.
+func (s *DeleteAPIKeyService) Do(ctx context.Context) error {
+	if s.subUid == "" {
+		return fmt.Errorf("subUid is required")
+	}
+	if s.apiKey == "" {
+		return fmt.Errorf("apiKey is required")
+	}
+	body, err := json.Marshal(map[string]interface{}{"subUid": s.subUid, "apiKey": s.apiKey})
+	if err != nil {
+		return err
+	}
+	_, _, err = s.c.CallAPI(ctx, "POST", EndpointDeleteAPIKey, nil, body, true)
+	return err
+}
@@ -0,0 +1,63 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestCreateAPIKeyService_Do(t *testing.T) {
+	client := &MockClient{}
+	client.On("CallAPI", mock.Anything, "POST", EndpointCreateAPIKey, mock.Anything, mock.Anything, true).
+		Return(okResponse(`[{"subUid":"9001","label":"bot","apiKey":"bg_1","secretKey":"s3cret","permType":"read_and_write","permList":["contract_trade"],"ipList":["10.0.0.1"]}]`), &fasthttp.ResponseHeader{}, nil)
+
+	svc := NewCreateAPIKeyService(client).SubUid("9001").Passphrase("passphrase01").Label("bot").
+		PermType(PermReadWrite).PermList(PermContractTrade).IPList("10.0.0.1")
+	key, err := svc.Clone().Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "bg_1", key.APIKey)
+	assert.Equal(t, "s3cret", key.SecretKey)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(client.Calls[0].Arguments.Get(4).([]byte), &body))
+	assert.Equal(t, map[string]interface{}{
+		"subUid": "9001", "passphrase": "passphrase01", "label": "bot", "permType": "read_and_write",
+		"permList": []interface{}{"contract_trade"}, "ipList": []interface{}{"10.0.0.1"},
+	}, body)
+}
+
+func TestCreateAPIKeyService_Validation(t *testing.T) {
+	_, err := NewCreateAPIKeyService(nil).SubUid("9001").Passphrase("passphrase01").Label("bot").Do(context.Background())
+	assert.EqualError(t, err, "permType is required")
+}
+
+func TestAPIKeyManagement(t *testing.T) {
+	client := &MockClient{}
+	client.On("CallAPI", mock.Anything, "GET", EndpointAPIKeyList, url.Values{"subUid": {"9001"}}, []byte(nil), true).
+		Return(okResponse(`[{"subUid":"9001","apiKey":"bg_1","permType":"readonly"}]`), &fasthttp.ResponseHeader{}, nil)
+	client.On("CallAPI", mock.Anything, "POST", EndpointModifyAPIKey, mock.Anything,
+		bodyEquals(map[string]interface{}{"subUid": "9001", "apiKey": "bg_1", "passphrase": "passphrase01", "permType": "readonly"}), true).
+		Return(okResponse(`{"subUid":"9001","apiKey":"bg_1","permType":"readonly"}`), &fasthttp.ResponseHeader{}, nil)
+	client.On("CallAPI", mock.Anything, "POST", EndpointDeleteAPIKey, mock.Anything,
+		bodyEquals(map[string]interface{}{"subUid": "9001", "apiKey": "bg_1"}), true).
+		Return(okResponse(`"success"`), &fasthttp.ResponseHeader{}, nil)
+	ctx := context.Background()
+
+	keys, err := NewAPIKeysService(client).SubUid("9001").Do(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+
+	key, err := NewModifyAPIKeyService(client).SubUid("9001").APIKey("bg_1").Passphrase("passphrase01").PermType(PermReadOnly).Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, PermReadOnly, key.PermType)
+
+	require.NoError(t, NewDeleteAPIKeyService(client).SubUid("9001").APIKey("bg_1").Do(ctx))
+	assert.EqualError(t, NewDeleteAPIKeyService(nil).SubUid("9001").Do(ctx), "apiKey is required")
+	client.AssertExpectations(t)
+}
@@ -0,0 +1,59 @@
+package broker
+
+import "slices"
+
+// Clone returns an independent copy of s and its parameters.
+func (s *APIKeysService) Clone() *APIKeysService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CommissionsService) Clone() *CommissionsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CreateAPIKeyService) Clone() *CreateAPIKeyService {
+	c := *s
+	c.keyParams = s.keyParams.clone()
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CreateSubaccountService) Clone() *CreateSubaccountService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *DeleteAPIKeyService) Clone() *DeleteAPIKeyService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *DepositAddressService) Clone() *DepositAddressService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *ModifyAPIKeyService) Clone() *ModifyAPIKeyService {
+	c := *s
+	c.keyParams = s.keyParams.clone()
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *SubaccountListService) Clone() *SubaccountListService {
+	c := *s
+	return &c
+}
+
+func (p keyParams) clone() keyParams {
+	p.permList = slices.Clone(p.permList)
+	p.ipList = slices.Clone(p.ipList)
+	return p
+}
@@ -0,0 +1,141 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// CommissionsService retrieves the commissions (rebates) earned on the trading fees of
+// sub-accounts and referred customers.
+type CommissionsService struct {
+	c ClientInterface
+
+	uid        string
+	coin       string
+	startTime  string
+	endTime    string
+	limit      string
+	idLessThan string
+}
+
+// Uid filters records by customer or sub-account.
+func (s *CommissionsService) Uid(uid string) *CommissionsService {
+	s.uid = uid
+	return s
+}
+
+// Coin filters records by commission coin, e.g. "USDT".
+func (s *CommissionsService) Coin(coin string) *CommissionsService {
+	s.coin = coin
+	return s
+}
+
+// StartTime sets the start of the query range in Unix milliseconds.
+func (s *CommissionsService) StartTime(startTime string) *CommissionsService {
+	s.startTime = startTime
+	return s
+}
+
+// EndTime sets the end of the query range in Unix milliseconds.
+func (s *CommissionsService) EndTime(endTime string) *CommissionsService {
+	s.endTime = endTime
+	return s
+}
+
+// Limit sets the page size, at most 100.
+func (s *CommissionsService) Limit(limit string) *CommissionsService {
+	s.limit = limit
+	return s
+}
+
+// IdLessThan requests the page of records older than the given EndId.
+func (s *CommissionsService) IdLessThan(idLessThan string) *CommissionsService {
+	s.idLessThan = idLessThan
+	return s
+}
+
+// Commission is the rebate earned on one customer's fees for one period.
+type Commission struct {
+	Id          string `json:"id"`
+	Uid         string `json:"uid"`
+	Coin        string `json:"coin"`
+	ProductType string `json:"productType"` // Business line, e.g. "spot" or "USDT-FUTURES"
+	Fee         string `json:"fee"`         // Fees paid by the customer
+	Commission  string `json:"commission"`  // Rebate earned by the broker
+	Ts          string `json:"ts"`
+}
+
+// Time returns the time of the record.
+func (c *Commission) Time() time.Time {
+	ms, _ := strconv.ParseInt(c.Ts, 10, 64)
+	return time.UnixMilli(ms)
+}
+
+// CommissionsResponse is one page of commission records.
+type CommissionsResponse struct {
+	List  []*Commission `json:"list"`
+	EndId string        `json:"endId"`
+}
+
+// Do sends the request.
+func (s *CommissionsService) Do(ctx context.Context) (*CommissionsResponse, error) {
+	params := url.Values{}
+	if s.uid != "" {
+		params.Set("uid", s.uid)
+	}
+	if s.coin != "" {
+		params.Set("coin", s.coin)
+	}
+	if s.startTime != "" {
+		params.Set("startTime", s.startTime)
+	}
+	if s.endTime != "" {
+		params.Set("endTime", s.endTime)
+	}
+	if s.limit != "" {
+		params.Set("limit", s.limit)
+	}
+	if s.idLessThan != "" {
+		params.Set("idLessThan", s.idLessThan)
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointCommissions, params, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	var response CommissionsResponse
+	if err := json.Unmarshal(res.Data, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Iter iterates over all matching records, newest first, following EndId across pages.
+func (s *CommissionsService) Iter(ctx context.Context) *common.Iter[Commission] {
+	return common.NewIter(ctx, func(ctx context.Context, cursor string) (common.Page[Commission], error) {
+		page := *s
+		if cursor != "" {
+			page.idLessThan = cursor
+		}
+		res, err := page.Do(ctx)
+		if err != nil || res == nil {
+			return common.Page[Commission]{}, err
+		}
+		return common.Page[Commission]{Items: common.Values(res.List), Cursor: res.EndId}, nil
+	})
+}
+
+// Total returns the sum of the commissions of records, by coin.
+func Total(records []Commission) map[string]float64 {
+	total := make(map[string]float64)
+	for _, r := range records {
+		v, _ := strconv.ParseFloat(r.Commission, 64)
+		total[r.Coin] += v
+	}
+	return total
+}
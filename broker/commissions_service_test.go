@@ -0,0 +1,32 @@
+package broker
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestCommissionsService_Iter(t *testing.T) {
+	client := &MockClient{}
+	client.On("CallAPI", mock.Anything, "GET", EndpointCommissions, url.Values{"startTime": {"1700000000000"}}, []byte(nil), true).
+		Return(okResponse(`{"list":[{"id":"3","uid":"9001","coin":"USDT","fee":"10","commission":"3","ts":"1700086400000"},{"id":"2","uid":"9002","coin":"USDT","commission":"1.5"}],"endId":"2"}`), &fasthttp.ResponseHeader{}, nil)
+	client.On("CallAPI", mock.Anything, "GET", EndpointCommissions, url.Values{"startTime": {"1700000000000"}, "idLessThan": {"2"}}, []byte(nil), true).
+		Return(okResponse(`{"list":[{"id":"1","uid":"9001","coin":"BTC","commission":"0.0001"}],"endId":"1"}`), &fasthttp.ResponseHeader{}, nil)
+	client.On("CallAPI", mock.Anything, "GET", EndpointCommissions, mock.Anything, []byte(nil), true).
+		Return(okResponse(`{"list":[],"endId":""}`), &fasthttp.ResponseHeader{}, nil)
+
+	it := NewCommissionsService(client).StartTime("1700000000000").Iter(context.Background())
+	var records []Commission
+	for it.Next() {
+		records = append(records, it.Item())
+	}
+	require.NoError(t, it.Err())
+	require.Len(t, records, 3)
+	assert.Equal(t, int64(1700086400000), records[0].Time().UnixMilli())
+	assert.Equal(t, map[string]float64{"USDT": 4.5, "BTC": 0.0001}, Total(records))
+}
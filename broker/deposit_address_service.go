@@ -0,0 +1,73 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// DepositAddressService retrieves the deposit address of a sub-account.
+type DepositAddressService struct {
+	c ClientInterface
+
+	subUid string
+	coin   string
+	chain  string
+}
+
+// SubUid sets the sub-account (required).
+func (s *DepositAddressService) SubUid(subUid string) *DepositAddressService {
+	s.subUid = subUid
+	return s
+}
+
+// Coin sets the coin to deposit, e.g. "USDT" (required).
+func (s *DepositAddressService) Coin(coin string) *DepositAddressService {
+	s.coin = coin
+	return s
+}
+
+// Chain sets the network, e.g. "trc20". Defaults to the coin's main chain.
+func (s *DepositAddressService) Chain(chain string) *DepositAddressService {
+	s.chain = chain
+	return s
+}
+
+// DepositAddress is a deposit address of a sub-account.
+type DepositAddress struct {
+	SubUid  string `json:"subUid"`
+	Coin    string `json:"coin"`
+	Address string `json:"address"`
+	Chain   string `json:"chain"`
+	Tag     string `json:"tag"` // Memo required by some chains
+	URL     string `json:"url"` // Block explorer link
+	CTime   string `json:"cTime"`
+}
+
+// Do sends the request.
+func (s *DepositAddressService) Do(ctx context.Context) (*DepositAddress, error) {
+	if s.subUid == "" {
+		return nil, fmt.Errorf("subUid is required")
+	}
+	if s.coin == "" {
+		return nil, fmt.Errorf("coin is required")
+	}
+	params := map[string]interface{}{"subUid": s.subUid, "coin": s.coin}
+	if s.chain != "" {
+		params["chain"] = s.chain
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "POST", EndpointDepositAddress, nil, body, true)
+	if err != nil {
+		return nil, err
+	}
+	var result DepositAddress
+	if err := decodeOne(res.Data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
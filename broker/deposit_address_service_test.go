@@ -0,0 +1,26 @@
+package broker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestDepositAddressService_Do(t *testing.T) {
+	client := &MockClient{}
+	client.On("CallAPI", mock.Anything, "POST", EndpointDepositAddress, mock.Anything,
+		bodyEquals(map[string]interface{}{"subUid": "9001", "coin": "USDT", "chain": "trc20"}), true).
+		Return(okResponse(`{"subUid":"9001","coin":"USDT","address":"TXa1","chain":"trc20"}`), &fasthttp.ResponseHeader{}, nil)
+
+	addr, err := NewDepositAddressService(client).SubUid("9001").Coin("USDT").Chain("trc20").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "TXa1", addr.Address)
+	client.AssertExpectations(t)
+
+	_, err = NewDepositAddressService(nil).SubUid("9001").Do(context.Background())
+	assert.EqualError(t, err, "coin is required")
+}
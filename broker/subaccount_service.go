@@ -0,0 +1,172 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// Subaccount is a broker sub-account.
+type Subaccount struct {
+	SubUid         string   `json:"subUid"`
+	SubaccountName string   `json:"subaccountName"`
+	Status         string   `json:"status"`
+	PermList       []string `json:"permList"`
+	Label          string   `json:"label"`
+	CTime          string   `json:"cTime"`
+	UTime          string   `json:"uTime"`
+}
+
+// Created returns the creation time of the sub-account.
+func (s *Subaccount) Created() time.Time {
+	ms, _ := strconv.ParseInt(s.CTime, 10, 64)
+	return time.UnixMilli(ms)
+}
+
+// CreateSubaccountService creates a broker sub-account.
+type CreateSubaccountService struct {
+	c ClientInterface
+
+	name  string
+	label string
+}
+
+// Name sets the sub-account name, lowercase letters and digits (required).
+func (s *CreateSubaccountService) Name(name string) *CreateSubaccountService {
+	s.name = name
+	return s
+}
+
+// Label sets a note for the sub-account.
+func (s *CreateSubaccountService) Label(label string) *CreateSubaccountService {
+	s.label = label
+	return s
+}
+
+// Do sends the request.
+func (s *CreateSubaccountService) Do(ctx context.Context) (*Subaccount, error) {
+	if s.name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	params := map[string]interface{}{"subaccountName": s.name}
+	if s.label != "" {
+		params["label"] = s.label
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "POST", EndpointCreateSubaccount, nil, body, true)
+	if err != nil {
+		return nil, err
+	}
+	var result Subaccount
+	if err := decodeOne(res.Data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SubaccountListService lists the broker sub-accounts.
+type SubaccountListService struct {
+	c ClientInterface
+
+	status     string
+	startTime  string
+	endTime    string
+	limit      string
+	idLessThan string
+}
+
+// Status filters sub-accounts by status, see StatusNormal.
+func (s *SubaccountListService) Status(status string) *SubaccountListService {
+	s.status = status
+	return s
+}
+
+// StartTime sets the start of the creation time range in Unix milliseconds.
+func (s *SubaccountListService) StartTime(startTime string) *SubaccountListService {
+	s.startTime = startTime
+	return s
+}
+
+// EndTime sets the end of the creation time range in Unix milliseconds.
+func (s *SubaccountListService) EndTime(endTime string) *SubaccountListService {
+	s.endTime = endTime
+	return s
+}
+
+// Limit sets the page size, at most 100.
+func (s *SubaccountListService) Limit(limit string) *SubaccountListService {
+	s.limit = limit
+	return s
+}
+
+// IdLessThan requests the page after the given cursor.
+func (s *SubaccountListService) IdLessThan(idLessThan string) *SubaccountListService {
+	s.idLessThan = idLessThan
+	return s
+}
+
+// SubaccountListResponse is one page of sub-accounts.
+type SubaccountListResponse struct {
+	HasNextPage bool          `json:"hasNextPage"`
+	IdLessThan  string        `json:"idLessThan"` // Cursor of the next page
+	SubList     []*Subaccount `json:"subList"`
+}
+
+// Do sends the request.
+func (s *SubaccountListService) Do(ctx context.Context) (*SubaccountListResponse, error) {
+	params := url.Values{}
+	if s.status != "" {
+		params.Set("status", s.status)
+	}
+	if s.startTime != "" {
+		params.Set("startTime", s.startTime)
+	}
+	if s.endTime != "" {
+		params.Set("endTime", s.endTime)
+	}
+	if s.limit != "" {
+		params.Set("limit", s.limit)
+	}
+	if s.idLessThan != "" {
+		params.Set("idLessThan", s.idLessThan)
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointSubaccountList, params, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	var response SubaccountListResponse
+	if err := json.Unmarshal(res.Data, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Iter iterates over all matching sub-accounts, following the cursor while HasNextPage
+// is set.
+func (s *SubaccountListService) Iter(ctx context.Context) *common.Iter[Subaccount] {
+	return common.NewIter(ctx, func(ctx context.Context, cursor string) (common.Page[Subaccount], error) {
+		page := *s
+		if cursor != "" {
+			page.idLessThan = cursor
+		}
+		res, err := page.Do(ctx)
+		if err != nil || res == nil {
+			return common.Page[Subaccount]{}, err
+		}
+		next := ""
+		if res.HasNextPage {
+			next = res.IdLessThan
+		}
+		return common.Page[Subaccount]{Items: common.Values(res.SubList), Cursor: next}, nil
+	})
+}
@@ -0,0 +1,45 @@
+package broker
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestCreateSubaccountService_Do(t *testing.T) {
+	client := &MockClient{}
+	client.On("CallAPI", mock.Anything, "POST", EndpointCreateSubaccount, mock.Anything,
+		bodyEquals(map[string]interface{}{"subaccountName": "desk01", "label": "desk"}), true).
+		Return(okResponse(`[{"subUid":"9001","subaccountName":"desk01@virtual-bitget.com","status":"normal","label":"desk","cTime":"1700000000000"}]`), &fasthttp.ResponseHeader{}, nil)
+
+	sub, err := NewCreateSubaccountService(client).Name("desk01").Label("desk").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "9001", sub.SubUid)
+	assert.Equal(t, int64(1700000000000), sub.Created().UnixMilli())
+	client.AssertExpectations(t)
+
+	_, err = NewCreateSubaccountService(nil).Do(context.Background())
+	assert.EqualError(t, err, "name is required")
+}
+
+func TestSubaccountListService_Iter(t *testing.T) {
+	client := &MockClient{}
+	client.On("CallAPI", mock.Anything, "GET", EndpointSubaccountList, url.Values{"status": {"normal"}}, []byte(nil), true).
+		Return(okResponse(`{"hasNextPage":true,"idLessThan":"9002","subList":[{"subUid":"9003"},{"subUid":"9002"}]}`), &fasthttp.ResponseHeader{}, nil)
+	client.On("CallAPI", mock.Anything, "GET", EndpointSubaccountList, url.Values{"status": {"normal"}, "idLessThan": {"9002"}}, []byte(nil), true).
+		Return(okResponse(`{"hasNextPage":false,"idLessThan":"9001","subList":[{"subUid":"9001"}]}`), &fasthttp.ResponseHeader{}, nil)
+
+	it := NewSubaccountListService(client).Status(StatusNormal).Iter(context.Background())
+	var uids []string
+	for it.Next() {
+		uids = append(uids, it.Item().SubUid)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"9003", "9002", "9001"}, uids)
+	client.AssertExpectations(t)
+}
@@ -0,0 +1,120 @@
+// Package broker provides services for Bitget broker accounts: creating and listing
+// broker sub-accounts, managing their API keys, their deposit addresses and the
+// commissions earned on them.
+//
+// Services follow the fluent API of the futures packages and accept any
+// ClientInterface; a futures.Client signed with the broker's API key signs broker
+// requests as well:
+//
+//	client := futures.NewClient(apiKey, secretKey, passphrase)
+//	sub, err := broker.NewCreateSubaccountService(client).Name("desk01").Label("desk").Do(ctx)
+//	// ...
+//	key, err := broker.NewCreateAPIKeyService(client).
+//		SubUid(sub.SubUid).
+//		Passphrase("passphrase01").
+//		Label("bot").
+//		PermType(broker.PermReadWrite).
+//		PermList(broker.PermContractTrade).
+//		Do(ctx)
+//
+// Sub-accounts of a regular or unified account are managed by the uta package instead.
+package broker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/khanbekov/go-bitget/common/client"
+)
+
+// Re-export common types to avoid importing the futures package
+type (
+	ClientInterface = client.ClientInterface
+	ApiResponse     = client.ApiResponse
+)
+
+// Sub-account statuses.
+const (
+	StatusNormal = "normal"
+	StatusFreeze = "freeze"
+)
+
+// API key permission types and permissions.
+const (
+	PermReadOnly  = "readonly"
+	PermReadWrite = "read_and_write"
+
+	PermSpotTrade     = "spot_trade"
+	PermMarginTrade   = "margin_trade"
+	PermContractTrade = "contract_trade"
+	PermTransfer      = "transfer"
+)
+
+// API Endpoints for broker operations
+const (
+	EndpointCreateSubaccount = "/api/v2/broker/account/create-subaccount"
+	EndpointSubaccountList   = "/api/v2/broker/account/subaccount-list"
+	EndpointDepositAddress   = "/api/v2/broker/account/subaccount-address"
+
+	EndpointCreateAPIKey = "/api/v2/broker/manage/create-subaccount-apikey"
+	EndpointAPIKeyList   = "/api/v2/broker/manage/subaccount-apikey-list"
+	EndpointModifyAPIKey = "/api/v2/broker/manage/modify-subaccount-apikey"
+	EndpointDeleteAPIKey = "/api/v2/broker/manage/delete-subaccount-apikey"
+
+	EndpointCommissions = "/api/v2/broker/customer-commissions"
+)
+
+// decodeOne decodes data holding either v or a list whose first element is v. Some
+// broker endpoints wrap single results in a list.
+func decodeOne(data []byte, v interface{}) error {
+	var list []json.RawMessage
+	if err := json.Unmarshal(data, &list); err == nil {
+		if len(list) == 0 {
+			return fmt.Errorf("empty response")
+		}
+		data = list[0]
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Service Constructor Functions
+
+// NewCreateSubaccountService creates a new sub-account creation service.
+func NewCreateSubaccountService(client ClientInterface) *CreateSubaccountService {
+	return &CreateSubaccountService{c: client}
+}
+
+// NewSubaccountListService creates a new sub-account list service.
+func NewSubaccountListService(client ClientInterface) *SubaccountListService {
+	return &SubaccountListService{c: client}
+}
+
+// NewDepositAddressService creates a new sub-account deposit address service.
+func NewDepositAddressService(client ClientInterface) *DepositAddressService {
+	return &DepositAddressService{c: client}
+}
+
+// NewCreateAPIKeyService creates a new sub-account API key creation service.
+func NewCreateAPIKeyService(client ClientInterface) *CreateAPIKeyService {
+	return &CreateAPIKeyService{c: client}
+}
+
+// NewAPIKeysService creates a new sub-account API key list service.
+func NewAPIKeysService(client ClientInterface) *APIKeysService {
+	return &APIKeysService{c: client}
+}
+
+// NewModifyAPIKeyService creates a new sub-account API key modification service.
+func NewModifyAPIKeyService(client ClientInterface) *ModifyAPIKeyService {
+	return &ModifyAPIKeyService{c: client}
+}
+
+// NewDeleteAPIKeyService creates a new sub-account API key deletion service.
+func NewDeleteAPIKeyService(client ClientInterface) *DeleteAPIKeyService {
+	return &DeleteAPIKeyService{c: client}
+}
+
+// NewCommissionsService creates a new commission record service.
+func NewCommissionsService(client ClientInterface) *CommissionsService {
+	return &CommissionsService{c: client}
+}
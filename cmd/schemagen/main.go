@@ -0,0 +1,40 @@
+// Command schemagen writes JSON Schemas and an OpenAPI document for the SDK response
+// types:
+//
+//	go run ./cmd/schemagen -out schemas -openapi schemas/openapi.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/khanbekov/go-bitget/schema"
+)
+
+func main() {
+	out := flag.String("out", "schemas", "directory for <Name>.schema.json files; empty to skip")
+	openapi := flag.String("openapi", "", "path of an OpenAPI 3.1 document; empty to skip")
+	title := flag.String("title", "Bitget Go SDK", "OpenAPI document title")
+	version := flag.String("version", "1.0.0", "OpenAPI document version")
+	flag.Parse()
+
+	types := schema.Types()
+	if *out != "" {
+		if err := schema.WriteDir(*out, types); err != nil {
+			fail(err)
+		}
+		fmt.Printf("wrote %d schemas to %s\n", len(types), *out)
+	}
+	if *openapi != "" {
+		if err := schema.WriteJSON(*openapi, schema.OpenAPI(*title, *version, types)); err != nil {
+			fail(err)
+		}
+		fmt.Printf("wrote %s\n", *openapi)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,76 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Candle is an OHLCV candle shared by the futures and uta packages, so indicator code
+// works with either. On the wire a candle is an array of decimal strings:
+//
+//	[timestamp, open, high, low, close, volume, quoteVolume]
+type Candle struct {
+	// CloseTime is the candle timestamp in milliseconds as reported by Bitget, which is
+	// the start of the period. The name is kept for compatibility.
+	CloseTime        int64
+	Open             float64
+	High             float64
+	Low              float64
+	Close            float64
+	Volume           float64 // Base asset volume
+	QuoteAssetVolume float64 // Quote asset volume (turnover)
+}
+
+// Time returns the candle timestamp in UTC.
+func (c Candle) Time() time.Time {
+	return time.UnixMilli(c.CloseTime).UTC()
+}
+
+// Strings returns the wire representation with decimals in plain notation.
+func (c Candle) Strings() [7]string {
+	return [7]string{
+		strconv.FormatInt(c.CloseTime, 10),
+		FormatFloat(c.Open),
+		FormatFloat(c.High),
+		FormatFloat(c.Low),
+		FormatFloat(c.Close),
+		FormatFloat(c.Volume),
+		FormatFloat(c.QuoteAssetVolume),
+	}
+}
+
+// MarshalJSON encodes the candle in the wire array form, so it round-trips through
+// UnmarshalJSON.
+func (c Candle) MarshalJSON() ([]byte, error) {
+	s := c.Strings()
+	return json.Marshal(s[:])
+}
+
+// UnmarshalJSON decodes the wire array form. Elements after the seventh are ignored.
+func (c *Candle) UnmarshalJSON(data []byte) error {
+	var arr []string
+	if err := json.Unmarshal(data, &arr); err != nil {
+		return err
+	}
+	if len(arr) < 7 {
+		return fmt.Errorf("expected 7 elements, received %d", len(arr))
+	}
+
+	var parsed Candle
+	ts, err := strconv.ParseInt(arr[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("incorrect CloseTime: %v", err)
+	}
+	parsed.CloseTime = ts
+	for i, dst := range []*float64{&parsed.Open, &parsed.High, &parsed.Low, &parsed.Close, &parsed.Volume, &parsed.QuoteAssetVolume} {
+		v, err := strconv.ParseFloat(arr[i+1], 64)
+		if err != nil {
+			return fmt.Errorf("failed parsing element %d: %v", i+2, err)
+		}
+		*dst = v
+	}
+	*c = parsed
+	return nil
+}
@@ -0,0 +1,44 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCandle_UnmarshalJSON(t *testing.T) {
+	var c Candle
+	err := json.Unmarshal([]byte(`["1700000000000","100.5","110","95.25","105","12.5","1300.75","extra"]`), &c)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1700000000000), c.CloseTime)
+	assert.Equal(t, 100.5, c.Open)
+	assert.Equal(t, 110.0, c.High)
+	assert.Equal(t, 95.25, c.Low)
+	assert.Equal(t, 105.0, c.Close)
+	assert.Equal(t, 12.5, c.Volume)
+	assert.Equal(t, 1300.75, c.QuoteAssetVolume)
+	assert.Equal(t, time.UnixMilli(1700000000000).UTC(), c.Time())
+}
+
+func TestCandle_UnmarshalJSON_Errors(t *testing.T) {
+	var c Candle
+	assert.EqualError(t, json.Unmarshal([]byte(`["1","2","3"]`), &c), "expected 7 elements, received 3")
+	assert.Error(t, json.Unmarshal([]byte(`["x","1","1","1","1","1","1"]`), &c))
+	assert.Error(t, json.Unmarshal([]byte(`["1","1","1","bad","1","1","1"]`), &c))
+}
+
+func TestCandle_RoundTrip(t *testing.T) {
+	in := Candle{CloseTime: 1700000000000, Open: 0.00001234, High: 2, Low: 0.5, Close: 1.5, Volume: 1e7, QuoteAssetVolume: 3}
+
+	data, err := json.Marshal(in)
+	require.NoError(t, err)
+	assert.JSONEq(t, `["1700000000000","0.00001234","2","0.5","1.5","10000000","3"]`, string(data))
+
+	var out Candle
+	require.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, in, out)
+}
@@ -0,0 +1,106 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeClock_SleepAndAfter(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	clock := NewFakeClock(start)
+
+	woke := make(chan time.Time)
+	go func() {
+		clock.Sleep(time.Minute)
+		woke <- clock.Now()
+	}()
+	clock.BlockUntil(1)
+	late := clock.After(2 * time.Minute)
+
+	clock.Advance(30 * time.Second)
+	select {
+	case <-woke:
+		t.Fatal("woke before the deadline")
+	default:
+	}
+	clock.Advance(3 * time.Minute)
+	assert.Equal(t, start.Add(210*time.Second), <-woke)
+	assert.Equal(t, start.Add(2*time.Minute), <-late, "receives the time it fired at")
+	assert.Zero(t, clock.Waiters())
+
+	select {
+	case <-clock.After(0):
+	default:
+		t.Fatal("zero duration fires immediately")
+	}
+}
+
+func TestFakeClock_Ticker(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	clock := NewFakeClock(start)
+	ticker := clock.NewTicker(time.Second)
+
+	clock.Advance(time.Second)
+	assert.Equal(t, start.Add(time.Second), <-ticker.C())
+	clock.Advance(5 * time.Second)
+	assert.Equal(t, start.Add(2*time.Second), <-ticker.C(), "missed ticks are dropped")
+	select {
+	case <-ticker.C():
+		t.Fatal("only one tick is buffered")
+	default:
+	}
+
+	ticker.Stop()
+	assert.Zero(t, clock.Waiters())
+	clock.Advance(time.Second)
+	select {
+	case <-ticker.C():
+		t.Fatal("stopped")
+	default:
+	}
+}
+
+func TestFakeClock_Concurrent(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	var wg sync.WaitGroup
+	for i := 1; i <= 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clock.Sleep(time.Duration(i) * time.Second)
+		}(i)
+	}
+	clock.BlockUntil(8)
+	clock.Advance(8 * time.Second)
+	wg.Wait()
+}
+
+func TestRateLimiter_FakeClock(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	l := NewRateLimiter(1, 1)
+	l.SetClock(clock)
+	require.True(t, l.Allow())
+	assert.False(t, l.Allow())
+
+	done := make(chan error)
+	go func() { done <- l.Wait(context.Background()) }()
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	assert.NoError(t, <-done)
+
+	clock.Advance(500 * time.Millisecond)
+	assert.False(t, l.Allow(), "half a token")
+	clock.Advance(500 * time.Millisecond)
+	assert.True(t, l.Allow())
+}
+
+func TestClockOrSystem(t *testing.T) {
+	assert.Equal(t, SystemClock(), ClockOrSystem(nil))
+	fake := NewFakeClock(time.Time{})
+	assert.Same(t, fake, ClockOrSystem(fake))
+}
@@ -0,0 +1,73 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Environment selects the Bitget REST and WebSocket hosts and whether requests are routed
+// to demo (paper) trading.
+type Environment int
+
+const (
+	// Production is live trading.
+	Production Environment = iota
+	// Demo is Bitget's demo trading: the production REST host with the "paptrading"
+	// header, and dedicated WebSocket hosts.
+	Demo
+	// Testnet is an alias for Demo. Bitget has no separate testnet; demo trading is
+	// the supported sandbox.
+	Testnet = Demo
+)
+
+// DemoTradingHeader is the request header that routes REST calls to demo trading.
+const DemoTradingHeader = "paptrading"
+
+// String returns "production" or "demo".
+func (e Environment) String() string {
+	switch e {
+	case Production:
+		return "production"
+	case Demo:
+		return "demo"
+	}
+	return fmt.Sprintf("Environment(%d)", int(e))
+}
+
+// ParseEnvironment parses "production"/"prod"/"live" or "demo"/"testnet"/"paper",
+// case-insensitively. An empty string selects Production.
+func ParseEnvironment(s string) (Environment, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "production", "prod", "live", "mainnet":
+		return Production, nil
+	case "demo", "testnet", "paper", "paptrading":
+		return Demo, nil
+	}
+	return Production, fmt.Errorf("unknown environment %q", s)
+}
+
+// IsDemo reports whether requests must carry the demo trading header.
+func (e Environment) IsDemo() bool {
+	return e == Demo
+}
+
+// RESTURL returns the REST base URL.
+func (e Environment) RESTURL() string {
+	return "https://api.bitget.com"
+}
+
+// PublicWSURL returns the public WebSocket URL.
+func (e Environment) PublicWSURL() string {
+	if e.IsDemo() {
+		return "wss://wspap.bitget.com/v2/ws/public"
+	}
+	return "wss://ws.bitget.com/v2/ws/public"
+}
+
+// PrivateWSURL returns the private WebSocket URL.
+func (e Environment) PrivateWSURL() string {
+	if e.IsDemo() {
+		return "wss://wspap.bitget.com/v2/ws/private"
+	}
+	return "wss://ws.bitget.com/v2/ws/private"
+}
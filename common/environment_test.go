@@ -0,0 +1,25 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEnvironment(t *testing.T) {
+	for in, want := range map[string]Environment{"": Production, "Live": Production, "demo": Demo, " TESTNET ": Demo} {
+		got, err := ParseEnvironment(in)
+		assert.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, err := ParseEnvironment("staging")
+	assert.Error(t, err)
+}
+
+func TestEnvironmentURLs(t *testing.T) {
+	assert.Equal(t, "https://api.bitget.com", Demo.RESTURL())
+	assert.Equal(t, "wss://ws.bitget.com/v2/ws/public", Production.PublicWSURL())
+	assert.Equal(t, "wss://wspap.bitget.com/v2/ws/private", Testnet.PrivateWSURL())
+	assert.True(t, Testnet.IsDemo())
+	assert.Equal(t, "demo", Demo.String())
+}
@@ -9,6 +9,8 @@ import (
 type APIError struct {
 	Code    string `json:"code"`
 	Message string `json:"msg"`
+	// Locale is the language Message was requested in, e.g. "zh-CN"
+	Locale string `json:"-"`
 }
 
 func (e *APIError) Error() string {
@@ -0,0 +1,182 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeLayout is the layout of parsed timestamps in marshaled responses: RFC 3339 in UTC
+// with millisecond precision.
+const TimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// FormatFloat formats f in plain decimal notation with the fewest digits that round-trip,
+// e.g. 0.0000001 instead of 1e-07.
+func FormatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// stepEpsilon absorbs float error in v/step, so 0.3/0.1 still counts as three steps.
+const stepEpsilon = 1e-9
+
+// RoundDownToStep rounds v down to a multiple of step, such as a size step or price tick.
+func RoundDownToStep(v, step float64) float64 {
+	return math.Floor(v/step+stepEpsilon) * step
+}
+
+// RoundUpToStep rounds v up to a multiple of step.
+func RoundUpToStep(v, step float64) float64 {
+	return math.Ceil(v/step-stepEpsilon) * step
+}
+
+// FormatToStep formats v with as many decimals as step has, e.g. "0.100" for a 0.001
+// step, which is the precision order sizes and prices are sent with.
+func FormatToStep(v, step float64) string {
+	decimals := 0
+	if s := FormatFloat(step); strings.Contains(s, ".") {
+		decimals = len(s) - strings.IndexByte(s, '.') - 1
+	}
+	return strconv.FormatFloat(v, 'f', decimals, 64)
+}
+
+// ParseMs converts a millisecond epoch timestamp, as a string or integer, to a UTC time.
+// It returns the zero time for empty, zero or malformed input.
+func ParseMs(ms interface{}) time.Time {
+	var v int64
+	switch ms := ms.(type) {
+	case int64:
+		v = ms
+	case string:
+		v, _ = strconv.ParseInt(ms, 10, 64)
+	}
+	if v <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(v).UTC()
+}
+
+// JSONField is an extra key appended by MarshalFields.
+type JSONField struct {
+	Key   string
+	Value interface{}
+}
+
+// TimeField returns an extra field holding the millisecond timestamp ms formatted with
+// TimeLayout. The field is omitted when ms is empty or zero.
+func TimeField(key string, ms interface{}) JSONField {
+	t := ParseMs(ms)
+	if t.IsZero() {
+		return JSONField{Key: key}
+	}
+	return JSONField{Key: key, Value: t.Format(TimeLayout)}
+}
+
+// MarshalFields encodes the response struct v as a JSON object with its fields in
+// declaration order, followed by the non-nil extra fields. float64 fields are written in
+// plain decimal notation so output is stable and diff-friendly; NaN and infinities become
+// null. json tags, including omitempty and "-", are honored.
+//
+// Response types use it to implement json.Marshaler without losing the wire field names,
+// so the output can still be unmarshaled into the same type.
+func MarshalFields(v interface{}, extra ...JSONField) ([]byte, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("MarshalFields: %T is not a struct", v)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	first := true
+	write := func(key string, raw []byte) {
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		k, _ := json.Marshal(key)
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(raw)
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fv := rv.Field(i)
+		if strings.Contains(opts, "omitempty") && fv.IsZero() {
+			continue
+		}
+
+		var raw []byte
+		if fv.Kind() == reflect.Float64 || fv.Kind() == reflect.Float32 {
+			f := fv.Float()
+			if math.IsNaN(f) || math.IsInf(f, 0) {
+				raw = []byte("null")
+			} else {
+				raw = []byte(FormatFloat(f))
+			}
+		} else {
+			var err error
+			if raw, err = json.Marshal(fv.Interface()); err != nil {
+				return nil, fmt.Errorf("MarshalFields: field %s: %w", field.Name, err)
+			}
+		}
+		write(name, raw)
+	}
+
+	for _, f := range extra {
+		if f.Value == nil {
+			continue
+		}
+		raw, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, fmt.Errorf("MarshalFields: field %s: %w", f.Key, err)
+		}
+		write(f.Key, raw)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Describe builds the compact String form of a response type, Name{part part ...},
+// skipping empty parts.
+func Describe(name string, parts ...string) string {
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteByte('{')
+	n := 0
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		if n > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(p)
+		n++
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// KV returns "key=value" for Describe, or "" when value is empty.
+func KV(key, value string) string {
+	if value == "" {
+		return ""
+	}
+	return key + "=" + value
+}
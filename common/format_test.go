@@ -0,0 +1,63 @@
+package common
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalFields(t *testing.T) {
+	type sample struct {
+		Name    string  `json:"name"`
+		Price   float64 `json:"price"`
+		Tiny    float64 `json:"tiny"`
+		Bad     float64 `json:"bad"`
+		Note    string  `json:"note,omitempty"`
+		Skipped string  `json:"-"`
+		Time    string  `json:"cTime"`
+		hidden  string
+	}
+	v := sample{Name: "a", Price: 65000.5, Tiny: 0.0000001, Bad: math.NaN(), Skipped: "x", Time: "1700000000123", hidden: "h"}
+
+	data, err := MarshalFields(v, TimeField("createdAt", v.Time), TimeField("updatedAt", ""), JSONField{Key: "extra", Value: 1})
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"name":"a","price":65000.5,"tiny":0.0000001,"bad":null,"cTime":"1700000000123","createdAt":"2023-11-14T22:13:20.123Z","extra":1}`,
+		string(data))
+	assert.True(t, json.Valid(data))
+
+	_, err = MarshalFields(42)
+	assert.Error(t, err)
+}
+
+func TestParseMs(t *testing.T) {
+	assert.Equal(t, int64(1700000000123), ParseMs("1700000000123").UnixMilli())
+	assert.Equal(t, int64(1700000000123), ParseMs(int64(1700000000123)).UnixMilli())
+	assert.True(t, ParseMs("").IsZero())
+	assert.True(t, ParseMs("abc").IsZero())
+	assert.True(t, ParseMs(int64(0)).IsZero())
+}
+
+func TestDescribe(t *testing.T) {
+	assert.Equal(t, "Order{BTCUSDT buy id=1}", Describe("Order", "BTCUSDT", "", "buy", KV("state", ""), KV("id", "1")))
+	assert.Equal(t, "Empty{}", Describe("Empty"))
+}
+
+func TestSteps(t *testing.T) {
+	assert.InDelta(t, 0.3, RoundDownToStep(0.3, 0.1), 1e-12)
+	assert.InDelta(t, 2.49, RoundDownToStep(2.4987, 0.01), 1e-12)
+	assert.InDelta(t, 2.5, RoundUpToStep(2.4987, 0.01), 1e-12)
+	assert.InDelta(t, 0.3, RoundUpToStep(0.3, 0.1), 1e-12)
+	assert.Equal(t, 50000.0, RoundDownToStep(50004, 5))
+
+	assert.Equal(t, "0.100", FormatToStep(0.1, 0.001))
+	assert.Equal(t, "2.49", FormatToStep(RoundDownToStep(2.4987, 0.01), 0.01))
+	assert.Equal(t, "50000", FormatToStep(50000, 5))
+	assert.Equal(t, "0.00000010", FormatToStep(1e-7, 1e-8))
+	assert.Equal(t, "60000.1", FormatToStep(RoundDownToStep(60000.15, 0.1), 0.1))
+	assert.Equal(t, "1.235", FormatToStep(RoundUpToStep(1.2341, 0.005), 0.005))
+	assert.Equal(t, "61000", FormatToStep(RoundDownToStep(61234, 1000), 1000))
+}
@@ -0,0 +1,17 @@
+package common
+
+import "strings"
+
+// ChannelCodeHeader is the request header carrying the broker or channel code that
+// attributes orders to a partner for fee rebates.
+const ChannelCodeHeader = "X-CHANNEL-API-CODE"
+
+// UserAgent returns base followed by suffix, e.g. "Bitget/golang mybot/2.1". An empty
+// suffix returns base unchanged.
+func UserAgent(base, suffix string) string {
+	suffix = strings.TrimSpace(suffix)
+	if suffix == "" {
+		return base
+	}
+	return base + " " + suffix
+}
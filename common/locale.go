@@ -0,0 +1,24 @@
+package common
+
+// Locale selects the language of REST error messages, e.g. "zh-CN".
+type Locale string
+
+// Locales supported by the Bitget API.
+const (
+	LocaleEnglish Locale = "en-US"
+	LocaleChinese Locale = "zh-CN"
+)
+
+// LocaleHeader is the request header carrying the locale.
+const LocaleHeader = "locale"
+
+// DefaultLocale is sent when no locale is configured.
+const DefaultLocale = LocaleEnglish
+
+// OrDefault returns l, or DefaultLocale when l is empty.
+func (l Locale) OrDefault() Locale {
+	if l == "" {
+		return DefaultLocale
+	}
+	return l
+}
@@ -0,0 +1,117 @@
+package common
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+// Logger is the minimal structured logger used by SDK clients. Key/value pairs are passed
+// as alternating keys and values, e.g. logger.Info("subscribed", "symbol", "BTCUSDT").
+// Errors are passed under the "error" key.
+//
+// Adapters are provided for zerolog, log/slog and zap, so applications can route SDK
+// logs into whatever logger they already use.
+type Logger interface {
+	Debug(msg string, keyvals ...interface{})
+	Info(msg string, keyvals ...interface{})
+	Warn(msg string, keyvals ...interface{})
+	Error(msg string, keyvals ...interface{})
+}
+
+// DebugEnabled reports whether the logger emits debug messages. Loggers that do not
+// implement DebugEnabled() bool are assumed to. Clients use it to skip building
+// expensive debug fields.
+func DebugEnabled(l Logger) bool {
+	if d, ok := l.(interface{ DebugEnabled() bool }); ok {
+		return d.DebugEnabled()
+	}
+	return true
+}
+
+// NopLogger returns a Logger that discards everything.
+func NopLogger() Logger { return nopLogger{} }
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+func (nopLogger) DebugEnabled() bool           { return false }
+
+// NewZerologLogger adapts a zerolog.Logger.
+func NewZerologLogger(l zerolog.Logger) Logger { return zerologLogger{l} }
+
+type zerologLogger struct{ l zerolog.Logger }
+
+func (z zerologLogger) Debug(msg string, kv ...interface{}) { z.log(z.l.Debug(), msg, kv) }
+func (z zerologLogger) Info(msg string, kv ...interface{})  { z.log(z.l.Info(), msg, kv) }
+func (z zerologLogger) Warn(msg string, kv ...interface{})  { z.log(z.l.Warn(), msg, kv) }
+func (z zerologLogger) Error(msg string, kv ...interface{}) { z.log(z.l.Error(), msg, kv) }
+func (z zerologLogger) DebugEnabled() bool                  { return z.l.Debug().Enabled() }
+
+func (z zerologLogger) log(e *zerolog.Event, msg string, kv []interface{}) {
+	if e == nil {
+		return
+	}
+	if len(kv) > 0 {
+		e = e.Fields(kv)
+	}
+	e.Msg(msg)
+}
+
+// NewSlogLogger adapts a *slog.Logger.
+func NewSlogLogger(l *slog.Logger) Logger { return slogLogger{l} }
+
+type slogLogger struct{ l *slog.Logger }
+
+func (s slogLogger) Debug(msg string, kv ...interface{}) { s.l.Debug(msg, kv...) }
+func (s slogLogger) Info(msg string, kv ...interface{})  { s.l.Info(msg, kv...) }
+func (s slogLogger) Warn(msg string, kv ...interface{})  { s.l.Warn(msg, kv...) }
+func (s slogLogger) Error(msg string, kv ...interface{}) { s.l.Error(msg, kv...) }
+func (s slogLogger) DebugEnabled() bool {
+	return s.l.Enabled(context.Background(), slog.LevelDebug)
+}
+
+// ZapSugaredLogger is the subset of *zap.SugaredLogger used by NewZapLogger. Declaring it
+// here keeps zap out of the SDK's dependencies; pass zapLogger.Sugar() directly.
+type ZapSugaredLogger interface {
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+// NewZapLogger adapts a zap sugared logger.
+func NewZapLogger(l ZapSugaredLogger) Logger { return zapLogger{l} }
+
+type zapLogger struct{ l ZapSugaredLogger }
+
+func (z zapLogger) Debug(msg string, kv ...interface{}) { z.l.Debugw(msg, kv...) }
+func (z zapLogger) Info(msg string, kv ...interface{})  { z.l.Infow(msg, kv...) }
+func (z zapLogger) Warn(msg string, kv ...interface{})  { z.l.Warnw(msg, kv...) }
+func (z zapLogger) Error(msg string, kv ...interface{}) { z.l.Errorw(msg, kv...) }
+
+// Sampler decides whether a sampled log message is emitted.
+type Sampler interface {
+	Sample() bool
+}
+
+// SampleEvery returns a Sampler that lets through every n-th message. n <= 1 samples
+// everything.
+func SampleEvery(n uint32) Sampler { return &everyN{n: n} }
+
+type everyN struct {
+	n       uint32
+	counter atomic.Uint32
+}
+
+func (s *everyN) Sample() bool {
+	if s.n <= 1 {
+		return true
+	}
+	return (s.counter.Add(1)-1)%s.n == 0
+}
@@ -0,0 +1,70 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZerologLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewZerologLogger(zerolog.New(&buf).Level(zerolog.InfoLevel))
+
+	l.Debug("hidden")
+	l.Info("subscribed", "symbol", "BTCUSDT", "count", 2)
+	l.Error("failed", "error", errors.New("boom"))
+
+	out := buf.String()
+	assert.NotContains(t, out, "hidden")
+	assert.Contains(t, out, `"level":"info","symbol":"BTCUSDT","count":2,"message":"subscribed"`)
+	assert.Contains(t, out, `"error":"boom"`)
+	assert.False(t, DebugEnabled(l))
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	l.Warn("retrying", "attempt", 2)
+	assert.Contains(t, buf.String(), "level=WARN msg=retrying attempt=2")
+	assert.True(t, DebugEnabled(l))
+}
+
+type fakeZap struct{ lines []string }
+
+func (f *fakeZap) log(level, msg string, kv []interface{}) {
+	f.lines = append(f.lines, fmt.Sprint(level, " ", msg, " ", kv))
+}
+func (f *fakeZap) Debugw(msg string, kv ...interface{}) { f.log("debug", msg, kv) }
+func (f *fakeZap) Infow(msg string, kv ...interface{})  { f.log("info", msg, kv) }
+func (f *fakeZap) Warnw(msg string, kv ...interface{})  { f.log("warn", msg, kv) }
+func (f *fakeZap) Errorw(msg string, kv ...interface{}) { f.log("error", msg, kv) }
+
+func TestZapLogger(t *testing.T) {
+	z := &fakeZap{}
+	l := NewZapLogger(z)
+	l.Debug("a", "k", 1)
+	l.Error("b")
+	assert.Equal(t, []string{"debug a [k 1]", "error b []"}, z.lines)
+}
+
+func TestNopLogger(t *testing.T) {
+	l := NopLogger()
+	l.Error("ignored", "k", "v")
+	assert.False(t, DebugEnabled(l))
+}
+
+func TestSampleEvery(t *testing.T) {
+	s := SampleEvery(3)
+	var got []bool
+	for i := 0; i < 6; i++ {
+		got = append(got, s.Sample())
+	}
+	assert.Equal(t, []bool{true, false, false, true, false, false}, got)
+	assert.True(t, SampleEvery(0).Sample())
+}
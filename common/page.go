@@ -0,0 +1,117 @@
+package common
+
+import (
+	"context"
+)
+
+// Page is one page of a cursor-paginated list.
+type Page[T any] struct {
+	Items []T
+	// Cursor requests the next page; empty when there are no more pages.
+	Cursor string
+}
+
+// PageFunc fetches the page starting at cursor. The first page has an empty cursor.
+type PageFunc[T any] func(ctx context.Context, cursor string) (Page[T], error)
+
+// Iter walks every item of a paginated list, fetching pages lazily:
+//
+//	it := trading.NewOrderHistoryService(client).ProductType(trading.ProductTypeUSDTFutures).Iter(ctx)
+//	for it.Next() {
+//		order := it.Item()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+//
+// Iteration stops when a page is empty, has no cursor or repeats the previous cursor, when
+// a fetch fails or when ctx is done.
+type Iter[T any] struct {
+	ctx    context.Context
+	fetch  PageFunc[T]
+	items  []T
+	idx    int
+	item   T
+	cursor string
+	done   bool
+	err    error
+	pages  int
+}
+
+// NewIter creates an iterator over the pages returned by fetch.
+func NewIter[T any](ctx context.Context, fetch PageFunc[T]) *Iter[T] {
+	return &Iter[T]{ctx: ctx, fetch: fetch}
+}
+
+// Next advances to the next item, fetching the next page when needed. It returns false
+// when the list is exhausted or an error occurred; check Err.
+func (it *Iter[T]) Next() bool {
+	for {
+		if it.idx < len(it.items) {
+			it.item = it.items[it.idx]
+			it.idx++
+			return true
+		}
+		if it.done || it.err != nil {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		page, err := it.fetch(it.ctx, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.pages++
+		it.items, it.idx = page.Items, 0
+		if len(page.Items) == 0 || page.Cursor == "" || page.Cursor == it.cursor {
+			it.done = true
+		}
+		it.cursor = page.Cursor
+	}
+}
+
+// Item returns the current item.
+func (it *Iter[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error that stopped iteration, if any.
+func (it *Iter[T]) Err() error {
+	return it.err
+}
+
+// Cursor returns the cursor of the next page to fetch, e.g. to resume a later run after
+// the current page has been consumed.
+func (it *Iter[T]) Cursor() string {
+	return it.cursor
+}
+
+// Pages returns the number of pages fetched so far.
+func (it *Iter[T]) Pages() int {
+	return it.pages
+}
+
+// Collect drains it into a slice, stopping after max items when max > 0.
+func Collect[T any](it *Iter[T], max int) ([]T, error) {
+	var out []T
+	for (max <= 0 || len(out) < max) && it.Next() {
+		out = append(out, it.Item())
+	}
+	return out, it.Err()
+}
+
+// Values dereferences a slice of pointers as returned by list endpoints, skipping nil
+// entries.
+func Values[T any](ptrs []*T) []T {
+	out := make([]T, 0, len(ptrs))
+	for _, p := range ptrs {
+		if p != nil {
+			out = append(out, *p)
+		}
+	}
+	return out
+}
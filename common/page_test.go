@@ -0,0 +1,86 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pages serves items 1..n in pages of size, with the last item ID as cursor.
+func pages(n, size int, cursors *[]string) PageFunc[int] {
+	return func(ctx context.Context, cursor string) (Page[int], error) {
+		*cursors = append(*cursors, cursor)
+		start := 0
+		if cursor != "" {
+			start, _ = strconv.Atoi(cursor)
+		}
+		var items []int
+		for i := start + 1; i <= n && len(items) < size; i++ {
+			items = append(items, i)
+		}
+		if len(items) == 0 {
+			return Page[int]{}, nil
+		}
+		return Page[int]{Items: items, Cursor: strconv.Itoa(items[len(items)-1])}, nil
+	}
+}
+
+func TestIter_WalksAllPages(t *testing.T) {
+	var cursors []string
+	it := NewIter(context.Background(), pages(5, 2, &cursors))
+	items, err := Collect(it, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+	assert.Equal(t, []string{"", "2", "4", "5"}, cursors)
+	assert.Equal(t, 4, it.Pages())
+	assert.False(t, it.Next(), "exhausted iterators stay exhausted")
+}
+
+func TestIter_StopsOnRepeatedCursorAndMax(t *testing.T) {
+	calls := 0
+	it := NewIter(context.Background(), func(ctx context.Context, cursor string) (Page[string], error) {
+		calls++
+		return Page[string]{Items: []string{"a", "b"}, Cursor: "same"}, nil
+	})
+	items, err := Collect(it, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "a", "b"}, items)
+	assert.Equal(t, 2, calls)
+
+	var cursors []string
+	items2, err := Collect(NewIter(context.Background(), pages(100, 10, &cursors)), 15)
+	require.NoError(t, err)
+	assert.Len(t, items2, 15)
+	assert.Len(t, cursors, 2, "no page is fetched beyond max")
+}
+
+func TestIter_Errors(t *testing.T) {
+	boom := errors.New("boom")
+	it := NewIter(context.Background(), func(ctx context.Context, cursor string) (Page[int], error) {
+		if cursor == "" {
+			return Page[int]{Items: []int{1}, Cursor: "1"}, nil
+		}
+		return Page[int]{}, boom
+	})
+	items, err := Collect(it, 0)
+	assert.Equal(t, []int{1}, items)
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, "1", it.Cursor(), "cursor allows resuming")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var cursors []string
+	_, err = Collect(NewIter(ctx, pages(3, 1, &cursors)), 0)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, cursors)
+}
+
+func TestValues(t *testing.T) {
+	a, b := 1, 2
+	assert.Equal(t, []int{1, 2}, Values([]*int{&a, nil, &b}))
+	assert.Empty(t, Values[int](nil))
+}
@@ -0,0 +1,35 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_Burst(t *testing.T) {
+	l := NewRateLimiter(1, 3)
+	for i := 0; i < 3; i++ {
+		assert.True(t, l.Allow(), "token %d", i)
+	}
+	assert.False(t, l.Allow())
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	l := NewRateLimiter(50, 1)
+	assert.True(t, l.Allow())
+
+	start := time.Now()
+	assert.NoError(t, l.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+}
+
+func TestRateLimiter_WaitCancelled(t *testing.T) {
+	l := NewRateLimiter(0.01, 1)
+	assert.True(t, l.Allow())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+}
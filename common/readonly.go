@@ -0,0 +1,46 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrReadOnly is matched by every *ReadOnlyError.
+var ErrReadOnly = errors.New("client is read-only")
+
+// ReadOnlyError is returned by a read-only client for a request that could change
+// account state. The request is rejected before it is signed or sent.
+type ReadOnlyError struct {
+	Method   string
+	Endpoint string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("client is read-only: %s %s rejected", e.Method, e.Endpoint)
+}
+
+// Is makes errors.Is(err, ErrReadOnly) match.
+func (e *ReadOnlyError) Is(target error) bool {
+	return target == ErrReadOnly
+}
+
+// IsMutating reports whether a request with the given HTTP method may change account
+// state. Every Bitget query is a GET, so any other method, including an unknown one,
+// counts as mutating.
+func IsMutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// CheckReadOnly returns a *ReadOnlyError when readOnly is set and the request is
+// mutating, and nil otherwise.
+func CheckReadOnly(readOnly bool, method, endpoint string) error {
+	if readOnly && IsMutating(method) {
+		return &ReadOnlyError{Method: method, Endpoint: endpoint}
+	}
+	return nil
+}
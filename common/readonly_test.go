@@ -0,0 +1,27 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsMutating(t *testing.T) {
+	assert.False(t, IsMutating("GET"))
+	assert.True(t, IsMutating("POST"))
+	assert.True(t, IsMutating("DELETE"))
+	assert.True(t, IsMutating(""), "unknown methods are mutating")
+}
+
+func TestCheckReadOnly(t *testing.T) {
+	assert.NoError(t, CheckReadOnly(false, "POST", "/api/v2/mix/order/place-order"))
+	assert.NoError(t, CheckReadOnly(true, "GET", "/api/v2/mix/order/detail"))
+
+	err := CheckReadOnly(true, "POST", "/api/v2/mix/order/place-order")
+	assert.ErrorIs(t, err, ErrReadOnly)
+	var roErr *ReadOnlyError
+	assert.True(t, errors.As(err, &roErr))
+	assert.Equal(t, "/api/v2/mix/order/place-order", roErr.Endpoint)
+	assert.EqualError(t, err, "client is read-only: POST /api/v2/mix/order/place-order rejected")
+}
@@ -0,0 +1,106 @@
+package common
+
+import (
+	"net/url"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Redacted replaces sensitive values in log output.
+const Redacted = "[REDACTED]"
+
+// sensitiveNames lists header, query and body field names that are never logged verbatim.
+// Names are compared case-insensitively with '-' and '_' removed.
+var sensitiveNames = map[string]bool{
+	"accesskey":        true,
+	"accesssign":       true,
+	"accesspassphrase": true,
+	"apikey":           true,
+	"secretkey":        true,
+	"secret":           true,
+	"passphrase":       true,
+	"password":         true,
+	"sign":             true,
+	"signature":        true,
+	"token":            true,
+}
+
+// IsSensitive reports whether a header, query parameter or JSON field with the given name
+// carries credentials and must be redacted before logging.
+func IsSensitive(name string) bool {
+	n := strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(name))
+	return sensitiveNames[n]
+}
+
+// RedactHeaders returns a copy of headers with sensitive values replaced.
+func RedactHeaders(headers map[string]string) map[string]string {
+	out := make(map[string]string, len(headers))
+	for k, v := range headers {
+		if IsSensitive(k) {
+			v = Redacted
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// RedactQuery returns the encoded query string with sensitive parameters replaced.
+func RedactQuery(params url.Values) string {
+	if len(params) == 0 {
+		return ""
+	}
+	out := make(url.Values, len(params))
+	for k, v := range params {
+		if IsSensitive(k) {
+			v = []string{Redacted}
+		}
+		out[k] = v
+	}
+	return out.Encode()
+}
+
+// RedactBody returns a JSON request or response body with sensitive fields replaced at
+// any depth. Bodies that are not valid JSON are returned unchanged.
+func RedactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v interface{}
+	if err := jsoniter.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	if !redactValue(v) {
+		return string(body)
+	}
+	redacted, err := jsoniter.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+// redactValue masks sensitive fields in place and reports whether anything changed.
+func redactValue(v interface{}) bool {
+	changed := false
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if IsSensitive(k) {
+				t[k] = Redacted
+				changed = true
+				continue
+			}
+			if redactValue(child) {
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, child := range t {
+			if redactValue(child) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
@@ -0,0 +1,40 @@
+package common
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactHeaders(t *testing.T) {
+	got := RedactHeaders(map[string]string{
+		"ACCESS-KEY":        "key",
+		"ACCESS-SIGN":       "sig",
+		"ACCESS-PASSPHRASE": "pass",
+		"ACCESS-TIMESTAMP":  "1700000000000",
+	})
+	assert.Equal(t, Redacted, got["ACCESS-KEY"])
+	assert.Equal(t, Redacted, got["ACCESS-SIGN"])
+	assert.Equal(t, Redacted, got["ACCESS-PASSPHRASE"])
+	assert.Equal(t, "1700000000000", got["ACCESS-TIMESTAMP"])
+}
+
+func TestRedactQuery(t *testing.T) {
+	assert.Equal(t, "", RedactQuery(nil))
+	q := url.Values{"symbol": {"BTCUSDT"}, "apiKey": {"key"}}
+	assert.Equal(t, "apiKey=%5BREDACTED%5D&symbol=BTCUSDT", RedactQuery(q))
+}
+
+func TestRedactBody(t *testing.T) {
+	body := `{"symbol":"BTCUSDT","passphrase":"p","sub":[{"secret_key":"s","size":"1"}]}`
+	got := RedactBody([]byte(body))
+	assert.NotContains(t, got, `"p"`)
+	assert.NotContains(t, got, `"s"`)
+	assert.Contains(t, got, `"symbol":"BTCUSDT"`)
+	assert.Contains(t, got, `"size":"1"`)
+
+	// Bodies without secrets and non-JSON bodies pass through untouched
+	assert.Equal(t, `{"b":1,"a":2}`, RedactBody([]byte(`{"b":1,"a":2}`)))
+	assert.Equal(t, "not json", RedactBody([]byte("not json")))
+}
@@ -0,0 +1,80 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrRetryBudgetExhausted is wrapped into the error of a request whose retry was
+// refused by a RetryBudget.
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// RetryBudgetStats counts the retries a RetryBudget granted and refused.
+type RetryBudgetStats struct {
+	Allowed uint64
+	Denied  uint64
+}
+
+// RetryBudget caps the retries of every client sharing it. REST retries and WebSocket
+// reconnections each take a token; when a flapping network exhausts the budget, REST
+// requests fail with their last error instead of retrying and WebSocket clients wait for
+// a token before reconnecting and resubscribing, so an outage does not amplify into a
+// request flood:
+//
+//	budget := common.NewRetryBudget(1, 20) // 20 retries at once, then one per second
+//	client := futures.NewClient(apiKey, secretKey, passphrase, futures.WithRetryBudget(budget))
+//	wsClient.SetRetryBudget(budget)
+//
+// First attempts never take a token. A nil *RetryBudget allows every retry. It is safe
+// for concurrent use.
+type RetryBudget struct {
+	limiter *RateLimiter
+	allowed atomic.Uint64
+	denied  atomic.Uint64
+}
+
+// NewRetryBudget creates a budget of burst retries that refills at rate retries per
+// second.
+func NewRetryBudget(rate float64, burst int) *RetryBudget {
+	return &RetryBudget{limiter: NewRateLimiter(rate, burst)}
+}
+
+// SetClock replaces the time source of the refill, e.g. with a FakeClock in tests.
+func (b *RetryBudget) SetClock(clock Clock) {
+	b.limiter.SetClock(clock)
+}
+
+// Allow takes a token for a retry if one is available.
+func (b *RetryBudget) Allow() bool {
+	if b == nil {
+		return true
+	}
+	if b.limiter.Allow() {
+		b.allowed.Add(1)
+		return true
+	}
+	b.denied.Add(1)
+	return false
+}
+
+// Wait blocks until a token is available or ctx is done. Callers that wait are counted
+// as denied once when they could not take a token immediately.
+func (b *RetryBudget) Wait(ctx context.Context) error {
+	if b == nil || b.Allow() {
+		return nil
+	}
+	if err := b.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	b.allowed.Add(1)
+	return nil
+}
+
+// Stats returns the retry counters.
+func (b *RetryBudget) Stats() RetryBudgetStats {
+	if b == nil {
+		return RetryBudgetStats{}
+	}
+	return RetryBudgetStats{Allowed: b.allowed.Load(), Denied: b.denied.Load()}
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryBudget_Shared(t *testing.T) {
+	b := NewRetryBudget(0.01, 10)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	granted := 0
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				if b.Allow() {
+					mu.Lock()
+					granted++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 10, granted)
+	assert.Equal(t, RetryBudgetStats{Allowed: 10, Denied: 10}, b.Stats())
+}
+
+func TestRetryBudget_Wait(t *testing.T) {
+	b := NewRetryBudget(50, 1)
+	assert.NoError(t, b.Wait(context.Background()))
+
+	start := time.Now()
+	assert.NoError(t, b.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+	assert.Equal(t, RetryBudgetStats{Allowed: 2, Denied: 1}, b.Stats())
+
+	empty := NewRetryBudget(0.01, 1)
+	assert.True(t, empty.Allow())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, empty.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestRetryBudget_Nil(t *testing.T) {
+	var b *RetryBudget
+	assert.True(t, b.Allow())
+	assert.NoError(t, b.Wait(context.Background()))
+	assert.Zero(t, b.Stats())
+}
@@ -10,19 +10,44 @@ import (
 	"encoding/base64"
 	"encoding/pem"
 	"errors"
+	"hash"
 	"strings"
+	"sync"
 )
 
 // Signer handles cryptographic signing of API requests for Bitget authentication.
-// It supports both HMAC-SHA256 and RSA signature algorithms.
+// It supports both HMAC-SHA256 and RSA signature algorithms. A Signer is safe for
+// concurrent use; keyed HMAC states are pooled so signing does not re-derive them.
 type Signer struct {
-	secretKey []byte // The secret key used for signing requests
+	secretKey []byte    // The secret key used for signing requests
+	macs      sync.Pool // Keyed HMAC-SHA256 states
 }
 
 // NewSigner creates a new Signer instance with the provided secret key.
 // The key will be used for HMAC-SHA256 signing or RSA signing depending on the method called.
 func NewSigner(key string) *Signer {
-	return &Signer{[]byte(key)}
+	return &Signer{secretKey: []byte(key)}
+}
+
+// Prepare keys n HMAC states ahead of time, so the first n concurrent signatures after
+// start-up or an idle period skip the key setup. Like any pooled value, prepared states
+// may be dropped by the garbage collector.
+func (p *Signer) Prepare(n int) {
+	macs := make([]hash.Hash, n)
+	for i := range macs {
+		macs[i] = p.acquire()
+	}
+	for _, mac := range macs {
+		p.macs.Put(mac)
+	}
+}
+
+func (p *Signer) acquire() hash.Hash {
+	if mac, ok := p.macs.Get().(hash.Hash); ok {
+		mac.Reset()
+		return mac
+	}
+	return hmac.New(sha256.New, p.secretKey)
 }
 
 // Sign creates an HMAC-SHA256 signature for API authentication.
@@ -36,18 +61,17 @@ func NewSigner(key string) *Signer {
 //
 // Returns a base64-encoded signature string.
 func (p *Signer) Sign(method string, requestPath string, body string, timesStamp string) string {
-	var payload strings.Builder
-	payload.WriteString(timesStamp)
-	payload.WriteString(method)
-	payload.WriteString(requestPath)
+	mac := p.acquire()
+	defer p.macs.Put(mac)
+	mac.Write([]byte(timesStamp))
+	mac.Write([]byte(method))
+	mac.Write([]byte(requestPath))
 	if body != "" && body != "?" {
-		payload.WriteString(body)
+		mac.Write([]byte(body))
 	}
-	hash := hmac.New(sha256.New, p.secretKey)
-	hash.Write([]byte(payload.String()))
 
-	result := base64.StdEncoding.EncodeToString(hash.Sum(nil))
-	return result
+	var sum [sha256.Size]byte
+	return base64.StdEncoding.EncodeToString(mac.Sum(sum[:0]))
 }
 
 // SignByRSA creates an RSA signature for API authentication.
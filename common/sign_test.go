@@ -0,0 +1,46 @@
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigner_Sign(t *testing.T) {
+	expected := func(payload string) string {
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(payload))
+		return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+
+	s := NewSigner("secret")
+	s.Prepare(4)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, expected("1700000000000GET/api/v2/mix/account/accounts?productType=USDT-FUTURES"),
+			s.Sign("GET", "/api/v2/mix/account/accounts", "?productType=USDT-FUTURES", "1700000000000"),
+			"reused states are reset")
+	}
+	assert.Equal(t, expected("1700000000000GET/api/v2/public/time"), s.Sign("GET", "/api/v2/public/time", "?", "1700000000000"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, expected(`1POST/p{"a":1}`), s.Sign("POST", "/p", `{"a":1}`, "1"))
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkSigner_Sign(b *testing.B) {
+	s := NewSigner("secret")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Sign("POST", "/api/v2/mix/order/place-order", `{"symbol":"BTCUSDT","size":"0.01"}`, "1700000000000")
+	}
+}
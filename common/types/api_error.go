@@ -13,6 +13,8 @@ type APIError struct {
 	RequestTime int64  `json:"requestTime"` // Добавлено поле для requestTime
 	Data        any    `json:"data"`        // Добавлено поле для data
 	Response    []byte `json:"-"`
+	// Locale is the language Message was requested in, e.g. "zh-CN"
+	Locale string `json:"-"`
 }
 
 // Error return error code and message
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/uta"
+	"github.com/khanbekov/go-bitget/ws"
+	"github.com/rs/zerolog"
+)
+
+// Logger returns a zerolog logger writing to stderr at the configured level.
+func (c *Config) Logger() common.Logger {
+	level, err := zerolog.ParseLevel(c.Log.Level)
+	if err != nil || c.Log.Level == "" {
+		level = zerolog.InfoLevel
+	}
+	return common.NewZerologLogger(zerolog.New(os.Stderr).Level(level).With().Timestamp().Logger())
+}
+
+// FuturesClient creates a futures REST client for the configured environment.
+func (c *Config) FuturesClient() *futures.Client {
+	return futures.NewClient(c.Credentials.APIKey, c.Credentials.SecretKey, c.Credentials.Passphrase,
+		futures.WithEnvironment(c.Env())).
+		SetApiEndpoint(c.ResolvedEndpoints().RESTURL).
+		SetLogger(c.Logger())
+}
+
+// UTAClient creates a unified trading account REST client for the configured environment.
+func (c *Config) UTAClient() *uta.Client {
+	return uta.NewClient(c.Credentials.APIKey, c.Credentials.SecretKey, c.Credentials.Passphrase).
+		SetEnvironment(c.Env()).
+		SetBaseURL(c.ResolvedEndpoints().RESTURL).
+		SetLogger(c.Logger())
+}
+
+// PublicWSClient creates an unconnected client for public WebSocket channels.
+func (c *Config) PublicWSClient() *ws.BaseWsClient {
+	return ws.NewBaseWsClient(c.Logger(), c.ResolvedEndpoints().PublicWSURL, "")
+}
+
+// PrivateWSClient creates an unconnected client for private WebSocket channels. After
+// connecting, authenticate with
+//
+//	client.Login(cfg.Credentials.APIKey, cfg.Credentials.Passphrase, common.SHA256)
+func (c *Config) PrivateWSClient() *ws.BaseWsClient {
+	return ws.NewBaseWsClient(c.Logger(), c.ResolvedEndpoints().PrivateWSURL, c.Credentials.SecretKey)
+}
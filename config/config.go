@@ -0,0 +1,180 @@
+// Package config loads SDK configuration from a JSON file and environment variables and
+// builds ready-to-use clients from it.
+//
+// Values are merged with the precedence environment > file > defaults. Validation
+// reports every problem at once instead of stopping at the first:
+//
+//	cfg, err := config.Load(config.Options{File: "config.json", RequireCredentials: true})
+//	if err != nil {
+//		log.Fatal(err) // e.g. "invalid configuration: credentials.passphrase is required; risk.max_leverage must be >= 0"
+//	}
+//	client := cfg.FuturesClient()
+//
+// Applications embed Config in their own struct to keep SDK and strategy settings in a
+// single file and load it with Decode. A Watcher reloads it at runtime and pushes changed
+// sections to the components that use them.
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/rs/zerolog"
+)
+
+// Config is the SDK configuration.
+type Config struct {
+	// Environment is "production" (default) or "demo"; see common.ParseEnvironment.
+	Environment string `json:"environment" env:"ENVIRONMENT"`
+
+	Credentials Credentials `json:"credentials"`
+	Endpoints   Endpoints   `json:"endpoints"`
+	Risk        RiskLimits  `json:"risk"`
+	Log         LogConfig   `json:"log"`
+}
+
+// Credentials holds the API key triple. Leave all fields empty for public-only use.
+type Credentials struct {
+	APIKey     string `json:"api_key" env:"API_KEY"`
+	SecretKey  string `json:"secret_key" env:"SECRET_KEY"`
+	Passphrase string `json:"passphrase" env:"PASSPHRASE"`
+}
+
+// Endpoints overrides the REST and WebSocket base URLs of the selected environment,
+// e.g. to go through a proxy. Empty fields use the environment preset.
+type Endpoints struct {
+	RESTURL      string `json:"rest_url" env:"REST_URL"`
+	PublicWSURL  string `json:"public_ws_url" env:"PUBLIC_WS_URL"`
+	PrivateWSURL string `json:"private_ws_url" env:"PRIVATE_WS_URL"`
+}
+
+// RiskLimits holds account-wide limits shared by trading components. Zero disables a limit.
+type RiskLimits struct {
+	MaxDailyLossPct     float64 `json:"max_daily_loss_pct" env:"RISK_MAX_DAILY_LOSS_PCT"`
+	MaxDrawdownPct      float64 `json:"max_drawdown_pct" env:"RISK_MAX_DRAWDOWN_PCT"`
+	MaxOpenPositions    int     `json:"max_open_positions" env:"RISK_MAX_OPEN_POSITIONS"`
+	MaxPositionNotional float64 `json:"max_position_notional" env:"RISK_MAX_POSITION_NOTIONAL"`
+	MaxLeverage         int     `json:"max_leverage" env:"RISK_MAX_LEVERAGE"`
+	MinBalance          float64 `json:"min_balance" env:"RISK_MIN_BALANCE"`
+}
+
+// LogConfig configures the logger handed to clients.
+type LogConfig struct {
+	Level string `json:"level" env:"LOG_LEVEL"` // debug, info, warn or error
+}
+
+// Default returns the production configuration without credentials.
+func Default() Config {
+	return Config{
+		Environment: common.Production.String(),
+		Log:         LogConfig{Level: "info"},
+	}
+}
+
+// Env returns the parsed environment, or Production when it is invalid.
+func (c *Config) Env() common.Environment {
+	env, _ := common.ParseEnvironment(c.Environment)
+	return env
+}
+
+// ResolvedEndpoints returns the environment preset URLs with overrides applied.
+func (c *Config) ResolvedEndpoints() Endpoints {
+	env := c.Env()
+	e := c.Endpoints
+	if e.RESTURL == "" {
+		e.RESTURL = env.RESTURL()
+	}
+	if e.PublicWSURL == "" {
+		e.PublicWSURL = env.PublicWSURL()
+	}
+	if e.PrivateWSURL == "" {
+		e.PrivateWSURL = env.PrivateWSURL()
+	}
+	return e
+}
+
+// HasCredentials reports whether an API key is configured.
+func (c *Config) HasCredentials() bool {
+	return c.Credentials.APIKey != "" || c.Credentials.SecretKey != "" || c.Credentials.Passphrase != ""
+}
+
+// Problems returns every validation problem, or nil when the configuration is valid.
+// Credentials are optional, but when any of them is set all three are required.
+func (c *Config) Problems(requireCredentials bool) []string {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if requireCredentials || c.HasCredentials() {
+		if c.Credentials.APIKey == "" {
+			add("credentials.api_key is required")
+		}
+		if c.Credentials.SecretKey == "" {
+			add("credentials.secret_key is required")
+		}
+		if c.Credentials.Passphrase == "" {
+			add("credentials.passphrase is required")
+		}
+	}
+
+	if _, err := common.ParseEnvironment(c.Environment); err != nil {
+		add("environment: %v", err)
+	}
+
+	checkURL := func(name, value string, schemes ...string) {
+		for _, s := range schemes {
+			if strings.HasPrefix(value, s+"://") {
+				return
+			}
+		}
+		add("endpoints.%s must start with %s://", name, strings.Join(schemes, ":// or "))
+	}
+	endpoints := c.ResolvedEndpoints()
+	checkURL("rest_url", endpoints.RESTURL, "https", "http")
+	checkURL("public_ws_url", endpoints.PublicWSURL, "wss", "ws")
+	checkURL("private_ws_url", endpoints.PrivateWSURL, "wss", "ws")
+
+	checkPct := func(name string, v float64) {
+		if v < 0 || v >= 1 {
+			add("risk.%s must be in [0, 1)", name)
+		}
+	}
+	checkPct("max_daily_loss_pct", c.Risk.MaxDailyLossPct)
+	checkPct("max_drawdown_pct", c.Risk.MaxDrawdownPct)
+	if c.Risk.MaxOpenPositions < 0 {
+		add("risk.max_open_positions must be >= 0")
+	}
+	if c.Risk.MaxPositionNotional < 0 {
+		add("risk.max_position_notional must be >= 0")
+	}
+	if c.Risk.MaxLeverage < 0 || c.Risk.MaxLeverage > 125 {
+		add("risk.max_leverage must be in [0, 125]")
+	}
+	if c.Risk.MinBalance < 0 {
+		add("risk.min_balance must be >= 0")
+	}
+
+	if _, err := zerolog.ParseLevel(c.Log.Level); err != nil || c.Log.Level == "" {
+		add("log.level %q is invalid", c.Log.Level)
+	}
+	return problems
+}
+
+// Validate returns a *ValidationError listing every problem, or nil.
+func (c *Config) Validate(requireCredentials bool) error {
+	if problems := c.Problems(requireCredentials); len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// ValidationError lists all configuration problems found.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration: " + strings.Join(e.Problems, "; ")
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func env(vars map[string]string) func(string) string {
+	return func(k string) string { return vars[k] }
+}
+
+func TestLoad_FileAndEnvMerge(t *testing.T) {
+	path := writeFile(t, `{
+		"credentials": {"api_key": "file-key", "secret_key": "file-secret", "passphrase": "file-pass"},
+		"risk": {"max_daily_loss_pct": 0.05, "max_leverage": 10}
+	}`)
+
+	cfg, err := Load(Options{
+		File:               path,
+		Getenv:             env(map[string]string{"BITGET_API_KEY": "env-key", "BITGET_RISK_MAX_LEVERAGE": "20"}),
+		RequireCredentials: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "env-key", cfg.Credentials.APIKey)
+	assert.Equal(t, "file-secret", cfg.Credentials.SecretKey)
+	assert.Equal(t, 20, cfg.Risk.MaxLeverage)
+	assert.Equal(t, 0.05, cfg.Risk.MaxDailyLossPct)
+	assert.Equal(t, "https://api.bitget.com", cfg.ResolvedEndpoints().RESTURL, "environment preset")
+
+	client := cfg.FuturesClient()
+	assert.Equal(t, "https://api.bitget.com", client.BaseURL)
+	assert.Equal(t, "https://api.bitget.com", cfg.UTAClient().BaseURL)
+}
+
+func TestLoad_ReportsAllProblems(t *testing.T) {
+	path := writeFile(t, `{
+		"credentials": {"api_key": "key"},
+		"endpoints": {"rest_url": "api.bitget.com"},
+		"risk": {"max_drawdown_pct": 1.5, "max_leverage": -1},
+		"log": {"level": "loud"}
+	}`)
+
+	_, err := Load(Options{File: path, Getenv: env(nil)})
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, []string{
+		"credentials.secret_key is required",
+		"credentials.passphrase is required",
+		"endpoints.rest_url must start with https:// or http://",
+		"risk.max_drawdown_pct must be in [0, 1)",
+		"risk.max_leverage must be in [0, 125]",
+		`log.level "loud" is invalid`,
+	}, verr.Problems)
+}
+
+func TestLoad_DemoEnvironment(t *testing.T) {
+	path := writeFile(t, `{"environment": "demo", "endpoints": {"rest_url": "https://proxy.local"}}`)
+	cfg, err := Load(Options{File: path, Getenv: env(nil)})
+	require.NoError(t, err)
+
+	endpoints := cfg.ResolvedEndpoints()
+	assert.Equal(t, "https://proxy.local", endpoints.RESTURL)
+	assert.Equal(t, "wss://wspap.bitget.com/v2/ws/public", endpoints.PublicWSURL)
+
+	client := cfg.FuturesClient()
+	assert.Equal(t, common.Demo, client.Environment())
+	assert.Equal(t, "https://proxy.local", client.BaseURL)
+	assert.True(t, cfg.UTAClient().DemoTrading)
+
+	_, err = Load(Options{Getenv: env(map[string]string{"BITGET_ENVIRONMENT": "staging"})})
+	assert.ErrorContains(t, err, `environment: unknown environment "staging"`)
+}
+
+func TestLoad_PublicOnly(t *testing.T) {
+	cfg, err := Load(Options{Getenv: env(nil)})
+	require.NoError(t, err)
+	assert.False(t, cfg.HasCredentials())
+
+	_, err = Load(Options{Getenv: env(nil), RequireCredentials: true})
+	assert.ErrorContains(t, err, "credentials.api_key is required")
+}
+
+func TestDecode_EmbeddedAppConfig(t *testing.T) {
+	type appConfig struct {
+		Config
+		Trading struct {
+			Symbols []string `json:"symbols" env:"TRADING_SYMBOLS"`
+			DryRun  bool     `json:"dry_run" env:"TRADING_DRY_RUN"`
+		} `json:"trading"`
+	}
+
+	path := writeFile(t, `{"trading": {"symbols": ["BTCUSDT"]}, "log": {"level": "debug"}}`)
+	cfg := appConfig{Config: Default()}
+	err := Decode(Options{
+		File:      path,
+		EnvPrefix: "APP_",
+		Getenv:    env(map[string]string{"APP_TRADING_SYMBOLS": "ETHUSDT, SOLUSDT", "APP_TRADING_DRY_RUN": "true"}),
+	}, &cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ETHUSDT", "SOLUSDT"}, cfg.Trading.Symbols)
+	assert.True(t, cfg.Trading.DryRun)
+	assert.Equal(t, "debug", cfg.Log.Level)
+	assert.Empty(t, cfg.Problems(false))
+}
+
+func TestDecode_InvalidInput(t *testing.T) {
+	path := writeFile(t, `{"credentails": {}}`)
+	cfg := Default()
+	err := Decode(Options{
+		File:   path,
+		Getenv: env(map[string]string{"BITGET_RISK_MAX_LEVERAGE": "ten"}),
+	}, &cfg)
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Problems, 2)
+	assert.Contains(t, verr.Problems[0], `unknown field "credentails"`)
+	assert.Equal(t, `BITGET_RISK_MAX_LEVERAGE: invalid integer "ten"`, verr.Problems[1])
+
+	_, err = Load(Options{File: filepath.Join(t.TempDir(), "missing.json")})
+	assert.Error(t, err)
+}
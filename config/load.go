@@ -0,0 +1,149 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Options controls where configuration is loaded from.
+type Options struct {
+	// File is a JSON file to read. Empty skips the file; a missing file is an error.
+	File string
+
+	// EnvPrefix is prepended to the env tag of each field. Defaults to "BITGET_", so
+	// Credentials.APIKey is read from BITGET_API_KEY.
+	EnvPrefix string
+
+	// Getenv looks up environment variables. Defaults to os.Getenv.
+	Getenv func(string) string
+
+	// RequireCredentials makes missing credentials a validation problem.
+	RequireCredentials bool
+}
+
+// Load reads the configuration over Default, applies environment variables and validates
+// the result.
+func Load(opts Options) (*Config, error) {
+	cfg := Default()
+	if err := Decode(opts, &cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(opts.RequireCredentials); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Decode reads opts.File into dst, which must be a pointer to a struct, then overrides
+// fields tagged `env:"NAME"` from the environment, descending into nested and embedded
+// structs. Unknown JSON fields and unparsable environment values are reported together
+// as a *ValidationError. Decode does not validate; call Config.Problems for that.
+//
+// Initialize dst with defaults before calling Decode; values absent from both sources are
+// left untouched.
+func Decode(opts Options, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return errors.New("config: dst must be a pointer to a struct")
+	}
+	if opts.EnvPrefix == "" {
+		opts.EnvPrefix = "BITGET_"
+	}
+	if opts.Getenv == nil {
+		opts.Getenv = os.Getenv
+	}
+
+	var problems []string
+	if opts.File != "" {
+		data, err := os.ReadFile(opts.File)
+		if err != nil {
+			return fmt.Errorf("config: %w", err)
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(dst); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", opts.File, err))
+		}
+	}
+
+	problems = append(problems, applyEnv(v.Elem(), opts)...)
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func applyEnv(v reflect.Value, opts Options) []string {
+	var problems []string
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok := field.Tag.Lookup("env")
+		if !ok {
+			if fv.Kind() == reflect.Struct {
+				problems = append(problems, applyEnv(fv, opts)...)
+			}
+			continue
+		}
+
+		key := opts.EnvPrefix + name
+		raw := opts.Getenv(key)
+		if raw == "" {
+			continue
+		}
+		if err := setValue(fv, raw); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", key, err))
+		}
+	}
+	return problems
+}
+
+func setValue(fv reflect.Value, raw string) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid bool %q", raw)
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		fv.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		fv.SetFloat(f)
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", fv.Type())
+		}
+		var items []string
+		for _, s := range strings.Split(raw, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				items = append(items, s)
+			}
+		}
+		fv.Set(reflect.ValueOf(items).Convert(fv.Type()))
+	default:
+		return fmt.Errorf("unsupported type %s", fv.Type())
+	}
+	return nil
+}
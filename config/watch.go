@@ -0,0 +1,204 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// WatchOptions configures a Watcher.
+type WatchOptions[T any] struct {
+	// Options selects the file and environment; Options.RequireCredentials is passed to
+	// the Validate method of T when it has one, as Config does.
+	Options
+
+	// Default returns the values a reload starts from before the file and environment are
+	// applied. Defaults to the zero value; use config.Default for Config.
+	Default func() T
+
+	// Validate checks a decoded value in addition to its own Validate method. Optional.
+	Validate func(*T) error
+
+	// Interval is how often the file modification time is checked. Defaults to 5 seconds;
+	// negative disables polling, leaving signals and Reload.
+	Interval time.Duration
+
+	// Signals trigger a reload. Defaults to SIGHUP.
+	Signals []os.Signal
+
+	// OnError receives reloads rejected by decoding or validation. Optional.
+	OnError func(error)
+}
+
+// Watcher holds the current configuration and reloads it when the file changes or on
+// SIGHUP. A reload that fails to decode or validate is rejected as a whole, so
+// subscribers only ever see complete, valid values; accepted values replace the current
+// one atomically:
+//
+//	w, err := config.NewWatcher(config.WatchOptions[config.Config]{
+//		Options: config.Options{File: "config.json"},
+//		Default: config.Default,
+//	})
+//	config.Select(w, func(c *config.Config) config.RiskLimits { return c.Risk },
+//		func(r config.RiskLimits) { positionGuard.SetLimits(guard.PositionLimitsFromConfig(r)) })
+//	go w.Run(ctx)
+//
+// T may be an application struct embedding Config. Values passed to subscribers are
+// shared and must not be modified. It is safe for concurrent use.
+type Watcher[T any] struct {
+	opts    WatchOptions[T]
+	current atomic.Pointer[T]
+
+	mu      sync.Mutex // Serializes reloads and notifications
+	subs    map[int]func(old, new *T)
+	nextID  int
+	modTime time.Time
+}
+
+// NewWatcher loads the configuration once and returns an error when it is invalid.
+func NewWatcher[T any](opts WatchOptions[T]) (*Watcher[T], error) {
+	if opts.Interval == 0 {
+		opts.Interval = 5 * time.Second
+	}
+	if opts.Signals == nil {
+		opts.Signals = []os.Signal{syscall.SIGHUP}
+	}
+	w := &Watcher[T]{opts: opts, subs: make(map[int]func(old, new *T))}
+	cfg, modTime, err := w.load()
+	if err != nil {
+		return nil, err
+	}
+	w.current.Store(cfg)
+	w.modTime = modTime
+	return w, nil
+}
+
+// Current returns the current configuration.
+func (w *Watcher[T]) Current() *T {
+	return w.current.Load()
+}
+
+// Subscribe calls fn after every accepted reload with the previous and new values and
+// returns a function that removes the subscription. Subscribers run one reload at a time
+// and must not call Reload themselves.
+func (w *Watcher[T]) Subscribe(fn func(old, new *T)) (unsubscribe func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.subscribe(fn)
+}
+
+// subscribe registers fn. Callers hold w.mu.
+func (w *Watcher[T]) subscribe(fn func(old, new *T)) func() {
+	id := w.nextID
+	w.nextID++
+	w.subs[id] = fn
+	return func() {
+		w.mu.Lock()
+		delete(w.subs, id)
+		w.mu.Unlock()
+	}
+}
+
+// Select calls fn with the part of the configuration chosen by get, once immediately and
+// then after every reload that changes it, so a component only hears about its own
+// settings:
+//
+//	config.Select(w, func(c *Config) RiskLimits { return c.Risk }, riskEngine.SetLimits)
+func Select[T any, V comparable](w *Watcher[T], get func(*T) V, fn func(V)) (unsubscribe func()) {
+	// Holding the lock keeps a concurrent reload from slipping in between
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	fn(get(w.Current()))
+	return w.subscribe(func(old, new *T) {
+		if v := get(new); v != get(old) {
+			fn(v)
+		}
+	})
+}
+
+// Reload decodes and validates the configuration and, when it is valid, swaps it in and
+// notifies subscribers. A rejected reload keeps the current value.
+func (w *Watcher[T]) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.reload()
+}
+
+func (w *Watcher[T]) reload() error {
+	cfg, modTime, err := w.load()
+	w.modTime = modTime // A broken edit is reported once, not on every poll
+	if err != nil {
+		if w.opts.OnError != nil {
+			w.opts.OnError(err)
+		}
+		return err
+	}
+	old := w.current.Swap(cfg)
+	for _, fn := range w.subs {
+		fn(old, cfg)
+	}
+	return nil
+}
+
+// Run reloads on the configured signals and file changes until ctx is cancelled.
+func (w *Watcher[T]) Run(ctx context.Context) {
+	sig := make(chan os.Signal, 1)
+	if len(w.opts.Signals) > 0 {
+		signal.Notify(sig, w.opts.Signals...)
+		defer signal.Stop(sig)
+	}
+	var tick <-chan time.Time
+	if w.opts.Interval > 0 && w.opts.File != "" {
+		ticker := time.NewTicker(w.opts.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sig:
+			_ = w.Reload()
+		case <-tick:
+			w.mu.Lock()
+			if info, err := os.Stat(w.opts.File); err == nil && !info.ModTime().Equal(w.modTime) {
+				_ = w.reload()
+			}
+			w.mu.Unlock()
+		}
+	}
+}
+
+// load decodes a fresh value and returns it with the file modification time.
+func (w *Watcher[T]) load() (*T, time.Time, error) {
+	var modTime time.Time
+	if w.opts.File != "" {
+		if info, err := os.Stat(w.opts.File); err == nil {
+			modTime = info.ModTime()
+		}
+	}
+	cfg := new(T)
+	if w.opts.Default != nil {
+		*cfg = w.opts.Default()
+	}
+	if err := Decode(w.opts.Options, cfg); err != nil {
+		return nil, modTime, err
+	}
+	if v, ok := any(cfg).(interface{ Validate(bool) error }); ok {
+		if err := v.Validate(w.opts.RequireCredentials); err != nil {
+			return nil, modTime, err
+		}
+	}
+	if w.opts.Validate != nil {
+		if err := w.opts.Validate(cfg); err != nil {
+			return nil, modTime, fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+	return cfg, modTime, nil
+}
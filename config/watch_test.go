@@ -0,0 +1,100 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher_ReloadAndSelect(t *testing.T) {
+	path := writeFile(t, `{"risk": {"max_leverage": 10}, "log": {"level": "info"}}`)
+	var rejected []error
+	w, err := NewWatcher(WatchOptions[Config]{
+		Options: Options{File: path, Getenv: env(nil)},
+		Default: Default,
+		OnError: func(err error) { rejected = append(rejected, err) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 10, w.Current().Risk.MaxLeverage)
+
+	var risks []RiskLimits
+	Select(w, func(c *Config) RiskLimits { return c.Risk }, func(r RiskLimits) { risks = append(risks, r) })
+	var logs int
+	unsubscribe := Select(w, func(c *Config) LogConfig { return c.Log }, func(LogConfig) { logs++ })
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"risk": {"max_leverage": 20}, "log": {"level": "info"}}`), 0o600))
+	require.NoError(t, w.Reload())
+	assert.Equal(t, []RiskLimits{{MaxLeverage: 10}, {MaxLeverage: 20}}, risks)
+	assert.Equal(t, 1, logs, "unchanged sections are not pushed")
+
+	// Invalid values are rejected as a whole
+	previous := w.Current()
+	require.NoError(t, os.WriteFile(path, []byte(`{"risk": {"max_leverage": 500, "max_open_positions": 3}}`), 0o600))
+	err = w.Reload()
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Same(t, previous, w.Current())
+	assert.Len(t, risks, 2)
+	assert.Len(t, rejected, 1)
+
+	unsubscribe()
+	require.NoError(t, os.WriteFile(path, []byte(`{"log": {"level": "debug"}}`), 0o600))
+	require.NoError(t, w.Reload())
+	assert.Equal(t, 1, logs)
+	assert.Equal(t, RiskLimits{}, risks[2])
+}
+
+type strategyConfig struct {
+	Config
+	Strategy struct {
+		Spread float64 `json:"spread" env:"STRATEGY_SPREAD"`
+	} `json:"strategy"`
+}
+
+func TestWatcher_EmbeddedConfigAndPolling(t *testing.T) {
+	path := writeFile(t, `{"strategy": {"spread": 0.001}}`)
+	w, err := NewWatcher(WatchOptions[strategyConfig]{
+		Options: Options{File: path, Getenv: env(nil)},
+		Default: func() strategyConfig { return strategyConfig{Config: Default()} },
+		Validate: func(c *strategyConfig) error {
+			if c.Strategy.Spread <= 0 {
+				return errors.New("strategy.spread must be > 0")
+			}
+			return nil
+		},
+		Interval: 5 * time.Millisecond,
+		Signals:  []os.Signal{},
+	})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var spreads []float64
+	w.Subscribe(func(old, new *strategyConfig) {
+		mu.Lock()
+		spreads = append(spreads, new.Strategy.Spread)
+		mu.Unlock()
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	later := time.Now().Add(time.Second)
+	require.NoError(t, os.WriteFile(path, []byte(`{"strategy": {"spread": 0.002}}`), 0o600))
+	require.NoError(t, os.Chtimes(path, later, later))
+	require.Eventually(t, func() bool { return w.Current().Strategy.Spread == 0.002 }, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"strategy": {"spread": 0}}`), 0o600))
+	assert.ErrorContains(t, w.Reload(), "strategy.spread must be > 0")
+	mu.Lock()
+	assert.Equal(t, []float64{0.002}, spreads)
+	mu.Unlock()
+
+	_, err = NewWatcher(WatchOptions[strategyConfig]{Options: Options{File: path, Getenv: env(nil)}})
+	assert.Error(t, err, "the zero value fails Config validation")
+}
@@ -0,0 +1,31 @@
+package earn
+
+// Clone returns an independent copy of s and its parameters.
+func (s *PositionsService) Clone() *PositionsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *ProductsService) Clone() *ProductsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *RecordsService) Clone() *RecordsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *RedeemService) Clone() *RedeemService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *SubscribeService) Clone() *SubscribeService {
+	c := *s
+	return &c
+}
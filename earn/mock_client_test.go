@@ -0,0 +1,47 @@
+package earn
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/valyala/fasthttp"
+)
+
+// MockClient is a mock implementation of ClientInterface for testing
+type MockClient struct {
+	mock.Mock
+}
+
+func (m *MockClient) CallAPI(ctx context.Context, method string, endpoint string, queryParams url.Values, body []byte, sign bool) (*ApiResponse, *fasthttp.ResponseHeader, error) {
+	args := m.Called(ctx, method, endpoint, queryParams, body, sign)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(*fasthttp.ResponseHeader), args.Error(2)
+	}
+	return args.Get(0).(*ApiResponse), args.Get(1).(*fasthttp.ResponseHeader), args.Error(2)
+}
+
+// Ensure MockClient implements ClientInterface
+var _ ClientInterface = (*MockClient)(nil)
+
+// okResponse wraps data in a successful response.
+func okResponse(data string) *ApiResponse {
+	return &ApiResponse{Code: "00000", Msg: "success", Data: json.RawMessage(data)}
+}
+
+// bodyEquals matches a JSON request body against want.
+func bodyEquals(want map[string]interface{}) interface{} {
+	return mock.MatchedBy(func(body []byte) bool {
+		var got map[string]interface{}
+		if err := json.Unmarshal(body, &got); err != nil || len(got) != len(want) {
+			return false
+		}
+		for k, v := range want {
+			if got[k] != v {
+				return false
+			}
+		}
+		return true
+	})
+}
@@ -0,0 +1,128 @@
+package earn
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// PositionsService retrieves the holdings in savings products, flexible and fixed-term
+// (staked) alike.
+type PositionsService struct {
+	c ClientInterface
+
+	periodType PeriodType
+	startTime  string
+	endTime    string
+	limit      string
+	idLessThan string
+}
+
+// PeriodType filters positions by term.
+func (s *PositionsService) PeriodType(periodType PeriodType) *PositionsService {
+	s.periodType = periodType
+	return s
+}
+
+// StartTime sets the start of the subscription time range in Unix milliseconds.
+func (s *PositionsService) StartTime(startTime string) *PositionsService {
+	s.startTime = startTime
+	return s
+}
+
+// EndTime sets the end of the subscription time range in Unix milliseconds.
+func (s *PositionsService) EndTime(endTime string) *PositionsService {
+	s.endTime = endTime
+	return s
+}
+
+// Limit sets the page size, at most 100.
+func (s *PositionsService) Limit(limit string) *PositionsService {
+	s.limit = limit
+	return s
+}
+
+// IdLessThan requests the page after the given EndId.
+func (s *PositionsService) IdLessThan(idLessThan string) *PositionsService {
+	s.idLessThan = idLessThan
+	return s
+}
+
+// Position is the holding of one savings subscription.
+type Position struct {
+	ProductId       string     `json:"productId"`
+	OrderId         string     `json:"orderId"`
+	ProductCoin     string     `json:"productCoin"`
+	InterestCoin    string     `json:"interestCoin"`
+	PeriodType      PeriodType `json:"periodType"`
+	Period          string     `json:"period"`
+	HoldAmount      string     `json:"holdAmount"`
+	LastProfit      string     `json:"lastProfit"`
+	TotalProfit     string     `json:"totalProfit"`
+	HoldDays        string     `json:"holdDays"`
+	Status          string     `json:"status"`
+	AllowRedemption string     `json:"allowRedemption"` // "yes" or "no"
+	ProductLevel    string     `json:"productLevel"`
+	APY             []APYTier  `json:"apy"`
+}
+
+// Redeemable reports whether the position can be redeemed now.
+func (p *Position) Redeemable() bool {
+	return p.AllowRedemption == "yes"
+}
+
+// PositionsResponse is one page of positions.
+type PositionsResponse struct {
+	ResultList []*Position `json:"resultList"`
+	EndId      string      `json:"endId"`
+}
+
+// Do sends the request.
+func (s *PositionsService) Do(ctx context.Context) (*PositionsResponse, error) {
+	if err := checkPeriod(s.periodType, false); err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	if s.periodType != "" {
+		params.Set("periodType", string(s.periodType))
+	}
+	if s.startTime != "" {
+		params.Set("startTime", s.startTime)
+	}
+	if s.endTime != "" {
+		params.Set("endTime", s.endTime)
+	}
+	if s.limit != "" {
+		params.Set("limit", s.limit)
+	}
+	if s.idLessThan != "" {
+		params.Set("idLessThan", s.idLessThan)
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointSavingsAssets, params, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	var response PositionsResponse
+	if err := json.Unmarshal(res.Data, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Iter iterates over all matching positions, following EndId across pages.
+func (s *PositionsService) Iter(ctx context.Context) *common.Iter[Position] {
+	return common.NewIter(ctx, func(ctx context.Context, cursor string) (common.Page[Position], error) {
+		page := *s
+		if cursor != "" {
+			page.idLessThan = cursor
+		}
+		res, err := page.Do(ctx)
+		if err != nil || res == nil {
+			return common.Page[Position]{}, err
+		}
+		return common.Page[Position]{Items: common.Values(res.ResultList), Cursor: res.EndId}, nil
+	})
+}
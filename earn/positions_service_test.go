@@ -0,0 +1,38 @@
+package earn
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestPositionsService_Iter(t *testing.T) {
+	client := &MockClient{}
+	first := url.Values{"periodType": {"fixed"}, "limit": {"1"}}
+	second := url.Values{"periodType": {"fixed"}, "limit": {"1"}, "idLessThan": {"s2"}}
+	client.On("CallAPI", mock.Anything, "GET", EndpointSavingsAssets, first, []byte(nil), true).
+		Return(okResponse(`{"resultList":[{"productId":"2","orderId":"s2","productCoin":"ETH","periodType":"fixed","holdAmount":"1.5","totalProfit":"0.01","allowRedemption":"no"}],"endId":"s2"}`), &fasthttp.ResponseHeader{}, nil)
+	client.On("CallAPI", mock.Anything, "GET", EndpointSavingsAssets, second, []byte(nil), true).
+		Return(okResponse(`{"resultList":[],"endId":""}`), &fasthttp.ResponseHeader{}, nil)
+
+	it := NewPositionsService(client).PeriodType(PeriodFixed).Limit("1").Iter(context.Background())
+	var positions []Position
+	for it.Next() {
+		positions = append(positions, it.Item())
+	}
+	require.NoError(t, it.Err())
+	require.Len(t, positions, 1)
+	assert.Equal(t, "1.5", positions[0].HoldAmount)
+	assert.False(t, positions[0].Redeemable())
+	client.AssertExpectations(t)
+}
+
+func TestPositionsService_Validation(t *testing.T) {
+	_, err := NewPositionsService(nil).PeriodType("weekly").Do(context.Background())
+	assert.EqualError(t, err, `invalid period type "weekly"`)
+}
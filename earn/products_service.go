@@ -0,0 +1,82 @@
+package earn
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// ProductsService lists savings products and their rates.
+type ProductsService struct {
+	c ClientInterface
+
+	coin   string
+	filter string
+}
+
+// Coin filters products by coin, e.g. "USDT".
+func (s *ProductsService) Coin(coin string) *ProductsService {
+	s.coin = coin
+	return s
+}
+
+// Filter selects products by availability, see FilterAvailable. The exchange default
+// is FilterAvailable.
+func (s *ProductsService) Filter(filter string) *ProductsService {
+	s.filter = filter
+	return s
+}
+
+// APYTier is the annual rate of one subscription amount range.
+type APYTier struct {
+	RateLevel  string `json:"rateLevel"`
+	MinStepVal string `json:"minStepVal"`
+	MaxStepVal string `json:"maxStepVal"`
+	CurrentAPY string `json:"currentApy"` // Percent, e.g. "5.5"
+}
+
+// Product is one savings product.
+type Product struct {
+	ProductId     string     `json:"productId"`
+	Coin          string     `json:"coin"`
+	PeriodType    PeriodType `json:"periodType"`
+	Period        string     `json:"period"` // Lock period in days, empty for flexible
+	APYType       string     `json:"apyType"`
+	AdvanceRedeem string     `json:"advanceRedeem"` // "yes" if fixed terms can be redeemed early
+	SettleMethod  string     `json:"settleMethod"`
+	APYList       []APYTier  `json:"apyList"`
+	Status        string     `json:"status"`
+	ProductLevel  string     `json:"productLevel"`
+}
+
+// APY returns the rate of the first tier as a fraction, e.g. 0.055 for 5.5%, or zero
+// when the product has no rate.
+func (p *Product) APY() float64 {
+	if len(p.APYList) == 0 {
+		return 0
+	}
+	apy, _ := strconv.ParseFloat(p.APYList[0].CurrentAPY, 64)
+	return apy / 100
+}
+
+// Do sends the request.
+func (s *ProductsService) Do(ctx context.Context) ([]*Product, error) {
+	params := url.Values{}
+	if s.coin != "" {
+		params.Set("coin", s.coin)
+	}
+	if s.filter != "" {
+		params.Set("filter", s.filter)
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointSavingsProducts, params, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	var products []*Product
+	if err := json.Unmarshal(res.Data, &products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
@@ -0,0 +1,27 @@
+package earn
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestProductsService_Do(t *testing.T) {
+	client := &MockClient{}
+	client.On("CallAPI", mock.Anything, "GET", EndpointSavingsProducts, url.Values{"coin": {"USDT"}, "filter": {"all"}}, []byte(nil), true).
+		Return(okResponse(`[{"productId":"1","coin":"USDT","periodType":"flexible","apyList":[{"rateLevel":"0","minStepVal":"0","maxStepVal":"500","currentApy":"5.5"}],"status":"in_progress"},{"productId":"2","coin":"USDT","periodType":"fixed","period":"30"}]`), &fasthttp.ResponseHeader{}, nil)
+
+	products, err := NewProductsService(client).Coin("USDT").Filter(FilterAll).Do(context.Background())
+	require.NoError(t, err)
+	require.Len(t, products, 2)
+	assert.Equal(t, PeriodFlexible, products[0].PeriodType)
+	assert.InDelta(t, 0.055, products[0].APY(), 1e-9)
+	assert.Equal(t, "30", products[1].Period)
+	assert.Zero(t, products[1].APY())
+	client.AssertExpectations(t)
+}
@@ -0,0 +1,146 @@
+package earn
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// RecordsService retrieves savings subscriptions, redemptions and earnings. Filter by
+// RecordPayInterest for the earnings history.
+type RecordsService struct {
+	c ClientInterface
+
+	coin       string
+	periodType PeriodType
+	orderType  string
+	startTime  string
+	endTime    string
+	limit      string
+	idLessThan string
+}
+
+// Coin filters records by coin, e.g. "USDT".
+func (s *RecordsService) Coin(coin string) *RecordsService {
+	s.coin = coin
+	return s
+}
+
+// PeriodType filters records by product term.
+func (s *RecordsService) PeriodType(periodType PeriodType) *RecordsService {
+	s.periodType = periodType
+	return s
+}
+
+// OrderType filters records by type, see RecordSubscribe.
+func (s *RecordsService) OrderType(orderType string) *RecordsService {
+	s.orderType = orderType
+	return s
+}
+
+// StartTime sets the start of the query range in Unix milliseconds.
+func (s *RecordsService) StartTime(startTime string) *RecordsService {
+	s.startTime = startTime
+	return s
+}
+
+// EndTime sets the end of the query range in Unix milliseconds.
+func (s *RecordsService) EndTime(endTime string) *RecordsService {
+	s.endTime = endTime
+	return s
+}
+
+// Limit sets the page size, at most 100.
+func (s *RecordsService) Limit(limit string) *RecordsService {
+	s.limit = limit
+	return s
+}
+
+// IdLessThan requests the page of records older than the given EndId.
+func (s *RecordsService) IdLessThan(idLessThan string) *RecordsService {
+	s.idLessThan = idLessThan
+	return s
+}
+
+// Record is one subscription, redemption or interest payment.
+type Record struct {
+	OrderId        string `json:"orderId"`
+	CoinName       string `json:"coinName"`
+	SettleCoinName string `json:"settleCoinName"`
+	ProductType    string `json:"productType"`
+	Period         string `json:"period"`
+	ProductLevel   string `json:"productLevel"`
+	Amount         string `json:"amount"`
+	Ts             string `json:"ts"`
+	OrderType      string `json:"orderType"`
+}
+
+// Time returns the time of the record.
+func (r *Record) Time() time.Time {
+	ms, _ := strconv.ParseInt(r.Ts, 10, 64)
+	return time.UnixMilli(ms)
+}
+
+// RecordsResponse is one page of records.
+type RecordsResponse struct {
+	ResultList []*Record `json:"resultList"`
+	EndId      string    `json:"endId"`
+}
+
+// Do sends the request.
+func (s *RecordsService) Do(ctx context.Context) (*RecordsResponse, error) {
+	if err := checkPeriod(s.periodType, false); err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	if s.coin != "" {
+		params.Set("coin", s.coin)
+	}
+	if s.periodType != "" {
+		params.Set("periodType", string(s.periodType))
+	}
+	if s.orderType != "" {
+		params.Set("orderType", s.orderType)
+	}
+	if s.startTime != "" {
+		params.Set("startTime", s.startTime)
+	}
+	if s.endTime != "" {
+		params.Set("endTime", s.endTime)
+	}
+	if s.limit != "" {
+		params.Set("limit", s.limit)
+	}
+	if s.idLessThan != "" {
+		params.Set("idLessThan", s.idLessThan)
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointSavingsRecords, params, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	var response RecordsResponse
+	if err := json.Unmarshal(res.Data, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Iter iterates over all matching records, newest first, following EndId across pages.
+func (s *RecordsService) Iter(ctx context.Context) *common.Iter[Record] {
+	return common.NewIter(ctx, func(ctx context.Context, cursor string) (common.Page[Record], error) {
+		page := *s
+		if cursor != "" {
+			page.idLessThan = cursor
+		}
+		res, err := page.Do(ctx)
+		if err != nil || res == nil {
+			return common.Page[Record]{}, err
+		}
+		return common.Page[Record]{Items: common.Values(res.ResultList), Cursor: res.EndId}, nil
+	})
+}
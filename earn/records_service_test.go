@@ -0,0 +1,33 @@
+package earn
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestRecordsService_Earnings(t *testing.T) {
+	client := &MockClient{}
+	first := url.Values{"coin": {"USDT"}, "orderType": {"pay_interest"}, "startTime": {"1700000000000"}}
+	second := url.Values{"coin": {"USDT"}, "orderType": {"pay_interest"}, "startTime": {"1700000000000"}, "idLessThan": {"2"}}
+	client.On("CallAPI", mock.Anything, "GET", EndpointSavingsRecords, first, []byte(nil), true).
+		Return(okResponse(`{"resultList":[{"orderId":"3","coinName":"USDT","amount":"0.02","ts":"1700086400000","orderType":"pay_interest"},{"orderId":"2","amount":"0.01"}],"endId":"2"}`), &fasthttp.ResponseHeader{}, nil)
+	client.On("CallAPI", mock.Anything, "GET", EndpointSavingsRecords, second, []byte(nil), true).
+		Return(okResponse(`{"resultList":[],"endId":""}`), &fasthttp.ResponseHeader{}, nil)
+
+	it := NewRecordsService(client).Coin("USDT").OrderType(RecordPayInterest).StartTime("1700000000000").Iter(context.Background())
+	var records []Record
+	for it.Next() {
+		records = append(records, it.Item())
+	}
+	require.NoError(t, it.Err())
+	require.Len(t, records, 2)
+	assert.Equal(t, "0.02", records[0].Amount)
+	assert.Equal(t, int64(1700086400000), records[0].Time().UnixMilli())
+	client.AssertExpectations(t)
+}
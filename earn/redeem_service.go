@@ -0,0 +1,78 @@
+package earn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RedeemService redeems an amount of a savings position back to the spot balance.
+type RedeemService struct {
+	c ClientInterface
+
+	productId  string
+	orderId    string
+	periodType PeriodType
+	amount     string
+}
+
+// ProductId sets the product to redeem from (required).
+func (s *RedeemService) ProductId(productId string) *RedeemService {
+	s.productId = productId
+	return s
+}
+
+// OrderId selects the subscription of a fixed-term product to redeem, see
+// Position.OrderId.
+func (s *RedeemService) OrderId(orderId string) *RedeemService {
+	s.orderId = orderId
+	return s
+}
+
+// PeriodType sets the term of the product (required).
+func (s *RedeemService) PeriodType(periodType PeriodType) *RedeemService {
+	s.periodType = periodType
+	return s
+}
+
+// Amount sets the amount to redeem (required).
+func (s *RedeemService) Amount(amount string) *RedeemService {
+	s.amount = amount
+	return s
+}
+
+// Do sends the redemption.
+func (s *RedeemService) Do(ctx context.Context) (*OrderResponse, error) {
+	if s.productId == "" {
+		return nil, fmt.Errorf("productId is required")
+	}
+	if err := checkPeriod(s.periodType, true); err != nil {
+		return nil, err
+	}
+	if s.amount == "" {
+		return nil, fmt.Errorf("amount is required")
+	}
+
+	params := map[string]interface{}{
+		"productId":  s.productId,
+		"periodType": s.periodType,
+		"amount":     s.amount,
+	}
+	if s.orderId != "" {
+		params["orderId"] = s.orderId
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "POST", EndpointSavingsRedeem, nil, body, true)
+	if err != nil {
+		return nil, err
+	}
+	var result OrderResponse
+	if err := json.Unmarshal(res.Data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,33 @@
+package earn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestRedeemService_Do(t *testing.T) {
+	client := &MockClient{}
+	client.On("CallAPI", mock.Anything, "POST", EndpointSavingsRedeem, mock.Anything,
+		bodyEquals(map[string]interface{}{"productId": "2", "orderId": "s2", "periodType": "fixed", "amount": "50"}), true).
+		Return(okResponse(`{"orderId":"r1","status":"success"}`), &fasthttp.ResponseHeader{}, nil)
+
+	res, err := NewRedeemService(client).ProductId("2").OrderId("s2").PeriodType(PeriodFixed).Amount("50").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "r1", res.OrderId)
+	client.AssertExpectations(t)
+}
+
+func TestRedeemService_Validation(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewRedeemService(nil).PeriodType(PeriodFlexible).Amount("1").Do(ctx)
+	assert.EqualError(t, err, "productId is required")
+	_, err = NewRedeemService(nil).ProductId("1").Amount("1").Do(ctx)
+	assert.EqualError(t, err, "periodType is required")
+	_, err = NewRedeemService(nil).ProductId("1").PeriodType(PeriodFlexible).Do(ctx)
+	assert.EqualError(t, err, "amount is required")
+}
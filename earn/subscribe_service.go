@@ -0,0 +1,72 @@
+package earn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// SubscribeService subscribes an amount of the spot balance to a savings product.
+type SubscribeService struct {
+	c ClientInterface
+
+	productId  string
+	periodType PeriodType
+	amount     string
+}
+
+// ProductId sets the product to subscribe to (required).
+func (s *SubscribeService) ProductId(productId string) *SubscribeService {
+	s.productId = productId
+	return s
+}
+
+// PeriodType sets the term of the product (required).
+func (s *SubscribeService) PeriodType(periodType PeriodType) *SubscribeService {
+	s.periodType = periodType
+	return s
+}
+
+// Amount sets the amount to subscribe (required).
+func (s *SubscribeService) Amount(amount string) *SubscribeService {
+	s.amount = amount
+	return s
+}
+
+// OrderResponse is the result of a subscription or redemption.
+type OrderResponse struct {
+	OrderId string `json:"orderId"`
+	Status  string `json:"status"`
+}
+
+// Do sends the subscription.
+func (s *SubscribeService) Do(ctx context.Context) (*OrderResponse, error) {
+	if s.productId == "" {
+		return nil, fmt.Errorf("productId is required")
+	}
+	if err := checkPeriod(s.periodType, true); err != nil {
+		return nil, err
+	}
+	if s.amount == "" {
+		return nil, fmt.Errorf("amount is required")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"productId":  s.productId,
+		"periodType": s.periodType,
+		"amount":     s.amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "POST", EndpointSavingsSubscribe, nil, body, true)
+	if err != nil {
+		return nil, err
+	}
+	var result OrderResponse
+	if err := json.Unmarshal(res.Data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
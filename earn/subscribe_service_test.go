@@ -0,0 +1,35 @@
+package earn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestSubscribeService_Do(t *testing.T) {
+	client := &MockClient{}
+	client.On("CallAPI", mock.Anything, "POST", EndpointSavingsSubscribe, mock.Anything,
+		bodyEquals(map[string]interface{}{"productId": "1", "periodType": "flexible", "amount": "100"}), true).
+		Return(okResponse(`{"orderId":"s1","status":"success"}`), &fasthttp.ResponseHeader{}, nil)
+
+	res, err := NewSubscribeService(client).ProductId("1").PeriodType(PeriodFlexible).Amount("100").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "s1", res.OrderId)
+	client.AssertExpectations(t)
+}
+
+func TestSubscribeService_Validation(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewSubscribeService(nil).PeriodType(PeriodFixed).Amount("1").Do(ctx)
+	assert.EqualError(t, err, "productId is required")
+	_, err = NewSubscribeService(nil).ProductId("1").Amount("1").Do(ctx)
+	assert.EqualError(t, err, "periodType is required")
+	_, err = NewSubscribeService(nil).ProductId("1").PeriodType("weekly").Amount("1").Do(ctx)
+	assert.EqualError(t, err, `invalid period type "weekly"`)
+	_, err = NewSubscribeService(nil).ProductId("1").PeriodType(PeriodFixed).Do(ctx)
+	assert.EqualError(t, err, "amount is required")
+}
@@ -35,18 +35,20 @@ type TradingBot struct {
 
 // NewTradingBot creates a new trading bot instance
 func NewTradingBot() *TradingBot {
+	// Use demo trading if requested
+	env := futures.Production
+	if os.Getenv("BITGET_TESTNET") == "true" {
+		env = futures.Demo
+	}
+
 	// Initialize client with credentials from environment
 	client := futures.NewClient(
 		os.Getenv("BITGET_API_KEY"),
 		os.Getenv("BITGET_SECRET_KEY"),
 		os.Getenv("BITGET_PASSPHRASE"),
+		futures.WithEnvironment(env),
 	)
 
-	// Configure for testnet if needed
-	if os.Getenv("BITGET_TESTNET") == "true" {
-		client.SetApiEndpoint("https://testnet.bitget.com")
-	}
-
 	return &TradingBot{
 		client:        client,
 		symbol:        "BTCUSDT",
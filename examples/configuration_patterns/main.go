@@ -5,14 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
 	"time"
 
+	"github.com/khanbekov/go-bitget/config"
 	"github.com/khanbekov/go-bitget/futures"
-	"github.com/khanbekov/go-bitget/futures/account"
 	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/khanbekov/go-bitget/health"
 )
 
 // ConfigurationDemo demonstrates best practices for configuration management,
@@ -37,80 +40,73 @@ type TradingApp struct {
 	logger *Logger
 	ctx    context.Context
 	cancel context.CancelFunc
+
+	// Open orders and the watcher that expires stale ones
+	orders     *tracker.OrderTracker
+	expiry     *tracker.ExpiryWatcher
+	lastPrices map[string]float64
 }
 
-// Config contains all application configuration with validation
+// Config contains all application configuration. SDK settings (credentials, endpoints,
+// risk limits, log level) come from the embedded config.Config; the remaining sections
+// are specific to this application and are loaded from the same file.
 type Config struct {
-	// API Configuration
-	Bitget BitgetConfig `json:"bitget"`
-	
+	config.Config
+
 	// Trading Configuration
 	Trading TradingConfig `json:"trading"`
-	
-	// Risk Management
-	Risk RiskConfig `json:"risk"`
-	
+
+	// Circuit breaker settings
+	CircuitBreaker CircuitBreakerConfig `json:"circuit_breaker"`
+
 	// Application Settings
 	App AppConfig `json:"app"`
 }
 
-// BitgetConfig contains Bitget API configuration
-type BitgetConfig struct {
-	APIKey      string `json:"api_key"`
-	SecretKey   string `json:"secret_key"`
-	Passphrase  string `json:"passphrase"`
-	BaseURL     string `json:"base_url"`
-	IsTestnet   bool   `json:"is_testnet"`
-	Timeout     int    `json:"timeout_seconds"`
-}
-
 // TradingConfig contains trading strategy parameters
 type TradingConfig struct {
-	Symbols     []string `json:"symbols"`
+	Symbols     []string `json:"symbols" env:"TRADING_SYMBOLS"`
 	ProductType string   `json:"product_type"`
 	MarginCoin  string   `json:"margin_coin"`
-	
+
 	// Position sizing
-	DefaultSize      string  `json:"default_size"`
-	MaxPositions     int     `json:"max_positions"`
-	PositionTimeout  int     `json:"position_timeout_hours"`
-	
+	DefaultSize  string `json:"default_size" env:"TRADING_DEFAULT_SIZE"`
+	MaxPositions int    `json:"max_positions"`
+
+	// Resting (GTC/post-only) orders older than this are cancelled, or re-priced at the
+	// last price when StaleOrderAction is "reprice". Zero disables expiry.
+	OrderMaxAgeMinutes int    `json:"order_max_age_minutes"`
+	StaleOrderAction   string `json:"stale_order_action"`
+
 	// Strategy parameters
-	TakeProfitPct    float64 `json:"take_profit_pct"`
-	StopLossPct      float64 `json:"stop_loss_pct"`
-	EntryThreshold   float64 `json:"entry_threshold_pct"`
+	TakeProfitPct  float64 `json:"take_profit_pct"`
+	StopLossPct    float64 `json:"stop_loss_pct"`
+	EntryThreshold float64 `json:"entry_threshold_pct"`
 }
 
-// RiskConfig contains risk management settings
-type RiskConfig struct {
-	MaxDailyLoss     float64 `json:"max_daily_loss_pct"`
-	MaxDrawdown      float64 `json:"max_drawdown_pct"`
-	MaxPortfolioRisk float64 `json:"max_portfolio_risk_pct"`
-	MinBalance       float64 `json:"min_balance_usdt"`
-	
-	// Circuit breakers
-	EnableCircuitBreaker   bool `json:"enable_circuit_breaker"`
-	MaxConsecutiveLosses   int  `json:"max_consecutive_losses"`
-	CooldownPeriodMinutes  int  `json:"cooldown_period_minutes"`
+// CircuitBreakerConfig contains circuit breaker settings
+type CircuitBreakerConfig struct {
+	Enabled               bool `json:"enabled"`
+	MaxConsecutiveLosses  int  `json:"max_consecutive_losses"`
+	CooldownPeriodMinutes int  `json:"cooldown_period_minutes"`
 }
 
 // AppConfig contains general application settings
 type AppConfig struct {
-	LogLevel          string `json:"log_level"`
-	LogFormat         string `json:"log_format"`
-	LogFile           string `json:"log_file"`
-	
-	UpdateInterval    int    `json:"update_interval_seconds"`
-	DataRetention     int    `json:"data_retention_days"`
-	
+	LogFormat string `json:"log_format"`
+	LogFile   string `json:"log_file" env:"LOG_FILE"`
+
+	UpdateInterval int `json:"update_interval_seconds"`
+	DataRetention  int `json:"data_retention_days"`
+
 	// Monitoring
-	EnableMetrics     bool   `json:"enable_metrics"`
-	MetricsPort       int    `json:"metrics_port"`
-	HealthCheckPort   int    `json:"health_check_port"`
-	
+	EnableMetrics   bool `json:"enable_metrics"`
+	MetricsPort     int  `json:"metrics_port"`
+	HealthCheckPort int  `json:"health_check_port"`
+
 	// Persistence
-	DatabasePath      string `json:"database_path"`
-	BackupInterval    int    `json:"backup_interval_hours"`
+	DatabasePath   string `json:"database_path"`
+	BackupInterval int    `json:"backup_interval_hours"`
 }
 
 // Logger provides structured logging with multiple output formats
@@ -121,9 +117,9 @@ type Logger struct {
 }
 
 // NewLogger creates a configured logger
-func NewLogger(config AppConfig) (*Logger, error) {
+func NewLogger(level string, config AppConfig) (*Logger, error) {
 	logger := &Logger{
-		level:  config.LogLevel,
+		level:  level,
 		format: config.LogFormat,
 	}
 	
@@ -211,23 +207,13 @@ func NewTradingApp() (*TradingApp, error) {
 	}
 	
 	// Initialize logger
-	logger, err := NewLogger(config.App)
+	logger, err := NewLogger(config.Log.Level, config.App)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize logger: %w", err)
 	}
 	
-	// Create Bitget client
-	client := futures.NewClient(
-		config.Bitget.APIKey,
-		config.Bitget.SecretKey,
-		config.Bitget.Passphrase,
-	)
-	
-	// Configure client for testnet if needed
-	if config.Bitget.IsTestnet {
-		client.SetApiEndpoint("https://testnet.bitget.com")
-		logger.Info("🔧 Configured for testnet environment")
-	}
+	// Create Bitget client with the configured credentials, endpoint and log level
+	client := config.FuturesClient()
 	
 	// Create context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
@@ -250,66 +236,60 @@ func NewTradingApp() (*TradingApp, error) {
 }
 
 // LoadConfig loads configuration from multiple sources with precedence:
-// 1. Environment variables (highest priority)
+// 1. Environment variables (highest priority), e.g. BITGET_API_KEY, BITGET_TRADING_SYMBOLS
 // 2. Configuration file
 // 3. Default values (lowest priority)
 func LoadConfig() (*Config, error) {
 	// Start with default configuration
-	config := getDefaultConfig()
-	
-	// Load from config file if exists
-	configFile := getConfigFile()
-	if configFile != "" {
-		if err := loadConfigFromFile(config, configFile); err != nil {
-			return nil, fmt.Errorf("failed to load config file %s: %w", configFile, err)
-		}
+	cfg := getDefaultConfig()
+
+	// Load the config file if one exists, then override with environment variables
+	if err := config.Decode(config.Options{File: getConfigFile()}, cfg); err != nil {
+		return nil, err
 	}
-	
-	// Override with environment variables
-	loadConfigFromEnv(config)
-	
-	return config, nil
+
+	return cfg, nil
 }
 
 // getDefaultConfig returns sensible default configuration
 func getDefaultConfig() *Config {
+	sdk := config.Default()
+	sdk.Risk = config.RiskLimits{
+		MaxDailyLossPct:  0.05,
+		MaxDrawdownPct:   0.15,
+		MaxOpenPositions: 3,
+		MinBalance:       100.0,
+	}
+
 	return &Config{
-		Bitget: BitgetConfig{
-			BaseURL:   "https://api.bitget.com",
-			IsTestnet: false,
-			Timeout:   30,
-		},
+		Config: sdk,
 		Trading: TradingConfig{
 			Symbols:         []string{"BTCUSDT"},
-			ProductType:     "USDT-FUTURES", 
+			ProductType:     "USDT-FUTURES",
 			MarginCoin:      "USDT",
 			DefaultSize:     "0.001",
-			MaxPositions:    3,
-			PositionTimeout: 24,
-			TakeProfitPct:   0.02,
-			StopLossPct:     0.01,
-			EntryThreshold:  0.005,
+			MaxPositions:       3,
+			OrderMaxAgeMinutes: 60,
+			StaleOrderAction:   "cancel",
+			TakeProfitPct:      0.02,
+			StopLossPct:        0.01,
+			EntryThreshold:     0.005,
 		},
-		Risk: RiskConfig{
-			MaxDailyLoss:           0.05,
-			MaxDrawdown:            0.15,
-			MaxPortfolioRisk:       0.25,
-			MinBalance:             100.0,
-			EnableCircuitBreaker:   true,
-			MaxConsecutiveLosses:   5,
-			CooldownPeriodMinutes:  60,
+		CircuitBreaker: CircuitBreakerConfig{
+			Enabled:               true,
+			MaxConsecutiveLosses:  5,
+			CooldownPeriodMinutes: 60,
 		},
 		App: AppConfig{
-			LogLevel:          "info",
-			LogFormat:         "text",
-			LogFile:           "",
-			UpdateInterval:    30,
-			DataRetention:     30,
-			EnableMetrics:     false,
-			MetricsPort:       8080,
-			HealthCheckPort:   8081,
-			DatabasePath:      "./data",
-			BackupInterval:    24,
+			LogFormat:       "text",
+			LogFile:         "",
+			UpdateInterval:  30,
+			DataRetention:   30,
+			EnableMetrics:   false,
+			MetricsPort:     8080,
+			HealthCheckPort: 8081,
+			DatabasePath:    "./data",
+			BackupInterval:  24,
 		},
 	}
 }
@@ -337,109 +317,43 @@ func getConfigFile() string {
 	return ""
 }
 
-// loadConfigFromFile loads configuration from JSON file
-func loadConfigFromFile(config *Config, filename string) error {
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		return err
-	}
-	
-	return json.Unmarshal(data, config)
-}
+// validateConfig performs comprehensive configuration validation, reporting every
+// problem at once
+func (app *TradingApp) validateConfig() error {
+	cfg := app.config
 
-// loadConfigFromEnv overrides config with environment variables
-func loadConfigFromEnv(config *Config) {
-	// Bitget API credentials
-	if apiKey := os.Getenv("BITGET_API_KEY"); apiKey != "" {
-		config.Bitget.APIKey = apiKey
-	}
-	if secretKey := os.Getenv("BITGET_SECRET_KEY"); secretKey != "" {
-		config.Bitget.SecretKey = secretKey
-	}
-	if passphrase := os.Getenv("BITGET_PASSPHRASE"); passphrase != "" {
-		config.Bitget.Passphrase = passphrase
-	}
-	
-	// Testnet configuration
-	if testnet := os.Getenv("BITGET_TESTNET"); testnet != "" {
-		config.Bitget.IsTestnet = testnet == "true"
-	}
-	
-	// Trading parameters
-	if symbols := os.Getenv("TRADING_SYMBOLS"); symbols != "" {
-		// Parse comma-separated symbols
-		config.Trading.Symbols = []string{symbols} // Simplified for demo
-	}
-	if size := os.Getenv("TRADING_DEFAULT_SIZE"); size != "" {
-		config.Trading.DefaultSize = size
-	}
-	
-	// Risk management
-	if maxLoss := os.Getenv("RISK_MAX_DAILY_LOSS"); maxLoss != "" {
-		if val, err := strconv.ParseFloat(maxLoss, 64); err == nil {
-			config.Risk.MaxDailyLoss = val
-		}
-	}
-	
-	// App configuration
-	if logLevel := os.Getenv("LOG_LEVEL"); logLevel != "" {
-		config.App.LogLevel = logLevel
-	}
-	if logFile := os.Getenv("LOG_FILE"); logFile != "" {
-		config.App.LogFile = logFile
-	}
-}
+	// SDK settings: credentials, endpoints, risk limits and log level
+	problems := cfg.Problems(true)
 
-// validateConfig performs comprehensive configuration validation
-func (app *TradingApp) validateConfig() error {
-	config := app.config
-	
-	// Validate API credentials
-	if config.Bitget.APIKey == "" {
-		return fmt.Errorf("BITGET_API_KEY is required")
-	}
-	if config.Bitget.SecretKey == "" {
-		return fmt.Errorf("BITGET_SECRET_KEY is required")
-	}
-	if config.Bitget.Passphrase == "" {
-		return fmt.Errorf("BITGET_PASSPHRASE is required")
+	// Application settings
+	if len(cfg.Trading.Symbols) == 0 {
+		problems = append(problems, "trading.symbols must not be empty")
 	}
-	
-	// Validate trading parameters
-	if len(config.Trading.Symbols) == 0 {
-		return fmt.Errorf("at least one trading symbol is required")
-	}
-	if config.Trading.MaxPositions <= 0 {
-		return fmt.Errorf("max_positions must be greater than 0")
-	}
-	if config.Trading.TakeProfitPct <= 0 {
-		return fmt.Errorf("take_profit_pct must be greater than 0")
+	if cfg.Trading.MaxPositions <= 0 {
+		problems = append(problems, "trading.max_positions must be greater than 0")
 	}
-	if config.Trading.StopLossPct <= 0 {
-		return fmt.Errorf("stop_loss_pct must be greater than 0")
+	if cfg.Trading.TakeProfitPct <= 0 {
+		problems = append(problems, "trading.take_profit_pct must be greater than 0")
 	}
-	
-	// Validate risk parameters
-	if config.Risk.MaxDailyLoss <= 0 || config.Risk.MaxDailyLoss >= 1 {
-		return fmt.Errorf("max_daily_loss_pct must be between 0 and 1")
+	if cfg.Trading.StopLossPct <= 0 {
+		problems = append(problems, "trading.stop_loss_pct must be greater than 0")
 	}
-	if config.Risk.MaxDrawdown <= 0 || config.Risk.MaxDrawdown >= 1 {
-		return fmt.Errorf("max_drawdown_pct must be between 0 and 1")
+	if cfg.Trading.OrderMaxAgeMinutes < 0 {
+		problems = append(problems, "trading.order_max_age_minutes must not be negative")
 	}
-	if config.Risk.MinBalance <= 0 {
-		return fmt.Errorf("min_balance_usdt must be greater than 0")
+	switch tracker.ExpiryAction(cfg.Trading.StaleOrderAction) {
+	case "", tracker.ExpiryCancel, tracker.ExpiryReprice:
+	default:
+		problems = append(problems, "trading.stale_order_action must be \"cancel\" or \"reprice\"")
 	}
-	
-	// Validate app parameters
-	validLogLevels := map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
-	if !validLogLevels[config.App.LogLevel] {
-		return fmt.Errorf("invalid log_level: %s", config.App.LogLevel)
+	if cfg.App.UpdateInterval <= 0 {
+		problems = append(problems, "app.update_interval_seconds must be greater than 0")
 	}
-	
-	if config.App.UpdateInterval <= 0 {
-		return fmt.Errorf("update_interval_seconds must be greater than 0")
+
+	if len(problems) > 0 {
+		return &config.ValidationError{Problems: problems}
 	}
-	
+
 	app.logger.Info("✅ Configuration validation passed")
 	return nil
 }
@@ -473,7 +387,7 @@ func (app *TradingApp) testConnectivity() error {
 	tickerService := market.NewTickerService(app.client)
 	_, err := tickerService.
 		Symbol(app.config.Trading.Symbols[0]).
-		ProductType(market.ProductType(app.config.Trading.ProductType)).
+		ProductType(app.config.Trading.ProductType).
 		Do(app.ctx)
 	
 	if err != nil {
@@ -481,16 +395,21 @@ func (app *TradingApp) testConnectivity() error {
 		return err
 	}
 	
-	// Test account access
-	accountService := account.NewAccountListService(app.client)
-	_, err = accountService.
-		ProductType(account.ProductType(app.config.Trading.ProductType)).
-		Do(app.ctx)
-	
-	if err != nil {
-		app.logger.Error("❌ Account access test failed: %v", err)
-		return err
+	// Verify credentials, key permissions and clock drift
+	report := health.Preflight(app.ctx, app.client, health.PreflightOptions{
+		Require: []health.Permission{health.PermissionRead, health.PermissionTrade},
+	})
+	for _, p := range report.Problems {
+		if p.Warning {
+			app.logger.Warn("⚠️ %s: %s (%s)", p.Check, p.Error, p.Hint)
+		} else {
+			app.logger.Error("❌ %s: %s (%s)", p.Check, p.Error, p.Hint)
+		}
 	}
+	if !report.OK {
+		return fmt.Errorf("preflight failed with %d problem(s)", len(report.Problems))
+	}
+	app.logger.Info("🔑 API key permissions: %v", report.Permissions)
 	
 	app.logger.Info("✅ API connectivity test passed")
 	return nil
@@ -515,9 +434,17 @@ func (app *TradingApp) initialize() error {
 	}
 	
 	// Initialize circuit breaker if enabled
-	if app.config.Risk.EnableCircuitBreaker {
+	if app.config.CircuitBreaker.Enabled {
 		app.logger.Info("🔒 Circuit breaker enabled")
 	}
+
+	// Expire stale resting orders
+	app.setupOrderExpiry()
+
+	// Expose /healthz and /readyz for orchestrators
+	if app.config.App.HealthCheckPort > 0 {
+		app.startHealthServer()
+	}
 	
 	// Log configuration summary
 	app.logConfigSummary()
@@ -526,6 +453,57 @@ func (app *TradingApp) initialize() error {
 	return nil
 }
 
+// setupOrderExpiry creates the order tracker and the watcher that cancels or re-prices
+// orders older than trading.order_max_age_minutes
+func (app *TradingApp) setupOrderExpiry() {
+	app.orders = tracker.NewOrderTracker(tracker.Options{})
+	app.lastPrices = make(map[string]float64)
+
+	rule := tracker.ExpiryRule{
+		MaxAge: time.Duration(app.config.Trading.OrderMaxAgeMinutes) * time.Minute,
+		Action: tracker.ExpiryAction(app.config.Trading.StaleOrderAction),
+		Reprice: func(o tracker.Order) (float64, bool) {
+			price, ok := app.lastPrices[o.Symbol]
+			return price, ok
+		},
+		MaxReprices: 3,
+	}
+	app.expiry = tracker.NewExpiryWatcher(app.client, app.orders, tracker.ExpiryOptions{
+		ProductType: futures.ProductType(app.config.Trading.ProductType),
+		MarginCoin:  app.config.Trading.MarginCoin,
+		Default:     rule,
+		OnExpire: func(ev tracker.ExpiryEvent) {
+			if ev.Err != nil {
+				app.logger.Warn("⚠️ Failed to %s stale order %s: %v", ev.Action, ev.Order.OrderID, ev.Err)
+				return
+			}
+			app.logger.Info("⏰ %s stale order %s after %s", ev.Action, ev.Order.OrderID, ev.Age.Round(time.Second))
+		},
+	})
+	if rule.MaxAge > 0 {
+		app.logger.Info("⏰ Order expiry: %s after %s", rule.Action, rule.MaxAge)
+	}
+}
+
+// startHealthServer serves liveness and readiness endpoints on the configured port
+func (app *TradingApp) startHealthServer() {
+	h := health.New(health.Options{CacheTTL: 10 * time.Second})
+	h.AddCheck(health.RESTCheck(app.client))
+	h.AddCheck(health.AuthCheck(app.client))
+	h.AddCheck(health.ClockDriftCheck(app.client, time.Second))
+
+	mux := http.NewServeMux()
+	h.Register(mux)
+
+	addr := fmt.Sprintf(":%d", app.config.App.HealthCheckPort)
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			app.logger.Error("❌ Health check server stopped: %v", err)
+		}
+	}()
+	app.logger.Info("🩺 Health checks listening on %s", addr)
+}
+
 // logConfigSummary logs key configuration parameters
 func (app *TradingApp) logConfigSummary() {
 	app.logger.Info("📋 Configuration Summary:")
@@ -534,10 +512,11 @@ func (app *TradingApp) logConfigSummary() {
 	app.logger.Info("  📊 Max Positions: %d", app.config.Trading.MaxPositions)
 	app.logger.Info("  📈 Take Profit: %.1f%%", app.config.Trading.TakeProfitPct*100)
 	app.logger.Info("  📉 Stop Loss: %.1f%%", app.config.Trading.StopLossPct*100)
-	app.logger.Info("  ⚠️ Max Daily Loss: %.1f%%", app.config.Risk.MaxDailyLoss*100)
-	app.logger.Info("  🔒 Circuit Breaker: %v", app.config.Risk.EnableCircuitBreaker)
-	if app.config.Bitget.IsTestnet {
-		app.logger.Warn("  🧪 TESTNET MODE ENABLED")
+	app.logger.Info("  ⚠️ Max Daily Loss: %.1f%%", app.config.Risk.MaxDailyLossPct*100)
+	app.logger.Info("  🔒 Circuit Breaker: %v", app.config.CircuitBreaker.Enabled)
+	app.logger.Info("  🌐 REST Endpoint: %s", app.config.ResolvedEndpoints().RESTURL)
+	if app.config.Env().IsDemo() {
+		app.logger.Warn("  🧪 DEMO TRADING ENABLED")
 	}
 }
 
@@ -577,7 +556,7 @@ func (app *TradingApp) processUpdate() error {
 		tickerService := market.NewTickerService(app.client)
 		ticker, err := tickerService.
 			Symbol(symbol).
-			ProductType(market.ProductType(app.config.Trading.ProductType)).
+			ProductType(app.config.Trading.ProductType).
 			Do(app.ctx)
 		
 		if err != nil {
@@ -586,6 +565,17 @@ func (app *TradingApp) processUpdate() error {
 		}
 		
 		app.logger.Debug("📊 %s: $%s", symbol, ticker.LastPr)
+		if price, err := strconv.ParseFloat(ticker.LastPr, 64); err == nil {
+			app.lastPrices[symbol] = price
+		}
+	}
+
+	// Refresh open orders and expire the stale ones. A WebSocket orders subscription
+	// (app.orders.HandleMessage) keeps the tracker current between cycles.
+	if err := app.orders.Sync(app.ctx, app.client, futures.ProductType(app.config.Trading.ProductType)); err != nil {
+		app.logger.Warn("⚠️ Failed to sync open orders: %v", err)
+	} else {
+		app.expiry.Check(app.ctx)
 	}
 	
 	return nil
@@ -14,6 +14,8 @@ import (
 
 	"github.com/khanbekov/go-bitget/futures"
 	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/futures/movers"
+	"github.com/khanbekov/go-bitget/futures/schedule"
 )
 
 // MarketDataStreamer demonstrates real-time market data collection and analysis
@@ -30,6 +32,12 @@ type MarketDataStreamer struct {
 	// Analytics
 	alertThresholds map[string]*AlertConfig
 	updateInterval  time.Duration
+
+	// Movers tracks 24h change and volume spikes across all symbols
+	movers *movers.Tracker
+
+	// Scheduler runs the loops on exchange-aligned boundaries
+	scheduler *schedule.Scheduler
 }
 
 // PriceHistory stores historical price data for a symbol
@@ -62,7 +70,6 @@ type AlertConfig struct {
 	PriceAlertHigh  float64
 	PriceAlertLow   float64
 	VolumeAlertHigh float64
-	ChangeAlert24h  float64 // Alert if 24h change exceeds this %
 	TechnicalAlerts bool
 	LastAlertTime   time.Time
 	AlertCooldown   time.Duration
@@ -99,8 +106,21 @@ func NewMarketDataStreamer() *MarketDataStreamer {
 		priceData:       make(map[string]*PriceHistory),
 		alertThresholds: make(map[string]*AlertConfig),
 		updateInterval:  10 * time.Second,
+		scheduler:       schedule.New(nil),
+		movers: movers.New(movers.Config{
+			ChangeThreshold:  0.05, // Alert on 5% change
+			VolumeZThreshold: 3,    // Alert when volume jumps 3 standard deviations
+			Cooldown:         5 * time.Minute,
+		}),
 	}
 
+	// Report mover events for monitored symbols
+	streamer.movers.OnEvent(func(e movers.Event) {
+		if _, ok := streamer.alertThresholds[e.Mover.Symbol]; ok {
+			streamer.displayMoverEvent(e)
+		}
+	})
+
 	// Initialize price data storage
 	for _, symbol := range symbols {
 		streamer.priceData[symbol] = &PriceHistory{
@@ -111,8 +131,7 @@ func NewMarketDataStreamer() *MarketDataStreamer {
 		// Configure default alerts
 		streamer.alertThresholds[symbol] = &AlertConfig{
 			Symbol:          symbol,
-			ChangeAlert24h:  5.0, // Alert on 5% change
-			VolumeAlertHigh: 0,   // Will be set dynamically
+			VolumeAlertHigh: 0, // Will be set dynamically
 			TechnicalAlerts: true,
 			AlertCooldown:   5 * time.Minute,
 		}
@@ -139,19 +158,11 @@ func (mds *MarketDataStreamer) Start() error {
 	select {}
 }
 
-// dataCollectionLoop continuously collects market data
+// dataCollectionLoop continuously collects market data on exchange-aligned boundaries
 func (mds *MarketDataStreamer) dataCollectionLoop() {
-	ticker := time.NewTicker(mds.updateInterval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			mds.collectMarketData()
-		case <-mds.ctx.Done():
-			return
-		}
-	}
+	mds.scheduler.Every(mds.ctx, mds.updateInterval, 0, func(time.Time) {
+		mds.collectMarketData()
+	})
 }
 
 // collectMarketData fetches latest market data for all symbols
@@ -167,6 +178,9 @@ func (mds *MarketDataStreamer) collectMarketData() {
 		return
 	}
 
+	// Feed the movers tracker with the whole market
+	mds.movers.Update(tickers, time.Now())
+
 	mds.dataLock.Lock()
 	defer mds.dataLock.Unlock()
 
@@ -238,18 +252,11 @@ func (mds *MarketDataStreamer) calculateTechnicalIndicators(ph *PriceHistory) {
 
 // analysisLoop performs continuous market analysis and alert checking
 func (mds *MarketDataStreamer) analysisLoop() {
-	ticker := time.NewTicker(30 * time.Second) // Analyze every 30 seconds
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			mds.checkAlerts()
-			mds.performMarketAnalysis()
-		case <-mds.ctx.Done():
-			return
-		}
-	}
+	// Analyze every 30 seconds, shortly after fresh data has been collected
+	mds.scheduler.Every(mds.ctx, 30*time.Second, 2*time.Second, func(time.Time) {
+		mds.checkAlerts()
+		mds.performMarketAnalysis()
+	})
 }
 
 // checkAlerts monitors for price alerts and technical signals
@@ -292,22 +299,6 @@ func (mds *MarketDataStreamer) checkAlerts() {
 			})
 		}
 
-		// 24h change alert
-		if abs(priceHistory.Change24h) >= alertConfig.ChangeAlert24h {
-			direction := "UP"
-			if priceHistory.Change24h < 0 {
-				direction = "DOWN"
-			}
-			alerts = append(alerts, MarketAlert{
-				Symbol:         symbol,
-				Type:           "VOLUME_SPIKE",
-				Message:        fmt.Sprintf("24h change: %s %.2f%%", direction, abs(priceHistory.Change24h)),
-				Timestamp:      now,
-				CurrentValue:   abs(priceHistory.Change24h),
-				ThresholdValue: alertConfig.ChangeAlert24h,
-			})
-		}
-
 		// Technical alerts
 		if alertConfig.TechnicalAlerts {
 			// RSI alerts
@@ -355,6 +346,33 @@ func (mds *MarketDataStreamer) checkAlerts() {
 	}
 }
 
+// displayMoverEvent converts a movers event into a market alert
+func (mds *MarketDataStreamer) displayMoverEvent(e movers.Event) {
+	alert := MarketAlert{
+		Symbol:         e.Mover.Symbol,
+		Timestamp:      e.Mover.UpdatedAt,
+		CurrentValue:   e.Value,
+		ThresholdValue: e.Threshold,
+	}
+
+	switch e.Type {
+	case movers.EventVolumeSpike:
+		alert.Type = "VOLUME_SPIKE"
+		alert.Message = fmt.Sprintf("Volume spike: z-score %.1f", e.Value)
+	case movers.EventPriceMove:
+		direction := "UP"
+		if e.Mover.Change24h < 0 {
+			direction = "DOWN"
+		}
+		alert.Type = "PRICE_MOVE"
+		alert.Message = fmt.Sprintf("24h change: %s %.2f%%", direction, abs(e.Mover.Change24h)*100)
+	default:
+		return
+	}
+
+	mds.displayAlert(alert)
+}
+
 // performMarketAnalysis generates market insights
 func (mds *MarketDataStreamer) performMarketAnalysis() {
 	mds.dataLock.RLock()
@@ -388,17 +406,10 @@ func (mds *MarketDataStreamer) performMarketAnalysis() {
 
 // displayLoop shows real-time market data dashboard
 func (mds *MarketDataStreamer) displayLoop() {
-	ticker := time.NewTicker(15 * time.Second) // Update display every 15 seconds
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			mds.displayMarketDashboard()
-		case <-mds.ctx.Done():
-			return
-		}
-	}
+	// Update display every 15 seconds
+	mds.scheduler.Every(mds.ctx, 15*time.Second, 0, func(time.Time) {
+		mds.displayMarketDashboard()
+	})
 }
 
 // displayMarketDashboard shows formatted market data
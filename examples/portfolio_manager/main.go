@@ -13,6 +13,7 @@ import (
 	"github.com/khanbekov/go-bitget/futures/account"
 	"github.com/khanbekov/go-bitget/futures/market"
 	"github.com/khanbekov/go-bitget/futures/position"
+	"github.com/khanbekov/go-bitget/futures/scanner"
 	"github.com/khanbekov/go-bitget/futures/trading"
 )
 
@@ -399,39 +400,37 @@ func (pm *PortfolioManager) closePosition(symbol string) error {
 func (pm *PortfolioManager) scanForOpportunities() error {
 	log.Println("🔍 Scanning for new opportunities...")
 
-	// Get market data for all symbols
-	for _, symbol := range pm.symbols {
-		// Skip if already have position
-		if _, exists := pm.positions[symbol]; exists {
-			continue
-		}
+	// Momentum of at least 2% over the last 2 candles on liquid symbols
+	s := scanner.New(pm.client, scanner.Config{
+		ProductType: futures.ProductType(pm.productType),
+		Symbols:     pm.symbols,
+		Granularity: "15m",
+		CandleLimit: 20,
+		Filters: []scanner.Filter{
+			scanner.VolumeFilter{MinQuoteVolume: 10_000_000},
+			scanner.MomentumFilter{Lookback: 2, MinChange: 0.02},
+		},
+	})
 
-		// Get recent price action
-		candleService := market.NewCandlestickService(pm.client)
-		candles, err := candleService.
-			Symbol(symbol).
-			ProductType(market.ProductType(pm.productType)).
-			Granularity("15m").
-			Limit("20").
-			Do(pm.ctx)
+	candidates, err := s.Scan(pm.ctx)
+	if err != nil {
+		return err
+	}
 
-		if err != nil {
+	for _, c := range candidates {
+		// Skip if already have position
+		if _, exists := pm.positions[c.Symbol]; exists {
 			continue
 		}
 
-		// Simple momentum detection
-		if len(candles) >= 3 {
-			recent := candles[len(candles)-1]
-			prev := candles[len(candles)-3]
-
-			// Check for 2% momentum
-			if recent.Close > prev.Close*1.02 {
-				log.Printf("📈 Momentum detected in %s - potential BUY signal", symbol)
-				// Could implement position opening logic here
-			} else if recent.Close < prev.Close*0.98 {
-				log.Printf("📉 Downward momentum in %s - potential SELL signal", symbol)
-				// Could implement short position logic here
-			}
+		recent := c.Candles[len(c.Candles)-1]
+		prev := c.Candles[len(c.Candles)-3]
+		if recent.Close > prev.Close {
+			log.Printf("📈 Momentum detected in %s (score %.2f) - potential BUY signal", c.Symbol, c.Score)
+			// Could implement position opening logic here
+		} else {
+			log.Printf("📉 Downward momentum in %s (score %.2f) - potential SELL signal", c.Symbol, c.Score)
+			// Could implement short position logic here
 		}
 	}
 
@@ -0,0 +1,166 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+)
+
+// Bill business types. The exchange defines more; unlisted ones are passed through.
+const (
+	BillOpenLong        = "open_long"
+	BillOpenShort       = "open_short"
+	BillCloseLong       = "close_long"
+	BillCloseShort      = "close_short"
+	BillForceCloseLong  = "force_close_long"
+	BillForceCloseShort = "force_close_short"
+	BillBurstLongLoss   = "burst_long_loss_query"
+	BillBurstShortLoss  = "burst_short_loss_query"
+	BillDeliveryLong    = "delivery_long"
+	BillDeliveryShort   = "delivery_short"
+	BillContractSettle  = "contract_settle_fee" // Funding fee settlement
+	BillTransferIn      = "trans_from_exchange"
+	BillTransferOut     = "trans_to_exchange"
+	BillAppendMargin    = "append_margin"
+	BillReduceMargin    = "reduce_margin"
+)
+
+// BillHistoryService retrieves the futures account ledger: realized PnL, fees, funding
+// settlements and transfers, one bill per balance change.
+type BillHistoryService struct {
+	c futures.ClientInterface
+
+	productType  futures.ProductType
+	coin         string
+	businessType string
+	startTime    string
+	endTime      string
+	limit        string
+	idLessThan   string
+}
+
+// ProductType sets the product type (required).
+func (s *BillHistoryService) ProductType(productType futures.ProductType) *BillHistoryService {
+	s.productType = productType
+	return s
+}
+
+// Coin filters bills by coin, e.g. "USDT".
+func (s *BillHistoryService) Coin(coin string) *BillHistoryService {
+	s.coin = coin
+	return s
+}
+
+// BusinessType filters bills by business type, e.g. BillContractSettle.
+func (s *BillHistoryService) BusinessType(businessType string) *BillHistoryService {
+	s.businessType = businessType
+	return s
+}
+
+// StartTime sets the start of the query range in Unix milliseconds.
+func (s *BillHistoryService) StartTime(startTime string) *BillHistoryService {
+	s.startTime = startTime
+	return s
+}
+
+// EndTime sets the end of the query range in Unix milliseconds. The range may span at
+// most 30 days.
+func (s *BillHistoryService) EndTime(endTime string) *BillHistoryService {
+	s.endTime = endTime
+	return s
+}
+
+// Limit sets the page size, at most 100.
+func (s *BillHistoryService) Limit(limit string) *BillHistoryService {
+	s.limit = limit
+	return s
+}
+
+// IdLessThan requests the page of bills older than the given end ID.
+func (s *BillHistoryService) IdLessThan(idLessThan string) *BillHistoryService {
+	s.idLessThan = idLessThan
+	return s
+}
+
+// Bill is one account ledger entry. Amount is the balance change excluding Fee.
+type Bill struct {
+	BillId       string `json:"billId"`
+	Symbol       string `json:"symbol"`
+	Amount       string `json:"amount"`
+	Fee          string `json:"fee"`
+	FeeByCoupon  string `json:"feeByCoupon"`
+	BusinessType string `json:"businessType"`
+	Coin         string `json:"coin"`
+	Balance      string `json:"balance"`
+	CTime        string `json:"cTime"`
+}
+
+// Time returns the bill creation time.
+func (b *Bill) Time() time.Time {
+	ms, _ := strconv.ParseInt(b.CTime, 10, 64)
+	return time.UnixMilli(ms)
+}
+
+// BillHistoryResponse is one page of bills.
+type BillHistoryResponse struct {
+	Bills []*Bill `json:"bills"`
+	EndId string  `json:"endId"`
+}
+
+// Do sends the request.
+func (s *BillHistoryService) Do(ctx context.Context) (*BillHistoryResponse, error) {
+	if s.productType == "" {
+		return nil, fmt.Errorf("productType is required")
+	}
+	params := url.Values{}
+	params.Set("productType", string(s.productType))
+	if s.coin != "" {
+		params.Set("coin", s.coin)
+	}
+	if s.businessType != "" {
+		params.Set("businessType", s.businessType)
+	}
+	if s.startTime != "" {
+		params.Set("startTime", s.startTime)
+	}
+	if s.endTime != "" {
+		params.Set("endTime", s.endTime)
+	}
+	if s.limit != "" {
+		params.Set("limit", s.limit)
+	}
+	if s.idLessThan != "" {
+		params.Set("idLessThan", s.idLessThan)
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", futures.EndpointAccountBills, params, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	var response BillHistoryResponse
+	if err := json.Unmarshal(res.Data, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Iter iterates over all matching bills, newest first, following EndId across pages.
+func (s *BillHistoryService) Iter(ctx context.Context) *common.Iter[Bill] {
+	return common.NewIter(ctx, func(ctx context.Context, cursor string) (common.Page[Bill], error) {
+		page := *s
+		if cursor != "" {
+			page.idLessThan = cursor
+		}
+		res, err := page.Do(ctx)
+		if err != nil || res == nil {
+			return common.Page[Bill]{}, err
+		}
+		return common.Page[Bill]{Items: common.Values(res.Bills), Cursor: res.EndId}, nil
+	})
+}
@@ -0,0 +1,49 @@
+package account
+
+// Clone returns an independent copy of s and its parameters.
+func (s *AccountInfoService) Clone() *AccountInfoService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *AccountListService) Clone() *AccountListService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *AdjustMarginService) Clone() *AdjustMarginService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *BillHistoryService) Clone() *BillHistoryService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetAccountBillService) Clone() *GetAccountBillService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *SetLeverageService) Clone() *SetLeverageService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *SetMarginModeService) Clone() *SetMarginModeService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *SetPositionModeService) Clone() *SetPositionModeService {
+	c := *s
+	return &c
+}
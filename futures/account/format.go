@@ -0,0 +1,20 @@
+package account
+
+import (
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// String returns a compact one-line summary, e.g.
+// Account{USDT equity=1050.5 available=1000 locked=0 upl=50.5 crossed one_way_mode}.
+func (a Account) String() string {
+	return common.Describe("Account", a.MarginCoin,
+		"equity="+common.FormatFloat(a.AccountEquity), "available="+common.FormatFloat(a.Available),
+		"locked="+common.FormatFloat(a.Locked), "upl="+common.FormatFloat(a.UnrealizedPL),
+		a.MarginMode, a.PosMode)
+}
+
+// MarshalJSON encodes the wire fields with decimals in plain notation, so the output
+// round-trips through UnmarshalJSON.
+func (a Account) MarshalJSON() ([]byte, error) {
+	return common.MarshalFields(a)
+}
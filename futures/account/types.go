@@ -86,4 +86,8 @@ func NewSetPositionModeService(client ClientInterface) *SetPositionModeService {
 // NewGetAccountBillService creates a new account bill service.
 func NewGetAccountBillService(client ClientInterface) *GetAccountBillService {
 	return &GetAccountBillService{c: client}
-}
\ No newline at end of file
+}
+// NewBillHistoryService creates a new account bill history service.
+func NewBillHistoryService(client ClientInterface) *BillHistoryService {
+	return &BillHistoryService{c: client}
+}
@@ -0,0 +1,309 @@
+// Package announcements polls Bitget public announcements and turns listings,
+// delistings and contract parameter changes into typed events, so universes and risk
+// settings can react without someone reading the news feed:
+//
+//	w := announcements.New(client, announcements.Options{Store: store})
+//	w.OnEvent(func(e announcements.Event) {
+//		switch e.Kind {
+//		case announcements.KindDelisting:
+//			riskEngine.Block(e.Symbols...)
+//			u.Refresh(ctx)
+//		case announcements.KindListing:
+//			u.Refresh(ctx)
+//		}
+//	})
+//	go w.Run(ctx, 5*time.Minute, onError)
+//
+// Every announcement is reported once. Seen announcements are cached in memory and, with
+// a state.Store, across restarts.
+package announcements
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/state"
+)
+
+// Kind classifies an announcement.
+type Kind string
+
+const (
+	KindListing         Kind = "listing"          // New coin or contract
+	KindDelisting       Kind = "delisting"        // Contract or coin removed
+	KindParameterChange Kind = "parameter_change" // Leverage, tick size, margin tiers, funding and similar
+	KindMaintenance     Kind = "maintenance"      // System or trading suspension
+	KindOther           Kind = "other"
+)
+
+// Event is a classified announcement.
+type Event struct {
+	Kind  Kind   `json:"kind"`
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+	// Symbols are the contract names found in the title and summary, e.g. "BTCUSDT".
+	Symbols []string                `json:"symbols,omitempty"`
+	Type    market.AnnouncementType `json:"type,omitempty"`
+	Time    time.Time               `json:"time"`
+}
+
+// Options configures a Watcher.
+type Options struct {
+	// Types are the categories polled. Defaults to listings, delistings, maintenance and
+	// latest news, where parameter changes are published.
+	Types []market.AnnouncementType
+	// Language defaults to market.AnnouncementLanguageEN; classification expects English.
+	Language string
+	// Limit is the page size per category. Defaults to 20.
+	Limit int
+
+	// Store caches seen announcements across restarts. Optional.
+	Store     state.Store
+	Namespace string // Defaults to "announcements"
+}
+
+// Watcher polls announcements and publishes new ones. It is safe for concurrent use.
+type Watcher struct {
+	client futures.ClientInterface
+	opts   Options
+
+	mu       sync.RWMutex
+	seen     map[string]Event
+	handlers []func(Event)
+	loaded   bool
+}
+
+// New creates a watcher.
+func New(client futures.ClientInterface, opts Options) *Watcher {
+	if len(opts.Types) == 0 {
+		opts.Types = []market.AnnouncementType{
+			market.AnnouncementListings,
+			market.AnnouncementDelistings,
+			market.AnnouncementMaintenance,
+			market.AnnouncementLatestNews,
+		}
+	}
+	if opts.Language == "" {
+		opts.Language = market.AnnouncementLanguageEN
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = "announcements"
+	}
+	return &Watcher{client: client, opts: opts, seen: make(map[string]Event)}
+}
+
+// OnEvent registers a handler for new announcements.
+func (w *Watcher) OnEvent(fn func(Event)) {
+	w.mu.Lock()
+	w.handlers = append(w.handlers, fn)
+	w.mu.Unlock()
+}
+
+// Poll fetches every configured category and returns the announcements not seen before,
+// oldest first, after passing them to the handlers. The first poll also restores the
+// cache from the store.
+func (w *Watcher) Poll(ctx context.Context) ([]Event, error) {
+	if err := w.load(ctx); err != nil {
+		return nil, err
+	}
+
+	var fresh []Event
+	pending := make(map[string]bool)
+	for _, typ := range w.opts.Types {
+		anns, err := market.NewAnnouncementsService(w.client).
+			AnnType(typ).
+			Language(w.opts.Language).
+			Limit(w.opts.Limit).
+			Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("announcements: %s: %w", typ, err)
+		}
+		w.mu.RLock()
+		for _, a := range anns {
+			// Categories overlap, e.g. listings also appear in the latest news
+			if _, ok := w.seen[a.AnnID]; ok || pending[a.AnnID] {
+				continue
+			}
+			pending[a.AnnID] = true
+			fresh = append(fresh, Classify(a, typ))
+		}
+		w.mu.RUnlock()
+	}
+	sort.SliceStable(fresh, func(i, j int) bool { return fresh[i].Time.Before(fresh[j].Time) })
+
+	w.mu.Lock()
+	for _, e := range fresh {
+		w.seen[e.ID] = e
+	}
+	handlers := append([]func(Event){}, w.handlers...)
+	w.mu.Unlock()
+
+	if w.opts.Store != nil {
+		for _, e := range fresh {
+			if err := state.PutJSON(ctx, w.opts.Store, w.opts.Namespace, e.ID, e); err != nil {
+				return fresh, fmt.Errorf("announcements: store: %w", err)
+			}
+		}
+	}
+	for _, e := range fresh {
+		for _, fn := range handlers {
+			fn(e)
+		}
+	}
+	return fresh, nil
+}
+
+// Run calls Poll every interval until ctx is cancelled.
+func (w *Watcher) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.Poll(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Events returns the cached announcements of the given kinds, or of every kind when none
+// are given, newest first.
+func (w *Watcher) Events(kinds ...Kind) []Event {
+	w.mu.RLock()
+	out := make([]Event, 0, len(w.seen))
+	for _, e := range w.seen {
+		if len(kinds) == 0 || hasKind(kinds, e.Kind) {
+			out = append(out, e)
+		}
+	}
+	w.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].Time.Equal(out[j].Time) {
+			return out[i].Time.After(out[j].Time)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// load restores the cache from the store once. Restored announcements are not
+// republished.
+func (w *Watcher) load(ctx context.Context) error {
+	w.mu.RLock()
+	loaded := w.loaded
+	w.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+
+	var cached []Event
+	if w.opts.Store != nil {
+		values, err := w.opts.Store.List(ctx, w.opts.Namespace)
+		if err != nil {
+			return fmt.Errorf("announcements: load: %w", err)
+		}
+		for key, data := range values {
+			var e Event
+			if err := json.Unmarshal(data, &e); err != nil {
+				return fmt.Errorf("announcements: restore %s: %w", key, err)
+			}
+			cached = append(cached, e)
+		}
+	}
+
+	w.mu.Lock()
+	for _, e := range cached {
+		w.seen[e.ID] = e
+	}
+	w.loaded = true
+	w.mu.Unlock()
+	return nil
+}
+
+var (
+	symbolPattern = regexp.MustCompile(`\b[A-Z0-9]{2,}(?:USDT|USDC|USD|PERP)\b`)
+
+	delistingWords = []string{"delist", "will remove", "removal of", "cease trading", "offline"}
+	listingWords   = []string{"will list", "new listing", "launch", "listed", "lists "}
+	parameterWords = []string{
+		"leverage", "tick size", "price precision", "quantity precision", "position tier",
+		"margin tier", "risk limit", "funding rate", "funding interval", "funding fee",
+		"adjust", "parameter", "minimum order", "max order",
+	}
+	maintenanceWords = []string{"maintenance", "suspend", "upgrade"}
+)
+
+// Classify derives the kind and symbols of an announcement from its category and title.
+// typ is the category it was fetched with, if known.
+func Classify(a market.Announcement, typ market.AnnouncementType) Event {
+	e := Event{
+		ID:    a.AnnID,
+		Title: a.AnnTitle,
+		URL:   a.AnnURL,
+		Type:  typ,
+		Time:  a.Time(),
+	}
+	e.Symbols = symbols(a.AnnTitle + " " + a.AnnDesc)
+
+	title := strings.ToLower(a.AnnTitle)
+	switch {
+	case typ == market.AnnouncementDelistings || containsAny(title, delistingWords):
+		e.Kind = KindDelisting
+	case typ == market.AnnouncementListings || containsAny(title, listingWords):
+		// Listing titles often mention leverage, so they are matched first
+		e.Kind = KindListing
+	case containsAny(title, parameterWords):
+		e.Kind = KindParameterChange
+	case typ == market.AnnouncementMaintenance || containsAny(title, maintenanceWords):
+		e.Kind = KindMaintenance
+	default:
+		e.Kind = KindOther
+	}
+	return e
+}
+
+func symbols(text string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, s := range symbolPattern.FindAllString(text, -1) {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func containsAny(s string, words []string) bool {
+	for _, w := range words {
+		if strings.Contains(s, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasKind(kinds []Kind, k Kind) bool {
+	for _, kind := range kinds {
+		if kind == k {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package announcements
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeClient struct {
+	byType map[string][]market.Announcement
+	calls  int
+}
+
+func (c *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.calls++
+	data, _ := json.Marshal(c.byType[query.Get("annType")])
+	return &futures.ApiResponse{Code: "00000", Data: data}, &fasthttp.ResponseHeader{}, nil
+}
+
+func ann(id, title, ms string) market.Announcement {
+	return market.Announcement{AnnID: id, AnnTitle: title, CTime: ms, AnnURL: "https://www.bitget.com/support/articles/" + id}
+}
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		title   string
+		typ     market.AnnouncementType
+		kind    Kind
+		symbols []string
+	}{
+		{"Bitget Will Delist ABCUSDT and XYZUSDT Perpetual Futures", "", KindDelisting, []string{"ABCUSDT", "XYZUSDT"}},
+		{"Bitget Will Launch NEWUSDT Perpetual Futures with up to 50x Leverage", "", KindListing, []string{"NEWUSDT"}},
+		{"Adjustment of Maximum Leverage for ETHUSD Coin-M Futures", market.AnnouncementLatestNews, KindParameterChange, []string{"ETHUSD"}},
+		{"Bitget Futures System Maintenance Notice", market.AnnouncementMaintenance, KindMaintenance, nil},
+		{"Bitget Lists FOO (FOO) in the Innovation Zone", market.AnnouncementListings, KindListing, nil},
+		{"Join the Trading Competition", market.AnnouncementPromotions, KindOther, nil},
+	}
+	for _, c := range cases {
+		e := Classify(ann("1", c.title, "1700000000000"), c.typ)
+		assert.Equal(t, c.kind, e.Kind, c.title)
+		assert.Equal(t, c.symbols, e.Symbols, c.title)
+	}
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	client := &fakeClient{byType: map[string][]market.Announcement{
+		string(market.AnnouncementDelistings): {ann("2", "Bitget Will Delist ABCUSDT", "1700000002000")},
+		string(market.AnnouncementLatestNews): {
+			ann("2", "Bitget Will Delist ABCUSDT", "1700000002000"),
+			ann("1", "Tick Size Adjustment for BTCUSDT", "1700000001000"),
+		},
+	}}
+	store := state.NewMemoryStore()
+	w := New(client, Options{
+		Types: []market.AnnouncementType{market.AnnouncementDelistings, market.AnnouncementLatestNews},
+		Store: store,
+	})
+	var got []Event
+	w.OnEvent(func(e Event) { got = append(got, e) })
+
+	fresh, err := w.Poll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, fresh, 2)
+	assert.Equal(t, "1", fresh[0].ID, "oldest first")
+	assert.Equal(t, KindParameterChange, fresh[0].Kind)
+	assert.Equal(t, KindDelisting, fresh[1].Kind)
+	assert.Equal(t, market.AnnouncementDelistings, fresh[1].Type)
+	assert.Equal(t, fresh, got)
+
+	// Nothing new on the next poll
+	fresh, err = w.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, fresh)
+
+	delistings := w.Events(KindDelisting)
+	require.Len(t, delistings, 1)
+	assert.Equal(t, []string{"ABCUSDT"}, delistings[0].Symbols)
+
+	// A restarted watcher restores the cache and does not republish
+	restarted := New(client, Options{
+		Types: []market.AnnouncementType{market.AnnouncementDelistings, market.AnnouncementLatestNews},
+		Store: store,
+	})
+	fresh, err = restarted.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, fresh)
+	assert.Len(t, restarted.Events(), 2)
+}
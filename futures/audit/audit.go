@@ -0,0 +1,217 @@
+// Package audit persists the raw responses of selected REST endpoints, so that what the
+// exchange actually returned during an incident can be reconstructed afterwards.
+//
+// A Client wraps a futures.ClientInterface and appends one JSON line per matching call
+// to a writer, usually a File rotated by size:
+//
+//	file, err := audit.OpenFile("logs/rest-audit.jsonl", 64<<20, 10)
+//	if err != nil {
+//		return err
+//	}
+//	defer file.Close()
+//	client := audit.New(futuresClient, audit.Options{Writer: file})
+//
+// Each line holds the request (method, endpoint, query and body) and the response
+// envelope or the error, including the raw body of rejected requests. Credentials and
+// the fields listed in Options.RedactFields are masked before anything is written.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/valyala/fasthttp"
+)
+
+// DefaultEndpoints are recorded when Options.Endpoints is empty: every order write and
+// margin transfers between account and positions.
+var DefaultEndpoints = []string{
+	futures.EndpointPlaceOrder,
+	futures.EndpointModifyOrder,
+	futures.EndpointCancelOrder,
+	futures.EndpointCancelAllOrders,
+	futures.EndpointBatchPlaceOrder,
+	futures.EndpointBatchCancelOrders,
+	futures.EndpointPlacePlanOrder,
+	futures.EndpointModifyPlanOrder,
+	futures.EndpointCancelPlanOrder,
+	futures.EndpointModifyTPSL,
+	futures.EndpointPlacePosTPSL,
+	futures.EndpointPlaceTPSL,
+	futures.EndpointReversal,
+	futures.EndpointClosePosition,
+	futures.EndpointSetMargin,
+}
+
+// Options configures a Client.
+type Options struct {
+	// Writer receives one JSON line per recorded call. Required.
+	Writer io.Writer
+	// Endpoints to record. Defaults to DefaultEndpoints.
+	Endpoints []string
+	// RedactFields are masked in queries and bodies in addition to credentials,
+	// e.g. "clientOid" when client order IDs carry account information.
+	RedactFields []string
+	// OnError is called when a record cannot be written. Optional.
+	OnError func(error)
+	// Clock measures request latency and stamps entries. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// Record is one recorded call.
+type Record struct {
+	Time      time.Time       `json:"time"`
+	Method    string          `json:"method"`
+	Endpoint  string          `json:"endpoint"`
+	Query     string          `json:"query,omitempty"`
+	Request   json.RawMessage `json:"request,omitempty"`
+	LatencyMs int64           `json:"latencyMs"`
+	// Response is the response envelope: code, msg, requestTime and data.
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+	// ErrorBody is the raw body of a request rejected with an HTTP error.
+	ErrorBody json.RawMessage `json:"errorBody,omitempty"`
+}
+
+// Client is a futures.ClientInterface that records the calls of selected endpoints. It
+// is safe for concurrent use.
+type Client struct {
+	next      futures.ClientInterface
+	opts      Options
+	endpoints map[string]bool
+	redact    map[string]bool
+	clock     common.Clock
+
+	mu sync.Mutex // Serializes writes
+}
+
+// New wraps next with response recording.
+func New(next futures.ClientInterface, opts Options) *Client {
+	if len(opts.Endpoints) == 0 {
+		opts.Endpoints = DefaultEndpoints
+	}
+	c := &Client{
+		next:      next,
+		opts:      opts,
+		endpoints: make(map[string]bool, len(opts.Endpoints)),
+		redact:    make(map[string]bool, len(opts.RedactFields)),
+		clock:     common.ClockOrSystem(opts.Clock),
+	}
+	for _, e := range opts.Endpoints {
+		c.endpoints[e] = true
+	}
+	for _, f := range opts.RedactFields {
+		c.redact[strings.ToLower(f)] = true
+	}
+	return c
+}
+
+// CallAPI forwards the request and records it when the endpoint is selected.
+func (c *Client) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	if !c.endpoints[endpoint] {
+		return c.next.CallAPI(ctx, method, endpoint, query, body, sign)
+	}
+	start := c.clock.Now()
+	res, header, err := c.next.CallAPI(ctx, method, endpoint, query, body, sign)
+
+	rec := Record{
+		Time:      start,
+		Method:    method,
+		Endpoint:  endpoint,
+		Query:     c.redactQuery(query),
+		Request:   c.redactJSON(body),
+		LatencyMs: c.clock.Now().Sub(start).Milliseconds(),
+	}
+	if res != nil {
+		if envelope, mErr := json.Marshal(res); mErr == nil {
+			rec.Response = c.redactJSON(envelope)
+		}
+	}
+	if err != nil {
+		rec.Error = err.Error()
+		var apiErr *types.APIError
+		if errors.As(err, &apiErr) {
+			rec.ErrorBody = c.redactJSON(apiErr.Response)
+		}
+	}
+	c.write(rec)
+	return res, header, err
+}
+
+func (c *Client) write(rec Record) {
+	line, err := json.Marshal(rec)
+	if err == nil {
+		c.mu.Lock()
+		_, err = c.opts.Writer.Write(append(line, '\n'))
+		c.mu.Unlock()
+	}
+	if err != nil && c.opts.OnError != nil {
+		c.opts.OnError(err)
+	}
+}
+
+func (c *Client) sensitive(name string) bool {
+	return common.IsSensitive(name) || c.redact[strings.ToLower(name)]
+}
+
+func (c *Client) redactQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	out := make(url.Values, len(query))
+	for k, v := range query {
+		if c.sensitive(k) {
+			v = []string{common.Redacted}
+		}
+		out[k] = v
+	}
+	return out.Encode()
+}
+
+// redactJSON masks sensitive fields at any depth. Bodies that are not JSON are kept as
+// a JSON string so the record stays valid.
+func (c *Client) redactJSON(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber() // Keep IDs and amounts exactly as received
+	if err := dec.Decode(&v); err != nil {
+		quoted, _ := json.Marshal(string(body))
+		return quoted
+	}
+	c.redactValue(v)
+	out, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+func (c *Client) redactValue(v any) {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			if c.sensitive(k) {
+				t[k] = common.Redacted
+				continue
+			}
+			c.redactValue(child)
+		}
+	case []any:
+		for _, child := range t {
+			c.redactValue(child)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeClient struct {
+	res *futures.ApiResponse
+	err error
+}
+
+func (c *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	return c.res, &fasthttp.ResponseHeader{}, c.err
+}
+
+func records(t *testing.T, buf *bytes.Buffer) []Record {
+	t.Helper()
+	var out []Record
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec Record
+		require.NoError(t, json.Unmarshal([]byte(line), &rec))
+		out = append(out, rec)
+	}
+	return out
+}
+
+// steppingClock advances by step on every Now call.
+type steppingClock struct {
+	*common.FakeClock
+	step time.Duration
+}
+
+func (c steppingClock) Now() time.Time {
+	c.Advance(c.step)
+	return c.FakeClock.Now()
+}
+
+func TestClient_RecordsSelectedEndpoints(t *testing.T) {
+	var buf bytes.Buffer
+	next := &fakeClient{res: &futures.ApiResponse{Code: "00000", Msg: "success", RequestTime: 1700000000123, Data: []byte(`{"orderId":"1234567890123456789","clientOid":"acct-7"}`)}}
+	clock := steppingClock{common.NewFakeClock(time.UnixMilli(1700000000000)), 25 * time.Millisecond}
+	c := New(next, Options{Writer: &buf, RedactFields: []string{"clientOid"}, Clock: clock})
+	ctx := context.Background()
+
+	_, _, err := c.CallAPI(ctx, "POST", futures.EndpointPlaceOrder, nil, []byte(`{"symbol":"BTCUSDT","size":"0.01","clientOid":"acct-7"}`), true)
+	require.NoError(t, err)
+	_, _, err = c.CallAPI(ctx, "GET", futures.EndpointAllTickers, url.Values{"productType": {"USDT-FUTURES"}}, nil, false)
+	require.NoError(t, err)
+
+	recs := records(t, &buf)
+	require.Len(t, recs, 1, "tickers are not selected")
+	rec := recs[0]
+	assert.Equal(t, futures.EndpointPlaceOrder, rec.Endpoint)
+	assert.Equal(t, "POST", rec.Method)
+	assert.Equal(t, int64(25), rec.LatencyMs)
+	assert.JSONEq(t, `{"symbol":"BTCUSDT","size":"0.01","clientOid":"[REDACTED]"}`, string(rec.Request))
+	assert.JSONEq(t, `{"code":"00000","msg":"success","requestTime":1700000000123,"data":{"orderId":"1234567890123456789","clientOid":"[REDACTED]"}}`, string(rec.Response))
+}
+
+func TestClient_RecordsErrors(t *testing.T) {
+	var buf bytes.Buffer
+	next := &fakeClient{err: &types.APIError{Code: 40762, Message: "balance", Response: []byte(`{"code":"40762","msg":"The order amount exceeds the balance","passphrase":"x"}`)}}
+	c := New(next, Options{Writer: &buf, Endpoints: []string{futures.EndpointPlaceOrder}})
+
+	_, _, err := c.CallAPI(context.Background(), "POST", futures.EndpointPlaceOrder, url.Values{"apiKey": {"k"}}, []byte(`not json`), true)
+	require.Error(t, err)
+
+	recs := records(t, &buf)
+	require.Len(t, recs, 1)
+	assert.Equal(t, err.Error(), recs[0].Error)
+	assert.JSONEq(t, `{"code":"40762","msg":"The order amount exceeds the balance","passphrase":"[REDACTED]"}`, string(recs[0].ErrorBody))
+	assert.Equal(t, `"not json"`, string(recs[0].Request))
+	assert.Equal(t, "apiKey=%5BREDACTED%5D", recs[0].Query)
+	assert.Empty(t, recs[0].Response)
+}
+
+func TestFile_Rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "rest.jsonl")
+	f, err := OpenFile(path, 10, 2)
+	require.NoError(t, err)
+
+	for _, line := range []string{"aaaa\n", "bbbb\n", "cccc\n", "dddd\n", "eeee\n", "ffff\n", "gggg\n"} {
+		_, err := f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	read := func(name string) string {
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		return string(data)
+	}
+	assert.Equal(t, "gggg\n", read(path))
+	assert.Equal(t, "eeee\nffff\n", read(path+".1"))
+	assert.Equal(t, "cccc\ndddd\n", read(path+".2"))
+	assert.NoFileExists(t, path+".3", "older backups are removed")
+
+	f, err = OpenFile(path, 10, 2)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("hh\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	assert.Equal(t, "gggg\nhh\n", read(path), "reopening appends")
+
+	_, err = f.Write([]byte("x"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+}
@@ -0,0 +1,111 @@
+package candles
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/khanbekov/go-bitget/futures/market"
+)
+
+// Storage persists candle series between runs.
+type Storage interface {
+	// Load returns the stored candles for key, or an empty slice when none exist.
+	Load(key Key) ([]market.Candlestick, error)
+	// Save replaces the stored candles for key.
+	Save(key Key, candles []market.Candlestick) error
+}
+
+// MemoryStorage keeps series in process memory. It is the default storage.
+type MemoryStorage struct {
+	mu   sync.RWMutex
+	data map[Key][]market.Candlestick
+}
+
+// NewMemoryStorage creates an empty in-memory storage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{data: make(map[Key][]market.Candlestick)}
+}
+
+// Load implements Storage.
+func (m *MemoryStorage) Load(key Key) ([]market.Candlestick, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]market.Candlestick, len(m.data[key]))
+	copy(out, m.data[key])
+	return out, nil
+}
+
+// Save implements Storage.
+func (m *MemoryStorage) Save(key Key, candles []market.Candlestick) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := make([]market.Candlestick, len(candles))
+	copy(stored, candles)
+	m.data[key] = stored
+	return nil
+}
+
+// FileStorage stores one JSON file per series in a directory. Candles are written in the
+// same array format the REST API returns, so files can be inspected or seeded by hand.
+type FileStorage struct {
+	dir string
+}
+
+// NewFileStorage creates a file storage rooted at dir, creating the directory if needed.
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create candle directory: %w", err)
+	}
+	return &FileStorage{dir: dir}, nil
+}
+
+func (f *FileStorage) path(key Key) string {
+	return filepath.Join(f.dir, key.String()+".json")
+}
+
+// Load implements Storage.
+func (f *FileStorage) Load(key Key) ([]market.Candlestick, error) {
+	data, err := os.ReadFile(f.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var candles []market.Candlestick
+	if err := json.Unmarshal(data, &candles); err != nil {
+		return nil, err
+	}
+	return candles, nil
+}
+
+// Save implements Storage. The file is replaced atomically.
+func (f *FileStorage) Save(key Key, candles []market.Candlestick) error {
+	rows := make([][]string, len(candles))
+	for i, c := range candles {
+		rows[i] = []string{
+			strconv.FormatInt(c.CloseTime, 10),
+			strconv.FormatFloat(c.Open, 'f', -1, 64),
+			strconv.FormatFloat(c.High, 'f', -1, 64),
+			strconv.FormatFloat(c.Low, 'f', -1, 64),
+			strconv.FormatFloat(c.Close, 'f', -1, 64),
+			strconv.FormatFloat(c.Volume, 'f', -1, 64),
+			strconv.FormatFloat(c.QuoteAssetVolume, 'f', -1, 64),
+		}
+	}
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return err
+	}
+
+	tmp := f.path(key) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path(key))
+}
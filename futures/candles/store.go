@@ -0,0 +1,305 @@
+// Package candles maintains continuous candlestick series per symbol and granularity.
+//
+// A Store keeps candles in memory, persists them through a pluggable Storage, detects
+// missing bars and backfills them from the REST candlestick endpoint. Strategies read a
+// gap-free series from Series instead of stitching REST pages together themselves.
+package candles
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures/market"
+)
+
+// maxCandlesPerRequest is the page size limit of the candlestick endpoint.
+const maxCandlesPerRequest = 1000
+
+// Key identifies a candle series.
+type Key struct {
+	Symbol      string
+	ProductType market.ProductType
+	Granularity string
+}
+
+// String returns a filesystem friendly representation of the key.
+func (k Key) String() string {
+	return fmt.Sprintf("%s_%s_%s", k.Symbol, k.ProductType, k.Granularity)
+}
+
+// Gap is a range of missing bars, [From, To] inclusive, expressed as bar open times.
+type Gap struct {
+	From time.Time
+	To   time.Time
+}
+
+// Bars returns the number of missing bars in the gap for the given interval.
+func (g Gap) Bars(interval time.Duration) int {
+	return int(g.To.Sub(g.From)/interval) + 1
+}
+
+// GranularityDuration converts a Bitget granularity ("1m", "4H", "1D", ...) to a duration.
+// Monthly candles have no fixed length and are not supported.
+func GranularityDuration(granularity string) (time.Duration, error) {
+	if len(granularity) < 2 {
+		return 0, fmt.Errorf("unsupported granularity %q", granularity)
+	}
+	unit := granularity[len(granularity)-1:]
+	n, err := strconv.Atoi(granularity[:len(granularity)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("unsupported granularity %q", granularity)
+	}
+
+	switch unit {
+	case "m":
+		return time.Duration(n) * time.Minute, nil
+	}
+	switch strings.ToUpper(unit) {
+	case "H":
+		return time.Duration(n) * time.Hour, nil
+	case "D":
+		return time.Duration(n) * 24 * time.Hour, nil
+	case "W":
+		return time.Duration(n) * 7 * 24 * time.Hour, nil
+	}
+	return 0, fmt.Errorf("unsupported granularity %q", granularity)
+}
+
+// Store keeps candle series in memory, persists them and fills gaps via REST.
+// It is safe for concurrent use.
+type Store struct {
+	client  market.ClientInterface
+	storage Storage
+	clock   common.Clock
+
+	mu     sync.RWMutex
+	series map[Key][]market.Candlestick
+}
+
+// NewStore creates a store. storage may be nil to keep candles in memory only.
+func NewStore(client market.ClientInterface, storage Storage) *Store {
+	if storage == nil {
+		storage = NewMemoryStorage()
+	}
+	return &Store{
+		client:  client,
+		storage: storage,
+		clock:   common.SystemClock(),
+		series:  make(map[Key][]market.Candlestick),
+	}
+}
+
+// SetClock sets the clock that decides which candles are closed.
+func (s *Store) SetClock(clock common.Clock) {
+	s.clock = common.ClockOrSystem(clock)
+}
+
+// Load restores the persisted series for key and backfills everything missing between
+// since and the last closed bar. It is intended to be called on startup.
+func (s *Store) Load(ctx context.Context, key Key, since time.Time) error {
+	interval, err := GranularityDuration(key.Granularity)
+	if err != nil {
+		return err
+	}
+
+	stored, err := s.storage.Load(key)
+	if err != nil {
+		return fmt.Errorf("load %s: %w", key, err)
+	}
+
+	s.mu.Lock()
+	s.series[key] = merge(s.series[key], stored)
+	s.mu.Unlock()
+
+	start := align(since, interval)
+	// The most recent bar is still forming, so only closed bars are expected
+	end := align(s.clock.Now(), interval).Add(-interval)
+	if end.Before(start) {
+		return nil
+	}
+
+	for _, gap := range s.gapsBetween(key, interval, start, end) {
+		if err := s.fetch(ctx, key, interval, gap); err != nil {
+			return err
+		}
+	}
+	return s.Save(key)
+}
+
+// Append adds or replaces a candle, typically from a live WebSocket feed.
+// It reports whether the candle opened a gap after the previous last bar.
+func (s *Store) Append(key Key, candle market.Candlestick) (gap bool) {
+	interval, err := GranularityDuration(key.Granularity)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	series := s.series[key]
+	if n := len(series); n > 0 && err == nil {
+		gap = candle.CloseTime-series[n-1].CloseTime > interval.Milliseconds()
+	}
+	s.series[key] = merge(series, []market.Candlestick{candle})
+	return gap
+}
+
+// Gaps returns the missing bar ranges inside the stored series.
+func (s *Store) Gaps(key Key) []Gap {
+	interval, err := GranularityDuration(key.Granularity)
+	if err != nil {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	series := s.series[key]
+	if len(series) == 0 {
+		return nil
+	}
+	return findGaps(series, interval, time.UnixMilli(series[0].CloseTime), time.UnixMilli(series[len(series)-1].CloseTime))
+}
+
+// Backfill fetches every gap inside the stored series and persists the result.
+func (s *Store) Backfill(ctx context.Context, key Key) error {
+	interval, err := GranularityDuration(key.Granularity)
+	if err != nil {
+		return err
+	}
+	for _, gap := range s.Gaps(key) {
+		if err := s.fetch(ctx, key, interval, gap); err != nil {
+			return err
+		}
+	}
+	return s.Save(key)
+}
+
+// Series returns a copy of the stored candles for key, ordered by time.
+func (s *Store) Series(key Key) []market.Candlestick {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]market.Candlestick, len(s.series[key]))
+	copy(out, s.series[key])
+	return out
+}
+
+// Range returns the stored candles whose open time lies in [from, to].
+func (s *Store) Range(key Key, from, to time.Time) []market.Candlestick {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	series := s.series[key]
+	lo := sort.Search(len(series), func(i int) bool { return series[i].CloseTime >= from.UnixMilli() })
+	hi := sort.Search(len(series), func(i int) bool { return series[i].CloseTime > to.UnixMilli() })
+	out := make([]market.Candlestick, hi-lo)
+	copy(out, series[lo:hi])
+	return out
+}
+
+// Last returns the most recent candle for key.
+func (s *Store) Last(key Key) (market.Candlestick, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	series := s.series[key]
+	if len(series) == 0 {
+		return market.Candlestick{}, false
+	}
+	return series[len(series)-1], true
+}
+
+// Save persists the series for key.
+func (s *Store) Save(key Key) error {
+	if err := s.storage.Save(key, s.Series(key)); err != nil {
+		return fmt.Errorf("save %s: %w", key, err)
+	}
+	return nil
+}
+
+// gapsBetween returns the ranges in [start, end] not covered by the stored series.
+func (s *Store) gapsBetween(key Key, interval time.Duration, start, end time.Time) []Gap {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return findGaps(s.series[key], interval, start, end)
+}
+
+// fetch pages through the candlestick endpoint to cover gap and merges the result.
+func (s *Store) fetch(ctx context.Context, key Key, interval time.Duration, gap Gap) error {
+	for cursor := gap.From; !cursor.After(gap.To); {
+		pageEnd := cursor.Add(interval * (maxCandlesPerRequest - 1))
+		if pageEnd.After(gap.To) {
+			pageEnd = gap.To
+		}
+
+		candles, err := market.NewCandlestickService(s.client).
+			Symbol(key.Symbol).
+			ProductType(key.ProductType).
+			Granularity(key.Granularity).
+			StartTime(strconv.FormatInt(cursor.UnixMilli(), 10)).
+			EndTime(strconv.FormatInt(pageEnd.UnixMilli(), 10)).
+			Limit(strconv.Itoa(maxCandlesPerRequest)).
+			Do(ctx)
+		if err != nil {
+			return fmt.Errorf("backfill %s from %s: %w", key, cursor.UTC().Format(time.RFC3339), err)
+		}
+
+		s.mu.Lock()
+		s.series[key] = merge(s.series[key], candles)
+		s.mu.Unlock()
+
+		cursor = pageEnd.Add(interval)
+	}
+	return nil
+}
+
+// merge combines two candle slices, ordering by open time; b wins on duplicates.
+func merge(a, b []market.Candlestick) []market.Candlestick {
+	if len(b) == 0 {
+		return a
+	}
+	byTime := make(map[int64]market.Candlestick, len(a)+len(b))
+	for _, c := range a {
+		byTime[c.CloseTime] = c
+	}
+	for _, c := range b {
+		byTime[c.CloseTime] = c
+	}
+	out := make([]market.Candlestick, 0, len(byTime))
+	for _, c := range byTime {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CloseTime < out[j].CloseTime })
+	return out
+}
+
+// align rounds t down to a multiple of interval since the Unix epoch.
+func align(t time.Time, interval time.Duration) time.Time {
+	ms := t.UnixMilli()
+	return time.UnixMilli(ms - ms%interval.Milliseconds())
+}
+
+// findGaps scans a sorted series for bars missing in [start, end].
+func findGaps(series []market.Candlestick, interval time.Duration, start, end time.Time) []Gap {
+	var gaps []Gap
+	step := interval.Milliseconds()
+	expected := start.UnixMilli()
+	last := end.UnixMilli()
+
+	for _, c := range series {
+		if c.CloseTime < expected {
+			continue
+		}
+		if c.CloseTime > last {
+			break
+		}
+		if c.CloseTime > expected {
+			gaps = append(gaps, Gap{From: time.UnixMilli(expected), To: time.UnixMilli(c.CloseTime - step)})
+		}
+		expected = c.CloseTime + step
+	}
+	if expected <= last {
+		gaps = append(gaps, Gap{From: time.UnixMilli(expected), To: time.UnixMilli(last)})
+	}
+	return gaps
+}
@@ -0,0 +1,143 @@
+package candles
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// fakeClient serves candles for every minute in the requested range except the skipped ones.
+type fakeClient struct {
+	skip  map[int64]bool
+	calls int
+	err   error
+}
+
+func (f *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*market.ApiResponse, *fasthttp.ResponseHeader, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, nil, f.err
+	}
+	start, _ := strconv.ParseInt(query.Get("startTime"), 10, 64)
+	end, _ := strconv.ParseInt(query.Get("endTime"), 10, 64)
+
+	var rows [][]string
+	for ts := start; ts <= end; ts += time.Minute.Milliseconds() {
+		if f.skip[ts] {
+			continue
+		}
+		rows = append(rows, []string{strconv.FormatInt(ts, 10), "1", "2", "0.5", "1.5", "10", "15"})
+	}
+	data, _ := json.Marshal(rows)
+	return &market.ApiResponse{Code: "00000", Data: data}, &fasthttp.ResponseHeader{}, nil
+}
+
+func candle(ts time.Time) market.Candlestick {
+	return market.Candlestick{CloseTime: ts.UnixMilli(), Open: 1, High: 2, Low: 0.5, Close: 1.5, Volume: 10}
+}
+
+var testKey = Key{Symbol: "BTCUSDT", ProductType: market.ProductTypeUSDTFutures, Granularity: "1m"}
+
+func TestGranularityDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"1m":  time.Minute,
+		"15m": 15 * time.Minute,
+		"4H":  4 * time.Hour,
+		"1h":  time.Hour,
+		"1D":  24 * time.Hour,
+		"1W":  7 * 24 * time.Hour,
+	}
+	for g, want := range cases {
+		got, err := GranularityDuration(g)
+		require.NoError(t, err, g)
+		assert.Equal(t, want, got, g)
+	}
+
+	_, err := GranularityDuration("1M")
+	assert.Error(t, err)
+}
+
+func TestStore_LoadBackfillsFromStorage(t *testing.T) {
+	now := time.Date(2024, 1, 1, 1, 0, 30, 0, time.UTC)
+	since := now.Add(-10 * time.Minute)
+
+	storage := NewMemoryStorage()
+	// Persisted bars cover the middle of the window only
+	require.NoError(t, storage.Save(testKey, []market.Candlestick{
+		candle(since.Add(3 * time.Minute).Truncate(time.Minute)),
+		candle(since.Add(4 * time.Minute).Truncate(time.Minute)),
+	}))
+
+	client := &fakeClient{}
+	store := NewStore(client, storage)
+	store.SetClock(common.NewFakeClock(now))
+
+	require.NoError(t, store.Load(context.Background(), testKey, since))
+
+	series := store.Series(testKey)
+	assert.Len(t, series, 10)
+	assert.Empty(t, store.Gaps(testKey))
+	assert.Equal(t, 2, client.calls, "one request per gap")
+
+	last, ok := store.Last(testKey)
+	require.True(t, ok)
+	assert.Equal(t, now.Truncate(time.Minute).Add(-time.Minute).UnixMilli(), last.CloseTime)
+
+	persisted, _ := storage.Load(testKey)
+	assert.Len(t, persisted, 10)
+}
+
+func TestStore_AppendDetectsGapAndBackfills(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewStore(&fakeClient{}, nil)
+
+	assert.False(t, store.Append(testKey, candle(base)))
+	assert.False(t, store.Append(testKey, candle(base.Add(time.Minute))))
+	assert.True(t, store.Append(testKey, candle(base.Add(5*time.Minute))))
+
+	gaps := store.Gaps(testKey)
+	require.Len(t, gaps, 1)
+	assert.Equal(t, base.Add(2*time.Minute), gaps[0].From.UTC())
+	assert.Equal(t, 3, gaps[0].Bars(time.Minute))
+
+	require.NoError(t, store.Backfill(context.Background(), testKey))
+	assert.Empty(t, store.Gaps(testKey))
+	assert.Len(t, store.Range(testKey, base.Add(time.Minute), base.Add(3*time.Minute)), 3)
+}
+
+func TestStore_BackfillError(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewStore(&fakeClient{err: errors.New("rate limited")}, nil)
+	store.Append(testKey, candle(base))
+	store.Append(testKey, candle(base.Add(3*time.Minute)))
+
+	err := store.Backfill(context.Background(), testKey)
+	assert.ErrorContains(t, err, "rate limited")
+}
+
+func TestFileStorage_RoundTrip(t *testing.T) {
+	storage, err := NewFileStorage(t.TempDir())
+	require.NoError(t, err)
+
+	empty, err := storage.Load(testKey)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	in := []market.Candlestick{candle(time.UnixMilli(1700000000000))}
+	in[0].QuoteAssetVolume = 12.5
+	require.NoError(t, storage.Save(testKey, in))
+
+	out, err := storage.Load(testKey)
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+}
@@ -0,0 +1,239 @@
+// Package chaos injects latency and faults into REST calls, to verify retry, cooldown
+// and circuit-breaker behavior in staging before the exchange misbehaves for real.
+//
+// A Client wraps a futures.ClientInterface; every service accepts it in place of the
+// client:
+//
+//	faulty := chaos.New(client, chaos.Options{
+//		Delay: 200 * time.Millisecond, DelayRate: 0.3,
+//		RateLimitRate: 0.05, ServerErrorRate: 0.02, DropRate: 0.01, MalformedRate: 0.01,
+//	})
+//	guarded := guard.NewCooldown(faulty, guard.CooldownOptions{})
+//
+// Rate limit and server errors are returned as *types.APIError without calling the
+// exchange, like a rejection by the gateway. Dropped responses are worse: the request
+// is sent and may take effect, but the caller only sees a timeout, which is what
+// idempotent clientOids and reconciliation are for.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/valyala/fasthttp"
+)
+
+// ErrInjected is matched by every *FaultError.
+var ErrInjected = errors.New("chaos: injected fault")
+
+// Fault is a kind of injected failure.
+type Fault string
+
+const (
+	FaultRateLimit   Fault = "rate_limit"   // 429 returned instead of calling the exchange
+	FaultServerError Fault = "server_error" // 5xx returned instead of calling the exchange
+	FaultDrop        Fault = "drop"         // Request sent, response lost
+	FaultMalformed   Fault = "malformed"    // Request sent, response payload corrupted
+)
+
+// FaultError is returned for dropped responses. It reports itself as a timeout.
+type FaultError struct {
+	Fault    Fault
+	Endpoint string
+}
+
+func (e *FaultError) Error() string {
+	return fmt.Sprintf("chaos: %s on %s", e.Fault, e.Endpoint)
+}
+
+// Is makes errors.Is(err, ErrInjected) match.
+func (e *FaultError) Is(target error) bool {
+	return target == ErrInjected
+}
+
+// Timeout reports true, as a lost response looks like a timeout to the caller.
+func (e *FaultError) Timeout() bool {
+	return true
+}
+
+// Options sets the share of calls, between 0 and 1, affected by each fault.
+type Options struct {
+	// Delay is added to DelayRate of the calls, plus up to DelayJitter at random.
+	Delay       time.Duration
+	DelayJitter time.Duration
+	DelayRate   float64
+
+	// At most one of these faults is injected per call; their rates add up.
+	RateLimitRate   float64
+	ServerErrorRate float64
+	DropRate        float64
+	MalformedRate   float64
+
+	// Endpoints restricts injection to these endpoints. Empty affects every call.
+	Endpoints []string
+
+	// Seed makes the fault sequence reproducible; zero uses the current time.
+	Seed int64
+
+	// OnFault is called for every injected fault. Optional.
+	OnFault func(fault Fault, endpoint string)
+}
+
+// Stats counts calls and injected faults.
+type Stats struct {
+	Calls   int
+	Delayed int
+	Faults  map[Fault]int
+}
+
+// Client is a futures.ClientInterface that injects faults. It is safe for concurrent
+// use.
+type Client struct {
+	next      futures.ClientInterface
+	opts      Options
+	endpoints map[string]bool
+	sleep     func(ctx context.Context, d time.Duration) error
+
+	mu      sync.Mutex
+	rng     *rand.Rand
+	enabled bool
+	stats   Stats
+}
+
+// New wraps next with fault injection, enabled from the start.
+func New(next futures.ClientInterface, opts Options) *Client {
+	if opts.Seed == 0 {
+		opts.Seed = time.Now().UnixNano()
+	}
+	c := &Client{
+		next:    next,
+		opts:    opts,
+		sleep:   sleep,
+		rng:     rand.New(rand.NewSource(opts.Seed)),
+		enabled: true,
+		stats:   Stats{Faults: make(map[Fault]int)},
+	}
+	if len(opts.Endpoints) > 0 {
+		c.endpoints = make(map[string]bool, len(opts.Endpoints))
+		for _, e := range opts.Endpoints {
+			c.endpoints[e] = true
+		}
+	}
+	return c
+}
+
+// SetEnabled turns injection on or off; disabled, calls pass through untouched.
+func (c *Client) SetEnabled(enabled bool) {
+	c.mu.Lock()
+	c.enabled = enabled
+	c.mu.Unlock()
+}
+
+// Stats returns the counters so far.
+func (c *Client) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.stats
+	s.Faults = make(map[Fault]int, len(c.stats.Faults))
+	for f, n := range c.stats.Faults {
+		s.Faults[f] = n
+	}
+	return s
+}
+
+// CallAPI forwards the request, possibly delayed, failed or corrupted.
+func (c *Client) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	delay, fault := c.draw(endpoint)
+	if fault != "" && c.opts.OnFault != nil {
+		c.opts.OnFault(fault, endpoint)
+	}
+	if delay > 0 {
+		if err := c.sleep(ctx, delay); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	switch fault {
+	case FaultRateLimit:
+		return nil, nil, &types.APIError{Code: 429, Message: "Too Many Requests"}
+	case FaultServerError:
+		return nil, nil, &types.APIError{Code: 503, Message: "Service Unavailable"}
+	}
+
+	res, header, err := c.next.CallAPI(ctx, method, endpoint, query, body, sign)
+	if err != nil {
+		return res, header, err
+	}
+	switch fault {
+	case FaultDrop:
+		return nil, nil, &FaultError{Fault: FaultDrop, Endpoint: endpoint}
+	case FaultMalformed:
+		corrupted := *res
+		corrupted.Data = truncate(res.Data)
+		return &corrupted, header, nil
+	}
+	return res, header, nil
+}
+
+// draw decides the delay and fault of one call.
+func (c *Client) draw(endpoint string) (time.Duration, Fault) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.Calls++
+	if !c.enabled || (c.endpoints != nil && !c.endpoints[endpoint]) {
+		return 0, ""
+	}
+
+	var delay time.Duration
+	if c.opts.DelayRate > 0 && c.rng.Float64() < c.opts.DelayRate {
+		delay = c.opts.Delay
+		if c.opts.DelayJitter > 0 {
+			delay += time.Duration(c.rng.Int63n(int64(c.opts.DelayJitter)))
+		}
+		c.stats.Delayed++
+	}
+
+	p := c.rng.Float64()
+	for _, f := range []struct {
+		fault Fault
+		rate  float64
+	}{
+		{FaultRateLimit, c.opts.RateLimitRate},
+		{FaultServerError, c.opts.ServerErrorRate},
+		{FaultDrop, c.opts.DropRate},
+		{FaultMalformed, c.opts.MalformedRate},
+	} {
+		if p < f.rate {
+			c.stats.Faults[f.fault]++
+			return delay, f.fault
+		}
+		p -= f.rate
+	}
+	return delay, ""
+}
+
+// truncate cuts a payload in half so that decoding it fails.
+func truncate(data []byte) []byte {
+	if len(data) < 2 {
+		return []byte("{")
+	}
+	return append([]byte(nil), data[:len(data)/2]...)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,144 @@
+package chaos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeClient struct {
+	calls int
+}
+
+func (c *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.calls++
+	return &futures.ApiResponse{Code: "00000", Data: json.RawMessage(`{"orderId":"1"}`)}, &fasthttp.ResponseHeader{}, nil
+}
+
+func call(c *Client, endpoint string) (*futures.ApiResponse, error) {
+	res, _, err := c.CallAPI(context.Background(), "POST", endpoint, nil, nil, true)
+	return res, err
+}
+
+func TestClient_PassThrough(t *testing.T) {
+	next := &fakeClient{}
+	c := New(next, Options{Seed: 1})
+
+	res, err := call(c, "/api/v2/mix/order/place-order")
+	require.NoError(t, err)
+	assert.Equal(t, "00000", res.Code)
+	assert.Equal(t, 1, next.calls)
+	assert.Equal(t, 1, c.Stats().Calls)
+}
+
+func TestClient_Faults(t *testing.T) {
+	cases := []struct {
+		opts      Options
+		fault     Fault
+		forwarded bool
+	}{
+		{Options{RateLimitRate: 1}, FaultRateLimit, false},
+		{Options{ServerErrorRate: 1}, FaultServerError, false},
+		{Options{DropRate: 1}, FaultDrop, true},
+		{Options{MalformedRate: 1}, FaultMalformed, true},
+	}
+	for _, tc := range cases {
+		t.Run(string(tc.fault), func(t *testing.T) {
+			next := &fakeClient{}
+			var faults []Fault
+			tc.opts.Seed = 1
+			tc.opts.OnFault = func(f Fault, endpoint string) { faults = append(faults, f) }
+			c := New(next, tc.opts)
+
+			res, err := call(c, "/api/v2/mix/order/place-order")
+			assert.Equal(t, tc.forwarded, next.calls == 1)
+			assert.Equal(t, []Fault{tc.fault}, faults)
+			assert.Equal(t, 1, c.Stats().Faults[tc.fault])
+
+			switch tc.fault {
+			case FaultRateLimit, FaultServerError:
+				var apiErr *types.APIError
+				require.ErrorAs(t, err, &apiErr)
+				if tc.fault == FaultRateLimit {
+					assert.Equal(t, int64(429), apiErr.Code)
+				} else {
+					assert.GreaterOrEqual(t, apiErr.Code, int64(500))
+				}
+			case FaultDrop:
+				assert.True(t, errors.Is(err, ErrInjected))
+				var faultErr *FaultError
+				require.ErrorAs(t, err, &faultErr)
+				assert.True(t, faultErr.Timeout())
+			case FaultMalformed:
+				require.NoError(t, err)
+				var v map[string]string
+				assert.Error(t, json.Unmarshal(res.Data, &v))
+			}
+		})
+	}
+}
+
+func TestClient_Rates(t *testing.T) {
+	c := New(&fakeClient{}, Options{Seed: 42, RateLimitRate: 0.2, ServerErrorRate: 0.1})
+	for i := 0; i < 2000; i++ {
+		_, _ = call(c, "/api/v2/mix/market/ticker")
+	}
+	stats := c.Stats()
+	assert.Equal(t, 2000, stats.Calls)
+	assert.InDelta(t, 400, stats.Faults[FaultRateLimit], 80)
+	assert.InDelta(t, 200, stats.Faults[FaultServerError], 60)
+
+	// The same seed injects the same sequence
+	a := New(&fakeClient{}, Options{Seed: 7, DropRate: 0.5})
+	b := New(&fakeClient{}, Options{Seed: 7, DropRate: 0.5})
+	for i := 0; i < 50; i++ {
+		_, errA := call(a, "/x")
+		_, errB := call(b, "/x")
+		assert.Equal(t, errA == nil, errB == nil)
+	}
+}
+
+func TestClient_EndpointsAndEnabled(t *testing.T) {
+	c := New(&fakeClient{}, Options{Seed: 1, ServerErrorRate: 1, Endpoints: []string{"/api/v2/mix/order/place-order"}})
+
+	_, err := call(c, "/api/v2/mix/market/ticker")
+	assert.NoError(t, err)
+	_, err = call(c, "/api/v2/mix/order/place-order")
+	assert.Error(t, err)
+
+	c.SetEnabled(false)
+	_, err = call(c, "/api/v2/mix/order/place-order")
+	assert.NoError(t, err)
+}
+
+func TestClient_Delay(t *testing.T) {
+	c := New(&fakeClient{}, Options{Seed: 1, Delay: time.Second, DelayJitter: time.Second, DelayRate: 1})
+	var slept []time.Duration
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+
+	_, err := call(c, "/x")
+	require.NoError(t, err)
+	require.Len(t, slept, 1)
+	assert.GreaterOrEqual(t, slept[0], time.Second)
+	assert.Less(t, slept[0], 2*time.Second)
+	assert.Equal(t, 1, c.Stats().Delayed)
+
+	// The real sleep gives up when the context ends
+	c.sleep = sleep
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = c.CallAPI(ctx, "GET", "/x", nil, nil, false)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -33,7 +33,6 @@ import (
 	"net/http"
 	"os"
 	"time"
-
 	// NOTE: Subdirectory package imports removed to avoid import cycles
 	// Factory methods will be implemented using interface{} returns
 )
@@ -63,12 +62,31 @@ type Client struct {
 	UserAgent  string
 	fastClient *fasthttp.Client
 
+	// Environment selects production or demo trading
+	environment common.Environment
+
+	// Locale selects the language of API error messages
+	locale common.Locale
+
+	// ChannelCode identifies the broker or channel for fee rebates
+	channelCode string
+
 	// Debugging and logging
-	Debug  bool
-	Logger zerolog.Logger
+	Debug      bool
+	Logger     common.Logger
+	logSampler common.Sampler
 
 	// Request signing
 	signer *common.Signer
+
+	// Retries shared with other clients; nil allows every retry
+	retryBudget *common.RetryBudget
+
+	// Time source of retry backoffs and latency measurements
+	clock common.Clock
+
+	// Rejects mutating requests with a *common.ReadOnlyError
+	readOnly bool
 }
 
 // NewClient initializes a new Bitget futures API client with the provided credentials.
@@ -86,8 +104,8 @@ type Client struct {
 //
 //	client := NewClient("your_api_key", "your_secret_key", "your_passphrase")
 //	candles, err := client.NewCandlestickService().Symbol("BTCUSDT").Do(ctx)
-func NewClient(apiKey, secretKey, passphrase string) *Client {
-	return &Client{
+func NewClient(apiKey, secretKey, passphrase string, opts ...ClientOption) *Client {
+	c := &Client{
 		apiKey:     apiKey,
 		secretKey:  secretKey,
 		passphrase: passphrase,
@@ -95,10 +113,140 @@ func NewClient(apiKey, secretKey, passphrase string) *Client {
 		BaseURL:    getApiEndpoint(),
 		UserAgent:  "Bitget/golang",
 		fastClient: &fasthttp.Client{},
-		Logger:     zerolog.New(os.Stderr).With().Timestamp().Logger(),
+		clock:      common.SystemClock(),
+		Logger:     common.NewZerologLogger(zerolog.New(os.Stderr).Level(zerolog.InfoLevel).With().Timestamp().Logger()),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Environment selects production or demo trading hosts. See common.Environment.
+type Environment = common.Environment
+
+// Environment presets.
+const (
+	Production = common.Production
+	Demo       = common.Demo
+	Testnet    = common.Testnet
+)
+
+// ClientOption configures a Client in NewClient.
+type ClientOption func(*Client)
+
+// WithEnvironment selects the REST base URL, the WebSocket URLs used by
+// NewWebSocketManager and, for Demo, adds the demo trading header to every request:
+//
+//	client := futures.NewClient(apiKey, secretKey, passphrase, futures.WithEnvironment(futures.Demo))
+func WithEnvironment(env Environment) ClientOption {
+	return func(c *Client) {
+		c.environment = env
+		c.BaseURL = env.RESTURL()
+	}
+}
+
+// WithLocale requests API error messages in the given language; APIError.Locale
+// reports it back. Defaults to common.LocaleEnglish:
+//
+//	client := futures.NewClient(apiKey, secretKey, passphrase, futures.WithLocale(common.LocaleChinese))
+func WithLocale(locale common.Locale) ClientOption {
+	return func(c *Client) {
+		c.locale = locale
+	}
+}
+
+// WithUserAgentSuffix appends suffix, e.g. "mybot/2.1", to the User-Agent header.
+func WithUserAgentSuffix(suffix string) ClientOption {
+	return func(c *Client) {
+		c.UserAgent = common.UserAgent(c.UserAgent, suffix)
+	}
+}
+
+// WithChannelCode sends the broker or channel code with every request, as required
+// for partner fee rebates.
+func WithChannelCode(code string) ClientOption {
+	return func(c *Client) {
+		c.channelCode = code
+	}
+}
+
+// WithIdleConnTimeout closes idle REST connections after d instead of the fasthttp
+// default of 10 seconds, keeping connections opened by WarmUp usable for longer.
+func WithIdleConnTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.fastClient.MaxIdleConnDuration = d
+	}
+}
+
+// WithRetryBudget makes retries of transient failures take a token from budget, which
+// can be shared with other REST and WebSocket clients. A request whose retry is refused
+// fails with its last error wrapped in common.ErrRetryBudgetExhausted.
+func WithRetryBudget(budget *common.RetryBudget) ClientOption {
+	return func(c *Client) {
+		c.retryBudget = budget
+	}
+}
+
+// WithClock replaces the time source of retry backoffs and latency measurements, and
+// of the WebSocket clients created by NewWebSocketManager, e.g. with a
+// common.FakeClock in tests.
+func WithClock(clock common.Clock) ClientOption {
+	return func(c *Client) {
+		c.clock = common.ClockOrSystem(clock)
 	}
 }
 
+// WithReadOnly rejects every mutating request, i.e. anything but GET, with a
+// *common.ReadOnlyError before it is signed or sent. Analytics and reporting
+// deployments can share code with trading ones while being unable to place, modify or
+// cancel orders, change leverage or move funds, even with over-permissioned keys:
+//
+//	client := futures.NewClient(apiKey, secretKey, passphrase, futures.WithReadOnly())
+//	_, err := trading.NewCreateOrderService(client)...Do(ctx)
+//	errors.Is(err, common.ErrReadOnly) // true
+func WithReadOnly() ClientOption {
+	return func(c *Client) {
+		c.readOnly = true
+	}
+}
+
+// ReadOnly reports whether the client rejects mutating requests, see WithReadOnly.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// Locale returns the language API error messages are requested in.
+func (c *Client) Locale() common.Locale {
+	return c.locale.OrDefault()
+}
+
+// Environment returns the environment the client was created for.
+func (c *Client) Environment() Environment {
+	return c.environment
+}
+
+// SetLogger replaces the client logger; use common.NewZerologLogger, NewSlogLogger or
+// NewZapLogger to adapt an existing logger. Requests and responses are logged at debug
+// level, retries at warn level and failures at error level, so the logger level decides
+// how verbose the client is. Credentials, signatures and secret-like body fields are
+// redacted using the same rules as the UTA client. Passing nil disables logging.
+func (c *Client) SetLogger(logger common.Logger) *Client {
+	if logger == nil {
+		logger = common.NopLogger()
+	}
+	c.Logger = logger
+	return c
+}
+
+// SetLogSampler samples the debug-level request and response logs, e.g.
+// common.SampleEvery(100) to log every 100th request. Warnings and errors are never
+// sampled. Pass nil to log every request.
+func (c *Client) SetLogSampler(sampler common.Sampler) *Client {
+	c.logSampler = sampler
+	return c
+}
+
 // callAPI sends an HTTP request to the specified Bitget API endpoint with automatic retry logic.
 // It handles request signing, authentication headers, and error retry for transient failures.
 //
@@ -115,6 +263,15 @@ func NewClient(apiKey, secretKey, passphrase string) *Client {
 func (c *Client) CallAPI(ctx context.Context, method string, endpoint string, queryParams url.Values, body []byte, sign bool) (*client.ApiResponse, *fasthttp.ResponseHeader, error) {
 	const maxRetries = 3
 	var backoff = 1 * time.Second
+	clock := common.ClockOrSystem(c.clock)
+
+	if err := common.CheckReadOnly(c.readOnly, method, endpoint); err != nil {
+		c.Logger.Warn("Rejected mutating request on read-only client", "method", method, "endpoint", endpoint)
+		return nil, nil, err
+	}
+
+	// Sample once per call so a request and its response are logged together
+	logDebug := common.DebugEnabled(c.Logger) && (c.logSampler == nil || c.logSampler.Sample())
 
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		req := fasthttp.AcquireRequest()
@@ -134,13 +291,21 @@ func (c *Client) CallAPI(ctx context.Context, method string, endpoint string, qu
 			req.Header.Set("Content-Type", "application/json")
 		}
 
+		if c.environment.IsDemo() {
+			req.Header.Set(common.DemoTradingHeader, "1")
+		}
+		req.Header.Set(common.LocaleHeader, string(c.Locale()))
+		req.Header.SetUserAgent(c.UserAgent)
+		if c.channelCode != "" {
+			req.Header.Set(common.ChannelCodeHeader, c.channelCode)
+		}
+
 		// Sign the request if needed
 		if sign {
 			ts := common.TimestampMs()
 			req.Header.Set("ACCESS-TIMESTAMP", ts)
 			req.Header.Set("ACCESS-KEY", c.apiKey)
 			req.Header.Set("ACCESS-PASSPHRASE", c.passphrase)
-			req.Header.Set("locale", "en-US")
 
 			var reqParamStr string
 			if method == "GET" {
@@ -152,6 +317,18 @@ func (c *Client) CallAPI(ctx context.Context, method string, endpoint string, qu
 			req.Header.Set("ACCESS-SIGN", sign)
 		}
 
+		if logDebug {
+			c.Logger.Debug("Making futures API request",
+				"method", method,
+				"endpoint", endpoint,
+				"query", common.RedactQuery(queryParams),
+				"body", common.RedactBody(body),
+				"headers", common.RedactHeaders(requestHeaders(req)),
+				"signed", sign,
+				"attempt", attempt+1)
+		}
+		start := clock.Now()
+
 		// Execute request
 		done := make(chan error, 1)
 		go func() {
@@ -161,8 +338,12 @@ func (c *Client) CallAPI(ctx context.Context, method string, endpoint string, qu
 
 		select {
 		case <-ctx.Done():
-			fasthttp.ReleaseRequest(req)
-			fasthttp.ReleaseResponse(resp)
+			// DoTimeout still uses req and resp; release them once it returns
+			go func() {
+				<-done
+				fasthttp.ReleaseRequest(req)
+				fasthttp.ReleaseResponse(resp)
+			}()
 			return nil, nil, ctx.Err()
 		case err := <-done:
 			if err != nil {
@@ -171,27 +352,61 @@ func (c *Client) CallAPI(ctx context.Context, method string, endpoint string, qu
 					fasthttp.ReleaseRequest(req)
 					fasthttp.ReleaseResponse(resp)
 					if attempt == maxRetries-1 {
+						c.Logger.Error("HTTP request failed", "error", err, "endpoint", endpoint, "attempt", attempt+1)
 						return nil, nil, err
 					}
-					time.Sleep(backoff)
+					if !c.retryBudget.Allow() {
+						c.Logger.Error("HTTP request failed, retry budget exhausted", "error", err, "endpoint", endpoint, "attempt", attempt+1)
+						return nil, nil, fmt.Errorf("%w: %w", common.ErrRetryBudgetExhausted, err)
+					}
+					c.Logger.Warn("Retrying futures API request",
+						"error", err,
+						"endpoint", endpoint,
+						"attempt", attempt+1,
+						"backoff", backoff)
+					// Stop waiting when the caller gives up, e.g. on an order deadline
+					select {
+					case <-ctx.Done():
+						return nil, nil, ctx.Err()
+					case <-clock.After(backoff):
+					}
 					backoff *= 2
 					continue
 				}
 
 				// Return non-retryable errors immediately
+				c.Logger.Error("HTTP request failed", "error", err, "endpoint", endpoint)
 				fasthttp.ReleaseRequest(req)
 				fasthttp.ReleaseResponse(resp)
 				return nil, nil, err
 			}
 
+			if logDebug {
+				c.Logger.Debug("Received futures API response",
+					"endpoint", endpoint,
+					"status_code", resp.StatusCode(),
+					"latency", clock.Now().Sub(start),
+					"body", common.RedactBody(resp.Body()))
+			}
+
 			// Process response
 			if resp.StatusCode() >= http.StatusBadRequest {
 				apiErr := &types.APIError{}
 				if err := jsoniter.Unmarshal(resp.Body(), apiErr); err != nil {
+					c.Logger.Error("Failed to unmarshal API error response",
+						"error", err,
+						"status_code", resp.StatusCode(),
+						"response_body", common.RedactBody(resp.Body()))
 					fasthttp.ReleaseRequest(req)
 					fasthttp.ReleaseResponse(resp)
 					return nil, nil, fmt.Errorf("error parsing API response: %w", err)
 				}
+				apiErr.Locale = string(c.Locale())
+				c.Logger.Error("API returned error",
+					"endpoint", endpoint,
+					"status_code", resp.StatusCode(),
+					"error_code", apiErr.Code,
+					"error_message", apiErr.Message)
 				fasthttp.ReleaseRequest(req)
 				fasthttp.ReleaseResponse(resp)
 				return nil, nil, apiErr
@@ -214,6 +429,15 @@ func (c *Client) CallAPI(ctx context.Context, method string, endpoint string, qu
 	return nil, nil, fmt.Errorf("max retries exceeded")
 }
 
+// requestHeaders collects the request headers for logging.
+func requestHeaders(req *fasthttp.Request) map[string]string {
+	headers := make(map[string]string)
+	req.Header.VisitAll(func(key, value []byte) {
+		headers[string(key)] = string(value)
+	})
+	return headers
+}
+
 // isRetryableError determines if an error is transient and worth retrying.
 // Returns true for network timeouts, connection errors, and other temporary failures.
 func isRetryableError(err error) bool {
@@ -274,7 +498,7 @@ func (c *Client) GetUrl(endpoint string) string {
 //   result, err := positions.ProductType("USDT-FUTURES").Do(ctx)
 //
 // This approach provides:
-// - Strong type safety 
+// - Strong type safety
 // - No import cycles
 // - Clear package organization
 // - Better IDE support with auto-completion
@@ -0,0 +1,172 @@
+// Package coalesce shares identical concurrent GET requests between callers.
+//
+// Several strategies in one process often poll the same endpoints (all tickers,
+// account list, positions) at about the same time. A Coalescer wraps the client so
+// that callers asking for the same endpoint and query while a request is in flight, or
+// within a short window after it completed, receive its response instead of sending
+// their own:
+//
+//	client := coalesce.New(futuresClient, coalesce.Options{Window: 200 * time.Millisecond})
+//	tickers, err := market.NewAllTickersService(client).ProductType(productType).Do(ctx)
+//
+// Only GET requests are coalesced; orders and other writes always pass through. It can
+// be stacked with the guard package.
+package coalesce
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/valyala/fasthttp"
+)
+
+// Options configures a Coalescer.
+type Options struct {
+	// Window is how long a successful response is reused after it arrived. Defaults to
+	// 50 milliseconds; negative shares only requests still in flight.
+	Window time.Duration
+	// Endpoints restricts coalescing to these endpoints. Empty coalesces every GET.
+	Endpoints []string
+	// PublicOnly leaves signed (account) requests alone.
+	PublicOnly bool
+	// Clock measures the reuse window. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// Stats counts requests seen by a Coalescer.
+type Stats struct {
+	Sent   int64 // Requests forwarded to the wrapped client
+	Shared int64 // Requests answered with another caller's response
+}
+
+// Coalescer is a futures.ClientInterface that shares identical GET requests. Shared
+// responses are the same *futures.ApiResponse for every caller and must not be
+// modified; services only read them. It is safe for concurrent use.
+type Coalescer struct {
+	next      futures.ClientInterface
+	opts      Options
+	endpoints map[string]bool
+	clock     common.Clock
+
+	mu    sync.Mutex
+	calls map[string]*call
+	stats Stats
+}
+
+// call is one forwarded request and its outcome.
+type call struct {
+	done   chan struct{}
+	at     time.Time // Completion time
+	res    *futures.ApiResponse
+	header *fasthttp.ResponseHeader
+	err    error
+}
+
+// New wraps next with request coalescing.
+func New(next futures.ClientInterface, opts Options) *Coalescer {
+	if opts.Window == 0 {
+		opts.Window = 50 * time.Millisecond
+	}
+	c := &Coalescer{next: next, opts: opts, clock: common.ClockOrSystem(opts.Clock), calls: make(map[string]*call)}
+	if len(opts.Endpoints) > 0 {
+		c.endpoints = make(map[string]bool, len(opts.Endpoints))
+		for _, e := range opts.Endpoints {
+			c.endpoints[e] = true
+		}
+	}
+	return c
+}
+
+// CallAPI forwards the request, or waits for and returns the response of an identical
+// request already sent.
+func (c *Coalescer) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	if !c.eligible(method, endpoint, sign) {
+		return c.next.CallAPI(ctx, method, endpoint, query, body, sign)
+	}
+	key := endpoint + "?" + query.Encode()
+	if sign {
+		key += "#signed"
+	}
+
+	for {
+		c.mu.Lock()
+		if cl, ok := c.calls[key]; ok {
+			select {
+			case <-cl.done:
+				if cl.err == nil && c.clock.Now().Sub(cl.at) < c.opts.Window {
+					c.stats.Shared++
+					c.mu.Unlock()
+					return cl.res, cl.header, nil
+				}
+				delete(c.calls, key)
+			default:
+				c.stats.Shared++
+				c.mu.Unlock()
+				select {
+				case <-ctx.Done():
+					return nil, nil, ctx.Err()
+				case <-cl.done:
+				}
+				if isContextError(cl.err) && ctx.Err() == nil {
+					// The caller that sent the request gave up; send our own
+					continue
+				}
+				return cl.res, cl.header, cl.err
+			}
+		}
+
+		cl := &call{done: make(chan struct{})}
+		c.prune()
+		c.calls[key] = cl
+		c.stats.Sent++
+		c.mu.Unlock()
+
+		cl.res, cl.header, cl.err = c.next.CallAPI(ctx, method, endpoint, query, body, sign)
+
+		c.mu.Lock()
+		cl.at = c.clock.Now()
+		close(cl.done)
+		if (cl.err != nil || c.opts.Window < 0) && c.calls[key] == cl {
+			delete(c.calls, key)
+		}
+		c.mu.Unlock()
+		return cl.res, cl.header, cl.err
+	}
+}
+
+// Stats returns the request counters.
+func (c *Coalescer) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+func (c *Coalescer) eligible(method, endpoint string, sign bool) bool {
+	if method != "GET" || (sign && c.opts.PublicOnly) {
+		return false
+	}
+	return c.endpoints == nil || c.endpoints[endpoint]
+}
+
+// prune drops completed calls whose window has passed. Callers hold c.mu.
+func (c *Coalescer) prune() {
+	now := c.clock.Now()
+	for key, cl := range c.calls {
+		select {
+		case <-cl.done:
+			if now.Sub(cl.at) >= c.opts.Window {
+				delete(c.calls, key)
+			}
+		default:
+		}
+	}
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
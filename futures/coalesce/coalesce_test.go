@@ -0,0 +1,149 @@
+package coalesce
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeClient struct {
+	calls   atomic.Int64
+	release chan struct{} // When set, requests block until it is closed
+	err     error
+}
+
+func (c *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.calls.Add(1)
+	if c.release != nil {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-c.release:
+		}
+	}
+	if c.err != nil {
+		return nil, nil, c.err
+	}
+	return &futures.ApiResponse{Code: "00000", Data: []byte(`[{"symbol":"BTCUSDT","lastPr":"65000"}]`)}, &fasthttp.ResponseHeader{}, nil
+}
+
+func newTestCoalescer(next *fakeClient, opts Options) (*Coalescer, *common.FakeClock) {
+	clock := common.NewFakeClock(time.UnixMilli(1700000000000))
+	opts.Clock = clock
+	return New(next, opts), clock
+}
+
+func TestCoalescer_SharesInFlight(t *testing.T) {
+	next := &fakeClient{release: make(chan struct{})}
+	c, _ := newTestCoalescer(next, Options{})
+
+	const callers = 8
+	var wg sync.WaitGroup
+	results := make(chan string, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tickers, err := market.NewAllTickersService(c).ProductType(futures.ProductTypeUSDTFutures).Do(context.Background())
+			if assert.NoError(t, err) {
+				results <- tickers[0].LastPr
+			}
+		}()
+	}
+	require.Eventually(t, func() bool { return c.Stats().Sent+c.Stats().Shared == callers }, time.Second, time.Millisecond)
+	close(next.release)
+	wg.Wait()
+	close(results)
+
+	assert.Equal(t, int64(1), next.calls.Load())
+	assert.Equal(t, Stats{Sent: 1, Shared: callers - 1}, c.Stats())
+	for price := range results {
+		assert.Equal(t, "65000", price)
+	}
+}
+
+func TestCoalescer_Window(t *testing.T) {
+	next := &fakeClient{}
+	c, clock := newTestCoalescer(next, Options{Window: 100 * time.Millisecond})
+	ctx := context.Background()
+	query := url.Values{"productType": {"USDT-FUTURES"}}
+
+	_, _, err := c.CallAPI(ctx, "GET", futures.EndpointAllTickers, query, nil, false)
+	require.NoError(t, err)
+	clock.Advance(50 * time.Millisecond)
+	_, _, err = c.CallAPI(ctx, "GET", futures.EndpointAllTickers, query, nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), next.calls.Load(), "reused within the window")
+
+	_, _, _ = c.CallAPI(ctx, "GET", futures.EndpointAllTickers, url.Values{"productType": {"COIN-FUTURES"}}, nil, false)
+	assert.Equal(t, int64(2), next.calls.Load(), "a different query is a different request")
+
+	clock.Advance(100 * time.Millisecond)
+	_, _, _ = c.CallAPI(ctx, "GET", futures.EndpointAllTickers, query, nil, false)
+	assert.Equal(t, int64(3), next.calls.Load(), "expired")
+	assert.Len(t, c.calls, 1, "expired calls are pruned")
+}
+
+func TestCoalescer_PassThrough(t *testing.T) {
+	next := &fakeClient{}
+	c, _ := newTestCoalescer(next, Options{Endpoints: []string{futures.EndpointAllTickers}, PublicOnly: true})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		c.CallAPI(ctx, "POST", futures.EndpointAllTickers, nil, []byte(`{}`), true)
+		c.CallAPI(ctx, "GET", futures.EndpointAllTickers, nil, nil, true)
+		c.CallAPI(ctx, "GET", futures.EndpointServerTime, nil, nil, false)
+	}
+	assert.Equal(t, int64(6), next.calls.Load())
+	assert.Zero(t, c.Stats().Shared)
+}
+
+func TestCoalescer_Errors(t *testing.T) {
+	next := &fakeClient{err: errors.New("boom")}
+	c, _ := newTestCoalescer(next, Options{})
+	ctx := context.Background()
+
+	_, _, err := c.CallAPI(ctx, "GET", futures.EndpointAllTickers, nil, nil, false)
+	assert.EqualError(t, err, "boom")
+	next.err = nil
+	_, _, err = c.CallAPI(ctx, "GET", futures.EndpointAllTickers, nil, nil, false)
+	assert.NoError(t, err, "failures are not reused")
+	assert.Equal(t, int64(2), next.calls.Load())
+}
+
+func TestCoalescer_LeaderCancelled(t *testing.T) {
+	next := &fakeClient{release: make(chan struct{})}
+	c, _ := newTestCoalescer(next, Options{})
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error)
+	go func() {
+		_, _, err := c.CallAPI(leaderCtx, "GET", futures.EndpointAllTickers, nil, nil, false)
+		leaderErr <- err
+	}()
+	require.Eventually(t, func() bool { return c.Stats().Sent == 1 }, time.Second, time.Millisecond)
+
+	waiterErr := make(chan error)
+	go func() {
+		_, _, err := c.CallAPI(context.Background(), "GET", futures.EndpointAllTickers, nil, nil, false)
+		waiterErr <- err
+	}()
+	require.Eventually(t, func() bool { return c.Stats().Shared == 1 }, time.Second, time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-leaderErr, context.Canceled)
+	require.Eventually(t, func() bool { return c.Stats().Sent == 2 }, time.Second, time.Millisecond)
+	close(next.release)
+	assert.NoError(t, <-waiterErr, "the waiter sends its own request")
+}
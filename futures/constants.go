@@ -2,6 +2,13 @@ package futures
 
 // API Endpoints - All Bitget Futures API v2 endpoints centralized
 const (
+	// Public Endpoints
+	EndpointServerTime    = "/api/v2/public/time"         // Get server time
+	EndpointAnnouncements = "/api/v2/public/annoucements" // Get announcements (sic)
+
+	// User Endpoints
+	EndpointAPIKeyInfo = "/api/v2/spot/account/info" // Get API key owner and permissions
+
 	// Account Management Endpoints
 	EndpointAccountInfo      = "/api/v2/mix/account/account"            // Get single account
 	EndpointAccountList      = "/api/v2/mix/account/accounts"           // Get all accounts
@@ -0,0 +1,13 @@
+package copytrading
+
+// Clone returns an independent copy of s and its parameters.
+func (s *TraderCurrentOrdersService) Clone() *TraderCurrentOrdersService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *TraderListService) Clone() *TraderListService {
+	c := *s
+	return &c
+}
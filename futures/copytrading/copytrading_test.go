@@ -0,0 +1,81 @@
+package copytrading
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+type fakeClient struct {
+	pages    map[string]string
+	requests []url.Values
+}
+
+func (f *fakeClient) CallAPI(_ context.Context, _ string, endpoint string, query url.Values, _ []byte, sign bool) (*ApiResponse, *fasthttp.ResponseHeader, error) {
+	f.requests = append(f.requests, query)
+	key := query.Get("pageNo") + query.Get("idLessThan")
+	return &ApiResponse{Code: "00000", Data: []byte(f.pages[key])}, &fasthttp.ResponseHeader{}, nil
+}
+
+func TestTraderListService_Do(t *testing.T) {
+	t.Run("bare array", func(t *testing.T) {
+		c := &fakeClient{pages: map[string]string{"2": `[{"traderId":"t1","traderName":"alpha","roi":"35.2","winRate":"61"}]`}}
+
+		traders, err := NewTraderListService(c).PageNo(2).PageSize(10).SortRule("roi").Do(context.Background())
+		require.NoError(t, err)
+		require.Len(t, traders, 1)
+		assert.Equal(t, "t1", traders[0].TraderId)
+		assert.Equal(t, "35.2", traders[0].ROI)
+		assert.Equal(t, "10", c.requests[0].Get("pageSize"))
+		assert.Equal(t, "roi", c.requests[0].Get("sortRule"))
+	})
+
+	t.Run("wrapped list", func(t *testing.T) {
+		c := &fakeClient{pages: map[string]string{"": `{"resultList":[{"traderId":"t2"}]}`}}
+
+		traders, err := NewTraderListService(c).Do(context.Background())
+		require.NoError(t, err)
+		require.Len(t, traders, 1)
+		assert.Equal(t, "t2", traders[0].TraderId)
+	})
+}
+
+func TestTraderListService_Iter(t *testing.T) {
+	c := &fakeClient{pages: map[string]string{
+		"1": `[{"traderId":"t1"},{"traderId":"t2"}]`,
+		"2": `[{"traderId":"t3"}]`,
+		"3": `[]`,
+	}}
+
+	traders, err := common.Collect(NewTraderListService(c).PageSize(2).Iter(context.Background()), 0)
+	require.NoError(t, err)
+	require.Len(t, traders, 3)
+	assert.Equal(t, "t3", traders[2].TraderId)
+}
+
+func TestTraderCurrentOrdersService(t *testing.T) {
+	_, err := NewTraderCurrentOrdersService(&fakeClient{}).Do(context.Background())
+	assert.EqualError(t, err, "productType is required")
+
+	c := &fakeClient{pages: map[string]string{
+		"":  `{"trackingList":[{"trackingNo":"9","traderId":"t1","symbol":"BTCUSDT","posSide":"long","openPriceAvg":"50000"}],"endId":"9"}`,
+		"9": `{"trackingList":[{"trackingNo":"5","traderId":"t1","symbol":"ETHUSDT","posSide":"short"}],"endId":"5"}`,
+		"5": `{"trackingList":[],"endId":""}`,
+	}}
+	orders, err := common.Collect(NewTraderCurrentOrdersService(c).
+		ProductType(ProductTypeUSDTFutures).
+		TraderId("t1").
+		Iter(context.Background()), 0)
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, "50000", orders[0].OpenPriceAvg)
+	assert.Equal(t, "short", orders[1].PosSide)
+	assert.Equal(t, "t1", c.requests[1].Get("traderId"))
+	assert.Equal(t, "9", c.requests[1].Get("idLessThan"))
+}
@@ -0,0 +1,169 @@
+package copytrading
+
+import (
+	"fmt"
+	"net/url"
+
+	jsoniter "github.com/json-iterator/go"
+	"golang.org/x/net/context"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// TraderCurrentOrdersService retrieves the copy orders that are still open, which mirror
+// the positions of the followed traders. Filter by TraderId to see a single trader.
+type TraderCurrentOrdersService struct {
+	c             ClientInterface
+	productType   ProductType
+	symbol        string
+	traderId      string
+	startTime     string
+	endTime       string
+	idLessThan    string
+	idGreaterThan string
+	limit         string
+}
+
+// ProductType sets the product type. Required parameter.
+func (s *TraderCurrentOrdersService) ProductType(productType ProductType) *TraderCurrentOrdersService {
+	s.productType = productType
+	return s
+}
+
+// Symbol filters by trading pair.
+func (s *TraderCurrentOrdersService) Symbol(symbol string) *TraderCurrentOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// TraderId filters by trader.
+func (s *TraderCurrentOrdersService) TraderId(traderId string) *TraderCurrentOrdersService {
+	s.traderId = traderId
+	return s
+}
+
+// StartTime sets the start of the range as a milliseconds timestamp string.
+func (s *TraderCurrentOrdersService) StartTime(startTime string) *TraderCurrentOrdersService {
+	s.startTime = startTime
+	return s
+}
+
+// EndTime sets the end of the range as a milliseconds timestamp string.
+func (s *TraderCurrentOrdersService) EndTime(endTime string) *TraderCurrentOrdersService {
+	s.endTime = endTime
+	return s
+}
+
+// IdLessThan returns orders older than the given tracking number (the previous EndId).
+func (s *TraderCurrentOrdersService) IdLessThan(id string) *TraderCurrentOrdersService {
+	s.idLessThan = id
+	return s
+}
+
+// IdGreaterThan returns orders newer than the given tracking number.
+func (s *TraderCurrentOrdersService) IdGreaterThan(id string) *TraderCurrentOrdersService {
+	s.idGreaterThan = id
+	return s
+}
+
+// Limit sets the page size. Default 20, maximum 50.
+func (s *TraderCurrentOrdersService) Limit(limit string) *TraderCurrentOrdersService {
+	s.limit = limit
+	return s
+}
+
+// Do executes the request.
+func (s *TraderCurrentOrdersService) Do(ctx context.Context) (*TraderCurrentOrdersResponse, error) {
+	if s.productType == "" {
+		return nil, fmt.Errorf("productType is required")
+	}
+
+	queryParams := url.Values{}
+	queryParams.Set("productType", string(s.productType))
+	if s.symbol != "" {
+		queryParams.Set("symbol", s.symbol)
+	}
+	if s.traderId != "" {
+		queryParams.Set("traderId", s.traderId)
+	}
+	if s.startTime != "" {
+		queryParams.Set("startTime", s.startTime)
+	}
+	if s.endTime != "" {
+		queryParams.Set("endTime", s.endTime)
+	}
+	if s.idLessThan != "" {
+		queryParams.Set("idLessThan", s.idLessThan)
+	}
+	if s.idGreaterThan != "" {
+		queryParams.Set("idGreaterThan", s.idGreaterThan)
+	}
+	if s.limit != "" {
+		queryParams.Set("limit", s.limit)
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointTraderCurrentOrders, queryParams, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result TraderCurrentOrdersResponse
+	if err := jsoniter.Unmarshal(res.Data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Iter iterates over all open copy orders, following EndId across pages.
+func (s *TraderCurrentOrdersService) Iter(ctx context.Context) *common.Iter[CopyOrder] {
+	return common.NewIter(ctx, func(ctx context.Context, cursor string) (common.Page[CopyOrder], error) {
+		page := *s
+		if cursor != "" {
+			page.idLessThan = cursor
+		}
+		res, err := page.Do(ctx)
+		if err != nil || res == nil {
+			return common.Page[CopyOrder]{}, err
+		}
+		return common.Page[CopyOrder]{Items: common.Values(res.TrackingList), Cursor: res.EndId}, nil
+	})
+}
+
+// TraderCurrentOrdersResponse is a page of open copy orders.
+type TraderCurrentOrdersResponse struct {
+	TrackingList []*CopyOrder `json:"trackingList"`
+
+	// End ID for pagination
+	EndId string `json:"endId"`
+}
+
+// CopyOrder is an open order copied from a trader.
+type CopyOrder struct {
+	// Tracking number of the copy order
+	TrackingNo string `json:"trackingNo"`
+
+	TraderId   string `json:"traderId"`
+	TraderName string `json:"traderName"`
+	Symbol     string `json:"symbol"`
+
+	// Position direction (long/short)
+	PosSide string `json:"posSide"`
+
+	// Margin mode (crossed/isolated)
+	MarginMode   string `json:"marginMode"`
+	MarginCoin   string `json:"marginCoin"`
+	OpenLeverage string `json:"openLeverage"`
+
+	// Average opening price, size and fee
+	OpenPriceAvg string `json:"openPriceAvg"`
+	OpenSize     string `json:"openSize"`
+	OpenFee      string `json:"openFee"`
+
+	// Opening order and time in milliseconds
+	OpenOrderId string `json:"openOrderId"`
+	OpenTime    string `json:"openTime"`
+
+	// Preset take-profit and stop-loss prices
+	PresetStopSurplusPrice string `json:"presetStopSurplusPrice"`
+	PresetStopLossPrice    string `json:"presetStopLossPrice"`
+}
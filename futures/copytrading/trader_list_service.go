@@ -0,0 +1,128 @@
+package copytrading
+
+import (
+	"bytes"
+	"net/url"
+	"strconv"
+
+	jsoniter "github.com/json-iterator/go"
+	"golang.org/x/net/context"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// TraderListService retrieves the elite traders available for copy trading, one page at
+// a time.
+type TraderListService struct {
+	c        ClientInterface
+	pageNo   int
+	pageSize int
+	sortRule string
+}
+
+// PageNo sets the 1-based page number. Default 1.
+func (s *TraderListService) PageNo(pageNo int) *TraderListService {
+	s.pageNo = pageNo
+	return s
+}
+
+// PageSize sets the number of traders per page. Default 20.
+func (s *TraderListService) PageSize(pageSize int) *TraderListService {
+	s.pageSize = pageSize
+	return s
+}
+
+// SortRule sets the ranking used for the list, passed through to the API
+// (e.g. "roi", "profit", "winRate").
+func (s *TraderListService) SortRule(sortRule string) *TraderListService {
+	s.sortRule = sortRule
+	return s
+}
+
+// Do executes the request.
+func (s *TraderListService) Do(ctx context.Context) ([]*Trader, error) {
+	queryParams := url.Values{}
+	if s.pageNo > 0 {
+		queryParams.Set("pageNo", strconv.Itoa(s.pageNo))
+	}
+	if s.pageSize > 0 {
+		queryParams.Set("pageSize", strconv.Itoa(s.pageSize))
+	}
+	if s.sortRule != "" {
+		queryParams.Set("sortRule", s.sortRule)
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointTraderList, queryParams, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// The list is returned either bare or wrapped in resultList depending on the account
+	data := bytes.TrimSpace(res.Data)
+	if len(data) > 0 && data[0] == '[' {
+		var traders []*Trader
+		if err := jsoniter.Unmarshal(data, &traders); err != nil {
+			return nil, err
+		}
+		return traders, nil
+	}
+	var wrapped struct {
+		ResultList []*Trader `json:"resultList"`
+	}
+	if err := jsoniter.Unmarshal(data, &wrapped); err != nil {
+		return nil, err
+	}
+	return wrapped.ResultList, nil
+}
+
+// Iter iterates over all pages of the trader list, starting at PageNo.
+func (s *TraderListService) Iter(ctx context.Context) *common.Iter[Trader] {
+	first := s.pageNo
+	if first <= 0 {
+		first = 1
+	}
+	return common.NewIter(ctx, func(ctx context.Context, cursor string) (common.Page[Trader], error) {
+		page := *s
+		page.pageNo = first
+		if cursor != "" {
+			page.pageNo, _ = strconv.Atoi(cursor)
+		}
+		traders, err := page.Do(ctx)
+		if err != nil {
+			return common.Page[Trader]{}, err
+		}
+		return common.Page[Trader]{Items: common.Values(traders), Cursor: strconv.Itoa(page.pageNo + 1)}, nil
+	})
+}
+
+// Trader is an elite trader profile with performance statistics.
+type Trader struct {
+	// Trader identifier, used to filter copy orders
+	TraderId string `json:"traderId"`
+
+	// Display name
+	TraderName string `json:"traderName"`
+
+	// Whether new followers are accepted ("yes"/"no")
+	CanTrace string `json:"canTrace"`
+
+	// Current and maximum number of followers
+	FollowerCount    string `json:"followerCount"`
+	MaxFollowerCount string `json:"maxFollowCount"`
+
+	// Return on investment in percent
+	ROI string `json:"roi"`
+
+	// Win rate in percent
+	WinRate string `json:"winRate"`
+
+	// Total profit of the trader and of all followers
+	TotalProfit         string `json:"totalProfit"`
+	TotalFollowerProfit string `json:"totalFollowerProfit"`
+
+	// Assets under management
+	AUM string `json:"aum"`
+
+	// Days since the trader started copy trading
+	TradeDays string `json:"tradeDays"`
+}
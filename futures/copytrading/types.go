@@ -0,0 +1,43 @@
+// Package copytrading provides read-only access to Bitget's futures copy-trading data:
+// the elite trader list and the orders a followed trader currently holds open.
+//
+// Bitget serves this data from the follower API, so requests are signed with the
+// client's credentials even though nothing is modified.
+package copytrading
+
+import (
+	"github.com/khanbekov/go-bitget/common/client"
+)
+
+// Re-export common types to avoid importing futures package
+type (
+	ClientInterface = client.ClientInterface
+	ApiResponse     = client.ApiResponse
+)
+
+// Futures-specific enums (duplicated to avoid import cycle)
+type ProductType string
+
+const (
+	ProductTypeUSDTFutures ProductType = "USDT-FUTURES"
+	ProductTypeCoinFutures ProductType = "COIN-FUTURES"
+	ProductTypeUSDCFutures ProductType = "USDC-FUTURES"
+)
+
+// API Endpoints for copy-trading data
+const (
+	EndpointTraderList          = "/api/v2/copy/mix-follower/query-traders"        // Elite trader list
+	EndpointTraderCurrentOrders = "/api/v2/copy/mix-follower/query-current-orders" // Open copy orders of a trader
+)
+
+// Service Constructor Functions
+
+// NewTraderListService creates a new trader list service.
+func NewTraderListService(client ClientInterface) *TraderListService {
+	return &TraderListService{c: client}
+}
+
+// NewTraderCurrentOrdersService creates a new trader current orders service.
+func NewTraderCurrentOrdersService(client ClientInterface) *TraderCurrentOrdersService {
+	return &TraderCurrentOrdersService{c: client}
+}
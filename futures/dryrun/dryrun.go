@@ -0,0 +1,824 @@
+// Package dryrun simulates order entry so that a strategy, its guards and the trackers
+// can run end to end without sending real orders.
+//
+// A Client wraps a futures.ClientInterface. Order placement, modification and
+// cancellation are answered locally with synthetic order IDs; every other write is
+// refused. The pending orders and the details of simulated orders are answered locally
+// too, other reads pass through to the exchange. With SimulateFills, orders are matched
+// against the live order book fed through HandleBook, and the resulting order updates,
+// position changes and fills are published into the trackers like the private channels
+// would:
+//
+//	orders := tracker.NewOrderTracker(tracker.Options{})
+//	positions := tracker.NewPositionTracker(tracker.Options{})
+//	client := dryrun.New(futuresClient, dryrun.Options{
+//		SimulateFills: true, Orders: orders, Positions: positions,
+//	})
+//	wsClient.SubscribeOrderBook5("BTCUSDT", "USDT-FUTURES", client.HandleBook)
+//
+//	_, err := trading.NewCreateOrderService(client).Symbol("BTCUSDT")...Do(ctx)
+//
+// The simulation is optimistic: a resting order fills as soon as the book trades
+// through its price, queue position and the order's own market impact are ignored.
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/khanbekov/go-bitget/ws"
+	"github.com/valyala/fasthttp"
+)
+
+// ErrNotSimulated is returned for writes the dry run does not simulate, such as plan
+// orders or leverage changes. They are never sent to the exchange.
+var ErrNotSimulated = errors.New("dryrun: request not simulated")
+
+// Options configures a Client.
+type Options struct {
+	// SimulateFills matches orders against the books passed to HandleBook and
+	// UpdateBook. Without it, orders rest until they are canceled.
+	SimulateFills bool
+	// Orders receives the synthetic order updates. Optional.
+	Orders *tracker.OrderTracker
+	// Positions receives the positions built from simulated fills. Optional.
+	Positions *tracker.PositionTracker
+	// OnFill is called for every simulated fill. Optional.
+	OnFill func(Fill)
+	// Clock timestamps orders and fills. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// Fill is one simulated execution.
+type Fill struct {
+	OrderID    string
+	ClientOid  string
+	Symbol     string
+	Side       string
+	TradeSide  string
+	Price      float64
+	Size       float64
+	Maker      bool // The order rested on the book before it filled
+	ReduceOnly bool
+	Time       time.Time
+}
+
+// Stats counts simulated requests and executions.
+type Stats struct {
+	Placed   int
+	Modified int
+	Canceled int
+	Rejected int
+	Fills    int
+}
+
+// level is one price level of a simulated book.
+type level struct {
+	price, size float64
+}
+
+// book is the latest top of the order book of a symbol.
+type book struct {
+	bids, asks []level // Best first
+}
+
+// Client is a futures.ClientInterface that simulates order entry. It is safe for
+// concurrent use.
+type Client struct {
+	next  futures.ClientInterface
+	opts  Options
+	clock common.Clock
+
+	mu        sync.Mutex
+	seq       int64
+	orders    map[string]*tracker.Order // Open orders by ID
+	finished  map[string]tracker.Order  // Filled and canceled orders by ID
+	books     map[string]book
+	positions map[string]tracker.Position // By Position.Key
+	stats     Stats
+}
+
+// New wraps next with simulated order entry.
+func New(next futures.ClientInterface, opts Options) *Client {
+	return &Client{
+		next:      next,
+		opts:      opts,
+		clock:     common.ClockOrSystem(opts.Clock),
+		orders:    make(map[string]*tracker.Order),
+		finished:  make(map[string]tracker.Order),
+		books:     make(map[string]book),
+		positions: make(map[string]tracker.Position),
+	}
+}
+
+// CallAPI answers order entry requests and queries of simulated orders from the
+// simulation, refuses other writes and forwards the remaining reads.
+func (c *Client) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	var (
+		data any
+		err  error
+	)
+	switch {
+	case method == "GET" && endpoint == futures.EndpointPendingOrders:
+		data = c.pending(query.Get("symbol"))
+	case method == "GET" && endpoint == futures.EndpointOrderDetails && c.simulated(query):
+		data, err = c.details(query)
+	case method == "GET":
+		return c.next.CallAPI(ctx, method, endpoint, query, body, sign)
+	case endpoint == futures.EndpointPlaceOrder:
+		data, err = c.place(body)
+	case endpoint == futures.EndpointBatchPlaceOrder:
+		data, err = c.batchPlace(body)
+	case endpoint == futures.EndpointModifyOrder:
+		data, err = c.modify(body)
+	case endpoint == futures.EndpointCancelOrder:
+		data, err = c.cancel(body)
+	case endpoint == futures.EndpointBatchCancelOrders:
+		data, err = c.batchCancel(body)
+	case endpoint == futures.EndpointCancelAllOrders:
+		data, err = c.cancelAll(body)
+	default:
+		return nil, nil, fmt.Errorf("%w: %s %s", ErrNotSimulated, method, endpoint)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &futures.ApiResponse{Code: "00000", Msg: "success", RequestTime: c.clock.Now().UnixMilli(), Data: raw}, &fasthttp.ResponseHeader{}, nil
+}
+
+// Open returns the simulated open orders of symbol, or of every symbol when symbol is
+// empty, oldest first.
+func (c *Client) Open(symbol string) []tracker.Order {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.open(symbol)
+}
+
+// Position returns the simulated position of symbol on holdSide.
+func (c *Client) Position(symbol, holdSide string) (tracker.Position, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.positions[symbol+":"+holdSide]
+	return p, ok
+}
+
+// Stats returns the counters so far.
+func (c *Client) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// HandleBook is a ws.OnReceive for the books, books1, books5 and books15 channels.
+// Incremental updates of the full-depth channel are ignored.
+func (c *Client) HandleBook(message string) {
+	var msg ws.WebSocketMessage
+	if json.Unmarshal([]byte(message), &msg) != nil || msg.Arg.Symbol == "" {
+		return
+	}
+	if msg.Arg.Channel == ws.ChannelBooks && msg.Action != "snapshot" {
+		return
+	}
+	var books []ws.OrderBookData
+	if json.Unmarshal(msg.Data, &books) != nil || len(books) == 0 {
+		return
+	}
+	c.UpdateBook(msg.Arg.Symbol, books[len(books)-1])
+}
+
+// UpdateBook replaces the book of symbol and, with SimulateFills, fills the resting
+// orders it crosses.
+func (c *Client) UpdateBook(symbol string, data ws.OrderBookData) {
+	b := book{bids: levels(data.Bids), asks: levels(data.Asks)}
+	c.mu.Lock()
+	c.books[symbol] = b
+	var fills []Fill
+	var updates []tracker.Order
+	if c.opts.SimulateFills {
+		for _, o := range c.open(symbol) {
+			f, u := c.match(c.orders[o.OrderID], &b, true)
+			fills, updates = append(fills, f...), append(updates, u...)
+		}
+	}
+	positions := c.applyFills(fills)
+	c.mu.Unlock()
+	c.publish(updates, positions, fills)
+}
+
+// request is the body of a single order entry request. Batch entries use the same
+// fields and inherit Symbol.
+type request struct {
+	Symbol       string `json:"symbol"`
+	Side         string `json:"side"`
+	TradeSide    string `json:"tradeSide"`
+	OrderType    string `json:"orderType"`
+	Force        string `json:"force"`
+	Price        string `json:"price"`
+	Size         string `json:"size"`
+	ClientOid    string `json:"clientOid"`
+	ReduceOnly   string `json:"reduceOnly"`
+	OrderID      string `json:"orderId"`
+	NewClientOid string `json:"newClientOid"`
+	NewSize      string `json:"newSize"`
+	NewPrice     string `json:"newPrice"`
+
+	OrderList   []request                      `json:"orderList"`
+	OrderIDList []trading.BatchCancelOrderItem `json:"orderIdList"`
+}
+
+// orderInfo is the response entry of order entry requests.
+type orderInfo struct {
+	OrderID   string `json:"orderId"`
+	ClientOid string `json:"clientOid"`
+	ErrorMsg  string `json:"errorMsg,omitempty"`
+	ErrorCode string `json:"errorCode,omitempty"`
+}
+
+type batchResult struct {
+	SuccessList []orderInfo `json:"successList"`
+	FailureList []orderInfo `json:"failureList"`
+}
+
+func parseRequest(body []byte) (request, error) {
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		return req, reject("invalid request body: %v", err)
+	}
+	return req, nil
+}
+
+func (c *Client) place(body []byte) (any, error) {
+	req, err := parseRequest(body)
+	if err != nil {
+		return nil, err
+	}
+	info, err := c.submit(req)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (c *Client) batchPlace(body []byte) (any, error) {
+	req, err := parseRequest(body)
+	if err != nil {
+		return nil, err
+	}
+	res := batchResult{SuccessList: []orderInfo{}, FailureList: []orderInfo{}}
+	for _, entry := range req.OrderList {
+		entry.Symbol = req.Symbol
+		info, err := c.submit(entry)
+		if err != nil {
+			res.FailureList = append(res.FailureList, failure(entry.OrderID, entry.ClientOid, err))
+			continue
+		}
+		res.SuccessList = append(res.SuccessList, info)
+	}
+	return res, nil
+}
+
+// submit validates and books a new order, filling it at once when it is marketable.
+func (c *Client) submit(req request) (orderInfo, error) {
+	o, err := c.newOrder(req)
+	if err != nil {
+		c.mu.Lock()
+		c.stats.Rejected++
+		c.mu.Unlock()
+		return orderInfo{}, err
+	}
+
+	c.mu.Lock()
+	if o.ClientOid != "" && c.byClientOid(o.Symbol, o.ClientOid) != nil {
+		c.stats.Rejected++
+		c.mu.Unlock()
+		return orderInfo{}, reject("duplicate clientOid %s", o.ClientOid)
+	}
+	if o.OrderType == "market" && (!c.opts.SimulateFills || len(c.books[o.Symbol].bids)+len(c.books[o.Symbol].asks) == 0) {
+		c.stats.Rejected++
+		c.mu.Unlock()
+		return orderInfo{}, reject("no order book for %s to fill a market order", o.Symbol)
+	}
+	c.seq++
+	o.OrderID = "dry-" + strconv.FormatInt(c.seq, 10)
+	if o.ClientOid == "" {
+		o.ClientOid = o.OrderID
+	}
+	c.orders[o.OrderID] = o
+	c.stats.Placed++
+	updates := []tracker.Order{*o}
+
+	var fills []Fill
+	if c.opts.SimulateFills {
+		b := c.books[o.Symbol]
+		f, u := c.match(o, &b, false)
+		fills, updates = f, append(updates, u...)
+	}
+	positions := c.applyFills(fills)
+	info := orderInfo{OrderID: o.OrderID, ClientOid: o.ClientOid}
+	c.mu.Unlock()
+
+	c.publish(updates, positions, fills)
+	return info, nil
+}
+
+func (c *Client) newOrder(req request) (*tracker.Order, error) {
+	if req.Symbol == "" {
+		return nil, reject("symbol is required")
+	}
+	if req.Side != "buy" && req.Side != "sell" {
+		return nil, reject("invalid side %q", req.Side)
+	}
+	size, err := strconv.ParseFloat(req.Size, 64)
+	if err != nil || size <= 0 {
+		return nil, reject("invalid size %q", req.Size)
+	}
+	o := &tracker.Order{
+		ClientOid:  req.ClientOid,
+		Symbol:     req.Symbol,
+		Side:       req.Side,
+		TradeSide:  req.TradeSide,
+		OrderType:  req.OrderType,
+		Force:      strings.ToLower(req.Force),
+		Size:       size,
+		Status:     tracker.StatusLive,
+		ReduceOnly: req.ReduceOnly == "YES" || req.ReduceOnly == "yes",
+	}
+	switch req.OrderType {
+	case "limit":
+		o.Price, err = strconv.ParseFloat(req.Price, 64)
+		if err != nil || o.Price <= 0 {
+			return nil, reject("invalid price %q", req.Price)
+		}
+		if o.Force == "" {
+			o.Force = "gtc"
+		}
+	case "market":
+		o.Force = "ioc"
+	default:
+		return nil, reject("invalid order type %q", req.OrderType)
+	}
+	o.CreatedAt = c.clock.Now()
+	o.UpdatedAt = o.CreatedAt
+	return o, nil
+}
+
+func (c *Client) modify(body []byte) (any, error) {
+	req, err := parseRequest(body)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	o := c.find(req.Symbol, req.OrderID, req.ClientOid)
+	if o == nil {
+		c.mu.Unlock()
+		return nil, reject("order does not exist")
+	}
+	modified := *o
+	if req.NewSize != "" {
+		size, err := strconv.ParseFloat(req.NewSize, 64)
+		if err != nil || size <= modified.FilledSize {
+			c.mu.Unlock()
+			return nil, reject("invalid newSize %q", req.NewSize)
+		}
+		modified.Size = size
+	}
+	if req.NewPrice != "" {
+		price, err := strconv.ParseFloat(req.NewPrice, 64)
+		if err != nil || price <= 0 {
+			c.mu.Unlock()
+			return nil, reject("invalid newPrice %q", req.NewPrice)
+		}
+		modified.Price = price
+	}
+	if req.NewClientOid != "" {
+		modified.ClientOid = req.NewClientOid
+	}
+	modified.UpdatedAt = c.clock.Now()
+	*o = modified
+	c.stats.Modified++
+	updates := []tracker.Order{modified}
+
+	var fills []Fill
+	if c.opts.SimulateFills {
+		b := c.books[o.Symbol]
+		f, u := c.match(o, &b, false)
+		fills, updates = f, append(updates, u...)
+	}
+	positions := c.applyFills(fills)
+	info := orderInfo{OrderID: modified.OrderID, ClientOid: modified.ClientOid}
+	c.mu.Unlock()
+
+	c.publish(updates, positions, fills)
+	return info, nil
+}
+
+func (c *Client) cancel(body []byte) (any, error) {
+	req, err := parseRequest(body)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	o := c.find(req.Symbol, req.OrderID, req.ClientOid)
+	if o == nil {
+		c.mu.Unlock()
+		return nil, reject("order does not exist")
+	}
+	canceled := c.close(o, tracker.StatusCanceled)
+	c.mu.Unlock()
+
+	c.publish([]tracker.Order{canceled}, nil, nil)
+	return orderInfo{OrderID: canceled.OrderID, ClientOid: canceled.ClientOid}, nil
+}
+
+func (c *Client) batchCancel(body []byte) (any, error) {
+	req, err := parseRequest(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.OrderIDList) == 0 {
+		return c.cancelAll(body)
+	}
+	res := batchResult{SuccessList: []orderInfo{}, FailureList: []orderInfo{}}
+	var updates []tracker.Order
+	c.mu.Lock()
+	for _, item := range req.OrderIDList {
+		o := c.find(req.Symbol, item.OrderId, item.ClientOid)
+		if o == nil {
+			res.FailureList = append(res.FailureList, failure(item.OrderId, item.ClientOid, reject("order does not exist")))
+			continue
+		}
+		canceled := c.close(o, tracker.StatusCanceled)
+		updates = append(updates, canceled)
+		res.SuccessList = append(res.SuccessList, orderInfo{OrderID: canceled.OrderID, ClientOid: canceled.ClientOid})
+	}
+	c.mu.Unlock()
+
+	c.publish(updates, nil, nil)
+	return res, nil
+}
+
+func (c *Client) cancelAll(body []byte) (any, error) {
+	req, err := parseRequest(body)
+	if err != nil {
+		return nil, err
+	}
+	res := batchResult{SuccessList: []orderInfo{}, FailureList: []orderInfo{}}
+	var updates []tracker.Order
+	c.mu.Lock()
+	for _, o := range c.open(req.Symbol) {
+		canceled := c.close(c.orders[o.OrderID], tracker.StatusCanceled)
+		updates = append(updates, canceled)
+		res.SuccessList = append(res.SuccessList, orderInfo{OrderID: canceled.OrderID, ClientOid: canceled.ClientOid})
+	}
+	c.mu.Unlock()
+
+	c.publish(updates, nil, nil)
+	return res, nil
+}
+
+// pending renders the open orders like the pending orders endpoint.
+func (c *Client) pending(symbol string) any {
+	c.mu.Lock()
+	open := c.open(symbol)
+	c.mu.Unlock()
+	list := make([]map[string]string, 0, len(open))
+	for _, o := range open {
+		entry := render(o)
+		entry["status"] = string(o.Status)
+		list = append(list, entry)
+	}
+	return map[string]any{"entrustedList": list, "endId": ""}
+}
+
+// simulated reports whether an order details query refers to a simulated order.
+func (c *Client) simulated(query url.Values) bool {
+	if strings.HasPrefix(query.Get("orderId"), "dry-") {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.lookup(query.Get("symbol"), query.Get("orderId"), query.Get("clientOid"))
+	return ok
+}
+
+// details renders an open or finished order like the order details endpoint.
+func (c *Client) details(query url.Values) (any, error) {
+	c.mu.Lock()
+	o, ok := c.lookup(query.Get("symbol"), query.Get("orderId"), query.Get("clientOid"))
+	c.mu.Unlock()
+	if !ok {
+		return nil, reject("order does not exist")
+	}
+	detail := render(o)
+	detail["state"] = string(o.Status)
+	detail["reduceOnly"] = "NO"
+	if o.ReduceOnly {
+		detail["reduceOnly"] = "YES"
+	}
+	return detail, nil
+}
+
+// render returns the fields the order endpoints share.
+func render(o tracker.Order) map[string]string {
+	return map[string]string{
+		"symbol":     o.Symbol,
+		"orderId":    o.OrderID,
+		"clientOid":  o.ClientOid,
+		"side":       o.Side,
+		"tradeSide":  o.TradeSide,
+		"orderType":  o.OrderType,
+		"force":      o.Force,
+		"price":      common.FormatFloat(o.Price),
+		"size":       common.FormatFloat(o.Size),
+		"baseVolume": common.FormatFloat(o.FilledSize),
+		"priceAvg":   common.FormatFloat(o.AvgPrice),
+		"cTime":      strconv.FormatInt(o.CreatedAt.UnixMilli(), 10),
+		"uTime":      strconv.FormatInt(o.UpdatedAt.UnixMilli(), 10),
+	}
+}
+
+// match fills o against b, consuming the liquidity it takes. Resting orders fill at
+// their own price, new orders walk the book as takers. Whatever an ioc, fok or market
+// order cannot fill is canceled, and a post_only order that would take is canceled
+// instead. It returns the fills and the resulting order updates. Callers hold c.mu.
+func (c *Client) match(o *tracker.Order, b *book, resting bool) ([]Fill, []tracker.Order) {
+	opposite := &b.asks
+	crosses := func(price float64) bool { return o.OrderType == "market" || price <= o.Price }
+	if sells(o.Side, o.TradeSide) {
+		opposite = &b.bids
+		crosses = func(price float64) bool { return o.OrderType == "market" || price >= o.Price }
+	}
+
+	available := 0.0
+	for _, l := range *opposite {
+		if !crosses(l.price) {
+			break
+		}
+		available += l.size
+	}
+	switch {
+	case available == 0 && (o.Force == "gtc" || o.Force == "post_only"):
+		return nil, nil
+	case available > 0 && o.Force == "post_only" && !resting,
+		available < o.Remaining() && o.Force == "fok":
+		return nil, []tracker.Order{c.close(o, tracker.StatusCanceled)}
+	}
+
+	var fills []Fill
+	now := c.clock.Now()
+	for i := range *opposite {
+		l := &(*opposite)[i]
+		if o.Remaining() <= 0 || !crosses(l.price) {
+			break
+		}
+		size := min(l.size, o.Remaining())
+		if size <= 0 {
+			continue
+		}
+		l.size -= size
+		price := l.price
+		if resting {
+			price = o.Price
+		}
+		o.AvgPrice = (o.AvgPrice*o.FilledSize + price*size) / (o.FilledSize + size)
+		o.FilledSize += size
+		fills = append(fills, Fill{
+			OrderID: o.OrderID, ClientOid: o.ClientOid, Symbol: o.Symbol, Side: o.Side, TradeSide: o.TradeSide,
+			Price: price, Size: size, Maker: resting, ReduceOnly: o.ReduceOnly, Time: now,
+		})
+	}
+	if len(fills) == 0 {
+		return nil, []tracker.Order{c.close(o, tracker.StatusCanceled)}
+	}
+	c.stats.Fills += len(fills)
+	o.UpdatedAt = now
+
+	switch {
+	case o.Remaining() <= 0:
+		return fills, []tracker.Order{c.close(o, tracker.StatusFilled)}
+	case o.Force != "gtc" && o.Force != "post_only":
+		o.Status = tracker.StatusPartiallyFilled
+		return fills, []tracker.Order{*o, c.close(o, tracker.StatusCanceled)}
+	default:
+		o.Status = tracker.StatusPartiallyFilled
+		return fills, []tracker.Order{*o}
+	}
+}
+
+// close finishes o with status and moves it from the open to the finished orders.
+// Callers hold c.mu.
+func (c *Client) close(o *tracker.Order, status trading.OrderStatus) tracker.Order {
+	o.Status = status
+	o.UpdatedAt = c.clock.Now()
+	if status == tracker.StatusCanceled {
+		c.stats.Canceled++
+	}
+	delete(c.orders, o.OrderID)
+	c.finished[o.OrderID] = *o
+	return *o
+}
+
+// sells reports whether an order takes from the bids. Hedge mode close orders carry the
+// side of the position, so closing a long with side buy sells.
+func sells(side, tradeSide string) bool {
+	return (side == "sell") != (tradeSide == "close")
+}
+
+// applyFills updates the simulated positions and returns the changed ones. Orders with
+// a tradeSide belong to hedge mode; the others net a one-way position. Callers hold c.mu.
+func (c *Client) applyFills(fills []Fill) []tracker.Position {
+	var changed []tracker.Position
+	for _, f := range fills {
+		if f.TradeSide == "" {
+			changed = append(changed, c.net(f)...)
+			continue
+		}
+		// A hedge mode order carries the side of the position it opens or closes
+		holdSide := "short"
+		if f.Side == "buy" {
+			holdSide = "long"
+		}
+		if f.TradeSide == "close" {
+			changed = append(changed, c.reduce(f, holdSide, f.Size))
+		} else {
+			changed = append(changed, c.increase(f, holdSide, f.Size))
+		}
+	}
+	return changed
+}
+
+// net applies a one-way mode fill: it reduces the opposite position first and opens
+// the fill's side with the rest, unless the order is reduce-only.
+func (c *Client) net(f Fill) []tracker.Position {
+	holdSide, opposite := "long", "short"
+	if f.Side == "sell" {
+		holdSide, opposite = "short", "long"
+	}
+	var changed []tracker.Position
+	size := f.Size
+	if p, ok := c.positions[f.Symbol+":"+opposite]; ok {
+		closed := min(p.Size, size)
+		changed = append(changed, c.reduce(f, opposite, closed))
+		size -= closed
+	}
+	if size > 0 && !f.ReduceOnly {
+		changed = append(changed, c.increase(f, holdSide, size))
+	}
+	return changed
+}
+
+func (c *Client) increase(f Fill, holdSide string, size float64) tracker.Position {
+	key := f.Symbol + ":" + holdSide
+	p, ok := c.positions[key]
+	if !ok {
+		p = tracker.Position{Symbol: f.Symbol, HoldSide: holdSide, CreatedAt: f.Time}
+	}
+	p.AvgPrice = (p.AvgPrice*p.Size + f.Price*size) / (p.Size + size)
+	p.Size += size
+	p.Available = p.Size
+	p.UpdatedAt = f.Time
+	c.positions[key] = p
+	return p
+}
+
+func (c *Client) reduce(f Fill, holdSide string, size float64) tracker.Position {
+	key := f.Symbol + ":" + holdSide
+	p, ok := c.positions[key]
+	if !ok {
+		return tracker.Position{Symbol: f.Symbol, HoldSide: holdSide}
+	}
+	size = min(size, p.Size)
+	pnl := (f.Price - p.AvgPrice) * size
+	if holdSide == "short" {
+		pnl = -pnl
+	}
+	p.AchievedProfits += pnl
+	p.Size -= size
+	p.Available = p.Size
+	p.UpdatedAt = f.Time
+	if p.Size <= 0 {
+		p.Size, p.Available = 0, 0
+		delete(c.positions, key)
+	} else {
+		c.positions[key] = p
+	}
+	return p
+}
+
+// publish forwards the changes to the trackers and OnFill, outside of c.mu.
+func (c *Client) publish(updates []tracker.Order, positions []tracker.Position, fills []Fill) {
+	if c.opts.Orders != nil {
+		for _, u := range updates {
+			c.opts.Orders.Update(u)
+		}
+	}
+	if c.opts.Positions != nil && len(positions) > 0 {
+		c.opts.Positions.Update(positions...)
+	}
+	if c.opts.OnFill != nil {
+		for _, f := range fills {
+			c.opts.OnFill(f)
+		}
+	}
+}
+
+// open returns copies of the open orders of symbol, oldest first. Callers hold c.mu.
+func (c *Client) open(symbol string) []tracker.Order {
+	out := make([]tracker.Order, 0, len(c.orders))
+	for _, o := range c.orders {
+		if symbol == "" || o.Symbol == symbol {
+			out = append(out, *o)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return orderSeq(out[i].OrderID) < orderSeq(out[j].OrderID) })
+	return out
+}
+
+// find looks an open order up by ID or client order ID. Callers hold c.mu.
+func (c *Client) find(symbol, orderID, clientOid string) *tracker.Order {
+	if orderID != "" {
+		return c.orders[orderID]
+	}
+	if clientOid != "" {
+		return c.byClientOid(symbol, clientOid)
+	}
+	return nil
+}
+
+// lookup finds an open or finished order by ID or client order ID. Callers hold c.mu.
+func (c *Client) lookup(symbol, orderID, clientOid string) (tracker.Order, bool) {
+	if o := c.find(symbol, orderID, clientOid); o != nil {
+		return *o, true
+	}
+	if orderID != "" {
+		o, ok := c.finished[orderID]
+		return o, ok
+	}
+	if clientOid == "" {
+		return tracker.Order{}, false
+	}
+	var found tracker.Order
+	for _, o := range c.finished {
+		// A client order ID can be reused once its order is finished; report the latest
+		if o.ClientOid == clientOid && (symbol == "" || o.Symbol == symbol) &&
+			(found.OrderID == "" || orderSeq(o.OrderID) > orderSeq(found.OrderID)) {
+			found = o
+		}
+	}
+	return found, found.OrderID != ""
+}
+
+func (c *Client) byClientOid(symbol, clientOid string) *tracker.Order {
+	for _, o := range c.orders {
+		if o.ClientOid == clientOid && (symbol == "" || o.Symbol == symbol) {
+			return o
+		}
+	}
+	return nil
+}
+
+func orderSeq(id string) int64 {
+	n, _ := strconv.ParseInt(id[len("dry-"):], 10, 64)
+	return n
+}
+
+func levels(in []ws.OrderBookLevel) []level {
+	out := make([]level, 0, len(in))
+	for _, l := range in {
+		if l.PriceFloat > 0 && l.AmountFloat > 0 {
+			out = append(out, level{price: l.PriceFloat, size: l.AmountFloat})
+		}
+	}
+	return out
+}
+
+// reject returns an API error like the exchange's parameter verification failures.
+func reject(format string, args ...any) error {
+	return &types.APIError{Code: 40017, Message: "dryrun: " + fmt.Sprintf(format, args...)}
+}
+
+func failure(orderID, clientOid string, err error) orderInfo {
+	info := orderInfo{OrderID: orderID, ClientOid: clientOid, ErrorMsg: err.Error()}
+	var apiErr *types.APIError
+	if errors.As(err, &apiErr) {
+		info.ErrorCode, info.ErrorMsg = strconv.FormatInt(apiErr.Code, 10), apiErr.Message
+	}
+	return info
+}
@@ -0,0 +1,303 @@
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/khanbekov/go-bitget/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeClient struct {
+	calls []string
+}
+
+func (c *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.calls = append(c.calls, method+" "+endpoint)
+	return &futures.ApiResponse{Code: "00000", Data: []byte(`[]`)}, &fasthttp.ResponseHeader{}, nil
+}
+
+const bookMessage = `{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"books5","instId":"BTCUSDT"},"data":[{"asks":[["100.5","1"],["101","2"]],"bids":[["99.5","1"],["99","2"]],"ts":"1700000000000"}],"ts":1700000000000}`
+
+func newTestClient(simulate bool) (*Client, *fakeClient, *tracker.OrderTracker, *tracker.PositionTracker, *[]Fill) {
+	next := &fakeClient{}
+	orders := tracker.NewOrderTracker(tracker.Options{})
+	positions := tracker.NewPositionTracker(tracker.Options{})
+	var fills []Fill
+	c := New(next, Options{
+		SimulateFills: simulate,
+		Orders:        orders,
+		Positions:     positions,
+		OnFill:        func(f Fill) { fills = append(fills, f) },
+		Clock:         common.NewFakeClock(time.UnixMilli(1700000000000)),
+	})
+	return c, next, orders, positions, &fills
+}
+
+func placeOrder(c *Client, side trading.Side, orderType trading.OrderType, size, price string) (*trading.OrderInfo, error) {
+	s := trading.NewCreateOrderService(c).
+		Symbol("BTCUSDT").
+		ProductType(trading.ProductTypeUSDTFutures).
+		MarginCoin("USDT").
+		MarginMode(trading.MarginModeCrossed).
+		SideType(side).
+		OrderType(orderType).
+		Size(size)
+	if price != "" {
+		s.Price(price)
+	}
+	return s.Do(context.Background())
+}
+
+func TestClient_RestingOrderFillsWhenBookCrosses(t *testing.T) {
+	c, _, orders, positions, fills := newTestClient(true)
+	c.HandleBook(bookMessage)
+
+	info, err := placeOrder(c, trading.SideBuy, trading.OrderTypeLimit, "1.5", "100")
+	require.NoError(t, err)
+	assert.Equal(t, "dry-1", info.OrderId)
+	o, ok := orders.Get(info.OrderId)
+	require.True(t, ok)
+	assert.Equal(t, trading.OrderStatus(tracker.StatusLive), o.Status)
+	assert.Empty(t, *fills, "below the best ask")
+
+	c.UpdateBook("BTCUSDT", book5(t, `{"asks":[["99.8","1"],["100","3"]],"bids":[["99.5","1"]]}`))
+	require.Len(t, *fills, 2)
+	assert.Equal(t, Fill{OrderID: "dry-1", ClientOid: "dry-1", Symbol: "BTCUSDT", Side: "buy", Price: 100, Size: 1, Maker: true, Time: time.UnixMilli(1700000000000)}, (*fills)[0])
+	assert.Equal(t, 0.5, (*fills)[1].Size)
+
+	_, ok = orders.Get(info.OrderId)
+	assert.False(t, ok, "filled orders leave the tracker")
+	p, ok := positions.Get("BTCUSDT", "long")
+	require.True(t, ok)
+	assert.Equal(t, 1.5, p.Size)
+	assert.Equal(t, 100.0, p.AvgPrice)
+	assert.Empty(t, c.Open(""))
+}
+
+func TestClient_MarketOrderWalksBook(t *testing.T) {
+	c, _, _, positions, fills := newTestClient(true)
+
+	_, err := placeOrder(c, trading.SideBuy, trading.OrderTypeMarket, "1", "")
+	var apiErr *types.APIError
+	require.ErrorAs(t, err, &apiErr, "no book yet")
+
+	c.HandleBook(bookMessage)
+	_, err = placeOrder(c, trading.SideBuy, trading.OrderTypeMarket, "2", "")
+	require.NoError(t, err)
+	require.Len(t, *fills, 2)
+	assert.Equal(t, 100.5, (*fills)[0].Price)
+	assert.Equal(t, 101.0, (*fills)[1].Price)
+	assert.False(t, (*fills)[0].Maker)
+	p, _ := positions.Get("BTCUSDT", "long")
+	assert.Equal(t, 100.75, p.AvgPrice)
+
+	// One-way mode: selling nets the long position and opens a short with the rest
+	_, err = placeOrder(c, trading.SideSell, trading.OrderTypeMarket, "3", "")
+	require.NoError(t, err)
+	long, ok := c.Position("BTCUSDT", "long")
+	assert.False(t, ok)
+	assert.Zero(t, long.Size)
+	short, ok := c.Position("BTCUSDT", "short")
+	require.True(t, ok)
+	assert.Equal(t, 1.0, short.Size)
+	assert.Equal(t, 99.0, short.AvgPrice)
+	_, ok = positions.Get("BTCUSDT", "long")
+	assert.False(t, ok, "closed in the tracker")
+}
+
+func TestClient_TimeInForce(t *testing.T) {
+	c, _, orders, _, fills := newTestClient(true)
+	c.HandleBook(bookMessage)
+	var events []tracker.OrderEvent
+	orders.OnEvent(func(ev tracker.OrderEvent) { events = append(events, ev) })
+
+	place := func(force trading.TimeInForce, size, price string) {
+		_, err := trading.NewCreateOrderService(c).Symbol("BTCUSDT").ProductType(trading.ProductTypeUSDTFutures).
+			MarginCoin("USDT").MarginMode(trading.MarginModeCrossed).SideType(trading.SideBuy).OrderType(trading.OrderTypeLimit).TimeInForce(force).Size(size).Price(price).
+			Do(context.Background())
+		require.NoError(t, err)
+	}
+
+	place(trading.TimeInForcePostOnly, "1", "100.5")
+	assert.Empty(t, *fills, "post_only does not take")
+	place(trading.TimeInForceFOK, "5", "101")
+	assert.Empty(t, *fills, "fok needs the whole size")
+	place(trading.TimeInForceIOC, "5", "100.5")
+	require.Len(t, *fills, 1)
+	assert.Equal(t, 1.0, (*fills)[0].Size)
+
+	require.NotEmpty(t, events)
+	last := events[len(events)-1]
+	assert.Equal(t, tracker.OrderCanceled, last.Type, "ioc remainder is canceled")
+	assert.Equal(t, 1.0, last.Order.FilledSize)
+	assert.Empty(t, c.Open("BTCUSDT"))
+	assert.Equal(t, 3, c.Stats().Canceled)
+}
+
+func TestClient_OrderManagement(t *testing.T) {
+	c, next, orders, _, fills := newTestClient(false)
+	ctx := context.Background()
+
+	info, err := placeOrder(c, trading.SideSell, trading.OrderTypeLimit, "1", "105")
+	require.NoError(t, err)
+	_, err = trading.NewModifyOrderService(c).Symbol("BTCUSDT").ProductType(trading.ProductTypeUSDTFutures).MarginCoin("USDT").
+		OrderId(info.OrderId).NewClientOrderId("moved").NewSize("1").NewPrice("104").Do(ctx)
+	require.NoError(t, err)
+
+	pending, err := trading.NewPendingOrdersService(c).Symbol("BTCUSDT").ProductType(trading.ProductTypeUSDTFutures).Do(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "104", pending[0].Price)
+	assert.Equal(t, "moved", pending[0].ClientOid)
+	o, _ := orders.Get(info.OrderId)
+	assert.Equal(t, 104.0, o.Price)
+
+	c.HandleBook(bookMessage)
+	c.UpdateBook("BTCUSDT", book5(t, `{"asks":[["106","1"]],"bids":[["105","1"]]}`))
+	assert.Empty(t, *fills, "fills are not simulated")
+
+	_, err = trading.NewCancelOrderService(c).Symbol("BTCUSDT").ProductType(trading.ProductTypeUSDTFutures).ClientOid("moved").Do(ctx)
+	require.NoError(t, err)
+	_, ok := orders.Get(info.OrderId)
+	assert.False(t, ok)
+	_, err = trading.NewCancelOrderService(c).Symbol("BTCUSDT").ProductType(trading.ProductTypeUSDTFutures).OrderId(info.OrderId).Do(ctx)
+	assert.Error(t, err, "already canceled")
+
+	_, _, err = c.CallAPI(ctx, "POST", futures.EndpointSetLeverage, nil, []byte(`{}`), true)
+	assert.ErrorIs(t, err, ErrNotSimulated)
+	_, _, err = c.CallAPI(ctx, "GET", futures.EndpointAllPositions, nil, nil, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GET " + futures.EndpointAllPositions}, next.calls, "only reads reach the exchange")
+	assert.Equal(t, Stats{Placed: 1, Modified: 1, Canceled: 1}, c.Stats())
+}
+
+func TestClient_Batch(t *testing.T) {
+	c, _, _, _, _ := newTestClient(true)
+	ctx := context.Background()
+
+	body := `{"symbol":"BTCUSDT","productType":"USDT-FUTURES","orderList":[
+		{"side":"buy","orderType":"limit","size":"1","price":"90","clientOid":"a"},
+		{"side":"buy","orderType":"limit","size":"0","price":"90","clientOid":"b"},
+		{"side":"sell","orderType":"limit","size":"1","price":"110","clientOid":"c"}]}`
+	res, _, err := c.CallAPI(ctx, "POST", futures.EndpointBatchPlaceOrder, nil, []byte(body), true)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"successList":[{"orderId":"dry-1","clientOid":"a"},{"orderId":"dry-2","clientOid":"c"}],
+		"failureList":[{"orderId":"","clientOid":"b","errorMsg":"dryrun: invalid size \"0\"","errorCode":"40017"}]}`, string(res.Data))
+
+	res, _, err = c.CallAPI(ctx, "POST", futures.EndpointCancelAllOrders, nil, []byte(`{"productType":"USDT-FUTURES"}`), true)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"successList":[{"orderId":"dry-1","clientOid":"a"},{"orderId":"dry-2","clientOid":"c"}],"failureList":[]}`, string(res.Data))
+	assert.Empty(t, c.Open(""))
+}
+
+func TestClient_HedgeMode(t *testing.T) {
+	c, _, _, positions, fills := newTestClient(true)
+	c.HandleBook(bookMessage)
+	ctx := context.Background()
+
+	place := func(side trading.Side, tradeSide trading.PositionSideType, size string) {
+		_, err := trading.NewCreateOrderService(c).Symbol("BTCUSDT").ProductType(trading.ProductTypeUSDTFutures).
+			MarginCoin("USDT").MarginMode(trading.MarginModeCrossed).SideType(side).PositionSideType(tradeSide).OrderType(trading.OrderTypeMarket).Size(size).Do(ctx)
+		require.NoError(t, err)
+	}
+
+	place(trading.SideSell, trading.PositionSideOpen, "1")
+	place(trading.SideBuy, trading.PositionSideOpen, "1")
+	short, _ := positions.Get("BTCUSDT", "short")
+	long, _ := positions.Get("BTCUSDT", "long")
+	assert.Equal(t, 1.0, short.Size, "hedge mode keeps both sides")
+	assert.Equal(t, 1.0, long.Size)
+
+	// Closing carries the side of the position: sell closes the short by buying
+	place(trading.SideSell, trading.PositionSideClose, "1")
+	_, ok := positions.Get("BTCUSDT", "short")
+	assert.False(t, ok)
+	closed, ok := c.Position("BTCUSDT", "long")
+	require.True(t, ok)
+	assert.Equal(t, 1.0, closed.Size)
+	assert.Equal(t, 101.0, (*fills)[len(*fills)-1].Price, "filled against the asks")
+}
+
+func TestClient_OrderDetails(t *testing.T) {
+	c, next, _, _, _ := newTestClient(true)
+	c.HandleBook(bookMessage)
+	ctx := context.Background()
+	details := func() *trading.GetOrderDetailsService {
+		return trading.NewGetOrderDetailsService(c).Symbol("BTCUSDT").ProductType(trading.ProductTypeUSDTFutures)
+	}
+
+	filled, err := trading.NewCreateOrderService(c).Symbol("BTCUSDT").ProductType(trading.ProductTypeUSDTFutures).
+		MarginCoin("USDT").MarginMode(trading.MarginModeCrossed).SideType(trading.SideBuy).OrderType(trading.OrderTypeLimit).
+		Price("101").Size("2").ClientOrderId("entry").Do(ctx)
+	require.NoError(t, err)
+	resting, err := placeOrder(c, trading.SideSell, trading.OrderTypeLimit, "1", "110")
+	require.NoError(t, err)
+	_, err = trading.NewCancelOrderService(c).Symbol("BTCUSDT").ProductType(trading.ProductTypeUSDTFutures).OrderId(resting.OrderId).Do(ctx)
+	require.NoError(t, err)
+
+	d, err := details().OrderId(filled.OrderId).Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, trading.OrderStatus(tracker.StatusFilled), d.State)
+	assert.Equal(t, "2", d.BaseVolume)
+	assert.Equal(t, "100.75", d.PriceAvg)
+	d, err = details().ClientOid("entry").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, filled.OrderId, d.OrderId, "found by client order ID")
+	d, err = details().OrderId(resting.OrderId).Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, trading.OrderStatus(tracker.StatusCanceled), d.State)
+	assert.Equal(t, "110", d.Price)
+
+	_, err = details().OrderId("dry-9").Do(ctx)
+	var apiErr *types.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Empty(t, next.calls, "simulated orders are never looked up on the exchange")
+
+	_, _, err = c.CallAPI(ctx, "GET", futures.EndpointOrderDetails, url.Values{"symbol": {"BTCUSDT"}, "orderId": {"1234567890"}}, nil, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GET " + futures.EndpointOrderDetails}, next.calls, "real orders are")
+}
+
+func TestClient_ReduceOnlyDoesNotFlip(t *testing.T) {
+	c, _, _, positions, _ := newTestClient(true)
+	c.HandleBook(bookMessage)
+	ctx := context.Background()
+	reduce := func(side trading.Side, size string) {
+		_, err := trading.NewCreateOrderService(c).Symbol("BTCUSDT").ProductType(trading.ProductTypeUSDTFutures).
+			MarginCoin("USDT").MarginMode(trading.MarginModeCrossed).SideType(side).OrderType(trading.OrderTypeMarket).
+			Size(size).ReduceOnly(true).Do(ctx)
+		require.NoError(t, err)
+	}
+
+	reduce(trading.SideSell, "1")
+	_, ok := c.Position("BTCUSDT", "short")
+	assert.False(t, ok, "nothing to reduce")
+
+	_, err := placeOrder(c, trading.SideBuy, trading.OrderTypeMarket, "1", "")
+	require.NoError(t, err)
+	reduce(trading.SideSell, "2")
+	_, ok = c.Position("BTCUSDT", "long")
+	assert.False(t, ok, "closed")
+	_, ok = c.Position("BTCUSDT", "short")
+	assert.False(t, ok, "the rest does not open a short")
+	_, ok = positions.Get("BTCUSDT", "short")
+	assert.False(t, ok)
+}
+
+func book5(t *testing.T, data string) ws.OrderBookData {
+	t.Helper()
+	var b ws.OrderBookData
+	require.NoError(t, json.Unmarshal([]byte(data), &b))
+	return b
+}
@@ -0,0 +1,208 @@
+// Package funding downloads and caches historical funding rates.
+//
+// A Store pages through the funding rate history of each symbol, keeps it in memory,
+// persists it through a pluggable Storage and answers time range queries. Later
+// downloads only fetch settlements newer than the stored ones:
+//
+//	storage, _ := funding.NewFileStorage("data/funding")
+//	store := funding.NewStore(client, storage)
+//	err := store.DownloadAll(ctx, market.ProductTypeUSDTFutures, []string{"BTCUSDT", "ETHUSDT"}, since)
+//
+//	key := funding.Key{Symbol: "BTCUSDT", ProductType: market.ProductTypeUSDTFutures}
+//	rates := store.Range(key, from, to)
+//	paid := store.Sum(key, from, to) // Cumulative rate, e.g. for carry estimates
+package funding
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures/market"
+)
+
+// pageSize is the page size limit of the funding rate history endpoint.
+const pageSize = 100
+
+// Key identifies a funding rate series.
+type Key struct {
+	Symbol      string
+	ProductType market.ProductType
+}
+
+// String returns a filesystem friendly representation of the key.
+func (k Key) String() string {
+	return fmt.Sprintf("%s_%s", k.Symbol, k.ProductType)
+}
+
+// Rate is one funding settlement.
+type Rate struct {
+	Time time.Time `json:"time"`
+	Rate float64   `json:"rate"`
+}
+
+// Store keeps funding rate series in memory, persists them and downloads missing
+// history via REST. It is safe for concurrent use.
+type Store struct {
+	client  market.ClientInterface
+	storage Storage
+
+	mu     sync.RWMutex
+	series map[Key][]Rate // Ascending by time
+}
+
+// NewStore creates a store. storage may be nil to keep rates in memory only.
+func NewStore(client market.ClientInterface, storage Storage) *Store {
+	if storage == nil {
+		storage = NewMemoryStorage()
+	}
+	return &Store{client: client, storage: storage, series: make(map[Key][]Rate)}
+}
+
+// Download restores the stored series for key and fetches the settlements back to since.
+// When the stored series already reaches back to since, only newer settlements are
+// fetched. It returns the number of settlements added.
+func (s *Store) Download(ctx context.Context, key Key, since time.Time) (int, error) {
+	stored, err := s.storage.Load(key)
+	if err != nil {
+		return 0, fmt.Errorf("load %s: %w", key, err)
+	}
+	s.mu.Lock()
+	s.series[key] = merge(s.series[key], stored)
+	known := len(s.series[key])
+	var newest time.Time
+	covered := known > 0 && !s.series[key][0].Time.After(since)
+	if known > 0 {
+		newest = s.series[key][known-1].Time
+	}
+	s.mu.Unlock()
+
+	var fetched []Rate
+	for page := 1; ; page++ {
+		res, err := market.NewHistoryFundingRateService(s.client).
+			Symbol(key.Symbol).
+			ProductType(key.ProductType).
+			PageSize(strconv.Itoa(pageSize)).
+			PageNo(strconv.Itoa(page)).
+			Do(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("fetch %s funding rates page %d: %w", key, page, err)
+		}
+
+		done := len(res.FundingRates) < pageSize
+		for _, r := range res.FundingRates {
+			rate, err := parseRate(r)
+			if err != nil {
+				return 0, fmt.Errorf("fetch %s funding rates: %w", key, err)
+			}
+			// Pages are newest first, so the rest is stored or out of range
+			if rate.Time.Before(since) || (covered && !rate.Time.After(newest)) {
+				done = true
+				break
+			}
+			fetched = append(fetched, rate)
+		}
+		if done {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	s.series[key] = merge(s.series[key], fetched)
+	added := len(s.series[key]) - known
+	s.mu.Unlock()
+	return added, s.Save(key)
+}
+
+// DownloadAll downloads the history of every symbol, stopping at the first error.
+func (s *Store) DownloadAll(ctx context.Context, productType market.ProductType, symbols []string, since time.Time) error {
+	for _, symbol := range symbols {
+		if _, err := s.Download(ctx, Key{Symbol: symbol, ProductType: productType}, since); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Save persists the in-memory series for key.
+func (s *Store) Save(key Key) error {
+	s.mu.RLock()
+	rates := s.series[key]
+	s.mu.RUnlock()
+	if err := s.storage.Save(key, rates); err != nil {
+		return fmt.Errorf("save %s: %w", key, err)
+	}
+	return nil
+}
+
+// Range returns the settlements with from <= time < to, oldest first. A zero to means
+// no upper bound.
+func (s *Store) Range(key Key, from, to time.Time) []Rate {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	series := s.series[key]
+	lo := sort.Search(len(series), func(i int) bool { return !series[i].Time.Before(from) })
+	hi := len(series)
+	if !to.IsZero() {
+		hi = sort.Search(len(series), func(i int) bool { return !series[i].Time.Before(to) })
+	}
+	if lo >= hi {
+		return nil
+	}
+	out := make([]Rate, hi-lo)
+	copy(out, series[lo:hi])
+	return out
+}
+
+// Sum returns the sum of the rates settled with from <= time < to: the fraction of
+// notional a long position paid (a short received) over the range.
+func (s *Store) Sum(key Key, from, to time.Time) float64 {
+	var sum float64
+	for _, r := range s.Range(key, from, to) {
+		sum += r.Rate
+	}
+	return sum
+}
+
+// Latest returns the most recent stored settlement.
+func (s *Store) Latest(key Key) (Rate, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	series := s.series[key]
+	if len(series) == 0 {
+		return Rate{}, false
+	}
+	return series[len(series)-1], true
+}
+
+func parseRate(r market.HistoryFundingRate) (Rate, error) {
+	ms, err := strconv.ParseInt(r.FundingTime, 10, 64)
+	if err != nil {
+		return Rate{}, fmt.Errorf("invalid funding time %q", r.FundingTime)
+	}
+	rate, err := strconv.ParseFloat(r.FundingRate, 64)
+	if err != nil {
+		return Rate{}, fmt.Errorf("invalid funding rate %q", r.FundingRate)
+	}
+	return Rate{Time: time.UnixMilli(ms), Rate: rate}, nil
+}
+
+// merge combines two series into one sorted by time, preferring b on duplicates.
+func merge(a, b []Rate) []Rate {
+	byTime := make(map[int64]Rate, len(a)+len(b))
+	for _, r := range a {
+		byTime[r.Time.UnixMilli()] = r
+	}
+	for _, r := range b {
+		byTime[r.Time.UnixMilli()] = r
+	}
+	out := make([]Rate, 0, len(byTime))
+	for _, r := range byTime {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Time.Before(out[j].Time) })
+	return out
+}
@@ -0,0 +1,134 @@
+package funding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+var (
+	base    = time.UnixMilli(1700000000000).Truncate(8 * time.Hour)
+	testKey = Key{Symbol: "BTCUSDT", ProductType: market.ProductTypeUSDTFutures}
+)
+
+// fakeClient serves settlements every 8 hours ending at latest, newest first, paged by
+// pageNo. The rate of settlement i (counting from base) is i/10000.
+type fakeClient struct {
+	latest time.Time
+	pages  []int
+	err    error
+}
+
+func (f *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*market.ApiResponse, *fasthttp.ResponseHeader, error) {
+	if f.err != nil {
+		return nil, nil, f.err
+	}
+	if endpoint != market.EndpointHistoryFundingRate {
+		return nil, nil, errors.New("unexpected endpoint " + endpoint)
+	}
+	page, _ := strconv.Atoi(query.Get("pageNo"))
+	size, _ := strconv.Atoi(query.Get("pageSize"))
+	f.pages = append(f.pages, page)
+
+	var rows []market.HistoryFundingRate
+	for i := (page - 1) * size; i < page*size; i++ {
+		ts := f.latest.Add(-time.Duration(i) * 8 * time.Hour)
+		if ts.Before(base) {
+			break
+		}
+		n := int(ts.Sub(base) / (8 * time.Hour))
+		rows = append(rows, market.HistoryFundingRate{
+			Symbol:      query.Get("symbol"),
+			FundingRate: strconv.FormatFloat(float64(n)/10000, 'f', -1, 64),
+			FundingTime: strconv.FormatInt(ts.UnixMilli(), 10),
+		})
+	}
+	data, _ := json.Marshal(rows)
+	return &market.ApiResponse{Code: "00000", Data: data}, &fasthttp.ResponseHeader{}, nil
+}
+
+func TestStore_Download(t *testing.T) {
+	client := &fakeClient{latest: base.Add(249 * 8 * time.Hour)}
+	storage := NewMemoryStorage()
+	store := NewStore(client, storage)
+
+	added, err := store.Download(context.Background(), testKey, base.Add(30*8*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 220, added)
+	assert.Equal(t, []int{1, 2, 3}, client.pages)
+
+	rates := store.Range(testKey, time.Time{}, time.Time{})
+	require.Len(t, rates, 220)
+	assert.Equal(t, base.Add(30*8*time.Hour), rates[0].Time, "oldest first, back to since")
+	assert.Equal(t, 0.003, rates[0].Rate)
+	saved, _ := storage.Load(testKey)
+	assert.Len(t, saved, 220)
+
+	// Two new settlements: only the first page is fetched
+	client.latest = client.latest.Add(16 * time.Hour)
+	client.pages = nil
+	added, err = store.Download(context.Background(), testKey, base.Add(30*8*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, added)
+	assert.Equal(t, []int{1}, client.pages)
+	latest, ok := store.Latest(testKey)
+	require.True(t, ok)
+	assert.Equal(t, client.latest, latest.Time)
+
+	// Reaching further back fills in the older history
+	client.pages = nil
+	added, err = store.Download(context.Background(), testKey, base)
+	require.NoError(t, err)
+	assert.Equal(t, 30, added)
+	assert.Equal(t, []int{1, 2, 3}, client.pages)
+
+	client.err = errors.New("boom")
+	_, err = store.Download(context.Background(), testKey, base)
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestStore_Queries(t *testing.T) {
+	store := NewStore(&fakeClient{latest: base.Add(9 * 8 * time.Hour)}, nil)
+	require.NoError(t, store.DownloadAll(context.Background(), market.ProductTypeUSDTFutures, []string{"BTCUSDT", "ETHUSDT"}, base))
+
+	from, to := base.Add(2*8*time.Hour), base.Add(5*8*time.Hour)
+	rates := store.Range(testKey, from, to)
+	require.Len(t, rates, 3, "to is exclusive")
+	assert.Equal(t, from, rates[0].Time)
+	assert.InDelta(t, 0.0002+0.0003+0.0004, store.Sum(testKey, from, to), 1e-12)
+	assert.Empty(t, store.Range(testKey, to, from))
+	assert.Len(t, store.Range(Key{Symbol: "ETHUSDT", ProductType: market.ProductTypeUSDTFutures}, time.Time{}, time.Time{}), 10)
+
+	_, ok := store.Latest(Key{Symbol: "XRPUSDT"})
+	assert.False(t, ok)
+}
+
+func TestFileStorage(t *testing.T) {
+	storage, err := NewFileStorage(t.TempDir())
+	require.NoError(t, err)
+
+	missing, err := storage.Load(testKey)
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+
+	rates := []Rate{{Time: base.UTC(), Rate: 0.0001}, {Time: base.Add(8 * time.Hour).UTC(), Rate: -0.00005}}
+	require.NoError(t, storage.Save(testKey, rates))
+	loaded, err := storage.Load(testKey)
+	require.NoError(t, err)
+	assert.Equal(t, rates, loaded)
+
+	// A restarted store serves the persisted history before downloading
+	store := NewStore(&fakeClient{latest: base.Add(8 * time.Hour)}, storage)
+	added, err := store.Download(context.Background(), testKey, base)
+	require.NoError(t, err)
+	assert.Zero(t, added)
+}
@@ -0,0 +1,95 @@
+package funding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Storage persists funding rate series between runs.
+type Storage interface {
+	// Load returns the stored rates for key, or an empty slice when none exist.
+	Load(key Key) ([]Rate, error)
+	// Save replaces the stored rates for key.
+	Save(key Key, rates []Rate) error
+}
+
+// MemoryStorage keeps series in process memory. It is the default storage.
+type MemoryStorage struct {
+	mu   sync.RWMutex
+	data map[Key][]Rate
+}
+
+// NewMemoryStorage creates an empty in-memory storage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{data: make(map[Key][]Rate)}
+}
+
+// Load implements Storage.
+func (m *MemoryStorage) Load(key Key) ([]Rate, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]Rate, len(m.data[key]))
+	copy(out, m.data[key])
+	return out, nil
+}
+
+// Save implements Storage.
+func (m *MemoryStorage) Save(key Key, rates []Rate) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := make([]Rate, len(rates))
+	copy(stored, rates)
+	m.data[key] = stored
+	return nil
+}
+
+// FileStorage stores one JSON file per series in a directory, as an array of
+// {"time": ..., "rate": ...} objects with RFC 3339 times.
+type FileStorage struct {
+	dir string
+}
+
+// NewFileStorage creates a file storage rooted at dir, creating the directory if needed.
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create funding directory: %w", err)
+	}
+	return &FileStorage{dir: dir}, nil
+}
+
+func (f *FileStorage) path(key Key) string {
+	return filepath.Join(f.dir, key.String()+".json")
+}
+
+// Load implements Storage.
+func (f *FileStorage) Load(key Key) ([]Rate, error) {
+	data, err := os.ReadFile(f.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rates []Rate
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// Save implements Storage. The file is replaced atomically.
+func (f *FileStorage) Save(key Key, rates []Rate) error {
+	data, err := json.Marshal(rates)
+	if err != nil {
+		return err
+	}
+	tmp := f.path(key) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path(key))
+}
@@ -0,0 +1,515 @@
+// Package grid runs a ladder of limit orders across a price band on one futures contract.
+//
+// A Grid splits the band [Lower, Upper] into levels and rests a buy at every level below
+// the current price and a sell at every level above it, leaving the level nearest to the
+// price empty. When an order fills, the counter order is placed one level away, so each
+// buy that fills is followed by a sell one level higher and vice versa; every completed
+// round trip realizes one grid spacing. Orders are placed and cancelled with the batch
+// endpoints, fills are read from a tracker.OrderTracker, and the net inventory is capped
+// by config.RiskLimits.
+//
+//	g, err := grid.New(client, grid.Options{
+//		Symbol:      "BTCUSDT",
+//		ProductType: futures.ProductTypeUSDTFutures,
+//		MarginCoin:  "USDT",
+//		MarginMode:  trading.MarginModeCrossed,
+//		Lower:       55000,
+//		Upper:       65000,
+//		Levels:      21,
+//		Size:        0.002,
+//		SizeStep:    0.001,
+//		TickSize:    0.1,
+//		Limits:      config.RiskLimits{MaxPositionNotional: 2000},
+//	})
+//	orders.OnEvent(g.HandleOrderEvent) // tracker.OrderTracker fed from the orders channel
+//	if err := g.Start(ctx, lastPrice); err != nil { ... }
+//	go g.Run(ctx, 5*time.Second, log.Println)
+//	defer g.Shutdown(context.Background()) // cancels the ladder
+package grid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/config"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/khanbekov/go-bitget/futures/trading"
+)
+
+// maxBatch is the largest batch accepted by the batch order endpoints.
+const maxBatch = 20
+
+// ErrStarted is returned by Start when the grid already has orders.
+var ErrStarted = errors.New("grid: already started")
+
+// Options configures a Grid.
+type Options struct {
+	Symbol      string
+	ProductType futures.ProductType
+	MarginCoin  string
+	MarginMode  trading.MarginMode
+	// Hedge is set when the account uses hedge position mode: levels below the start price
+	// trade a long position and levels above it a short position.
+	Hedge bool
+
+	// Lower and Upper bound the band; both are levels. Required.
+	Lower, Upper float64
+	// Levels is the number of price levels including both bounds, at least 2. Required.
+	Levels int
+	// Geometric spaces levels by a constant ratio instead of a constant difference.
+	Geometric bool
+
+	// Size is the order size at every level. Required.
+	Size float64
+	// SizeStep and TickSize are the contract's size and price steps. Required.
+	SizeStep float64
+	TickSize float64
+	// PostOnly places every order as post-only.
+	PostOnly bool
+
+	// Limits.MaxPositionNotional caps the absolute net inventory valued at the order
+	// price; orders that could push the inventory past it are not placed. Optional.
+	Limits config.RiskLimits
+
+	// OnFill is called for every filled grid order. Optional.
+	OnFill func(Fill)
+
+	// Tagger generates the clientOids of the grid orders for attribution. Optional.
+	Tagger *trading.Tagger
+}
+
+// Fill is a filled grid order.
+type Fill struct {
+	Level    int
+	Price    float64
+	Side     trading.Side
+	Size     float64
+	Realized float64 // Profit of the round trip this fill completed, zero for an opening fill
+}
+
+// Level is one price level of the ladder.
+type Level struct {
+	Index   int
+	Price   float64
+	Side    trading.Side // Empty when no order rests at the level
+	OrderID string
+}
+
+// Status is a snapshot of the grid.
+type Status struct {
+	Levels    []Level
+	Inventory float64 // Net filled size: positive long, negative short
+	Realized  float64 // Sum of completed round trips in the margin coin
+	Fills     int
+}
+
+// rung is a resting order, or one waiting to be placed when orderID is empty.
+type rung struct {
+	side      trading.Side
+	orderID   string
+	clientOid string
+	filled    float64
+}
+
+// Grid maintains the ladder. It is safe for concurrent use.
+type Grid struct {
+	client futures.ClientInterface
+	opts   Options
+	prices []float64
+	start  int // Level nearest to the start price; no order rests there initially
+
+	wake chan struct{}
+
+	mu        sync.Mutex
+	rungs     map[int]*rung
+	byOrder   map[string]int
+	inventory float64
+	realized  float64
+	fills     int
+	seq       int
+	started   bool
+}
+
+// New validates opts and creates a grid. No orders are placed until Start.
+func New(client futures.ClientInterface, opts Options) (*Grid, error) {
+	prices, err := opts.Prices()
+	if err != nil {
+		return nil, err
+	}
+	if opts.Size <= 0 || opts.SizeStep <= 0 {
+		return nil, fmt.Errorf("grid: size and size step are required")
+	}
+	if common.RoundDownToStep(opts.Size, opts.SizeStep) <= 0 {
+		return nil, fmt.Errorf("grid: size %v is below the size step %v", opts.Size, opts.SizeStep)
+	}
+	return &Grid{
+		client:  client,
+		opts:    opts,
+		prices:  prices,
+		wake:    make(chan struct{}, 1),
+		rungs:   make(map[int]*rung),
+		byOrder: make(map[string]int),
+	}, nil
+}
+
+// Prices returns the level prices from Lower to Upper, rounded to the tick size.
+func (o Options) Prices() ([]float64, error) {
+	if o.Lower <= 0 || o.Upper <= o.Lower {
+		return nil, fmt.Errorf("grid: invalid band %v-%v", o.Lower, o.Upper)
+	}
+	if o.Levels < 2 {
+		return nil, fmt.Errorf("grid: at least 2 levels are required")
+	}
+	if o.TickSize <= 0 {
+		return nil, fmt.Errorf("grid: tick size is required")
+	}
+	prices := make([]float64, o.Levels)
+	n := float64(o.Levels - 1)
+	for i := range prices {
+		p := o.Lower + (o.Upper-o.Lower)*float64(i)/n
+		if o.Geometric {
+			p = o.Lower * math.Pow(o.Upper/o.Lower, float64(i)/n)
+		}
+		prices[i] = math.Round(p/o.TickSize) * o.TickSize
+		if i > 0 && prices[i] <= prices[i-1] {
+			return nil, fmt.Errorf("grid: levels closer than the tick size")
+		}
+	}
+	return prices, nil
+}
+
+// Start places the initial ladder around price: buys below it and sells above it.
+func (g *Grid) Start(ctx context.Context, price float64) error {
+	g.mu.Lock()
+	if g.started {
+		g.mu.Unlock()
+		return ErrStarted
+	}
+	g.started = true
+	g.start = g.nearest(price)
+	for i, p := range g.prices {
+		switch {
+		case i == g.start:
+		case p < price:
+			g.rungs[i] = &rung{side: trading.SideBuy}
+		default:
+			g.rungs[i] = &rung{side: trading.SideSell}
+		}
+	}
+	g.mu.Unlock()
+
+	return g.Rebalance(ctx)
+}
+
+// HandleOrderEvent records fills of grid orders and queues their counter orders. Pass it
+// to tracker.OrderTracker.OnEvent.
+func (g *Grid) HandleOrderEvent(ev tracker.OrderEvent) {
+	g.mu.Lock()
+	i, ok := g.byOrder[ev.Order.OrderID]
+	if !ok {
+		g.mu.Unlock()
+		return
+	}
+	r := g.rungs[i]
+	var fill *Fill
+	if delta := ev.Order.FilledSize - r.filled; delta > 0 {
+		r.filled = ev.Order.FilledSize
+		if r.side == trading.SideBuy {
+			g.inventory += delta
+		} else {
+			g.inventory -= delta
+		}
+	}
+	if ev.Order.Terminal() && r.filled > 0 {
+		fill = &Fill{Level: i, Price: g.prices[i], Side: r.side, Size: r.filled}
+	}
+	if ev.Order.Terminal() {
+		delete(g.byOrder, r.orderID)
+		delete(g.rungs, i)
+		if fill != nil {
+			g.fills++
+			if g.closing(i, r.side) {
+				fill.Realized = g.spacing(i, r.side) * fill.Size
+				g.realized += fill.Realized
+			}
+			g.queueCounter(i, r.side)
+		} else {
+			// Cancelled outside of the grid: restore it on the next rebalance
+			g.rungs[i] = &rung{side: r.side}
+		}
+	}
+	g.mu.Unlock()
+
+	if ev.Order.Terminal() {
+		select {
+		case g.wake <- struct{}{}:
+		default:
+		}
+	}
+	if fill != nil && g.opts.OnFill != nil {
+		g.opts.OnFill(*fill)
+	}
+}
+
+// Rebalance places every queued order, in batches, and returns the failures.
+func (g *Grid) Rebalance(ctx context.Context) error {
+	g.mu.Lock()
+	var orders []trading.BatchOrderInfo
+	pending := make(map[string]int)
+	// The inventory if every resting buy, or every resting sell, filled
+	long, short := g.inventory, g.inventory
+	for _, r := range g.rungs {
+		if r.orderID == "" {
+			continue
+		}
+		if r.side == trading.SideBuy {
+			long += g.orderSize() - r.filled
+		} else {
+			short -= g.orderSize() - r.filled
+		}
+	}
+	for _, i := range g.queued() {
+		r := g.rungs[i]
+		size := g.orderSize()
+		exposure := &long
+		if r.side == trading.SideSell {
+			exposure = &short
+		}
+		if !g.withinLimits(*exposure, r.side, size, g.prices[i]) {
+			continue
+		}
+		if r.side == trading.SideBuy {
+			*exposure += size
+		} else {
+			*exposure -= size
+		}
+		g.seq++
+		if g.opts.Tagger != nil {
+			r.clientOid = g.opts.Tagger.Next()
+		} else {
+			r.clientOid = fmt.Sprintf("g%d%d", time.Now().UnixMilli(), g.seq)
+		}
+		pending[r.clientOid] = i
+		orders = append(orders, g.batchOrder(r, i, size))
+	}
+	g.mu.Unlock()
+
+	var errs []error
+	for start := 0; start < len(orders); start += maxBatch {
+		end := min(start+maxBatch, len(orders))
+		res, err := trading.NewCreateBatchOrdersService(g.client).
+			Symbol(g.opts.Symbol).
+			ProductType(trading.ProductType(g.opts.ProductType)).
+			MarginCoin(g.opts.MarginCoin).
+			MarginMode(g.opts.MarginMode).
+			Orders(orders[start:end]).
+			Do(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("grid: place %d orders: %w", end-start, err))
+			continue
+		}
+		g.mu.Lock()
+		for _, info := range res.SuccessList {
+			if i, ok := pending[info.ClientOrderId]; ok {
+				if r := g.rungs[i]; r != nil && r.clientOid == info.ClientOrderId {
+					r.orderID = info.OrderId
+					g.byOrder[info.OrderId] = i
+				}
+			}
+		}
+		g.mu.Unlock()
+		for _, f := range res.FailureList {
+			errs = append(errs, fmt.Errorf("grid: place %s: %s %s", f.ClientOrderId, f.ErrorCode, f.ErrorMsg))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run rebalances after every fill and at least every interval until ctx is cancelled.
+// Placement errors are passed to onError when it is non-nil.
+func (g *Grid) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-g.wake:
+		}
+		if err := g.Rebalance(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+	}
+}
+
+// Status returns the ladder, inventory and realized profit.
+func (g *Grid) Status() Status {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	s := Status{Inventory: g.inventory, Realized: g.realized, Fills: g.fills}
+	for i, p := range g.prices {
+		level := Level{Index: i, Price: p}
+		if r, ok := g.rungs[i]; ok && r.orderID != "" {
+			level.Side, level.OrderID = r.side, r.orderID
+		}
+		s.Levels = append(s.Levels, level)
+	}
+	return s
+}
+
+// Shutdown cancels every resting grid order with the batch cancel endpoint. The
+// inventory is left open. It implements lifecycle.Component.
+func (g *Grid) Shutdown(ctx context.Context) error {
+	g.mu.Lock()
+	ids := make([]string, 0, len(g.byOrder))
+	for id := range g.byOrder {
+		ids = append(ids, id)
+	}
+	g.mu.Unlock()
+	sort.Strings(ids)
+
+	var errs []error
+	for start := 0; start < len(ids); start += maxBatch {
+		end := min(start+maxBatch, len(ids))
+		svc := trading.NewBatchCancelOrdersService(g.client).
+			Symbol(g.opts.Symbol).
+			ProductType(trading.ProductType(g.opts.ProductType)).
+			MarginCoin(g.opts.MarginCoin)
+		for _, id := range ids[start:end] {
+			svc.AddOrderId(id)
+		}
+		res, err := svc.Do(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("grid: cancel %d orders: %w", end-start, err))
+			continue
+		}
+		g.mu.Lock()
+		for _, info := range res.SuccessList {
+			if i, ok := g.byOrder[info.OrderId]; ok {
+				delete(g.byOrder, info.OrderId)
+				delete(g.rungs, i)
+			}
+		}
+		g.mu.Unlock()
+		for _, f := range res.FailureList {
+			errs = append(errs, fmt.Errorf("grid: cancel %s: %s %s", f.OrderId, f.ErrorCode, f.ErrorMsg))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// queueCounter queues the order that completes a round trip of a fill at level i.
+func (g *Grid) queueCounter(i int, side trading.Side) {
+	next, counterSide := i+1, trading.SideSell
+	if side == trading.SideSell {
+		next, counterSide = i-1, trading.SideBuy
+	}
+	if next < 0 || next >= len(g.prices) {
+		return
+	}
+	if _, busy := g.rungs[next]; !busy {
+		g.rungs[next] = &rung{side: counterSide}
+	}
+}
+
+// closing reports whether an order at level i reduces the inventory built since the
+// start: sells at or below the start level close longs, buys at or above it close shorts.
+func (g *Grid) closing(i int, side trading.Side) bool {
+	if side == trading.SideSell {
+		return i <= g.start
+	}
+	return i >= g.start
+}
+
+// spacing is the price distance a closing order at level i captured.
+func (g *Grid) spacing(i int, side trading.Side) float64 {
+	if side == trading.SideSell && i > 0 {
+		return g.prices[i] - g.prices[i-1]
+	}
+	if side == trading.SideBuy && i+1 < len(g.prices) {
+		return g.prices[i+1] - g.prices[i]
+	}
+	return 0
+}
+
+// withinLimits reports whether a fill of size at price keeps the worst-case inventory
+// within MaxPositionNotional. Orders that reduce it are always allowed.
+func (g *Grid) withinLimits(inventory float64, side trading.Side, size, price float64) bool {
+	max := g.opts.Limits.MaxPositionNotional
+	if max <= 0 {
+		return true
+	}
+	after := inventory + size
+	if side == trading.SideSell {
+		after = inventory - size
+	}
+	return math.Abs(after) <= math.Abs(inventory) || math.Abs(after)*price <= max
+}
+
+func (g *Grid) batchOrder(r *rung, i int, size float64) trading.BatchOrderInfo {
+	order := trading.BatchOrderInfo{
+		Size:          common.FormatToStep(size, g.opts.SizeStep),
+		Price:         common.FormatToStep(g.prices[i], g.opts.TickSize),
+		SideType:      r.side,
+		OrderType:     trading.OrderTypeLimit,
+		ClientOrderId: r.clientOid,
+	}
+	if g.opts.PostOnly {
+		order.TimeInForceType = trading.TimeInForcePostOnly
+	}
+	if g.opts.Hedge {
+		order.PositionSideType = trading.PositionSideOpen
+		if g.closing(i, r.side) {
+			order.PositionSideType = trading.PositionSideClose
+			// Close orders carry the side of the position they close
+			if r.side == trading.SideSell {
+				order.SideType = trading.SideBuy
+			} else {
+				order.SideType = trading.SideSell
+			}
+		}
+	}
+	return order
+}
+
+func (g *Grid) orderSize() float64 {
+	return common.RoundDownToStep(g.opts.Size, g.opts.SizeStep)
+}
+
+// nearest returns the index of the level closest to price.
+func (g *Grid) nearest(price float64) int {
+	best := 0
+	for i, p := range g.prices {
+		if math.Abs(p-price) < math.Abs(g.prices[best]-price) {
+			best = i
+		}
+	}
+	return best
+}
+
+// queued returns the levels waiting for an order: buys from the highest price down, then
+// sells from the lowest price up, so the risk limit keeps the levels nearest the market.
+func (g *Grid) queued() []int {
+	var buys, sells []int
+	for i, r := range g.rungs {
+		switch {
+		case r.orderID != "":
+		case r.side == trading.SideBuy:
+			buys = append(buys, i)
+		default:
+			sells = append(sells, i)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(buys)))
+	sort.Ints(sells)
+	return append(buys, sells...)
+}
@@ -0,0 +1,202 @@
+package grid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/config"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// batchClient accepts every batch order, assigning IDs o1, o2, ..., and every cancel.
+type batchClient struct {
+	mu        sync.Mutex
+	batches   [][]map[string]string
+	cancelled []string
+	next      int
+}
+
+func (c *batchClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ok := func(v interface{}) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+		data, _ := json.Marshal(v)
+		return &futures.ApiResponse{Code: "00000", Data: data}, &fasthttp.ResponseHeader{}, nil
+	}
+	switch endpoint {
+	case trading.EndpointBatchOrders:
+		var req struct {
+			OrderList []map[string]string `json:"orderList"`
+		}
+		_ = json.Unmarshal(body, &req)
+		c.batches = append(c.batches, req.OrderList)
+		var success []map[string]string
+		for _, o := range req.OrderList {
+			c.next++
+			success = append(success, map[string]string{"orderId": fmt.Sprintf("o%d", c.next), "clientOid": o["clientOid"]})
+		}
+		return ok(map[string]interface{}{"successList": success, "failureList": []interface{}{}})
+	case trading.EndpointBatchCancelOrders:
+		var req struct {
+			OrderIdList []map[string]string `json:"orderIdList"`
+		}
+		_ = json.Unmarshal(body, &req)
+		var success []map[string]string
+		for _, o := range req.OrderIdList {
+			c.cancelled = append(c.cancelled, o["orderId"])
+			success = append(success, map[string]string{"orderId": o["orderId"]})
+		}
+		return ok(map[string]interface{}{"successList": success, "failureList": []interface{}{}})
+	}
+	return nil, nil, fmt.Errorf("unexpected endpoint %s", endpoint)
+}
+
+func testOptions() Options {
+	return Options{
+		Symbol:      "BTCUSDT",
+		ProductType: futures.ProductTypeUSDTFutures,
+		MarginCoin:  "USDT",
+		MarginMode:  trading.MarginModeCrossed,
+		Lower:       100,
+		Upper:       140,
+		Levels:      5,
+		Size:        1,
+		SizeStep:    0.01,
+		TickSize:    0.1,
+	}
+}
+
+func filled(g *Grid, level int) tracker.OrderEvent {
+	var id string
+	for _, l := range g.Status().Levels {
+		if l.Index == level {
+			id = l.OrderID
+		}
+	}
+	return tracker.OrderEvent{Type: tracker.OrderFilled, Order: tracker.Order{OrderID: id, Size: 1, FilledSize: 1, Status: tracker.StatusFilled}}
+}
+
+func TestOptions_Prices(t *testing.T) {
+	prices, err := testOptions().Prices()
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{100, 110, 120, 130, 140}, prices, 1e-9)
+
+	o := testOptions()
+	o.Geometric = true
+	o.Levels = 3
+	o.Upper = 144
+	prices, err = o.Prices()
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{100, 120, 144}, prices, 1e-9)
+
+	o = testOptions()
+	o.Upper = 100.2
+	_, err = o.Prices()
+	assert.ErrorContains(t, err, "closer than the tick size")
+}
+
+func TestGrid_StartPlacesLadderInOneBatch(t *testing.T) {
+	client := &batchClient{}
+	g, err := New(client, testOptions())
+	require.NoError(t, err)
+
+	require.NoError(t, g.Start(context.Background(), 121))
+	require.Len(t, client.batches, 1)
+	var got []string
+	for _, o := range client.batches[0] {
+		got = append(got, o["side"]+"@"+o["price"])
+		assert.Equal(t, "1.00", o["size"])
+		assert.Equal(t, "limit", o["orderType"])
+	}
+	assert.Equal(t, []string{"buy@110.0", "buy@100.0", "sell@130.0", "sell@140.0"}, got)
+
+	levels := g.Status().Levels
+	assert.Empty(t, levels[2].OrderID, "the level at the start price stays empty")
+	assert.Equal(t, ErrStarted, g.Start(context.Background(), 121))
+}
+
+func TestGrid_FillPlacesCounterOrderAndRealizes(t *testing.T) {
+	client := &batchClient{}
+	var fills []Fill
+	opts := testOptions()
+	opts.OnFill = func(f Fill) { fills = append(fills, f) }
+	g, err := New(client, opts)
+	require.NoError(t, err)
+	require.NoError(t, g.Start(context.Background(), 121))
+
+	// The buy at 110 fills: a sell goes to the empty level at 120
+	g.HandleOrderEvent(filled(g, 1))
+	require.NoError(t, g.Rebalance(context.Background()))
+	require.Len(t, client.batches, 2)
+	assert.Equal(t, "sell", client.batches[1][0]["side"])
+	assert.Equal(t, "120.0", client.batches[1][0]["price"])
+	assert.InDelta(t, 1, g.Status().Inventory, 1e-9)
+
+	// The sell at 120 fills: one round trip, and the buy at 110 is restored
+	g.HandleOrderEvent(filled(g, 2))
+	require.NoError(t, g.Rebalance(context.Background()))
+	assert.Equal(t, "buy", client.batches[2][0]["side"])
+	assert.Equal(t, "110.0", client.batches[2][0]["price"])
+
+	s := g.Status()
+	assert.Zero(t, s.Inventory)
+	assert.InDelta(t, 10, s.Realized, 1e-9)
+	assert.Equal(t, 2, s.Fills)
+	require.Len(t, fills, 2)
+	assert.Zero(t, fills[0].Realized)
+	assert.InDelta(t, 10, fills[1].Realized, 1e-9)
+}
+
+func TestGrid_RiskLimitBlocksIncreasingOrders(t *testing.T) {
+	client := &batchClient{}
+	opts := testOptions()
+	opts.Limits = config.RiskLimits{MaxPositionNotional: 150}
+	g, err := New(client, opts)
+	require.NoError(t, err)
+
+	require.NoError(t, g.Start(context.Background(), 121))
+	// One level per side fits in 150; a second fill on either side would exceed it
+	var got []string
+	for _, o := range client.batches[0] {
+		got = append(got, o["side"]+"@"+o["price"])
+	}
+	assert.Equal(t, []string{"buy@110.0", "sell@130.0"}, got)
+}
+
+func TestGrid_HedgeModeOpensAndCloses(t *testing.T) {
+	client := &batchClient{}
+	opts := testOptions()
+	opts.Hedge = true
+	g, err := New(client, opts)
+	require.NoError(t, err)
+	require.NoError(t, g.Start(context.Background(), 121))
+	assert.Equal(t, "open", client.batches[0][0]["tradeSide"])
+
+	// Selling the long bought at 110 is a close order carrying the long side
+	g.HandleOrderEvent(filled(g, 1))
+	require.NoError(t, g.Rebalance(context.Background()))
+	assert.Equal(t, "close", client.batches[1][0]["tradeSide"])
+	assert.Equal(t, "buy", client.batches[1][0]["side"])
+}
+
+func TestGrid_ShutdownCancelsWithBatch(t *testing.T) {
+	client := &batchClient{}
+	g, err := New(client, testOptions())
+	require.NoError(t, err)
+	require.NoError(t, g.Start(context.Background(), 121))
+
+	require.NoError(t, g.Shutdown(context.Background()))
+	assert.ElementsMatch(t, []string{"o1", "o2", "o3", "o4"}, client.cancelled)
+	for _, l := range g.Status().Levels {
+		assert.Empty(t, l.OrderID)
+	}
+}
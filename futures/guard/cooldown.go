@@ -0,0 +1,279 @@
+package guard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/valyala/fasthttp"
+)
+
+// ErrCooldown is matched by every *CooldownError.
+var ErrCooldown = errors.New("guard: api cooldown")
+
+// CooldownError is returned for non-essential requests held back after an error burst.
+type CooldownError struct {
+	Endpoint   string
+	RetryAfter time.Duration
+}
+
+func (e *CooldownError) Error() string {
+	return fmt.Sprintf("guard: %s held back by api cooldown, retry after %s", e.Endpoint, e.RetryAfter)
+}
+
+// Is makes errors.Is(err, ErrCooldown) match.
+func (e *CooldownError) Is(target error) bool {
+	return target == ErrCooldown
+}
+
+// ErrorClass is the kind of a counted error.
+type ErrorClass string
+
+const (
+	ClassRateLimit ErrorClass = "rate_limit"
+	ClassAuth      ErrorClass = "auth"
+	ClassServer    ErrorClass = "server"
+)
+
+// authCodes are Bitget codes for signature, key, passphrase, timestamp and IP errors.
+var authCodes = map[int64]bool{
+	40001: true, 40002: true, 40003: true, 40004: true, 40005: true, 40006: true,
+	40008: true, 40009: true, 40011: true, 40012: true, 40014: true, 40018: true, 40037: true,
+}
+
+// serverCodes are Bitget codes for timeouts, maintenance and internal errors. Other
+// 4xxxx codes, including 45xxx order validation errors such as 45110 (below the
+// minimum amount), are rejections of the request and do not count.
+var serverCodes = map[int64]bool{
+	40010: true, // Request timed out
+	40015: true, // System is abnormal
+	40200: true, // Server upgrade
+	40725: true, // Service returned an error
+	45001: true, // Unknown error
+}
+
+// Classify returns the class of err, or false for errors that do not count towards a
+// burst, such as validation errors and cancelled contexts.
+func Classify(err error) (ErrorClass, bool) {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "", false
+	}
+	var apiErr *types.APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.Code == 429 || strings.Contains(strings.ToLower(apiErr.Message), "too many requests"):
+			return ClassRateLimit, true
+		case authCodes[apiErr.Code]:
+			return ClassAuth, true
+		case serverCodes[apiErr.Code] || apiErr.Code >= 500 && apiErr.Code < 600:
+			return ClassServer, true
+		}
+		return "", false
+	}
+	// Rejections by other guards never reached the exchange
+	if errors.Is(err, ErrThrottled) || errors.Is(err, ErrDuplicate) || errors.Is(err, ErrPositionLimit) ||
+		errors.Is(err, ErrPolicy) || errors.Is(err, ErrCooldown) {
+		return "", false
+	}
+	// Transport failures and unparseable (HTML) error pages from the gateway
+	return ClassServer, true
+}
+
+// CooldownOptions configures a Cooldown.
+type CooldownOptions struct {
+	// Threshold errors within Window trip the cooldown. Default to 5 in 10 seconds.
+	Threshold int
+	Window    time.Duration
+
+	// Cooldown is the first pause, doubled for every trip during the ramp up to
+	// MaxCooldown. Default to 30 seconds and 5 minutes.
+	Cooldown    time.Duration
+	MaxCooldown time.Duration
+
+	// After the pause non-essential requests resume gradually: the minimum spacing
+	// between them starts at RampInterval and shrinks to zero over Ramp. Default to 1
+	// second and 30 seconds.
+	Ramp         time.Duration
+	RampInterval time.Duration
+
+	// Essential reports requests that are never held back. Defaults to cancels,
+	// position closes, reduce-only and close orders, and unsigned public requests.
+	Essential func(method, endpoint string, body []byte) bool
+
+	// OnTrip is called when a cooldown starts, OnResume when requests resume fully.
+	// Optional.
+	OnTrip   func(CooldownTrip)
+	OnResume func()
+	// Clock times the cooldowns. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// CooldownTrip describes a started cooldown.
+type CooldownTrip struct {
+	Errors   map[ErrorClass]int // Errors within the window by class
+	Cooldown time.Duration
+	Until    time.Time
+}
+
+// Cooldown is a futures.ClientInterface that watches API errors and pauses
+// non-essential requests after a burst, protecting the API key from temporary bans:
+//
+//	guarded := guard.NewCooldown(client, guard.CooldownOptions{
+//		OnTrip: func(t guard.CooldownTrip) { log.Printf("api cooldown until %s: %v", t.Until, t.Errors) },
+//	})
+//
+// It is safe for concurrent use.
+type Cooldown struct {
+	next  futures.ClientInterface
+	opts  CooldownOptions
+	clock common.Clock
+
+	mu       sync.Mutex
+	errors   []classified
+	until    time.Time // End of the pause
+	rampEnd  time.Time // End of the gradual resume
+	last     time.Time // Last non-essential request let through during the ramp
+	duration time.Duration
+}
+
+type classified struct {
+	at    time.Time
+	class ErrorClass
+}
+
+// NewCooldown wraps next with burst detection.
+func NewCooldown(next futures.ClientInterface, opts CooldownOptions) *Cooldown {
+	if opts.Threshold <= 0 {
+		opts.Threshold = 5
+	}
+	if opts.Window <= 0 {
+		opts.Window = 10 * time.Second
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = 30 * time.Second
+	}
+	if opts.MaxCooldown < opts.Cooldown {
+		opts.MaxCooldown = max(5*time.Minute, opts.Cooldown)
+	}
+	if opts.Ramp <= 0 {
+		opts.Ramp = 30 * time.Second
+	}
+	if opts.RampInterval <= 0 {
+		opts.RampInterval = time.Second
+	}
+	if opts.Essential == nil {
+		opts.Essential = Essential
+	}
+	return &Cooldown{next: next, opts: opts, clock: common.ClockOrSystem(opts.Clock)}
+}
+
+// Essential is the default CooldownOptions.Essential.
+func Essential(method, endpoint string, body []byte) bool {
+	switch endpoint {
+	case futures.EndpointCancelOrder, futures.EndpointCancelAllOrders, futures.EndpointBatchCancelOrders,
+		futures.EndpointCancelPlanOrder, futures.EndpointClosePosition:
+		return true
+	}
+	orders := parseOrders(endpoint, body)
+	if len(orders) == 0 {
+		return false
+	}
+	for _, o := range orders {
+		if !o.closing() {
+			return false
+		}
+	}
+	return true
+}
+
+// CallAPI forwards the request unless a cooldown holds it back, and records the outcome.
+func (c *Cooldown) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	if sign && !c.opts.Essential(method, endpoint, body) {
+		if wait := c.admit(); wait > 0 {
+			return nil, nil, &CooldownError{Endpoint: endpoint, RetryAfter: wait}
+		}
+	}
+	res, header, err := c.next.CallAPI(ctx, method, endpoint, query, body, sign)
+	if class, ok := Classify(err); ok {
+		c.record(class)
+	}
+	return res, header, err
+}
+
+// Paused reports whether non-essential requests are currently paused and until when.
+func (c *Cooldown) Paused() (bool, time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.clock.Now().Before(c.until), c.until
+}
+
+// admit returns how long a non-essential request has to wait, zero to let it through.
+func (c *Cooldown) admit() time.Duration {
+	c.mu.Lock()
+	now := c.clock.Now()
+	var wait time.Duration
+	var resumed bool
+	switch {
+	case now.Before(c.until):
+		wait = c.until.Sub(now)
+	case now.Before(c.rampEnd):
+		progress := float64(now.Sub(c.until)) / float64(c.rampEnd.Sub(c.until))
+		spacing := time.Duration(float64(c.opts.RampInterval) * (1 - progress))
+		if next := c.last.Add(spacing); now.Before(next) {
+			wait = next.Sub(now)
+		} else {
+			c.last = now
+		}
+	case !c.rampEnd.IsZero():
+		c.rampEnd, c.until, c.duration = time.Time{}, time.Time{}, 0
+		resumed = true
+	}
+	c.mu.Unlock()
+	if resumed && c.opts.OnResume != nil {
+		c.opts.OnResume()
+	}
+	return wait
+}
+
+func (c *Cooldown) record(class ErrorClass) {
+	c.mu.Lock()
+	now := c.clock.Now()
+	c.errors = append(c.errors, classified{at: now, class: class})
+	cutoff := now.Add(-c.opts.Window)
+	i := 0
+	for i < len(c.errors) && !c.errors[i].at.After(cutoff) {
+		i++
+	}
+	c.errors = c.errors[i:]
+	if len(c.errors) < c.opts.Threshold || now.Before(c.until) {
+		c.mu.Unlock()
+		return
+	}
+
+	// A burst during the ramp escalates the pause
+	if c.duration == 0 {
+		c.duration = c.opts.Cooldown
+	} else {
+		c.duration = min(c.duration*2, c.opts.MaxCooldown)
+	}
+	c.until = now.Add(c.duration)
+	c.rampEnd = c.until.Add(c.opts.Ramp)
+	c.last = c.until.Add(-c.opts.RampInterval)
+	trip := CooldownTrip{Errors: make(map[ErrorClass]int), Cooldown: c.duration, Until: c.until}
+	for _, e := range c.errors {
+		trip.Errors[e.class]++
+	}
+	c.errors = nil
+	c.mu.Unlock()
+
+	if c.opts.OnTrip != nil {
+		c.opts.OnTrip(trip)
+	}
+}
@@ -0,0 +1,136 @@
+package guard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type failingClient struct {
+	err   error
+	calls int
+}
+
+func (c *failingClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, nil, c.err
+	}
+	return &futures.ApiResponse{Code: "00000"}, &fasthttp.ResponseHeader{}, nil
+}
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		err   error
+		class ErrorClass
+		ok    bool
+	}{
+		{&types.APIError{Code: 429, Message: "Too Many Requests"}, ClassRateLimit, true},
+		{&types.APIError{Code: 40009, Message: "sign signature error"}, ClassAuth, true},
+		{&types.APIError{Code: 45001, Message: "Unknown error"}, ClassServer, true},
+		{&types.APIError{Code: 40762, Message: "The order amount exceeds the balance"}, "", false},
+		{&types.APIError{Code: 45110, Message: "less than the minimum order amount"}, "", false},
+		{fmt.Errorf("error parsing API response: %w", errors.New("invalid character '<'")), ClassServer, true},
+		{context.Canceled, "", false},
+		{&ThrottleError{Limit: LimitOrders}, "", false},
+	}
+	for _, c := range cases {
+		class, ok := Classify(c.err)
+		assert.Equal(t, c.ok, ok, c.err.Error())
+		assert.Equal(t, c.class, class, c.err.Error())
+	}
+}
+
+func TestCooldown_TripAndRamp(t *testing.T) {
+	client := &failingClient{err: &types.APIError{Code: 429, Message: "Too Many Requests"}}
+	var trips []CooldownTrip
+	resumed := false
+	now := time.Unix(1700000000, 0)
+	clock := common.NewFakeClock(now)
+	c := NewCooldown(client, CooldownOptions{
+		Threshold: 3, Window: 10 * time.Second,
+		Cooldown: 30 * time.Second, Ramp: 10 * time.Second, RampInterval: 2 * time.Second,
+		OnTrip:   func(tr CooldownTrip) { trips = append(trips, tr) },
+		OnResume: func() { resumed = true },
+		Clock:    clock,
+	})
+	call := func(endpoint string, body string) error {
+		_, _, err := c.CallAPI(context.Background(), "POST", endpoint, nil, []byte(body), true)
+		return err
+	}
+
+	for i := 0; i < 3; i++ {
+		require.Error(t, call(futures.EndpointPendingOrders, ""))
+	}
+	require.Len(t, trips, 1)
+	assert.Equal(t, 3, trips[0].Errors[ClassRateLimit])
+	paused, until := c.Paused()
+	assert.True(t, paused)
+	assert.Equal(t, now.Add(30*time.Second), until)
+
+	client.err = nil
+	err := call(futures.EndpointPlaceOrder, `{"symbol":"BTCUSDT","side":"buy","size":"1"}`)
+	var ce *CooldownError
+	require.ErrorAs(t, err, &ce)
+	assert.Equal(t, 30*time.Second, ce.RetryAfter)
+	assert.Equal(t, 3, client.calls, "held back locally")
+
+	// Essential requests pass during the pause
+	require.NoError(t, call(futures.EndpointCancelOrder, `{"orderId":"1"}`))
+	require.NoError(t, call(futures.EndpointPlaceOrder, `{"symbol":"BTCUSDT","side":"sell","size":"1","reduceOnly":"YES"}`))
+	_, _, err = c.CallAPI(context.Background(), "GET", futures.EndpointTicker, nil, nil, false)
+	require.NoError(t, err, "public requests are not held back")
+
+	// Ramp: spacing starts at 2s and shrinks to zero over 10s
+	clock.Set(until)
+	require.NoError(t, call(futures.EndpointPendingOrders, ""))
+	clock.Advance(time.Second)
+	require.ErrorIs(t, call(futures.EndpointPendingOrders, ""), ErrCooldown)
+	clock.Advance(time.Second)
+	require.NoError(t, call(futures.EndpointPendingOrders, ""))
+
+	clock.Set(until.Add(10 * time.Second))
+	require.NoError(t, call(futures.EndpointPendingOrders, ""))
+	assert.True(t, resumed)
+	paused, _ = c.Paused()
+	assert.False(t, paused)
+}
+
+func TestCooldown_EscalatesDuringRamp(t *testing.T) {
+	client := &failingClient{err: &types.APIError{Code: 40009, Message: "sign signature error"}}
+	var trips []CooldownTrip
+	clock := common.NewFakeClock(time.Unix(1700000000, 0))
+	c := NewCooldown(client, CooldownOptions{Threshold: 1, Cooldown: 10 * time.Second, MaxCooldown: 15 * time.Second,
+		OnTrip: func(tr CooldownTrip) { trips = append(trips, tr) }, Clock: clock})
+
+	_, _, _ = c.CallAPI(context.Background(), "GET", futures.EndpointPendingOrders, nil, nil, true)
+	clock.Advance(10 * time.Second)
+	_, _, _ = c.CallAPI(context.Background(), "GET", futures.EndpointPendingOrders, nil, nil, true)
+	require.Len(t, trips, 2)
+	assert.Equal(t, 10*time.Second, trips[0].Cooldown)
+	assert.Equal(t, 15*time.Second, trips[1].Cooldown, "doubled, capped at MaxCooldown")
+	assert.Equal(t, 1, trips[1].Errors[ClassAuth])
+}
+
+func TestCooldown_IgnoresValidationErrors(t *testing.T) {
+	client := &failingClient{err: &types.APIError{Code: 45110, Message: "less than the minimum order amount"}}
+	c := NewCooldown(client, CooldownOptions{Threshold: 2})
+	for i := 0; i < 5; i++ {
+		_, _, err := c.CallAPI(context.Background(), "POST", futures.EndpointPlaceOrder, nil, []byte(`{"symbol":"BTCUSDT","side":"buy","size":"0.0001"}`), true)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrCooldown, "undersized orders are not counted")
+	}
+	paused, _ := c.Paused()
+	assert.False(t, paused)
+	assert.Equal(t, 5, client.calls)
+}
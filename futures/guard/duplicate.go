@@ -0,0 +1,168 @@
+package guard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/valyala/fasthttp"
+)
+
+// ErrDuplicate is matched by every *DuplicateError.
+var ErrDuplicate = errors.New("guard: duplicate order")
+
+// DuplicateError reports an order identical to one submitted within the window.
+type DuplicateError struct {
+	Symbol string
+	Side   string
+	Price  string // Empty for market orders
+	Size   string
+	// Age is the time since the identical order was submitted.
+	Age time.Duration
+}
+
+func (e *DuplicateError) Error() string {
+	price := e.Price
+	if price == "" {
+		price = "market"
+	}
+	return fmt.Sprintf("guard: duplicate order %s %s %s@%s submitted %s ago", e.Symbol, e.Side, e.Size, price, e.Age)
+}
+
+// Is makes errors.Is(err, ErrDuplicate) match.
+func (e *DuplicateError) Is(target error) bool {
+	return target == ErrDuplicate
+}
+
+// DuplicateOptions configures a Dedup.
+type DuplicateOptions struct {
+	// Window is how long a submitted order blocks identical ones. Defaults to 2 seconds.
+	Window time.Duration
+	// WarnOnly reports duplicates through OnDuplicate but lets them through.
+	WarnOnly bool
+	// OnDuplicate is called for every duplicate, blocked or not. Optional.
+	OnDuplicate func(*DuplicateError)
+	// Clock measures the duplicate window. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// Dedup is a futures.ClientInterface that blocks identical order submissions (same
+// product type, symbol, plan type, side, price, trigger price and size) within a window,
+// catching double-fires from retry bugs:
+//
+//	client := guard.NewDedup(futuresClient, guard.DuplicateOptions{Window: 3 * time.Second})
+//
+// Resubmitting with the clientOid of the earlier order is let through, as the exchange
+// deduplicates on clientOid itself. Orders the exchange rejected do not block retries.
+// It is safe for concurrent use and can be stacked with Guard.
+type Dedup struct {
+	next  futures.ClientInterface
+	opts  DuplicateOptions
+	clock common.Clock
+
+	mu   sync.Mutex
+	seen map[string]submission
+}
+
+type submission struct {
+	at        time.Time
+	clientOid string
+}
+
+// NewDedup wraps next with duplicate detection.
+func NewDedup(next futures.ClientInterface, opts DuplicateOptions) *Dedup {
+	if opts.Window <= 0 {
+		opts.Window = 2 * time.Second
+	}
+	return &Dedup{next: next, opts: opts, clock: common.ClockOrSystem(opts.Clock), seen: make(map[string]submission)}
+}
+
+// CallAPI forwards the request unless it contains an order identical to one submitted
+// within the window, in which case a *DuplicateError is returned.
+func (d *Dedup) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	orders := parseOrders(endpoint, body)
+	if len(orders) == 0 {
+		return d.next.CallAPI(ctx, method, endpoint, query, body, sign)
+	}
+	keys, err := d.register(orders)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, header, err := d.next.CallAPI(ctx, method, endpoint, query, body, sign)
+	if types.IsAPIError(err) {
+		// Nothing was placed, so an identical retry is legitimate
+		d.forget(keys)
+	}
+	return res, header, err
+}
+
+// register records the orders unless one of them is a blocked duplicate. It returns
+// the keys it recorded.
+func (d *Dedup) register(orders []order) ([]string, error) {
+	d.mu.Lock()
+	now := d.clock.Now()
+	for key, s := range d.seen {
+		if now.Sub(s.at) >= d.opts.Window {
+			delete(d.seen, key)
+		}
+	}
+
+	var dups []*DuplicateError
+	keys := make([]string, 0, len(orders))
+	batch := make(map[string]bool, len(orders))
+	for _, o := range orders {
+		key := strings.Join([]string{o.ProductType, o.Symbol, o.PlanType, o.Side, o.TradeSide,
+			normalize(o.Price), normalize(o.TriggerPrice), normalize(o.Size)}, "|")
+		prev, seen := d.seen[key]
+		switch {
+		case batch[key]:
+			dups = append(dups, &DuplicateError{Symbol: o.Symbol, Side: o.Side, Price: o.Price, Size: o.Size})
+		case seen && (o.ClientOid == "" || o.ClientOid != prev.clientOid):
+			dups = append(dups, &DuplicateError{Symbol: o.Symbol, Side: o.Side, Price: o.Price, Size: o.Size, Age: now.Sub(prev.at)})
+		}
+		batch[key] = true
+		keys = append(keys, key)
+	}
+	blocked := len(dups) > 0 && !d.opts.WarnOnly
+	if !blocked {
+		for i, o := range orders {
+			d.seen[keys[i]] = submission{at: now, clientOid: o.ClientOid}
+		}
+	}
+	d.mu.Unlock()
+
+	if d.opts.OnDuplicate != nil {
+		for _, dup := range dups {
+			d.opts.OnDuplicate(dup)
+		}
+	}
+	if blocked {
+		return nil, dups[0]
+	}
+	return keys, nil
+}
+
+func (d *Dedup) forget(keys []string) {
+	d.mu.Lock()
+	for _, key := range keys {
+		delete(d.seen, key)
+	}
+	d.mu.Unlock()
+}
+
+// normalize formats numbers canonically so "0.010" and "0.01" match.
+func normalize(s string) string {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return s
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,96 @@
+package guard
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type rejectingClient struct {
+	calls  int
+	reject bool
+}
+
+func (c *rejectingClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.calls++
+	if c.reject {
+		return nil, nil, &types.APIError{Code: 40762, Message: "The order amount exceeds the balance"}
+	}
+	return &futures.ApiResponse{Code: "00000", Data: []byte(`{}`)}, &fasthttp.ResponseHeader{}, nil
+}
+
+func submit(d *Dedup, body string) error {
+	_, _, err := d.CallAPI(context.Background(), "POST", futures.EndpointPlaceOrder, nil, []byte(body), true)
+	return err
+}
+
+func TestDedup_BlocksWithinWindow(t *testing.T) {
+	client := &rejectingClient{}
+	var reported []*DuplicateError
+	clock := common.NewFakeClock(time.Unix(1700000000, 0))
+	d := NewDedup(client, DuplicateOptions{Window: 2 * time.Second, Clock: clock, OnDuplicate: func(e *DuplicateError) { reported = append(reported, e) }})
+
+	require.NoError(t, submit(d, `{"symbol":"BTCUSDT","side":"buy","size":"0.010","price":"50000","clientOid":"a"}`))
+	clock.Advance(time.Second)
+
+	err := submit(d, `{"symbol":"BTCUSDT","side":"buy","size":"0.01","price":"50000.0","clientOid":"b"}`)
+	require.ErrorIs(t, err, ErrDuplicate)
+	assert.Contains(t, err.Error(), "submitted 1s ago")
+	require.Len(t, reported, 1)
+	assert.Equal(t, 1, client.calls)
+
+	// Same clientOid is an idempotent retry; other sides and sizes are distinct orders
+	require.NoError(t, submit(d, `{"symbol":"BTCUSDT","side":"buy","size":"0.01","price":"50000","clientOid":"a"}`))
+	require.NoError(t, submit(d, `{"symbol":"BTCUSDT","side":"sell","size":"0.01","price":"50000"}`))
+	require.NoError(t, submit(d, `{"symbol":"BTCUSDT","side":"buy","size":"0.02","price":"50000"}`))
+
+	clock.Advance(2 * time.Second)
+	require.NoError(t, submit(d, `{"symbol":"BTCUSDT","side":"buy","size":"0.02","price":"50000"}`), "window expired")
+}
+
+func TestDedup_WarnOnlyAndBatch(t *testing.T) {
+	client := &rejectingClient{}
+	var reported int
+	d := NewDedup(client, DuplicateOptions{WarnOnly: true, OnDuplicate: func(*DuplicateError) { reported++ }})
+
+	_, _, err := d.CallAPI(context.Background(), "POST", futures.EndpointBatchPlaceOrder, nil,
+		[]byte(`{"symbol":"ETHUSDT","orderList":[{"side":"buy","size":"1","price":"3000"},{"side":"buy","size":"1","price":"3000"}]}`), true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reported, "duplicate inside the batch")
+	assert.Equal(t, 1, client.calls)
+}
+
+func TestDedup_RejectedOrderDoesNotBlockRetry(t *testing.T) {
+	client := &rejectingClient{reject: true}
+	d := NewDedup(client, DuplicateOptions{})
+
+	body := `{"symbol":"BTCUSDT","side":"buy","size":"0.01","orderType":"market"}`
+	assert.True(t, types.IsAPIError(submit(d, body)))
+	client.reject = false
+	require.NoError(t, submit(d, body))
+	assert.ErrorIs(t, submit(d, body), ErrDuplicate)
+}
+
+func TestDedup_PlanOrdersByTrigger(t *testing.T) {
+	client := &rejectingClient{}
+	d := NewDedup(client, DuplicateOptions{})
+	plan := func(body string) error {
+		_, _, err := d.CallAPI(context.Background(), "POST", futures.EndpointPlacePlanOrder, nil, []byte(body), true)
+		return err
+	}
+
+	require.NoError(t, plan(`{"symbol":"BTCUSDT","productType":"USDT-FUTURES","planType":"normal_plan","side":"sell","size":"0.01","orderType":"market","triggerPrice":"49000"}`))
+	require.NoError(t, plan(`{"symbol":"BTCUSDT","productType":"USDT-FUTURES","planType":"normal_plan","side":"sell","size":"0.01","orderType":"market","triggerPrice":"48000"}`), "another stop")
+	require.NoError(t, plan(`{"symbol":"BTCUSDT","productType":"USDT-FUTURES","planType":"track_plan","side":"sell","size":"0.01","orderType":"market","triggerPrice":"49000"}`), "another plan type")
+	require.NoError(t, plan(`{"symbol":"BTCUSDT","productType":"SUSDT-FUTURES","planType":"normal_plan","side":"sell","size":"0.01","orderType":"market","triggerPrice":"49000"}`), "another product type")
+	require.ErrorIs(t, plan(`{"symbol":"BTCUSDT","productType":"USDT-FUTURES","planType":"normal_plan","side":"sell","size":"0.01","orderType":"market","triggerPrice":"49000.0"}`), ErrDuplicate)
+	assert.Equal(t, 4, client.calls)
+}
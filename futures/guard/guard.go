@@ -0,0 +1,258 @@
+// Package guard caps order submission locally before requests reach the exchange.
+//
+// A Guard wraps a futures.ClientInterface and counts every order sent through the
+// place, batch place and plan order endpoints. Orders that would exceed the configured
+// count or notional within the sliding window are rejected with a *ThrottleError
+// without calling the exchange, which stops a runaway strategy loop from flooding the
+// account. Every service accepts the guard in place of the client:
+//
+//	guarded := guard.New(client, guard.Limits{
+//		Window:               time.Minute,
+//		MaxOrders:            60,
+//		MaxOrdersPerSymbol:   20,
+//		MaxNotionalPerSymbol: 50000,
+//	})
+//	_, err := trading.NewCreateOrderService(guarded).Symbol("BTCUSDT")...Do(ctx)
+//	if errors.Is(err, guard.ErrThrottled) {
+//		// back off
+//	}
+//
+// Dedup blocks identical orders submitted within a short window, PositionGuard rejects
+// orders that would take a position past size or notional limits, Policy blocks denied
+// symbols, trading outside configured windows and blackouts, and Cooldown pauses
+// non-essential requests after a burst of exchange errors. The guards
+// can be stacked, e.g. guard.NewDedup(guard.New(client, limits), guard.DuplicateOptions{}).
+package guard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/valyala/fasthttp"
+)
+
+// ErrThrottled is matched by every *ThrottleError.
+var ErrThrottled = errors.New("guard: order throttled")
+
+// Limit names reported in ThrottleError.Limit.
+const (
+	LimitOrders            = "orders"
+	LimitOrdersPerSymbol   = "orders_per_symbol"
+	LimitNotional          = "notional"
+	LimitNotionalPerSymbol = "notional_per_symbol"
+)
+
+// ThrottleError is returned for requests rejected locally.
+type ThrottleError struct {
+	Limit  string
+	Symbol string // Set for per-symbol limits
+	// Current is the usage of the window including the rejected request; Max is the cap.
+	Current float64
+	Max     float64
+	// RetryAfter is when enough of the window expires for the request to fit, or zero
+	// when the request exceeds the cap on its own.
+	RetryAfter time.Duration
+}
+
+func (e *ThrottleError) Error() string {
+	subject := e.Limit
+	if e.Symbol != "" {
+		subject += " " + e.Symbol
+	}
+	return fmt.Sprintf("guard: %s limit exceeded: %s > %s in window, retry after %s",
+		subject, strconv.FormatFloat(e.Current, 'f', -1, 64), strconv.FormatFloat(e.Max, 'f', -1, 64), e.RetryAfter)
+}
+
+// Is makes errors.Is(err, ErrThrottled) match.
+func (e *ThrottleError) Is(target error) bool {
+	return target == ErrThrottled
+}
+
+// Limits are the caps within one sliding window. Zero disables a cap.
+type Limits struct {
+	// Window is the length of the sliding window. Defaults to one minute.
+	Window time.Duration
+
+	MaxOrders          int
+	MaxOrdersPerSymbol int
+
+	// Notional caps value orders at size times price. Orders without a price (market
+	// orders) are valued through Price and count zero when it is not set.
+	MaxNotional          float64
+	MaxNotionalPerSymbol float64
+
+	// ExemptReduceOnly lets reduce-only orders, and close orders in hedge mode, through
+	// uncounted, so positions can still be closed while the guard is tripped.
+	ExemptReduceOnly bool
+
+	// Price returns a reference price for orders without one. Optional.
+	Price func(symbol string) float64
+}
+
+type entry struct {
+	at       time.Time
+	symbol   string
+	notional float64
+}
+
+// Guard is a futures.ClientInterface that enforces Limits on order submission. It is
+// safe for concurrent use.
+type Guard struct {
+	next   futures.ClientInterface
+	limits Limits
+	clock  common.Clock
+
+	mu      sync.Mutex
+	entries []entry
+}
+
+// New wraps next with limits.
+func New(next futures.ClientInterface, limits Limits) *Guard {
+	if limits.Window <= 0 {
+		limits.Window = time.Minute
+	}
+	return &Guard{next: next, limits: limits, clock: common.SystemClock()}
+}
+
+// SetClock sets the clock of the order rate windows, e.g. a common.FakeClock in tests.
+func (g *Guard) SetClock(clock common.Clock) {
+	g.clock = common.ClockOrSystem(clock)
+}
+
+// CallAPI counts order submissions against the limits and forwards the request when it
+// fits. Rejected requests return a *ThrottleError. Requests are counted when sent, so
+// failing orders still consume the window.
+func (g *Guard) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	if orders := g.orders(endpoint, body); len(orders) > 0 {
+		if err := g.reserve(orders); err != nil {
+			return nil, nil, err
+		}
+	}
+	return g.next.CallAPI(ctx, method, endpoint, query, body, sign)
+}
+
+// Usage returns the number of orders and the notional counted in the current window,
+// for symbol or for all symbols when symbol is empty.
+func (g *Guard) Usage(symbol string) (orders int, notional float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.expire(g.clock.Now())
+	for _, e := range g.entries {
+		if symbol == "" || e.symbol == symbol {
+			orders++
+			notional += e.notional
+		}
+	}
+	return orders, notional
+}
+
+// orders values the orders of a submission request.
+func (g *Guard) orders(endpoint string, body []byte) []entry {
+	list := parseOrders(endpoint, body)
+	entries := make([]entry, 0, len(list))
+	for _, o := range list {
+		if g.limits.ExemptReduceOnly && o.closing() {
+			continue
+		}
+		size, _ := strconv.ParseFloat(o.Size, 64)
+		price, _ := strconv.ParseFloat(o.Price, 64)
+		if price <= 0 {
+			price, _ = strconv.ParseFloat(o.TriggerPrice, 64)
+		}
+		if price <= 0 && g.limits.Price != nil {
+			price = g.limits.Price(o.Symbol)
+		}
+		entries = append(entries, entry{symbol: o.Symbol, notional: size * price})
+	}
+	return entries
+}
+
+// reserve records orders if all of them fit the limits.
+func (g *Guard) reserve(orders []entry) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := g.clock.Now()
+	g.expire(now)
+
+	symbol := orders[0].symbol
+	var notional float64
+	for _, o := range orders {
+		notional += o.notional
+	}
+	n := len(orders)
+	l := g.limits
+	if l.MaxOrders > 0 {
+		if err := g.check(LimitOrders, "", float64(n), float64(l.MaxOrders), now, func(entry) float64 { return 1 }); err != nil {
+			return err
+		}
+	}
+	if l.MaxOrdersPerSymbol > 0 {
+		if err := g.check(LimitOrdersPerSymbol, symbol, float64(n), float64(l.MaxOrdersPerSymbol), now, func(entry) float64 { return 1 }); err != nil {
+			return err
+		}
+	}
+	if l.MaxNotional > 0 {
+		if err := g.check(LimitNotional, "", notional, l.MaxNotional, now, func(e entry) float64 { return e.notional }); err != nil {
+			return err
+		}
+	}
+	if l.MaxNotionalPerSymbol > 0 {
+		if err := g.check(LimitNotionalPerSymbol, symbol, notional, l.MaxNotionalPerSymbol, now, func(e entry) float64 { return e.notional }); err != nil {
+			return err
+		}
+	}
+
+	for _, o := range orders {
+		o.at = now
+		g.entries = append(g.entries, o)
+	}
+	return nil
+}
+
+// check returns a ThrottleError when adding add to the usage of the window, filtered
+// by symbol when set, would exceed max.
+func (g *Guard) check(limit, symbol string, add, max float64, now time.Time, weight func(entry) float64) error {
+	var used float64
+	for _, e := range g.entries {
+		if symbol == "" || e.symbol == symbol {
+			used += weight(e)
+		}
+	}
+	if used+add <= max {
+		return nil
+	}
+	err := &ThrottleError{Limit: limit, Symbol: symbol, Current: used + add, Max: max}
+	if add > max {
+		return err
+	}
+	// Walk the window from the oldest entry until enough usage has expired
+	excess := used + add - max
+	for _, e := range g.entries {
+		if symbol != "" && e.symbol != symbol {
+			continue
+		}
+		excess -= weight(e)
+		if excess <= 0 {
+			err.RetryAfter = e.at.Add(g.limits.Window).Sub(now)
+			break
+		}
+	}
+	return err
+}
+
+// expire drops entries that left the window.
+func (g *Guard) expire(now time.Time) {
+	cutoff := now.Add(-g.limits.Window)
+	i := 0
+	for i < len(g.entries) && !g.entries[i].at.After(cutoff) {
+		i++
+	}
+	g.entries = g.entries[i:]
+}
@@ -0,0 +1,99 @@
+package guard
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type countingClient struct {
+	calls int
+}
+
+func (c *countingClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.calls++
+	return &futures.ApiResponse{Code: "00000", Data: []byte(`{}`)}, &fasthttp.ResponseHeader{}, nil
+}
+
+func place(g *Guard, body string) error {
+	_, _, err := g.CallAPI(context.Background(), "POST", futures.EndpointPlaceOrder, nil, []byte(body), true)
+	return err
+}
+
+func TestGuard_MaxOrdersSlidingWindow(t *testing.T) {
+	client := &countingClient{}
+	g := New(client, Limits{Window: time.Minute, MaxOrders: 2})
+	clock := common.NewFakeClock(time.Unix(1700000000, 0))
+	g.SetClock(clock)
+
+	require.NoError(t, place(g, `{"symbol":"BTCUSDT","size":"0.01","price":"50000"}`))
+	clock.Advance(20 * time.Second)
+	require.NoError(t, place(g, `{"symbol":"ETHUSDT","size":"1","price":"3000"}`))
+
+	err := place(g, `{"symbol":"BTCUSDT","size":"0.01","price":"50000"}`)
+	require.ErrorIs(t, err, ErrThrottled)
+	var te *ThrottleError
+	require.True(t, errors.As(err, &te))
+	assert.Equal(t, LimitOrders, te.Limit)
+	assert.Equal(t, 40*time.Second, te.RetryAfter)
+	assert.Equal(t, 2, client.calls, "rejected locally")
+
+	// Reads pass through untouched
+	_, _, err = g.CallAPI(context.Background(), "GET", futures.EndpointPendingOrders, nil, nil, true)
+	require.NoError(t, err)
+
+	clock.Advance(40 * time.Second)
+	require.NoError(t, place(g, `{"symbol":"BTCUSDT","size":"0.01","price":"50000"}`))
+	orders, _ := g.Usage("")
+	assert.Equal(t, 2, orders)
+}
+
+func TestGuard_PerSymbolAndNotional(t *testing.T) {
+	client := &countingClient{}
+	g := New(client, Limits{
+		MaxOrdersPerSymbol:   1,
+		MaxNotionalPerSymbol: 2000,
+		MaxNotional:          5000,
+		Price:                func(symbol string) float64 { return 3000 },
+	})
+
+	require.NoError(t, place(g, `{"symbol":"BTCUSDT","size":"0.02","price":"50000"}`))
+	err := place(g, `{"symbol":"BTCUSDT","size":"0.001","price":"50000"}`)
+	var te *ThrottleError
+	require.ErrorAs(t, err, &te)
+	assert.Equal(t, LimitOrdersPerSymbol, te.Limit)
+	assert.Equal(t, "BTCUSDT", te.Symbol)
+
+	// Market order valued through Price: 1 * 3000 exceeds the per-symbol notional cap
+	err = place(g, `{"symbol":"ETHUSDT","size":"1","orderType":"market"}`)
+	require.ErrorAs(t, err, &te)
+	assert.Equal(t, LimitNotionalPerSymbol, te.Limit)
+	assert.Zero(t, te.RetryAfter, "the order alone exceeds the cap")
+
+	// A batch counts every order
+	_, _, err = g.CallAPI(context.Background(), "POST", futures.EndpointBatchPlaceOrder, nil,
+		[]byte(`{"symbol":"SOLUSDT","orderList":[{"size":"10","price":"150"},{"size":"10","price":"150"}]}`), true)
+	require.ErrorAs(t, err, &te)
+	assert.Equal(t, LimitOrdersPerSymbol, te.Limit)
+	assert.Equal(t, 2.0, te.Current)
+	assert.Equal(t, 1, client.calls)
+}
+
+func TestGuard_ExemptReduceOnly(t *testing.T) {
+	client := &countingClient{}
+	g := New(client, Limits{MaxOrders: 1, ExemptReduceOnly: true})
+
+	require.NoError(t, place(g, `{"symbol":"BTCUSDT","size":"0.01","price":"50000"}`))
+	require.ErrorIs(t, place(g, `{"symbol":"BTCUSDT","size":"0.01","price":"50000"}`), ErrThrottled)
+	require.NoError(t, place(g, `{"symbol":"BTCUSDT","size":"0.01","reduceOnly":"YES"}`))
+	require.NoError(t, place(g, `{"symbol":"BTCUSDT","size":"0.01","side":"buy","tradeSide":"close"}`), "hedge-mode close")
+	assert.Equal(t, 3, client.calls)
+}
@@ -0,0 +1,57 @@
+package guard
+
+import (
+	"encoding/json"
+
+	"github.com/khanbekov/go-bitget/futures"
+)
+
+// order is one order of a submission request.
+type order struct {
+	Symbol       string `json:"symbol"`
+	ProductType  string `json:"productType"`
+	PlanType     string `json:"planType"`
+	Side         string `json:"side"`
+	TradeSide    string `json:"tradeSide"`
+	Size         string `json:"size"`
+	Price        string `json:"price"`
+	TriggerPrice string `json:"triggerPrice"`
+	ReduceOnly   string `json:"reduceOnly"`
+	ClientOid    string `json:"clientOid"`
+}
+
+func (o order) reduceOnly() bool {
+	return o.ReduceOnly == "YES" || o.ReduceOnly == "yes"
+}
+
+// closing reports orders that can only reduce a position: reduce-only orders in one-way
+// mode and close orders in hedge mode.
+func (o order) closing() bool {
+	return o.reduceOnly() || o.TradeSide == "close"
+}
+
+// parseOrders extracts the orders of a place, plan or batch place request. Other
+// endpoints and undecodable bodies return nil.
+func parseOrders(endpoint string, body []byte) []order {
+	switch endpoint {
+	case futures.EndpointPlaceOrder, futures.EndpointPlacePlanOrder, futures.EndpointBatchPlaceOrder:
+	default:
+		return nil
+	}
+	var req struct {
+		order
+		OrderList []order `json:"orderList"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil
+	}
+	if endpoint != futures.EndpointBatchPlaceOrder {
+		return []order{req.order}
+	}
+	// Batch entries inherit the symbol and product type of the request
+	for i := range req.OrderList {
+		req.OrderList[i].Symbol = req.Symbol
+		req.OrderList[i].ProductType = req.ProductType
+	}
+	return req.OrderList
+}
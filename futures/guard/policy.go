@@ -0,0 +1,281 @@
+package guard
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/schedule"
+	"github.com/valyala/fasthttp"
+)
+
+// ErrPolicy is matched by every *PolicyError.
+var ErrPolicy = errors.New("guard: order blocked by policy")
+
+// PolicyError is returned for orders blocked by a Policy.
+type PolicyError struct {
+	Symbol string
+	Reason string
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("guard: order on %s blocked: %s", e.Symbol, e.Reason)
+}
+
+// Is makes errors.Is(err, ErrPolicy) match.
+func (e *PolicyError) Is(target error) bool {
+	return target == ErrPolicy
+}
+
+// Window is a daily trading window in UTC. End before Start wraps past midnight.
+type Window struct {
+	Start time.Duration // Offset from midnight, e.g. 8 * time.Hour
+	End   time.Duration
+	// Weekdays restricts the window to these days, every day when empty. The day is
+	// the one the window starts on.
+	Weekdays []time.Weekday
+}
+
+// contains reports whether t falls inside the window.
+func (w Window) contains(t time.Time) bool {
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := t.Sub(midnight)
+	if w.End > w.Start {
+		return offset >= w.Start && offset < w.End && w.onDay(t.Weekday())
+	}
+	// Wrapping window: the evening part starts today, the morning part yesterday
+	if offset >= w.Start {
+		return w.onDay(t.Weekday())
+	}
+	return offset < w.End && w.onDay(midnight.Add(-time.Hour).Weekday())
+}
+
+func (w Window) onDay(day time.Weekday) bool {
+	if len(w.Weekdays) == 0 {
+		return true
+	}
+	for _, d := range w.Weekdays {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Blackout blocks trading around an instant: a fixed time such as a delivery, or every
+// multiple of Interval since the Unix epoch such as funding settlements.
+type Blackout struct {
+	Name     string
+	At       time.Time
+	Interval time.Duration
+	// Before and After are the blocked time around each instant.
+	Before time.Duration
+	After  time.Duration
+	// Symbols limits the blackout to these symbols, all symbols when empty.
+	Symbols []string
+}
+
+// active reports whether t falls inside the blackout for symbol.
+func (b Blackout) active(symbol string, t time.Time) bool {
+	if len(b.Symbols) > 0 && !contains(b.Symbols, symbol) {
+		return false
+	}
+	if b.Interval > 0 {
+		next := schedule.NextBoundary(t, b.Interval)
+		prev := next.Add(-b.Interval)
+		return next.Sub(t) <= b.Before || t.Sub(prev) < b.After
+	}
+	if b.At.IsZero() {
+		return false
+	}
+	return !t.Before(b.At.Add(-b.Before)) && t.Before(b.At.Add(b.After))
+}
+
+// PolicyOptions configures a Policy.
+type PolicyOptions struct {
+	// Deny lists symbols that may not be traded.
+	Deny []string
+	// Windows restricts trading to these windows. Trading is allowed at any time when
+	// empty.
+	Windows   []Window
+	Blackouts []Blackout
+
+	// AllowReduceOnly lets reduce-only and close orders through outside the windows and
+	// during blackouts, so positions can still be closed. Denied symbols stay blocked.
+	AllowReduceOnly bool
+
+	// Offset converts local time to exchange time. Optional.
+	Offset schedule.OffsetSource
+	// Clock evaluates trading windows and override expiry. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// Policy is a futures.ClientInterface that blocks order placement on denied symbols,
+// outside trading windows and during blackouts:
+//
+//	policy := guard.NewPolicy(client, guard.PolicyOptions{
+//		Deny:      []string{"LUNAUSDT"},
+//		Blackouts: []guard.Blackout{{Name: "funding", Interval: 8 * time.Hour, Before: 2 * time.Minute, After: time.Minute}},
+//	})
+//
+// Manual intervention bypasses the policy with an override token carried in the context:
+//
+//	token := policy.IssueOverride(10 * time.Minute)
+//	_, err := trading.NewCreateOrderService(policy)...Do(guard.WithOverride(ctx, token))
+//
+// Policy is safe for concurrent use.
+type Policy struct {
+	next  futures.ClientInterface
+	opts  PolicyOptions
+	clock common.Clock
+
+	mu        sync.RWMutex
+	deny      map[string]bool
+	overrides map[string]time.Time
+}
+
+// NewPolicy wraps next with the policy.
+func NewPolicy(next futures.ClientInterface, opts PolicyOptions) *Policy {
+	if opts.Offset == nil {
+		opts.Offset = schedule.StaticOffset(0)
+	}
+	p := &Policy{
+		next:      next,
+		opts:      opts,
+		clock:     common.ClockOrSystem(opts.Clock),
+		deny:      make(map[string]bool),
+		overrides: make(map[string]time.Time),
+	}
+	for _, symbol := range opts.Deny {
+		p.deny[symbol] = true
+	}
+	return p
+}
+
+// CallAPI forwards the request unless the policy blocks one of its orders, in which case
+// a *PolicyError is returned.
+func (p *Policy) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	if orders := parseOrders(endpoint, body); len(orders) > 0 && !p.overridden(ctx) {
+		for _, o := range orders {
+			if err := p.check(o); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return p.next.CallAPI(ctx, method, endpoint, query, body, sign)
+}
+
+// Deny adds symbol to the deny-list.
+func (p *Policy) Deny(symbol string) {
+	p.mu.Lock()
+	p.deny[symbol] = true
+	p.mu.Unlock()
+}
+
+// Allow removes symbol from the deny-list.
+func (p *Policy) Allow(symbol string) {
+	p.mu.Lock()
+	delete(p.deny, symbol)
+	p.mu.Unlock()
+}
+
+// Allowed reports whether an order on symbol would pass the policy now.
+func (p *Policy) Allowed(symbol string) error {
+	return p.check(order{Symbol: symbol})
+}
+
+// IssueOverride returns a token that bypasses the policy for ttl when passed through
+// WithOverride.
+func (p *Policy) IssueOverride(ttl time.Duration) string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	token := hex.EncodeToString(b)
+	p.mu.Lock()
+	p.overrides[token] = p.clock.Now().Add(ttl)
+	p.mu.Unlock()
+	return token
+}
+
+// RevokeOverride invalidates a token.
+func (p *Policy) RevokeOverride(token string) {
+	p.mu.Lock()
+	delete(p.overrides, token)
+	p.mu.Unlock()
+}
+
+type overrideKey struct{}
+
+// WithOverride returns a context carrying an override token for Policy.
+func WithOverride(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, overrideKey{}, token)
+}
+
+func (p *Policy) overridden(ctx context.Context) bool {
+	token, ok := ctx.Value(overrideKey{}).(string)
+	if !ok {
+		return false
+	}
+	now := p.clock.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for t, expires := range p.overrides {
+		if !now.Before(expires) {
+			delete(p.overrides, t)
+		}
+	}
+	_, valid := p.overrides[token]
+	return valid
+}
+
+func (p *Policy) check(o order) error {
+	p.mu.RLock()
+	denied := p.deny[o.Symbol]
+	p.mu.RUnlock()
+	if denied {
+		return &PolicyError{Symbol: o.Symbol, Reason: "symbol is denied"}
+	}
+	if p.opts.AllowReduceOnly && (o.reduceOnly() || o.TradeSide == "close") {
+		return nil
+	}
+
+	now := p.clock.Now().Add(p.opts.Offset.Offset())
+	if len(p.opts.Windows) > 0 {
+		inside := false
+		for _, w := range p.opts.Windows {
+			if w.contains(now) {
+				inside = true
+				break
+			}
+		}
+		if !inside {
+			return &PolicyError{Symbol: o.Symbol, Reason: "outside trading windows"}
+		}
+	}
+	for _, b := range p.opts.Blackouts {
+		if b.active(o.Symbol, now) {
+			name := b.Name
+			if name == "" {
+				name = "blackout"
+			}
+			return &PolicyError{Symbol: o.Symbol, Reason: "in " + name + " blackout"}
+		}
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package guard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func placeCtx(ctx context.Context, p *Policy, body string) error {
+	_, _, err := p.CallAPI(ctx, "POST", futures.EndpointPlaceOrder, nil, []byte(body), true)
+	return err
+}
+
+func TestPolicy_DenyListAndOverride(t *testing.T) {
+	client := &countingClient{}
+	clock := common.NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	p := NewPolicy(client, PolicyOptions{Deny: []string{"LUNAUSDT"}, Clock: clock})
+
+	err := placeCtx(context.Background(), p, `{"symbol":"LUNAUSDT","side":"buy","size":"1"}`)
+	require.ErrorIs(t, err, ErrPolicy)
+	assert.Contains(t, err.Error(), "symbol is denied")
+	require.NoError(t, placeCtx(context.Background(), p, `{"symbol":"BTCUSDT","side":"buy","size":"1"}`))
+
+	token := p.IssueOverride(time.Minute)
+	ctx := WithOverride(context.Background(), token)
+	require.NoError(t, placeCtx(ctx, p, `{"symbol":"LUNAUSDT","side":"sell","size":"1"}`))
+	assert.ErrorIs(t, placeCtx(WithOverride(context.Background(), "forged"), p, `{"symbol":"LUNAUSDT","side":"sell","size":"1"}`), ErrPolicy)
+
+	clock.Advance(time.Minute)
+	assert.ErrorIs(t, placeCtx(ctx, p, `{"symbol":"LUNAUSDT","side":"sell","size":"1"}`), ErrPolicy, "token expired")
+
+	p.Allow("LUNAUSDT")
+	assert.NoError(t, p.Allowed("LUNAUSDT"))
+	p.Deny("ETHUSDT")
+	assert.ErrorIs(t, p.Allowed("ETHUSDT"), ErrPolicy)
+	assert.Equal(t, 2, client.calls)
+}
+
+func TestPolicy_WindowsAndBlackouts(t *testing.T) {
+	clock := common.NewFakeClock(time.Time{})
+	p := NewPolicy(&countingClient{}, PolicyOptions{
+		Windows: []Window{{Start: 22 * time.Hour, End: 6 * time.Hour, Weekdays: []time.Weekday{time.Monday}}},
+		Blackouts: []Blackout{
+			{Name: "funding", Interval: 8 * time.Hour, Before: 5 * time.Minute, After: time.Minute},
+			{Name: "delivery", At: time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC), Before: time.Hour, Symbols: []string{"BTCUSDT0329"}},
+		},
+		AllowReduceOnly: true,
+		Clock:           clock,
+	})
+
+	// Monday 2024-01-01 23:00 is inside the window that wraps into Tuesday
+	clock.Set(time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC))
+	assert.NoError(t, p.Allowed("BTCUSDT"))
+	clock.Set(time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC))
+	assert.NoError(t, p.Allowed("BTCUSDT"), "Tuesday morning belongs to Monday's window")
+	clock.Set(time.Date(2024, 1, 2, 23, 0, 0, 0, time.UTC))
+	err := p.Allowed("BTCUSDT")
+	require.ErrorIs(t, err, ErrPolicy)
+	assert.Contains(t, err.Error(), "outside trading windows")
+
+	// 23:57 Monday is within five minutes of the 00:00 funding settlement
+	clock.Set(time.Date(2024, 1, 1, 23, 57, 0, 0, time.UTC))
+	assert.ErrorContains(t, p.Allowed("BTCUSDT"), "funding blackout")
+	clock.Set(time.Date(2024, 1, 2, 0, 0, 30, 0, time.UTC))
+	assert.ErrorContains(t, p.Allowed("BTCUSDT"), "funding blackout")
+	// Closing stays possible
+	require.NoError(t, placeCtx(context.Background(), p, `{"symbol":"BTCUSDT","side":"sell","size":"1","reduceOnly":"YES"}`))
+
+	clock.Set(time.Date(2024, 1, 2, 1, 30, 0, 0, time.UTC))
+	assert.ErrorContains(t, p.Allowed("BTCUSDT0329"), "delivery blackout")
+	assert.NoError(t, p.Allowed("BTCUSDT"))
+}
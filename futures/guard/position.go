@@ -0,0 +1,273 @@
+package guard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/khanbekov/go-bitget/config"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/valyala/fasthttp"
+)
+
+// ErrPositionLimit is matched by every *PositionLimitError.
+var ErrPositionLimit = errors.New("guard: position limit exceeded")
+
+// Limit names reported in PositionLimitError.Limit.
+const (
+	LimitPositionSize     = "position_size"
+	LimitPositionNotional = "position_notional"
+	LimitTotalNotional    = "total_notional"
+)
+
+// PositionLimitError is returned for orders that would take a position past a limit.
+type PositionLimitError struct {
+	Limit  string
+	Symbol string
+	// Projected is the size or notional if the order and the resting orders filled.
+	Projected float64
+	Max       float64
+}
+
+func (e *PositionLimitError) Error() string {
+	return fmt.Sprintf("guard: %s limit exceeded for %s: projected %s > %s", e.Limit, e.Symbol,
+		strconv.FormatFloat(e.Projected, 'f', -1, 64), strconv.FormatFloat(e.Max, 'f', -1, 64))
+}
+
+// Is makes errors.Is(err, ErrPositionLimit) match.
+func (e *PositionLimitError) Is(target error) bool {
+	return target == ErrPositionLimit
+}
+
+// PositionLimits caps position size and notional per symbol and across the account.
+// Zero disables a cap. Sizes are gross: the long and short sides of a hedge-mode
+// position count in full.
+type PositionLimits struct {
+	// MaxSize and MaxNotional override the defaults per symbol.
+	MaxSize     map[string]float64
+	MaxNotional map[string]float64
+
+	DefaultMaxSize     float64
+	DefaultMaxNotional float64
+	// MaxTotalNotional caps the gross notional of all positions.
+	MaxTotalNotional float64
+
+	// Price returns a reference price for orders without one. Positions fall back to
+	// their tracked mark or average price. Optional.
+	Price func(symbol string) float64
+	// Multiplier returns the contract multiplier, the base coin amount of one unit of
+	// size. Bitget sizes are in the base coin, so it defaults to 1.
+	Multiplier func(symbol string) float64
+}
+
+// PositionLimitsFromConfig applies RiskLimits.MaxPositionNotional to every symbol.
+func PositionLimitsFromConfig(r config.RiskLimits) PositionLimits {
+	return PositionLimits{DefaultMaxNotional: r.MaxPositionNotional}
+}
+
+// PositionGuard is a futures.ClientInterface that rejects orders which would take a
+// position past PositionLimits, using the position tracker for the current positions:
+//
+//	positions := tracker.NewPositionTracker(tracker.Options{})
+//	wsClient.SubscribePositions("USDT-FUTURES", positions.HandleMessage)
+//	guarded := guard.NewPositionGuard(client, positions, orders, guard.PositionLimits{
+//		MaxSize:          map[string]float64{"BTCUSDT": 0.5},
+//		MaxTotalNotional: 100000,
+//	})
+//
+// When an order tracker is given, resting orders count as if they filled. Orders that
+// only reduce positions always pass. It is safe for concurrent use.
+type PositionGuard struct {
+	next      futures.ClientInterface
+	positions *tracker.PositionTracker
+	orders    *tracker.OrderTracker
+
+	mu     sync.RWMutex
+	limits PositionLimits
+}
+
+// NewPositionGuard wraps next with limits. orders may be nil.
+func NewPositionGuard(next futures.ClientInterface, positions *tracker.PositionTracker, orders *tracker.OrderTracker, limits PositionLimits) *PositionGuard {
+	return &PositionGuard{next: next, positions: positions, orders: orders, limits: limits}
+}
+
+// SetLimits replaces the limits, e.g. from a config.Watcher. Orders being checked finish
+// against the previous limits.
+func (g *PositionGuard) SetLimits(limits PositionLimits) {
+	g.mu.Lock()
+	g.limits = limits
+	g.mu.Unlock()
+}
+
+// CallAPI forwards the request unless one of its orders would exceed a limit, in which
+// case a *PositionLimitError is returned.
+func (g *PositionGuard) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	if orders := parseOrders(endpoint, body); len(orders) > 0 {
+		if err := g.check(orders); err != nil {
+			return nil, nil, err
+		}
+	}
+	return g.next.CallAPI(ctx, method, endpoint, query, body, sign)
+}
+
+// exposure is the projected long and short size of a symbol.
+type exposure struct {
+	long, short float64
+	price       float64
+}
+
+func (e exposure) gross() float64 { return e.long + e.short }
+
+// apply adds an order to the exposure. One-way orders net against the opposite side
+// first; hedge-mode close orders reduce their side.
+func (e *exposure) apply(side, tradeSide string, reduceOnly bool, size float64) {
+	buy := side == "buy"
+	switch {
+	case tradeSide == "close":
+		// Hedge mode closes use the side of the position
+		if buy {
+			e.long = max(e.long-size, 0)
+		} else {
+			e.short = max(e.short-size, 0)
+		}
+	case tradeSide == "open":
+		if buy {
+			e.long += size
+		} else {
+			e.short += size
+		}
+	case buy:
+		netted := min(e.short, size)
+		e.short -= netted
+		if !reduceOnly {
+			e.long += size - netted
+		}
+	default:
+		netted := min(e.long, size)
+		e.long -= netted
+		if !reduceOnly {
+			e.short += size - netted
+		}
+	}
+}
+
+// check returns a *PositionLimitError if the orders would exceed a limit.
+func (g *PositionGuard) check(orders []order) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	current := make(map[string]*exposure)
+	get := func(symbol string) *exposure {
+		if e, ok := current[symbol]; ok {
+			return e
+		}
+		e := &exposure{}
+		current[symbol] = e
+		return e
+	}
+	for _, p := range g.positions.Open("") {
+		e := get(p.Symbol)
+		if p.HoldSide == "short" {
+			e.short += p.Size
+		} else {
+			e.long += p.Size
+		}
+		if p.MarkPrice > 0 {
+			e.price = p.MarkPrice
+		} else if e.price == 0 {
+			e.price = p.AvgPrice
+		}
+	}
+	if g.orders != nil {
+		for _, o := range g.orders.Open("") {
+			// Resting orders may fill in any order, so only additions are projected
+			if o.ReduceOnly || o.TradeSide == "close" {
+				continue
+			}
+			get(o.Symbol).apply(o.Side, o.TradeSide, false, o.Remaining())
+		}
+	}
+
+	projected := make(map[string]exposure, len(current))
+	for symbol, e := range current {
+		projected[symbol] = *e
+	}
+	for _, o := range orders {
+		size, _ := strconv.ParseFloat(o.Size, 64)
+		e := projected[o.Symbol]
+		before := e.gross()
+		e.apply(o.Side, o.TradeSide, o.reduceOnly(), size)
+		if price := g.orderPrice(o, e.price); price > 0 {
+			e.price = price
+		}
+		projected[o.Symbol] = e
+		if e.gross() <= before {
+			continue
+		}
+		if err := g.checkSymbol(o.Symbol, e); err != nil {
+			return err
+		}
+	}
+
+	if g.limits.MaxTotalNotional > 0 {
+		var total, before float64
+		for symbol, e := range projected {
+			total += g.notional(symbol, e.gross(), e.price)
+			if c, ok := current[symbol]; ok {
+				before += g.notional(symbol, c.gross(), e.price)
+			}
+		}
+		if total > g.limits.MaxTotalNotional && total > before {
+			return &PositionLimitError{Limit: LimitTotalNotional, Symbol: "*", Projected: total, Max: g.limits.MaxTotalNotional}
+		}
+	}
+	return nil
+}
+
+func (g *PositionGuard) checkSymbol(symbol string, e exposure) error {
+	maxSize := g.limits.DefaultMaxSize
+	if v, ok := g.limits.MaxSize[symbol]; ok {
+		maxSize = v
+	}
+	if maxSize > 0 && e.gross() > maxSize {
+		return &PositionLimitError{Limit: LimitPositionSize, Symbol: symbol, Projected: e.gross(), Max: maxSize}
+	}
+	maxNotional := g.limits.DefaultMaxNotional
+	if v, ok := g.limits.MaxNotional[symbol]; ok {
+		maxNotional = v
+	}
+	if notional := g.notional(symbol, e.gross(), e.price); maxNotional > 0 && notional > maxNotional {
+		return &PositionLimitError{Limit: LimitPositionNotional, Symbol: symbol, Projected: notional, Max: maxNotional}
+	}
+	return nil
+}
+
+// orderPrice returns the price of an order, falling back to the reference price and
+// then to the tracked price of the symbol.
+func (g *PositionGuard) orderPrice(o order, tracked float64) float64 {
+	if price, _ := strconv.ParseFloat(o.Price, 64); price > 0 {
+		return price
+	}
+	if g.limits.Price != nil {
+		if price := g.limits.Price(o.Symbol); price > 0 {
+			return price
+		}
+	}
+	if price, _ := strconv.ParseFloat(o.TriggerPrice, 64); price > 0 {
+		return price
+	}
+	return tracked
+}
+
+func (g *PositionGuard) notional(symbol string, size, price float64) float64 {
+	multiplier := 1.0
+	if g.limits.Multiplier != nil {
+		if m := g.limits.Multiplier(symbol); m > 0 {
+			multiplier = m
+		}
+	}
+	return size * multiplier * price
+}
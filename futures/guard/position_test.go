@@ -0,0 +1,65 @@
+package guard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/config"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func placeThrough(g *PositionGuard, body string) error {
+	_, _, err := g.CallAPI(context.Background(), "POST", futures.EndpointPlaceOrder, nil, []byte(body), true)
+	return err
+}
+
+func TestPositionGuard_SizeLimit(t *testing.T) {
+	positions := tracker.NewPositionTracker(tracker.Options{})
+	positions.Update(tracker.Position{Symbol: "BTCUSDT", HoldSide: "long", Size: 0.4, MarkPrice: 50000})
+	client := &countingClient{}
+	g := NewPositionGuard(client, positions, nil, PositionLimits{MaxSize: map[string]float64{"BTCUSDT": 0.5}})
+
+	require.NoError(t, placeThrough(g, `{"symbol":"BTCUSDT","side":"buy","size":"0.1","price":"50000"}`))
+	err := placeThrough(g, `{"symbol":"BTCUSDT","side":"buy","size":"0.2","price":"50000"}`)
+	require.ErrorIs(t, err, ErrPositionLimit)
+	var pe *PositionLimitError
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, LimitPositionSize, pe.Limit)
+	assert.InDelta(t, 0.6, pe.Projected, 1e-9)
+
+	// Selling reduces the long in one-way mode, even past the limit's size
+	require.NoError(t, placeThrough(g, `{"symbol":"BTCUSDT","side":"sell","size":"0.9","orderType":"market"}`), "flips to a 0.5 short")
+	require.Error(t, placeThrough(g, `{"symbol":"BTCUSDT","side":"sell","size":"1","orderType":"market"}`))
+	require.NoError(t, placeThrough(g, `{"symbol":"BTCUSDT","side":"buy","size":"0.4","tradeSide":"close"}`))
+	assert.Equal(t, 3, client.calls)
+}
+
+func TestPositionGuard_NotionalWithRestingOrders(t *testing.T) {
+	positions := tracker.NewPositionTracker(tracker.Options{})
+	positions.Update(tracker.Position{Symbol: "ETHUSDT", HoldSide: "short", Size: 5, MarkPrice: 3000})
+	orders := tracker.NewOrderTracker(tracker.Options{})
+	orders.Update(tracker.Order{OrderID: "1", Symbol: "ETHUSDT", Side: "sell", TradeSide: "open", Size: 3, Price: 3100, Status: tracker.StatusLive})
+
+	limits := PositionLimitsFromConfig(config.RiskLimits{MaxPositionNotional: 30000})
+	limits.MaxTotalNotional = 35000
+	g := NewPositionGuard(&countingClient{}, positions, orders, limits)
+
+	// 5 held + 3 resting + 2 new = 10 ETH at 3000
+	require.NoError(t, placeThrough(g, `{"symbol":"ETHUSDT","side":"sell","tradeSide":"open","size":"2","price":"3000"}`))
+	err := placeThrough(g, `{"symbol":"ETHUSDT","side":"sell","tradeSide":"open","size":"2.1","price":"3000"}`)
+	var pe *PositionLimitError
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, LimitPositionNotional, pe.Limit)
+
+	// BTC fits its own default cap but breaks the account-wide cap with the ETH exposure
+	err = placeThrough(g, `{"symbol":"BTCUSDT","side":"buy","size":"0.3","price":"50000"}`)
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, LimitTotalNotional, pe.Limit)
+	assert.InDelta(t, 39000, pe.Projected, 1e-6)
+
+	// Reduce-only orders always pass
+	require.NoError(t, placeThrough(g, `{"symbol":"ETHUSDT","side":"buy","size":"1","reduceOnly":"YES"}`))
+}
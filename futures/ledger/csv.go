@@ -0,0 +1,64 @@
+package ledger
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures/position"
+)
+
+// CSVHeader is the column layout written by WriteCSV.
+var CSVHeader = []string{"time", "kind", "type", "symbol", "coin", "amount", "fee", "net", "balance", "side", "size", "price", "ref"}
+
+// WriteCSV writes entries as a generic CSV ledger with a CSVHeader row. Times are UTC
+// RFC 3339 with milliseconds.
+func WriteCSV(w io.Writer, entries []Entry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(CSVHeader); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		err := cw.Write([]string{
+			e.Time.UTC().Format(timeLayout), string(e.Kind), e.Type, e.Symbol, e.Coin,
+			normalize(e.Amount), normalize(e.Fee), e.Net(), e.Balance,
+			e.Side, e.Size, e.Price, e.Ref,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// PositionsHeader is the column layout written by WritePositionsCSV.
+var PositionsHeader = []string{"time", "symbol", "hold_side", "margin_mode", "margin_coin", "size", "open_price", "mark_price", "margin", "leverage", "unrealized_pl", "liquidation_price"}
+
+// WritePositionsCSV writes a snapshot of open positions taken at ts, one row each.
+// Positions with zero size are skipped.
+func WritePositionsCSV(w io.Writer, positions []*position.Position, ts time.Time) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(PositionsHeader); err != nil {
+		return err
+	}
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	for _, p := range positions {
+		if p == nil || p.Total == 0 {
+			continue
+		}
+		err := cw.Write([]string{
+			ts.UTC().Format(timeLayout), p.Symbol, string(p.HoldSide), p.MarginMode, p.MarginCoin,
+			f(p.Total), f(p.AverageOpenPrice), f(p.MarkPrice), f(p.MarginSize), f(p.Leverage),
+			f(p.UnrealizedPL), f(p.LiquidationPrice),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+const timeLayout = "2006-01-02T15:04:05.000Z07:00"
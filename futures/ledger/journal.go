@@ -0,0 +1,201 @@
+package ledger
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Accounts names the journal accounts entries are booked to. Zero fields use the
+// defaults shown.
+type Accounts struct {
+	// Assets is the futures balance, suffixed with the coin. Default "Assets:Bitget:Futures".
+	Assets string
+	// Transfers is the other side of transfers, suffixed with the coin. Default
+	// "Assets:Bitget:Spot".
+	Transfers string
+	// PnL receives realized profit and loss of trades and liquidations. Default
+	// "Income:Bitget:PnL".
+	PnL string
+	// Funding receives funding settlements. Default "Income:Bitget:Funding".
+	Funding string
+	// Fees receives trading fees. Default "Expenses:Bitget:Fees".
+	Fees string
+	// Other receives every other balance change. Default "Income:Bitget:Other".
+	Other string
+}
+
+func (a Accounts) withDefaults() Accounts {
+	def := func(v *string, d string) {
+		if *v == "" {
+			*v = d
+		}
+	}
+	def(&a.Assets, "Assets:Bitget:Futures")
+	def(&a.Transfers, "Assets:Bitget:Spot")
+	def(&a.PnL, "Income:Bitget:PnL")
+	def(&a.Funding, "Income:Bitget:Funding")
+	def(&a.Fees, "Expenses:Bitget:Fees")
+	def(&a.Other, "Income:Bitget:Other")
+	return a
+}
+
+// posting is one leg of a journal transaction.
+type posting struct {
+	account string
+	amount  string
+}
+
+// postings books e against its asset account, the counter account for its kind and the
+// fee account. The asset posting comes first.
+func (a Accounts) postings(e Entry) []posting {
+	coin := commodity(e.Coin)
+	ps := []posting{{a.Assets + ":" + coin, e.Net()}}
+	if !isZero(e.Amount) {
+		var counter string
+		switch e.Kind {
+		case KindTrade, KindLiquidation:
+			counter = a.PnL
+		case KindFunding:
+			counter = a.Funding
+		case KindTransfer:
+			counter = a.Transfers + ":" + coin
+		default:
+			counter = a.Other
+		}
+		ps = append(ps, posting{counter, negate(e.Amount)})
+	}
+	if !isZero(e.Fee) {
+		ps = append(ps, posting{a.Fees, negate(e.Fee)})
+	}
+	return ps
+}
+
+// BeancountOptions configures WriteBeancount.
+type BeancountOptions struct {
+	Accounts Accounts
+	// Payee of every transaction. Defaults to "Bitget".
+	Payee string
+	// Balances appends a balance assertion per coin with the balance after the last
+	// entry. Entries without a Balance (fills) assert nothing.
+	Balances bool
+	// SkipOpen omits the open directives, for appending to a file that already opens
+	// the accounts.
+	SkipOpen bool
+}
+
+// WriteBeancount writes entries as Beancount transactions, preceded by open directives
+// for every account used. Dates are UTC; the bill or trade ID is kept as "ref" metadata.
+func WriteBeancount(w io.Writer, entries []Entry, opts BeancountOptions) error {
+	accounts := opts.Accounts.withDefaults()
+	if opts.Payee == "" {
+		opts.Payee = "Bitget"
+	}
+	bw := bufio.NewWriter(w)
+
+	if !opts.SkipOpen && len(entries) > 0 {
+		opened := map[string]bool{}
+		for _, e := range entries {
+			for _, p := range accounts.postings(e) {
+				opened[p.account] = true
+			}
+		}
+		names := make([]string, 0, len(opened))
+		for name := range opened {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		first := entries[0].Time.UTC().Format(time.DateOnly)
+		for _, name := range names {
+			fmt.Fprintf(bw, "%s open %s\n", first, name)
+		}
+		bw.WriteString("\n")
+	}
+
+	for _, e := range entries {
+		fmt.Fprintf(bw, "%s * %s %s\n", e.Time.UTC().Format(time.DateOnly), quote(opts.Payee), quote(e.Description()))
+		if e.Ref != "" {
+			fmt.Fprintf(bw, "  ref: %s\n", quote(e.Ref))
+		}
+		for _, p := range accounts.postings(e) {
+			fmt.Fprintf(bw, "  %s  %s %s\n", p.account, p.amount, commodity(e.Coin))
+		}
+		bw.WriteString("\n")
+	}
+
+	if opts.Balances {
+		for _, b := range lastBalances(entries) {
+			// Beancount checks a balance at the start of its date
+			date := b.Time.UTC().AddDate(0, 0, 1).Format(time.DateOnly)
+			fmt.Fprintf(bw, "%s balance %s:%s  %s %s\n", date, accounts.Assets, commodity(b.Coin), normalize(b.Balance), commodity(b.Coin))
+		}
+	}
+	return bw.Flush()
+}
+
+// LedgerOptions configures WriteLedger.
+type LedgerOptions struct {
+	Accounts Accounts
+	// Balances adds a balance assertion to the asset posting of the last entry per coin.
+	Balances bool
+}
+
+// WriteLedger writes entries as ledger-cli (and hledger) journal transactions. Dates
+// are UTC; the bill or trade ID is kept as a "ref" tag.
+func WriteLedger(w io.Writer, entries []Entry, opts LedgerOptions) error {
+	accounts := opts.Accounts.withDefaults()
+	last := map[int]bool{}
+	if opts.Balances {
+		seen := map[string]bool{}
+		for i := len(entries) - 1; i >= 0; i-- {
+			if e := entries[i]; e.Balance != "" && !seen[e.Coin] {
+				seen[e.Coin] = true
+				last[i] = true
+			}
+		}
+	}
+	bw := bufio.NewWriter(w)
+	for i, e := range entries {
+		fmt.Fprintf(bw, "%s * %s\n", e.Time.UTC().Format("2006/01/02"), e.Description())
+		if e.Ref != "" {
+			fmt.Fprintf(bw, "    ; ref: %s\n", e.Ref)
+		}
+		for j, p := range accounts.postings(e) {
+			fmt.Fprintf(bw, "    %s  %s %s", p.account, p.amount, commodity(e.Coin))
+			if j == 0 && last[i] {
+				fmt.Fprintf(bw, " = %s %s", normalize(e.Balance), commodity(e.Coin))
+			}
+			bw.WriteString("\n")
+		}
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+// lastBalances returns the last entry with a balance of each coin, sorted by coin.
+func lastBalances(entries []Entry) []Entry {
+	byCoin := map[string]Entry{}
+	for _, e := range entries {
+		if e.Balance != "" {
+			byCoin[e.Coin] = e
+		}
+	}
+	out := make([]Entry, 0, len(byCoin))
+	for _, e := range byCoin {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Coin < out[j].Coin })
+	return out
+}
+
+// commodity returns coin as a journal commodity name.
+func commodity(coin string) string {
+	return strings.ToUpper(strings.TrimSpace(coin))
+}
+
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
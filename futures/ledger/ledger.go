@@ -0,0 +1,271 @@
+// Package ledger exports futures account activity for accounting tools.
+//
+// Bills (the account ledger: realized PnL, fees, funding and transfers) and fills are
+// converted to Entries, which can be written as a generic CSV ledger, as Beancount
+// transactions or as ledger-cli journal entries:
+//
+//	bills, err := ledger.FetchBills(ctx, client, futures.ProductTypeUSDTFutures, start, end)
+//	if err != nil {
+//		return err
+//	}
+//	entries := ledger.FromBills(bills)
+//	err = ledger.WriteBeancount(f, entries, ledger.BeancountOptions{Balances: true})
+//
+// Bills already include the PnL and fees of every fill, so use bills for a ledger of
+// balance changes and fills for a trade journal; mixing both counts trades twice.
+//
+// Amounts are kept as the exact decimal strings reported by the exchange.
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/account"
+	"github.com/khanbekov/go-bitget/futures/trading"
+)
+
+// Kind classifies an entry for accounting.
+type Kind string
+
+const (
+	// KindTrade is realized PnL and fees of opening, closing or delivering a position.
+	KindTrade Kind = "trade"
+	// KindLiquidation is the loss of a forced close.
+	KindLiquidation Kind = "liquidation"
+	// KindFunding is a funding fee settlement.
+	KindFunding Kind = "funding"
+	// KindTransfer moves funds into or out of the futures account.
+	KindTransfer Kind = "transfer"
+	// KindOther is any other balance change, e.g. margin adjustments.
+	KindOther Kind = "other"
+)
+
+// Entry is one balance change of a coin.
+type Entry struct {
+	Time   time.Time
+	Kind   Kind
+	Symbol string
+	Coin   string
+
+	// Amount is the balance change excluding fees.
+	Amount string
+	// Fee is the balance change caused by fees, negative when a fee was paid.
+	Fee string
+	// Balance is the coin balance after the entry. Empty for fills.
+	Balance string
+
+	// Side, Size and Price describe the trade of a fill entry.
+	Side  string
+	Size  string
+	Price string
+
+	// Ref is the bill or trade ID.
+	Ref string
+	// Type is the exchange business type of a bill or the trade side of a fill.
+	Type string
+}
+
+// Net returns Amount plus Fee.
+func (e Entry) Net() string {
+	return addDecimal(e.Amount, e.Fee)
+}
+
+// Description returns a one-line summary used as narration, e.g. "close_long BTCUSDT"
+// or "buy 0.01 BTCUSDT @ 65000".
+func (e Entry) Description() string {
+	if e.Size != "" {
+		side := e.Side
+		if e.Type != "" {
+			side += " " + e.Type
+		}
+		return strings.TrimSpace(fmt.Sprintf("%s %s %s @ %s", side, e.Size, e.Symbol, e.Price))
+	}
+	return strings.TrimSpace(e.Type + " " + e.Symbol)
+}
+
+// KindOf classifies a bill business type.
+func KindOf(businessType string) Kind {
+	switch {
+	case businessType == account.BillContractSettle:
+		return KindFunding
+	case businessType == account.BillTransferIn || businessType == account.BillTransferOut:
+		return KindTransfer
+	case strings.HasPrefix(businessType, "force_close_") || strings.HasPrefix(businessType, "burst_"):
+		return KindLiquidation
+	case strings.HasPrefix(businessType, "open_") || strings.HasPrefix(businessType, "close_") ||
+		strings.HasPrefix(businessType, "delivery_"):
+		return KindTrade
+	}
+	return KindOther
+}
+
+// FromBills converts bills to entries in chronological order. Bills that change no
+// balance, such as fee-free opens, are dropped.
+func FromBills(bills []*account.Bill) []Entry {
+	entries := make([]Entry, 0, len(bills))
+	for _, b := range bills {
+		if b == nil || (isZero(b.Amount) && isZero(b.Fee)) {
+			continue
+		}
+		entries = append(entries, Entry{
+			Time:    b.Time(),
+			Kind:    KindOf(b.BusinessType),
+			Symbol:  b.Symbol,
+			Coin:    b.Coin,
+			Amount:  normalize(b.Amount),
+			Fee:     normalize(b.Fee),
+			Balance: b.Balance,
+			Ref:     b.BillId,
+			Type:    b.BusinessType,
+		})
+	}
+	sortEntries(entries)
+	return entries
+}
+
+// FromFills converts fills to trade entries in chronological order. Amount is the
+// realized profit of the fill.
+func FromFills(fills []*trading.FillRecord) []Entry {
+	entries := make([]Entry, 0, len(fills))
+	for _, f := range fills {
+		if f == nil {
+			continue
+		}
+		coin := f.MarginCoin
+		if coin == "" {
+			coin = f.FeeCcy
+		}
+		ms, _ := strconv.ParseInt(f.CTime, 10, 64)
+		entries = append(entries, Entry{
+			Time:   time.UnixMilli(ms),
+			Kind:   KindTrade,
+			Symbol: f.Symbol,
+			Coin:   coin,
+			Amount: normalize(f.Profit),
+			Fee:    normalize(f.Fee),
+			Side:   string(f.Side),
+			Size:   f.Size,
+			Price:  f.Price,
+			Ref:    f.TradeId,
+			Type:   f.TradeSide,
+		})
+	}
+	sortEntries(entries)
+	return entries
+}
+
+// window is the longest time range the bill and fill endpoints accept per query.
+const window = 30 * 24 * time.Hour
+
+// FetchBills returns every bill between start and end, querying in 30 day windows.
+func FetchBills(ctx context.Context, client futures.ClientInterface, productType futures.ProductType, start, end time.Time) ([]*account.Bill, error) {
+	var bills []*account.Bill
+	err := eachWindow(start, end, func(from, to string) error {
+		it := account.NewBillHistoryService(client).ProductType(productType).
+			StartTime(from).EndTime(to).Limit("100").Iter(ctx)
+		for it.Next() {
+			b := it.Item()
+			bills = append(bills, &b)
+		}
+		return it.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ledger: bills: %w", err)
+	}
+	return bills, nil
+}
+
+// FetchFills returns every fill between start and end, querying in 30 day windows.
+func FetchFills(ctx context.Context, client futures.ClientInterface, productType futures.ProductType, start, end time.Time) ([]*trading.FillRecord, error) {
+	var fills []*trading.FillRecord
+	err := eachWindow(start, end, func(from, to string) error {
+		it := trading.NewFillHistoryService(client).ProductType(trading.ProductType(productType)).
+			StartTime(from).EndTime(to).PageSize("100").Iter(ctx)
+		for it.Next() {
+			f := it.Item()
+			fills = append(fills, &f)
+		}
+		return it.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ledger: fills: %w", err)
+	}
+	return fills, nil
+}
+
+func eachWindow(start, end time.Time, fetch func(from, to string) error) error {
+	for from := start; from.Before(end); from = from.Add(window) {
+		to := from.Add(window)
+		if to.After(end) {
+			to = end
+		}
+		if err := fetch(strconv.FormatInt(from.UnixMilli(), 10), strconv.FormatInt(to.UnixMilli(), 10)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortEntries(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].Time.Equal(entries[j].Time) {
+			return entries[i].Time.Before(entries[j].Time)
+		}
+		return entries[i].Ref < entries[j].Ref
+	})
+}
+
+// parseDecimal parses s exactly, returning zero and the number of decimal places.
+func parseDecimal(s string) (*big.Rat, int) {
+	s = strings.TrimSpace(s)
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return new(big.Rat), 0
+	}
+	places := 0
+	if strings.ContainsAny(s, "eE") {
+		places = 18
+	} else if i := strings.IndexByte(s, '.'); i >= 0 {
+		places = len(s) - i - 1
+	}
+	return r, places
+}
+
+func formatDecimal(r *big.Rat, places int) string {
+	s := r.FloatString(places)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
+
+func normalize(s string) string {
+	r, places := parseDecimal(s)
+	return formatDecimal(r, places)
+}
+
+func addDecimal(a, b string) string {
+	x, pa := parseDecimal(a)
+	y, pb := parseDecimal(b)
+	return formatDecimal(x.Add(x, y), max(pa, pb))
+}
+
+func negate(s string) string {
+	r, places := parseDecimal(s)
+	return formatDecimal(r.Neg(r), places)
+}
+
+func isZero(s string) bool {
+	r, _ := parseDecimal(s)
+	return r.Sign() == 0
+}
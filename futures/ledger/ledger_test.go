@@ -0,0 +1,179 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/account"
+	"github.com/khanbekov/go-bitget/futures/position"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// 2023-11-14T22:13:20Z
+const t0 = 1700000000000
+
+var bills = []*account.Bill{
+	{BillId: "105", Symbol: "", Amount: "-100", Fee: "0", BusinessType: account.BillTransferOut, Coin: "USDT", Balance: "896.7", CTime: "1700100000000"},
+	{BillId: "104", Symbol: "BTCUSDT", Amount: "-1.25", Fee: "0", BusinessType: account.BillContractSettle, Coin: "USDT", Balance: "996.7", CTime: "1700003600000"},
+	{BillId: "103", Symbol: "BTCUSDT", Amount: "10.50", Fee: "-0.39", BusinessType: account.BillCloseLong, Coin: "USDT", Balance: "997.95", CTime: "1700001000000"},
+	{BillId: "102", Symbol: "BTCUSDT", Amount: "0", Fee: "-0.36", BusinessType: account.BillOpenLong, Coin: "USDT", Balance: "987.84", CTime: "1700000500000"},
+	{BillId: "101", Symbol: "BTCUSDT", Amount: "0", Fee: "0", BusinessType: account.BillOpenLong, Coin: "USDT", Balance: "988.2", CTime: "1700000400000"},
+	{BillId: "100", Amount: "988.2", Fee: "0", BusinessType: account.BillTransferIn, Coin: "USDT", Balance: "988.2", CTime: "1700000000000"},
+}
+
+func TestFromBills(t *testing.T) {
+	entries := FromBills(bills)
+	require.Len(t, entries, 5, "zero bills are dropped")
+	assert.Equal(t, []string{"100", "102", "103", "104", "105"}, refs(entries), "chronological")
+	assert.Equal(t, KindTransfer, entries[0].Kind)
+	assert.Equal(t, KindTrade, entries[1].Kind)
+	assert.Equal(t, KindFunding, entries[3].Kind)
+	assert.Equal(t, "10.5", entries[2].Amount)
+	assert.Equal(t, "10.11", entries[2].Net())
+	assert.Equal(t, "close_long BTCUSDT", entries[2].Description())
+	assert.Equal(t, time.UnixMilli(1700001000000), entries[2].Time)
+
+	assert.Equal(t, KindLiquidation, KindOf(account.BillBurstLongLoss))
+	assert.Equal(t, KindOther, KindOf(account.BillAppendMargin))
+}
+
+func TestFromFills(t *testing.T) {
+	entries := FromFills([]*trading.FillRecord{
+		{TradeId: "t2", Symbol: "BTCUSDT", Size: "0.01", Price: "66000", Side: "sell", Fee: "-0.396", Profit: "10", MarginCoin: "USDT", TradeSide: "close", CTime: "1700001000000"},
+		{TradeId: "t1", Symbol: "BTCUSDT", Size: "0.01", Price: "65000", Side: "buy", Fee: "-0.39", Profit: "0", FeeCcy: "USDT", TradeSide: "open", CTime: "1700000500000"},
+	})
+	require.Len(t, entries, 2)
+	assert.Equal(t, "t1", entries[0].Ref)
+	assert.Equal(t, "USDT", entries[0].Coin, "falls back to the fee coin")
+	assert.Equal(t, "buy open 0.01 BTCUSDT @ 65000", entries[0].Description())
+	assert.Equal(t, "9.604", entries[1].Net())
+	assert.Empty(t, entries[1].Balance)
+}
+
+func TestDecimals(t *testing.T) {
+	assert.Equal(t, "0.3", addDecimal("0.1", "0.2"), "exact, unlike float64")
+	assert.Equal(t, "0", addDecimal("1.50", "-1.5"))
+	assert.Equal(t, "-12.000001", negate("12.000001"))
+	assert.Equal(t, "0", negate("0"))
+	assert.Equal(t, "0.0000001", normalize("1e-7"))
+	assert.Equal(t, "0", normalize("n/a"))
+	assert.True(t, isZero(""))
+}
+
+func TestWriteCSV(t *testing.T) {
+	var b strings.Builder
+	require.NoError(t, WriteCSV(&b, FromBills(bills)[1:3]))
+	assert.Equal(t,
+		"time,kind,type,symbol,coin,amount,fee,net,balance,side,size,price,ref\n"+
+			"2023-11-14T22:21:40.000Z,trade,open_long,BTCUSDT,USDT,0,-0.36,-0.36,987.84,,,,102\n"+
+			"2023-11-14T22:30:00.000Z,trade,close_long,BTCUSDT,USDT,10.5,-0.39,10.11,997.95,,,,103\n",
+		b.String())
+}
+
+func TestWritePositionsCSV(t *testing.T) {
+	var b strings.Builder
+	positions := []*position.Position{
+		{Symbol: "BTCUSDT", HoldSide: "long", MarginMode: "crossed", MarginCoin: "USDT", Total: 0.01, AverageOpenPrice: 65000, MarkPrice: 66000, MarginSize: 65, Leverage: 10, UnrealizedPL: 10},
+		{Symbol: "ETHUSDT", HoldSide: "short", Total: 0},
+	}
+	require.NoError(t, WritePositionsCSV(&b, positions, time.UnixMilli(t0)))
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "2023-11-14T22:13:20.000Z,BTCUSDT,long,crossed,USDT,0.01,65000,66000,65,10,10,0", lines[1])
+}
+
+func TestWriteBeancount(t *testing.T) {
+	var b strings.Builder
+	require.NoError(t, WriteBeancount(&b, FromBills(bills), BeancountOptions{Balances: true}))
+	out := b.String()
+
+	assert.True(t, strings.HasPrefix(out,
+		"2023-11-14 open Assets:Bitget:Futures:USDT\n"+
+			"2023-11-14 open Assets:Bitget:Spot:USDT\n"+
+			"2023-11-14 open Expenses:Bitget:Fees\n"+
+			"2023-11-14 open Income:Bitget:Funding\n"+
+			"2023-11-14 open Income:Bitget:PnL\n\n"), out)
+	assert.Contains(t, out,
+		"2023-11-14 * \"Bitget\" \"close_long BTCUSDT\"\n"+
+			"  ref: \"103\"\n"+
+			"  Assets:Bitget:Futures:USDT  10.11 USDT\n"+
+			"  Income:Bitget:PnL  -10.5 USDT\n"+
+			"  Expenses:Bitget:Fees  0.39 USDT\n")
+	assert.Contains(t, out,
+		"2023-11-14 * \"Bitget\" \"trans_from_exchange\"\n"+
+			"  ref: \"100\"\n"+
+			"  Assets:Bitget:Futures:USDT  988.2 USDT\n"+
+			"  Assets:Bitget:Spot:USDT  -988.2 USDT\n")
+	assert.True(t, strings.HasSuffix(out, "2023-11-17 balance Assets:Bitget:Futures:USDT  896.7 USDT\n"), out)
+
+	b.Reset()
+	require.NoError(t, WriteBeancount(&b, FromBills(bills)[:1], BeancountOptions{SkipOpen: true, Payee: `Bitget "main"`}))
+	assert.True(t, strings.HasPrefix(b.String(), `2023-11-14 * "Bitget \"main\"" "trans_from_exchange"`), b.String())
+}
+
+func TestWriteLedger(t *testing.T) {
+	var b strings.Builder
+	accounts := Accounts{Assets: "Assets:Exchange:Bitget", Fees: "Expenses:Trading"}
+	require.NoError(t, WriteLedger(&b, FromBills(bills), LedgerOptions{Accounts: accounts, Balances: true}))
+	out := b.String()
+	assert.Contains(t, out,
+		"2023/11/14 * open_long BTCUSDT\n"+
+			"    ; ref: 102\n"+
+			"    Assets:Exchange:Bitget:USDT  -0.36 USDT\n"+
+			"    Expenses:Trading  0.36 USDT\n\n")
+	assert.Contains(t, out, "    Assets:Exchange:Bitget:USDT  -100 USDT = 896.7 USDT\n")
+	assert.Equal(t, 1, strings.Count(out, " = "), "only the last entry asserts the balance")
+}
+
+type fakeClient struct {
+	queries []url.Values
+}
+
+func (c *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.queries = append(c.queries, query)
+	ok := func(data string) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+		return &futures.ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil
+	}
+	switch endpoint {
+	case futures.EndpointAccountBills:
+		if query.Get("idLessThan") != "" {
+			return ok(`{"bills":[],"endId":""}`)
+		}
+		return ok(`{"bills":[{"billId":"` + query.Get("startTime") + `","amount":"1","fee":"0","coin":"USDT","cTime":"` + query.Get("startTime") + `"}],"endId":"1"}`)
+	case trading.EndpointFillHistory:
+		return ok(`{"list":[],"endId":""}`)
+	}
+	return nil, nil, errors.New("unexpected endpoint " + endpoint)
+}
+
+func TestFetchBills(t *testing.T) {
+	client := &fakeClient{}
+	start := time.UnixMilli(t0)
+	got, err := FetchBills(context.Background(), client, futures.ProductTypeUSDTFutures, start, start.Add(45*24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, got, 2, "one bill per 30 day window")
+	assert.Equal(t, "1700000000000", client.queries[0].Get("startTime"))
+	assert.Equal(t, "1702592000000", client.queries[0].Get("endTime"))
+	assert.Equal(t, "1702592000000", got[1].BillId)
+	assert.Equal(t, "1703888000000", client.queries[2].Get("endTime"))
+
+	fills, err := FetchFills(context.Background(), client, futures.ProductTypeUSDTFutures, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, fills)
+}
+
+func refs(entries []Entry) []string {
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = e.Ref
+	}
+	return out
+}
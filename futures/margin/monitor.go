@@ -0,0 +1,443 @@
+// Package margin watches account margin ratios and raises tiered alerts.
+//
+// A Monitor tracks the cross margin risk rate of the account and the margin ratio of
+// every isolated position. Ratios come from REST (Check, Run) or from the private
+// account and positions channels. When a ratio crosses the warning or critical
+// threshold the matching callback receives an Alert with suggested deleveraging
+// actions that would bring the ratio back to Options.Target:
+//
+//	m := margin.NewMonitor(client, margin.Options{
+//		ProductType: futures.ProductTypeUSDTFutures,
+//		MarginCoin:  "USDT",
+//		Warning:     0.5,
+//		Critical:    0.8,
+//		OnWarning:   func(a margin.Alert) { notify(a.String()) },
+//		OnCritical:  func(a margin.Alert) { page(a.String()) },
+//	})
+//	wsClient.SubscribeAccount("default", "USDT-FUTURES", m.HandleAccountMessage)
+//	wsClient.SubscribePositions("USDT-FUTURES", m.HandlePositionsMessage)
+//	go m.Run(ctx, 30*time.Second, onError) // REST fallback
+//
+// Margin ratios follow Bitget's definition: maintenance margin divided by margin
+// equity, with liquidation at 1.
+package margin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/account"
+	"github.com/khanbekov/go-bitget/futures/position"
+)
+
+// Level is the severity of a margin ratio.
+type Level string
+
+const (
+	LevelOK       Level = "ok"
+	LevelWarning  Level = "warning"
+	LevelCritical Level = "critical"
+)
+
+// Margin modes of a Ratio.
+const (
+	ModeCrossed  = "crossed"
+	ModeIsolated = "isolated"
+)
+
+// Options configures a Monitor.
+type Options struct {
+	ProductType futures.ProductType
+	MarginCoin  string
+
+	// Warning and Critical are the margin ratio thresholds. Default to 0.5 and 0.8.
+	Warning  float64
+	Critical float64
+	// Target is the ratio the suggested actions aim for. Defaults to 80% of Warning.
+	Target float64
+
+	// OnWarning and OnCritical receive alerts when a ratio enters the level. Optional.
+	OnWarning  func(Alert)
+	OnCritical func(Alert)
+	// OnRecovered receives alerts when a ratio drops back below Warning. Optional.
+	OnRecovered func(Alert)
+	// Clock stamps alerts. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// Ratio is the margin ratio of the cross margin account or of one isolated position.
+type Ratio struct {
+	Mode       string // ModeCrossed or ModeIsolated
+	MarginCoin string
+	// Symbol and HoldSide are set for isolated positions.
+	Symbol   string
+	HoldSide string
+	Value    float64
+	Level    Level
+}
+
+// Key identifies the ratio: "crossed:USDT" or "isolated:BTCUSDT:long".
+func (r Ratio) Key() string {
+	if r.Mode == ModeIsolated {
+		return ModeIsolated + ":" + r.Symbol + ":" + r.HoldSide
+	}
+	return ModeCrossed + ":" + r.MarginCoin
+}
+
+// ActionType is the kind of a suggested action.
+type ActionType string
+
+const (
+	// ActionReduce closes Size of a position.
+	ActionReduce ActionType = "reduce"
+	// ActionAddMargin adds Amount of margin to an isolated position.
+	ActionAddMargin ActionType = "add_margin"
+)
+
+// Action is a suggested deleveraging step.
+type Action struct {
+	Type     ActionType
+	Symbol   string
+	HoldSide string
+	Size     float64 // ActionReduce
+	Amount   float64 // ActionAddMargin, in the margin coin
+}
+
+// Alert reports a ratio that changed level.
+type Alert struct {
+	Ratio    Ratio
+	Previous Level
+	Actions  []Action
+	Time     time.Time
+}
+
+// String returns a one-line summary for notifications.
+func (a Alert) String() string {
+	s := fmt.Sprintf("margin %s %s ratio=%.2f%%", a.Ratio.Level, a.Ratio.Key(), a.Ratio.Value*100)
+	for _, act := range a.Actions {
+		switch act.Type {
+		case ActionReduce:
+			s += fmt.Sprintf("; reduce %s %s by %s", act.Symbol, act.HoldSide, common.FormatFloat(act.Size))
+		case ActionAddMargin:
+			s += fmt.Sprintf("; add %s margin to %s %s", common.FormatFloat(act.Amount), act.Symbol, act.HoldSide)
+		}
+	}
+	return s
+}
+
+// holding is the last known state of a position.
+type holding struct {
+	symbol      string
+	holdSide    string
+	marginMode  string
+	total       float64
+	marginSize  float64
+	unrealized  float64
+	marginRatio float64
+}
+
+// Monitor tracks margin ratios. It is safe for concurrent use.
+type Monitor struct {
+	client futures.ClientInterface
+	opts   Options
+	clock  common.Clock
+
+	mu        sync.Mutex
+	crossRate float64
+	crossSeen bool
+	holdings  map[string]holding
+	levels    map[string]Level
+}
+
+// NewMonitor creates a margin monitor.
+func NewMonitor(client futures.ClientInterface, opts Options) *Monitor {
+	if opts.Warning <= 0 {
+		opts.Warning = 0.5
+	}
+	if opts.Critical <= 0 {
+		opts.Critical = 0.8
+	}
+	if opts.Target <= 0 || opts.Target >= opts.Warning {
+		opts.Target = opts.Warning * 0.8
+	}
+	return &Monitor{
+		client:   client,
+		opts:     opts,
+		clock:    common.ClockOrSystem(opts.Clock),
+		holdings: make(map[string]holding),
+		levels:   make(map[string]Level),
+	}
+}
+
+// Level returns the level of a ratio value.
+func (m *Monitor) Level(ratio float64) Level {
+	switch {
+	case ratio >= m.opts.Critical:
+		return LevelCritical
+	case ratio >= m.opts.Warning:
+		return LevelWarning
+	}
+	return LevelOK
+}
+
+// Check fetches the account and positions over REST and fires alerts for ratios that
+// changed level.
+func (m *Monitor) Check(ctx context.Context) ([]Alert, error) {
+	accounts, err := account.NewAccountListService(m.client).ProductType(m.opts.ProductType).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("margin: accounts: %w", err)
+	}
+	svc := position.NewAllPositionsService(m.client).ProductType(m.opts.ProductType)
+	if m.opts.MarginCoin != "" {
+		svc.MarginCoin(m.opts.MarginCoin)
+	}
+	positions, err := svc.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("margin: positions: %w", err)
+	}
+
+	m.mu.Lock()
+	for _, a := range accounts.Accounts {
+		if m.opts.MarginCoin == "" || a.MarginCoin == m.opts.MarginCoin {
+			m.crossRate, m.crossSeen = parseFloat(a.CrossRiskRate), true
+			break
+		}
+	}
+	m.holdings = make(map[string]holding, len(positions))
+	for _, p := range positions {
+		if p == nil || p.Total <= 0 {
+			continue
+		}
+		h := holding{
+			symbol:      p.Symbol,
+			holdSide:    string(p.HoldSide),
+			marginMode:  p.MarginMode,
+			total:       p.Total,
+			marginSize:  p.MarginSize,
+			unrealized:  p.UnrealizedPL,
+			marginRatio: p.MarginRatio,
+		}
+		m.holdings[h.symbol+":"+h.holdSide] = h
+	}
+	m.mu.Unlock()
+	return m.evaluate(), nil
+}
+
+// Run calls Check every interval until ctx is cancelled.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := m.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := m.Check(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// HandleAccountMessage ingests a message from the private account channel. Its
+// signature matches ws.OnReceive so it can be passed to SubscribeAccount directly.
+func (m *Monitor) HandleAccountMessage(message string) {
+	var msg struct {
+		Data []struct {
+			MarginCoin      string `json:"marginCoin"`
+			CrossedRiskRate string `json:"crossedRiskRate"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(message), &msg); err != nil {
+		return
+	}
+	m.mu.Lock()
+	for _, d := range msg.Data {
+		if m.opts.MarginCoin == "" || d.MarginCoin == m.opts.MarginCoin {
+			m.crossRate, m.crossSeen = parseFloat(d.CrossedRiskRate), true
+		}
+	}
+	m.mu.Unlock()
+	m.evaluate()
+}
+
+// HandlePositionsMessage ingests a snapshot from the private positions channel. Its
+// signature matches ws.OnReceive so it can be passed to SubscribePositions directly.
+func (m *Monitor) HandlePositionsMessage(message string) {
+	var msg struct {
+		Data []struct {
+			InstID       string `json:"instId"`
+			MarginCoin   string `json:"marginCoin"`
+			HoldSide     string `json:"holdSide"`
+			MarginMode   string `json:"marginMode"`
+			Total        string `json:"total"`
+			MarginSize   string `json:"marginSize"`
+			UnrealizedPL string `json:"unrealizedPL"`
+			MarginRatio  string `json:"marginRatio"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(message), &msg); err != nil {
+		return
+	}
+	m.mu.Lock()
+	// The positions channel pushes the full list of open positions
+	m.holdings = make(map[string]holding, len(msg.Data))
+	for _, d := range msg.Data {
+		if m.opts.MarginCoin != "" && d.MarginCoin != "" && d.MarginCoin != m.opts.MarginCoin {
+			continue
+		}
+		h := holding{
+			symbol:      d.InstID,
+			holdSide:    d.HoldSide,
+			marginMode:  d.MarginMode,
+			total:       parseFloat(d.Total),
+			marginSize:  parseFloat(d.MarginSize),
+			unrealized:  parseFloat(d.UnrealizedPL),
+			marginRatio: parseFloat(d.MarginRatio),
+		}
+		if h.total > 0 {
+			m.holdings[h.symbol+":"+h.holdSide] = h
+		}
+	}
+	m.mu.Unlock()
+	m.evaluate()
+}
+
+// Ratios returns the current cross margin ratio followed by the isolated positions,
+// highest first.
+func (m *Monitor) Ratios() []Ratio {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ratios()
+}
+
+func (m *Monitor) ratios() []Ratio {
+	var ratios []Ratio
+	if m.crossSeen {
+		ratios = append(ratios, Ratio{Mode: ModeCrossed, MarginCoin: m.opts.MarginCoin, Value: m.crossRate, Level: m.Level(m.crossRate)})
+	}
+	var isolated []Ratio
+	for _, h := range m.holdings {
+		if h.marginMode != ModeIsolated {
+			continue
+		}
+		isolated = append(isolated, Ratio{
+			Mode:       ModeIsolated,
+			MarginCoin: m.opts.MarginCoin,
+			Symbol:     h.symbol,
+			HoldSide:   h.holdSide,
+			Value:      h.marginRatio,
+			Level:      m.Level(h.marginRatio),
+		})
+	}
+	sort.Slice(isolated, func(i, j int) bool {
+		if isolated[i].Value != isolated[j].Value {
+			return isolated[i].Value > isolated[j].Value
+		}
+		return isolated[i].Key() < isolated[j].Key()
+	})
+	return append(ratios, isolated...)
+}
+
+// evaluate compares the ratios with the last known levels and fires the callbacks.
+func (m *Monitor) evaluate() []Alert {
+	m.mu.Lock()
+	ratios := m.ratios()
+	seen := make(map[string]bool, len(ratios))
+	var alerts []Alert
+	for _, r := range ratios {
+		key := r.Key()
+		seen[key] = true
+		prev, ok := m.levels[key]
+		if !ok {
+			prev = LevelOK
+		}
+		m.levels[key] = r.Level
+		if r.Level == prev {
+			continue
+		}
+		alert := Alert{Ratio: r, Previous: prev, Time: m.clock.Now()}
+		if r.Level != LevelOK {
+			alert.Actions = m.actions(r)
+		}
+		alerts = append(alerts, alert)
+	}
+	// Closed isolated positions no longer need attention
+	for key := range m.levels {
+		if !seen[key] {
+			delete(m.levels, key)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, a := range alerts {
+		var fn func(Alert)
+		switch a.Ratio.Level {
+		case LevelWarning:
+			fn = m.opts.OnWarning
+		case LevelCritical:
+			fn = m.opts.OnCritical
+		default:
+			fn = m.opts.OnRecovered
+		}
+		if fn != nil {
+			fn(a)
+		}
+	}
+	return alerts
+}
+
+// actions suggests how to bring r back to the target ratio. Maintenance margin scales
+// with position size, so cross margin ratios fall proportionally when every crossed
+// position is reduced by the same fraction. Reducing an isolated position releases its
+// margin as well, so isolated positions need more margin instead.
+func (m *Monitor) actions(r Ratio) []Action {
+	if r.Value <= 0 {
+		return nil
+	}
+	if r.Mode == ModeIsolated {
+		h, ok := m.holdings[r.Symbol+":"+r.HoldSide]
+		if !ok {
+			return nil
+		}
+		equity := h.marginSize + h.unrealized
+		amount := equity * (r.Value/m.opts.Target - 1)
+		if amount <= 0 {
+			return nil
+		}
+		return []Action{{Type: ActionAddMargin, Symbol: h.symbol, HoldSide: h.holdSide, Amount: amount}}
+	}
+
+	fraction := 1 - m.opts.Target/r.Value
+	var actions []Action
+	for _, h := range m.holdings {
+		if h.marginMode == ModeIsolated {
+			continue
+		}
+		actions = append(actions, Action{Type: ActionReduce, Symbol: h.symbol, HoldSide: h.holdSide, Size: h.total * fraction})
+	}
+	sort.Slice(actions, func(i, j int) bool {
+		if actions[i].Symbol != actions[j].Symbol {
+			return actions[i].Symbol < actions[j].Symbol
+		}
+		return actions[i].HoldSide < actions[j].HoldSide
+	})
+	return actions
+}
+
+func parseFloat(s string) float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) {
+		return 0
+	}
+	return v
+}
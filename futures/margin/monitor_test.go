@@ -0,0 +1,121 @@
+package margin
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeClient struct {
+	riskRate  string
+	positions string
+}
+
+func (c *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	ok := func(data string) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+		return &futures.ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil
+	}
+	switch endpoint {
+	case futures.EndpointAccountList:
+		return ok(`[{"marginCoin":"USDT","crossRiskRate":"` + c.riskRate + `"}]`)
+	case futures.EndpointAllPositions:
+		return ok(c.positions)
+	}
+	return nil, nil, errors.New("unexpected endpoint " + endpoint)
+}
+
+type recorder struct {
+	warnings, criticals, recovered []Alert
+}
+
+func (r *recorder) options() Options {
+	return Options{
+		ProductType: futures.ProductTypeUSDTFutures,
+		MarginCoin:  "USDT",
+		Warning:     0.5,
+		Critical:    0.8,
+		Target:      0.4,
+		OnWarning:   func(a Alert) { r.warnings = append(r.warnings, a) },
+		OnCritical:  func(a Alert) { r.criticals = append(r.criticals, a) },
+		OnRecovered: func(a Alert) { r.recovered = append(r.recovered, a) },
+	}
+}
+
+func TestMonitor_CheckCrossedLevels(t *testing.T) {
+	client := &fakeClient{
+		riskRate: "0.2",
+		positions: `[{"symbol":"BTCUSDT","holdSide":"long","marginMode":"crossed","total":"2"},
+			{"symbol":"ETHUSDT","holdSide":"short","marginMode":"crossed","total":"10"}]`,
+	}
+	rec := &recorder{}
+	m := NewMonitor(client, rec.options())
+
+	alerts, err := m.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, alerts)
+
+	client.riskRate = "0.6"
+	alerts, err = m.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	require.Len(t, rec.warnings, 1)
+	a := rec.warnings[0]
+	assert.Equal(t, LevelWarning, a.Ratio.Level)
+	assert.Equal(t, LevelOK, a.Previous)
+	// Reducing by a third brings 0.6 to the 0.4 target
+	require.Len(t, a.Actions, 2)
+	assert.Equal(t, ActionReduce, a.Actions[0].Type)
+	assert.Equal(t, "BTCUSDT", a.Actions[0].Symbol)
+	assert.InDelta(t, 2.0/3, a.Actions[0].Size, 1e-9)
+	assert.InDelta(t, 10.0/3, a.Actions[1].Size, 1e-9)
+
+	// No repeated alert while the level holds
+	client.riskRate = "0.65"
+	alerts, _ = m.Check(context.Background())
+	assert.Empty(t, alerts)
+
+	client.riskRate = "0.9"
+	_, _ = m.Check(context.Background())
+	require.Len(t, rec.criticals, 1)
+	assert.Equal(t, LevelWarning, rec.criticals[0].Previous)
+
+	client.riskRate = "0.1"
+	_, _ = m.Check(context.Background())
+	require.Len(t, rec.recovered, 1)
+	assert.Empty(t, rec.recovered[0].Actions)
+	assert.Contains(t, rec.criticals[0].String(), "reduce BTCUSDT long")
+}
+
+func TestMonitor_WebSocketIsolated(t *testing.T) {
+	rec := &recorder{}
+	m := NewMonitor(&fakeClient{}, rec.options())
+
+	m.HandleAccountMessage(`{"arg":{"channel":"account"},"data":[{"marginCoin":"USDT","crossedRiskRate":"0.05"}]}`)
+	m.HandlePositionsMessage(`{"arg":{"channel":"positions"},"data":[
+		{"instId":"BTCUSDT","marginCoin":"USDT","holdSide":"short","marginMode":"isolated","total":"1","marginSize":"900","unrealizedPL":"100","marginRatio":"0.85"},
+		{"instId":"ETHUSDT","marginCoin":"USDT","holdSide":"long","marginMode":"isolated","total":"1","marginRatio":"0.1"}]}`)
+
+	require.Len(t, rec.criticals, 1)
+	a := rec.criticals[0]
+	assert.Equal(t, "isolated:BTCUSDT:short", a.Ratio.Key())
+	require.Len(t, a.Actions, 1)
+	assert.Equal(t, ActionAddMargin, a.Actions[0].Type)
+	// Margin equity 1000 must grow to 1000 * 0.85 / 0.4
+	assert.InDelta(t, 1125, a.Actions[0].Amount, 1e-9)
+
+	ratios := m.Ratios()
+	require.Len(t, ratios, 3)
+	assert.Equal(t, ModeCrossed, ratios[0].Mode)
+	assert.Equal(t, "BTCUSDT", ratios[1].Symbol, "isolated ratios sorted highest first")
+
+	// Closing the position drops it without a recovery alert
+	m.HandlePositionsMessage(`{"data":[]}`)
+	assert.Len(t, m.Ratios(), 1)
+	assert.Empty(t, rec.recovered)
+}
@@ -98,4 +98,13 @@ type Ticker struct {
 
 	// Open interest
 	OpenI string `json:"openI"`
+
+	// Index price
+	IndexPrice string `json:"indexPrice"`
+
+	// Mark price
+	MarkPrice string `json:"markPrice"`
+
+	// Current funding rate
+	FundingRate string `json:"fundingRate"`
 }
\ No newline at end of file
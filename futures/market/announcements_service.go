@@ -0,0 +1,130 @@
+package market
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// AnnouncementType selects a category of exchange announcements.
+type AnnouncementType string
+
+const (
+	AnnouncementLatestNews  AnnouncementType = "latest_news"
+	AnnouncementListings    AnnouncementType = "coin_listings"
+	AnnouncementPromotions  AnnouncementType = "trading_competitions_promotions"
+	AnnouncementMaintenance AnnouncementType = "maintenance_system_updates"
+	AnnouncementDelistings  AnnouncementType = "symbol_delisting"
+)
+
+// Announcement languages.
+const (
+	AnnouncementLanguageEN = "en_US"
+	AnnouncementLanguageZH = "zh_CN"
+)
+
+// AnnouncementsService retrieves Bitget public announcements, newest first. Announcements
+// older than one month are not served.
+type AnnouncementsService struct {
+	c ClientInterface
+
+	annType   AnnouncementType
+	language  string
+	startTime string
+	endTime   string
+	cursor    string
+	limit     string
+}
+
+// AnnType restricts the announcements to one category. Optional; all categories by default.
+func (s *AnnouncementsService) AnnType(annType AnnouncementType) *AnnouncementsService {
+	s.annType = annType
+	return s
+}
+
+// Language sets the announcement language, AnnouncementLanguageEN by default.
+func (s *AnnouncementsService) Language(language string) *AnnouncementsService {
+	s.language = language
+	return s
+}
+
+// StartTime sets the start of the creation time range.
+func (s *AnnouncementsService) StartTime(t time.Time) *AnnouncementsService {
+	s.startTime = strconv.FormatInt(t.UnixMilli(), 10)
+	return s
+}
+
+// EndTime sets the end of the creation time range.
+func (s *AnnouncementsService) EndTime(t time.Time) *AnnouncementsService {
+	s.endTime = strconv.FormatInt(t.UnixMilli(), 10)
+	return s
+}
+
+// Cursor requests announcements older than the given announcement ID, for pagination.
+func (s *AnnouncementsService) Cursor(annID string) *AnnouncementsService {
+	s.cursor = annID
+	return s
+}
+
+// Limit sets the page size, 10 by default.
+func (s *AnnouncementsService) Limit(limit int) *AnnouncementsService {
+	s.limit = strconv.Itoa(limit)
+	return s
+}
+
+// Announcement is a public exchange announcement.
+type Announcement struct {
+	AnnID    string `json:"annId"`    // Announcement ID
+	AnnTitle string `json:"annTitle"` // Title
+	AnnDesc  string `json:"annDesc"`  // Summary
+	CTime    string `json:"cTime"`    // Creation time (ms)
+	Language string `json:"language"` // Language
+	AnnURL   string `json:"annUrl"`   // Link to the full announcement
+}
+
+// Time returns the creation time.
+func (a *Announcement) Time() time.Time {
+	ms, err := strconv.ParseInt(a.CTime, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+// Do executes the announcements request.
+func (s *AnnouncementsService) Do(ctx context.Context) ([]Announcement, error) {
+	params := url.Values{}
+	language := s.language
+	if language == "" {
+		language = AnnouncementLanguageEN
+	}
+	params.Set("language", language)
+	if s.annType != "" {
+		params.Set("annType", string(s.annType))
+	}
+	if s.startTime != "" {
+		params.Set("startTime", s.startTime)
+	}
+	if s.endTime != "" {
+		params.Set("endTime", s.endTime)
+	}
+	if s.cursor != "" {
+		params.Set("cursor", s.cursor)
+	}
+	if s.limit != "" {
+		params.Set("limit", s.limit)
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointAnnouncements, params, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var announcements []Announcement
+	if err := json.Unmarshal(res.Data, &announcements); err != nil {
+		return nil, err
+	}
+	return announcements, nil
+}
@@ -0,0 +1,30 @@
+package market
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestAnnouncementsService_Do(t *testing.T) {
+	mockClient := &MockClient{}
+	params := url.Values{}
+	params.Set("language", AnnouncementLanguageEN)
+	params.Set("annType", string(AnnouncementDelistings))
+	params.Set("limit", "5")
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointAnnouncements, params, []byte(nil), false).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`[{"annId":"123","annTitle":"Bitget will delist XYZUSDT perpetual futures","annDesc":"","cTime":"1700000000000","language":"en_US","annUrl":"https://www.bitget.com/support/articles/123"}]`)},
+			&fasthttp.ResponseHeader{}, nil)
+
+	anns, err := NewAnnouncementsService(mockClient).AnnType(AnnouncementDelistings).Limit(5).Do(context.Background())
+	require.NoError(t, err)
+	require.Len(t, anns, 1)
+	assert.Equal(t, "123", anns[0].AnnID)
+	assert.Equal(t, int64(1700000000000), anns[0].Time().UnixMilli())
+	mockClient.AssertExpectations(t)
+}
@@ -1,12 +1,10 @@
 package market
 
 import (
-	"encoding/json"
-	"fmt"
 	jsoniter "github.com/json-iterator/go"
 	"golang.org/x/net/context"
 	"net/url"
-	"strconv"
+	"time"
 
 	"github.com/khanbekov/go-bitget/common"
 	"github.com/khanbekov/go-bitget/futures"
@@ -59,6 +57,12 @@ func (s *CandlestickService) EndTime(endTime string) *CandlestickService {
 	return s
 }
 
+// TimeRange sets StartTime and EndTime from time values.
+func (s *CandlestickService) TimeRange(start, end time.Time) *CandlestickService {
+	s.startTime, s.endTime = msString(start), msString(end)
+	return s
+}
+
 // Limit sets the maximum number of candlesticks to return.
 // Optional parameter. Default and maximum value is 1000.
 func (s *CandlestickService) Limit(limit string) *CandlestickService {
@@ -108,69 +112,5 @@ func (s *CandlestickService) Do(ctx context.Context) (candles []Candlestick, err
 }
 
 // Candlestick represents OHLCV (Open, High, Low, Close, Volume) data for a specific time period.
-// Contains all essential price and volume information for technical analysis.
-type Candlestick struct {
-	CloseTime        int64   `json:"time"`             // Timestamp when the candlestick period ended (milliseconds)
-	Open             float64 `json:"entry"`            // Opening price at the start of the period
-	High             float64 `json:"high"`             // Highest price during the period
-	Low              float64 `json:"low"`              // Lowest price during the period
-	Close            float64 `json:"exit"`             // Closing price at the end of the period
-	Volume           float64 `json:"volume"`           // Base asset volume traded during the period
-	QuoteAssetVolume float64 `json:"quoteAssetVolume"` // Quote asset volume traded during the period
-}
-
-// UnmarshalJSON implements custom JSON unmarshaling for Candlestick.
-// The Bitget API returns candlestick data as arrays of strings rather than objects,
-// so this method converts the array format to the struct fields.
-//
-// Expected array format: [timestamp, open, high, low, close, volume, quoteVolume]
-func (c *Candlestick) UnmarshalJSON(data []byte) error {
-	var arr []string
-	if err := json.Unmarshal(data, &arr); err != nil {
-		return err
-	}
-	if len(arr) != 7 {
-		return fmt.Errorf("expected 7 elements, received %d", len(arr))
-	}
-
-	// Парсим CloseTime
-	closeTime, err := strconv.ParseInt(arr[0], 10, 64)
-	if err != nil {
-		return fmt.Errorf("incorrect CloseTime: %v", err)
-	}
-
-	// Парсим числовые значения
-	parsers := []func(string) (any, error){
-		func(s string) (any, error) { return strconv.ParseFloat(s, 64) },
-		func(s string) (any, error) { return strconv.ParseFloat(s, 64) },
-		func(s string) (any, error) { return strconv.ParseFloat(s, 64) },
-		func(s string) (any, error) { return strconv.ParseFloat(s, 64) },
-		func(s string) (any, error) { return strconv.ParseFloat(s, 64) },
-		func(s string) (any, error) { return strconv.ParseFloat(s, 64) },
-	}
-
-	// Присваиваем значения полям
-	c.CloseTime = closeTime
-	for i := 1; i < 7; i++ {
-		val, err := parsers[i-1](arr[i])
-		if err != nil {
-			return fmt.Errorf("failed parsing element %d: %v", i+1, err)
-		}
-		switch i {
-		case 1:
-			c.Open = common.SafeFloat64Cast(val)
-		case 2:
-			c.High = common.SafeFloat64Cast(val)
-		case 3:
-			c.Low = common.SafeFloat64Cast(val)
-		case 4:
-			c.Close = common.SafeFloat64Cast(val)
-		case 5:
-			c.Volume = common.SafeFloat64Cast(val)
-		case 6:
-			c.QuoteAssetVolume = common.SafeFloat64Cast(val)
-		}
-	}
-
-	return nil
-}
+// It is an alias of common.Candle, shared with the uta package.
+type Candlestick = common.Candle
@@ -0,0 +1,154 @@
+package market
+
+import "slices"
+
+// Clone returns an independent copy of s and its parameters.
+func (s *AllTickersService) Clone() *AllTickersService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *AnnouncementsService) Clone() *AnnouncementsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CandlestickService) Clone() *CandlestickService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *ContractsService) Clone() *ContractsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CurrentFundingRateService) Clone() *CurrentFundingRateService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *ElitePositionRatioService) Clone() *ElitePositionRatioService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *HistoryCandlesticksService) Clone() *HistoryCandlesticksService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *HistoryFundingRateService) Clone() *HistoryFundingRateService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *HistoryIndexCandlesService) Clone() *HistoryIndexCandlesService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *HistoryMarkCandlesService) Clone() *HistoryMarkCandlesService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *IndexComponentsService) Clone() *IndexComponentsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *LiquidationOrdersService) Clone() *LiquidationOrdersService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *LongShortRatioService) Clone() *LongShortRatioService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *MultiTickerService) Clone() *MultiTickerService {
+	c := *s
+	c.symbols = slices.Clone(s.symbols)
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *OpenInterestService) Clone() *OpenInterestService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *OrderBookService) Clone() *OrderBookService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *PositionTierService) Clone() *PositionTierService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *PremiumIndexCandlesService) Clone() *PremiumIndexCandlesService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *PriceLimitService) Clone() *PriceLimitService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *RecentTradesService) Clone() *RecentTradesService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *RiskReserveService) Clone() *RiskReserveService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *ServerTimeService) Clone() *ServerTimeService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *SymbolPriceService) Clone() *SymbolPriceService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *TakerVolumeService) Clone() *TakerVolumeService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *TickerService) Clone() *TickerService {
+	c := *s
+	return &c
+}
@@ -0,0 +1,287 @@
+package market
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"golang.org/x/net/context"
+
+	"github.com/khanbekov/go-bitget/futures"
+)
+
+// candleQuery holds the parameters shared by the candlestick services.
+type candleQuery struct {
+	symbol      string
+	productType ProductType
+	granularity string
+	limit       string
+	startTime   string
+	endTime     string
+	kLineType   string // Price the candles are built from; empty for the traded price
+}
+
+func (q candleQuery) values() url.Values {
+	queryParams := url.Values{}
+	queryParams.Set("symbol", q.symbol)
+	queryParams.Set("productType", string(q.productType))
+	queryParams.Set("granularity", q.granularity)
+	if q.limit != "" {
+		queryParams.Set("limit", q.limit)
+	}
+	if q.startTime != "" {
+		queryParams.Set("startTime", q.startTime)
+	}
+	if q.endTime != "" {
+		queryParams.Set("endTime", q.endTime)
+	}
+	if q.kLineType != "" {
+		queryParams.Set("kLineType", q.kLineType)
+	}
+	return queryParams
+}
+
+func fetchCandles(ctx context.Context, c ClientInterface, endpoint string, q candleQuery) ([]Candlestick, error) {
+	res, _, err := c.CallAPI(ctx, "GET", endpoint, q.values(), nil, false)
+	if err != nil {
+		return nil, err
+	}
+	var candles []Candlestick
+	if err := jsoniter.Unmarshal(res.Data, &candles); err != nil {
+		return nil, err
+	}
+	return candles, nil
+}
+
+// fetchCandleRange pages backwards with endTime from q.endTime (or now) until q.startTime
+// is reached, a page comes back empty or max candles are collected (max <= 0 means no
+// limit). The result is sorted by time and free of duplicates.
+func fetchCandleRange(ctx context.Context, c ClientInterface, endpoint string, q candleQuery, max int) ([]Candlestick, error) {
+	start, _ := strconv.ParseInt(q.startTime, 10, 64)
+	end, _ := strconv.ParseInt(q.endTime, 10, 64)
+	if end <= 0 {
+		end = time.Now().UnixMilli()
+	}
+
+	seen := make(map[int64]bool)
+	var out []Candlestick
+	for max <= 0 || len(out) < max {
+		page := q
+		page.endTime = strconv.FormatInt(end, 10)
+		candles, err := fetchCandles(ctx, c, endpoint, page)
+		if err != nil {
+			return nil, err
+		}
+		if len(candles) == 0 {
+			break
+		}
+		oldest := end
+		for _, candle := range candles {
+			if candle.CloseTime < oldest {
+				oldest = candle.CloseTime
+			}
+			if candle.CloseTime < start || candle.CloseTime > end || seen[candle.CloseTime] {
+				continue
+			}
+			seen[candle.CloseTime] = true
+			out = append(out, candle)
+		}
+		if oldest <= start || oldest >= end {
+			break
+		}
+		end = oldest - 1
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].CloseTime < out[j].CloseTime })
+	if max > 0 && len(out) > max {
+		out = out[len(out)-max:]
+	}
+	return out, nil
+}
+
+func msString(t time.Time) string {
+	return strconv.FormatInt(t.UnixMilli(), 10)
+}
+
+// HistoryCandlesticksService retrieves historical candlesticks beyond the range served by
+// CandlestickService. Each request returns at most 200 candles; use All to page through a
+// longer time range.
+type HistoryCandlesticksService struct {
+	c ClientInterface
+	q candleQuery
+}
+
+// Symbol sets the trading pair symbol. Required parameter.
+func (s *HistoryCandlesticksService) Symbol(symbol string) *HistoryCandlesticksService {
+	s.q.symbol = symbol
+	return s
+}
+
+// ProductType sets the product type. Required parameter.
+func (s *HistoryCandlesticksService) ProductType(productType ProductType) *HistoryCandlesticksService {
+	s.q.productType = productType
+	return s
+}
+
+// Granularity sets the candle interval, e.g. "1m", "1H", "1D". Required parameter.
+func (s *HistoryCandlesticksService) Granularity(granularity string) *HistoryCandlesticksService {
+	s.q.granularity = granularity
+	return s
+}
+
+// StartTime sets the start of the range as a milliseconds timestamp string.
+func (s *HistoryCandlesticksService) StartTime(startTime string) *HistoryCandlesticksService {
+	s.q.startTime = startTime
+	return s
+}
+
+// EndTime sets the end of the range as a milliseconds timestamp string.
+func (s *HistoryCandlesticksService) EndTime(endTime string) *HistoryCandlesticksService {
+	s.q.endTime = endTime
+	return s
+}
+
+// TimeRange sets StartTime and EndTime from time values.
+func (s *HistoryCandlesticksService) TimeRange(start, end time.Time) *HistoryCandlesticksService {
+	s.q.startTime, s.q.endTime = msString(start), msString(end)
+	return s
+}
+
+// Limit sets the number of candles per request. Default 100, maximum 200.
+func (s *HistoryCandlesticksService) Limit(limit string) *HistoryCandlesticksService {
+	s.q.limit = limit
+	return s
+}
+
+// Do executes a single request.
+func (s *HistoryCandlesticksService) Do(ctx context.Context) ([]Candlestick, error) {
+	return fetchCandles(ctx, s.c, futures.EndpointHistoryCandles, s.q)
+}
+
+// All pages backwards from EndTime (default now) to StartTime and returns up to max
+// candles sorted by time; max <= 0 returns the whole range.
+func (s *HistoryCandlesticksService) All(ctx context.Context, max int) ([]Candlestick, error) {
+	return fetchCandleRange(ctx, s.c, futures.EndpointHistoryCandles, s.q, max)
+}
+
+// HistoryIndexCandlesService retrieves historical index price candlesticks. Each request
+// returns at most 200 candles; use All to page through a longer time range.
+type HistoryIndexCandlesService struct {
+	c ClientInterface
+	q candleQuery
+}
+
+// Symbol sets the trading pair symbol. Required parameter.
+func (s *HistoryIndexCandlesService) Symbol(symbol string) *HistoryIndexCandlesService {
+	s.q.symbol = symbol
+	return s
+}
+
+// ProductType sets the product type. Required parameter.
+func (s *HistoryIndexCandlesService) ProductType(productType ProductType) *HistoryIndexCandlesService {
+	s.q.productType = productType
+	return s
+}
+
+// Granularity sets the candle interval, e.g. "1m", "1H", "1D". Required parameter.
+func (s *HistoryIndexCandlesService) Granularity(granularity string) *HistoryIndexCandlesService {
+	s.q.granularity = granularity
+	return s
+}
+
+// StartTime sets the start of the range as a milliseconds timestamp string.
+func (s *HistoryIndexCandlesService) StartTime(startTime string) *HistoryIndexCandlesService {
+	s.q.startTime = startTime
+	return s
+}
+
+// EndTime sets the end of the range as a milliseconds timestamp string.
+func (s *HistoryIndexCandlesService) EndTime(endTime string) *HistoryIndexCandlesService {
+	s.q.endTime = endTime
+	return s
+}
+
+// TimeRange sets StartTime and EndTime from time values.
+func (s *HistoryIndexCandlesService) TimeRange(start, end time.Time) *HistoryIndexCandlesService {
+	s.q.startTime, s.q.endTime = msString(start), msString(end)
+	return s
+}
+
+// Limit sets the number of candles per request. Default 100, maximum 200.
+func (s *HistoryIndexCandlesService) Limit(limit string) *HistoryIndexCandlesService {
+	s.q.limit = limit
+	return s
+}
+
+// Do executes a single request.
+func (s *HistoryIndexCandlesService) Do(ctx context.Context) ([]Candlestick, error) {
+	return fetchCandles(ctx, s.c, futures.EndpointHistoryIndexCandles, s.q)
+}
+
+// All pages backwards from EndTime (default now) to StartTime and returns up to max
+// candles sorted by time; max <= 0 returns the whole range.
+func (s *HistoryIndexCandlesService) All(ctx context.Context, max int) ([]Candlestick, error) {
+	return fetchCandleRange(ctx, s.c, futures.EndpointHistoryIndexCandles, s.q, max)
+}
+
+// HistoryMarkCandlesService retrieves historical mark price candlesticks. Each request
+// returns at most 200 candles; use All to page through a longer time range.
+type HistoryMarkCandlesService struct {
+	c ClientInterface
+	q candleQuery
+}
+
+// Symbol sets the trading pair symbol. Required parameter.
+func (s *HistoryMarkCandlesService) Symbol(symbol string) *HistoryMarkCandlesService {
+	s.q.symbol = symbol
+	return s
+}
+
+// ProductType sets the product type. Required parameter.
+func (s *HistoryMarkCandlesService) ProductType(productType ProductType) *HistoryMarkCandlesService {
+	s.q.productType = productType
+	return s
+}
+
+// Granularity sets the candle interval, e.g. "1m", "1H", "1D". Required parameter.
+func (s *HistoryMarkCandlesService) Granularity(granularity string) *HistoryMarkCandlesService {
+	s.q.granularity = granularity
+	return s
+}
+
+// StartTime sets the start of the range as a milliseconds timestamp string.
+func (s *HistoryMarkCandlesService) StartTime(startTime string) *HistoryMarkCandlesService {
+	s.q.startTime = startTime
+	return s
+}
+
+// EndTime sets the end of the range as a milliseconds timestamp string.
+func (s *HistoryMarkCandlesService) EndTime(endTime string) *HistoryMarkCandlesService {
+	s.q.endTime = endTime
+	return s
+}
+
+// TimeRange sets StartTime and EndTime from time values.
+func (s *HistoryMarkCandlesService) TimeRange(start, end time.Time) *HistoryMarkCandlesService {
+	s.q.startTime, s.q.endTime = msString(start), msString(end)
+	return s
+}
+
+// Limit sets the number of candles per request. Default 100, maximum 200.
+func (s *HistoryMarkCandlesService) Limit(limit string) *HistoryMarkCandlesService {
+	s.q.limit = limit
+	return s
+}
+
+// Do executes a single request.
+func (s *HistoryMarkCandlesService) Do(ctx context.Context) ([]Candlestick, error) {
+	return fetchCandles(ctx, s.c, futures.EndpointHistoryMarkCandles, s.q)
+}
+
+// All pages backwards from EndTime (default now) to StartTime and returns up to max
+// candles sorted by time; max <= 0 returns the whole range.
+func (s *HistoryMarkCandlesService) All(ctx context.Context, max int) ([]Candlestick, error) {
+	return fetchCandleRange(ctx, s.c, futures.EndpointHistoryMarkCandles, s.q, max)
+}
@@ -0,0 +1,85 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// candlePage returns candles at the given minutes (in milliseconds) in ascending order.
+func candlePage(minutes ...int64) *futures.ApiResponse {
+	data := "["
+	for i, m := range minutes {
+		if i > 0 {
+			data += ","
+		}
+		data += fmt.Sprintf(`["%d","1","2","0.5","1.5","10","15"]`, m*60000)
+	}
+	return &futures.ApiResponse{Code: "00000", Data: []byte(data + "]")}
+}
+
+func endTimeIs(ms int64) interface{} {
+	return mock.MatchedBy(func(q url.Values) bool { return q.Get("endTime") == fmt.Sprint(ms) })
+}
+
+func TestHistoryCandlesticksService_Do(t *testing.T) {
+	mockClient := &MockClient{}
+	start, end := time.UnixMilli(60000), time.UnixMilli(180000)
+	query := url.Values{
+		"symbol": {"BTCUSDT"}, "productType": {"USDT-FUTURES"}, "granularity": {"1m"},
+		"startTime": {"60000"}, "endTime": {"180000"}, "limit": {"200"},
+	}
+	mockClient.On("CallAPI", mock.Anything, "GET", futures.EndpointHistoryMarkCandles, query, []byte(nil), false).
+		Return(candlePage(1, 2, 3), &fasthttp.ResponseHeader{}, nil)
+
+	candles, err := NewHistoryMarkCandlesService(mockClient).
+		Symbol("BTCUSDT").ProductType(ProductTypeUSDTFutures).Granularity("1m").
+		TimeRange(start, end).Limit("200").
+		Do(context.Background())
+	require.NoError(t, err)
+	require.Len(t, candles, 3)
+	assert.Equal(t, 1.5, candles[2].Close)
+	mockClient.AssertExpectations(t)
+}
+
+func TestHistoryCandlesticksService_AllPagesBackwards(t *testing.T) {
+	mockClient := &MockClient{}
+	call := func(end int64, resp *futures.ApiResponse) {
+		mockClient.On("CallAPI", mock.Anything, "GET", futures.EndpointHistoryCandles, endTimeIs(end), []byte(nil), false).
+			Return(resp, &fasthttp.ResponseHeader{}, nil).Once()
+	}
+	call(10*60000, candlePage(8, 9, 10))
+	call(8*60000-1, candlePage(5, 6, 7))
+	call(5*60000-1, candlePage(2, 3, 4))
+
+	service := NewHistoryCandlesticksService(mockClient).
+		Symbol("BTCUSDT").ProductType(ProductTypeUSDTFutures).Granularity("1m").
+		StartTime(fmt.Sprint(3 * 60000)).EndTime(fmt.Sprint(10 * 60000))
+	candles, err := service.All(context.Background(), 0)
+	require.NoError(t, err)
+
+	var minutes []int64
+	for _, c := range candles {
+		minutes = append(minutes, c.CloseTime/60000)
+	}
+	assert.Equal(t, []int64{3, 4, 5, 6, 7, 8, 9, 10}, minutes, "ascending and clipped to StartTime")
+	mockClient.AssertExpectations(t)
+
+	mockClient = &MockClient{}
+	mockClient.On("CallAPI", mock.Anything, "GET", futures.EndpointHistoryIndexCandles, mock.Anything, []byte(nil), false).
+		Return(candlePage(8, 9, 10), &fasthttp.ResponseHeader{}, nil).Once()
+	mockClient.On("CallAPI", mock.Anything, "GET", futures.EndpointHistoryIndexCandles, mock.Anything, []byte(nil), false).
+		Return(candlePage(5, 6, 7), &fasthttp.ResponseHeader{}, nil).Once()
+	candles, err = NewHistoryIndexCandlesService(mockClient).EndTime(fmt.Sprint(10*60000)).All(context.Background(), 4)
+	require.NoError(t, err)
+	require.Len(t, candles, 4)
+	assert.Equal(t, int64(7*60000), candles[0].CloseTime, "max keeps the most recent candles")
+}
@@ -1,6 +1,7 @@
 package market
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"net/url"
@@ -79,6 +80,16 @@ type HistoryFundingRateResponse struct {
 	NextPage     string               `json:"nextPage"`
 }
 
+// UnmarshalJSON accepts the plain array the endpoint returns, newest first, as well as
+// the wrapped {"data": [...], "nextPage": ...} form.
+func (r *HistoryFundingRateResponse) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		return json.Unmarshal(trimmed, &r.FundingRates)
+	}
+	type wrapped HistoryFundingRateResponse
+	return json.Unmarshal(data, (*wrapped)(r))
+}
+
 // Do executes the history funding rate request.
 func (s *HistoryFundingRateService) Do(ctx context.Context) (*HistoryFundingRateResponse, error) {
 	// Build query parameters
@@ -0,0 +1,34 @@
+package market
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestHistoryFundingRateService_Do(t *testing.T) {
+	mockClient := &MockClient{}
+	query := url.Values{"symbol": {"BTCUSDT"}, "productType": {"USDT-FUTURES"}, "pageSize": {"100"}, "pageNo": {"2"}}
+	mockClient.On("CallAPI", mock.Anything, "GET", "/api/v2/mix/market/history-fund-rate", query, []byte(nil), false).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`[{"symbol":"BTCUSDT","fundingRate":"0.0001","fundingTime":"1700006400000"},
+			{"symbol":"BTCUSDT","fundingRate":"-0.00002","fundingTime":"1699977600000"}]`)}, &fasthttp.ResponseHeader{}, nil).Once()
+
+	res, err := NewHistoryFundingRateService(mockClient).
+		Symbol("BTCUSDT").ProductType(ProductTypeUSDTFutures).PageSize("100").PageNo("2").
+		Do(context.Background())
+	require.NoError(t, err)
+	require.Len(t, res.FundingRates, 2)
+	assert.Equal(t, "0.0001", res.FundingRates[0].FundingRate)
+	assert.Equal(t, "1699977600000", res.FundingRates[1].FundingTime)
+	mockClient.AssertExpectations(t)
+
+	var wrapped HistoryFundingRateResponse
+	require.NoError(t, wrapped.UnmarshalJSON([]byte(`{"data":[{"fundingRate":"0.1"}],"nextPage":"x"}`)))
+	assert.Equal(t, "x", wrapped.NextPage)
+	assert.Len(t, wrapped.FundingRates, 1)
+}
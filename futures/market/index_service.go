@@ -0,0 +1,171 @@
+package market
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+)
+
+// IndexComponent is one spot market feeding an index price.
+type IndexComponent struct {
+	Exchange        string  // Source exchange
+	SpotPair        string  // Spot pair on the source exchange
+	EquivalentPrice float64 // Source price converted to the index quote coin
+	Weight          float64 // Weight in the index, the weights sum to 1
+}
+
+// UnmarshalJSON decodes the exchange record, where numbers are sent as strings.
+func (c *IndexComponent) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Exchange        string      `json:"exchange"`
+		SpotPair        string      `json:"spotPair"`
+		EquivalentPrice json.Number `json:"equivalentPrice"`
+		Weight          json.Number `json:"weight"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	parsed := IndexComponent{Exchange: raw.Exchange, SpotPair: raw.SpotPair}
+	var err error
+	if parsed.EquivalentPrice, err = parseNumber(raw.EquivalentPrice); err != nil {
+		return fmt.Errorf("invalid component price: %w", err)
+	}
+	if parsed.Weight, err = parseNumber(raw.Weight); err != nil {
+		return fmt.Errorf("invalid component weight: %w", err)
+	}
+	*c = parsed
+	return nil
+}
+
+// IndexComponents lists the spot markets an index price is computed from.
+type IndexComponents struct {
+	Index      string           `json:"index"`
+	Components []IndexComponent `json:"components"`
+}
+
+// Price returns the weighted price of the components, normalized by their total weight
+// so that components missing from a partial response do not skew it. It returns 0 when
+// there are no weighted components.
+func (ic *IndexComponents) Price() float64 {
+	var sum, weights float64
+	for _, c := range ic.Components {
+		sum += c.EquivalentPrice * c.Weight
+		weights += c.Weight
+	}
+	if weights == 0 {
+		return 0
+	}
+	return sum / weights
+}
+
+// IndexComponentsService retrieves the constituents of the index price of a symbol.
+//
+// Bitget only serves this data on the v3 market API.
+type IndexComponentsService struct {
+	c ClientInterface
+
+	// Required parameters
+	symbol string
+}
+
+// Symbol sets the index symbol, the same as the contract symbol (e.g., "BTCUSDT").
+// Required parameter.
+func (s *IndexComponentsService) Symbol(symbol string) *IndexComponentsService {
+	s.symbol = symbol
+	return s
+}
+
+// Do executes the index components request.
+func (s *IndexComponentsService) Do(ctx context.Context) (*IndexComponents, error) {
+	if s.symbol == "" {
+		return nil, fmt.Errorf("symbol is required")
+	}
+
+	params := url.Values{}
+	params.Set("symbol", s.symbol)
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointIndexComponents, params, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var components IndexComponents
+	if err := json.Unmarshal(res.Data, &components); err != nil {
+		return nil, err
+	}
+	return &components, nil
+}
+
+// PremiumIndexCandlesService retrieves premium index candlesticks of a perpetual
+// contract. The premium index is the relative difference between the contract price and
+// the index price that drives the funding rate, so the candle prices are fractions
+// (0.0001 = 1 basis point) and volumes are zero. Each request returns at most 1000
+// candles; use All to page through a longer time range.
+type PremiumIndexCandlesService struct {
+	c ClientInterface
+	q candleQuery
+}
+
+// Symbol sets the trading pair symbol. Required parameter.
+func (s *PremiumIndexCandlesService) Symbol(symbol string) *PremiumIndexCandlesService {
+	s.q.symbol = symbol
+	return s
+}
+
+// ProductType sets the product type. Required parameter.
+func (s *PremiumIndexCandlesService) ProductType(productType ProductType) *PremiumIndexCandlesService {
+	s.q.productType = productType
+	return s
+}
+
+// Granularity sets the candle interval, e.g. "1m", "1H", "1D". Required parameter.
+func (s *PremiumIndexCandlesService) Granularity(granularity string) *PremiumIndexCandlesService {
+	s.q.granularity = granularity
+	return s
+}
+
+// StartTime sets the start of the range as a milliseconds timestamp string.
+func (s *PremiumIndexCandlesService) StartTime(startTime string) *PremiumIndexCandlesService {
+	s.q.startTime = startTime
+	return s
+}
+
+// EndTime sets the end of the range as a milliseconds timestamp string.
+func (s *PremiumIndexCandlesService) EndTime(endTime string) *PremiumIndexCandlesService {
+	s.q.endTime = endTime
+	return s
+}
+
+// TimeRange sets StartTime and EndTime from time values.
+func (s *PremiumIndexCandlesService) TimeRange(start, end time.Time) *PremiumIndexCandlesService {
+	s.q.startTime, s.q.endTime = msString(start), msString(end)
+	return s
+}
+
+// Limit sets the number of candles per request. Default 100, maximum 1000.
+func (s *PremiumIndexCandlesService) Limit(limit string) *PremiumIndexCandlesService {
+	s.q.limit = limit
+	return s
+}
+
+// Do executes a single request.
+func (s *PremiumIndexCandlesService) Do(ctx context.Context) ([]Candlestick, error) {
+	return fetchCandles(ctx, s.c, futures.EndpointCandlesticks, s.premium())
+}
+
+// All pages backwards from EndTime (default now) to StartTime and returns up to max
+// candles sorted by time; max <= 0 returns the whole range.
+func (s *PremiumIndexCandlesService) All(ctx context.Context, max int) ([]Candlestick, error) {
+	return fetchCandleRange(ctx, s.c, futures.EndpointCandlesticks, s.premium(), max)
+}
+
+func (s *PremiumIndexCandlesService) premium() candleQuery {
+	q := s.q
+	q.kLineType = "PREMIUM"
+	return q
+}
@@ -0,0 +1,54 @@
+package market
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestIndexComponentsService_Do(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointIndexComponents, url.Values{"symbol": {"BTCUSDT"}}, []byte(nil), false).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`{"index":"BTCUSDT","components":[
+			{"exchange":"binance","spotPair":"BTCUSDT","equivalentPrice":"65000","weight":"0.75"},
+			{"exchange":"okx","spotPair":"BTCUSDT","equivalentPrice":"65040","weight":"0.25"}]}`)}, &fasthttp.ResponseHeader{}, nil)
+
+	index, err := NewIndexComponentsService(mockClient).Symbol("BTCUSDT").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "BTCUSDT", index.Index)
+	require.Len(t, index.Components, 2)
+	assert.Equal(t, IndexComponent{Exchange: "binance", SpotPair: "BTCUSDT", EquivalentPrice: 65000, Weight: 0.75}, index.Components[0])
+	assert.InDelta(t, 65010, index.Price(), 1e-9)
+	mockClient.AssertExpectations(t)
+
+	_, err = NewIndexComponentsService(mockClient).Do(context.Background())
+	assert.EqualError(t, err, "symbol is required")
+	assert.Zero(t, (&IndexComponents{}).Price())
+}
+
+func TestPremiumIndexCandlesService_Do(t *testing.T) {
+	mockClient := &MockClient{}
+	query := url.Values{
+		"symbol": {"BTCUSDT"}, "productType": {"USDT-FUTURES"}, "granularity": {"1H"},
+		"kLineType": {"PREMIUM"}, "limit": {"2"},
+	}
+	mockClient.On("CallAPI", mock.Anything, "GET", futures.EndpointCandlesticks, query, []byte(nil), false).
+		Return(&futures.ApiResponse{Code: "00000", Data: []byte(`[
+			["1700000000000","0.0001","0.0003","-0.0002","0.0002","0","0"],
+			["1700003600000","0.0002","0.0004","0.0001","0.0003","0","0"]]`)}, &fasthttp.ResponseHeader{}, nil)
+
+	service := NewPremiumIndexCandlesService(mockClient).
+		Symbol("BTCUSDT").ProductType(ProductTypeUSDTFutures).Granularity("1H").Limit("2")
+	candles, err := service.Do(context.Background())
+	require.NoError(t, err)
+	require.Len(t, candles, 2)
+	assert.Equal(t, 0.0003, candles[1].Close)
+	assert.Empty(t, service.q.kLineType, "the service parameters are not modified")
+	mockClient.AssertExpectations(t)
+}
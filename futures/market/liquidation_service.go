@@ -0,0 +1,178 @@
+package market
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/khanbekov/go-bitget/ws"
+)
+
+// LiquidationEvent is a forced order executed by the liquidation engine. Side is the side
+// of the forced order: "sell" closes a liquidated long, "buy" a liquidated short.
+type LiquidationEvent struct {
+	Symbol string
+	Side   string
+	Price  float64
+	Size   float64
+	Ts     int64 // Execution time (ms)
+}
+
+// Time returns the execution time.
+func (e LiquidationEvent) Time() time.Time {
+	return time.UnixMilli(e.Ts)
+}
+
+// Value returns the notional value of the forced order.
+func (e LiquidationEvent) Value() float64 {
+	return e.Price * e.Size
+}
+
+// LongLiquidated reports whether the event closed a long position.
+func (e LiquidationEvent) LongLiquidated() bool {
+	return e.Side == "sell"
+}
+
+// UnmarshalJSON decodes the exchange record, where numbers are sent as strings.
+func (e *LiquidationEvent) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Symbol string      `json:"symbol"`
+		InstId string      `json:"instId"`
+		Side   string      `json:"side"`
+		Price  json.Number `json:"price"`
+		Size   json.Number `json:"size"`
+		Ts     json.Number `json:"ts"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	parsed := LiquidationEvent{Symbol: raw.Symbol, Side: raw.Side}
+	if parsed.Symbol == "" {
+		parsed.Symbol = raw.InstId
+	}
+	var err error
+	if parsed.Price, err = parseNumber(raw.Price); err != nil {
+		return fmt.Errorf("invalid liquidation price: %w", err)
+	}
+	if parsed.Size, err = parseNumber(raw.Size); err != nil {
+		return fmt.Errorf("invalid liquidation size: %w", err)
+	}
+	if raw.Ts != "" {
+		if parsed.Ts, err = strconv.ParseInt(string(raw.Ts), 10, 64); err != nil {
+			return fmt.Errorf("invalid liquidation ts: %w", err)
+		}
+	}
+	*e = parsed
+	return nil
+}
+
+func parseNumber(n json.Number) (float64, error) {
+	if n == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(string(n), 64)
+}
+
+// ParseLiquidationMessage decodes a push of the public liquidation WebSocket channel
+// (see ws.BaseWsClient.SubscribeLiquidations). Records without a symbol take it from the
+// subscription.
+func ParseLiquidationMessage(message string) ([]LiquidationEvent, error) {
+	var msg ws.WebSocketMessage
+	if err := json.Unmarshal([]byte(message), &msg); err != nil {
+		return nil, err
+	}
+	if msg.Arg.Channel != ws.ChannelLiquidation {
+		return nil, fmt.Errorf("unexpected channel %q", msg.Arg.Channel)
+	}
+	var events []LiquidationEvent
+	if err := json.Unmarshal(msg.Data, &events); err != nil {
+		return nil, err
+	}
+	for i := range events {
+		if events[i].Symbol == "" {
+			events[i].Symbol = msg.Arg.Symbol
+		}
+	}
+	return events, nil
+}
+
+// LiquidationOrdersService retrieves recent public forced (liquidation) orders.
+type LiquidationOrdersService struct {
+	c ClientInterface
+
+	// Required parameters
+	productType ProductType
+
+	// Optional parameters
+	symbol    string
+	startTime string
+	endTime   string
+	limit     string
+}
+
+// ProductType sets the product type. Required parameter.
+func (s *LiquidationOrdersService) ProductType(productType ProductType) *LiquidationOrdersService {
+	s.productType = productType
+	return s
+}
+
+// Symbol filters by trading symbol (e.g., "BTCUSDT").
+func (s *LiquidationOrdersService) Symbol(symbol string) *LiquidationOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// StartTime sets the start of the range as a milliseconds timestamp string.
+func (s *LiquidationOrdersService) StartTime(startTime string) *LiquidationOrdersService {
+	s.startTime = startTime
+	return s
+}
+
+// EndTime sets the end of the range as a milliseconds timestamp string.
+func (s *LiquidationOrdersService) EndTime(endTime string) *LiquidationOrdersService {
+	s.endTime = endTime
+	return s
+}
+
+// Limit sets the number of records. Default 100.
+func (s *LiquidationOrdersService) Limit(limit string) *LiquidationOrdersService {
+	s.limit = limit
+	return s
+}
+
+// Do executes the request and returns the forced orders, newest first.
+func (s *LiquidationOrdersService) Do(ctx context.Context) ([]LiquidationEvent, error) {
+	if s.productType == "" {
+		return nil, fmt.Errorf("productType is required")
+	}
+
+	params := url.Values{}
+	params.Set("productType", string(s.productType))
+	if s.symbol != "" {
+		params.Set("symbol", s.symbol)
+	}
+	if s.startTime != "" {
+		params.Set("startTime", s.startTime)
+	}
+	if s.endTime != "" {
+		params.Set("endTime", s.endTime)
+	}
+	if s.limit != "" {
+		params.Set("limit", s.limit)
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointLiquidationOrders, params, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []LiquidationEvent
+	if err := json.Unmarshal(res.Data, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
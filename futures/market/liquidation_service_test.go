@@ -0,0 +1,51 @@
+package market
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestLiquidationOrdersService_Do(t *testing.T) {
+	mockClient := &MockClient{}
+	query := url.Values{"productType": {"USDT-FUTURES"}, "symbol": {"BTCUSDT"}, "limit": {"2"}}
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointLiquidationOrders, query, []byte(nil), false).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`[
+			{"symbol":"BTCUSDT","side":"sell","price":"50000","size":"0.5","ts":"1700000000000"},
+			{"symbol":"BTCUSDT","side":"buy","price":"50100.5","size":"2","ts":"1699999990000"}]`)}, &fasthttp.ResponseHeader{}, nil)
+
+	events, err := NewLiquidationOrdersService(mockClient).
+		ProductType(ProductTypeUSDTFutures).
+		Symbol("BTCUSDT").
+		Limit("2").
+		Do(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, 25000.0, events[0].Value())
+	assert.True(t, events[0].LongLiquidated())
+	assert.False(t, events[1].LongLiquidated())
+	assert.Equal(t, int64(1700000000000), events[0].Time().UnixMilli())
+	mockClient.AssertExpectations(t)
+
+	_, err = NewLiquidationOrdersService(mockClient).Do(context.Background())
+	assert.EqualError(t, err, "productType is required")
+}
+
+func TestParseLiquidationMessage(t *testing.T) {
+	events, err := ParseLiquidationMessage(`{"action":"update","arg":{"instType":"USDT-FUTURES","channel":"liquidation","instId":"ETHUSDT"},
+		"data":[{"side":"buy","price":"3000","size":"4","ts":"1700000000000"}],"ts":1700000000001}`)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, LiquidationEvent{Symbol: "ETHUSDT", Side: "buy", Price: 3000, Size: 4, Ts: 1700000000000}, events[0])
+
+	_, err = ParseLiquidationMessage(`{"arg":{"channel":"trade"},"data":[]}`)
+	assert.EqualError(t, err, `unexpected channel "trade"`)
+
+	_, err = ParseLiquidationMessage(`{"arg":{"channel":"liquidation"},"data":[{"price":"x"}]}`)
+	assert.Error(t, err)
+}
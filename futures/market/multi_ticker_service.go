@@ -0,0 +1,157 @@
+package market
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/context"
+
+	"github.com/khanbekov/go-bitget/futures"
+)
+
+// MultiTickerService retrieves 24hr ticker statistics for a list of symbols.
+//
+// Bitget has no multi-symbol ticker endpoint. Small lists are fetched with parallel
+// single-symbol requests, which keeps the payload to the requested symbols; lists longer
+// than MaxIndividual use one all-tickers request filtered locally.
+type MultiTickerService struct {
+	c             futures.ClientInterface
+	symbols       []string
+	productType   futures.ProductType
+	maxIndividual int
+	concurrency   int
+}
+
+// Symbols adds symbols to the request. Duplicates are ignored.
+func (s *MultiTickerService) Symbols(symbols ...string) *MultiTickerService {
+	s.symbols = append(s.symbols, symbols...)
+	return s
+}
+
+// ProductType sets the product type for the request.
+// Required parameter. Use ProductTypeUSDTFutures, ProductTypeUSDCFutures, or ProductTypeCOINFutures.
+func (s *MultiTickerService) ProductType(productType futures.ProductType) *MultiTickerService {
+	s.productType = productType
+	return s
+}
+
+// MaxIndividual sets the largest symbol count fetched with single-symbol requests.
+// Longer lists use the all-tickers endpoint. Default 20; 0 always uses all-tickers.
+func (s *MultiTickerService) MaxIndividual(n int) *MultiTickerService {
+	s.maxIndividual = n
+	return s
+}
+
+// Concurrency bounds parallel single-symbol requests. Default 5.
+func (s *MultiTickerService) Concurrency(n int) *MultiTickerService {
+	s.concurrency = n
+	return s
+}
+
+// Do executes the request and returns the tickers keyed by symbol. It fails when any
+// requested symbol has no ticker, listing the missing symbols.
+func (s *MultiTickerService) Do(ctx context.Context) (map[string]*Ticker, error) {
+	symbols := uniqueSymbols(s.symbols)
+	if len(symbols) == 0 {
+		return nil, fmt.Errorf("at least one symbol is required")
+	}
+
+	var (
+		tickers map[string]*Ticker
+		err     error
+	)
+	if len(symbols) <= s.maxIndividual {
+		tickers, err = s.individual(ctx, symbols)
+	} else {
+		tickers, err = s.filtered(ctx, symbols)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, symbol := range symbols {
+		if tickers[symbol] == nil {
+			missing = append(missing, symbol)
+		}
+	}
+	if len(missing) > 0 {
+		return tickers, fmt.Errorf("no ticker data returned for %s", strings.Join(missing, ", "))
+	}
+	return tickers, nil
+}
+
+// individual fetches each symbol with TickerService.
+func (s *MultiTickerService) individual(ctx context.Context, symbols []string) (map[string]*Ticker, error) {
+	concurrency := s.concurrency
+	if concurrency <= 0 {
+		concurrency = 5
+	}
+	sem := make(chan struct{}, concurrency)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	tickers := make(map[string]*Ticker, len(symbols))
+
+	for _, symbol := range symbols {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(symbol string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			ticker, err := NewTickerService(s.c).Symbol(symbol).ProductType(string(s.productType)).Do(ctx)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("ticker %s: %w", symbol, err)
+				}
+				return
+			}
+			tickers[symbol] = ticker
+		}(symbol)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return tickers, nil
+}
+
+// filtered fetches all tickers and keeps the requested symbols.
+func (s *MultiTickerService) filtered(ctx context.Context, symbols []string) (map[string]*Ticker, error) {
+	all, err := NewAllTickersService(s.c).ProductType(s.productType).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		wanted[symbol] = true
+	}
+	tickers := make(map[string]*Ticker, len(symbols))
+	for _, t := range all {
+		if t != nil && wanted[t.Symbol] {
+			tickers[t.Symbol] = t
+		}
+	}
+	return tickers, nil
+}
+
+func uniqueSymbols(symbols []string) []string {
+	seen := make(map[string]bool, len(symbols))
+	out := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+		if symbol != "" && !seen[symbol] {
+			seen[symbol] = true
+			out = append(out, symbol)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,65 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func tickerResponse(data string) *futures.ApiResponse {
+	return &futures.ApiResponse{Code: "00000", Data: []byte(data)}
+}
+
+func TestMultiTickerService_Individual(t *testing.T) {
+	mockClient := &MockClient{}
+	for _, symbol := range []string{"BTCUSDT", "ETHUSDT"} {
+		query := url.Values{"symbol": {symbol}, "productType": {"USDT-FUTURES"}}
+		mockClient.On("CallAPI", mock.Anything, "GET", futures.EndpointTicker, query, []byte(nil), false).
+			Return(tickerResponse(`[{"symbol":"`+symbol+`","lastPr":"1"}]`), &fasthttp.ResponseHeader{}, nil).Once()
+	}
+
+	tickers, err := NewMultiTickerService(mockClient).
+		Symbols("BTCUSDT", "ethusdt", "BTCUSDT").
+		ProductType(futures.ProductTypeUSDTFutures).
+		Do(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, tickers, 2)
+	assert.Equal(t, "ETHUSDT", tickers["ETHUSDT"].Symbol)
+	mockClient.AssertExpectations(t)
+}
+
+func TestMultiTickerService_FilteredAllTickers(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("CallAPI", mock.Anything, "GET", futures.EndpointAllTickers, url.Values{"productType": {"USDT-FUTURES"}}, []byte(nil), false).
+		Return(tickerResponse(`[{"symbol":"BTCUSDT"},{"symbol":"ETHUSDT"},{"symbol":"SOLUSDT"}]`), &fasthttp.ResponseHeader{}, nil)
+
+	service := NewMultiTickerService(mockClient).ProductType(futures.ProductTypeUSDTFutures).MaxIndividual(1)
+	tickers, err := service.Symbols("BTCUSDT", "SOLUSDT").Do(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, tickers, 2)
+	assert.NotNil(t, tickers["SOLUSDT"])
+
+	tickers, err = NewMultiTickerService(mockClient).ProductType(futures.ProductTypeUSDTFutures).
+		MaxIndividual(0).Symbols("BTCUSDT", "XRPUSDT", "DOGEUSDT").Do(context.Background())
+	assert.EqualError(t, err, "no ticker data returned for DOGEUSDT, XRPUSDT")
+	assert.Len(t, tickers, 1, "found tickers are still returned")
+	mockClient.AssertNumberOfCalls(t, "CallAPI", 2)
+}
+
+func TestMultiTickerService_Errors(t *testing.T) {
+	_, err := NewMultiTickerService(&MockClient{}).Do(context.Background())
+	assert.EqualError(t, err, "at least one symbol is required")
+
+	mockClient := &MockClient{}
+	mockClient.On("CallAPI", mock.Anything, "GET", futures.EndpointTicker, mock.Anything, []byte(nil), false).
+		Return(nil, &fasthttp.ResponseHeader{}, errors.New("rate limited"))
+	_, err = NewMultiTickerService(mockClient).Symbols("BTCUSDT").Do(context.Background())
+	assert.EqualError(t, err, "ticker BTCUSDT: rate limited")
+}
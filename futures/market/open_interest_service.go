@@ -1,6 +1,7 @@
 package market
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"net/url"
@@ -54,9 +55,24 @@ func (s *OpenInterestService) Do(ctx context.Context) (*OpenInterestResponse, er
 		return nil, err
 	}
 
-	// Parse response - the API returns an array directly
+	// The API returns {"openInterestList": [...], "ts": "..."}; older responses were a
+	// plain array
 	var openInterests []OpenInterest
-	if err := json.Unmarshal(res.Data, &openInterests); err != nil {
+	if trimmed := bytes.TrimSpace(res.Data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var wrapped struct {
+			OpenInterestList []OpenInterest `json:"openInterestList"`
+			Ts               string         `json:"ts"`
+		}
+		if err := json.Unmarshal(trimmed, &wrapped); err != nil {
+			return nil, err
+		}
+		openInterests = wrapped.OpenInterestList
+		for i := range openInterests {
+			if openInterests[i].Timestamp == "" {
+				openInterests[i].Timestamp = wrapped.Ts
+			}
+		}
+	} else if err := json.Unmarshal(res.Data, &openInterests); err != nil {
 		return nil, err
 	}
 
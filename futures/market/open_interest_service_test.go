@@ -0,0 +1,32 @@
+package market
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestOpenInterestService_Do(t *testing.T) {
+	query := url.Values{"productType": {"USDT-FUTURES"}, "symbol": {"BTCUSDT"}}
+	for name, data := range map[string]string{
+		"wrapped": `{"openInterestList":[{"symbol":"BTCUSDT","size":"34278.06"}],"ts":"1700000000000"}`,
+		"array":   `[{"symbol":"BTCUSDT","size":"34278.06","timestamp":"1700000000000"}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			mockClient.On("CallAPI", mock.Anything, "GET", EndpointOpenInterest, query, []byte(nil), false).
+				Return(&ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil)
+
+			res, err := NewOpenInterestService(mockClient).Symbol("BTCUSDT").ProductType(ProductTypeUSDTFutures).Do(context.Background())
+			require.NoError(t, err)
+			require.Len(t, res.OpenInterests, 1)
+			assert.Equal(t, "34278.06", res.OpenInterests[0].Size)
+			assert.Equal(t, "1700000000000", res.OpenInterests[0].Timestamp)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package market
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// PositionTierService retrieves the position tiers (leverage brackets) of a contract.
+// Each tier covers a range of position value and sets the maximum leverage and the
+// maintenance margin rate that applies within it.
+type PositionTierService struct {
+	c ClientInterface
+
+	// Required parameters
+	symbol      string
+	productType ProductType
+}
+
+// Symbol sets the trading symbol (e.g., "BTCUSDT"). Required parameter.
+func (s *PositionTierService) Symbol(symbol string) *PositionTierService {
+	s.symbol = symbol
+	return s
+}
+
+// ProductType sets the product type. Required parameter.
+func (s *PositionTierService) ProductType(productType ProductType) *PositionTierService {
+	s.productType = productType
+	return s
+}
+
+// PositionTier is one leverage bracket of a contract.
+type PositionTier struct {
+	Symbol         string  `json:"symbol"`
+	Level          int     `json:"level,string"`
+	StartUnit      float64 `json:"startUnit,string"`      // Lower bound of the position value (inclusive)
+	EndUnit        float64 `json:"endUnit,string"`        // Upper bound of the position value
+	Leverage       float64 `json:"leverage,string"`       // Maximum leverage
+	KeepMarginRate float64 `json:"keepMarginRate,string"` // Maintenance margin rate
+}
+
+// PositionTiers are the brackets of a contract in ascending order.
+type PositionTiers []PositionTier
+
+// ForValue returns the tier that applies to a position value. Values above the last
+// tier use the last tier.
+func (t PositionTiers) ForValue(value float64) (PositionTier, bool) {
+	if len(t) == 0 {
+		return PositionTier{}, false
+	}
+	for _, tier := range t {
+		if value < tier.EndUnit {
+			return tier, true
+		}
+	}
+	return t[len(t)-1], true
+}
+
+// MaintenanceMargin returns the maintenance margin required for a position value.
+func (t PositionTiers) MaintenanceMargin(value float64) float64 {
+	tier, ok := t.ForValue(value)
+	if !ok {
+		return 0
+	}
+	return value * tier.KeepMarginRate
+}
+
+// Do executes the position tier request.
+func (s *PositionTierService) Do(ctx context.Context) (PositionTiers, error) {
+	if s.symbol == "" {
+		return nil, fmt.Errorf("symbol is required")
+	}
+	if s.productType == "" {
+		return nil, fmt.Errorf("productType is required")
+	}
+
+	params := url.Values{}
+	params.Set("symbol", s.symbol)
+	params.Set("productType", string(s.productType))
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointPositionTier, params, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var tiers PositionTiers
+	if err := json.Unmarshal(res.Data, &tiers); err != nil {
+		return nil, err
+	}
+	return tiers, nil
+}
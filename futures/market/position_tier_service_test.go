@@ -0,0 +1,46 @@
+package market
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestPositionTierService_Do(t *testing.T) {
+	mockClient := &MockClient{}
+	query := url.Values{"symbol": {"BTCUSDT"}, "productType": {"USDT-FUTURES"}}
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointPositionTier, query, []byte(nil), false).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`[
+			{"symbol":"BTCUSDT","level":"1","startUnit":"0","endUnit":"150000","leverage":"125","keepMarginRate":"0.004"},
+			{"symbol":"BTCUSDT","level":"2","startUnit":"150000","endUnit":"750000","leverage":"100","keepMarginRate":"0.005"}]`)}, &fasthttp.ResponseHeader{}, nil)
+
+	tiers, err := NewPositionTierService(mockClient).
+		Symbol("BTCUSDT").
+		ProductType(ProductTypeUSDTFutures).
+		Do(context.Background())
+	require.NoError(t, err)
+	require.Len(t, tiers, 2)
+	assert.Equal(t, 2, tiers[1].Level)
+	assert.Equal(t, 0.005, tiers[1].KeepMarginRate)
+
+	tier, ok := tiers.ForValue(200000)
+	require.True(t, ok)
+	assert.Equal(t, 2, tier.Level)
+	tier, _ = tiers.ForValue(1e9)
+	assert.Equal(t, 2, tier.Level, "values above the last tier use the last tier")
+	assert.InDelta(t, 400, tiers.MaintenanceMargin(100000), 1e-9)
+	mockClient.AssertExpectations(t)
+}
+
+func TestPositionTierService_Validation(t *testing.T) {
+	_, err := NewPositionTierService(&MockClient{}).ProductType(ProductTypeUSDTFutures).Do(context.Background())
+	assert.EqualError(t, err, "symbol is required")
+
+	_, ok := PositionTiers(nil).ForValue(100)
+	assert.False(t, ok)
+}
@@ -0,0 +1,146 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/khanbekov/go-bitget/futures"
+)
+
+// PriceLimit is the band of limit prices a symbol currently accepts. Bitget rejects buy
+// orders above MaxBuyPrice and sell orders below MinSellPrice; both move with the mark
+// price.
+type PriceLimit struct {
+	Symbol       string
+	MarkPrice    float64
+	MaxBuyPrice  float64
+	MinSellPrice float64
+	TickSize     float64
+	Timestamp    int64 // Mark price timestamp (ms)
+}
+
+// Allowed reports whether a limit order at price passes the band.
+func (l PriceLimit) Allowed(side string, price float64) bool {
+	if isBuy(side) {
+		return price <= l.MaxBuyPrice
+	}
+	return price >= l.MinSellPrice
+}
+
+// Clamp moves price inside the band for the given side ("buy" or "sell") and leaves
+// prices that already pass unchanged.
+func (l PriceLimit) Clamp(side string, price float64) float64 {
+	if isBuy(side) {
+		return math.Min(price, l.MaxBuyPrice)
+	}
+	return math.Max(price, l.MinSellPrice)
+}
+
+func isBuy(side string) bool {
+	return strings.EqualFold(side, "buy")
+}
+
+// PriceLimitService computes the allowed limit price band for a symbol.
+//
+// Bitget has no endpoint returning the band itself. It is derived from the contract's
+// buyLimitPriceRatio/sellLimitPriceRatio and the current mark price, then rounded to
+// the price tick towards the inside of the band.
+type PriceLimitService struct {
+	c ClientInterface
+
+	// Required parameters
+	symbol      string
+	productType ProductType
+}
+
+// Symbol sets the trading symbol (e.g., "BTCUSDT"). Required parameter.
+func (s *PriceLimitService) Symbol(symbol string) *PriceLimitService {
+	s.symbol = symbol
+	return s
+}
+
+// ProductType sets the product type. Required parameter.
+func (s *PriceLimitService) ProductType(productType ProductType) *PriceLimitService {
+	s.productType = productType
+	return s
+}
+
+// Do fetches the contract configuration and mark price and returns the band.
+func (s *PriceLimitService) Do(ctx context.Context) (*PriceLimit, error) {
+	if s.symbol == "" {
+		return nil, fmt.Errorf("symbol is required")
+	}
+	if s.productType == "" {
+		return nil, fmt.Errorf("productType is required")
+	}
+
+	contracts, err := NewContractsService(s.c).
+		ProductType(futures.ProductType(s.productType)).
+		Symbol(s.symbol).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var contract *Contract
+	for _, c := range contracts {
+		if c != nil && c.Symbol == s.symbol {
+			contract = c
+			break
+		}
+	}
+	if contract == nil {
+		return nil, fmt.Errorf("no contract found for %s", s.symbol)
+	}
+
+	prices, err := NewSymbolPriceService(s.c).Symbol(s.symbol).ProductType(s.productType).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(prices.SymbolPrices) == 0 {
+		return nil, fmt.Errorf("no mark price returned for %s", s.symbol)
+	}
+	mark, err := strconv.ParseFloat(prices.SymbolPrices[0].MarkPrice, 64)
+	if err != nil || mark <= 0 {
+		return nil, fmt.Errorf("invalid mark price %q for %s", prices.SymbolPrices[0].MarkPrice, s.symbol)
+	}
+	ts, _ := strconv.ParseInt(prices.SymbolPrices[0].Timestamp, 10, 64)
+
+	return newPriceLimit(contract, mark, ts)
+}
+
+// newPriceLimit derives the band from the contract ratios and the mark price.
+func newPriceLimit(contract *Contract, mark float64, ts int64) (*PriceLimit, error) {
+	buyRatio, err := strconv.ParseFloat(contract.BuyLimitPriceRatio, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid buyLimitPriceRatio %q: %w", contract.BuyLimitPriceRatio, err)
+	}
+	sellRatio, err := strconv.ParseFloat(contract.SellLimitPriceRatio, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sellLimitPriceRatio %q: %w", contract.SellLimitPriceRatio, err)
+	}
+
+	places, _ := strconv.Atoi(contract.PricePlace)
+	step, _ := strconv.ParseFloat(contract.PriceEndStep, 64)
+	if step <= 0 {
+		step = 1
+	}
+	scale := math.Pow10(places)
+	tick := step / scale
+
+	// Round towards the inside of the band; the epsilon absorbs float noise on exact ticks
+	const eps = 1e-9
+	maxBuy := math.Floor(mark*(1+buyRatio)/tick+eps) * tick
+	minSell := math.Ceil(mark*(1-sellRatio)/tick-eps) * tick
+
+	return &PriceLimit{
+		Symbol:       contract.Symbol,
+		MarkPrice:    mark,
+		MaxBuyPrice:  math.Round(maxBuy*scale) / scale,
+		MinSellPrice: math.Round(minSell*scale) / scale,
+		TickSize:     tick,
+		Timestamp:    ts,
+	}, nil
+}
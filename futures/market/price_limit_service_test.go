@@ -0,0 +1,58 @@
+package market
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/khanbekov/go-bitget/futures"
+)
+
+func TestPriceLimitService_Do(t *testing.T) {
+	mockClient := &MockClient{}
+	query := url.Values{"symbol": {"BTCUSDT"}, "productType": {"USDT-FUTURES"}}
+	mockClient.On("CallAPI", mock.Anything, "GET", futures.EndpointContracts, query, []byte(nil), false).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`[{"symbol":"BTCUSDT","buyLimitPriceRatio":"0.05","sellLimitPriceRatio":"0.05","pricePlace":"1","priceEndStep":"1"}]`)},
+			&fasthttp.ResponseHeader{}, nil)
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointSymbolPrice, query, []byte(nil), false).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`[{"symbol":"BTCUSDT","markPrice":"50000.33","timestamp":"1700000000000"}]`)},
+			&fasthttp.ResponseHeader{}, nil)
+
+	limit, err := NewPriceLimitService(mockClient).
+		Symbol("BTCUSDT").
+		ProductType(ProductTypeUSDTFutures).
+		Do(context.Background())
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+
+	assert.Equal(t, 50000.33, limit.MarkPrice)
+	assert.Equal(t, 52500.3, limit.MaxBuyPrice, "rounded down to the 0.1 tick")
+	assert.Equal(t, 47500.4, limit.MinSellPrice, "rounded up to the 0.1 tick")
+	assert.InDelta(t, 0.1, limit.TickSize, 1e-12)
+	assert.Equal(t, int64(1700000000000), limit.Timestamp)
+
+	assert.Equal(t, 52500.3, limit.Clamp("buy", 60000))
+	assert.Equal(t, 51000.0, limit.Clamp("BUY", 51000))
+	assert.Equal(t, 47500.4, limit.Clamp("sell", 40000))
+	assert.True(t, limit.Allowed("sell", 49000))
+	assert.False(t, limit.Allowed("buy", 52500.4))
+}
+
+func TestPriceLimitService_Errors(t *testing.T) {
+	_, err := NewPriceLimitService(&MockClient{}).ProductType(ProductTypeUSDTFutures).Do(context.Background())
+	assert.EqualError(t, err, "symbol is required")
+
+	mockClient := &MockClient{}
+	mockClient.On("CallAPI", mock.Anything, "GET", futures.EndpointContracts, mock.Anything, []byte(nil), false).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`[]`)}, &fasthttp.ResponseHeader{}, nil)
+	_, err = NewPriceLimitService(mockClient).Symbol("XYZUSDT").ProductType(ProductTypeUSDTFutures).Do(context.Background())
+	assert.EqualError(t, err, "no contract found for XYZUSDT")
+
+	_, err = newPriceLimit(&Contract{Symbol: "BTCUSDT", BuyLimitPriceRatio: "", SellLimitPriceRatio: "0.05"}, 100, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,85 @@
+package market
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// RiskReserveService retrieves the balance history of the insurance fund (risk reserve)
+// that backs a futures contract against losses from liquidations.
+//
+// Bitget only serves this data on the v3 market API, where the product type is sent as
+// the category.
+type RiskReserveService struct {
+	c ClientInterface
+
+	// Required parameters
+	symbol      string
+	productType ProductType
+}
+
+// Symbol sets the trading symbol (e.g., "BTCUSDT"). Required parameter.
+func (s *RiskReserveService) Symbol(symbol string) *RiskReserveService {
+	s.symbol = symbol
+	return s
+}
+
+// ProductType sets the product type. Required parameter.
+func (s *RiskReserveService) ProductType(productType ProductType) *RiskReserveService {
+	s.productType = productType
+	return s
+}
+
+// RiskReserveRecord is a point in the insurance fund balance history.
+type RiskReserveRecord struct {
+	Balance string `json:"balance"` // Fund balance after the change
+	Amount  string `json:"amount"`  // Change of the balance
+	Ts      string `json:"ts"`      // Timestamp (ms)
+}
+
+// RiskReserve is the insurance fund balance history for a symbol's margin coin.
+type RiskReserve struct {
+	Coin    string              `json:"coin"`               // Margin coin of the fund
+	Records []RiskReserveRecord `json:"riskReserveRecords"` // Balance history
+}
+
+// Latest returns the most recent record, or nil when there are none.
+func (r *RiskReserve) Latest() *RiskReserveRecord {
+	var latest *RiskReserveRecord
+	var latestTs int64 = -1
+	for i := range r.Records {
+		ts, err := strconv.ParseInt(r.Records[i].Ts, 10, 64)
+		if err == nil && ts > latestTs {
+			latest, latestTs = &r.Records[i], ts
+		}
+	}
+	return latest
+}
+
+// Do executes the risk reserve request.
+func (s *RiskReserveService) Do(ctx context.Context) (*RiskReserve, error) {
+	if s.symbol == "" {
+		return nil, fmt.Errorf("symbol is required")
+	}
+	if s.productType == "" {
+		return nil, fmt.Errorf("productType is required")
+	}
+
+	params := url.Values{}
+	params.Set("symbol", s.symbol)
+	params.Set("category", string(s.productType))
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointRiskReserve, params, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var reserve RiskReserve
+	if err := json.Unmarshal(res.Data, &reserve); err != nil {
+		return nil, err
+	}
+	return &reserve, nil
+}
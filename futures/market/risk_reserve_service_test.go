@@ -0,0 +1,42 @@
+package market
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestRiskReserveService_Do(t *testing.T) {
+	mockClient := &MockClient{}
+	query := url.Values{"symbol": {"BTCUSDT"}, "category": {"USDT-FUTURES"}}
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointRiskReserve, query, []byte(nil), false).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`{"coin":"USDT","riskReserveRecords":[
+			{"balance":"1000","amount":"10","ts":"1700000000000"},
+			{"balance":"1025","amount":"25","ts":"1700000600000"},
+			{"balance":"990","amount":"-10","ts":"1699999400000"}]}`)}, &fasthttp.ResponseHeader{}, nil)
+
+	reserve, err := NewRiskReserveService(mockClient).
+		Symbol("BTCUSDT").
+		ProductType(ProductTypeUSDTFutures).
+		Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "USDT", reserve.Coin)
+	require.Len(t, reserve.Records, 3)
+	assert.Equal(t, "1025", reserve.Latest().Balance)
+	mockClient.AssertExpectations(t)
+}
+
+func TestRiskReserveService_Validation(t *testing.T) {
+	_, err := NewRiskReserveService(&MockClient{}).ProductType(ProductTypeUSDTFutures).Do(context.Background())
+	assert.EqualError(t, err, "symbol is required")
+
+	_, err = NewRiskReserveService(&MockClient{}).Symbol("BTCUSDT").Do(context.Background())
+	assert.EqualError(t, err, "productType is required")
+
+	assert.Nil(t, (&RiskReserve{}).Latest())
+}
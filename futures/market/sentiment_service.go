@@ -0,0 +1,314 @@
+package market
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// SentimentPeriod is the aggregation period of the long/short ratio and taker volume
+// statistics.
+type SentimentPeriod string
+
+const (
+	SentimentPeriod5m  SentimentPeriod = "5m"
+	SentimentPeriod15m SentimentPeriod = "15m"
+	SentimentPeriod30m SentimentPeriod = "30m"
+	SentimentPeriod1h  SentimentPeriod = "1h"
+	SentimentPeriod2h  SentimentPeriod = "2h"
+	SentimentPeriod4h  SentimentPeriod = "4h"
+	SentimentPeriod6h  SentimentPeriod = "6h"
+	SentimentPeriod12h SentimentPeriod = "12h"
+	SentimentPeriod1d  SentimentPeriod = "1d"
+)
+
+// LongShortRatio is the share of accounts holding long and short positions in a period.
+type LongShortRatio struct {
+	LongAccountRatio  float64 // Fraction of accounts net long
+	ShortAccountRatio float64 // Fraction of accounts net short
+	LongShortRatio    float64 // LongAccountRatio / ShortAccountRatio
+	Ts                int64   // Period time (ms)
+}
+
+// Time returns the period time.
+func (r LongShortRatio) Time() time.Time {
+	return time.UnixMilli(r.Ts)
+}
+
+// UnmarshalJSON decodes the exchange record, where numbers are sent as strings.
+func (r *LongShortRatio) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		LongAccountRatio      json.Number `json:"longAccountRatio"`
+		ShortAccountRatio     json.Number `json:"shortAccountRatio"`
+		LongShortAccountRatio json.Number `json:"longShortAccountRatio"`
+		Ts                    json.Number `json:"ts"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var parsed LongShortRatio
+	var err error
+	if parsed.LongAccountRatio, err = parseNumber(raw.LongAccountRatio); err != nil {
+		return fmt.Errorf("invalid long account ratio: %w", err)
+	}
+	if parsed.ShortAccountRatio, err = parseNumber(raw.ShortAccountRatio); err != nil {
+		return fmt.Errorf("invalid short account ratio: %w", err)
+	}
+	if parsed.LongShortRatio, err = parseNumber(raw.LongShortAccountRatio); err != nil {
+		return fmt.Errorf("invalid long/short ratio: %w", err)
+	}
+	if parsed.Ts, err = parseTs(raw.Ts); err != nil {
+		return fmt.Errorf("invalid long/short ratio ts: %w", err)
+	}
+	*r = parsed
+	return nil
+}
+
+// ElitePositionRatio is the share of long and short position size held by elite (top)
+// traders in a period.
+type ElitePositionRatio struct {
+	LongPositionRatio  float64 // Fraction of elite position size that is long
+	ShortPositionRatio float64 // Fraction of elite position size that is short
+	LongShortRatio     float64 // LongPositionRatio / ShortPositionRatio
+	Ts                 int64   // Period time (ms)
+}
+
+// Time returns the period time.
+func (r ElitePositionRatio) Time() time.Time {
+	return time.UnixMilli(r.Ts)
+}
+
+// UnmarshalJSON decodes the exchange record, where numbers are sent as strings.
+func (r *ElitePositionRatio) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		LongPositionRatio      json.Number `json:"longPositionRatio"`
+		ShortPositionRatio     json.Number `json:"shortPositionRatio"`
+		LongShortPositionRatio json.Number `json:"longShortPositionRatio"`
+		Ts                     json.Number `json:"ts"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var parsed ElitePositionRatio
+	var err error
+	if parsed.LongPositionRatio, err = parseNumber(raw.LongPositionRatio); err != nil {
+		return fmt.Errorf("invalid long position ratio: %w", err)
+	}
+	if parsed.ShortPositionRatio, err = parseNumber(raw.ShortPositionRatio); err != nil {
+		return fmt.Errorf("invalid short position ratio: %w", err)
+	}
+	if parsed.LongShortRatio, err = parseNumber(raw.LongShortPositionRatio); err != nil {
+		return fmt.Errorf("invalid long/short position ratio: %w", err)
+	}
+	if parsed.Ts, err = parseTs(raw.Ts); err != nil {
+		return fmt.Errorf("invalid position ratio ts: %w", err)
+	}
+	*r = parsed
+	return nil
+}
+
+// TakerVolume is the volume bought and sold by takers in a period, in base coin.
+type TakerVolume struct {
+	BuyVolume  float64
+	SellVolume float64
+	Ts         int64 // Period time (ms)
+}
+
+// Time returns the period time.
+func (v TakerVolume) Time() time.Time {
+	return time.UnixMilli(v.Ts)
+}
+
+// Ratio returns BuyVolume / SellVolume, or 0 when nothing was sold.
+func (v TakerVolume) Ratio() float64 {
+	if v.SellVolume == 0 {
+		return 0
+	}
+	return v.BuyVolume / v.SellVolume
+}
+
+// Delta returns BuyVolume - SellVolume.
+func (v TakerVolume) Delta() float64 {
+	return v.BuyVolume - v.SellVolume
+}
+
+// UnmarshalJSON decodes the exchange record, where numbers are sent as strings.
+func (v *TakerVolume) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		BuyVolume  json.Number `json:"buyVolume"`
+		SellVolume json.Number `json:"sellVolume"`
+		Ts         json.Number `json:"ts"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var parsed TakerVolume
+	var err error
+	if parsed.BuyVolume, err = parseNumber(raw.BuyVolume); err != nil {
+		return fmt.Errorf("invalid taker buy volume: %w", err)
+	}
+	if parsed.SellVolume, err = parseNumber(raw.SellVolume); err != nil {
+		return fmt.Errorf("invalid taker sell volume: %w", err)
+	}
+	if parsed.Ts, err = parseTs(raw.Ts); err != nil {
+		return fmt.Errorf("invalid taker volume ts: %w", err)
+	}
+	*v = parsed
+	return nil
+}
+
+func parseTs(n json.Number) (int64, error) {
+	if n == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(string(n), 10, 64)
+}
+
+// LongShortRatioService retrieves the account long/short ratio of a symbol.
+type LongShortRatioService struct {
+	c ClientInterface
+
+	// Required parameters
+	symbol string
+
+	// Optional parameters
+	period SentimentPeriod
+}
+
+// Symbol sets the trading symbol (e.g., "BTCUSDT"). Required parameter.
+func (s *LongShortRatioService) Symbol(symbol string) *LongShortRatioService {
+	s.symbol = symbol
+	return s
+}
+
+// Period sets the aggregation period. Default 5m.
+func (s *LongShortRatioService) Period(period SentimentPeriod) *LongShortRatioService {
+	s.period = period
+	return s
+}
+
+// Do executes the request and returns the ratio series, oldest first.
+func (s *LongShortRatioService) Do(ctx context.Context) ([]LongShortRatio, error) {
+	params, err := sentimentParams(s.symbol, s.period)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointAccountLongShort, params, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var ratios []LongShortRatio
+	if err := json.Unmarshal(res.Data, &ratios); err != nil {
+		return nil, err
+	}
+	sort.Slice(ratios, func(i, j int) bool { return ratios[i].Ts < ratios[j].Ts })
+	return ratios, nil
+}
+
+// ElitePositionRatioService retrieves the long/short position ratio of elite traders
+// for a symbol.
+type ElitePositionRatioService struct {
+	c ClientInterface
+
+	// Required parameters
+	symbol string
+
+	// Optional parameters
+	period SentimentPeriod
+}
+
+// Symbol sets the trading symbol (e.g., "BTCUSDT"). Required parameter.
+func (s *ElitePositionRatioService) Symbol(symbol string) *ElitePositionRatioService {
+	s.symbol = symbol
+	return s
+}
+
+// Period sets the aggregation period. Default 5m.
+func (s *ElitePositionRatioService) Period(period SentimentPeriod) *ElitePositionRatioService {
+	s.period = period
+	return s
+}
+
+// Do executes the request and returns the ratio series, oldest first.
+func (s *ElitePositionRatioService) Do(ctx context.Context) ([]ElitePositionRatio, error) {
+	params, err := sentimentParams(s.symbol, s.period)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointPositionLongShort, params, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var ratios []ElitePositionRatio
+	if err := json.Unmarshal(res.Data, &ratios); err != nil {
+		return nil, err
+	}
+	sort.Slice(ratios, func(i, j int) bool { return ratios[i].Ts < ratios[j].Ts })
+	return ratios, nil
+}
+
+// TakerVolumeService retrieves the taker buy/sell volume of a symbol.
+type TakerVolumeService struct {
+	c ClientInterface
+
+	// Required parameters
+	symbol string
+
+	// Optional parameters
+	period SentimentPeriod
+}
+
+// Symbol sets the trading symbol (e.g., "BTCUSDT"). Required parameter.
+func (s *TakerVolumeService) Symbol(symbol string) *TakerVolumeService {
+	s.symbol = symbol
+	return s
+}
+
+// Period sets the aggregation period. Default 5m.
+func (s *TakerVolumeService) Period(period SentimentPeriod) *TakerVolumeService {
+	s.period = period
+	return s
+}
+
+// Do executes the request and returns the volume series, oldest first.
+func (s *TakerVolumeService) Do(ctx context.Context) ([]TakerVolume, error) {
+	params, err := sentimentParams(s.symbol, s.period)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointTakerBuySell, params, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var volumes []TakerVolume
+	if err := json.Unmarshal(res.Data, &volumes); err != nil {
+		return nil, err
+	}
+	sort.Slice(volumes, func(i, j int) bool { return volumes[i].Ts < volumes[j].Ts })
+	return volumes, nil
+}
+
+func sentimentParams(symbol string, period SentimentPeriod) (url.Values, error) {
+	if symbol == "" {
+		return nil, fmt.Errorf("symbol is required")
+	}
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	if period != "" {
+		params.Set("period", string(period))
+	}
+	return params, nil
+}
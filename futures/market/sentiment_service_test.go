@@ -0,0 +1,65 @@
+package market
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestLongShortRatioService_Do(t *testing.T) {
+	mockClient := &MockClient{}
+	query := url.Values{"symbol": {"BTCUSDT"}, "period": {"1h"}}
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointAccountLongShort, query, []byte(nil), false).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`[
+			{"longAccountRatio":"0.6","shortAccountRatio":"0.4","longShortAccountRatio":"1.5","ts":"1700003600000"},
+			{"longAccountRatio":"0.5","shortAccountRatio":"0.5","longShortAccountRatio":"1","ts":"1700000000000"}]`)}, &fasthttp.ResponseHeader{}, nil)
+
+	ratios, err := NewLongShortRatioService(mockClient).Symbol("BTCUSDT").Period(SentimentPeriod1h).Do(context.Background())
+	require.NoError(t, err)
+	require.Len(t, ratios, 2)
+	assert.Equal(t, LongShortRatio{LongAccountRatio: 0.5, ShortAccountRatio: 0.5, LongShortRatio: 1, Ts: 1700000000000}, ratios[0], "oldest first")
+	assert.Equal(t, 1.5, ratios[1].LongShortRatio)
+	assert.Equal(t, int64(1700003600000), ratios[1].Time().UnixMilli())
+	mockClient.AssertExpectations(t)
+
+	_, err = NewLongShortRatioService(mockClient).Do(context.Background())
+	assert.EqualError(t, err, "symbol is required")
+}
+
+func TestTakerVolumeService_Do(t *testing.T) {
+	mockClient := &MockClient{}
+	query := url.Values{"symbol": {"ETHUSDT"}}
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointTakerBuySell, query, []byte(nil), false).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`[
+			{"buyVolume":"120","sellVolume":"80","ts":"1700000000000"},
+			{"buyVolume":"5","sellVolume":"0","ts":"1700000300000"}]`)}, &fasthttp.ResponseHeader{}, nil)
+
+	volumes, err := NewTakerVolumeService(mockClient).Symbol("ETHUSDT").Do(context.Background())
+	require.NoError(t, err)
+	require.Len(t, volumes, 2)
+	assert.Equal(t, 1.5, volumes[0].Ratio())
+	assert.Equal(t, 40.0, volumes[0].Delta())
+	assert.Zero(t, volumes[1].Ratio())
+	mockClient.AssertExpectations(t)
+
+	var v TakerVolume
+	assert.Error(t, v.UnmarshalJSON([]byte(`{"buyVolume":"x"}`)))
+}
+
+func TestElitePositionRatioService_Do(t *testing.T) {
+	mockClient := &MockClient{}
+	query := url.Values{"symbol": {"BTCUSDT"}, "period": {"4h"}}
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointPositionLongShort, query, []byte(nil), false).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`[
+			{"longPositionRatio":"0.7","shortPositionRatio":"0.3","longShortPositionRatio":"2.33","ts":"1700000000000"}]`)}, &fasthttp.ResponseHeader{}, nil)
+
+	ratios, err := NewElitePositionRatioService(mockClient).Symbol("BTCUSDT").Period(SentimentPeriod4h).Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []ElitePositionRatio{{LongPositionRatio: 0.7, ShortPositionRatio: 0.3, LongShortRatio: 2.33, Ts: 1700000000000}}, ratios)
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,36 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// ServerTimeService retrieves the exchange server time. The endpoint is public and cheap,
+// which also makes it suitable for latency probes and clock sync.
+type ServerTimeService struct {
+	c ClientInterface
+}
+
+// Do executes the request and returns the server time.
+func (s *ServerTimeService) Do(ctx context.Context) (time.Time, error) {
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointServerTime, nil, nil, false)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var data struct {
+		ServerTime string `json:"serverTime"`
+	}
+	if err := jsoniter.Unmarshal(res.Data, &data); err != nil {
+		return time.Time{}, err
+	}
+	ms, err := strconv.ParseInt(data.ServerTime, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid server time %q", data.ServerTime)
+	}
+	return time.UnixMilli(ms), nil
+}
@@ -0,0 +1,27 @@
+package market
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestServerTimeService_Do(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointServerTime, url.Values(nil), []byte(nil), false).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`{"serverTime":"1700000000123"}`)}, &fasthttp.ResponseHeader{}, nil).Once()
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointServerTime, url.Values(nil), []byte(nil), false).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`{"serverTime":""}`)}, &fasthttp.ResponseHeader{}, nil).Once()
+
+	ts, err := NewServerTimeService(mockClient).Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000123), ts.UnixMilli())
+
+	_, err = NewServerTimeService(mockClient).Do(context.Background())
+	assert.EqualError(t, err, `invalid server time ""`)
+}
@@ -28,9 +28,18 @@ const (
 	EndpointContractConfig      = "/api/v2/mix/market/contracts"
 	EndpointRecentTrades        = "/api/v2/mix/market/fills"
 	EndpointCurrentFundingRate  = "/api/v2/mix/market/current-funding-rate"
-	EndpointHistoryFundingRate  = "/api/v2/mix/market/history-funding-rate"
+	EndpointHistoryFundingRate  = "/api/v2/mix/market/history-fund-rate"
 	EndpointOpenInterest        = "/api/v2/mix/market/open-interest"
 	EndpointSymbolPrice         = "/api/v2/mix/market/symbol-price"
+	EndpointRiskReserve         = "/api/v3/market/risk-reserve"
+	EndpointIndexComponents     = "/api/v3/market/index-components"
+	EndpointLiquidationOrders   = "/api/v2/mix/market/liquidation-orders"
+	EndpointAccountLongShort    = "/api/v2/mix/market/account-long-short"
+	EndpointTakerBuySell        = "/api/v2/mix/market/taker-buy-sell"
+	EndpointPositionLongShort   = "/api/v2/mix/market/position-long-short"
+	EndpointPositionTier        = "/api/v2/mix/market/query-position-lever"
+	EndpointServerTime          = "/api/v2/public/time"
+	EndpointAnnouncements       = "/api/v2/public/annoucements" // Sic, as spelled by the API
 )
 
 // Service Constructor Functions
@@ -40,6 +49,21 @@ func NewCandlestickService(client ClientInterface) *CandlestickService {
 	return &CandlestickService{c: client}
 }
 
+// NewHistoryCandlesticksService creates a new history candlesticks service.
+func NewHistoryCandlesticksService(client ClientInterface) *HistoryCandlesticksService {
+	return &HistoryCandlesticksService{c: client}
+}
+
+// NewHistoryIndexCandlesService creates a new history index candles service.
+func NewHistoryIndexCandlesService(client ClientInterface) *HistoryIndexCandlesService {
+	return &HistoryIndexCandlesService{c: client}
+}
+
+// NewHistoryMarkCandlesService creates a new history mark candles service.
+func NewHistoryMarkCandlesService(client ClientInterface) *HistoryMarkCandlesService {
+	return &HistoryMarkCandlesService{c: client}
+}
+
 // NewAllTickersService creates a new all tickers service.
 func NewAllTickersService(client ClientInterface) *AllTickersService {
 	return &AllTickersService{c: client}
@@ -50,6 +74,11 @@ func NewTickerService(client ClientInterface) *TickerService {
 	return &TickerService{c: client}
 }
 
+// NewMultiTickerService creates a new multi-symbol ticker service.
+func NewMultiTickerService(client ClientInterface) *MultiTickerService {
+	return &MultiTickerService{c: client, maxIndividual: 20, concurrency: 5}
+}
+
 // NewOrderBookService creates a new order book service.
 func NewOrderBookService(client ClientInterface) *OrderBookService {
 	return &OrderBookService{c: client}
@@ -83,4 +112,59 @@ func NewSymbolPriceService(client ClientInterface) *SymbolPriceService {
 // NewContractsService creates a new contracts service.
 func NewContractsService(client ClientInterface) *ContractsService {
 	return &ContractsService{c: client}
-}
\ No newline at end of file
+}
+
+// NewLiquidationOrdersService creates a new liquidation orders service.
+func NewLiquidationOrdersService(client ClientInterface) *LiquidationOrdersService {
+	return &LiquidationOrdersService{c: client}
+}
+
+// NewPriceLimitService creates a new price limit service.
+func NewPriceLimitService(client ClientInterface) *PriceLimitService {
+	return &PriceLimitService{c: client}
+}
+
+// NewServerTimeService creates a new server time service.
+func NewServerTimeService(client ClientInterface) *ServerTimeService {
+	return &ServerTimeService{c: client}
+}
+
+// NewAnnouncementsService creates a new announcements service.
+func NewAnnouncementsService(client ClientInterface) *AnnouncementsService {
+	return &AnnouncementsService{c: client}
+}
+
+// NewRiskReserveService creates a new risk reserve service.
+func NewRiskReserveService(client ClientInterface) *RiskReserveService {
+	return &RiskReserveService{c: client}
+}
+
+// NewPositionTierService creates a new position tier service.
+func NewPositionTierService(client ClientInterface) *PositionTierService {
+	return &PositionTierService{c: client}
+}
+
+// NewIndexComponentsService creates a new index components service.
+func NewIndexComponentsService(client ClientInterface) *IndexComponentsService {
+	return &IndexComponentsService{c: client}
+}
+
+// NewPremiumIndexCandlesService creates a new premium index candles service.
+func NewPremiumIndexCandlesService(client ClientInterface) *PremiumIndexCandlesService {
+	return &PremiumIndexCandlesService{c: client}
+}
+
+// NewLongShortRatioService creates a new account long/short ratio service.
+func NewLongShortRatioService(client ClientInterface) *LongShortRatioService {
+	return &LongShortRatioService{c: client}
+}
+
+// NewElitePositionRatioService creates a new elite trader position ratio service.
+func NewElitePositionRatioService(client ClientInterface) *ElitePositionRatioService {
+	return &ElitePositionRatioService{c: client}
+}
+
+// NewTakerVolumeService creates a new taker buy/sell volume service.
+func NewTakerVolumeService(client ClientInterface) *TakerVolumeService {
+	return &TakerVolumeService{c: client}
+}
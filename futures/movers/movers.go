@@ -0,0 +1,328 @@
+// Package movers tracks top movers and volume spikes across all futures symbols.
+//
+// A Tracker is fed ticker snapshots, either from the all-tickers REST endpoint (Poll) or
+// from the WebSocket ticker channel (UpdateWS). For every symbol it keeps a rolling window
+// of 24h quote volume increments and reports a volume z-score, and it scores each symbol's
+// 24h change against the cross-section of all symbols. Events fire when configured
+// thresholds are crossed, with a per-symbol cooldown.
+package movers
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/ws"
+)
+
+// EventType classifies tracker events.
+type EventType string
+
+const (
+	// EventVolumeSpike fires when a symbol's volume increment z-score exceeds VolumeZThreshold.
+	EventVolumeSpike EventType = "volume_spike"
+	// EventPriceMove fires when the absolute 24h change exceeds ChangeThreshold.
+	EventPriceMove EventType = "price_move"
+	// EventOutlier fires when the 24h change z-score across all symbols exceeds ChangeZThreshold.
+	EventOutlier EventType = "outlier"
+)
+
+// Event is a threshold crossing for a symbol.
+type Event struct {
+	Type      EventType
+	Mover     Mover
+	Value     float64 // The metric that crossed
+	Threshold float64 // The configured threshold
+}
+
+// Mover holds the current statistics for a symbol.
+type Mover struct {
+	Symbol      string
+	LastPrice   float64
+	Change24h   float64 // Fraction, as reported by the exchange
+	QuoteVolume float64 // Rolling 24h quote volume
+	VolumeDelta float64 // Quote volume added since the previous sample
+	VolumeZ     float64 // Z-score of VolumeDelta against the symbol's rolling window
+	ChangeZ     float64 // Z-score of Change24h against all tracked symbols
+	UpdatedAt   time.Time
+}
+
+// Config sets the rolling window and event thresholds. Zero thresholds disable the
+// corresponding event.
+type Config struct {
+	// Window is the number of volume increments kept per symbol. Defaults to 30.
+	Window int
+	// MinSamples is the number of increments required before volume z-scores are
+	// reported. Defaults to 10.
+	MinSamples int
+
+	VolumeZThreshold float64
+	ChangeThreshold  float64
+	ChangeZThreshold float64
+
+	// Cooldown suppresses repeated events of the same type for a symbol.
+	Cooldown time.Duration
+}
+
+type symbolState struct {
+	mover      Mover
+	deltas     []float64
+	lastVolume float64
+	hasVolume  bool
+	lastFired  map[EventType]time.Time
+}
+
+// Tracker maintains mover statistics. It is safe for concurrent use.
+type Tracker struct {
+	cfg     Config
+	mu      sync.RWMutex
+	symbols map[string]*symbolState
+	handler func(Event)
+}
+
+// New creates a tracker.
+func New(cfg Config) *Tracker {
+	if cfg.Window <= 0 {
+		cfg.Window = 30
+	}
+	if cfg.MinSamples <= 0 {
+		cfg.MinSamples = 10
+	}
+	if cfg.MinSamples > cfg.Window {
+		cfg.MinSamples = cfg.Window
+	}
+	return &Tracker{cfg: cfg, symbols: make(map[string]*symbolState)}
+}
+
+// OnEvent sets the handler called for every event. Events returned by Update are also
+// passed to the handler.
+func (t *Tracker) OnEvent(handler func(Event)) {
+	t.mu.Lock()
+	t.handler = handler
+	t.mu.Unlock()
+}
+
+// Update ingests a full all-tickers snapshot and returns the events it triggered.
+func (t *Tracker) Update(tickers []*market.Ticker, at time.Time) []Event {
+	t.mu.Lock()
+	for _, tk := range tickers {
+		t.ingest(tk.Symbol, parse(tk.LastPr), parse(tk.Change24h), parse(tk.QuoteVolume), at)
+	}
+	events := t.evaluate(at)
+	handler := t.handler
+	t.mu.Unlock()
+
+	dispatch(handler, events)
+	return events
+}
+
+// UpdateWS ingests a single ticker from the WebSocket ticker channel.
+func (t *Tracker) UpdateWS(tk ws.TickerData, at time.Time) []Event {
+	symbol := tk.InstId
+	if symbol == "" {
+		symbol = tk.Symbol
+	}
+
+	t.mu.Lock()
+	t.ingest(symbol, parse(tk.LastPrice), parse(tk.Change24h), parse(tk.QuoteVolume), at)
+	events := t.evaluate(at, symbol)
+	handler := t.handler
+	t.mu.Unlock()
+
+	dispatch(handler, events)
+	return events
+}
+
+// Poll fetches all tickers every interval and feeds them to the tracker until ctx is
+// cancelled. Request errors are passed to onError when it is non-nil.
+func (t *Tracker) Poll(ctx context.Context, client futures.ClientInterface, productType futures.ProductType, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		tickers, err := market.NewAllTickersService(client).ProductType(productType).Do(ctx)
+		if err != nil {
+			if onError != nil && ctx.Err() == nil {
+				onError(err)
+			}
+		} else {
+			t.Update(tickers, time.Now())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Get returns the statistics for a symbol.
+func (t *Tracker) Get(symbol string) (Mover, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	st, ok := t.symbols[symbol]
+	if !ok {
+		return Mover{}, false
+	}
+	return st.mover, true
+}
+
+// Gainers returns the n symbols with the highest 24h change.
+func (t *Tracker) Gainers(n int) []Mover {
+	return t.top(n, func(a, b Mover) bool { return a.Change24h > b.Change24h })
+}
+
+// Losers returns the n symbols with the lowest 24h change.
+func (t *Tracker) Losers(n int) []Mover {
+	return t.top(n, func(a, b Mover) bool { return a.Change24h < b.Change24h })
+}
+
+// VolumeLeaders returns the n symbols with the highest volume z-score.
+func (t *Tracker) VolumeLeaders(n int) []Mover {
+	return t.top(n, func(a, b Mover) bool { return a.VolumeZ > b.VolumeZ })
+}
+
+func (t *Tracker) top(n int, less func(a, b Mover) bool) []Mover {
+	t.mu.RLock()
+	out := make([]Mover, 0, len(t.symbols))
+	for _, st := range t.symbols {
+		out = append(out, st.mover)
+	}
+	t.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if less(out[i], out[j]) != less(out[j], out[i]) {
+			return less(out[i], out[j])
+		}
+		return out[i].Symbol < out[j].Symbol
+	})
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// ingest updates a symbol's state. Callers must hold the write lock.
+func (t *Tracker) ingest(symbol string, last, change, quoteVolume float64, at time.Time) {
+	if symbol == "" {
+		return
+	}
+	st, ok := t.symbols[symbol]
+	if !ok {
+		st = &symbolState{lastFired: make(map[EventType]time.Time)}
+		t.symbols[symbol] = st
+	}
+
+	m := &st.mover
+	m.Symbol = symbol
+	m.LastPrice = last
+	m.Change24h = change
+	m.QuoteVolume = quoteVolume
+	m.UpdatedAt = at
+	m.VolumeDelta = 0
+	m.VolumeZ = 0
+
+	if st.hasVolume {
+		// The 24h volume is a rolling sum, so the increment can be negative when large
+		// trades drop out of the window; clamp to keep the score about new activity.
+		delta := math.Max(quoteVolume-st.lastVolume, 0)
+		m.VolumeDelta = delta
+		if len(st.deltas) >= t.cfg.MinSamples {
+			m.VolumeZ = zscore(delta, st.deltas)
+		}
+		st.deltas = append(st.deltas, delta)
+		if len(st.deltas) > t.cfg.Window {
+			st.deltas = st.deltas[len(st.deltas)-t.cfg.Window:]
+		}
+	}
+	st.lastVolume = quoteVolume
+	st.hasVolume = true
+}
+
+// evaluate refreshes cross-sectional scores and collects events for the given symbols,
+// or all symbols when none are given. Callers must hold the write lock.
+func (t *Tracker) evaluate(at time.Time, only ...string) []Event {
+	changes := make([]float64, 0, len(t.symbols))
+	for _, st := range t.symbols {
+		changes = append(changes, st.mover.Change24h)
+	}
+	for _, st := range t.symbols {
+		st.mover.ChangeZ = zscore(st.mover.Change24h, changes)
+	}
+
+	symbols := only
+	if len(symbols) == 0 {
+		for s := range t.symbols {
+			symbols = append(symbols, s)
+		}
+		sort.Strings(symbols)
+	}
+
+	var events []Event
+	for _, s := range symbols {
+		st, ok := t.symbols[s]
+		if !ok {
+			continue
+		}
+		m := st.mover
+		if t.cfg.VolumeZThreshold > 0 && m.VolumeZ >= t.cfg.VolumeZThreshold {
+			events = t.fire(events, st, EventVolumeSpike, m.VolumeZ, t.cfg.VolumeZThreshold, at)
+		}
+		if t.cfg.ChangeThreshold > 0 && math.Abs(m.Change24h) >= t.cfg.ChangeThreshold {
+			events = t.fire(events, st, EventPriceMove, m.Change24h, t.cfg.ChangeThreshold, at)
+		}
+		if t.cfg.ChangeZThreshold > 0 && math.Abs(m.ChangeZ) >= t.cfg.ChangeZThreshold {
+			events = t.fire(events, st, EventOutlier, m.ChangeZ, t.cfg.ChangeZThreshold, at)
+		}
+	}
+	return events
+}
+
+func (t *Tracker) fire(events []Event, st *symbolState, typ EventType, value, threshold float64, at time.Time) []Event {
+	if last, ok := st.lastFired[typ]; ok && at.Sub(last) < t.cfg.Cooldown {
+		return events
+	}
+	st.lastFired[typ] = at
+	return append(events, Event{Type: typ, Mover: st.mover, Value: value, Threshold: threshold})
+}
+
+func dispatch(handler func(Event), events []Event) {
+	if handler == nil {
+		return
+	}
+	for _, e := range events {
+		handler(e)
+	}
+}
+
+// zscore returns (x - mean) / stddev of sample, or 0 when the sample has no spread.
+func zscore(x float64, sample []float64) float64 {
+	if len(sample) < 2 {
+		return 0
+	}
+	var sum float64
+	for _, v := range sample {
+		sum += v
+	}
+	mean := sum / float64(len(sample))
+	var sq float64
+	for _, v := range sample {
+		sq += (v - mean) * (v - mean)
+	}
+	std := math.Sqrt(sq / float64(len(sample)))
+	if std == 0 {
+		return 0
+	}
+	return (x - mean) / std
+}
+
+func parse(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
@@ -0,0 +1,82 @@
+package movers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ticker(symbol string, change, volume float64) *market.Ticker {
+	return &market.Ticker{
+		Symbol:      symbol,
+		LastPr:      "100",
+		Change24h:   fmt.Sprint(change),
+		QuoteVolume: fmt.Sprint(volume),
+	}
+}
+
+func TestTracker_VolumeSpike(t *testing.T) {
+	tr := New(Config{Window: 10, MinSamples: 5, VolumeZThreshold: 3, Cooldown: time.Minute})
+
+	var handled []Event
+	tr.OnEvent(func(e Event) { handled = append(handled, e) })
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	volume := 1000.0
+	for i := 0; i < 8; i++ {
+		// Small steady increments with a little noise
+		volume += 10 + float64(i%2)
+		events := tr.Update([]*market.Ticker{ticker("BTCUSDT", 0.01, volume)}, base.Add(time.Duration(i)*time.Second))
+		assert.Empty(t, events)
+	}
+
+	volume += 500
+	events := tr.Update([]*market.Ticker{ticker("BTCUSDT", 0.01, volume)}, base.Add(10*time.Second))
+	require.Len(t, events, 1)
+	assert.Equal(t, EventVolumeSpike, events[0].Type)
+	assert.Equal(t, 500.0, events[0].Mover.VolumeDelta)
+	assert.Greater(t, events[0].Value, 3.0)
+	assert.Equal(t, events, handled)
+
+	// Cooldown suppresses a repeat
+	volume += 5000
+	events = tr.Update([]*market.Ticker{ticker("BTCUSDT", 0.01, volume)}, base.Add(20*time.Second))
+	assert.Empty(t, events)
+}
+
+func TestTracker_PriceMoveAndOutlier(t *testing.T) {
+	tr := New(Config{ChangeThreshold: 0.05, ChangeZThreshold: 2})
+
+	tickers := []*market.Ticker{ticker("SOLUSDT", 0.30, 1)}
+	for i := 0; i < 9; i++ {
+		tickers = append(tickers, ticker(fmt.Sprintf("C%dUSDT", i), 0.01, 1))
+	}
+	events := tr.Update(tickers, time.Now())
+
+	types := map[EventType]string{}
+	for _, e := range events {
+		types[e.Type] = e.Mover.Symbol
+	}
+	assert.Equal(t, map[EventType]string{EventPriceMove: "SOLUSDT", EventOutlier: "SOLUSDT"}, types)
+
+	assert.Equal(t, "SOLUSDT", tr.Gainers(1)[0].Symbol)
+	assert.Equal(t, "C0USDT", tr.Losers(1)[0].Symbol)
+	assert.Len(t, tr.Gainers(0), 10)
+}
+
+func TestTracker_UpdateWS(t *testing.T) {
+	tr := New(Config{ChangeThreshold: 0.1})
+
+	events := tr.UpdateWS(ws.TickerData{InstId: "ETHUSDT", LastPrice: "3000", Change24h: "-0.12", QuoteVolume: "10"}, time.Now())
+	require.Len(t, events, 1)
+	assert.Equal(t, EventPriceMove, events[0].Type)
+
+	m, ok := tr.Get("ETHUSDT")
+	require.True(t, ok)
+	assert.Equal(t, 3000.0, m.LastPrice)
+}
@@ -0,0 +1,361 @@
+// Package openinterest samples open interest across futures symbols and scores its
+// changes.
+//
+// Bitget publishes only the current open interest of a symbol, so a Collector builds the
+// history itself: every Interval it reads the all-tickers endpoint (one request for all
+// symbols), appends each symbol's open interest to a rolling series and scores the
+// relative change since the previous sample against the recent changes. Scores crossing
+// ZThreshold are published as Signals, both to a callback and to a buffered channel:
+//
+//	oi := openinterest.New(client, openinterest.Options{
+//		ProductType: futures.ProductTypeUSDTFutures,
+//		ZThreshold:  3,
+//		Cooldown:    15 * time.Minute,
+//	})
+//	go oi.Run(ctx, func(err error) { log.Println(err) })
+//
+//	for sig := range oi.Signals() {
+//		log.Printf("%s open interest %+.2f%% (z=%.1f)", sig.Symbol, sig.Change*100, sig.Z)
+//	}
+//
+// Series recorded elsewhere, e.g. by a previous run, can be loaded with Seed so scores
+// are available from the first sample.
+package openinterest
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+)
+
+// DefaultBuffer is the default capacity of the signal channel.
+const DefaultBuffer = 256
+
+// Sample is the open interest of a symbol at a point in time, in base coin.
+type Sample struct {
+	Time time.Time
+	Size float64
+}
+
+// Signal is a scored open interest change.
+type Signal struct {
+	Symbol       string
+	Time         time.Time
+	OpenInterest float64
+	Change       float64 // Relative change since the previous sample
+	Z            float64 // Z-score of Change against the symbol's rolling window
+}
+
+// Options configures a Collector.
+type Options struct {
+	ProductType futures.ProductType
+	// Symbols restricts collection to these symbols. Empty collects every symbol.
+	Symbols []string
+	// Interval is the sampling period of Run. Defaults to one minute.
+	Interval time.Duration
+
+	// Window is the number of changes kept per symbol. Defaults to 60.
+	Window int
+	// MinSamples is the number of changes required before a change is scored. Defaults
+	// to 20.
+	MinSamples int
+	// ZThreshold publishes changes whose absolute z-score reaches it. Zero publishes
+	// every scored change.
+	ZThreshold float64
+	// Cooldown suppresses repeated signals for a symbol.
+	Cooldown time.Duration
+
+	// Buffer is the capacity of the channel returned by Signals. Defaults to
+	// DefaultBuffer. When the buffer is full new signals are dropped; see Dropped.
+	Buffer int
+	// Clock stamps samples. Defaults to the system clock.
+	Clock common.Clock
+}
+
+type series struct {
+	samples   []Sample  // Last Window+1 samples, oldest first
+	changes   []float64 // Last Window changes, oldest first
+	lastFired time.Time
+}
+
+// Collector samples open interest and publishes change signals. It is safe for
+// concurrent use.
+type Collector struct {
+	client  futures.ClientInterface
+	opts    Options
+	symbols map[string]bool
+	clock   common.Clock
+
+	mu     sync.Mutex
+	series map[string]*series
+
+	streamMu sync.Mutex
+	ch       chan Signal
+	handler  func(Signal)
+	closed   bool
+	dropped  atomic.Uint64
+}
+
+// New creates a collector.
+func New(client futures.ClientInterface, opts Options) *Collector {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Minute
+	}
+	if opts.Window <= 0 {
+		opts.Window = 60
+	}
+	if opts.MinSamples <= 0 {
+		opts.MinSamples = 20
+	}
+	if opts.MinSamples > opts.Window {
+		opts.MinSamples = opts.Window
+	}
+	if opts.Buffer <= 0 {
+		opts.Buffer = DefaultBuffer
+	}
+	c := &Collector{client: client, opts: opts, clock: common.ClockOrSystem(opts.Clock), series: make(map[string]*series)}
+	if len(opts.Symbols) > 0 {
+		c.symbols = make(map[string]bool, len(opts.Symbols))
+		for _, s := range opts.Symbols {
+			c.symbols[s] = true
+		}
+	}
+	return c
+}
+
+// Run collects a sample every Interval until ctx is cancelled. Request errors are
+// passed to onError when it is non-nil.
+func (c *Collector) Run(ctx context.Context, onError func(error)) {
+	ticker := c.clock.NewTicker(c.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.Collect(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// Collect fetches the current open interest of every tracked symbol, records it and
+// returns the signals it triggered. Tickers whose timestamp is not newer than the last
+// sample of the symbol are ignored.
+func (c *Collector) Collect(ctx context.Context) ([]Signal, error) {
+	tickers, err := market.NewAllTickersService(c.client).ProductType(c.opts.ProductType).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := c.clock.Now()
+	c.mu.Lock()
+	var signals []Signal
+	for _, tk := range tickers {
+		if tk.OpenI == "" || (c.symbols != nil && !c.symbols[tk.Symbol]) {
+			continue
+		}
+		size, err := strconv.ParseFloat(tk.OpenI, 64)
+		if err != nil {
+			continue
+		}
+		at := now
+		if ms, err := strconv.ParseInt(tk.Ts, 10, 64); err == nil && ms > 0 {
+			at = time.UnixMilli(ms)
+		}
+		if sig, ok := c.add(tk.Symbol, Sample{Time: at, Size: size}); ok {
+			signals = append(signals, sig)
+		}
+	}
+	c.mu.Unlock()
+
+	sort.Slice(signals, func(i, j int) bool { return signals[i].Symbol < signals[j].Symbol })
+	c.publish(signals)
+	return signals, nil
+}
+
+// Add records a sample for symbol, e.g. from the WebSocket ticker channel, and returns
+// the signal it triggered, if any. The signal is also published.
+func (c *Collector) Add(symbol string, s Sample) (Signal, bool) {
+	c.mu.Lock()
+	sig, ok := c.add(symbol, s)
+	c.mu.Unlock()
+	if ok {
+		c.publish([]Signal{sig})
+	}
+	return sig, ok
+}
+
+// Seed loads previously recorded samples for symbol without publishing signals.
+// Samples not newer than the stored series are ignored.
+func (c *Collector) Seed(symbol string, samples []Sample) {
+	sorted := make([]Sample, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range sorted {
+		c.record(c.get(symbol), s)
+	}
+}
+
+// Series returns the stored samples of symbol, oldest first.
+func (c *Collector) Series(symbol string) []Sample {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	st, ok := c.series[symbol]
+	if !ok {
+		return nil
+	}
+	out := make([]Sample, len(st.samples))
+	copy(out, st.samples)
+	return out
+}
+
+// Signals returns the buffered signal channel. It is closed by Shutdown. Every call
+// returns the same channel, so signals are shared by all readers.
+func (c *Collector) Signals() <-chan Signal {
+	c.streamMu.Lock()
+	defer c.streamMu.Unlock()
+	if c.ch == nil {
+		c.ch = make(chan Signal, c.opts.Buffer)
+		if c.closed {
+			close(c.ch)
+		}
+	}
+	return c.ch
+}
+
+// OnSignal sets the handler called for every signal, after it is queued on Signals.
+func (c *Collector) OnSignal(handler func(Signal)) {
+	c.streamMu.Lock()
+	c.handler = handler
+	c.streamMu.Unlock()
+}
+
+// Dropped returns the number of signals discarded because the channel buffer was full.
+func (c *Collector) Dropped() uint64 {
+	return c.dropped.Load()
+}
+
+// Shutdown closes the signal channel. It implements lifecycle.Component.
+func (c *Collector) Shutdown(ctx context.Context) error {
+	c.streamMu.Lock()
+	defer c.streamMu.Unlock()
+	if !c.closed {
+		c.closed = true
+		if c.ch != nil {
+			close(c.ch)
+		}
+	}
+	return nil
+}
+
+func (c *Collector) get(symbol string) *series {
+	st, ok := c.series[symbol]
+	if !ok {
+		st = &series{}
+		c.series[symbol] = st
+	}
+	return st
+}
+
+// add records a sample and scores its change. Callers hold c.mu.
+func (c *Collector) add(symbol string, s Sample) (Signal, bool) {
+	st := c.get(symbol)
+	change, z, scored := c.record(st, s)
+	if !scored || math.Abs(z) < c.opts.ZThreshold {
+		return Signal{}, false
+	}
+	if !st.lastFired.IsZero() && s.Time.Sub(st.lastFired) < c.opts.Cooldown {
+		return Signal{}, false
+	}
+	st.lastFired = s.Time
+	return Signal{Symbol: symbol, Time: s.Time, OpenInterest: s.Size, Change: change, Z: z}, true
+}
+
+// record appends a sample to the series and returns its change and z-score. scored is
+// false for stale samples and while the window holds fewer than MinSamples changes.
+func (c *Collector) record(st *series, s Sample) (change, z float64, scored bool) {
+	n := len(st.samples)
+	if n > 0 && !s.Time.After(st.samples[n-1].Time) {
+		return 0, 0, false
+	}
+	if n > 0 && st.samples[n-1].Size > 0 {
+		change = s.Size/st.samples[n-1].Size - 1
+		if len(st.changes) >= c.opts.MinSamples {
+			z, scored = zscore(change, st.changes), true
+		}
+		st.changes = append(st.changes, change)
+		if len(st.changes) > c.opts.Window {
+			st.changes = st.changes[len(st.changes)-c.opts.Window:]
+		}
+	}
+	st.samples = append(st.samples, s)
+	if len(st.samples) > c.opts.Window+1 {
+		st.samples = st.samples[len(st.samples)-c.opts.Window-1:]
+	}
+	return change, z, scored
+}
+
+// publish delivers signals to the channel without blocking, then to the handler.
+func (c *Collector) publish(signals []Signal) {
+	if len(signals) == 0 {
+		return
+	}
+	c.streamMu.Lock()
+	if c.closed {
+		c.streamMu.Unlock()
+		return
+	}
+	if c.ch != nil {
+		for _, s := range signals {
+			select {
+			case c.ch <- s:
+			default:
+				c.dropped.Add(1)
+			}
+		}
+	}
+	handler := c.handler
+	c.streamMu.Unlock()
+
+	if handler != nil {
+		for _, s := range signals {
+			handler(s)
+		}
+	}
+}
+
+// zscore returns (x - mean) / stddev of sample, or 0 when the sample has no spread.
+func zscore(x float64, sample []float64) float64 {
+	if len(sample) < 2 {
+		return 0
+	}
+	var sum float64
+	for _, v := range sample {
+		sum += v
+	}
+	mean := sum / float64(len(sample))
+	var sq float64
+	for _, v := range sample {
+		sq += (v - mean) * (v - mean)
+	}
+	std := math.Sqrt(sq / float64(len(sample)))
+	if std == 0 {
+		return 0
+	}
+	return (x - mean) / std
+}
@@ -0,0 +1,93 @@
+package openinterest
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeClient struct {
+	ts int64
+	oi map[string]string
+}
+
+func (c *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	var items []string
+	for _, symbol := range []string{"BTCUSDT", "ETHUSDT"} {
+		items = append(items, fmt.Sprintf(`{"symbol":%q,"openI":%q,"ts":"%d"}`, symbol, c.oi[symbol], c.ts))
+	}
+	return &futures.ApiResponse{Code: "00000", Data: []byte("[" + strings.Join(items, ",") + "]")}, &fasthttp.ResponseHeader{}, nil
+}
+
+func TestCollector_Collect(t *testing.T) {
+	client := &fakeClient{ts: 1700000000000, oi: map[string]string{"BTCUSDT": "1000", "ETHUSDT": "500"}}
+	c := New(client, Options{Symbols: []string{"BTCUSDT"}, Window: 5, MinSamples: 3, ZThreshold: 2})
+	ctx := context.Background()
+
+	// Small alternating changes, then a jump
+	for i, size := range []string{"1000", "1010", "1000", "1010", "1000", "1300"} {
+		client.ts = 1700000000000 + int64(i)*60000
+		client.oi["BTCUSDT"] = size
+		signals, err := c.Collect(ctx)
+		require.NoError(t, err)
+		if i < 5 {
+			assert.Empty(t, signals, "sample %d", i)
+		} else {
+			require.Len(t, signals, 1)
+			assert.Equal(t, "BTCUSDT", signals[0].Symbol)
+			assert.Equal(t, 1300.0, signals[0].OpenInterest)
+			assert.InDelta(t, 0.3, signals[0].Change, 1e-9)
+			assert.Greater(t, signals[0].Z, 2.0)
+			assert.Equal(t, time.UnixMilli(client.ts), signals[0].Time)
+		}
+	}
+
+	_, err := c.Collect(ctx)
+	require.NoError(t, err)
+	assert.Len(t, c.Series("BTCUSDT"), 6, "repeated snapshots are ignored; window + 1 samples kept")
+	assert.Nil(t, c.Series("ETHUSDT"), "not tracked")
+}
+
+func TestCollector_SeedAndCooldown(t *testing.T) {
+	c := New(nil, Options{Window: 10, MinSamples: 4, ZThreshold: 2, Cooldown: 5 * time.Minute})
+	start := time.UnixMilli(1700000000000)
+	var seed []Sample
+	for i := 0; i < 6; i++ {
+		seed = append(seed, Sample{Time: start.Add(time.Duration(i) * time.Minute), Size: 100 + float64(i%2)})
+	}
+	var handled []Signal
+	c.OnSignal(func(s Signal) { handled = append(handled, s) })
+	c.Seed("BTCUSDT", seed)
+	assert.Empty(t, handled, "seeding does not publish")
+
+	sig, ok := c.Add("BTCUSDT", Sample{Time: start.Add(6 * time.Minute), Size: 80})
+	require.True(t, ok)
+	assert.Less(t, sig.Z, -2.0, "falling open interest scores negative")
+	_, ok = c.Add("BTCUSDT", Sample{Time: start.Add(7 * time.Minute), Size: 120})
+	assert.False(t, ok, "cooldown")
+	assert.Equal(t, []Signal{sig}, handled)
+}
+
+func TestCollector_SignalsChannel(t *testing.T) {
+	c := New(nil, Options{Window: 2, MinSamples: 1, Buffer: 1})
+	signals := c.Signals()
+	start := time.UnixMilli(1700000000000)
+	for i, size := range []float64{100, 110, 100, 130} {
+		c.Add("BTCUSDT", Sample{Time: start.Add(time.Duration(i) * time.Minute), Size: size})
+	}
+	sig := <-signals
+	assert.InDelta(t, 100.0/110-1, sig.Change, 1e-9)
+	assert.Equal(t, uint64(1), c.Dropped())
+
+	require.NoError(t, c.Shutdown(context.Background()))
+	_, open := <-signals
+	assert.False(t, open)
+}
@@ -0,0 +1,378 @@
+// Package pairs trades two offsetting futures positions as one unit.
+//
+// A Pair opens a long leg and a short leg sized at a target notional ratio, measures the
+// slippage of each leg's fill against the quote it was sized from, and watches the
+// combined unrealized PnL from a tracker.PositionTracker. Both legs are unwound together
+// when a stop triggers or one leg disappears, and the first leg is unwound when the
+// second cannot be opened, so the account is never left holding one side by accident.
+//
+//	p := pairs.New(client, positions, pairs.Options{
+//		ProductType: futures.ProductTypeUSDTFutures,
+//		MarginCoin:  "USDT",
+//		MarginMode:  trading.MarginModeCrossed,
+//		Long:        pairs.Leg{Symbol: "ETHUSDT", SizeStep: 0.01},
+//		Short:       pairs.Leg{Symbol: "BTCUSDT", SizeStep: 0.001},
+//		Ratio:       1,
+//		StopLoss:    50,
+//		TakeProfit:  120,
+//	})
+//	if err := p.Open(ctx, 5000); err != nil { ... }
+//	go p.Run(ctx, 5*time.Second, func(err error) { log.Println(err) })
+package pairs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/config"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/khanbekov/go-bitget/futures/trading"
+)
+
+// ErrAlreadyOpen is returned by Open when the pair holds positions.
+var ErrAlreadyOpen = errors.New("pair is already open")
+
+// Leg is one side of the pair.
+type Leg struct {
+	Symbol string
+	// SizeStep is the contract size step; order sizes are rounded down to it. Required.
+	SizeStep float64
+}
+
+// Options configures a Pair.
+type Options struct {
+	ProductType futures.ProductType
+	MarginCoin  string
+	MarginMode  trading.MarginMode
+	// Hedge is set when the account uses hedge position mode.
+	Hedge bool
+
+	Long  Leg
+	Short Leg
+	// Ratio is the short leg notional per unit of long leg notional. Defaults to 1.
+	Ratio float64
+
+	// StopLoss unwinds the pair when the combined unrealized PnL falls to -StopLoss (in
+	// the margin coin). Zero disables it.
+	StopLoss float64
+	// TakeProfit unwinds the pair when the combined unrealized PnL reaches TakeProfit.
+	// Zero disables it.
+	TakeProfit float64
+	// MaxSlippage unwinds the pair right after opening when either leg filled worse than
+	// its quote by more than this fraction, e.g. 0.002. Zero disables it.
+	MaxSlippage float64
+
+	// Limits apply MaxPositionNotional to each leg before opening. Optional.
+	Limits config.RiskLimits
+
+	// OnUnwind is called after the pair was unwound by a trigger. Optional.
+	OnUnwind func(reason string, status Status)
+	// Clock paces Run. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// LegState is the state of an open leg.
+type LegState struct {
+	Symbol    string
+	HoldSide  string
+	Size      string
+	Quote     float64 // Price the leg was sized from: ask for the long leg, bid for the short leg
+	FillPrice float64
+	// Slippage is the adverse deviation of FillPrice from Quote as a fraction; negative
+	// values are price improvement.
+	Slippage     float64
+	UnrealizedPL float64
+	Open         bool // Still reported by the position tracker
+	// Seen is set once the position tracker has reported the leg. Until then a missing
+	// position means the push has not arrived yet, not that the leg was closed.
+	Seen bool
+}
+
+// Status is a snapshot of the pair.
+type Status struct {
+	Long     LegState
+	Short    LegState
+	Combined float64 // Sum of the legs' unrealized PnL
+	Open     bool
+}
+
+// Pair trades a long and a short leg together. It is safe for concurrent use.
+type Pair struct {
+	client    futures.ClientInterface
+	positions *tracker.PositionTracker
+	opts      Options
+	clock     common.Clock
+
+	mu    sync.Mutex
+	long  *LegState
+	short *LegState
+}
+
+// New creates a pair. positions must be kept up to date from the positions channel.
+func New(client futures.ClientInterface, positions *tracker.PositionTracker, opts Options) *Pair {
+	if opts.Ratio <= 0 {
+		opts.Ratio = 1
+	}
+	return &Pair{client: client, positions: positions, opts: opts, clock: common.ClockOrSystem(opts.Clock)}
+}
+
+// Sizes returns the leg sizes for a long notional at the given prices, rounded down to
+// the size steps.
+func (o Options) Sizes(notional, longPrice, shortPrice float64) (longSize, shortSize float64, err error) {
+	if longPrice <= 0 || shortPrice <= 0 {
+		return 0, 0, fmt.Errorf("pairs: invalid prices %v/%v", longPrice, shortPrice)
+	}
+	if o.Long.SizeStep <= 0 || o.Short.SizeStep <= 0 {
+		return 0, 0, fmt.Errorf("pairs: size steps are required")
+	}
+	ratio := o.Ratio
+	if ratio <= 0 {
+		ratio = 1
+	}
+	longSize = common.RoundDownToStep(notional/longPrice, o.Long.SizeStep)
+	shortSize = common.RoundDownToStep(notional*ratio/shortPrice, o.Short.SizeStep)
+	if longSize <= 0 || shortSize <= 0 {
+		return 0, 0, fmt.Errorf("pairs: notional %v is below the minimum size of a leg", notional)
+	}
+	if max := o.Limits.MaxPositionNotional; max > 0 {
+		if v := longSize * longPrice; v > max {
+			return 0, 0, fmt.Errorf("pairs: %s notional %.2f exceeds the limit of %.2f", o.Long.Symbol, v, max)
+		}
+		if v := shortSize * shortPrice; v > max {
+			return 0, 0, fmt.Errorf("pairs: %s notional %.2f exceeds the limit of %.2f", o.Short.Symbol, v, max)
+		}
+	}
+	return longSize, shortSize, nil
+}
+
+// Open opens both legs with market orders, sizing the long leg at notional (in the
+// margin coin) and the short leg at notional*Ratio. When the short leg fails the long
+// leg is closed again and the error is returned.
+func (p *Pair) Open(ctx context.Context, notional float64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.long != nil || p.short != nil {
+		return ErrAlreadyOpen
+	}
+
+	longQuote, err := p.quote(ctx, p.opts.Long.Symbol, trading.SideBuy)
+	if err != nil {
+		return err
+	}
+	shortQuote, err := p.quote(ctx, p.opts.Short.Symbol, trading.SideSell)
+	if err != nil {
+		return err
+	}
+	longSize, shortSize, err := p.opts.Sizes(notional, longQuote, shortQuote)
+	if err != nil {
+		return err
+	}
+
+	long, err := p.openLeg(ctx, p.opts.Long, trading.SideBuy, longSize, longQuote)
+	if err != nil {
+		return fmt.Errorf("pairs: open %s: %w", p.opts.Long.Symbol, err)
+	}
+	short, err := p.openLeg(ctx, p.opts.Short, trading.SideSell, shortSize, shortQuote)
+	if err != nil {
+		if closeErr := p.closeLeg(ctx, long); closeErr != nil {
+			return fmt.Errorf("pairs: open %s: %w; unwinding %s also failed: %v", p.opts.Short.Symbol, err, long.Symbol, closeErr)
+		}
+		return fmt.Errorf("pairs: open %s: %w (%s unwound)", p.opts.Short.Symbol, err, long.Symbol)
+	}
+	p.long, p.short = long, short
+
+	if max := p.opts.MaxSlippage; max > 0 && (long.Slippage > max || short.Slippage > max) {
+		status := p.statusLocked()
+		if err := p.closeLocked(ctx); err != nil {
+			return fmt.Errorf("pairs: slippage above %v; unwind failed: %w", max, err)
+		}
+		p.unwound("slippage", status)
+	}
+	return nil
+}
+
+// Status returns the current legs and combined PnL from the position tracker.
+func (p *Pair) Status() Status {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.statusLocked()
+}
+
+func (p *Pair) statusLocked() Status {
+	var s Status
+	if p.long == nil || p.short == nil {
+		return s
+	}
+	s.Open = true
+	s.Long, s.Short = p.legStatus(p.long), p.legStatus(p.short)
+	s.Combined = s.Long.UnrealizedPL + s.Short.UnrealizedPL
+	return s
+}
+
+func (p *Pair) legStatus(state *LegState) LegState {
+	leg := *state
+	if pos, ok := p.positions.Get(leg.Symbol, leg.HoldSide); ok && pos.Size > 0 {
+		leg.Open = true
+		leg.UnrealizedPL = pos.UnrealizedPL
+		state.Seen, leg.Seen = true, true
+	}
+	return leg
+}
+
+// closed reports whether the tracker saw the leg open and no longer reports it.
+func (l LegState) closed() bool {
+	return l.Seen && !l.Open
+}
+
+// Check evaluates the stop rules and unwinds both legs when one triggers. It returns the
+// trigger ("stop_loss", "take_profit" or "leg_closed"), or "" when the pair stays open.
+// A leg counts as closed only after the position tracker has reported it at least once.
+func (p *Pair) Check(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s := p.statusLocked()
+	if !s.Open {
+		return "", nil
+	}
+
+	var reason string
+	switch {
+	case s.Long.closed() || s.Short.closed():
+		reason = "leg_closed"
+	case p.opts.StopLoss > 0 && s.Combined <= -p.opts.StopLoss:
+		reason = "stop_loss"
+	case p.opts.TakeProfit > 0 && s.Combined >= p.opts.TakeProfit:
+		reason = "take_profit"
+	default:
+		return "", nil
+	}
+	if err := p.closeLocked(ctx); err != nil {
+		return reason, err
+	}
+	p.unwound(reason, s)
+	return reason, nil
+}
+
+// Run calls Check every interval until ctx is cancelled or the pair is closed. Unwind
+// errors are passed to onError when it is non-nil; the legs stay tracked and the unwind
+// is retried on the next interval.
+func (p *Pair) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := p.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Check(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+		if !p.Status().Open {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// Close unwinds both legs with reduce-only market orders. Legs that closed are
+// forgotten even when the other one fails, so Close can be retried.
+func (p *Pair) Close(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closeLocked(ctx)
+}
+
+func (p *Pair) closeLocked(ctx context.Context) error {
+	var errs []error
+	for _, leg := range []**LegState{&p.long, &p.short} {
+		if *leg == nil {
+			continue
+		}
+		if err := p.closeLeg(ctx, *leg); err != nil {
+			errs = append(errs, fmt.Errorf("pairs: close %s: %w", (*leg).Symbol, err))
+			continue
+		}
+		*leg = nil
+	}
+	return errors.Join(errs...)
+}
+
+func (p *Pair) unwound(reason string, status Status) {
+	if p.opts.OnUnwind != nil {
+		p.opts.OnUnwind(reason, status)
+	}
+}
+
+// quote returns the best ask for buys and the best bid for sells, falling back to the
+// last price.
+func (p *Pair) quote(ctx context.Context, symbol string, side trading.Side) (float64, error) {
+	t, err := market.NewTickerService(p.client).Symbol(symbol).ProductType(string(p.opts.ProductType)).Do(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("pairs: ticker %s: %w", symbol, err)
+	}
+	price := t.BidPr
+	if side == trading.SideBuy {
+		price = t.AskPr
+	}
+	if v, err := strconv.ParseFloat(price, 64); err == nil && v > 0 {
+		return v, nil
+	}
+	return strconv.ParseFloat(t.LastPr, 64)
+}
+
+func (p *Pair) openLeg(ctx context.Context, leg Leg, side trading.Side, size, quote float64) (*LegState, error) {
+	holdSide := string(trading.HoldSideLong)
+	if side == trading.SideSell {
+		holdSide = string(trading.HoldSideShort)
+	}
+	state := &LegState{Symbol: leg.Symbol, HoldSide: holdSide, Size: common.FormatToStep(size, leg.SizeStep), Quote: quote}
+
+	order := p.order(leg.Symbol, side, state.Size)
+	if p.opts.Hedge {
+		order.PositionSideType(trading.PositionSideOpen)
+	}
+	info, err := order.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	state.FillPrice = quote
+	if detail, err := trading.NewGetOrderDetailsService(p.client).
+		Symbol(leg.Symbol).
+		ProductType(trading.ProductType(p.opts.ProductType)).
+		OrderId(info.OrderId).
+		Do(ctx); err == nil {
+		if avg, err := strconv.ParseFloat(detail.PriceAvg, 64); err == nil && avg > 0 {
+			state.FillPrice = avg
+		}
+	}
+	state.Slippage = (state.FillPrice - quote) / quote
+	if side == trading.SideSell {
+		state.Slippage = -state.Slippage
+	}
+	return state, nil
+}
+
+func (p *Pair) closeLeg(ctx context.Context, leg *LegState) error {
+	_, err := p.order(leg.Symbol, "", leg.Size).Close(trading.HoldSide(leg.HoldSide), p.opts.Hedge).Do(ctx)
+	return err
+}
+
+func (p *Pair) order(symbol string, side trading.Side, size string) *trading.CreateOrderService {
+	return trading.NewCreateOrderService(p.client).
+		Symbol(symbol).
+		ProductType(trading.ProductType(p.opts.ProductType)).
+		MarginCoin(p.opts.MarginCoin).
+		MarginMode(p.opts.MarginMode).
+		SideType(side).
+		OrderType(trading.OrderTypeMarket).
+		Size(size)
+}
@@ -0,0 +1,178 @@
+package pairs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/config"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// fakeClient quotes ETHUSDT at 2000/2001 and BTCUSDT at 50000/50010 and fills market
+// orders at fills[symbol].
+type fakeClient struct {
+	mu     sync.Mutex
+	fills  map[string]string
+	fail   map[string]bool
+	orders []map[string]string
+}
+
+func (c *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ok := func(data string) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+		return &futures.ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil
+	}
+	switch endpoint {
+	case futures.EndpointTicker:
+		if query.Get("symbol") == "ETHUSDT" {
+			return ok(`[{"symbol":"ETHUSDT","lastPr":"2000.5","bidPr":"2000","askPr":"2001"}]`)
+		}
+		return ok(`[{"symbol":"BTCUSDT","lastPr":"50005","bidPr":"50000","askPr":"50010"}]`)
+	case trading.EndpointPlaceOrder:
+		var req map[string]string
+		_ = json.Unmarshal(body, &req)
+		if c.fail[req["symbol"]] {
+			return nil, nil, errors.New("insufficient margin")
+		}
+		c.orders = append(c.orders, req)
+		return ok(`{"orderId":"` + req["symbol"] + `"}`)
+	case trading.EndpointOrderDetails:
+		return ok(`{"orderId":"` + query.Get("orderId") + `","priceAvg":"` + c.fills[query.Get("orderId")] + `"}`)
+	}
+	return nil, nil, errors.New("unexpected endpoint " + endpoint)
+}
+
+func testOptions() Options {
+	return Options{
+		ProductType: futures.ProductTypeUSDTFutures,
+		MarginCoin:  "USDT",
+		MarginMode:  trading.MarginModeCrossed,
+		Long:        Leg{Symbol: "ETHUSDT", SizeStep: 0.01},
+		Short:       Leg{Symbol: "BTCUSDT", SizeStep: 0.001},
+		StopLoss:    50,
+		TakeProfit:  100,
+	}
+}
+
+func TestOptions_Sizes(t *testing.T) {
+	o := testOptions()
+	o.Ratio = 0.5
+	long, short, err := o.Sizes(10000, 2000, 50000)
+	require.NoError(t, err)
+	assert.InDelta(t, 5, long, 1e-9)
+	assert.InDelta(t, 0.1, short, 1e-9)
+
+	_, _, err = o.Sizes(10, 2000, 50000)
+	assert.Error(t, err, "below the minimum size")
+
+	o.Limits = config.RiskLimits{MaxPositionNotional: 8000}
+	_, _, err = o.Sizes(10000, 2000, 50000)
+	assert.ErrorContains(t, err, "exceeds the limit")
+}
+
+func TestPair_OpenAndStopLoss(t *testing.T) {
+	client := &fakeClient{fills: map[string]string{"ETHUSDT": "2002", "BTCUSDT": "49990"}}
+	positions := tracker.NewPositionTracker(tracker.Options{})
+	var unwound string
+	opts := testOptions()
+	opts.OnUnwind = func(reason string, s Status) { unwound = reason }
+	p := New(client, positions, opts)
+	ctx := context.Background()
+
+	require.NoError(t, p.Open(ctx, 5000))
+	assert.ErrorIs(t, p.Open(ctx, 5000), ErrAlreadyOpen)
+	require.Len(t, client.orders, 2)
+	assert.Equal(t, "buy", client.orders[0]["side"])
+	assert.Equal(t, "2.49", client.orders[0]["size"])
+	assert.Equal(t, "sell", client.orders[1]["side"])
+	assert.Equal(t, "0.100", client.orders[1]["size"])
+
+	positions.Update(
+		tracker.Position{Symbol: "ETHUSDT", HoldSide: "long", Size: 2.49, UnrealizedPL: -40},
+		tracker.Position{Symbol: "BTCUSDT", HoldSide: "short", Size: 0.1, UnrealizedPL: 5},
+	)
+	s := p.Status()
+	assert.InDelta(t, -35, s.Combined, 1e-9)
+	assert.InDelta(t, 1.0/2001, s.Long.Slippage, 1e-9)
+	assert.InDelta(t, 10.0/50000, s.Short.Slippage, 1e-9)
+
+	reason, err := p.Check(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, reason)
+
+	positions.Update(tracker.Position{Symbol: "ETHUSDT", HoldSide: "long", Size: 2.49, UnrealizedPL: -60})
+	reason, err = p.Check(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "stop_loss", reason)
+	assert.Equal(t, "stop_loss", unwound)
+	assert.False(t, p.Status().Open)
+
+	require.Len(t, client.orders, 4)
+	assert.Equal(t, "sell", client.orders[2]["side"])
+	assert.Equal(t, "YES", client.orders[2]["reduceOnly"])
+	assert.Equal(t, "buy", client.orders[3]["side"])
+}
+
+func TestPair_LegClosedUnwindsOther(t *testing.T) {
+	client := &fakeClient{}
+	positions := tracker.NewPositionTracker(tracker.Options{})
+	opts := testOptions()
+	opts.Hedge = true
+	p := New(client, positions, opts)
+	ctx := context.Background()
+	require.NoError(t, p.Open(ctx, 5000))
+
+	// Only the long leg is reported so far: the short leg's push has not arrived yet
+	positions.Update(tracker.Position{Symbol: "ETHUSDT", HoldSide: "long", Size: 2.49})
+	reason, err := p.Check(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, reason, "a leg that was never reported is not closed")
+
+	// The short leg shows up and then disappears: it was closed or liquidated
+	positions.Update(tracker.Position{Symbol: "BTCUSDT", HoldSide: "short", Size: 0.1})
+	reason, err = p.Check(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, reason)
+	positions.Update(tracker.Position{Symbol: "BTCUSDT", HoldSide: "short", Size: 0})
+	reason, err = p.Check(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "leg_closed", reason)
+	require.Len(t, client.orders, 4)
+	assert.Equal(t, "close", client.orders[2]["tradeSide"])
+	assert.Equal(t, "buy", client.orders[2]["side"], "hedge mode closes a long with buy/close")
+}
+
+func TestPair_SecondLegFailureUnwindsFirst(t *testing.T) {
+	client := &fakeClient{fail: map[string]bool{"BTCUSDT": true}}
+	p := New(client, tracker.NewPositionTracker(tracker.Options{}), testOptions())
+
+	err := p.Open(context.Background(), 5000)
+	assert.ErrorContains(t, err, "ETHUSDT unwound")
+	require.Len(t, client.orders, 2)
+	assert.Equal(t, "YES", client.orders[1]["reduceOnly"])
+	assert.False(t, p.Status().Open)
+}
+
+func TestPair_SlippageUnwinds(t *testing.T) {
+	client := &fakeClient{fills: map[string]string{"ETHUSDT": "2021", "BTCUSDT": "50000"}}
+	opts := testOptions()
+	opts.MaxSlippage = 0.005
+	var unwound string
+	opts.OnUnwind = func(reason string, s Status) { unwound = reason }
+	p := New(client, tracker.NewPositionTracker(tracker.Options{}), opts)
+
+	require.NoError(t, p.Open(context.Background(), 5000))
+	assert.Equal(t, "slippage", unwound)
+	assert.Len(t, client.orders, 4)
+	assert.False(t, p.Status().Open)
+}
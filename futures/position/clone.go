@@ -0,0 +1,25 @@
+package position
+
+// Clone returns an independent copy of s and its parameters.
+func (s *AllPositionsService) Clone() *AllPositionsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *ClosePositionService) Clone() *ClosePositionService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *HistoryPositionsService) Clone() *HistoryPositionsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *SinglePositionService) Clone() *SinglePositionService {
+	c := *s
+	return &c
+}
@@ -0,0 +1,124 @@
+package position
+
+import (
+	"math"
+	"sort"
+
+	"github.com/khanbekov/go-bitget/futures"
+)
+
+// Exposure combines the long and short positions of one symbol.
+type Exposure struct {
+	Symbol     string
+	MarginCoin string
+	MarkPrice  float64
+
+	LongSize       float64
+	ShortSize      float64
+	LongOpenPrice  float64 // Average open price of the long side, zero when flat
+	ShortOpenPrice float64
+
+	// NetSize is LongSize - ShortSize; GrossSize is their sum.
+	NetSize   float64
+	GrossSize float64
+	// NetNotional and GrossNotional value the sizes at the mark price.
+	NetNotional   float64
+	GrossNotional float64
+
+	// HedgedSize is the size held on both sides, which carries no price risk.
+	HedgedSize float64
+	// Basis is ShortOpenPrice - LongOpenPrice: the spread locked in by the hedged size.
+	// Positive values lock in a profit. Zero unless both sides are open.
+	Basis float64
+	// LockedPnL is Basis * HedgedSize.
+	LockedPnL float64
+
+	UnrealizedPL float64
+}
+
+// ExposureReport is the exposure of every symbol plus account totals in the margin coin.
+type ExposureReport struct {
+	Symbols       []Exposure // Sorted by gross notional, largest first
+	NetNotional   float64    // Sum of the signed net notionals
+	GrossNotional float64
+	LockedPnL     float64
+	UnrealizedPL  float64
+}
+
+// NetExposure nets hedge-mode positions per symbol. One-way positions are counted on
+// the side they hold, so the report covers mixed accounts as well:
+//
+//	positions, err := position.NewAllPositionsService(client).ProductType(futures.ProductTypeUSDTFutures).Do(ctx)
+//	report := position.NetExposure(positions)
+//	for _, e := range report.Symbols {
+//		fmt.Printf("%s net=%v gross=%v basis=%v\n", e.Symbol, e.NetSize, e.GrossSize, e.Basis)
+//	}
+func NetExposure(positions []*Position) ExposureReport {
+	bySymbol := make(map[string]*Exposure)
+	var order []string
+	for _, p := range positions {
+		if p == nil || p.Total <= 0 {
+			continue
+		}
+		key := p.Symbol + "/" + p.MarginCoin
+		e, ok := bySymbol[key]
+		if !ok {
+			e = &Exposure{Symbol: p.Symbol, MarginCoin: p.MarginCoin}
+			bySymbol[key] = e
+			order = append(order, key)
+		}
+		if p.MarkPrice > 0 {
+			e.MarkPrice = p.MarkPrice
+		}
+		e.UnrealizedPL += p.UnrealizedPL
+		// Several entries for one side (e.g. isolated and crossed) are averaged by size
+		if p.HoldSide == futures.HoldSideShort {
+			e.ShortOpenPrice = weighted(e.ShortOpenPrice, e.ShortSize, p.AverageOpenPrice, p.Total)
+			e.ShortSize += p.Total
+		} else {
+			e.LongOpenPrice = weighted(e.LongOpenPrice, e.LongSize, p.AverageOpenPrice, p.Total)
+			e.LongSize += p.Total
+		}
+	}
+
+	var report ExposureReport
+	for _, key := range order {
+		e := bySymbol[key]
+		e.NetSize = e.LongSize - e.ShortSize
+		e.GrossSize = e.LongSize + e.ShortSize
+		e.NetNotional = e.NetSize * e.MarkPrice
+		e.GrossNotional = e.GrossSize * e.MarkPrice
+		e.HedgedSize = math.Min(e.LongSize, e.ShortSize)
+		if e.HedgedSize > 0 {
+			e.Basis = e.ShortOpenPrice - e.LongOpenPrice
+			e.LockedPnL = e.Basis * e.HedgedSize
+		}
+
+		report.Symbols = append(report.Symbols, *e)
+		report.NetNotional += e.NetNotional
+		report.GrossNotional += e.GrossNotional
+		report.LockedPnL += e.LockedPnL
+		report.UnrealizedPL += e.UnrealizedPL
+	}
+	sort.SliceStable(report.Symbols, func(i, j int) bool {
+		return report.Symbols[i].GrossNotional > report.Symbols[j].GrossNotional
+	})
+	return report
+}
+
+// Get returns the exposure of symbol.
+func (r ExposureReport) Get(symbol string) (Exposure, bool) {
+	for _, e := range r.Symbols {
+		if e.Symbol == symbol {
+			return e, true
+		}
+	}
+	return Exposure{}, false
+}
+
+func weighted(price, size, addPrice, addSize float64) float64 {
+	if size+addSize <= 0 {
+		return 0
+	}
+	return (price*size + addPrice*addSize) / (size + addSize)
+}
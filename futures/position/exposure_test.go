@@ -0,0 +1,55 @@
+package position
+
+import (
+	"testing"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetExposure(t *testing.T) {
+	positions := []*Position{
+		{Symbol: "BTCUSDT", MarginCoin: "USDT", HoldSide: futures.HoldSideLong, Total: 0.3, AverageOpenPrice: 60000, MarkPrice: 61000, UnrealizedPL: 300},
+		{Symbol: "BTCUSDT", MarginCoin: "USDT", HoldSide: futures.HoldSideShort, Total: 0.1, AverageOpenPrice: 62000, MarkPrice: 61000, UnrealizedPL: 100},
+		{Symbol: "ETHUSDT", MarginCoin: "USDT", HoldSide: futures.HoldSideShort, Total: 2, AverageOpenPrice: 3000, MarkPrice: 3100, UnrealizedPL: -200},
+		{Symbol: "SOLUSDT", MarginCoin: "USDT", HoldSide: futures.HoldSideLong, Total: 0},
+		nil,
+	}
+
+	report := NetExposure(positions)
+	require.Len(t, report.Symbols, 2)
+	assert.Equal(t, "BTCUSDT", report.Symbols[0].Symbol, "sorted by gross notional")
+
+	btc, ok := report.Get("BTCUSDT")
+	require.True(t, ok)
+	assert.InDelta(t, 0.2, btc.NetSize, 1e-9)
+	assert.InDelta(t, 0.4, btc.GrossSize, 1e-9)
+	assert.InDelta(t, 12200, btc.NetNotional, 1e-6)
+	assert.InDelta(t, 24400, btc.GrossNotional, 1e-6)
+	assert.InDelta(t, 0.1, btc.HedgedSize, 1e-9)
+	assert.InDelta(t, 2000, btc.Basis, 1e-9)
+	assert.InDelta(t, 200, btc.LockedPnL, 1e-6)
+	assert.InDelta(t, 400, btc.UnrealizedPL, 1e-9)
+
+	eth, _ := report.Get("ETHUSDT")
+	assert.InDelta(t, -2, eth.NetSize, 1e-9)
+	assert.InDelta(t, -6200, eth.NetNotional, 1e-9)
+	assert.Zero(t, eth.Basis, "no basis without both sides")
+
+	assert.InDelta(t, 6000, report.NetNotional, 1e-6)
+	assert.InDelta(t, 30600, report.GrossNotional, 1e-6)
+	assert.InDelta(t, 200, report.LockedPnL, 1e-6)
+	_, ok = report.Get("SOLUSDT")
+	assert.False(t, ok)
+}
+
+func TestNetExposure_AveragesSameSideEntries(t *testing.T) {
+	report := NetExposure([]*Position{
+		{Symbol: "BTCUSDT", HoldSide: futures.HoldSideLong, Total: 1, AverageOpenPrice: 100, MarkPrice: 110, MarginMode: "crossed"},
+		{Symbol: "BTCUSDT", HoldSide: futures.HoldSideLong, Total: 3, AverageOpenPrice: 120, MarkPrice: 110, MarginMode: "isolated"},
+	})
+	require.Len(t, report.Symbols, 1)
+	assert.InDelta(t, 115, report.Symbols[0].LongOpenPrice, 1e-9)
+	assert.InDelta(t, 4, report.Symbols[0].LongSize, 1e-9)
+}
@@ -0,0 +1,25 @@
+package position
+
+import (
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// String returns a compact one-line summary, e.g.
+// Position{BTCUSDT long 0.01@65000 mark=65500 upl=5 lev=10x liq=59000 crossed}.
+func (p Position) String() string {
+	var liq string
+	if p.LiquidationPrice > 0 {
+		liq = "liq=" + common.FormatFloat(p.LiquidationPrice)
+	}
+	return common.Describe("Position", p.Symbol, string(p.HoldSide),
+		common.FormatFloat(p.Total)+"@"+common.FormatFloat(p.AverageOpenPrice),
+		"mark="+common.FormatFloat(p.MarkPrice), "upl="+common.FormatFloat(p.UnrealizedPL),
+		"lev="+common.FormatFloat(p.Leverage)+"x", liq, p.MarginMode)
+}
+
+// MarshalJSON encodes the wire fields with decimals in plain notation plus
+// createdAt/updatedAt parsed from ctime/utime. The output round-trips through
+// UnmarshalJSON.
+func (p Position) MarshalJSON() ([]byte, error) {
+	return common.MarshalFields(p, common.TimeField("createdAt", p.Ctime), common.TimeField("updatedAt", p.Utime))
+}
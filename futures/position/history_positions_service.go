@@ -92,6 +92,22 @@ func (s *HistoryPositionsService) Do(ctx context.Context) (*HistoryPositionsResp
 	return response, nil
 }
 
+// Iter iterates over all matching closed positions, following EndId across pages. The page size and
+// filters of the service apply to every page.
+func (s *HistoryPositionsService) Iter(ctx context.Context) *common.Iter[HistoryPosition] {
+	return common.NewIter(ctx, func(ctx context.Context, cursor string) (common.Page[HistoryPosition], error) {
+		page := *s
+		if cursor != "" {
+			page.lastEndId = cursor
+		}
+		res, err := page.Do(ctx)
+		if err != nil || res == nil {
+			return common.Page[HistoryPosition]{}, err
+		}
+		return common.Page[HistoryPosition]{Items: common.Values(res.List), Cursor: res.EndId}, nil
+	})
+}
+
 type HistoryPositionsResponse struct {
 	// List of historical positions
 	List []*HistoryPosition `json:"list"`
@@ -111,7 +127,7 @@ type HistoryPosition struct {
 	Symbol string `json:"symbol"`
 
 	// Position side: long/short
-	HoldSide string `json:"holdSide"`
+	HoldSide futures.HoldSideType `json:"holdSide"`
 
 	// Position size (positive number)
 	Size float64 `json:"size"`
@@ -163,7 +179,7 @@ func (hp *HistoryPosition) UnmarshalJSON(data []byte) error {
 		case "symbol":
 			hp.Symbol = common.SafeStringCast(value)
 		case "holdSide":
-			hp.HoldSide = common.SafeStringCast(value)
+			hp.HoldSide = futures.HoldSideType(common.SafeStringCast(value))
 		case "size":
 			v, err := common.ConvertToFloat64(value)
 			if err != nil {
@@ -0,0 +1,345 @@
+// Package quoter maintains a pair of post-only quotes around the order book mid.
+//
+// A Quoter keeps one bid and one ask resting at a configurable distance from the mid
+// price. Book updates only move the quotes once the target price has drifted more than a
+// tolerance from the resting price, so small ticks do not churn orders; quotes are moved
+// with trading.CancelReplaceService and every order action passes a rate limiter. With a
+// position tracker the quotes are skewed away from the target inventory, see Skew.
+//
+//	q := quoter.New(client, quoter.Options{
+//		Symbol:      "BTCUSDT",
+//		ProductType: futures.ProductTypeUSDTFutures,
+//		MarginCoin:  "USDT",
+//		MarginMode:  trading.MarginModeCrossed,
+//		Size:        "0.001",
+//		Offset:      0.0005, // 5 bps from mid
+//		Tolerance:   0.0002, // re-peg after 2 bps of drift
+//		TickSize:    0.1,
+//	})
+//	wsClient.SubscribeOrderBook5("BTCUSDT", "USDT-FUTURES", q.HandleMessage)
+//	orders.OnEvent(q.HandleOrderEvent) // tracker.OrderTracker: re-quote after fills
+//	go q.Run(ctx)
+//	defer q.Shutdown(context.Background()) // cancels the resting quotes
+package quoter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/khanbekov/go-bitget/futures/trading"
+)
+
+// Options configures a Quoter.
+type Options struct {
+	Symbol      string
+	ProductType futures.ProductType
+	MarginCoin  string
+	MarginMode  trading.MarginMode
+	// TradeSide is set to "open" in hedge mode. Leave empty in one-way mode.
+	TradeSide trading.PositionSideType
+
+	// Size is the quantity of each quote.
+	Size string
+
+	// Offset is the distance of each quote from the mid as a fraction of the mid,
+	// e.g. 0.001 for 10 bps.
+	Offset float64
+
+	// Improve quotes one tick ahead of the best price on the same side when the offset
+	// price would sit behind it, but never closer to the mid than MinOffset.
+	Improve   bool
+	MinOffset float64
+
+	// Tolerance is the drift of the target price from the resting price, as a fraction,
+	// that triggers a re-peg. Zero re-pegs on every tick change.
+	Tolerance float64
+
+	// TickSize rounds bids down and asks up to the contract's price step. Required.
+	TickSize float64
+
+	// Positions, when set, skews both quotes by the inventory of Symbol: with more
+	// inventory than TargetInventory the quotes move down to sell more and buy less,
+	// with less they move up. MaxInventory is the distance from the target, in
+	// contracts, at which the skew is full, shifting the quotes by SkewFactor of the
+	// mid. See Skew.
+	Positions       *tracker.PositionTracker
+	TargetInventory float64
+	MaxInventory    float64
+	SkewFactor      float64
+
+	// Limiter bounds order actions. Defaults to 10 per second with a burst of 10.
+	Limiter *common.RateLimiter
+
+	// Tagger generates the clientOids of the quotes for attribution. Optional.
+	Tagger *trading.Tagger
+
+	// OnError receives failed order actions. Optional.
+	OnError func(error)
+}
+
+// Quote is the state of one side.
+type Quote struct {
+	Side      trading.Side
+	OrderID   string
+	ClientOid string
+	Price     float64
+}
+
+// Quoter maintains a post-only bid and ask. It is safe for concurrent use.
+type Quoter struct {
+	client futures.ClientInterface
+	opts   Options
+
+	books chan [2]float64 // latest best bid/ask, coalesced
+
+	mu     sync.Mutex
+	quotes map[trading.Side]*Quote
+	seq    int
+}
+
+// New creates a quoter. No orders are placed until Run receives a book.
+func New(client futures.ClientInterface, opts Options) *Quoter {
+	if opts.Limiter == nil {
+		opts.Limiter = common.NewRateLimiter(10, 10)
+	}
+	return &Quoter{
+		client: client,
+		opts:   opts,
+		books:  make(chan [2]float64, 1),
+		quotes: make(map[trading.Side]*Quote),
+	}
+}
+
+// Targets returns the quote prices for a top of book. ok is false when the book is
+// crossed or empty.
+func (o Options) Targets(bestBid, bestAsk float64) (bid, ask float64, ok bool) {
+	return o.SkewedTargets(bestBid, bestAsk, 0)
+}
+
+// SkewedTargets returns the quote prices for a top of book around a mid shifted by
+// skew * SkewFactor, with skew from Skew. The quotes stay post-only safe however far
+// they are skewed.
+func (o Options) SkewedTargets(bestBid, bestAsk, skew float64) (bid, ask float64, ok bool) {
+	if bestBid <= 0 || bestAsk <= 0 || bestBid >= bestAsk || o.TickSize <= 0 {
+		return 0, 0, false
+	}
+	mid := (bestBid + bestAsk) / 2 * (1 - skew*o.SkewFactor)
+	bid, ask = mid*(1-o.Offset), mid*(1+o.Offset)
+
+	if o.Improve {
+		if improved := math.Min(bestBid+o.TickSize, mid*(1-o.MinOffset)); improved > bid {
+			bid = improved
+		}
+		if improved := math.Max(bestAsk-o.TickSize, mid*(1+o.MinOffset)); improved < ask {
+			ask = improved
+		}
+	}
+
+	// Post-only: never cross the opposite side
+	bid = math.Min(common.RoundDownToStep(bid, o.TickSize), common.RoundDownToStep(bestAsk-o.TickSize, o.TickSize))
+	ask = math.Max(common.RoundUpToStep(ask, o.TickSize), common.RoundUpToStep(bestBid+o.TickSize, o.TickSize))
+	return bid, ask, bid > 0
+}
+
+// UpdateBook queues a top of book for Run. Only the latest book is kept, so a slow
+// order round trip never backs up the caller.
+func (q *Quoter) UpdateBook(bestBid, bestAsk float64) {
+	select {
+	case <-q.books:
+	default:
+	}
+	select {
+	case q.books <- [2]float64{bestBid, bestAsk}:
+	default:
+	}
+}
+
+// HandleMessage ingests a message from a snapshot book channel (books1, books5 or
+// books15). Its signature matches ws.OnReceive.
+func (q *Quoter) HandleMessage(message string) {
+	var msg struct {
+		Data []struct {
+			Asks [][]string `json:"asks"`
+			Bids [][]string `json:"bids"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(message), &msg); err != nil || len(msg.Data) == 0 {
+		return
+	}
+	book := msg.Data[0]
+	if len(book.Bids) == 0 || len(book.Asks) == 0 || len(book.Bids[0]) == 0 || len(book.Asks[0]) == 0 {
+		return
+	}
+	bid, _ := strconv.ParseFloat(book.Bids[0][0], 64)
+	ask, _ := strconv.ParseFloat(book.Asks[0][0], 64)
+	q.UpdateBook(bid, ask)
+}
+
+// HandleOrderEvent forgets a quote once its order is filled or cancelled, so the next
+// book update places a fresh one. Pass it to tracker.OrderTracker.OnEvent.
+func (q *Quoter) HandleOrderEvent(ev tracker.OrderEvent) {
+	if !ev.Order.Terminal() {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for side, quote := range q.quotes {
+		if quote.OrderID == ev.Order.OrderID {
+			delete(q.quotes, side)
+		}
+	}
+}
+
+// Quotes returns the resting quotes.
+func (q *Quoter) Quotes() []Quote {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	out := make([]Quote, 0, len(q.quotes))
+	for _, side := range []trading.Side{trading.SideBuy, trading.SideSell} {
+		if quote, ok := q.quotes[side]; ok {
+			out = append(out, *quote)
+		}
+	}
+	return out
+}
+
+// Run reconciles the quotes with every book update until ctx is cancelled. The quotes
+// are left resting; call Shutdown to cancel them.
+func (q *Quoter) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case book := <-q.books:
+			q.Reconcile(ctx, book[0], book[1])
+		}
+	}
+}
+
+// Reconcile places or re-pegs both quotes for a top of book.
+func (q *Quoter) Reconcile(ctx context.Context, bestBid, bestAsk float64) {
+	bid, ask, ok := q.opts.SkewedTargets(bestBid, bestAsk, q.Skew())
+	if !ok {
+		return
+	}
+	q.reconcileSide(ctx, trading.SideBuy, bid)
+	q.reconcileSide(ctx, trading.SideSell, ask)
+}
+
+func (q *Quoter) reconcileSide(ctx context.Context, side trading.Side, price float64) {
+	q.mu.Lock()
+	current, resting := q.quotes[side]
+	var cur Quote
+	if resting {
+		cur = *current
+	}
+	q.mu.Unlock()
+
+	if resting && math.Abs(price-cur.Price) <= cur.Price*q.opts.Tolerance+q.opts.TickSize/2 {
+		return
+	}
+	if err := q.opts.Limiter.Wait(ctx); err != nil {
+		return
+	}
+
+	clientOid := q.nextClientOid(side)
+	if !resting {
+		info, err := trading.NewCreateOrderService(q.client).
+			Symbol(q.opts.Symbol).
+			ProductType(trading.ProductType(q.opts.ProductType)).
+			MarginCoin(q.opts.MarginCoin).
+			MarginMode(q.opts.MarginMode).
+			PositionSideType(q.opts.TradeSide).
+			SideType(side).
+			OrderType(trading.OrderTypeLimit).
+			Price(common.FormatToStep(price, q.opts.TickSize)).
+			Size(q.opts.Size).
+			PostOnly().
+			ClientOrderId(clientOid).
+			Do(ctx)
+		if err != nil {
+			q.error(fmt.Errorf("quoter: place %s %s: %w", side, q.opts.Symbol, err))
+			return
+		}
+		q.set(side, &Quote{Side: side, OrderID: info.OrderId, ClientOid: clientOid, Price: price})
+		return
+	}
+
+	res, err := trading.NewCancelReplaceService(q.client).
+		Symbol(q.opts.Symbol).
+		ProductType(trading.ProductType(q.opts.ProductType)).
+		MarginCoin(q.opts.MarginCoin).
+		OrderId(cur.OrderID).
+		NewPrice(common.FormatToStep(price, q.opts.TickSize)).
+		NewClientOid(clientOid).
+		Do(ctx)
+	switch {
+	case errors.Is(err, trading.ErrOrderFilled):
+		q.clear(side, cur.OrderID)
+	case err != nil:
+		q.error(fmt.Errorf("quoter: re-peg %s %s: %w", side, q.opts.Symbol, err))
+	case res.Replacement == nil:
+		q.clear(side, cur.OrderID)
+	default:
+		q.set(side, &Quote{Side: side, OrderID: res.Replacement.OrderId, ClientOid: clientOid, Price: price})
+	}
+}
+
+// Shutdown cancels the resting quotes. It implements lifecycle.Component.
+func (q *Quoter) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, quote := range q.Quotes() {
+		_, err := trading.NewCancelOrderService(q.client).
+			Symbol(q.opts.Symbol).
+			ProductType(trading.ProductType(q.opts.ProductType)).
+			MarginCoin(q.opts.MarginCoin).
+			OrderId(quote.OrderID).
+			Do(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("quoter: cancel %s %s: %w", quote.Side, quote.OrderID, err))
+			continue
+		}
+		q.clear(quote.Side, quote.OrderID)
+	}
+	return errors.Join(errs...)
+}
+
+func (q *Quoter) nextClientOid(side trading.Side) string {
+	if q.opts.Tagger != nil {
+		return q.opts.Tagger.Next()
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.seq++
+	return fmt.Sprintf("q%s%d%d", string(side)[:1], time.Now().UnixMilli(), q.seq)
+}
+
+func (q *Quoter) set(side trading.Side, quote *Quote) {
+	q.mu.Lock()
+	q.quotes[side] = quote
+	q.mu.Unlock()
+}
+
+// clear forgets a side unless it already points at a newer order.
+func (q *Quoter) clear(side trading.Side, orderID string) {
+	q.mu.Lock()
+	if quote, ok := q.quotes[side]; ok && quote.OrderID == orderID {
+		delete(q.quotes, side)
+	}
+	q.mu.Unlock()
+}
+
+func (q *Quoter) error(err error) {
+	if q.opts.OnError != nil {
+		q.opts.OnError(err)
+	}
+}
@@ -0,0 +1,175 @@
+package quoter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// fakeClient accepts orders and amendments and records the request bodies.
+type fakeClient struct {
+	mu     sync.Mutex
+	nextID int
+	calls  []map[string]string
+}
+
+func (c *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	req := map[string]string{"endpoint": endpoint}
+	_ = json.Unmarshal(body, &req)
+	for k := range query {
+		req[k] = query.Get(k)
+	}
+	c.calls = append(c.calls, req)
+
+	ok := func(data string) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+		return &futures.ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil
+	}
+	c.nextID++
+	id := strconv.Itoa(c.nextID)
+	switch endpoint {
+	case trading.EndpointPlaceOrder:
+		return ok(`{"orderId":"` + id + `","clientOId":"` + req["clientOid"] + `"}`)
+	case trading.EndpointModifyOrder:
+		return ok(`{"orderId":"` + id + `","clientOId":"` + req["newClientOid"] + `"}`)
+	case trading.EndpointCancelOrder:
+		return ok(`{"orderId":"` + req["orderId"] + `"}`)
+	case trading.EndpointOrderDetails:
+		if req["orderId"] == "" {
+			return nil, nil, &types.APIError{Code: 40109, Message: "order does not exist"}
+		}
+		return ok(`{"orderId":"` + req["orderId"] + `","size":"0.01","baseVolume":"0","price":"100","state":"live","side":"buy","orderType":"limit","force":"post_only","marginMode":"crossed"}`)
+	}
+	return nil, nil, errors.New("unexpected endpoint " + endpoint)
+}
+
+func (c *fakeClient) requests(endpoint string) []map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []map[string]string
+	for _, r := range c.calls {
+		if r["endpoint"] == endpoint {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func testOptions() Options {
+	return Options{
+		Symbol:      "BTCUSDT",
+		ProductType: futures.ProductTypeUSDTFutures,
+		MarginCoin:  "USDT",
+		MarginMode:  trading.MarginModeCrossed,
+		Size:        "0.01",
+		Offset:      0.001,
+		Tolerance:   0.0005,
+		TickSize:    0.1,
+	}
+}
+
+func TestOptions_Targets(t *testing.T) {
+	o := testOptions()
+	bid, ask, ok := o.Targets(9999.9, 10000.1)
+	require.True(t, ok)
+	assert.InDelta(t, 9990.0, bid, 1e-9)
+	assert.InDelta(t, 10010.0, ask, 1e-9)
+
+	_, _, ok = o.Targets(100, 100)
+	assert.False(t, ok, "crossed book")
+}
+
+func TestOptions_TargetsImprove(t *testing.T) {
+	o := testOptions()
+	o.Offset = 0.01
+	o.Improve = true
+	o.MinOffset = 0.0001
+
+	// Wide book: the offset prices sit behind the best prices, so quote a tick ahead
+	bid, ask, ok := o.Targets(9950, 10050)
+	require.True(t, ok)
+	assert.InDelta(t, 9950.1, bid, 1e-9)
+	assert.InDelta(t, 10049.9, ask, 1e-9)
+
+	// One-tick book: improving would cross, so the quotes stay post-only safe
+	o.Offset = 0
+	bid, ask, _ = o.Targets(100.0, 100.1)
+	assert.InDelta(t, 100.0, bid, 1e-9)
+	assert.InDelta(t, 100.1, ask, 1e-9)
+}
+
+func TestQuoter_PlacesAndRepegs(t *testing.T) {
+	client := &fakeClient{}
+	q := New(client, testOptions())
+	ctx := context.Background()
+
+	q.Reconcile(ctx, 9999.9, 10000.1)
+	placed := client.requests(trading.EndpointPlaceOrder)
+	require.Len(t, placed, 2)
+	assert.Equal(t, "9990.0", placed[0]["price"])
+	assert.Equal(t, "post_only", placed[0]["force"])
+	assert.Equal(t, "sell", placed[1]["side"])
+	assert.Len(t, q.Quotes(), 2)
+
+	// A move within tolerance leaves the quotes alone
+	q.Reconcile(ctx, 10002.9, 10003.1)
+	assert.Empty(t, client.requests(trading.EndpointModifyOrder))
+
+	// A larger move re-pegs both sides with an amendment
+	q.Reconcile(ctx, 10019.9, 10020.1)
+	modified := client.requests(trading.EndpointModifyOrder)
+	require.Len(t, modified, 2)
+	assert.Equal(t, "10009.9", modified[0]["newPrice"])
+	quotes := q.Quotes()
+	assert.Equal(t, modified[0]["newClientOid"], quotes[0].ClientOid)
+	assert.InDelta(t, 10009.9, quotes[0].Price, 1e-9)
+}
+
+func TestQuoter_RequotesAfterFill(t *testing.T) {
+	client := &fakeClient{}
+	q := New(client, testOptions())
+	ctx := context.Background()
+	q.Reconcile(ctx, 9999.9, 10000.1)
+
+	bid := q.Quotes()[0]
+	q.HandleOrderEvent(tracker.OrderEvent{Type: tracker.OrderFilled, Order: tracker.Order{OrderID: bid.OrderID, Status: tracker.StatusFilled}})
+	require.Len(t, q.Quotes(), 1)
+
+	q.Reconcile(ctx, 9999.9, 10000.1)
+	assert.Len(t, client.requests(trading.EndpointPlaceOrder), 3)
+	assert.Len(t, q.Quotes(), 2)
+}
+
+func TestQuoter_HandleMessageAndShutdown(t *testing.T) {
+	client := &fakeClient{}
+	q := New(client, testOptions())
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		q.Run(ctx)
+		close(done)
+	}()
+	q.HandleMessage(`{"action":"snapshot","arg":{"channel":"books5"},"data":[{"asks":[["10000.1","1"]],"bids":[["9999.9","2"]],"ts":"1"}]}`)
+	require.Eventually(t, func() bool { return len(q.Quotes()) == 2 }, time.Second, time.Millisecond)
+	cancel()
+	<-done
+
+	require.NoError(t, q.Shutdown(context.Background()))
+	assert.Len(t, client.requests(trading.EndpointCancelOrder), 2)
+	assert.Empty(t, q.Quotes())
+}
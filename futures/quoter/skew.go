@@ -0,0 +1,41 @@
+package quoter
+
+import (
+	"math"
+
+	"github.com/khanbekov/go-bitget/futures/tracker"
+)
+
+// Skew returns the quote skew for an inventory: (inventory - target) / maxInventory,
+// clamped to [-1, 1]. A positive skew means too much inventory, so the quotes move down;
+// a negative one means too little, so they move up. It is 0 when maxInventory is not
+// positive.
+func Skew(inventory, target, maxInventory float64) float64 {
+	if maxInventory <= 0 {
+		return 0
+	}
+	return math.Max(-1, math.Min(1, (inventory-target)/maxInventory))
+}
+
+// Inventory returns the net size of positions: longs count positive, shorts negative.
+func Inventory(positions []tracker.Position) float64 {
+	var net float64
+	for _, p := range positions {
+		if p.HoldSide == "short" {
+			net -= p.Size
+		} else {
+			net += p.Size
+		}
+	}
+	return net
+}
+
+// Skew returns the current skew of the quotes from the positions of Options.Positions,
+// or 0 when no tracker is set.
+func (q *Quoter) Skew() float64 {
+	if q.opts.Positions == nil {
+		return 0
+	}
+	inventory := Inventory(q.opts.Positions.Open(q.opts.Symbol))
+	return Skew(inventory, q.opts.TargetInventory, q.opts.MaxInventory)
+}
@@ -0,0 +1,81 @@
+package quoter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkew(t *testing.T) {
+	assert.Zero(t, Skew(0.5, 0.5, 1), "at target")
+	assert.InDelta(t, 0.5, Skew(0.5, 0, 1), 1e-12)
+	assert.InDelta(t, -0.25, Skew(-0.25, 0, 1), 1e-12)
+	assert.Equal(t, 1.0, Skew(1, 0, 1), "at the limit")
+	assert.Equal(t, 1.0, Skew(5, 0, 1), "clamped above the limit")
+	assert.Equal(t, -1.0, Skew(-5, 0, 1), "clamped below the limit")
+	assert.Equal(t, -1.0, Skew(0, 2, 1), "a long target with a flat position")
+	assert.Zero(t, Skew(5, 0, 0), "disabled without a maximum")
+	assert.Zero(t, Skew(5, 0, -1))
+}
+
+func TestInventory(t *testing.T) {
+	assert.Zero(t, Inventory(nil))
+	assert.InDelta(t, 0.3, Inventory([]tracker.Position{
+		{Symbol: "BTCUSDT", HoldSide: "long", Size: 0.5},
+		{Symbol: "BTCUSDT", HoldSide: "short", Size: 0.2},
+	}), 1e-12)
+}
+
+func TestOptions_SkewedTargets(t *testing.T) {
+	o := testOptions()
+	o.SkewFactor = 0.001
+
+	bid, ask, ok := o.SkewedTargets(9999.9, 10000.1, 0)
+	require.True(t, ok)
+	assert.InDelta(t, 9990.0, bid, 1e-9, "no skew matches Targets")
+	assert.InDelta(t, 10010.0, ask, 1e-9)
+
+	// Full long inventory: both quotes move down, the ask to the best bid plus a tick
+	bid, ask, _ = o.SkewedTargets(9999.9, 10000.1, 1)
+	assert.InDelta(t, 9980.0, bid, 1e-9)
+	assert.InDelta(t, 10000.0, ask, 1e-9)
+
+	// Full short inventory: both quotes move up, the bid to the best ask minus a tick
+	bid, ask, _ = o.SkewedTargets(9999.9, 10000.1, -1)
+	assert.InDelta(t, 9999.9, bid, 1e-9)
+	assert.InDelta(t, 10020.1, ask, 1e-9)
+
+	// However large the skew, the quotes never cross the book
+	o.SkewFactor = 0.05
+	bid, ask, _ = o.SkewedTargets(9999.9, 10000.1, 1)
+	assert.InDelta(t, 10000.0, ask, 1e-9)
+	assert.Less(t, bid, 9999.9)
+	bid, ask, _ = o.SkewedTargets(9999.9, 10000.1, -1)
+	assert.InDelta(t, 10000.0, bid, 1e-9)
+	assert.Greater(t, ask, 10000.1)
+}
+
+func TestQuoter_SkewsByTrackedInventory(t *testing.T) {
+	positions := tracker.NewPositionTracker(tracker.Options{})
+	o := testOptions()
+	o.Positions = positions
+	o.MaxInventory = 0.1
+	o.SkewFactor = 0.001
+	client := &fakeClient{}
+	q := New(client, o)
+
+	assert.Zero(t, q.Skew(), "flat")
+	positions.Update(tracker.Position{Symbol: "BTCUSDT", HoldSide: "long", Size: 0.05})
+	positions.Update(tracker.Position{Symbol: "ETHUSDT", HoldSide: "long", Size: 10})
+	assert.InDelta(t, 0.5, q.Skew(), 1e-12, "only the quoted symbol counts")
+
+	q.Reconcile(context.Background(), 9999.9, 10000.1)
+	placed := client.requests(trading.EndpointPlaceOrder)
+	require.Len(t, placed, 2)
+	assert.Equal(t, "9985.0", placed[0]["price"])
+	assert.Equal(t, "10005.0", placed[1]["price"])
+}
@@ -0,0 +1,332 @@
+// Package roll moves a position out of an expiring delivery contract into the next
+// contract or the perpetual.
+//
+// Roll closes the position in the old contract and opens the same exposure in the new
+// one. Both legs trade with immediate-or-cancel limit orders priced at most MaxSlippage
+// beyond the quote, repeated until the leg is complete or the attempts run out, so a
+// thin book fills partially instead of at any price. The new leg is sized from what the
+// close actually filled, never from what was requested:
+//
+//	r := roll.New(client, roll.Options{
+//		ProductType: futures.ProductTypeUSDTFutures,
+//		MarginCoin:  "USDT",
+//		MarginMode:  trading.MarginModeCrossed,
+//		MaxSlippage: 0.002,
+//	})
+//	res, err := r.Roll(ctx, "BTCUSDT_250926", "BTCUSDT")
+//	if err != nil {
+//		log.Printf("roll incomplete: closed %v, opened %v: %v", res.Close.Filled, res.Open.Filled, err)
+//	}
+package roll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/futures/position"
+	"github.com/khanbekov/go-bitget/futures/trading"
+)
+
+var (
+	// ErrNoPosition is returned when the old contract holds no position to roll.
+	ErrNoPosition = errors.New("roll: no open position")
+	// ErrIncomplete is matched by the error of a roll whose leg did not fill completely
+	// within the attempts. The Result tells how far it got.
+	ErrIncomplete = errors.New("roll: leg not completely filled")
+)
+
+// Options configures a Roller.
+type Options struct {
+	ProductType futures.ProductType
+	MarginCoin  string
+	MarginMode  trading.MarginMode
+	// Hedge is set when the account uses hedge position mode.
+	Hedge bool
+	// HoldSide selects the position to roll when hedge mode holds both sides of the old
+	// contract: "long" or "short".
+	HoldSide string
+
+	// Size rolls only this quantity of the position; zero rolls all of it.
+	Size float64
+	// KeepNotional sizes the new leg to the notional closed rather than to the quantity
+	// closed, for contracts whose prices differ noticeably.
+	KeepNotional bool
+
+	// MaxSlippage bounds every order price at this fraction beyond its quote: the ask
+	// for buys, the bid for sells. Defaults to 0.003.
+	MaxSlippage float64
+	// Attempts is the number of orders per leg. Defaults to 5.
+	Attempts int
+	// Pause is the wait between attempts, for the book to refill. Defaults to 500ms.
+	Pause time.Duration
+}
+
+// Leg is the outcome of one side of the roll.
+type Leg struct {
+	Symbol    string
+	Side      trading.Side
+	TradeSide trading.PositionSideType // Hedge mode only
+	Requested float64
+	Filled    float64
+	AvgPrice  float64
+	OrderIDs  []string
+}
+
+// Remaining returns the quantity not filled.
+func (l Leg) Remaining() float64 {
+	return l.Requested - l.Filled
+}
+
+// Result describes a roll.
+type Result struct {
+	HoldSide string
+	Close    Leg
+	Open     Leg
+}
+
+// Roller rolls positions between contracts.
+type Roller struct {
+	client futures.ClientInterface
+	opts   Options
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+// New creates a roller.
+func New(client futures.ClientInterface, opts Options) *Roller {
+	if opts.MaxSlippage <= 0 {
+		opts.MaxSlippage = 0.003
+	}
+	if opts.Attempts <= 0 {
+		opts.Attempts = 5
+	}
+	if opts.Pause <= 0 {
+		opts.Pause = 500 * time.Millisecond
+	}
+	return &Roller{client: client, opts: opts, sleep: sleep}
+}
+
+// Roll closes the position in fromSymbol and opens the equivalent position in
+// toSymbol. The result is returned with every error after the first order, so callers
+// see how much was closed and opened. A close that filled only partially is still
+// rolled for the filled quantity, and the error matches ErrIncomplete.
+func (r *Roller) Roll(ctx context.Context, fromSymbol, toSymbol string) (*Result, error) {
+	holdSide, size, err := r.position(ctx, fromSymbol)
+	if err != nil {
+		return nil, err
+	}
+	from, err := r.contract(ctx, fromSymbol)
+	if err != nil {
+		return nil, err
+	}
+	to, err := r.contract(ctx, toSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{HoldSide: holdSide}
+	res.Close = Leg{Symbol: fromSymbol, Side: trading.CloseSide(trading.HoldSide(holdSide), r.opts.Hedge), Requested: from.roundSize(size)}
+	res.Open = Leg{Symbol: toSymbol, Side: trading.SideBuy}
+	if holdSide == string(trading.HoldSideShort) {
+		res.Open.Side = trading.SideSell
+	}
+	if r.opts.Hedge {
+		res.Close.TradeSide, res.Open.TradeSide = trading.PositionSideClose, trading.PositionSideOpen
+	}
+	if res.Close.Requested < from.minSize {
+		return nil, fmt.Errorf("roll: %s position %v is below the minimum order size", fromSymbol, size)
+	}
+
+	closeErr := r.execute(ctx, from, &res.Close, true)
+	if res.Close.Filled <= 0 {
+		return res, fmt.Errorf("roll: close %s: %w", fromSymbol, closeErr)
+	}
+
+	target := res.Close.Filled
+	if r.opts.KeepNotional {
+		quote, err := r.quote(ctx, toSymbol, res.Open.Side == trading.SideSell)
+		if err != nil {
+			return res, err
+		}
+		target = res.Close.Filled * res.Close.AvgPrice / quote
+	}
+	res.Open.Requested = to.roundSize(target)
+	if res.Open.Requested < to.minSize {
+		return res, fmt.Errorf("roll: %v is below the minimum order size of %s", target, toSymbol)
+	}
+	if err := r.execute(ctx, to, &res.Open, false); err != nil {
+		return res, fmt.Errorf("roll: open %s: %w", toSymbol, err)
+	}
+	if closeErr != nil {
+		return res, fmt.Errorf("roll: close %s: %w", fromSymbol, closeErr)
+	}
+	return res, nil
+}
+
+// position returns the hold side and available size to roll.
+func (r *Roller) position(ctx context.Context, symbol string) (string, float64, error) {
+	positions, err := position.NewSinglePositionService(r.client).
+		Symbol(symbol).
+		ProductType(r.opts.ProductType).
+		MarginCoin(r.opts.MarginCoin).
+		Do(ctx)
+	if err != nil {
+		return "", 0, fmt.Errorf("roll: position %s: %w", symbol, err)
+	}
+	var open []*position.Position
+	for _, p := range positions {
+		if p.Available > 0 && (r.opts.HoldSide == "" || string(p.HoldSide) == r.opts.HoldSide) {
+			open = append(open, p)
+		}
+	}
+	switch {
+	case len(open) == 0:
+		return "", 0, fmt.Errorf("%w in %s", ErrNoPosition, symbol)
+	case len(open) > 1:
+		return "", 0, fmt.Errorf("roll: %s holds both sides; set HoldSide", symbol)
+	}
+	size := open[0].Available
+	if r.opts.Size > 0 && r.opts.Size < size {
+		size = r.opts.Size
+	}
+	return string(open[0].HoldSide), size, nil
+}
+
+// spec holds the trading rules of a contract.
+type spec struct {
+	tick, step, minSize float64
+}
+
+func (s spec) roundSize(v float64) float64 {
+	return common.RoundDownToStep(v, s.step)
+}
+
+func (r *Roller) contract(ctx context.Context, symbol string) (spec, error) {
+	contracts, err := market.NewContractsService(r.client).Symbol(symbol).ProductType(r.opts.ProductType).Do(ctx)
+	if err != nil {
+		return spec{}, fmt.Errorf("roll: contract %s: %w", symbol, err)
+	}
+	if len(contracts) == 0 {
+		return spec{}, fmt.Errorf("roll: no contract found for %s", symbol)
+	}
+	c := contracts[0]
+	places, _ := strconv.Atoi(c.PricePlace)
+	endStep, _ := strconv.ParseFloat(c.PriceEndStep, 64)
+	if endStep <= 0 {
+		endStep = 1
+	}
+	volumePlace, _ := strconv.Atoi(c.VolumePlace)
+	s := spec{tick: endStep / math.Pow10(places), step: 1 / math.Pow10(volumePlace)}
+	if m, _ := strconv.ParseFloat(c.SizeMultiplier, 64); m > s.step {
+		s.step = m
+	}
+	s.minSize, _ = strconv.ParseFloat(c.MinTradeNum, 64)
+	if s.minSize < s.step {
+		s.minSize = s.step
+	}
+	return s, nil
+}
+
+// execute sends immediate-or-cancel limit orders until leg is filled or the attempts
+// run out. Closing orders are reduce-only in one-way mode.
+func (r *Roller) execute(ctx context.Context, s spec, leg *Leg, closing bool) error {
+	// Hedge mode close orders carry the position's side, so closing a long sells
+	sells := (leg.Side == trading.SideSell) != (leg.TradeSide == trading.PositionSideClose)
+	for attempt := 0; attempt < r.opts.Attempts; attempt++ {
+		size := s.roundSize(leg.Remaining())
+		if size < s.minSize {
+			return nil
+		}
+		if attempt > 0 {
+			if err := r.sleep(ctx, r.opts.Pause); err != nil {
+				return err
+			}
+		}
+		quote, err := r.quote(ctx, leg.Symbol, sells)
+		if err != nil {
+			return err
+		}
+		price := common.RoundDownToStep(quote*(1+r.opts.MaxSlippage), s.tick)
+		if sells {
+			price = common.RoundUpToStep(quote*(1-r.opts.MaxSlippage), s.tick)
+		}
+
+		order := trading.NewCreateOrderService(r.client).
+			Symbol(leg.Symbol).
+			ProductType(trading.ProductType(r.opts.ProductType)).
+			MarginCoin(r.opts.MarginCoin).
+			MarginMode(r.opts.MarginMode).
+			SideType(leg.Side).
+			OrderType(trading.OrderTypeLimit).
+			TimeInForce(trading.TimeInForceIOC).
+			Size(common.FormatToStep(size, s.step)).
+			Price(common.FormatToStep(price, s.tick))
+		if leg.TradeSide != "" {
+			order.PositionSideType(leg.TradeSide)
+		} else if closing {
+			order.ReduceOnly(true)
+		}
+		info, err := order.Do(ctx)
+		if err != nil {
+			return err
+		}
+		leg.OrderIDs = append(leg.OrderIDs, info.OrderId)
+
+		detail, err := trading.NewGetOrderDetailsService(r.client).
+			Symbol(leg.Symbol).
+			ProductType(trading.ProductType(r.opts.ProductType)).
+			OrderId(info.OrderId).
+			Do(ctx)
+		if err != nil {
+			return fmt.Errorf("order %s: %w", info.OrderId, err)
+		}
+		filled, _ := strconv.ParseFloat(detail.BaseVolume, 64)
+		avg, _ := strconv.ParseFloat(detail.PriceAvg, 64)
+		if filled > 0 {
+			leg.AvgPrice = (leg.AvgPrice*leg.Filled + avg*filled) / (leg.Filled + filled)
+			leg.Filled += filled
+		}
+	}
+	if s.roundSize(leg.Remaining()) >= s.minSize {
+		return fmt.Errorf("%w: %v of %v after %d orders", ErrIncomplete, leg.Filled, leg.Requested, r.opts.Attempts)
+	}
+	return nil
+}
+
+// quote returns the best bid for sells and the best ask for buys, falling back to the
+// last price.
+func (r *Roller) quote(ctx context.Context, symbol string, sells bool) (float64, error) {
+	t, err := market.NewTickerService(r.client).Symbol(symbol).ProductType(string(r.opts.ProductType)).Do(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("ticker %s: %w", symbol, err)
+	}
+	price := t.AskPr
+	if sells {
+		price = t.BidPr
+	}
+	if v, err := strconv.ParseFloat(price, 64); err == nil && v > 0 {
+		return v, nil
+	}
+	v, err := strconv.ParseFloat(t.LastPr, 64)
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("ticker %s: no price", symbol)
+	}
+	return v, nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,155 @@
+package roll
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// fakeClient holds positions in BTCUSDT_250926 (quoted 50000/50010) and trades against
+// BTCUSDT (quoted 50100/50110). Each order fills the next quantity of fills[symbol], or
+// nothing when the queue is empty.
+type fakeClient struct {
+	positions string
+	fills     map[string][]string
+	orders    []map[string]string
+	details   map[string]string
+}
+
+func (c *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	ok := func(data string) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+		return &futures.ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil
+	}
+	switch endpoint {
+	case futures.EndpointSinglePosition:
+		return ok(c.positions)
+	case futures.EndpointContracts:
+		return ok(`[{"symbol":"` + query.Get("symbol") + `","pricePlace":"1","priceEndStep":"1","volumePlace":"3","sizeMultiplier":"0.001","minTradeNum":"0.001"}]`)
+	case futures.EndpointTicker:
+		if query.Get("symbol") == "BTCUSDT" {
+			return ok(`[{"symbol":"BTCUSDT","lastPr":"50105","bidPr":"50100","askPr":"50110"}]`)
+		}
+		return ok(`[{"symbol":"BTCUSDT_250926","lastPr":"50005","bidPr":"50000","askPr":"50010"}]`)
+	case trading.EndpointPlaceOrder:
+		var req map[string]string
+		_ = json.Unmarshal(body, &req)
+		c.orders = append(c.orders, req)
+		id := strconv.Itoa(len(c.orders))
+		filled, price := "0", "0"
+		if queue := c.fills[req["symbol"]]; len(queue) > 0 {
+			filled, price, c.fills[req["symbol"]] = queue[0], req["price"], queue[1:]
+		}
+		if c.details == nil {
+			c.details = map[string]string{}
+		}
+		c.details[id] = `{"orderId":"` + id + `","baseVolume":"` + filled + `","priceAvg":"` + price + `"}`
+		return ok(`{"orderId":"` + id + `"}`)
+	case trading.EndpointOrderDetails:
+		return ok(c.details[query.Get("orderId")])
+	}
+	return nil, nil, errors.New("unexpected endpoint " + endpoint)
+}
+
+func newRoller(c *fakeClient, opts Options) *Roller {
+	opts.ProductType = futures.ProductTypeUSDTFutures
+	opts.MarginCoin = "USDT"
+	opts.MarginMode = trading.MarginModeCrossed
+	opts.MaxSlippage = 0.001
+	r := New(c, opts)
+	r.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+	return r
+}
+
+func TestRoller_RollLongWithPartialFills(t *testing.T) {
+	c := &fakeClient{
+		positions: `[{"symbol":"BTCUSDT_250926","holdSide":"long","total":"1","available":"1"}]`,
+		fills: map[string][]string{
+			"BTCUSDT_250926": {"0.6", "0.4"},
+			"BTCUSDT":        {"1"},
+		},
+	}
+	res, err := newRoller(c, Options{}).Roll(context.Background(), "BTCUSDT_250926", "BTCUSDT")
+	require.NoError(t, err)
+
+	assert.Equal(t, "long", res.HoldSide)
+	assert.Equal(t, 1.0, res.Close.Filled)
+	assert.Equal(t, []string{"1", "2"}, res.Close.OrderIDs)
+	assert.Equal(t, 1.0, res.Open.Requested)
+	assert.Equal(t, 1.0, res.Open.Filled)
+
+	require.Len(t, c.orders, 3)
+	first := c.orders[0]
+	assert.Equal(t, "sell", first["side"])
+	assert.Equal(t, "IOC", first["force"])
+	assert.Equal(t, "YES", first["reduceOnly"])
+	assert.Equal(t, "1.000", first["size"])
+	assert.Equal(t, "49950.0", first["price"], "bid less the slippage, rounded away from the bid")
+	assert.Equal(t, "0.400", c.orders[1]["size"], "the remainder is retried")
+
+	open := c.orders[2]
+	assert.Equal(t, "buy", open["side"])
+	assert.Empty(t, open["reduceOnly"])
+	assert.Equal(t, "50160.1", open["price"], "ask plus the slippage, rounded toward the ask")
+}
+
+func TestRoller_HedgeShortKeepNotional(t *testing.T) {
+	c := &fakeClient{
+		positions: `[{"symbol":"BTCUSDT_250926","holdSide":"long","total":"1","available":"1"},{"symbol":"BTCUSDT_250926","holdSide":"short","total":"2","available":"2"}]`,
+		fills: map[string][]string{
+			"BTCUSDT_250926": {"0.5"},
+			"BTCUSDT":        {"0.499"},
+		},
+	}
+	r := newRoller(c, Options{Hedge: true, KeepNotional: true, Attempts: 1})
+
+	_, err := r.Roll(context.Background(), "BTCUSDT_250926", "BTCUSDT")
+	assert.ErrorContains(t, err, "set HoldSide")
+
+	r.opts.HoldSide = "short"
+	r.opts.Size = 0.5
+	res, err := r.Roll(context.Background(), "BTCUSDT_250926", "BTCUSDT")
+	require.NoError(t, err)
+
+	// Closing a short in hedge mode is a sell order with tradeSide close that buys
+	require.Len(t, c.orders, 2)
+	assert.Equal(t, "sell", c.orders[0]["side"])
+	assert.Equal(t, "close", c.orders[0]["tradeSide"])
+	assert.Equal(t, "50060.0", c.orders[0]["price"], "priced off the ask")
+	assert.Equal(t, "sell", c.orders[1]["side"])
+	assert.Equal(t, "open", c.orders[1]["tradeSide"])
+	assert.Empty(t, c.orders[0]["reduceOnly"])
+
+	// 0.5 closed at 50060 is 25030 of notional, 0.499 at the bid of 50100
+	assert.Equal(t, 0.499, res.Open.Requested)
+	assert.Equal(t, 0.499, res.Open.Filled)
+}
+
+func TestRoller_Incomplete(t *testing.T) {
+	c := &fakeClient{
+		positions: `[{"symbol":"BTCUSDT_250926","holdSide":"long","total":"1","available":"1"}]`,
+		fills:     map[string][]string{"BTCUSDT_250926": {"0.3"}},
+	}
+	r := newRoller(c, Options{Attempts: 2})
+
+	res, err := r.Roll(context.Background(), "BTCUSDT_250926", "BTCUSDT")
+	require.ErrorIs(t, err, ErrIncomplete)
+	assert.Contains(t, err.Error(), "open BTCUSDT")
+	assert.Equal(t, 0.3, res.Close.Filled)
+	assert.Equal(t, 0.3, res.Open.Requested, "only the closed quantity is reopened")
+	assert.Equal(t, 0.0, res.Open.Filled)
+	assert.Len(t, c.orders, 4)
+
+	c.positions = `[{"symbol":"BTCUSDT_250926","holdSide":"long","total":"0","available":"0"}]`
+	_, err = r.Roll(context.Background(), "BTCUSDT_250926", "BTCUSDT")
+	assert.ErrorIs(t, err, ErrNoPosition)
+}
@@ -0,0 +1,305 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/ws"
+)
+
+// Measure is one of the divergences compared by a BasisMonitor.
+type Measure string
+
+const (
+	MeasureMarkIndex Measure = "mark_index" // (mark - index) / index, the basis
+	MeasureLastMark  Measure = "last_mark"  // (last - mark) / mark
+	MeasureLastIndex Measure = "last_index" // (last - index) / index
+)
+
+// Level is the severity of a divergence.
+type Level string
+
+const (
+	LevelOK       Level = "ok"
+	LevelWarning  Level = "warning"
+	LevelCritical Level = "critical"
+)
+
+// Thresholds are absolute divergences, as fractions. A zero Warning disables the
+// measure; a zero Critical leaves it at the warning level.
+type Thresholds struct {
+	Warning  float64
+	Critical float64
+}
+
+// BasisOptions configures a BasisMonitor.
+type BasisOptions struct {
+	MarkIndex Thresholds
+	LastMark  Thresholds
+	LastIndex Thresholds
+
+	// OnWarning and OnCritical receive alerts when a divergence enters the level.
+	// OnRecovered receives alerts when it drops back below Warning. Optional.
+	OnWarning   func(BasisAlert)
+	OnCritical  func(BasisAlert)
+	OnRecovered func(BasisAlert)
+	// Clock stamps basis samples. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// Divergence holds the prices of a symbol and their relative differences.
+type Divergence struct {
+	Symbol    string
+	Last      float64
+	Mark      float64
+	Index     float64
+	MarkIndex float64
+	LastMark  float64
+	LastIndex float64
+	UpdatedAt time.Time
+}
+
+// Value returns the divergence of measure m.
+func (d Divergence) Value(m Measure) float64 {
+	switch m {
+	case MeasureMarkIndex:
+		return d.MarkIndex
+	case MeasureLastMark:
+		return d.LastMark
+	case MeasureLastIndex:
+		return d.LastIndex
+	}
+	return 0
+}
+
+// BasisAlert reports a measure that changed level.
+type BasisAlert struct {
+	Divergence Divergence
+	Measure    Measure
+	Value      float64
+	Level      Level
+	Previous   Level
+	Time       time.Time
+}
+
+// String returns a one-line summary for notifications.
+func (a BasisAlert) String() string {
+	d := a.Divergence
+	return fmt.Sprintf("basis %s %s %s=%+.3f%% last=%g mark=%g index=%g",
+		a.Level, d.Symbol, a.Measure, a.Value*100, d.Last, d.Mark, d.Index)
+}
+
+// BasisMonitor cross-checks the last, mark and index prices of every symbol it is fed
+// and raises tiered alerts when they diverge. A last price far from mark and index
+// usually means a bad print or a thin book; a wide mark/index basis is a signal in its
+// own right:
+//
+//	m := sanity.NewBasisMonitor(sanity.BasisOptions{
+//		MarkIndex: sanity.Thresholds{Warning: 0.005, Critical: 0.02},
+//		LastMark:  sanity.Thresholds{Warning: 0.01, Critical: 0.03},
+//		OnWarning: func(a sanity.BasisAlert) { notify(a.String()) },
+//	})
+//	wsClient.SubscribeTicker("BTCUSDT", "USDT-FUTURES", m.HandleMessage)
+//	go m.Poll(ctx, client, futures.ProductTypeUSDTFutures, time.Minute, onError)
+//
+// It is safe for concurrent use.
+type BasisMonitor struct {
+	opts  BasisOptions
+	clock common.Clock
+
+	mu      sync.RWMutex
+	symbols map[string]Divergence
+	levels  map[string]Level // Keyed by symbol and measure
+}
+
+// NewBasisMonitor creates a monitor.
+func NewBasisMonitor(opts BasisOptions) *BasisMonitor {
+	return &BasisMonitor{
+		opts:    opts,
+		clock:   common.ClockOrSystem(opts.Clock),
+		symbols: make(map[string]Divergence),
+		levels:  make(map[string]Level),
+	}
+}
+
+// Update ingests an all-tickers snapshot and returns the alerts it triggered.
+func (m *BasisMonitor) Update(tickers []*market.Ticker) []BasisAlert {
+	var alerts []BasisAlert
+	for _, t := range tickers {
+		alerts = append(alerts, m.Observe(t.Symbol, parseFloat(t.LastPr), parseFloat(t.MarkPrice), parseFloat(t.IndexPrice))...)
+	}
+	return alerts
+}
+
+// UpdateWS ingests a ticker from the WebSocket ticker channel.
+func (m *BasisMonitor) UpdateWS(t ws.TickerData) []BasisAlert {
+	symbol := t.InstId
+	if symbol == "" {
+		symbol = t.Symbol
+	}
+	return m.Observe(symbol, parseFloat(t.LastPrice), parseFloat(t.MarkPrice), parseFloat(t.IndexPrice))
+}
+
+// HandleMessage ingests a raw ticker channel message. Its signature matches ws.OnReceive
+// so it can be passed to SubscribeTicker directly.
+func (m *BasisMonitor) HandleMessage(message string) {
+	var msg struct {
+		Arg  ws.SubscriptionArgs `json:"arg"`
+		Data []ws.TickerData     `json:"data"`
+	}
+	if json.Unmarshal([]byte(message), &msg) != nil || msg.Arg.Channel != ws.ChannelTicker {
+		return
+	}
+	for _, t := range msg.Data {
+		m.UpdateWS(t)
+	}
+}
+
+// Poll fetches all tickers every interval and feeds them to the monitor until ctx is
+// cancelled. Request errors are passed to onError when it is non-nil.
+func (m *BasisMonitor) Poll(ctx context.Context, client futures.ClientInterface, productType futures.ProductType, interval time.Duration, onError func(error)) {
+	ticker := m.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		tickers, err := market.NewAllTickersService(client).ProductType(productType).Do(ctx)
+		if err != nil {
+			if onError != nil && ctx.Err() == nil {
+				onError(err)
+			}
+		} else {
+			m.Update(tickers)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// Observe compares the prices of symbol and fires alerts for measures that changed
+// level. Measures involving a missing (zero) price are skipped.
+func (m *BasisMonitor) Observe(symbol string, last, mark, index float64) []BasisAlert {
+	if symbol == "" {
+		return nil
+	}
+	now := m.clock.Now()
+	d := Divergence{
+		Symbol: symbol, Last: last, Mark: mark, Index: index,
+		MarkIndex: ratio(mark, index),
+		LastMark:  ratio(last, mark),
+		LastIndex: ratio(last, index),
+		UpdatedAt: now,
+	}
+
+	var alerts []BasisAlert
+	m.mu.Lock()
+	m.symbols[symbol] = d
+	for _, c := range []struct {
+		measure Measure
+		t       Thresholds
+		ok      bool
+	}{
+		{MeasureMarkIndex, m.opts.MarkIndex, mark > 0 && index > 0},
+		{MeasureLastMark, m.opts.LastMark, last > 0 && mark > 0},
+		{MeasureLastIndex, m.opts.LastIndex, last > 0 && index > 0},
+	} {
+		if c.t.Warning <= 0 || !c.ok {
+			continue
+		}
+		value := d.Value(c.measure)
+		level := c.t.level(value)
+		key := symbol + ":" + string(c.measure)
+		prev, ok := m.levels[key]
+		if !ok {
+			prev = LevelOK
+		}
+		m.levels[key] = level
+		if level != prev {
+			alerts = append(alerts, BasisAlert{Divergence: d, Measure: c.measure, Value: value, Level: level, Previous: prev, Time: now})
+		}
+	}
+	m.mu.Unlock()
+
+	for _, a := range alerts {
+		var fn func(BasisAlert)
+		switch a.Level {
+		case LevelWarning:
+			fn = m.opts.OnWarning
+		case LevelCritical:
+			fn = m.opts.OnCritical
+		default:
+			fn = m.opts.OnRecovered
+		}
+		if fn != nil {
+			fn(a)
+		}
+	}
+	return alerts
+}
+
+// Get returns the latest divergence of symbol.
+func (m *BasisMonitor) Get(symbol string) (Divergence, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	d, ok := m.symbols[symbol]
+	return d, ok
+}
+
+// Widest returns the n symbols with the largest absolute divergence of measure, for
+// use as a signal input. n <= 0 returns all symbols.
+func (m *BasisMonitor) Widest(measure Measure, n int) []Divergence {
+	m.mu.RLock()
+	out := make([]Divergence, 0, len(m.symbols))
+	for _, d := range m.symbols {
+		out = append(out, d)
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		a, b := math.Abs(out[i].Value(measure)), math.Abs(out[j].Value(measure))
+		if a != b {
+			return a > b
+		}
+		return out[i].Symbol < out[j].Symbol
+	})
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+func (t Thresholds) level(value float64) Level {
+	v := math.Abs(value)
+	switch {
+	case t.Critical > 0 && v >= t.Critical:
+		return LevelCritical
+	case v >= t.Warning:
+		return LevelWarning
+	}
+	return LevelOK
+}
+
+// ratio returns a/b - 1, or 0 when either price is missing.
+func ratio(a, b float64) float64 {
+	if a <= 0 || b <= 0 {
+		return 0
+	}
+	return a/b - 1
+}
+
+func parseFloat(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
@@ -0,0 +1,68 @@
+package sanity
+
+import (
+	"testing"
+
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasisMonitor_Levels(t *testing.T) {
+	var warnings, criticals, recovered []BasisAlert
+	m := NewBasisMonitor(BasisOptions{
+		MarkIndex:   Thresholds{Warning: 0.005, Critical: 0.02},
+		LastMark:    Thresholds{Warning: 0.01},
+		OnWarning:   func(a BasisAlert) { warnings = append(warnings, a) },
+		OnCritical:  func(a BasisAlert) { criticals = append(criticals, a) },
+		OnRecovered: func(a BasisAlert) { recovered = append(recovered, a) },
+	})
+
+	assert.Empty(t, m.Observe("BTCUSDT", 100, 100.1, 100))
+
+	alerts := m.Observe("BTCUSDT", 100, 101, 100)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, MeasureMarkIndex, alerts[0].Measure)
+	assert.Equal(t, LevelWarning, alerts[0].Level)
+	assert.InDelta(t, 0.01, alerts[0].Value, 1e-9)
+
+	// Unchanged levels do not repeat alerts
+	assert.Empty(t, m.Observe("BTCUSDT", 100.5, 101, 100))
+
+	alerts = m.Observe("BTCUSDT", 95, 97, 100)
+	require.Len(t, alerts, 2)
+	assert.Equal(t, LevelCritical, alerts[0].Level)
+	assert.InDelta(t, -0.03, alerts[0].Value, 1e-9)
+	assert.Equal(t, MeasureLastMark, alerts[1].Measure)
+
+	m.Observe("BTCUSDT", 100, 100, 100)
+	assert.Len(t, warnings, 2)
+	assert.Len(t, criticals, 1)
+	require.Len(t, recovered, 2)
+	assert.Equal(t, LevelCritical, recovered[0].Previous)
+}
+
+func TestBasisMonitor_MissingPrices(t *testing.T) {
+	m := NewBasisMonitor(BasisOptions{LastIndex: Thresholds{Warning: 0.01}})
+	assert.Empty(t, m.Observe("BTCUSDT", 100, 0, 0))
+
+	d, ok := m.Get("BTCUSDT")
+	require.True(t, ok)
+	assert.Zero(t, d.LastIndex)
+}
+
+func TestBasisMonitor_Feeds(t *testing.T) {
+	m := NewBasisMonitor(BasisOptions{MarkIndex: Thresholds{Warning: 0.01}})
+
+	m.Update([]*market.Ticker{
+		{Symbol: "BTCUSDT", LastPr: "100", MarkPrice: "100", IndexPrice: "100"},
+		{Symbol: "ETHUSDT", LastPr: "10", MarkPrice: "10.3", IndexPrice: "10"},
+	})
+	m.HandleMessage(`{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"ticker","instId":"SOLUSDT"},"data":[{"instId":"SOLUSDT","lastPr":"20","markPrice":"19.8","indexPrice":"20"}]}`)
+
+	widest := m.Widest(MeasureMarkIndex, 2)
+	require.Len(t, widest, 2)
+	assert.Equal(t, "ETHUSDT", widest[0].Symbol)
+	assert.Equal(t, "SOLUSDT", widest[1].Symbol)
+	assert.InDelta(t, -0.01, widest[1].MarkIndex, 1e-9)
+}
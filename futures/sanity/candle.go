@@ -0,0 +1,391 @@
+package sanity
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures/candles"
+	"github.com/khanbekov/go-bitget/ws"
+)
+
+const (
+	IssueCloseMismatch Issue = "close_mismatch" // Closed candle differs from the last ticker print of its period
+	IssueMissedPrint   Issue = "missed_print"   // Ticker print outside the high/low range of its candle
+	IssueStaleCandle   Issue = "stale_candle"   // No new candle after the period ended while the ticker printed
+)
+
+// CandleOptions configures a CandleValidator.
+type CandleOptions struct {
+	// Tolerance is the largest accepted difference between a candle and the ticker, as a
+	// fraction. Defaults to 0.0005.
+	Tolerance float64
+	// StaleAfter is how long past the end of its period a candle may remain the latest
+	// while the ticker keeps printing. Defaults to 10s.
+	StaleAfter time.Duration
+
+	// OnEvent is called for every discrepancy, outside the validator lock. Optional.
+	OnEvent func(Event)
+	// OnCheck is called for every closed candle compared with the ticker, e.g. to export
+	// the deviation as a metric. Optional.
+	OnCheck func(CandleCheck)
+	// Clock decides when a candle is stale and stamps events. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// CandleCheck is the comparison of a closed candle with the ticker prints of its period.
+type CandleCheck struct {
+	Symbol      string
+	Granularity string
+	Start       time.Time
+	Close       float64
+	// Last is the last ticker print of the period; Low and High are the extremes of all
+	// prints, zero when the validator saw only part of the period.
+	Last      float64
+	Low       float64
+	High      float64
+	Deviation float64 // Close / Last - 1
+	Issues    []Issue
+}
+
+// CandleMetrics are the data-quality metrics of a CandleValidator.
+type CandleMetrics struct {
+	// Checked is the number of closed candles compared with the ticker; Unchecked the
+	// number closed without a ticker print in their period.
+	Checked   int
+	Unchecked int
+	Counts    map[Issue]int
+	// MeanDeviation and MaxDeviation are the mean and largest absolute close deviation
+	// of checked candles, as fractions.
+	MeanDeviation float64
+	MaxDeviation  float64
+}
+
+// printBucket collects the ticker prints of one candle period.
+type printBucket struct {
+	start     time.Time
+	partial   bool // Created after the period started
+	low, high float64
+	last      float64
+	lastAt    time.Time
+	n         int
+}
+
+type candleStream struct {
+	symbol      string
+	granularity string
+	period      time.Duration
+	since       time.Time     // When the validator started following the stream
+	current     common.Candle // Latest update of the newest candle
+	buckets     []*printBucket
+	stale       bool
+}
+
+func (s *candleStream) start() time.Time {
+	return time.UnixMilli(s.current.CloseTime)
+}
+
+// CandleValidator cross-checks candles against the ticker of the same symbol. When a
+// candle closes, its close is compared with the last ticker print of its period and its
+// high/low range with every print; a candle that does not roll over after its period
+// ended while the ticker keeps printing is stale:
+//
+//	v := sanity.NewCandleValidator(sanity.CandleOptions{
+//		OnEvent: func(e sanity.Event) { log.Printf("data quality: %s", e) },
+//	})
+//	wsClient.SubscribeTicker("BTCUSDT", "USDT-FUTURES", v.HandleMessage)
+//	wsClient.SubscribeCandles("BTCUSDT", "USDT-FUTURES", "1m", v.HandleMessage)
+//
+// Events carry the candle price as Price and the ticker price as Reference. A candle is
+// considered closed when the next one arrives, so the last update received is what is
+// checked: a missed final update shows up as a close mismatch. It is safe for concurrent
+// use.
+type CandleValidator struct {
+	opts  CandleOptions
+	clock common.Clock
+
+	mu       sync.Mutex
+	streams  map[string][]*candleStream // Keyed by symbol
+	metrics  CandleMetrics
+	deviance float64 // Sum of absolute deviations
+}
+
+// NewCandleValidator creates a validator.
+func NewCandleValidator(opts CandleOptions) *CandleValidator {
+	if opts.Tolerance <= 0 {
+		opts.Tolerance = 0.0005
+	}
+	if opts.StaleAfter <= 0 {
+		opts.StaleAfter = 10 * time.Second
+	}
+	return &CandleValidator{
+		opts:    opts,
+		clock:   common.ClockOrSystem(opts.Clock),
+		streams: make(map[string][]*candleStream),
+		metrics: CandleMetrics{Counts: make(map[Issue]int)},
+	}
+}
+
+// HandleMessage ingests a raw ticker or candle channel message. Its signature matches
+// ws.OnReceive so it can be passed to SubscribeTicker and SubscribeCandles directly.
+func (v *CandleValidator) HandleMessage(message string) {
+	var msg ws.WebSocketMessage
+	if json.Unmarshal([]byte(message), &msg) != nil || len(msg.Data) == 0 {
+		return
+	}
+	switch channel := msg.Arg.Channel; {
+	case channel == ws.ChannelTicker:
+		var tickers []ws.TickerData
+		if json.Unmarshal(msg.Data, &tickers) != nil {
+			return
+		}
+		for _, t := range tickers {
+			symbol := t.InstId
+			if symbol == "" {
+				symbol = t.Symbol
+			}
+			at := v.clock.Now()
+			if ms, err := strconv.ParseInt(t.Timestamp, 10, 64); err == nil && ms > 0 {
+				at = time.UnixMilli(ms)
+			}
+			v.ObserveTicker(symbol, parseFloat(t.LastPrice), at)
+		}
+	case strings.HasPrefix(channel, ws.ChannelCandle):
+		var rows []ws.CandlestickData
+		if json.Unmarshal(msg.Data, &rows) != nil {
+			return
+		}
+		granularity := strings.TrimPrefix(channel, ws.ChannelCandle)
+		for _, c := range rows {
+			v.ObserveCandle(msg.Arg.Symbol, granularity, common.Candle{
+				CloseTime: c.TimestampDate.UnixMilli(),
+				Open:      c.OpenFloat,
+				High:      c.HighFloat,
+				Low:       c.LowFloat,
+				Close:     c.CloseFloat,
+			})
+		}
+	}
+}
+
+// ObserveTicker records a ticker print of symbol at time at, usually the exchange
+// timestamp of the ticker, and returns the stale candle events it triggered. Invalid
+// prices are ignored.
+func (v *CandleValidator) ObserveTicker(symbol string, price float64, at time.Time) []Event {
+	if !valid(price) {
+		return nil
+	}
+	v.mu.Lock()
+	var events []Event
+	for _, s := range v.streams[symbol] {
+		if b := s.bucket(at); b != nil {
+			b.add(price, at)
+		}
+		if !s.stale && at.Sub(s.start().Add(s.period)) > v.opts.StaleAfter {
+			s.stale = true
+			events = append(events, v.event(IssueStaleCandle, s, s.current.Close, price))
+		}
+	}
+	v.mu.Unlock()
+	v.emit(events, nil)
+	return events
+}
+
+// ObserveCandle records an update of the latest candle of symbol, from the candle
+// channel or the REST candle endpoints. A candle with a later start closes the previous
+// one, which is then checked against the ticker. Returns the discrepancies found.
+func (v *CandleValidator) ObserveCandle(symbol, granularity string, c common.Candle) []Event {
+	v.mu.Lock()
+	s := v.stream(symbol, granularity)
+	if s == nil {
+		v.mu.Unlock()
+		return nil
+	}
+	start := c.Time()
+	var (
+		events []Event
+		check  *CandleCheck
+	)
+	switch {
+	case s.current.CloseTime == 0:
+		s.current = c
+		s.buckets = []*printBucket{s.newBucket(start)}
+	case start.Before(s.start()):
+		// History, e.g. the older rows of a snapshot
+	case start.Equal(s.start()):
+		s.current = c
+	default:
+		check, events = v.close(s)
+		s.current, s.stale = c, false
+		kept := s.buckets[:0]
+		for _, b := range s.buckets {
+			if !b.start.Before(start) {
+				kept = append(kept, b)
+			}
+		}
+		s.buckets = kept
+		if len(s.buckets) == 0 || !s.buckets[0].start.Equal(start) {
+			s.buckets = append([]*printBucket{s.newBucket(start)}, s.buckets...)
+		}
+	}
+	v.mu.Unlock()
+	v.emit(events, check)
+	return events
+}
+
+// Stale reports whether the candles of symbol and granularity are stale.
+func (v *CandleValidator) Stale(symbol, granularity string) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, s := range v.streams[symbol] {
+		if s.granularity == granularity {
+			return s.stale
+		}
+	}
+	return false
+}
+
+// Metrics returns the data-quality metrics accumulated so far.
+func (v *CandleValidator) Metrics() CandleMetrics {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	m := v.metrics
+	m.Counts = make(map[Issue]int, len(v.metrics.Counts))
+	for issue, n := range v.metrics.Counts {
+		m.Counts[issue] = n
+	}
+	if m.Checked > 0 {
+		m.MeanDeviation = v.deviance / float64(m.Checked)
+	}
+	return m
+}
+
+// close checks the current candle of s against the prints of its period. Callers hold
+// v.mu.
+func (v *CandleValidator) close(s *candleStream) (*CandleCheck, []Event) {
+	var b *printBucket
+	for _, candidate := range s.buckets {
+		if candidate.start.Equal(s.start()) {
+			b = candidate
+		}
+	}
+	if b == nil || !s.start().Add(s.period).After(s.since) {
+		return nil, nil
+	}
+	if b.n == 0 {
+		v.metrics.Unchecked++
+		return nil, nil
+	}
+
+	c := s.current
+	check := &CandleCheck{
+		Symbol: s.symbol, Granularity: s.granularity, Start: b.start,
+		Close: c.Close, Last: b.last, Deviation: c.Close/b.last - 1,
+	}
+	var events []Event
+	if math.Abs(check.Deviation) > v.opts.Tolerance {
+		events = append(events, v.event(IssueCloseMismatch, s, c.Close, b.last))
+	}
+	if !b.partial {
+		check.Low, check.High = b.low, b.high
+		switch {
+		case b.high > c.High*(1+v.opts.Tolerance):
+			events = append(events, v.event(IssueMissedPrint, s, c.High, b.high))
+		case b.low < c.Low*(1-v.opts.Tolerance):
+			events = append(events, v.event(IssueMissedPrint, s, c.Low, b.low))
+		}
+	}
+	for _, e := range events {
+		check.Issues = append(check.Issues, e.Issue)
+	}
+
+	dev := math.Abs(check.Deviation)
+	v.metrics.Checked++
+	v.deviance += dev
+	v.metrics.MaxDeviation = max(v.metrics.MaxDeviation, dev)
+	return check, events
+}
+
+// event builds an event and counts it. Callers hold v.mu.
+func (v *CandleValidator) event(issue Issue, s *candleStream, price, reference float64) Event {
+	v.metrics.Counts[issue]++
+	return Event{
+		Issue: issue, Symbol: s.symbol, Channel: ws.ChannelCandle + s.granularity,
+		Price: price, Reference: reference, Time: v.clock.Now(),
+	}
+}
+
+func (v *CandleValidator) emit(events []Event, check *CandleCheck) {
+	if v.opts.OnEvent != nil {
+		for _, e := range events {
+			v.opts.OnEvent(e)
+		}
+	}
+	if check != nil && v.opts.OnCheck != nil {
+		v.opts.OnCheck(*check)
+	}
+}
+
+// stream returns the stream of symbol and granularity, or nil when the granularity has
+// no fixed length. Callers hold v.mu.
+func (v *CandleValidator) stream(symbol, granularity string) *candleStream {
+	for _, s := range v.streams[symbol] {
+		if s.granularity == granularity {
+			return s
+		}
+	}
+	period, err := candles.GranularityDuration(granularity)
+	if err != nil || symbol == "" {
+		return nil
+	}
+	s := &candleStream{symbol: symbol, granularity: granularity, period: period, since: v.clock.Now()}
+	v.streams[symbol] = append(v.streams[symbol], s)
+	return s
+}
+
+// maxBuckets bounds the periods kept for a stream whose candles stopped arriving.
+const maxBuckets = 4
+
+// bucket returns the bucket of the period containing at, opening periods after the
+// newest one as needed, or nil when at precedes the tracked periods.
+func (s *candleStream) bucket(at time.Time) *printBucket {
+	if len(s.buckets) == 0 || at.Before(s.buckets[0].start) {
+		return nil
+	}
+	last := s.buckets[len(s.buckets)-1]
+	if end := last.start.Add(s.period); !at.Before(end) {
+		start := end.Add(at.Sub(end) / s.period * s.period)
+		last = s.newBucket(start)
+		s.buckets = append(s.buckets, last)
+		if len(s.buckets) > maxBuckets {
+			s.buckets = s.buckets[len(s.buckets)-maxBuckets:]
+		}
+		return last
+	}
+	for i := len(s.buckets) - 1; i >= 0; i-- {
+		if !at.Before(s.buckets[i].start) {
+			return s.buckets[i]
+		}
+	}
+	return nil
+}
+
+func (s *candleStream) newBucket(start time.Time) *printBucket {
+	return &printBucket{start: start, partial: s.since.After(start)}
+}
+
+func (b *printBucket) add(price float64, at time.Time) {
+	if b.n == 0 {
+		b.low, b.high = price, price
+	} else {
+		b.low, b.high = min(b.low, price), max(b.high, price)
+	}
+	if !at.Before(b.lastAt) {
+		b.last, b.lastAt = price, at
+	}
+	b.n++
+}
@@ -0,0 +1,121 @@
+package sanity
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var t0 = time.UnixMilli(1700000040000).UTC() // Start of a minute
+
+func bar(start time.Time, open, high, low, close float64) common.Candle {
+	return common.Candle{CloseTime: start.UnixMilli(), Open: open, High: high, Low: low, Close: close}
+}
+
+func newCandleValidator(opts CandleOptions) *CandleValidator {
+	opts.Clock = common.NewFakeClock(t0.Add(5 * time.Second))
+	return NewCandleValidator(opts)
+}
+
+func TestCandleValidator_ConsistentCandles(t *testing.T) {
+	var checks []CandleCheck
+	v := newCandleValidator(CandleOptions{OnCheck: func(c CandleCheck) { checks = append(checks, c) }})
+	t1 := t0.Add(time.Minute)
+
+	assert.Empty(t, v.ObserveCandle("BTCUSDT", "1m", bar(t0, 100, 100, 100, 100)))
+	v.ObserveTicker("BTCUSDT", 100, t0.Add(10*time.Second))
+	v.ObserveTicker("BTCUSDT", 101, t0.Add(50*time.Second))
+	v.ObserveCandle("BTCUSDT", "1m", bar(t0, 100, 101, 100, 101))
+
+	v.ObserveTicker("BTCUSDT", 101, t1.Add(time.Second))
+	assert.Empty(t, v.ObserveCandle("BTCUSDT", "1m", bar(t1, 101, 101, 101, 101)))
+	v.ObserveTicker("BTCUSDT", 103, t1.Add(30*time.Second))
+	v.ObserveTicker("BTCUSDT", 102, t1.Add(59*time.Second))
+	v.ObserveCandle("BTCUSDT", "1m", bar(t1, 101, 103, 101, 102))
+	assert.Empty(t, v.ObserveCandle("BTCUSDT", "1m", bar(t1.Add(time.Minute), 102, 102, 102, 102)))
+
+	require.Len(t, checks, 2)
+	assert.Equal(t, t0, checks[0].Start)
+	assert.Equal(t, 101.0, checks[0].Last)
+	assert.Zero(t, checks[0].High, "the first period was only partly observed")
+	assert.Equal(t, 101.0, checks[1].Low)
+	assert.Equal(t, 103.0, checks[1].High)
+	assert.Empty(t, checks[1].Issues)
+
+	m := v.Metrics()
+	assert.Equal(t, 2, m.Checked)
+	assert.Zero(t, m.MaxDeviation)
+	assert.Empty(t, m.Counts)
+}
+
+func TestCandleValidator_MissedUpdates(t *testing.T) {
+	var events []Event
+	v := newCandleValidator(CandleOptions{OnEvent: func(e Event) { events = append(events, e) }})
+	t1 := t0.Add(time.Minute)
+
+	v.ObserveCandle("BTCUSDT", "1m", bar(t0, 100, 100, 100, 100))
+	v.ObserveCandle("BTCUSDT", "1m", bar(t1, 100, 100, 100, 100))
+	v.ObserveTicker("BTCUSDT", 101, t1.Add(10*time.Second))
+	v.ObserveTicker("BTCUSDT", 103, t1.Add(30*time.Second))
+	v.ObserveTicker("BTCUSDT", 102.5, t1.Add(58*time.Second))
+	// The updates after the first print never arrived
+	v.ObserveCandle("BTCUSDT", "1m", bar(t1, 100, 101, 100, 101))
+
+	got := v.ObserveCandle("BTCUSDT", "1m", bar(t1.Add(time.Minute), 102.5, 102.5, 102.5, 102.5))
+	require.Len(t, got, 2)
+	assert.Equal(t, got, events)
+	assert.Equal(t, IssueCloseMismatch, got[0].Issue)
+	assert.Equal(t, 101.0, got[0].Price)
+	assert.Equal(t, 102.5, got[0].Reference)
+	assert.Equal(t, "candle1m", got[0].Channel)
+	assert.Equal(t, IssueMissedPrint, got[1].Issue)
+	assert.Equal(t, 103.0, got[1].Reference)
+
+	m := v.Metrics()
+	assert.Equal(t, 1, m.Checked)
+	assert.Equal(t, 1, m.Unchecked, "no prints in the first period")
+	assert.Equal(t, map[Issue]int{IssueCloseMismatch: 1, IssueMissedPrint: 1}, m.Counts)
+	assert.InDelta(t, 1.5/102.5, m.MaxDeviation, 1e-9)
+	assert.InDelta(t, m.MaxDeviation, m.MeanDeviation, 1e-9)
+}
+
+func TestCandleValidator_StaleCandle(t *testing.T) {
+	v := newCandleValidator(CandleOptions{})
+	v.ObserveCandle("BTCUSDT", "1m", bar(t0, 100, 100, 100, 100))
+
+	assert.Empty(t, v.ObserveTicker("BTCUSDT", 100, t0.Add(65*time.Second)))
+	events := v.ObserveTicker("BTCUSDT", 100.2, t0.Add(71*time.Second))
+	require.Len(t, events, 1)
+	assert.Equal(t, IssueStaleCandle, events[0].Issue)
+	assert.Equal(t, 100.2, events[0].Reference)
+	assert.True(t, v.Stale("BTCUSDT", "1m"))
+	assert.Empty(t, v.ObserveTicker("BTCUSDT", 100.1, t0.Add(90*time.Second)), "reported once")
+
+	v.ObserveCandle("BTCUSDT", "1m", bar(t0.Add(time.Minute), 100, 100.2, 100, 100.1))
+	assert.False(t, v.Stale("BTCUSDT", "1m"))
+	assert.False(t, v.Stale("ETHUSDT", "1m"))
+}
+
+func TestCandleValidator_HandleMessage(t *testing.T) {
+	var checks []CandleCheck
+	v := newCandleValidator(CandleOptions{OnCheck: func(c CandleCheck) { checks = append(checks, c) }})
+	ticker := func(last string, at time.Time) string {
+		return fmt.Sprintf(`{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"ticker","instId":"BTCUSDT"},"data":[{"instId":"BTCUSDT","lastPr":%q,"ts":"%d"}],"ts":1}`, last, at.UnixMilli())
+	}
+	ms := func(at time.Time) string { return fmt.Sprint(at.UnixMilli()) }
+	t1 := t0.Add(time.Minute)
+
+	// Snapshot history before the current candle is ignored
+	v.HandleMessage(candleMsg("snapshot", [5]string{ms(t0.Add(-time.Minute)), "99", "99", "99", "99"}, [5]string{ms(t0), "100", "100", "100", "100"}))
+	v.HandleMessage(ticker("100.5", t0.Add(20*time.Second)))
+	v.HandleMessage(candleMsg("update", [5]string{ms(t1), "100.5", "100.5", "100.5", "100.5"}))
+
+	require.Len(t, checks, 1)
+	assert.Equal(t, "1m", checks[0].Granularity)
+	assert.Equal(t, []Issue{IssueCloseMismatch}, checks[0].Issues, "the close was never updated")
+	assert.Equal(t, 1, v.Metrics().Counts[IssueCloseMismatch])
+}
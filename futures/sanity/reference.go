@@ -0,0 +1,320 @@
+package sanity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+)
+
+// PriceReference supplies a reference price for a symbol. Implementations for other
+// exchanges or aggregators are provided by the user, usually through ReferenceFunc;
+// they receive Bitget symbols and map them to their own.
+type PriceReference interface {
+	// Name identifies the reference in alerts, e.g. "binance".
+	Name() string
+	// Price returns the current reference price of symbol.
+	Price(ctx context.Context, symbol string) (float64, error)
+}
+
+// PriceField selects the price of a Bitget ticker.
+type PriceField string
+
+const (
+	FieldLast  PriceField = "last"
+	FieldMark  PriceField = "mark"
+	FieldIndex PriceField = "index"
+)
+
+// BitgetReference implements PriceReference with the Bitget futures ticker.
+type BitgetReference struct {
+	Client      futures.ClientInterface
+	ProductType futures.ProductType
+	// Field defaults to FieldLast.
+	Field PriceField
+}
+
+// Name implements PriceReference.
+func (r BitgetReference) Name() string {
+	if r.Field == "" || r.Field == FieldLast {
+		return "bitget"
+	}
+	return "bitget-" + string(r.Field)
+}
+
+// Price implements PriceReference.
+func (r BitgetReference) Price(ctx context.Context, symbol string) (float64, error) {
+	t, err := market.NewTickerService(r.Client).Symbol(symbol).ProductType(string(r.ProductType)).Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	price := t.LastPr
+	switch r.Field {
+	case FieldMark:
+		price = t.MarkPrice
+	case FieldIndex:
+		price = t.IndexPrice
+	}
+	if v := parseFloat(price); v > 0 {
+		return v, nil
+	}
+	return 0, fmt.Errorf("no %s price for %s", r.Name(), symbol)
+}
+
+// funcReference is returned by ReferenceFunc.
+type funcReference struct {
+	name string
+	fn   func(ctx context.Context, symbol string) (float64, error)
+}
+
+func (r funcReference) Name() string { return r.name }
+
+func (r funcReference) Price(ctx context.Context, symbol string) (float64, error) {
+	return r.fn(ctx, symbol)
+}
+
+// ReferenceFunc adapts a function, such as a client of another exchange, to a
+// PriceReference.
+func ReferenceFunc(name string, fn func(ctx context.Context, symbol string) (float64, error)) PriceReference {
+	return funcReference{name: name, fn: fn}
+}
+
+// medianReference is returned by MedianReference.
+type medianReference struct {
+	name string
+	refs []PriceReference
+}
+
+// MedianReference combines several references into their median, so a single venue
+// with a bad print or an outage does not move the reference. It fails only when every
+// reference fails.
+func MedianReference(name string, refs ...PriceReference) PriceReference {
+	return medianReference{name: name, refs: refs}
+}
+
+func (r medianReference) Name() string { return r.name }
+
+func (r medianReference) Price(ctx context.Context, symbol string) (float64, error) {
+	prices := make([]float64, 0, len(r.refs))
+	var errs []error
+	for _, ref := range r.refs {
+		p, err := ref.Price(ctx, symbol)
+		if err != nil || p <= 0 {
+			if err == nil {
+				err = errors.New("no price")
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", ref.Name(), err))
+			continue
+		}
+		prices = append(prices, p)
+	}
+	if len(prices) == 0 {
+		return 0, errors.Join(errs...)
+	}
+	sort.Float64s(prices)
+	n := len(prices)
+	if n%2 == 1 {
+		return prices[n/2], nil
+	}
+	return (prices[n/2-1] + prices[n/2]) / 2, nil
+}
+
+// Deviation is the divergence of a Bitget price from its reference.
+type Deviation struct {
+	Symbol    string
+	Source    string // Name of the reference
+	Price     float64
+	Reference float64
+	// Value is price / reference - 1.
+	Value     float64
+	UpdatedAt time.Time
+}
+
+// DeviationAlert reports a symbol whose deviation changed level.
+type DeviationAlert struct {
+	Deviation Deviation
+	Level     Level
+	Previous  Level
+	Time      time.Time
+}
+
+// String returns a one-line summary for notifications.
+func (a DeviationAlert) String() string {
+	d := a.Deviation
+	return fmt.Sprintf("deviation %s %s %+.3f%% price=%g %s=%g",
+		a.Level, d.Symbol, d.Value*100, d.Price, d.Source, d.Reference)
+}
+
+// DeviationOptions configures a DeviationMonitor.
+type DeviationOptions struct {
+	// Thresholds are absolute deviations, as fractions. Warning is required.
+	Thresholds Thresholds
+	// MaxAge is how long a deviation is trusted by Deviating. Defaults to one minute.
+	MaxAge time.Duration
+
+	// OnWarning and OnCritical receive alerts when a deviation enters the level.
+	// OnRecovered receives alerts when it drops back below Warning. Optional.
+	OnWarning   func(DeviationAlert)
+	OnCritical  func(DeviationAlert)
+	OnRecovered func(DeviationAlert)
+	// Clock ages reference prices against MaxAge. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// DeviationMonitor compares Bitget prices with a reference and alerts when they
+// diverge. Stop logic can consult Deviating before acting on a Bitget price, so a wick
+// that no other venue printed does not trigger it:
+//
+//	binance := sanity.ReferenceFunc("binance", binanceLastPrice)
+//	m := sanity.NewDeviationMonitor(binance, sanity.DeviationOptions{
+//		Thresholds: sanity.Thresholds{Warning: 0.003, Critical: 0.01},
+//		OnCritical: func(a sanity.DeviationAlert) { notify(a.String()) },
+//	})
+//	go m.Poll(ctx, sanity.BitgetReference{Client: client, ProductType: futures.ProductTypeUSDTFutures},
+//		[]string{"BTCUSDT", "ETHUSDT"}, 5*time.Second, onError)
+//
+//	if price <= stop && !m.Deviating("BTCUSDT") {
+//		closePosition()
+//	}
+//
+// It is safe for concurrent use.
+type DeviationMonitor struct {
+	ref   PriceReference
+	opts  DeviationOptions
+	clock common.Clock
+
+	mu      sync.RWMutex
+	symbols map[string]Deviation
+	levels  map[string]Level
+}
+
+// NewDeviationMonitor creates a monitor against ref.
+func NewDeviationMonitor(ref PriceReference, opts DeviationOptions) *DeviationMonitor {
+	if opts.MaxAge <= 0 {
+		opts.MaxAge = time.Minute
+	}
+	return &DeviationMonitor{
+		ref:     ref,
+		opts:    opts,
+		clock:   common.ClockOrSystem(opts.Clock),
+		symbols: make(map[string]Deviation),
+		levels:  make(map[string]Level),
+	}
+}
+
+// Check fetches the reference price of symbol and compares price, usually the latest
+// Bitget price from a ticker stream, with it.
+func (m *DeviationMonitor) Check(ctx context.Context, symbol string, price float64) (Deviation, error) {
+	ref, err := m.ref.Price(ctx, symbol)
+	if err != nil {
+		return Deviation{}, fmt.Errorf("sanity: %s reference for %s: %w", m.ref.Name(), symbol, err)
+	}
+	d, _ := m.Observe(symbol, price, ref)
+	return d, nil
+}
+
+// Poll compares the prices of symbols from bitget with the reference every interval
+// until ctx is cancelled. Errors are passed to onError when it is non-nil.
+func (m *DeviationMonitor) Poll(ctx context.Context, bitget PriceReference, symbols []string, interval time.Duration, onError func(error)) {
+	ticker := m.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, symbol := range symbols {
+			price, err := bitget.Price(ctx, symbol)
+			if err == nil {
+				_, err = m.Check(ctx, symbol, price)
+			}
+			if err != nil && onError != nil && ctx.Err() == nil {
+				onError(err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// Observe compares price with reference and fires an alert when the level of symbol
+// changed. Missing (zero) prices are ignored.
+func (m *DeviationMonitor) Observe(symbol string, price, reference float64) (Deviation, *DeviationAlert) {
+	if symbol == "" || price <= 0 || reference <= 0 {
+		return Deviation{}, nil
+	}
+	now := m.clock.Now()
+	d := Deviation{
+		Symbol:    symbol,
+		Source:    m.ref.Name(),
+		Price:     price,
+		Reference: reference,
+		Value:     ratio(price, reference),
+		UpdatedAt: now,
+	}
+	level := m.opts.Thresholds.level(d.Value)
+
+	m.mu.Lock()
+	m.symbols[symbol] = d
+	prev, ok := m.levels[symbol]
+	if !ok {
+		prev = LevelOK
+	}
+	m.levels[symbol] = level
+	m.mu.Unlock()
+
+	if level == prev {
+		return d, nil
+	}
+	alert := &DeviationAlert{Deviation: d, Level: level, Previous: prev, Time: now}
+	var fn func(DeviationAlert)
+	switch level {
+	case LevelWarning:
+		fn = m.opts.OnWarning
+	case LevelCritical:
+		fn = m.opts.OnCritical
+	default:
+		fn = m.opts.OnRecovered
+	}
+	if fn != nil {
+		fn(*alert)
+	}
+	return d, alert
+}
+
+// Get returns the latest deviation of symbol.
+func (m *DeviationMonitor) Get(symbol string) (Deviation, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	d, ok := m.symbols[symbol]
+	return d, ok
+}
+
+// Level returns the current level of symbol, LevelOK when it was never observed.
+func (m *DeviationMonitor) Level(symbol string) Level {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if level, ok := m.levels[symbol]; ok {
+		return level
+	}
+	return LevelOK
+}
+
+// Deviating reports whether the latest deviation of symbol is at the warning level or
+// above and no older than MaxAge.
+func (m *DeviationMonitor) Deviating(symbol string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	d, ok := m.symbols[symbol]
+	if !ok || m.clock.Now().Sub(d.UpdatedAt) > m.opts.MaxAge {
+		return false
+	}
+	return m.levels[symbol] != LevelOK
+}
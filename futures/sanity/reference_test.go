@@ -0,0 +1,100 @@
+package sanity
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type tickerClient struct{}
+
+func (tickerClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	if endpoint != futures.EndpointTicker {
+		return nil, nil, errors.New("unexpected endpoint " + endpoint)
+	}
+	data := `[{"symbol":"BTCUSDT","lastPr":"50000","markPrice":"50010","indexPrice":"50020"}]`
+	return &futures.ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil
+}
+
+func fixed(name string, price float64, err error) PriceReference {
+	return ReferenceFunc(name, func(ctx context.Context, symbol string) (float64, error) { return price, err })
+}
+
+func TestBitgetReference(t *testing.T) {
+	ctx := context.Background()
+	last, err := BitgetReference{Client: tickerClient{}, ProductType: futures.ProductTypeUSDTFutures}.Price(ctx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, 50000.0, last)
+
+	ref := BitgetReference{Client: tickerClient{}, ProductType: futures.ProductTypeUSDTFutures, Field: FieldIndex}
+	index, err := ref.Price(ctx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, 50020.0, index)
+	assert.Equal(t, "bitget-index", ref.Name())
+}
+
+func TestMedianReference(t *testing.T) {
+	ctx := context.Background()
+	ref := MedianReference("external", fixed("a", 100, nil), fixed("b", 130, nil), fixed("c", 101, nil), fixed("d", 0, errors.New("down")))
+	price, err := ref.Price(ctx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, 101.0, price, "the outlier and the failed venue are ignored")
+
+	price, err = MedianReference("two", fixed("a", 100, nil), fixed("b", 102, nil)).Price(ctx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, 101.0, price)
+
+	_, err = MedianReference("none", fixed("a", 0, errors.New("down"))).Price(ctx, "BTCUSDT")
+	assert.ErrorContains(t, err, "a: down")
+}
+
+func TestDeviationMonitor(t *testing.T) {
+	var warnings, criticals, recovered []DeviationAlert
+	reference := 100.0
+	ref := ReferenceFunc("binance", func(ctx context.Context, symbol string) (float64, error) { return reference, nil })
+	m := NewDeviationMonitor(ref, DeviationOptions{
+		Thresholds:  Thresholds{Warning: 0.005, Critical: 0.02},
+		OnWarning:   func(a DeviationAlert) { warnings = append(warnings, a) },
+		OnCritical:  func(a DeviationAlert) { criticals = append(criticals, a) },
+		OnRecovered: func(a DeviationAlert) { recovered = append(recovered, a) },
+	})
+	clock := common.NewFakeClock(time.UnixMilli(1700000000000))
+	m.clock = clock
+	ctx := context.Background()
+
+	d, err := m.Check(ctx, "BTCUSDT", 100.2)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.002, d.Value, 1e-9)
+	assert.False(t, m.Deviating("BTCUSDT"))
+
+	// A wick on Bitget that the reference did not print
+	_, err = m.Check(ctx, "BTCUSDT", 97)
+	require.NoError(t, err)
+	require.Len(t, criticals, 1)
+	assert.Equal(t, "deviation critical BTCUSDT -3.000% price=97 binance=100", criticals[0].String())
+	assert.True(t, m.Deviating("BTCUSDT"))
+	assert.Equal(t, LevelCritical, m.Level("BTCUSDT"))
+
+	_, alert := m.Observe("BTCUSDT", 97.1, 97)
+	require.NotNil(t, alert)
+	assert.Equal(t, LevelOK, alert.Level)
+	assert.Len(t, recovered, 1)
+	assert.False(t, m.Deviating("BTCUSDT"))
+
+	m.Observe("BTCUSDT", 98, 97)
+	assert.Len(t, warnings, 1)
+	clock.Advance(2 * time.Minute)
+	assert.False(t, m.Deviating("BTCUSDT"), "stale deviations are not trusted")
+
+	reference = 0
+	_, err = m.Check(ctx, "BTCUSDT", 98)
+	assert.NoError(t, err, "a missing reference price is ignored")
+}
@@ -0,0 +1,368 @@
+// Package sanity checks ticker and candle streams for obviously bad prints before
+// strategies act on them.
+//
+// A Filter sits between the WebSocket client and the handlers:
+//
+//	filter := sanity.New(sanity.Options{
+//		MaxJump: 0.05, Mode: sanity.ModeDrop,
+//		OnEvent: func(e sanity.Event) { log.Printf("data quality: %s", e) },
+//	})
+//	wsClient.SubscribeOrderBook5("BTCUSDT", "USDT-FUTURES", filter.Wrap(dc.HandleMessage))
+//	wsClient.SubscribeTicker("BTCUSDT", "USDT-FUTURES", filter.Wrap(dc.HandleMessage))
+//
+// Zero, negative and unparsable prices, crossed bid/ask and candles whose open or close
+// lies outside their high/low range are always bad. A price that jumps more than MaxJump
+// from the previous good price is bad unless the latest order book, fed through the same
+// filter, confirms it; without a book, Confirm consecutive prints at the new level accept
+// it, so a genuine gap is not dropped forever.
+//
+// A BasisMonitor cross-checks last, mark and index prices and alerts on divergence.
+// A DeviationMonitor compares Bitget prices with a PriceReference, such as another
+// exchange, and alerts when they diverge. A CandleValidator cross-checks closed candles
+// against the ticker to catch stale candles and missed updates.
+package sanity
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/ws"
+)
+
+// Issue classifies a bad print.
+type Issue string
+
+const (
+	IssueInvalidPrice  Issue = "invalid_price"  // Zero, negative or unparsable price
+	IssueCrossedBook   Issue = "crossed_book"   // Bid above ask
+	IssueInvalidCandle Issue = "invalid_candle" // Open or close outside the high/low range
+	IssueJump          Issue = "jump"           // Move beyond MaxJump not backed by the book
+)
+
+// Mode selects what happens to bad prints.
+type Mode int
+
+const (
+	// ModeFlag reports bad prints and passes messages through unchanged.
+	ModeFlag Mode = iota
+	// ModeDrop reports bad prints and removes them from messages; a message left without
+	// data is not delivered.
+	ModeDrop
+)
+
+// Event is a data-quality event for one bad print.
+type Event struct {
+	Issue   Issue
+	Symbol  string
+	Channel string
+	Price   float64
+	// Reference is the previous good price for IssueJump, zero otherwise.
+	Reference float64
+	// Dropped reports whether the print was removed.
+	Dropped bool
+	Time    time.Time
+}
+
+func (e Event) String() string {
+	s := fmt.Sprintf("%s %s %s price=%g", e.Issue, e.Symbol, e.Channel, e.Price)
+	if e.Reference > 0 {
+		s += fmt.Sprintf(" ref=%g (%+.2f%%)", e.Reference, (e.Price/e.Reference-1)*100)
+	}
+	if e.Dropped {
+		s += " dropped"
+	}
+	return s
+}
+
+// Options configures a Filter.
+type Options struct {
+	// MaxJump is the largest accepted move from the previous good price, as a fraction.
+	// Defaults to 0.1; negative disables the jump check.
+	MaxJump float64
+	// BookTolerance widens the best bid/ask range a jumped price must fall in to be
+	// accepted. Defaults to 0.002.
+	BookTolerance float64
+	// MaxBookAge is how long a book snapshot is used to confirm jumps. Defaults to 5s.
+	MaxBookAge time.Duration
+	// Confirm is the number of consecutive prints within MaxJump of each other that
+	// accept a new price level without a book. Defaults to 3.
+	Confirm int
+
+	Mode Mode
+
+	// OnEvent is called for every bad print, outside the filter lock. Optional.
+	OnEvent func(Event)
+	// Clock ages book and ticker updates. Defaults to the system clock.
+	Clock common.Clock
+}
+
+type symbolState struct {
+	last     float64 // Previous good price
+	bid, ask float64
+	bookAt   time.Time
+	pending  float64 // Level of the jumped prints awaiting confirmation
+	count    int
+}
+
+// Filter validates stream messages per symbol. It is safe for concurrent use.
+type Filter struct {
+	opts  Options
+	clock common.Clock
+
+	mu      sync.Mutex
+	symbols map[string]*symbolState
+	counts  map[Issue]int
+}
+
+// New creates a filter.
+func New(opts Options) *Filter {
+	if opts.MaxJump == 0 {
+		opts.MaxJump = 0.1
+	}
+	if opts.BookTolerance <= 0 {
+		opts.BookTolerance = 0.002
+	}
+	if opts.MaxBookAge <= 0 {
+		opts.MaxBookAge = 5 * time.Second
+	}
+	if opts.Confirm <= 0 {
+		opts.Confirm = 3
+	}
+	return &Filter{
+		opts:    opts,
+		clock:   common.ClockOrSystem(opts.Clock),
+		symbols: make(map[string]*symbolState),
+		counts:  make(map[Issue]int),
+	}
+}
+
+// Wrap returns a handler that checks every message before passing it to handler.
+func (f *Filter) Wrap(handler ws.OnReceive) ws.OnReceive {
+	return func(message string) {
+		if out, ok := f.Process(message); ok {
+			handler(out)
+		}
+	}
+}
+
+// Process checks a raw WebSocket message and returns the message to deliver, with bad
+// prints removed in ModeDrop, and whether to deliver it at all. Book messages update the
+// reference used for jumps; other messages pass through untouched.
+func (f *Filter) Process(message string) (string, bool) {
+	var msg ws.WebSocketMessage
+	if err := json.Unmarshal([]byte(message), &msg); err != nil || len(msg.Data) == 0 {
+		return message, true
+	}
+	channel := msg.Arg.Channel
+	switch {
+	case channel == ws.ChannelTicker, strings.HasPrefix(channel, ws.ChannelCandle):
+	case strings.HasPrefix(channel, ws.ChannelBooks):
+		f.handleBook(msg)
+		return message, true
+	default:
+		return message, true
+	}
+
+	var rows []json.RawMessage
+	if json.Unmarshal(msg.Data, &rows) != nil {
+		return message, true
+	}
+
+	now := f.clock.Now()
+	var events []Event
+	kept := make([]json.RawMessage, 0, len(rows))
+	f.mu.Lock()
+	for _, row := range rows {
+		var e *Event
+		if channel == ws.ChannelTicker {
+			e = f.checkTicker(row, now)
+		} else {
+			// Snapshot rows are history and may span any move; only live updates are
+			// compared with the previous price
+			e = f.checkCandle(msg.Arg.Symbol, channel, row, msg.Action != "snapshot", now)
+		}
+		if e == nil {
+			kept = append(kept, row)
+			continue
+		}
+		e.Channel = channel
+		e.Time = now
+		e.Dropped = f.opts.Mode == ModeDrop
+		f.counts[e.Issue]++
+		events = append(events, *e)
+		if !e.Dropped {
+			kept = append(kept, row)
+		}
+	}
+	f.mu.Unlock()
+
+	for _, e := range events {
+		if f.opts.OnEvent != nil {
+			f.opts.OnEvent(e)
+		}
+	}
+	if len(kept) == len(rows) {
+		return message, true
+	}
+	if len(kept) == 0 {
+		return "", false
+	}
+	return rewrite(message, kept)
+}
+
+// Counts returns the number of bad prints seen per issue.
+func (f *Filter) Counts() map[Issue]int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make(map[Issue]int, len(f.counts))
+	for issue, n := range f.counts {
+		out[issue] = n
+	}
+	return out
+}
+
+// LastPrice returns the last good price of symbol.
+func (f *Filter) LastPrice(symbol string) (float64, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	st, ok := f.symbols[symbol]
+	if !ok || st.last == 0 {
+		return 0, false
+	}
+	return st.last, true
+}
+
+func (f *Filter) handleBook(msg ws.WebSocketMessage) {
+	// Incremental updates of the full-depth channel do not carry the top of book
+	if msg.Arg.Channel == ws.ChannelBooks && msg.Action != "snapshot" {
+		return
+	}
+	var books []ws.OrderBookData
+	if json.Unmarshal(msg.Data, &books) != nil || len(books) == 0 {
+		return
+	}
+	b := books[len(books)-1]
+	bid, _ := b.BestBid()
+	ask, _ := b.BestAsk()
+	if bid <= 0 || ask <= 0 || bid > ask {
+		return
+	}
+	f.mu.Lock()
+	st := f.state(msg.Arg.Symbol)
+	st.bid, st.ask, st.bookAt = bid, ask, f.clock.Now()
+	f.mu.Unlock()
+}
+
+// checkTicker returns the issue of a ticker row, or nil. Callers hold f.mu.
+func (f *Filter) checkTicker(row json.RawMessage, now time.Time) *Event {
+	var t ws.TickerData
+	if json.Unmarshal(row, &t) != nil {
+		return nil
+	}
+	symbol := t.InstId
+	if symbol == "" {
+		symbol = t.Symbol
+	}
+	last, ok := price(t.LastPrice)
+	if !ok {
+		return &Event{Issue: IssueInvalidPrice, Symbol: symbol, Price: last}
+	}
+	bid, bidOK := price(t.BidPrice)
+	ask, askOK := price(t.AskPrice)
+	if bidOK && askOK && bid > ask {
+		return &Event{Issue: IssueCrossedBook, Symbol: symbol, Price: last}
+	}
+	return f.checkJump(symbol, last, true, now)
+}
+
+// checkCandle returns the issue of a candle row, or nil. Callers hold f.mu.
+func (f *Filter) checkCandle(symbol, channel string, row json.RawMessage, live bool, now time.Time) *Event {
+	var c ws.CandlestickData
+	if json.Unmarshal(row, &c) != nil {
+		return &Event{Issue: IssueInvalidPrice, Symbol: symbol}
+	}
+	for _, p := range []float64{c.OpenFloat, c.HighFloat, c.LowFloat, c.CloseFloat} {
+		if !valid(p) {
+			return &Event{Issue: IssueInvalidPrice, Symbol: symbol, Price: c.CloseFloat}
+		}
+	}
+	if c.HighFloat < c.LowFloat ||
+		c.OpenFloat > c.HighFloat || c.OpenFloat < c.LowFloat ||
+		c.CloseFloat > c.HighFloat || c.CloseFloat < c.LowFloat {
+		return &Event{Issue: IssueInvalidCandle, Symbol: symbol, Price: c.CloseFloat}
+	}
+	return f.checkJump(symbol, c.CloseFloat, live, now)
+}
+
+// checkJump compares p with the previous good price and records it when accepted.
+// Callers hold f.mu.
+func (f *Filter) checkJump(symbol string, p float64, compare bool, now time.Time) *Event {
+	st := f.state(symbol)
+	if !compare || st.last == 0 || f.opts.MaxJump < 0 || math.Abs(p/st.last-1) <= f.opts.MaxJump {
+		st.last, st.count = p, 0
+		return nil
+	}
+	if f.bookConfirms(st, p, now) {
+		st.last, st.count = p, 0
+		return nil
+	}
+	if st.count > 0 && math.Abs(p/st.pending-1) <= f.opts.MaxJump {
+		st.count++
+	} else {
+		st.pending, st.count = p, 1
+	}
+	if st.count >= f.opts.Confirm {
+		st.last, st.count = p, 0
+		return nil
+	}
+	return &Event{Issue: IssueJump, Symbol: symbol, Price: p, Reference: st.last}
+}
+
+func (f *Filter) bookConfirms(st *symbolState, p float64, now time.Time) bool {
+	if st.bookAt.IsZero() || now.Sub(st.bookAt) > f.opts.MaxBookAge {
+		return false
+	}
+	return p >= st.bid*(1-f.opts.BookTolerance) && p <= st.ask*(1+f.opts.BookTolerance)
+}
+
+func (f *Filter) state(symbol string) *symbolState {
+	st, ok := f.symbols[symbol]
+	if !ok {
+		st = &symbolState{}
+		f.symbols[symbol] = st
+	}
+	return st
+}
+
+// rewrite replaces the data array of message with rows.
+func rewrite(message string, rows []json.RawMessage) (string, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(message), &fields); err != nil {
+		return message, true
+	}
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return message, true
+	}
+	fields["data"] = data
+	out, err := json.Marshal(fields)
+	if err != nil {
+		return message, true
+	}
+	return string(out), true
+}
+
+func price(s string) (float64, bool) {
+	v, err := strconv.ParseFloat(s, 64)
+	return v, err == nil && valid(v)
+}
+
+func valid(p float64) bool {
+	return p > 0 && !math.IsInf(p, 0) && !math.IsNaN(p)
+}
@@ -0,0 +1,152 @@
+package sanity
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tickerMsg(last, bid, ask string) string {
+	return fmt.Sprintf(`{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"ticker","instId":"BTCUSDT"},"data":[{"instId":"BTCUSDT","lastPr":%q,"bidPr":%q,"askPr":%q}],"ts":1}`, last, bid, ask)
+}
+
+func bookMsg(bid, ask string) string {
+	return fmt.Sprintf(`{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"books5","instId":"BTCUSDT"},"data":[{"bids":[[%q,"1"]],"asks":[[%q,"1"]],"ts":"1"}],"ts":1}`, bid, ask)
+}
+
+func candleMsg(action string, rows ...[5]string) string {
+	data := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		data = append(data, []string{r[0], r[1], r[2], r[3], r[4], "1", "1", "1"})
+	}
+	raw, _ := json.Marshal(data)
+	return fmt.Sprintf(`{"action":%q,"arg":{"instType":"USDT-FUTURES","channel":"candle1m","instId":"BTCUSDT"},"data":%s,"ts":1}`, action, raw)
+}
+
+func TestFilter_InvalidPrints(t *testing.T) {
+	var events []Event
+	f := New(Options{Mode: ModeDrop, OnEvent: func(e Event) { events = append(events, e) }})
+
+	_, ok := f.Process(tickerMsg("0", "100", "101"))
+	assert.False(t, ok)
+	_, ok = f.Process(tickerMsg("100", "102", "101"))
+	assert.False(t, ok)
+	_, ok = f.Process(candleMsg("update", [5]string{"1000", "100", "101", "99", "105"}))
+	assert.False(t, ok)
+
+	require.Len(t, events, 3)
+	assert.Equal(t, IssueInvalidPrice, events[0].Issue)
+	assert.Equal(t, IssueCrossedBook, events[1].Issue)
+	assert.Equal(t, IssueInvalidCandle, events[2].Issue)
+	assert.True(t, events[2].Dropped)
+	assert.Equal(t, "BTCUSDT", events[2].Symbol)
+	assert.Equal(t, "candle1m", events[2].Channel)
+
+	_, ok = f.LastPrice("BTCUSDT")
+	assert.False(t, ok)
+}
+
+func TestFilter_Jump(t *testing.T) {
+	var events []Event
+	f := New(Options{MaxJump: 0.05, Confirm: 3, Mode: ModeDrop, OnEvent: func(e Event) { events = append(events, e) }})
+
+	_, ok := f.Process(tickerMsg("100", "99.9", "100.1"))
+	require.True(t, ok)
+	_, ok = f.Process(tickerMsg("103", "102.9", "103.1"))
+	require.True(t, ok)
+
+	// A single print far from the last one is dropped
+	_, ok = f.Process(tickerMsg("150", "102.9", "103.1"))
+	assert.False(t, ok)
+	require.Len(t, events, 1)
+	assert.Equal(t, IssueJump, events[0].Issue)
+	assert.Equal(t, 103.0, events[0].Reference)
+
+	_, ok = f.Process(tickerMsg("104", "103.9", "104.1"))
+	assert.True(t, ok)
+
+	// A sustained move is accepted on the third consecutive print
+	for i, want := range []bool{false, false, true} {
+		_, ok = f.Process(tickerMsg(fmt.Sprintf("%d", 130+i), "1", "200"))
+		assert.Equal(t, want, ok, i)
+	}
+	last, _ := f.LastPrice("BTCUSDT")
+	assert.Equal(t, 132.0, last)
+	assert.Equal(t, 3, f.Counts()[IssueJump])
+}
+
+func TestFilter_BookConfirmsJump(t *testing.T) {
+	clock := common.NewFakeClock(time.Unix(1700000000, 0))
+	f := New(Options{MaxJump: 0.05, Mode: ModeDrop, Clock: clock})
+
+	_, ok := f.Process(tickerMsg("100", "99.9", "100.1"))
+	require.True(t, ok)
+	_, ok = f.Process(bookMsg("119.9", "120.1"))
+	require.True(t, ok)
+	_, ok = f.Process(tickerMsg("120", "119.9", "120.1"))
+	assert.True(t, ok, "the book backs the move")
+
+	// A stale book confirms nothing
+	clock.Advance(time.Minute)
+	_, ok = f.Process(tickerMsg("90", "89.9", "90.1"))
+	assert.False(t, ok)
+}
+
+func TestFilter_Candles(t *testing.T) {
+	f := New(Options{MaxJump: 0.05, Mode: ModeDrop})
+
+	// History snapshots may span any move
+	_, ok := f.Process(candleMsg("snapshot",
+		[5]string{"1000", "50", "52", "49", "51"},
+		[5]string{"2000", "100", "101", "99", "100"},
+	))
+	require.True(t, ok)
+
+	// One bad row is removed and the rest delivered
+	out, ok := f.Process(candleMsg("update",
+		[5]string{"3000", "100", "102", "99", "101"},
+		[5]string{"4000", "101", "300", "101", "300"},
+	))
+	require.True(t, ok)
+	var msg struct {
+		Action string     `json:"action"`
+		Data   [][]string `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &msg))
+	assert.Equal(t, "update", msg.Action)
+	require.Len(t, msg.Data, 1)
+	assert.Equal(t, "3000", msg.Data[0][0])
+}
+
+func TestFilter_FlagMode(t *testing.T) {
+	var events []Event
+	f := New(Options{OnEvent: func(e Event) { events = append(events, e) }})
+
+	msg := tickerMsg("0", "1", "2")
+	out, ok := f.Process(msg)
+	assert.True(t, ok)
+	assert.Equal(t, msg, out)
+	require.Len(t, events, 1)
+	assert.False(t, events[0].Dropped)
+
+	// Other channels pass through untouched
+	other := `{"arg":{"channel":"orders"},"data":[{"price":"0"}]}`
+	out, ok = f.Process(other)
+	assert.True(t, ok)
+	assert.Equal(t, other, out)
+}
+
+func TestFilter_Wrap(t *testing.T) {
+	f := New(Options{Mode: ModeDrop})
+	var got []string
+	handler := f.Wrap(func(message string) { got = append(got, message) })
+
+	handler(tickerMsg("100", "99", "101"))
+	handler(tickerMsg("-1", "99", "101"))
+	assert.Len(t, got, 1)
+}
@@ -0,0 +1,130 @@
+package scanner
+
+import (
+	"math"
+	"strconv"
+)
+
+// VolumeFilter passes instruments with enough 24h quote volume.
+// Score is log10(volume / MinQuoteVolume), so larger markets rank higher.
+type VolumeFilter struct {
+	MinQuoteVolume float64
+}
+
+// Name implements Filter.
+func (f VolumeFilter) Name() string { return "volume" }
+
+// Apply implements Filter.
+func (f VolumeFilter) Apply(c *Candidate) (float64, bool) {
+	volume := parse(c.Ticker.QuoteVolume)
+	if volume <= 0 || volume < f.MinQuoteVolume {
+		return 0, false
+	}
+	if f.MinQuoteVolume <= 0 {
+		return 0, true
+	}
+	return math.Log10(volume / f.MinQuoteVolume), true
+}
+
+// VolatilityFilter passes instruments whose 24h range, (high-low)/low, lies in [Min, Max].
+// A zero Max means no upper bound. Score is the range itself.
+type VolatilityFilter struct {
+	Min float64
+	Max float64
+}
+
+// Name implements Filter.
+func (f VolatilityFilter) Name() string { return "volatility" }
+
+// Apply implements Filter.
+func (f VolatilityFilter) Apply(c *Candidate) (float64, bool) {
+	high, low := parse(c.Ticker.High24h), parse(c.Ticker.Low24h)
+	if low <= 0 {
+		return 0, false
+	}
+	rng := (high - low) / low
+	if rng < f.Min || (f.Max > 0 && rng > f.Max) {
+		return 0, false
+	}
+	return rng, true
+}
+
+// FundingFilter passes instruments whose absolute funding rate is at least MinAbsRate
+// (and at most MaxAbsRate when set). Score is the absolute rate scaled to basis points.
+type FundingFilter struct {
+	MinAbsRate float64
+	MaxAbsRate float64
+}
+
+// Name implements Filter.
+func (f FundingFilter) Name() string { return "funding" }
+
+// Apply implements Filter.
+func (f FundingFilter) Apply(c *Candidate) (float64, bool) {
+	rate := math.Abs(parse(c.Ticker.FundingRate))
+	if rate < f.MinAbsRate || (f.MaxAbsRate > 0 && rate > f.MaxAbsRate) {
+		return 0, false
+	}
+	return rate * 10000, true
+}
+
+// MomentumFilter passes instruments whose close moved at least MinChange (as a fraction)
+// over the last Lookback candles, in either direction. Set Direction to 1 or -1 to only
+// accept upward or downward moves. Score is the absolute change in percent.
+type MomentumFilter struct {
+	Lookback  int
+	MinChange float64
+	Direction int
+}
+
+// Name implements Filter.
+func (f MomentumFilter) Name() string { return "momentum" }
+
+// RequiresCandles implements CandleFilter.
+func (f MomentumFilter) RequiresCandles() bool { return true }
+
+// Apply implements Filter.
+func (f MomentumFilter) Apply(c *Candidate) (float64, bool) {
+	lookback := f.Lookback
+	if lookback <= 0 {
+		lookback = 1
+	}
+	if len(c.Candles) <= lookback {
+		return 0, false
+	}
+	last := c.Candles[len(c.Candles)-1].Close
+	prev := c.Candles[len(c.Candles)-1-lookback].Close
+	if prev <= 0 {
+		return 0, false
+	}
+
+	change := (last - prev) / prev
+	if f.Direction > 0 && change < 0 || f.Direction < 0 && change > 0 {
+		return 0, false
+	}
+	if math.Abs(change) < f.MinChange {
+		return 0, false
+	}
+	return math.Abs(change) * 100, true
+}
+
+// FilterFunc adapts a function to the Filter interface.
+type FilterFunc struct {
+	FilterName string
+	Candles    bool
+	Fn         func(c *Candidate) (float64, bool)
+}
+
+// Name implements Filter.
+func (f FilterFunc) Name() string { return f.FilterName }
+
+// RequiresCandles implements CandleFilter.
+func (f FilterFunc) RequiresCandles() bool { return f.Candles }
+
+// Apply implements Filter.
+func (f FilterFunc) Apply(c *Candidate) (float64, bool) { return f.Fn(c) }
+
+func parse(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
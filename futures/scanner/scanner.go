@@ -0,0 +1,214 @@
+// Package scanner runs pluggable filters across all futures instruments and produces
+// ranked candidate lists.
+//
+// A scan fetches 24h tickers for the whole product type in one request, applies the
+// ticker-based filters, then loads candles only for the symbols that survived and runs the
+// candle-based filters. Candidates that pass every filter are ranked by their total score.
+//
+//	s := scanner.New(client, scanner.Config{
+//		ProductType: futures.ProductTypeUSDTFutures,
+//		Filters: []scanner.Filter{
+//			scanner.VolumeFilter{MinQuoteVolume: 50_000_000},
+//			scanner.MomentumFilter{Lookback: 3, MinChange: 0.02},
+//		},
+//		TopN: 10,
+//	})
+//	s.Run(ctx, time.Minute, func(candidates []scanner.Candidate, err error) { ... })
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+)
+
+// Candidate is an instrument under evaluation.
+type Candidate struct {
+	Symbol  string
+	Ticker  *market.Ticker
+	Candles []market.Candlestick // Only loaded when a filter requires candles
+
+	// Score is the sum of all filter scores; Scores holds them by filter name
+	Score  float64
+	Scores map[string]float64
+}
+
+// Filter decides whether a candidate passes and contributes to its ranking score.
+type Filter interface {
+	// Name identifies the filter in Candidate.Scores.
+	Name() string
+	// Apply returns the filter score and whether the candidate passes.
+	Apply(c *Candidate) (score float64, ok bool)
+}
+
+// CandleFilter is implemented by filters that need candle history.
+// The scanner loads candles only when at least one such filter is configured.
+type CandleFilter interface {
+	Filter
+	RequiresCandles() bool
+}
+
+// Config configures a Scanner.
+type Config struct {
+	ProductType futures.ProductType
+	Filters     []Filter
+
+	// Symbols restricts the scan to the listed instruments. Empty scans everything.
+	Symbols []string
+
+	// Granularity and CandleLimit control candle loading. Defaults are "15m" and 50.
+	Granularity string
+	CandleLimit int
+
+	// TopN truncates the ranked result. Zero returns all candidates.
+	TopN int
+
+	// Concurrency bounds parallel candle requests. Defaults to 5.
+	Concurrency int
+}
+
+// Scanner evaluates filters across instruments.
+type Scanner struct {
+	client futures.ClientInterface
+	cfg    Config
+}
+
+// New creates a scanner.
+func New(client futures.ClientInterface, cfg Config) *Scanner {
+	if cfg.Granularity == "" {
+		cfg.Granularity = "15m"
+	}
+	if cfg.CandleLimit <= 0 {
+		cfg.CandleLimit = 50
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 5
+	}
+	return &Scanner{client: client, cfg: cfg}
+}
+
+// Scan runs a single pass and returns passing candidates ordered by descending score.
+func (s *Scanner) Scan(ctx context.Context) ([]Candidate, error) {
+	if s.cfg.ProductType == "" {
+		return nil, fmt.Errorf("productType is required")
+	}
+
+	tickers, err := market.NewAllTickersService(s.client).ProductType(s.cfg.ProductType).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch tickers: %w", err)
+	}
+
+	var allowed map[string]bool
+	if len(s.cfg.Symbols) > 0 {
+		allowed = make(map[string]bool, len(s.cfg.Symbols))
+		for _, sym := range s.cfg.Symbols {
+			allowed[sym] = true
+		}
+	}
+
+	var tickerFilters, candleFilters []Filter
+	for _, f := range s.cfg.Filters {
+		if cf, ok := f.(CandleFilter); ok && cf.RequiresCandles() {
+			candleFilters = append(candleFilters, f)
+		} else {
+			tickerFilters = append(tickerFilters, f)
+		}
+	}
+
+	// First stage: cheap ticker-only filters
+	var stage []*Candidate
+	for _, t := range tickers {
+		if allowed != nil && !allowed[t.Symbol] {
+			continue
+		}
+		c := &Candidate{Symbol: t.Symbol, Ticker: t, Scores: make(map[string]float64)}
+		if apply(c, tickerFilters) {
+			stage = append(stage, c)
+		}
+	}
+
+	// Second stage: candle filters on the survivors
+	if len(candleFilters) > 0 {
+		if err := s.loadCandles(ctx, stage); err != nil {
+			return nil, err
+		}
+		survivors := stage[:0]
+		for _, c := range stage {
+			if c.Candles != nil && apply(c, candleFilters) {
+				survivors = append(survivors, c)
+			}
+		}
+		stage = survivors
+	}
+
+	out := make([]Candidate, len(stage))
+	for i, c := range stage {
+		out[i] = *c
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	if s.cfg.TopN > 0 && len(out) > s.cfg.TopN {
+		out = out[:s.cfg.TopN]
+	}
+	return out, nil
+}
+
+// Run scans immediately and then on every interval until ctx is cancelled.
+func (s *Scanner) Run(ctx context.Context, interval time.Duration, fn func([]Candidate, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fn(s.Scan(ctx))
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// loadCandles fetches candles for candidates in parallel. Symbols whose request fails are
+// left without candles and dropped; the scan only fails when ctx is cancelled.
+func (s *Scanner) loadCandles(ctx context.Context, candidates []*Candidate) error {
+	sem := make(chan struct{}, s.cfg.Concurrency)
+	var wg sync.WaitGroup
+
+	for _, c := range candidates {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(c *Candidate) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			candles, err := market.NewCandlestickService(s.client).
+				Symbol(c.Symbol).
+				ProductType(market.ProductType(s.cfg.ProductType)).
+				Granularity(s.cfg.Granularity).
+				Limit(strconv.Itoa(s.cfg.CandleLimit)).
+				Do(ctx)
+			if err == nil {
+				c.Candles = candles
+			}
+		}(c)
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+func apply(c *Candidate, filters []Filter) bool {
+	for _, f := range filters {
+		score, ok := f.Apply(c)
+		if !ok {
+			return false
+		}
+		c.Scores[f.Name()] = score
+		c.Score += score
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeClient struct {
+	tickers []map[string]string
+	candles map[string][][]string
+}
+
+func (f *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	var data []byte
+	switch endpoint {
+	case futures.EndpointAllTickers:
+		data, _ = json.Marshal(f.tickers)
+	case futures.EndpointCandlesticks:
+		data, _ = json.Marshal(f.candles[query.Get("symbol")])
+	}
+	return &futures.ApiResponse{Code: "00000", Data: data}, &fasthttp.ResponseHeader{}, nil
+}
+
+func candles(closes ...string) [][]string {
+	rows := make([][]string, len(closes))
+	for i, c := range closes {
+		rows[i] = []string{"1700000000000", c, c, c, c, "1", "1"}
+	}
+	return rows
+}
+
+func newFake() *fakeClient {
+	return &fakeClient{
+		tickers: []map[string]string{
+			{"symbol": "BTCUSDT", "quoteVolume": "1000000000", "high24h": "105", "low24h": "100", "fundingRate": "0.0001"},
+			{"symbol": "ETHUSDT", "quoteVolume": "500000000", "high24h": "110", "low24h": "100", "fundingRate": "-0.0005"},
+			{"symbol": "DOGEUSDT", "quoteVolume": "1000", "high24h": "1.5", "low24h": "1", "fundingRate": "0.001"},
+		},
+		candles: map[string][][]string{
+			"BTCUSDT": candles("100", "100.5", "101"),
+			"ETHUSDT": candles("100", "95", "90"),
+		},
+	}
+}
+
+func TestScanner_TickerFilters(t *testing.T) {
+	s := New(newFake(), Config{
+		ProductType: futures.ProductTypeUSDTFutures,
+		Filters: []Filter{
+			VolumeFilter{MinQuoteVolume: 1e8},
+			VolatilityFilter{Min: 0.01},
+		},
+	})
+
+	got, err := s.Scan(context.Background())
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "BTCUSDT", got[0].Symbol, "higher volume outweighs volatility")
+	assert.Contains(t, got[0].Scores, "volume")
+	assert.Nil(t, got[0].Candles, "no candle filter configured")
+}
+
+func TestScanner_CandleFiltersAndRanking(t *testing.T) {
+	s := New(newFake(), Config{
+		ProductType: futures.ProductTypeUSDTFutures,
+		Filters: []Filter{
+			VolumeFilter{MinQuoteVolume: 1e8},
+			MomentumFilter{Lookback: 2, MinChange: 0.005},
+		},
+		TopN: 1,
+	})
+
+	got, err := s.Scan(context.Background())
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "ETHUSDT", got[0].Symbol)
+	assert.InDelta(t, 10, got[0].Scores["momentum"], 1e-9)
+}
+
+func TestScanner_SymbolsAndDirection(t *testing.T) {
+	s := New(newFake(), Config{
+		ProductType: futures.ProductTypeUSDTFutures,
+		Symbols:     []string{"BTCUSDT", "ETHUSDT"},
+		Filters:     []Filter{MomentumFilter{Lookback: 2, Direction: 1}},
+	})
+
+	got, err := s.Scan(context.Background())
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "BTCUSDT", got[0].Symbol)
+}
+
+func TestScanner_FundingFilter(t *testing.T) {
+	s := New(newFake(), Config{
+		ProductType: futures.ProductTypeUSDTFutures,
+		Filters:     []Filter{FundingFilter{MinAbsRate: 0.0003, MaxAbsRate: 0.0008}},
+	})
+
+	got, err := s.Scan(context.Background())
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "ETHUSDT", got[0].Symbol)
+}
+
+func TestScanner_RequiresProductType(t *testing.T) {
+	_, err := New(newFake(), Config{}).Scan(context.Background())
+	assert.Error(t, err)
+}
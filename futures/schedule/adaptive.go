@@ -0,0 +1,233 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+)
+
+// AdaptiveOptions configures an AdaptivePoller.
+type AdaptiveOptions struct {
+	// Interval is the poll interval while volatility is at its long-run average.
+	// Defaults to 5s.
+	Interval time.Duration
+	// MinInterval and MaxInterval bound the interval. They default to a tenth and six
+	// times Interval.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	// Window is the number of recent returns measuring current volatility. Defaults
+	// to 10.
+	Window int
+	// HalfLife is the number of polls after which a return weighs half as much in the
+	// long-run average. Defaults to 100.
+	HalfLife int
+	// Limiter is a rate limiter the poller takes a token from before every poll. The
+	// futures client does not limit requests itself, so to keep pollers within a request
+	// budget, share one limiter between them and the other code calling the same
+	// endpoints. A poll that finds no token is skipped and the interval doubles, so
+	// pollers yield instead of competing. Optional.
+	Limiter *common.RateLimiter
+	// Clock replaces the time source, e.g. with a common.FakeClock in tests. Optional.
+	Clock common.Clock
+}
+
+// AdaptivePoller polls a price faster while realized volatility runs above its
+// long-run average and slower while the market is quiet:
+//
+//	p := schedule.NewAdaptivePoller(schedule.AdaptiveOptions{
+//		Interval:    5 * time.Second,
+//		MinInterval: 500 * time.Millisecond,
+//		Limiter:     limiter, // Also taken by the code sharing the budget
+//	})
+//	go p.Run(ctx, schedule.TickerPrice(client, "BTCUSDT", futures.ProductTypeUSDTFutures), func(price float64) {
+//		// react to the new price
+//	}, log.Println)
+//
+// Returns are scaled by the square root of the time between polls, so volatility
+// measured at different intervals is comparable. The interval is Interval divided by
+// the ratio of current to average volatility, within the bounds. It is safe for
+// concurrent use.
+type AdaptivePoller struct {
+	opts  AdaptiveOptions
+	clock common.Clock
+	alpha float64
+
+	mu       sync.Mutex
+	last     float64
+	lastAt   time.Time
+	recent   []float64 // Squared scaled returns, oldest first
+	avgVar   float64
+	samples  int
+	interval time.Duration
+	skipped  uint64
+}
+
+// NewAdaptivePoller creates a poller that starts at Interval.
+func NewAdaptivePoller(opts AdaptiveOptions) *AdaptivePoller {
+	if opts.Interval <= 0 {
+		opts.Interval = 5 * time.Second
+	}
+	if opts.MinInterval <= 0 {
+		opts.MinInterval = opts.Interval / 10
+	}
+	if opts.MaxInterval <= 0 {
+		opts.MaxInterval = opts.Interval * 6
+	}
+	if opts.Window <= 0 {
+		opts.Window = 10
+	}
+	if opts.HalfLife <= 0 {
+		opts.HalfLife = 100
+	}
+	return &AdaptivePoller{
+		opts:     opts,
+		clock:    common.ClockOrSystem(opts.Clock),
+		alpha:    1 - math.Pow(0.5, 1/float64(opts.HalfLife)),
+		interval: opts.Interval,
+	}
+}
+
+// Interval returns the current poll interval.
+func (p *AdaptivePoller) Interval() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.interval
+}
+
+// Ratio returns current over long-run average volatility, or 1 until Window returns
+// have been observed.
+func (p *AdaptivePoller) Ratio() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.ratio()
+}
+
+// Skipped returns the number of polls skipped because the limiter had no token.
+func (p *AdaptivePoller) Skipped() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.skipped
+}
+
+// Observe records a price observed at t and returns the next interval.
+func (p *AdaptivePoller) Observe(price float64, t time.Time) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if price <= 0 {
+		return p.interval
+	}
+	if p.last > 0 {
+		if dt := t.Sub(p.lastAt).Seconds(); dt > 0 {
+			r := math.Log(price/p.last) / math.Sqrt(dt)
+			p.add(r * r)
+		}
+	}
+	p.last, p.lastAt = price, t
+	p.interval = p.clamp(time.Duration(float64(p.opts.Interval) / p.ratio()))
+	return p.interval
+}
+
+// Run polls until ctx is cancelled, feeding every price to onPrice when it is non-nil
+// and adapting the interval. Errors are passed to onError when it is non-nil.
+func (p *AdaptivePoller) Run(ctx context.Context, poll func(ctx context.Context) (float64, error), onPrice func(price float64), onError func(error)) {
+	for {
+		if p.opts.Limiter != nil && !p.opts.Limiter.Allow() {
+			p.throttle()
+		} else if price, err := poll(ctx); err != nil {
+			if onError != nil && ctx.Err() == nil {
+				onError(err)
+			}
+		} else {
+			p.Observe(price, p.clock.Now())
+			if onPrice != nil {
+				onPrice(price)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.clock.After(p.Interval()):
+		}
+	}
+}
+
+func (p *AdaptivePoller) add(sq float64) {
+	p.recent = append(p.recent, sq)
+	if len(p.recent) > p.opts.Window {
+		p.recent = p.recent[1:]
+	}
+	if p.samples == 0 {
+		p.avgVar = sq
+	} else {
+		p.avgVar += p.alpha * (sq - p.avgVar)
+	}
+	p.samples++
+}
+
+func (p *AdaptivePoller) ratio() float64 {
+	if p.samples < p.opts.Window {
+		return 1
+	}
+	var cur float64
+	for _, sq := range p.recent {
+		cur += sq
+	}
+	cur /= float64(len(p.recent))
+	if p.avgVar <= 0 {
+		if cur > 0 {
+			return math.Inf(1)
+		}
+		return 1
+	}
+	return math.Sqrt(cur / p.avgVar)
+}
+
+func (p *AdaptivePoller) throttle() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.skipped++
+	p.interval = p.clamp(2 * p.interval)
+}
+
+func (p *AdaptivePoller) clamp(d time.Duration) time.Duration {
+	return min(max(d, p.opts.MinInterval), p.opts.MaxInterval)
+}
+
+// TickerPrice returns a poll function reading the last price of symbol.
+func TickerPrice(client futures.ClientInterface, symbol string, productType futures.ProductType) func(ctx context.Context) (float64, error) {
+	return func(ctx context.Context) (float64, error) {
+		t, err := market.NewTickerService(client).Symbol(symbol).ProductType(string(productType)).Do(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.ParseFloat(t.LastPr, 64)
+	}
+}
+
+// CandlePrice returns a poll function reading the close of the latest candle of
+// symbol.
+func CandlePrice(client futures.ClientInterface, symbol string, productType futures.ProductType, granularity string) func(ctx context.Context) (float64, error) {
+	return func(ctx context.Context) (float64, error) {
+		candles, err := market.NewCandlestickService(client).
+			Symbol(symbol).
+			ProductType(market.ProductType(productType)).
+			Granularity(granularity).
+			Limit("1").
+			Do(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if len(candles) == 0 {
+			return 0, fmt.Errorf("no %s candles for %s", granularity, symbol)
+		}
+		return candles[len(candles)-1].Close, nil
+	}
+}
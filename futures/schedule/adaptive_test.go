@@ -0,0 +1,83 @@
+package schedule
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestAdaptivePoller_FollowsVolatility(t *testing.T) {
+	p := NewAdaptivePoller(AdaptiveOptions{Interval: 4 * time.Second, MinInterval: time.Second, MaxInterval: 20 * time.Second, Window: 5, HalfLife: 50})
+	at := time.Unix(1700000000, 0)
+	price := 100.0
+	step := func(move float64, n int) time.Duration {
+		var d time.Duration
+		for i := 0; i < n; i++ {
+			at = at.Add(p.Interval())
+			price *= 1 + move*math.Sqrt(p.Interval().Seconds())*float64(1-2*(i%2))
+			d = p.Observe(price, at)
+		}
+		return d
+	}
+
+	step(0.001, 3)
+	assert.Equal(t, 4*time.Second, p.Interval(), "warming up")
+	assert.Equal(t, 1.0, p.Ratio())
+
+	assert.Equal(t, 4*time.Second, step(0.001, 60).Round(100*time.Millisecond), "steady volatility keeps the base interval")
+
+	fast := step(0.003, 5)
+	assert.Less(t, fast, 2*time.Second, "a spike shortens the interval")
+	assert.GreaterOrEqual(t, fast, time.Second)
+
+	slow := step(0.0002, 5)
+	assert.Greater(t, slow, 8*time.Second, "quiet markets relax it")
+	assert.LessOrEqual(t, slow, 20*time.Second)
+}
+
+// tickerClient answers ticker requests with a fixed price.
+type tickerClient struct {
+	calls atomic.Int32
+}
+
+func (c *tickerClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.calls.Add(1)
+	return &futures.ApiResponse{Code: "00000", Data: []byte(`[{"symbol":"` + query.Get("symbol") + `","lastPr":"60000"}]`)}, &fasthttp.ResponseHeader{}, nil
+}
+
+func TestAdaptivePoller_RunYieldsToLimiter(t *testing.T) {
+	clock := common.NewFakeClock(time.Unix(1700000000, 0))
+	limiter := common.NewRateLimiter(0.1, 1) // One request per 10s
+	limiter.SetClock(clock)
+	p := NewAdaptivePoller(AdaptiveOptions{Interval: 2 * time.Second, MaxInterval: 8 * time.Second, Limiter: limiter, Clock: clock})
+
+	client := &tickerClient{}
+	prices := make(chan float64, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go p.Run(ctx, TickerPrice(client, "BTCUSDT", futures.ProductTypeUSDTFutures), func(price float64) { prices <- price }, func(err error) { t.Error(err) })
+
+	require.Equal(t, 60000.0, <-prices)
+	clock.BlockUntil(1)
+	clock.Advance(2 * time.Second)
+	clock.BlockUntil(1)
+	assert.Equal(t, uint64(1), p.Skipped(), "no token left")
+	assert.Equal(t, 4*time.Second, p.Interval())
+
+	clock.Advance(4 * time.Second)
+	clock.BlockUntil(1)
+	assert.Equal(t, 8*time.Second, p.Interval(), "capped at MaxInterval")
+
+	clock.Advance(8 * time.Second)
+	require.Equal(t, 60000.0, <-prices)
+	assert.Equal(t, int32(2), client.calls.Load())
+}
@@ -0,0 +1,154 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// Estimator is an OffsetSource measured from REST round trips and WebSocket push
+// timestamps.
+//
+// Round trips bound the offset on both sides; the sample with the shortest round trip of
+// the recent window is the most precise, as in NTP. Push timestamps only bound it from
+// below (a message is stamped before it is received), which still catches an offset that
+// drifted upwards between syncs:
+//
+//	est := schedule.NewEstimator(0)
+//	go est.Run(ctx, market.NewServerTimeService(client).Do, time.Minute, onError)
+//	publicWs.SetRawTap(est.Tap)
+//	s := schedule.New(est)
+//
+// It is safe for concurrent use.
+type Estimator struct {
+	mu      sync.Mutex
+	size    int
+	trips   []roundTrip
+	pushMax time.Duration // Highest push lower bound since the last round trip
+	pushSet bool
+	offset  time.Duration
+	clock   common.Clock
+}
+
+type roundTrip struct {
+	rtt    time.Duration
+	offset time.Duration
+}
+
+// NewEstimator keeps the last window round trips, 8 when window is not positive.
+func NewEstimator(window int) *Estimator {
+	if window <= 0 {
+		window = 8
+	}
+	return &Estimator{size: window, clock: common.SystemClock()}
+}
+
+// SetClock sets the clock that times round trips and pushes.
+func (e *Estimator) SetClock(clock common.Clock) {
+	e.clock = common.ClockOrSystem(clock)
+}
+
+// Offset implements OffsetSource.
+func (e *Estimator) Offset() time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.offset
+}
+
+// AddRoundTrip records a request sent at local time sent and answered at received with
+// the exchange time server.
+func (e *Estimator) AddRoundTrip(sent, received, server time.Time) {
+	rtt := received.Sub(sent)
+	if rtt < 0 {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.trips = append(e.trips, roundTrip{rtt: rtt, offset: server.Sub(sent.Add(rtt / 2))})
+	if len(e.trips) > e.size {
+		e.trips = e.trips[len(e.trips)-e.size:]
+	}
+	e.pushSet = false
+	e.update()
+}
+
+// AddPush records a message stamped server by the exchange and received at local time
+// received.
+func (e *Estimator) AddPush(server, received time.Time) {
+	bound := server.Sub(received)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.pushSet || bound > e.pushMax {
+		e.pushMax, e.pushSet = bound, true
+	}
+	e.update()
+}
+
+// HandleMessage records the "ts" field of a WebSocket push. Its signature matches
+// ws.OnReceive, so it can be used as a raw tap or subscription handler.
+func (e *Estimator) HandleMessage(message string) {
+	received := e.clock.Now()
+	var msg struct {
+		Ts json.Number `json:"ts"`
+	}
+	if err := json.Unmarshal([]byte(message), &msg); err != nil || msg.Ts == "" {
+		return
+	}
+	ms, err := msg.Ts.Int64()
+	if err != nil || ms <= 0 {
+		return
+	}
+	e.AddPush(time.UnixMilli(ms), received)
+}
+
+// Tap is HandleMessage for ws.BaseWsClient.SetRawTap.
+func (e *Estimator) Tap(frame []byte) {
+	e.HandleMessage(string(frame))
+}
+
+// Sync performs one round trip with ping, which returns the exchange time, e.g.
+// market.NewServerTimeService(client).Do.
+func (e *Estimator) Sync(ctx context.Context, ping func(ctx context.Context) (time.Time, error)) error {
+	sent := e.clock.Now()
+	server, err := ping(ctx)
+	received := e.clock.Now()
+	if err != nil {
+		return err
+	}
+	e.AddRoundTrip(sent, received, server)
+	return nil
+}
+
+// Run calls Sync every interval until ctx is cancelled.
+func (e *Estimator) Run(ctx context.Context, ping func(ctx context.Context) (time.Time, error), interval time.Duration, onError func(error)) {
+	ticker := e.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.Sync(ctx, ping); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// update recomputes the offset. Callers hold e.mu.
+func (e *Estimator) update() {
+	if len(e.trips) > 0 {
+		best := make([]roundTrip, len(e.trips))
+		copy(best, e.trips)
+		sort.Slice(best, func(i, j int) bool { return best[i].rtt < best[j].rtt })
+		e.offset = best[0].offset
+	}
+	if e.pushSet && (len(e.trips) == 0 || e.pushMax > e.offset) {
+		e.offset = e.pushMax
+	}
+}
@@ -0,0 +1,75 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimator_PrefersShortestRoundTrip(t *testing.T) {
+	e := NewEstimator(3)
+	local := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Exchange is 250ms ahead; the slow trip has asymmetric latency and misleads
+	e.AddRoundTrip(local, local.Add(400*time.Millisecond), local.Add(250*time.Millisecond+350*time.Millisecond))
+	assert.Equal(t, 400*time.Millisecond, e.Offset())
+	e.AddRoundTrip(local, local.Add(20*time.Millisecond), local.Add(260*time.Millisecond))
+	assert.Equal(t, 250*time.Millisecond, e.Offset())
+
+	// A push stamped later than the estimate allows raises it
+	e.AddPush(local.Add(1300*time.Millisecond), local.Add(time.Second))
+	assert.Equal(t, 300*time.Millisecond, e.Offset())
+	e.AddPush(local.Add(1100*time.Millisecond), local.Add(time.Second))
+	assert.Equal(t, 300*time.Millisecond, e.Offset(), "lower bounds below the estimate are ignored")
+
+	// The next round trip resets the push bound
+	e.AddRoundTrip(local, local.Add(20*time.Millisecond), local.Add(260*time.Millisecond))
+	assert.Equal(t, 250*time.Millisecond, e.Offset())
+}
+
+func TestEstimator_HandleMessageAndSync(t *testing.T) {
+	local := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	e := NewEstimator(0)
+	e.SetClock(common.NewFakeClock(local))
+
+	e.HandleMessage(`{"action":"snapshot","arg":{"channel":"candle1m"},"data":[],"ts":` +
+		strconv.FormatInt(local.Add(80*time.Millisecond).UnixMilli(), 10) + `}`)
+	assert.Equal(t, 80*time.Millisecond, e.Offset())
+	e.Tap([]byte(`pong`))
+	assert.Equal(t, 80*time.Millisecond, e.Offset())
+
+	// A round trip replaces the push bound gathered before it
+	require.NoError(t, e.Sync(context.Background(), func(ctx context.Context) (time.Time, error) {
+		return local.Add(-2 * time.Second), nil
+	}))
+	assert.Equal(t, -2*time.Second, e.Offset())
+
+	assert.Error(t, e.Sync(context.Background(), func(ctx context.Context) (time.Time, error) {
+		return time.Time{}, errors.New("timeout")
+	}))
+}
+
+func TestScheduler_LocalAndSleepUntil(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	// Exchange is 2s ahead of the local clock
+	s, clock := newTestScheduler(start, StaticOffset(2*time.Second))
+
+	closeAt, err := CandleClose(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), "1m")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 1, 0, 0, time.UTC), closeAt)
+
+	assert.Equal(t, start.Add(58*time.Second), s.Local(closeAt))
+	assert.Equal(t, 58*time.Second, s.Until(closeAt))
+
+	require.NoError(t, s.SleepUntil(context.Background(), closeAt))
+	assert.Equal(t, start.Add(58*time.Second), clock.t)
+
+	_, err = CandleClose(start, "1M")
+	assert.Error(t, err, "months have no fixed length")
+}
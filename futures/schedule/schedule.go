@@ -0,0 +1,170 @@
+// Package schedule triggers callbacks aligned to exchange time: candle boundaries, funding
+// timestamps or any fixed interval since the Unix epoch.
+//
+// Bitget closes candles and settles funding on its own clock, so a local time.Ticker
+// started at an arbitrary moment fires at the wrong instant and drifts with clock skew.
+// A Scheduler computes boundaries in exchange time using an OffsetSource (server time
+// minus local time) and sleeps until the next one:
+//
+//	s := schedule.New(schedule.StaticOffset(0))
+//	go s.EveryCandle(ctx, "15m", 2*time.Second, func(boundary time.Time) {
+//		// runs 2s after every 15m candle close
+//	})
+//	go s.BeforeFunding(ctx, 8*time.Hour, time.Minute, func(funding time.Time) {
+//		// runs one minute before each funding settlement
+//	})
+//
+// An Estimator measures the offset from REST round trips and WebSocket push timestamps.
+// Local and SleepUntil map exchange timestamps, such as the close of a streamed candle,
+// to the local monotonic clock:
+//
+//	closeAt, _ := schedule.CandleClose(candle.Time, "1m")
+//	s.SleepUntil(ctx, closeAt.Add(50*time.Millisecond))
+package schedule
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures/candles"
+)
+
+// OffsetSource reports the difference between exchange time and local time.
+type OffsetSource interface {
+	Offset() time.Duration
+}
+
+// StaticOffset is a fixed offset.
+type StaticOffset time.Duration
+
+// Offset implements OffsetSource.
+func (o StaticOffset) Offset() time.Duration { return time.Duration(o) }
+
+// SyncedOffset is an offset that can be updated concurrently, e.g. by a periodic
+// server time probe.
+type SyncedOffset struct {
+	v atomic.Int64
+}
+
+// Offset implements OffsetSource.
+func (o *SyncedOffset) Offset() time.Duration { return time.Duration(o.v.Load()) }
+
+// Set stores a new offset.
+func (o *SyncedOffset) Set(d time.Duration) { o.v.Store(int64(d)) }
+
+// Scheduler fires callbacks on exchange-aligned boundaries.
+type Scheduler struct {
+	offset OffsetSource
+	now    func() time.Time
+	after  func(d time.Duration) <-chan time.Time
+}
+
+// New creates a scheduler. A nil offset assumes the local clock matches the exchange.
+func New(offset OffsetSource) *Scheduler {
+	if offset == nil {
+		offset = StaticOffset(0)
+	}
+	return &Scheduler{offset: offset, now: time.Now, after: time.After}
+}
+
+// SetClock replaces the local time source, e.g. with a common.FakeClock in tests. Call
+// it before starting any loop.
+func (s *Scheduler) SetClock(clock common.Clock) {
+	clock = common.ClockOrSystem(clock)
+	s.now, s.after = clock.Now, clock.After
+}
+
+// Now returns the current exchange time.
+func (s *Scheduler) Now() time.Time {
+	return s.now().Add(s.offset.Offset())
+}
+
+// Local converts an exchange timestamp, e.g. a candle or message ts, to local time. The
+// result carries a monotonic clock reading, so comparisons with time.Now and timers
+// derived from it are immune to wall clock steps.
+func (s *Scheduler) Local(exchange time.Time) time.Time {
+	now := s.now()
+	return now.Add(exchange.Sub(now.Add(s.offset.Offset())))
+}
+
+// Until returns the local duration until an exchange timestamp.
+func (s *Scheduler) Until(exchange time.Time) time.Duration {
+	return exchange.Sub(s.Now())
+}
+
+// SleepUntil blocks until the exchange timestamp is reached or ctx is done.
+func (s *Scheduler) SleepUntil(ctx context.Context, exchange time.Time) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.after(s.Until(exchange)):
+		return nil
+	}
+}
+
+// CandleClose returns the exchange time a candle opened at open closes.
+func CandleClose(open time.Time, granularity string) (time.Time, error) {
+	interval, err := candles.GranularityDuration(granularity)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return open.Add(interval), nil
+}
+
+// NextBoundary returns the first multiple of interval since the Unix epoch strictly after t.
+func NextBoundary(t time.Time, interval time.Duration) time.Time {
+	step := interval.Nanoseconds()
+	ns := t.UnixNano()
+	next := ns - ns%step + step
+	return time.Unix(0, next).In(t.Location())
+}
+
+// Every calls fn at every interval boundary shifted by delay (negative delay fires early)
+// until ctx is cancelled. fn receives the boundary in exchange time. Calls are sequential;
+// a boundary that passes while fn is still running is skipped.
+func (s *Scheduler) Every(ctx context.Context, interval, delay time.Duration, fn func(boundary time.Time)) {
+	if interval <= 0 {
+		return
+	}
+	var last time.Time
+	for ctx.Err() == nil {
+		now := s.Now()
+		boundary := NextBoundary(now.Add(-delay), interval)
+		// Guard against firing twice for one boundary when the offset moves backwards
+		if !last.IsZero() && !boundary.After(last) {
+			boundary = last.Add(interval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.after(boundary.Add(delay).Sub(now)):
+		}
+		last = boundary
+		fn(boundary)
+	}
+}
+
+// EveryCandle calls fn after every candle close of the given granularity ("1m", "4H", ...).
+// Returns immediately when the granularity has no fixed length.
+func (s *Scheduler) EveryCandle(ctx context.Context, granularity string, delay time.Duration, fn func(boundary time.Time)) error {
+	interval, err := candles.GranularityDuration(granularity)
+	if err != nil {
+		return err
+	}
+	s.Every(ctx, interval, delay, fn)
+	return nil
+}
+
+// BeforeFunding calls fn lead before each funding settlement. Bitget settles funding on
+// multiples of the contract's funding interval (Contract.FundInterval hours) in UTC.
+func (s *Scheduler) BeforeFunding(ctx context.Context, fundingInterval, lead time.Duration, fn func(funding time.Time)) {
+	s.Every(ctx, fundingInterval, -lead, fn)
+}
+
+// NextFunding returns the next funding settlement time for the given interval.
+func (s *Scheduler) NextFunding(fundingInterval time.Duration) time.Time {
+	return NextBoundary(s.Now(), fundingInterval)
+}
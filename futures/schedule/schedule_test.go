@@ -0,0 +1,115 @@
+package schedule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock advances instantly whenever the scheduler waits.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) after(d time.Duration) <-chan time.Time {
+	c.t = c.t.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.t
+	return ch
+}
+
+func newTestScheduler(start time.Time, offset OffsetSource) (*Scheduler, *fakeClock) {
+	clock := &fakeClock{t: start}
+	s := New(offset)
+	s.now = clock.now
+	s.after = clock.after
+	return s, clock
+}
+
+func TestNextBoundary(t *testing.T) {
+	at := time.Date(2024, 1, 1, 10, 7, 30, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC), NextBoundary(at, 15*time.Minute))
+	assert.Equal(t, time.Date(2024, 1, 1, 16, 0, 0, 0, time.UTC), NextBoundary(at, 8*time.Hour))
+
+	exact := time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC)
+	assert.Equal(t, exact.Add(15*time.Minute), NextBoundary(exact, 15*time.Minute), "strictly after")
+}
+
+func TestScheduler_EveryCandleWithDelayAndOffset(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 7, 30, 0, time.UTC)
+	// Local clock is 3s behind the exchange
+	s, clock := newTestScheduler(start, StaticOffset(3*time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var boundaries, fired []time.Time
+	err := s.EveryCandle(ctx, "15m", 2*time.Second, func(b time.Time) {
+		boundaries = append(boundaries, b)
+		fired = append(fired, clock.t)
+		if len(boundaries) == 3 {
+			cancel()
+		}
+	})
+	require.NoError(t, err)
+
+	require.Len(t, boundaries, 3)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC), boundaries[0])
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 45, 0, 0, time.UTC), boundaries[2])
+	// Fires at local 10:14:59 == exchange 10:15:02
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 14, 59, 0, time.UTC), fired[0])
+}
+
+func TestScheduler_BeforeFunding(t *testing.T) {
+	start := time.Date(2024, 1, 1, 7, 59, 30, 0, time.UTC)
+	s, clock := newTestScheduler(start, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var got []time.Time
+	s.BeforeFunding(ctx, 8*time.Hour, time.Minute, func(funding time.Time) {
+		got = append(got, funding)
+		assert.Equal(t, funding.Add(-time.Minute), clock.t)
+		cancel()
+	})
+
+	// 07:59:30 is already inside the lead window for 08:00, so the next one is 16:00
+	require.Len(t, got, 1)
+	assert.Equal(t, time.Date(2024, 1, 1, 16, 0, 0, 0, time.UTC), got[0])
+	// The clock now sits one minute before the 16:00 settlement
+	assert.Equal(t, time.Date(2024, 1, 1, 16, 0, 0, 0, time.UTC), s.NextFunding(8*time.Hour))
+}
+
+func TestScheduler_InvalidGranularity(t *testing.T) {
+	s := New(nil)
+	assert.Error(t, s.EveryCandle(context.Background(), "1M", 0, func(time.Time) {}))
+}
+
+func TestSyncedOffset(t *testing.T) {
+	var o SyncedOffset
+	o.Set(250 * time.Millisecond)
+	assert.Equal(t, 250*time.Millisecond, o.Offset())
+}
+
+func TestScheduler_SetClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 7, 30, 0, time.UTC)
+	clock := common.NewFakeClock(start)
+	s := New(StaticOffset(time.Second))
+	s.SetClock(clock)
+	assert.Equal(t, start.Add(time.Second), s.Now())
+
+	done := make(chan error)
+	go func() { done <- s.SleepUntil(context.Background(), time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC)) }()
+	clock.BlockUntil(1)
+	clock.Advance(7*time.Minute + 28*time.Second)
+	select {
+	case <-done:
+		t.Fatal("woke a second early")
+	default:
+	}
+	clock.Advance(time.Second)
+	assert.NoError(t, <-done)
+}
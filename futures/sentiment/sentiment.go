@@ -0,0 +1,212 @@
+// Package sentiment polls the public positioning statistics of futures symbols: the
+// account long/short ratio, the elite trader position ratio and the taker buy/sell
+// volume. Every new period is published once as a typed Event:
+//
+//	p := sentiment.New(client, sentiment.Options{
+//		Symbols: []string{"BTCUSDT", "ETHUSDT"},
+//		Period:  market.SentimentPeriod15m,
+//	})
+//	p.OnEvent(func(e sentiment.Event) {
+//		if e.Kind == sentiment.KindElitePosition && e.Value > 2 {
+//			log.Printf("%s elite traders %.1fx long", e.Symbol, e.Value)
+//		}
+//	})
+//	go p.Run(ctx, onError)
+//
+// The first poll reports the history returned by the exchange, later polls only periods
+// not seen before.
+package sentiment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+)
+
+// Kind identifies a statistic.
+type Kind string
+
+const (
+	KindLongShort     Kind = "long_short"     // Account long/short ratio
+	KindElitePosition Kind = "elite_position" // Elite trader long/short position ratio
+	KindTakerVolume   Kind = "taker_volume"   // Taker buy/sell volume
+)
+
+// Event is one period of a statistic. Only the record matching Kind is set.
+type Event struct {
+	Kind   Kind
+	Symbol string
+	Time   time.Time
+	// Value is the headline ratio: long/short accounts, elite long/short positions or
+	// taker buy/sell volume.
+	Value float64
+
+	LongShort     market.LongShortRatio
+	ElitePosition market.ElitePositionRatio
+	TakerVolume   market.TakerVolume
+}
+
+// Options configures a Poller.
+type Options struct {
+	Symbols []string
+	// Kinds are the statistics polled. Defaults to all of them.
+	Kinds []Kind
+	// Period is the aggregation period. Defaults to market.SentimentPeriod5m.
+	Period market.SentimentPeriod
+	// Interval is the polling interval of Run. Defaults to one minute, so new periods
+	// are seen shortly after they are published.
+	Interval time.Duration
+}
+
+type seriesKey struct {
+	kind   Kind
+	symbol string
+}
+
+// Poller fetches sentiment statistics and publishes new periods. It is safe for
+// concurrent use.
+type Poller struct {
+	client futures.ClientInterface
+	opts   Options
+
+	mu       sync.RWMutex
+	latest   map[seriesKey]Event
+	handlers []func(Event)
+}
+
+// New creates a poller.
+func New(client futures.ClientInterface, opts Options) *Poller {
+	if len(opts.Kinds) == 0 {
+		opts.Kinds = []Kind{KindLongShort, KindElitePosition, KindTakerVolume}
+	}
+	if opts.Period == "" {
+		opts.Period = market.SentimentPeriod5m
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = time.Minute
+	}
+	return &Poller{client: client, opts: opts, latest: make(map[seriesKey]Event)}
+}
+
+// OnEvent registers a handler for new periods.
+func (p *Poller) OnEvent(fn func(Event)) {
+	p.mu.Lock()
+	p.handlers = append(p.handlers, fn)
+	p.mu.Unlock()
+}
+
+// Poll fetches every configured statistic and symbol and returns the periods not seen
+// before, oldest first, after passing them to the handlers. A failing request stops
+// the poll; periods fetched before it are still published.
+func (p *Poller) Poll(ctx context.Context) ([]Event, error) {
+	var fresh []Event
+	var err error
+	for _, symbol := range p.opts.Symbols {
+		for _, kind := range p.opts.Kinds {
+			var events []Event
+			if events, err = p.fetch(ctx, kind, symbol); err != nil {
+				err = fmt.Errorf("sentiment: %s %s: %w", symbol, kind, err)
+				break
+			}
+			fresh = append(fresh, p.record(kind, symbol, events)...)
+		}
+		if err != nil {
+			break
+		}
+	}
+	sort.SliceStable(fresh, func(i, j int) bool { return fresh[i].Time.Before(fresh[j].Time) })
+
+	p.mu.RLock()
+	handlers := append([]func(Event){}, p.handlers...)
+	p.mu.RUnlock()
+	for _, e := range fresh {
+		for _, fn := range handlers {
+			fn(e)
+		}
+	}
+	return fresh, err
+}
+
+// Run calls Poll every Interval until ctx is cancelled.
+func (p *Poller) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(p.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Poll(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Latest returns the most recent period of a statistic for symbol.
+func (p *Poller) Latest(kind Kind, symbol string) (Event, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	e, ok := p.latest[seriesKey{kind, symbol}]
+	return e, ok
+}
+
+func (p *Poller) fetch(ctx context.Context, kind Kind, symbol string) ([]Event, error) {
+	var events []Event
+	switch kind {
+	case KindLongShort:
+		ratios, err := market.NewLongShortRatioService(p.client).Symbol(symbol).Period(p.opts.Period).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range ratios {
+			events = append(events, Event{Kind: kind, Symbol: symbol, Time: r.Time(), Value: r.LongShortRatio, LongShort: r})
+		}
+	case KindElitePosition:
+		ratios, err := market.NewElitePositionRatioService(p.client).Symbol(symbol).Period(p.opts.Period).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range ratios {
+			events = append(events, Event{Kind: kind, Symbol: symbol, Time: r.Time(), Value: r.LongShortRatio, ElitePosition: r})
+		}
+	case KindTakerVolume:
+		volumes, err := market.NewTakerVolumeService(p.client).Symbol(symbol).Period(p.opts.Period).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range volumes {
+			events = append(events, Event{Kind: kind, Symbol: symbol, Time: v.Time(), Value: v.Ratio(), TakerVolume: v})
+		}
+	default:
+		return nil, fmt.Errorf("unknown kind %q", kind)
+	}
+	return events, nil
+}
+
+// record returns the events newer than the latest recorded one of the series, which
+// come oldest first, and remembers the newest.
+func (p *Poller) record(kind Kind, symbol string, events []Event) []Event {
+	key := seriesKey{kind, symbol}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	last, seen := p.latest[key]
+	var fresh []Event
+	for _, e := range events {
+		if seen && !e.Time.After(last.Time) {
+			continue
+		}
+		fresh = append(fresh, e)
+	}
+	if len(fresh) > 0 {
+		p.latest[key] = fresh[len(fresh)-1]
+	}
+	return fresh
+}
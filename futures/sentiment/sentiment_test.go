@@ -0,0 +1,79 @@
+package sentiment
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeClient struct {
+	data  map[string]string
+	fail  string
+	calls []url.Values
+}
+
+func (c *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.calls = append(c.calls, query)
+	if endpoint == c.fail {
+		return nil, nil, errors.New("boom")
+	}
+	return &futures.ApiResponse{Code: "00000", Data: []byte(c.data[endpoint])}, &fasthttp.ResponseHeader{}, nil
+}
+
+func TestPoller_Poll(t *testing.T) {
+	client := &fakeClient{data: map[string]string{
+		market.EndpointAccountLongShort: `[
+			{"longAccountRatio":"0.6","shortAccountRatio":"0.4","longShortAccountRatio":"1.5","ts":"1700000900000"},
+			{"longAccountRatio":"0.5","shortAccountRatio":"0.5","longShortAccountRatio":"1","ts":"1700000000000"}]`,
+		market.EndpointPositionLongShort: `[{"longPositionRatio":"0.7","shortPositionRatio":"0.3","longShortPositionRatio":"2.33","ts":"1700000000000"}]`,
+		market.EndpointTakerBuySell:      `[{"buyVolume":"30","sellVolume":"20","ts":"1700000900000"}]`,
+	}}
+	p := New(client, Options{Symbols: []string{"BTCUSDT"}, Period: market.SentimentPeriod15m})
+	var handled []Event
+	p.OnEvent(func(e Event) { handled = append(handled, e) })
+
+	events, err := p.Poll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	assert.Equal(t, handled, events)
+	assert.Equal(t, "15m", client.calls[0].Get("period"))
+	for i := 1; i < len(events); i++ {
+		assert.False(t, events[i].Time.Before(events[i-1].Time), "oldest first")
+	}
+
+	elite, ok := p.Latest(KindElitePosition, "BTCUSDT")
+	require.True(t, ok)
+	assert.Equal(t, 2.33, elite.Value)
+	assert.Equal(t, 0.7, elite.ElitePosition.LongPositionRatio)
+	taker, _ := p.Latest(KindTakerVolume, "BTCUSDT")
+	assert.Equal(t, 1.5, taker.Value)
+	longShort, _ := p.Latest(KindLongShort, "BTCUSDT")
+	assert.Equal(t, int64(1700000900000), longShort.Time.UnixMilli())
+
+	client.data[market.EndpointTakerBuySell] = `[
+		{"buyVolume":"30","sellVolume":"20","ts":"1700000900000"},
+		{"buyVolume":"10","sellVolume":"40","ts":"1700001800000"}]`
+	events, err = p.Poll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 1, "only new periods")
+	assert.Equal(t, KindTakerVolume, events[0].Kind)
+	assert.Equal(t, 0.25, events[0].Value)
+}
+
+func TestPoller_Error(t *testing.T) {
+	client := &fakeClient{fail: market.EndpointTakerBuySell, data: map[string]string{
+		market.EndpointAccountLongShort: `[{"longShortAccountRatio":"1","ts":"1700000000000"}]`,
+	}}
+	p := New(client, Options{Symbols: []string{"BTCUSDT"}, Kinds: []Kind{KindLongShort, KindTakerVolume}})
+
+	events, err := p.Poll(context.Background())
+	assert.EqualError(t, err, "sentiment: BTCUSDT taker_volume: boom")
+	assert.Len(t, events, 1, "periods fetched before the failure are kept")
+}
@@ -0,0 +1,329 @@
+// Package session records what a trading session did and reports it on shutdown.
+//
+// A Recorder counts orders from the order tracker, fees and realized profit from the
+// private fill channel and API errors from a wrapped REST client. On Shutdown it
+// builds a Report, writes it as JSON and passes it to the configured hooks:
+//
+//	rec := session.New(session.Options{
+//		Path:       "reports/session.json",
+//		Equity:     func(ctx context.Context) (float64, error) { return accountEquity(ctx, client) },
+//		Reconnects: wsClient.Reconnects,
+//		Hooks:      []session.Hook{func(ctx context.Context, r session.Report) error { return notify(ctx, r.String()) }},
+//	})
+//	_ = rec.Start(ctx)
+//	client := rec.Wrap(futuresClient)
+//	orders.OnEvent(rec.HandleOrderEvent)
+//	wsClient.SubscribeFills("", "USDT-FUTURES", rec.HandleFill)
+//	lm.OnFlush("session", rec.Shutdown)
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/khanbekov/go-bitget/ws"
+	"github.com/valyala/fasthttp"
+)
+
+// Hook receives the report on shutdown, e.g. to post it to a chat.
+type Hook func(ctx context.Context, r Report) error
+
+// Options configures a Recorder.
+type Options struct {
+	// Path is the file the JSON report is written to on Shutdown. Empty skips the file.
+	Path string
+	// Hooks receive the report on Shutdown, in order. A failing hook does not stop the
+	// others.
+	Hooks []Hook
+	// Equity returns the account equity. When set, it is sampled by Start and Report
+	// and the report's PnLDelta is the equity change. Optional.
+	Equity func(ctx context.Context) (float64, error)
+	// Reconnects returns the WebSocket reconnection count, e.g.
+	// ws.BaseWsClient.Reconnects. Optional.
+	Reconnects func() int64
+	// Clock defaults to the system clock.
+	Clock common.Clock
+}
+
+// OrderCounts counts order outcomes.
+type OrderCounts struct {
+	Placed   int `json:"placed"`
+	Filled   int `json:"filled"`
+	Canceled int `json:"canceled"`
+}
+
+// Report summarizes a session.
+type Report struct {
+	Start         time.Time   `json:"start"`
+	End           time.Time   `json:"end"`
+	UptimeSeconds float64     `json:"uptimeSeconds"`
+	Orders        OrderCounts `json:"orders"`
+	Volume        float64     `json:"volume"`      // Filled notional in quote currency
+	Fees          float64     `json:"fees"`        // Fees paid; rebates are negative
+	RealizedPnL   float64     `json:"realizedPnl"` // Profit of closing fills, before fees
+	// PnLDelta is the equity change when Options.Equity is set, otherwise realized
+	// profit net of fees.
+	PnLDelta    float64          `json:"pnlDelta"`
+	EquityStart *float64         `json:"equityStart,omitempty"`
+	EquityEnd   *float64         `json:"equityEnd,omitempty"`
+	APICalls    int64            `json:"apiCalls"`
+	APIErrors   map[string]int64 `json:"apiErrors,omitempty"` // By error code; "transport" for failures without one
+	Reconnects  int64            `json:"reconnects"`
+}
+
+// Uptime returns the session duration.
+func (r Report) Uptime() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// String returns a compact one-line summary.
+func (r Report) String() string {
+	var errs int64
+	for _, n := range r.APIErrors {
+		errs += n
+	}
+	return common.Describe("Session", r.Uptime().Round(time.Second).String(),
+		fmt.Sprintf("orders=%d/%d/%d", r.Orders.Placed, r.Orders.Filled, r.Orders.Canceled),
+		"volume="+common.FormatFloat(r.Volume), "fees="+common.FormatFloat(r.Fees),
+		"pnl="+common.FormatFloat(r.PnLDelta), fmt.Sprintf("apiErrors=%d", errs),
+		fmt.Sprintf("reconnects=%d", r.Reconnects))
+}
+
+// Recorder collects session statistics. It is safe for concurrent use.
+type Recorder struct {
+	opts  Options
+	clock common.Clock
+
+	mu          sync.Mutex
+	start       time.Time
+	equityStart *float64
+	orders      OrderCounts
+	volume      float64
+	fees        float64
+	realized    float64
+	apiCalls    int64
+	apiErrors   map[string]int64
+	fills       map[string]bool // Trade IDs already counted
+}
+
+// New creates a recorder; the session starts now.
+func New(opts Options) *Recorder {
+	clock := common.ClockOrSystem(opts.Clock)
+	return &Recorder{
+		opts:      opts,
+		clock:     clock,
+		start:     clock.Now(),
+		apiErrors: make(map[string]int64),
+		fills:     make(map[string]bool),
+	}
+}
+
+// Start restarts the session clock and samples the starting equity.
+func (r *Recorder) Start(ctx context.Context) error {
+	var equity *float64
+	if r.opts.Equity != nil {
+		v, err := r.opts.Equity(ctx)
+		if err != nil {
+			return fmt.Errorf("session: starting equity: %w", err)
+		}
+		equity = &v
+	}
+	r.mu.Lock()
+	r.start, r.equityStart = r.clock.Now(), equity
+	r.mu.Unlock()
+	return nil
+}
+
+// Wrap returns a client that counts the calls and errors of next.
+func (r *Recorder) Wrap(next futures.ClientInterface) futures.ClientInterface {
+	return &client{next: next, rec: r}
+}
+
+// HandleOrderEvent counts placed, filled and canceled orders and the filled volume.
+// Pass it to tracker.OrderTracker.OnEvent.
+func (r *Recorder) HandleOrderEvent(ev tracker.OrderEvent) {
+	notional := ev.Order.FilledSize * ev.Order.AvgPrice
+	if ev.Previous != nil {
+		notional -= ev.Previous.FilledSize * ev.Previous.AvgPrice
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if ev.Previous == nil {
+		r.orders.Placed++
+	}
+	switch ev.Type {
+	case tracker.OrderFilled:
+		r.orders.Filled++
+	case tracker.OrderCanceled:
+		r.orders.Canceled++
+	}
+	if notional > 0 {
+		r.volume += notional
+	}
+}
+
+// wsFill is an entry of the private fill channel.
+type wsFill struct {
+	TradeID   string `json:"tradeId"`
+	Profit    string `json:"profit"`
+	FeeDetail []struct {
+		TotalFee string `json:"totalFee"`
+	} `json:"feeDetail"`
+}
+
+// HandleFill is a ws.OnReceive for the private fill channel. It adds the fees and
+// realized profit of each fill once.
+func (r *Recorder) HandleFill(message string) {
+	var msg ws.WebSocketMessage
+	if json.Unmarshal([]byte(message), &msg) != nil || msg.Arg.Channel != ws.ChannelFill {
+		return
+	}
+	var fills []wsFill
+	if json.Unmarshal(msg.Data, &fills) != nil {
+		return
+	}
+	for _, f := range fills {
+		if f.TradeID != "" {
+			r.mu.Lock()
+			seen := r.fills[f.TradeID]
+			r.fills[f.TradeID] = true
+			r.mu.Unlock()
+			if seen {
+				continue
+			}
+		}
+		var fee float64
+		for _, d := range f.FeeDetail {
+			v, _ := strconv.ParseFloat(d.TotalFee, 64)
+			fee -= v // Reported negative when paid
+		}
+		profit, _ := strconv.ParseFloat(f.Profit, 64)
+		r.AddFill(fee, profit)
+	}
+}
+
+// AddFill adds the fee paid and the realized profit of a fill from another source,
+// such as simulated fills in a dry run.
+func (r *Recorder) AddFill(fee, profit float64) {
+	r.mu.Lock()
+	r.fees += fee
+	r.realized += profit
+	r.mu.Unlock()
+}
+
+// Report builds the report of the session so far, sampling the current equity. An
+// equity error is returned along with the report, whose PnLDelta then falls back to
+// realized profit net of fees.
+func (r *Recorder) Report(ctx context.Context) (Report, error) {
+	var (
+		equity *float64
+		err    error
+	)
+	if r.opts.Equity != nil {
+		v, eqErr := r.opts.Equity(ctx)
+		if eqErr != nil {
+			err = fmt.Errorf("session: ending equity: %w", eqErr)
+		} else {
+			equity = &v
+		}
+	}
+
+	r.mu.Lock()
+	rep := Report{
+		Start:       r.start,
+		End:         r.clock.Now(),
+		Orders:      r.orders,
+		Volume:      r.volume,
+		Fees:        r.fees,
+		RealizedPnL: r.realized,
+		PnLDelta:    r.realized - r.fees,
+		EquityStart: r.equityStart,
+		EquityEnd:   equity,
+		APICalls:    r.apiCalls,
+	}
+	if len(r.apiErrors) > 0 {
+		rep.APIErrors = make(map[string]int64, len(r.apiErrors))
+		for code, n := range r.apiErrors {
+			rep.APIErrors[code] = n
+		}
+	}
+	r.mu.Unlock()
+
+	rep.UptimeSeconds = rep.Uptime().Seconds()
+	if rep.EquityStart != nil && rep.EquityEnd != nil {
+		rep.PnLDelta = *rep.EquityEnd - *rep.EquityStart
+	}
+	if r.opts.Reconnects != nil {
+		rep.Reconnects = r.opts.Reconnects()
+	}
+	return rep, err
+}
+
+// Shutdown builds the report, writes it to Options.Path and runs the hooks. Errors
+// are joined; the report is delivered even if the equity sample failed. It matches
+// the signature of lifecycle.Manager.OnFlush.
+func (r *Recorder) Shutdown(ctx context.Context) error {
+	rep, err := r.Report(ctx)
+	errs := []error{err}
+	if r.opts.Path != "" {
+		errs = append(errs, WriteFile(r.opts.Path, rep))
+	}
+	for i, hook := range r.opts.Hooks {
+		if err := hook(ctx, rep); err != nil {
+			errs = append(errs, fmt.Errorf("session: hook %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WriteFile writes the report as indented JSON, creating the directory if needed.
+func WriteFile(path string, rep Report) error {
+	data, err := json.MarshalIndent(rep, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("session: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("session: %w", err)
+	}
+	return nil
+}
+
+// client counts the calls and errors of the wrapped client.
+type client struct {
+	next futures.ClientInterface
+	rec  *Recorder
+}
+
+func (c *client) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	res, header, err := c.next.CallAPI(ctx, method, endpoint, query, body, sign)
+	c.rec.mu.Lock()
+	c.rec.apiCalls++
+	if err != nil && !errors.Is(err, context.Canceled) {
+		c.rec.apiErrors[errorCode(err)]++
+	}
+	c.rec.mu.Unlock()
+	return res, header, err
+}
+
+func errorCode(err error) string {
+	var apiErr *types.APIError
+	if errors.As(err, &apiErr) && apiErr.Code != 0 {
+		return strconv.FormatInt(apiErr.Code, 10)
+	}
+	return "transport"
+}
@@ -0,0 +1,106 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeClient struct {
+	errs []error
+}
+
+func (c *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	var err error
+	if len(c.errs) > 0 {
+		err, c.errs = c.errs[0], c.errs[1:]
+	}
+	return &futures.ApiResponse{Code: "00000"}, &fasthttp.ResponseHeader{}, err
+}
+
+func TestRecorder_Report(t *testing.T) {
+	clock := common.NewFakeClock(time.UnixMilli(1700000000000))
+	equity := 1000.0
+	rec := New(Options{
+		Clock:      clock,
+		Equity:     func(ctx context.Context) (float64, error) { return equity, nil },
+		Reconnects: func() int64 { return 2 },
+	})
+	ctx := context.Background()
+	require.NoError(t, rec.Start(ctx))
+
+	orders := tracker.NewOrderTracker(tracker.Options{})
+	orders.OnEvent(rec.HandleOrderEvent)
+	orders.Update(
+		tracker.Order{OrderID: "1", Symbol: "BTCUSDT", Size: 2, Status: tracker.StatusLive},
+		tracker.Order{OrderID: "2", Symbol: "BTCUSDT", Size: 1, Status: tracker.StatusLive},
+	)
+	orders.Update(tracker.Order{OrderID: "1", Symbol: "BTCUSDT", Size: 2, FilledSize: 1, AvgPrice: 100, Status: tracker.StatusPartiallyFilled})
+	orders.Update(tracker.Order{OrderID: "1", Symbol: "BTCUSDT", Size: 2, FilledSize: 2, AvgPrice: 101, Status: tracker.StatusFilled})
+	orders.Update(tracker.Order{OrderID: "2", Symbol: "BTCUSDT", Size: 1, Status: tracker.StatusCanceled})
+
+	fill := `{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"fill","instId":"default"},"data":[{"tradeId":"t1","profit":"5","feeDetail":[{"feeCoin":"USDT","totalFee":"-0.12"}]},{"tradeId":"t2","profit":"0","feeDetail":[{"feeCoin":"USDT","totalFee":"-0.08"}]}]}`
+	rec.HandleFill(fill)
+	rec.HandleFill(fill) // Redelivered fills are counted once
+
+	client := rec.Wrap(&fakeClient{errs: []error{nil, &types.APIError{Code: 40017}, errors.New("timeout"), context.Canceled}})
+	for i := 0; i < 4; i++ {
+		client.CallAPI(ctx, "GET", futures.EndpointAllTickers, nil, nil, false)
+	}
+
+	clock.Advance(90 * time.Minute)
+	equity = 1004.5
+	rep, err := rec.Report(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, OrderCounts{Placed: 2, Filled: 1, Canceled: 1}, rep.Orders)
+	assert.InDelta(t, 202.0, rep.Volume, 1e-9)
+	assert.InDelta(t, 0.2, rep.Fees, 1e-9)
+	assert.Equal(t, 5.0, rep.RealizedPnL)
+	assert.Equal(t, 4.5, rep.PnLDelta, "equity change")
+	assert.Equal(t, 90*time.Minute, rep.Uptime())
+	assert.Equal(t, 5400.0, rep.UptimeSeconds)
+	assert.Equal(t, int64(4), rep.APICalls)
+	assert.Equal(t, map[string]int64{"40017": 1, "transport": 1}, rep.APIErrors)
+	assert.Equal(t, int64(2), rep.Reconnects)
+	assert.Equal(t, "Session{1h30m0s orders=2/1/1 volume=202 fees=0.2 pnl=4.5 apiErrors=2 reconnects=2}", rep.String())
+}
+
+func TestRecorder_Shutdown(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", "session.json")
+	var hooked []Report
+	rec := New(Options{
+		Path:   path,
+		Equity: func(ctx context.Context) (float64, error) { return 0, errors.New("unavailable") },
+		Hooks: []Hook{
+			func(ctx context.Context, r Report) error { return errors.New("chat down") },
+			func(ctx context.Context, r Report) error { hooked = append(hooked, r); return nil },
+		},
+	})
+	rec.AddFill(0.5, 3)
+
+	err := rec.Shutdown(context.Background())
+	assert.ErrorContains(t, err, "ending equity: unavailable")
+	assert.ErrorContains(t, err, "hook 0: chat down")
+	require.Len(t, hooked, 1, "a failing hook does not stop the others")
+	assert.Equal(t, 2.5, hooked[0].PnLDelta, "realized profit net of fees without equity")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var written Report
+	require.NoError(t, json.Unmarshal(data, &written))
+	assert.Equal(t, 2.5, written.PnLDelta)
+	assert.Nil(t, written.EquityEnd)
+}
@@ -0,0 +1,430 @@
+// Package signals receives trading signals from external alerting such as TradingView
+// webhooks and turns them into orders.
+//
+// A Handler is an http.Handler that authenticates each request, decodes the Signal,
+// maps it to an Intent and places the order through a futures.ClientInterface. Passing
+// a guard stack as the client routes every signal through the same risk checks as the
+// bots:
+//
+//	risk := guard.NewPolicy(guard.New(client, limits), policyOptions)
+//	h := signals.NewHandler(risk, signals.Options{
+//		Secret:      []byte(os.Getenv("WEBHOOK_SECRET")),
+//		ProductType: futures.ProductTypeUSDTFutures,
+//		MarginCoin:  "USDT",
+//		MarginMode:  trading.MarginModeCrossed,
+//		Symbols:     []string{"BTCUSDT", "ETHUSDT"},
+//	})
+//	http.Handle("/webhook", h)
+//
+// Requests are authenticated by an HMAC-SHA256 of the raw body in the X-Signature
+// header (hex, optionally prefixed with "sha256="). TradingView cannot set headers, so
+// a shared Passphrase field in the payload is accepted instead when
+// Options.Passphrase is set. A TradingView alert message looks like:
+//
+//	{"id":"{{strategy.order.id}}-{{timenow}}","symbol":"{{ticker}}","action":"{{strategy.order.action}}",
+//	 "size":"{{strategy.order.contracts}}","passphrase":"..."}
+//
+// Signal IDs are remembered for Options.MaxAge so a retried delivery places one order.
+package signals
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/guard"
+	"github.com/khanbekov/go-bitget/futures/trading"
+)
+
+// Signal actions.
+const (
+	ActionBuy        = "buy"         // Open or add to a long; reduces a short in one-way mode
+	ActionSell       = "sell"        // Open or add to a short; reduces a long in one-way mode
+	ActionCloseLong  = "close_long"  // Reduce-only sell of a long
+	ActionCloseShort = "close_short" // Reduce-only buy of a short
+)
+
+// Signal is the webhook payload.
+type Signal struct {
+	// ID identifies the alert; duplicates within MaxAge are rejected. Required.
+	ID     string `json:"id"`
+	Symbol string `json:"symbol"`
+	Action string `json:"action"`
+	Size   string `json:"size"`
+	// Price makes the order a limit order. Optional.
+	Price string `json:"price,omitempty"`
+	// TakeProfit and StopLoss are preset trigger prices. Optional.
+	TakeProfit string `json:"takeProfit,omitempty"`
+	StopLoss   string `json:"stopLoss,omitempty"`
+	// Timestamp is the alert time in Unix milliseconds; signals older than MaxAge are
+	// rejected. Optional.
+	Timestamp  int64  `json:"timestamp,omitempty"`
+	Strategy   string `json:"strategy,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+// Intent is the order a signal maps to.
+type Intent struct {
+	Symbol     string
+	Side       trading.Side
+	Close      bool // Closes or reduces a position instead of opening one
+	Size       string
+	Price      string // Empty for market orders
+	TakeProfit string
+	StopLoss   string
+	ClientOid  string
+	Signal     Signal
+}
+
+// Result reports the outcome of one signal.
+type Result struct {
+	Intent  Intent
+	OrderID string
+	Err     error
+	Time    time.Time
+}
+
+// Errors returned for rejected signals, wrapped with details.
+var (
+	ErrUnauthorized = errors.New("signals: invalid signature")
+	ErrInvalid      = errors.New("signals: invalid signal")
+	ErrStale        = errors.New("signals: stale signal")
+	ErrDuplicate    = errors.New("signals: duplicate signal")
+)
+
+// Options configures a Handler.
+type Options struct {
+	// Secret is the HMAC-SHA256 key for the X-Signature header.
+	Secret []byte
+	// Passphrase, when set, is accepted in the payload in place of a signature.
+	Passphrase string
+
+	ProductType futures.ProductType
+	MarginCoin  string
+	MarginMode  trading.MarginMode
+	// Hedge is set when the account uses hedge position mode.
+	Hedge bool
+
+	// Symbols restricts signals to these symbols. Empty allows every symbol.
+	Symbols []string
+	// MaxAge bounds the age of timestamped signals and how long IDs are remembered.
+	// Defaults to 5 minutes.
+	MaxAge time.Duration
+	// MaxBodyBytes bounds the request body. Defaults to 64 KiB.
+	MaxBodyBytes int64
+
+	// Map overrides the mapping of signals to intents. Defaults to DefaultMap.
+	Map func(Signal) (Intent, error)
+	// OnResult receives the outcome of every authenticated signal. Optional.
+	OnResult func(Result)
+	// Clock stamps results and expires remembered signal IDs. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// Handler is the webhook endpoint. It is safe for concurrent use.
+type Handler struct {
+	client  futures.ClientInterface
+	opts    Options
+	symbols map[string]bool
+	clock   common.Clock
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewHandler creates a handler placing orders through client.
+func NewHandler(client futures.ClientInterface, opts Options) *Handler {
+	if opts.MaxAge <= 0 {
+		opts.MaxAge = 5 * time.Minute
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = 64 << 10
+	}
+	if opts.Map == nil {
+		opts.Map = DefaultMap
+	}
+	h := &Handler{client: client, opts: opts, clock: common.ClockOrSystem(opts.Clock), seen: make(map[string]time.Time)}
+	if len(opts.Symbols) > 0 {
+		h.symbols = make(map[string]bool, len(opts.Symbols))
+		for _, s := range opts.Symbols {
+			h.symbols[strings.ToUpper(s)] = true
+		}
+	}
+	return h
+}
+
+// response is the JSON body returned to the sender.
+type response struct {
+	OrderID   string `json:"orderId,omitempty"`
+	ClientOid string `json:"clientOid,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, response{Error: "method not allowed"})
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, h.opts.MaxBodyBytes+1))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, response{Error: err.Error()})
+		return
+	}
+	if int64(len(body)) > h.opts.MaxBodyBytes {
+		writeJSON(w, http.StatusRequestEntityTooLarge, response{Error: "body too large"})
+		return
+	}
+
+	res := h.Handle(r.Context(), body, r.Header.Get("X-Signature"))
+	if res.Err != nil {
+		writeJSON(w, statusOf(res.Err), response{ClientOid: res.Intent.ClientOid, Error: res.Err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, response{OrderID: res.OrderID, ClientOid: res.Intent.ClientOid})
+}
+
+// Handle authenticates, validates and executes a raw payload. ServeHTTP calls it; it is
+// exported for transports other than HTTP.
+func (h *Handler) Handle(ctx context.Context, body []byte, signature string) Result {
+	var sig Signal
+	err := json.Unmarshal(body, &sig)
+	if !h.authenticate(body, signature, sig.Passphrase) {
+		// Not reported to OnResult: unauthenticated requests are noise
+		return Result{Err: ErrUnauthorized, Time: h.clock.Now()}
+	}
+	if err != nil {
+		res := Result{Err: fmt.Errorf("%w: %v", ErrInvalid, err), Time: h.clock.Now()}
+		if h.opts.OnResult != nil {
+			h.opts.OnResult(res)
+		}
+		return res
+	}
+	sig.Passphrase = ""
+
+	res := h.execute(ctx, sig)
+	if h.opts.OnResult != nil {
+		h.opts.OnResult(res)
+	}
+	return res
+}
+
+func (h *Handler) execute(ctx context.Context, sig Signal) Result {
+	now := h.clock.Now()
+	res := Result{Intent: Intent{Signal: sig}, Time: now}
+	if sig.ID == "" {
+		res.Err = fmt.Errorf("%w: missing id", ErrInvalid)
+		return res
+	}
+	if sig.Timestamp > 0 && now.Sub(time.UnixMilli(sig.Timestamp)) > h.opts.MaxAge {
+		res.Err = fmt.Errorf("%w: %s sent at %s", ErrStale, sig.ID, time.UnixMilli(sig.Timestamp).UTC().Format(time.RFC3339))
+		return res
+	}
+
+	intent, err := h.opts.Map(sig)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	intent.Signal = sig
+	res.Intent = intent
+	if h.symbols != nil && !h.symbols[intent.Symbol] {
+		res.Err = fmt.Errorf("%w: symbol %s not allowed", ErrInvalid, intent.Symbol)
+		return res
+	}
+	if !h.remember(sig.ID, now) {
+		res.Err = fmt.Errorf("%w: %s", ErrDuplicate, sig.ID)
+		return res
+	}
+
+	info, err := h.order(intent).Do(ctx)
+	if err != nil {
+		if isAPIError(err) || isRiskRejection(err) {
+			// Nothing was placed, so a corrected retry with the same ID is allowed
+			h.forget(sig.ID)
+		}
+		res.Err = fmt.Errorf("signals: %s %s %s: %w", sig.ID, intent.Side, intent.Symbol, err)
+		return res
+	}
+	res.OrderID = info.OrderId
+	return res
+}
+
+func (h *Handler) order(in Intent) *trading.CreateOrderService {
+	order := trading.NewCreateOrderService(h.client).
+		Symbol(in.Symbol).
+		ProductType(trading.ProductType(h.opts.ProductType)).
+		MarginCoin(h.opts.MarginCoin).
+		MarginMode(h.opts.MarginMode).
+		SideType(in.Side).
+		OrderType(trading.OrderTypeMarket).
+		Size(in.Size).
+		ClientOrderId(in.ClientOid)
+	if in.Price != "" {
+		order.OrderType(trading.OrderTypeLimit).Price(in.Price).TimeInForce(trading.TimeInForceGTC)
+	}
+	if in.TakeProfit != "" {
+		order.PresetStopSurplusPrice(in.TakeProfit)
+	}
+	if in.StopLoss != "" {
+		order.PresetStopLossPrice(in.StopLoss)
+	}
+	switch {
+	case in.Close:
+		// A close intent trades against the position it closes
+		order.Close(in.Side.Opposite().HoldSide(), h.opts.Hedge)
+	case h.opts.Hedge:
+		order.PositionSideType(trading.PositionSideOpen)
+	}
+	return order
+}
+
+// DefaultMap maps a signal to an intent. Symbols are upper-cased and TradingView
+// suffixes such as ".P" and exchange prefixes such as "BITGET:" are stripped; actions
+// are case-insensitive, with "long" and "short" accepted for buy and sell. The client
+// order ID is derived from the signal ID.
+func DefaultMap(sig Signal) (Intent, error) {
+	in := Intent{
+		Symbol:     normalizeSymbol(sig.Symbol),
+		Size:       strings.TrimSpace(sig.Size),
+		Price:      strings.TrimSpace(sig.Price),
+		TakeProfit: strings.TrimSpace(sig.TakeProfit),
+		StopLoss:   strings.TrimSpace(sig.StopLoss),
+		ClientOid:  ClientOid(sig.ID),
+	}
+	switch strings.ToLower(strings.TrimSpace(sig.Action)) {
+	case ActionBuy, "long":
+		in.Side = trading.SideBuy
+	case ActionSell, "short":
+		in.Side = trading.SideSell
+	case ActionCloseLong:
+		in.Side, in.Close = trading.SideSell, true
+	case ActionCloseShort:
+		in.Side, in.Close = trading.SideBuy, true
+	default:
+		return in, fmt.Errorf("%w: unknown action %q", ErrInvalid, sig.Action)
+	}
+	if in.Symbol == "" {
+		return in, fmt.Errorf("%w: missing symbol", ErrInvalid)
+	}
+	if in.Close && (in.TakeProfit != "" || in.StopLoss != "") {
+		return in, fmt.Errorf("%w: take-profit and stop-loss cannot be attached to a close", ErrInvalid)
+	}
+	for name, v := range map[string]string{"size": in.Size, "price": in.Price, "takeProfit": in.TakeProfit, "stopLoss": in.StopLoss} {
+		if v == "" && name != "size" {
+			continue
+		}
+		if f, err := strconv.ParseFloat(v, 64); err != nil || f <= 0 {
+			return in, fmt.Errorf("%w: %s %q", ErrInvalid, name, v)
+		}
+	}
+	return in, nil
+}
+
+// ClientOid derives a client order ID from a signal ID: "sig" followed by the first 24
+// hex digits of its SHA-256, so equal IDs map to equal order IDs.
+func ClientOid(id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return "sig" + hex.EncodeToString(sum[:12])
+}
+
+// Sign returns the X-Signature header value for body.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (h *Handler) authenticate(body []byte, signature, passphrase string) bool {
+	if len(h.opts.Secret) > 0 && signature != "" {
+		got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+		if err != nil {
+			return false
+		}
+		mac := hmac.New(sha256.New, h.opts.Secret)
+		mac.Write(body)
+		return hmac.Equal(got, mac.Sum(nil))
+	}
+	if h.opts.Passphrase != "" && passphrase != "" {
+		return subtle.ConstantTimeCompare([]byte(passphrase), []byte(h.opts.Passphrase)) == 1
+	}
+	return false
+}
+
+// remember records id and reports whether it was new. Expired IDs are pruned.
+func (h *Handler) remember(id string, now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for k, t := range h.seen {
+		if now.Sub(t) > h.opts.MaxAge {
+			delete(h.seen, k)
+		}
+	}
+	if _, ok := h.seen[id]; ok {
+		return false
+	}
+	h.seen[id] = now
+	return true
+}
+
+func (h *Handler) forget(id string) {
+	h.mu.Lock()
+	delete(h.seen, id)
+	h.mu.Unlock()
+}
+
+func isAPIError(err error) bool {
+	var apiErr *types.APIError
+	return errors.As(err, &apiErr)
+}
+
+func isRiskRejection(err error) bool {
+	return errors.Is(err, guard.ErrThrottled) || errors.Is(err, guard.ErrDuplicate) ||
+		errors.Is(err, guard.ErrPositionLimit) || errors.Is(err, guard.ErrPolicy) ||
+		errors.Is(err, guard.ErrCooldown)
+}
+
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrInvalid), errors.Is(err, ErrStale):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrDuplicate):
+		return http.StatusConflict
+	case isRiskRejection(err):
+		return http.StatusForbidden
+	case isAPIError(err):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadGateway
+}
+
+func normalizeSymbol(s string) string {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if i := strings.LastIndexByte(s, ':'); i >= 0 {
+		s = s[i+1:]
+	}
+	return strings.TrimSuffix(s, ".P")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,184 @@
+package signals
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/guard"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+var secret = []byte("webhook-secret")
+
+type fakeClient struct {
+	orders []map[string]string
+	err    error
+}
+
+func (c *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	if c.err != nil {
+		return nil, nil, c.err
+	}
+	var order map[string]string
+	if err := json.Unmarshal(body, &order); err != nil {
+		return nil, nil, err
+	}
+	c.orders = append(c.orders, order)
+	return &futures.ApiResponse{Code: "00000", Data: []byte(`{"orderId":"1001","clientOid":"` + order["clientOid"] + `"}`)}, &fasthttp.ResponseHeader{}, nil
+}
+
+func newTestHandler(client *fakeClient, opts Options) *Handler {
+	opts.Secret = secret
+	opts.ProductType = futures.ProductTypeUSDTFutures
+	opts.MarginCoin = "USDT"
+	opts.MarginMode = trading.MarginModeCrossed
+	opts.Clock = common.NewFakeClock(time.UnixMilli(1700000000000))
+	return NewHandler(client, opts)
+}
+
+func post(h http.Handler, body, signature string) (*httptest.ResponseRecorder, response) {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	if signature != "" {
+		req.Header.Set("X-Signature", signature)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var resp response
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	return rec, resp
+}
+
+func TestHandler_SignedSignal(t *testing.T) {
+	client := &fakeClient{}
+	var results []Result
+	h := newTestHandler(client, Options{OnResult: func(r Result) { results = append(results, r) }})
+
+	body := `{"id":"a1","symbol":"BITGET:BTCUSDT.P","action":"BUY","size":"0.01","price":"65000","stopLoss":"64000","timestamp":1699999990000}`
+	rec, resp := post(h, body, Sign(secret, []byte(body)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "1001", resp.OrderID)
+	assert.Equal(t, ClientOid("a1"), resp.ClientOid)
+
+	require.Len(t, client.orders, 1)
+	o := client.orders[0]
+	assert.Equal(t, "BTCUSDT", o["symbol"])
+	assert.Equal(t, "buy", o["side"])
+	assert.Equal(t, "limit", o["orderType"])
+	assert.Equal(t, "65000", o["price"])
+	assert.Equal(t, "64000", o["presetStopLossPrice"])
+	assert.Empty(t, o["tradeSide"])
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+
+	// A retried delivery places nothing
+	rec, _ = post(h, body, Sign(secret, []byte(body)))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Len(t, client.orders, 1)
+}
+
+func TestHandler_Authentication(t *testing.T) {
+	client := &fakeClient{}
+	h := newTestHandler(client, Options{Passphrase: "tv-pass"})
+	body := `{"id":"a1","symbol":"BTCUSDT","action":"buy","size":"0.01"}`
+
+	rec, _ := post(h, body, Sign([]byte("other"), []byte(body)))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec, _ = post(h, body, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec, _ = post(h, `{"id":"a1","symbol":"BTCUSDT","action":"buy","size":"0.01","passphrase":"wrong"}`, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec, _ = post(h, `not json`, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Empty(t, client.orders)
+
+	rec, _ = post(h, `{"id":"a1","symbol":"BTCUSDT","action":"buy","size":"0.01","passphrase":"tv-pass"}`, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/webhook", nil)
+	get := httptest.NewRecorder()
+	h.ServeHTTP(get, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, get.Code)
+}
+
+func TestHandler_Rejections(t *testing.T) {
+	client := &fakeClient{}
+	h := newTestHandler(client, Options{Symbols: []string{"ETHUSDT"}, MaxAge: time.Minute})
+	cases := map[string]string{
+		"stale":        `{"id":"s1","symbol":"ETHUSDT","action":"buy","size":"1","timestamp":1699999900000}`,
+		"symbol":       `{"id":"s2","symbol":"BTCUSDT","action":"buy","size":"1"}`,
+		"action":       `{"id":"s3","symbol":"ETHUSDT","action":"flip","size":"1"}`,
+		"size":         `{"id":"s4","symbol":"ETHUSDT","action":"buy","size":"-1"}`,
+		"id":           `{"symbol":"ETHUSDT","action":"buy","size":"1"}`,
+		"close preset": `{"id":"s5","symbol":"ETHUSDT","action":"close_long","size":"1","takeProfit":"4000"}`,
+	}
+	for name, body := range cases {
+		rec, resp := post(h, body, Sign(secret, []byte(body)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, name)
+		assert.NotEmpty(t, resp.Error, name)
+	}
+	assert.Empty(t, client.orders)
+}
+
+func TestHandler_CloseOrders(t *testing.T) {
+	client := &fakeClient{}
+	oneWay := newTestHandler(client, Options{})
+	body := `{"id":"c1","symbol":"BTCUSDT","action":"close_long","size":"0.01"}`
+	rec, _ := post(oneWay, body, Sign(secret, []byte(body)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "sell", client.orders[0]["side"])
+	assert.Equal(t, "YES", client.orders[0]["reduceOnly"])
+
+	hedge := newTestHandler(client, Options{Hedge: true})
+	rec, _ = post(hedge, body, Sign(secret, []byte(body)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "buy", client.orders[1]["side"], "hedge closes carry the position side")
+	assert.Equal(t, "close", client.orders[1]["tradeSide"])
+
+	body = `{"id":"c2","symbol":"BTCUSDT","action":"short","size":"0.01"}`
+	rec, _ = post(hedge, body, Sign(secret, []byte(body)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "sell", client.orders[2]["side"])
+	assert.Equal(t, "open", client.orders[2]["tradeSide"])
+	assert.Equal(t, "market", client.orders[2]["orderType"])
+}
+
+func TestHandler_ExecutionErrors(t *testing.T) {
+	client := &fakeClient{err: &guard.PolicyError{Symbol: "BTCUSDT", Reason: "outside trading window"}}
+	h := newTestHandler(client, Options{})
+	body := `{"id":"e1","symbol":"BTCUSDT","action":"buy","size":"0.01"}`
+
+	rec, resp := post(h, body, Sign(secret, []byte(body)))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, resp.Error, "outside trading window")
+
+	client.err = &types.APIError{Code: 40762, Message: "The order amount exceeds the balance"}
+	rec, _ = post(h, body, Sign(secret, []byte(body)))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, "rejected signals can be retried")
+
+	client.err = context.DeadlineExceeded
+	rec, _ = post(h, body, Sign(secret, []byte(body)))
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+
+	// The outcome of a timed out order is unknown, so the ID stays used
+	client.err = nil
+	rec, _ = post(h, body, Sign(secret, []byte(body)))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestClientOid(t *testing.T) {
+	assert.Equal(t, ClientOid("x"), ClientOid("x"))
+	assert.NotEqual(t, ClientOid("x"), ClientOid("y"))
+	assert.Len(t, ClientOid("x"), 27)
+}
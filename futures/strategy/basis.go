@@ -0,0 +1,363 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/khanbekov/go-bitget/uta"
+)
+
+// SpotQuoter returns the best bid and ask of a spot market.
+type SpotQuoter interface {
+	Quote(ctx context.Context) (bid, ask float64, err error)
+}
+
+// Quote implements SpotQuoter with the spot ticker of the unified trading account.
+func (l SpotLeg) Quote(ctx context.Context) (float64, float64, error) {
+	tickers, err := l.Client.NewGetTickersService().Category(uta.CategorySpot).Symbol(l.Symbol).Do(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(tickers) == 0 {
+		return 0, 0, fmt.Errorf("no spot ticker for %s", l.Symbol)
+	}
+	bid, _ := strconv.ParseFloat(tickers[0].Bid1Price, 64)
+	ask, _ := strconv.ParseFloat(tickers[0].Ask1Price, 64)
+	return bid, ask, nil
+}
+
+// Basis is the spread of a perpetual contract over its spot market. Rates are fractions
+// of the spot price.
+type Basis struct {
+	Spot float64 // Spot mid price
+	Perp float64 // Perpetual mid price
+	// Rate is (perp - spot) / spot at the mid prices.
+	Rate float64
+	// Entry is the rate a cash-and-carry locks in when opening now, selling the
+	// perpetual at its bid and buying spot at its ask.
+	Entry float64
+	// Exit is the rate a cash-and-carry gives up when closing now, buying the perpetual
+	// at its ask and selling spot at its bid.
+	Exit float64
+	// Annualized is Rate scaled to a year over the horizon it was computed for.
+	Annualized float64
+	Time       time.Time
+}
+
+// ComputeBasis computes the basis from the top of book of both markets. horizon is the
+// period the basis is expected to converge over; see Annualize.
+func ComputeBasis(spotBid, spotAsk, perpBid, perpAsk float64, horizon time.Duration) Basis {
+	b := Basis{Spot: (spotBid + spotAsk) / 2, Perp: (perpBid + perpAsk) / 2}
+	if b.Spot > 0 {
+		b.Rate = (b.Perp - b.Spot) / b.Spot
+	}
+	if spotAsk > 0 {
+		b.Entry = (perpBid - spotAsk) / spotAsk
+	}
+	if spotBid > 0 {
+		b.Exit = (perpAsk - spotBid) / spotBid
+	}
+	b.Annualized = Annualize(b.Rate, horizon)
+	return b
+}
+
+// Annualize scales a return earned over horizon to a year, without compounding. It
+// returns 0 for a horizon of zero.
+func Annualize(rate float64, horizon time.Duration) float64 {
+	if horizon <= 0 {
+		return 0
+	}
+	return rate * float64(365*24*time.Hour) / float64(horizon)
+}
+
+// BasisSource quotes the live basis of a perpetual contract against its spot market.
+type BasisSource struct {
+	client      futures.ClientInterface
+	symbol      string
+	productType futures.ProductType
+	spot        SpotQuoter
+	horizon     time.Duration
+	clock       common.Clock
+}
+
+// NewBasisSource creates a basis source for the perpetual symbol and the spot market
+// quoted by spot, usually a SpotLeg. horizon is used by Basis.Annualized and defaults to
+// 8h, the funding interval that pulls the perpetual toward spot.
+func NewBasisSource(client futures.ClientInterface, symbol string, productType futures.ProductType, spot SpotQuoter, horizon time.Duration) *BasisSource {
+	if horizon <= 0 {
+		horizon = 8 * time.Hour
+	}
+	return &BasisSource{client: client, symbol: symbol, productType: productType, spot: spot, horizon: horizon, clock: common.SystemClock()}
+}
+
+// SetClock sets the clock that stamps basis samples. Nil restores the system clock.
+func (s *BasisSource) SetClock(clock common.Clock) {
+	s.clock = common.ClockOrSystem(clock)
+}
+
+// Basis quotes both markets and returns the current basis.
+func (s *BasisSource) Basis(ctx context.Context) (Basis, error) {
+	spotBid, spotAsk, err := s.spot.Quote(ctx)
+	if err != nil {
+		return Basis{}, fmt.Errorf("basis: spot quote: %w", err)
+	}
+	t, err := market.NewTickerService(s.client).Symbol(s.symbol).ProductType(string(s.productType)).Do(ctx)
+	if err != nil {
+		return Basis{}, fmt.Errorf("basis: ticker %s: %w", s.symbol, err)
+	}
+	perpBid, _ := strconv.ParseFloat(t.BidPr, 64)
+	perpAsk, _ := strconv.ParseFloat(t.AskPr, 64)
+	if spotBid <= 0 || spotAsk <= 0 || perpBid <= 0 || perpAsk <= 0 {
+		return Basis{}, fmt.Errorf("basis: incomplete quotes for %s", s.symbol)
+	}
+	b := ComputeBasis(spotBid, spotAsk, perpBid, perpAsk, s.horizon)
+	b.Time = s.clock.Now()
+	return b, nil
+}
+
+// CarryOptions configures a CarryTrade.
+type CarryOptions struct {
+	// Symbol is the perpetual contract that is sold.
+	Symbol      string
+	ProductType futures.ProductType
+	MarginCoin  string
+	MarginMode  trading.MarginMode
+	// Hedge is set when the account uses hedge position mode.
+	Hedge bool
+
+	// Spot buys and sells the spot side, usually a SpotLeg. Required.
+	Spot HedgeLeg
+	// Source quotes the basis. Required.
+	Source *BasisSource
+
+	// Notional is the value of each side in the quote coin. Required.
+	Notional float64
+	// SizeStep is the size step shared by both legs; sizes are rounded down to it. Required.
+	SizeStep float64
+
+	// EnterBasis is the minimum Basis.Entry to open, e.g. 0.002. It should cover the
+	// fees of four trades.
+	EnterBasis float64
+	// ExitBasis closes the position once Basis.Exit falls to it or below, e.g. 0.0002.
+	ExitBasis float64
+
+	// OnEvent is called after every open and close. Optional.
+	OnEvent func(CarryEvent)
+}
+
+// CarryPosition is an open cash-and-carry position: spot held against a short
+// perpetual of the same size.
+type CarryPosition struct {
+	Size       string
+	SpotPrice  float64
+	PerpPrice  float64
+	EntryBasis float64 // Realized at the fill prices
+	OpenedAt   time.Time
+
+	perpClosed, spotClosed bool // Set by a partially failed close so a retry skips them
+}
+
+// CarryEvent reports an open or close of a CarryTrade.
+type CarryEvent struct {
+	Action   string // "open" or "close"
+	Basis    Basis
+	Position CarryPosition
+	Err      error
+}
+
+// CarryTrade enters a cash-and-carry position when the perpetual trades far enough above
+// spot and exits once the basis has converged:
+//
+//	spot := strategy.SpotLeg{Client: utaClient, Symbol: "BTCUSDT"}
+//	carry := strategy.NewCarryTrade(client, strategy.CarryOptions{
+//		Symbol:      "BTCUSDT",
+//		ProductType: futures.ProductTypeUSDTFutures,
+//		MarginCoin:  "USDT",
+//		MarginMode:  trading.MarginModeCrossed,
+//		Spot:        spot,
+//		Source:      strategy.NewBasisSource(client, "BTCUSDT", futures.ProductTypeUSDTFutures, spot, 0),
+//		Notional:    5000,
+//		SizeStep:    0.001,
+//		EnterBasis:  0.002,
+//		ExitBasis:   0.0002,
+//	})
+//	go carry.Run(ctx, 10*time.Second, log.Println)
+//
+// Spot cannot be shorted, so a perpetual trading below spot is not traded. It is safe
+// for concurrent use.
+type CarryTrade struct {
+	client futures.ClientInterface
+	opts   CarryOptions
+
+	mu       sync.Mutex
+	position *CarryPosition
+	basis    Basis
+}
+
+// NewCarryTrade creates a cash-and-carry executor.
+func NewCarryTrade(client futures.ClientInterface, opts CarryOptions) *CarryTrade {
+	return &CarryTrade{client: client, opts: opts}
+}
+
+// Position returns the open position, or nil.
+func (c *CarryTrade) Position() *CarryPosition {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.position == nil {
+		return nil
+	}
+	p := *c.position
+	return &p
+}
+
+// Check quotes the basis and opens or closes the position when the thresholds are
+// crossed. It returns "open", "close" or "" when nothing changed.
+func (c *CarryTrade) Check(ctx context.Context) (string, error) {
+	b, err := c.opts.Source.Basis(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.basis = b
+
+	if c.position == nil {
+		if b.Entry < c.opts.EnterBasis || b.Entry <= 0 {
+			return "", nil
+		}
+		return "open", c.enterLocked(ctx)
+	}
+	if b.Exit > c.opts.ExitBasis {
+		return "", nil
+	}
+	return "close", c.exitLocked(ctx)
+}
+
+// Enter opens the position at the last quoted basis regardless of the thresholds.
+func (c *CarryTrade) Enter(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.enterLocked(ctx)
+}
+
+// Exit closes both legs.
+func (c *CarryTrade) Exit(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.exitLocked(ctx)
+}
+
+// Run calls Check every interval until ctx is cancelled. Errors are passed to onError
+// when it is non-nil.
+func (c *CarryTrade) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.Check(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown closes the position. It implements lifecycle.Component.
+func (c *CarryTrade) Shutdown(ctx context.Context) error {
+	return c.Exit(ctx)
+}
+
+func (c *CarryTrade) perp() PerpLeg {
+	return PerpLeg{
+		Client:      c.client,
+		Symbol:      c.opts.Symbol,
+		ProductType: c.opts.ProductType,
+		MarginCoin:  c.opts.MarginCoin,
+		MarginMode:  c.opts.MarginMode,
+		Hedge:       c.opts.Hedge,
+	}
+}
+
+func (c *CarryTrade) enterLocked(ctx context.Context) error {
+	if c.position != nil {
+		return nil
+	}
+	if c.opts.SizeStep <= 0 {
+		return fmt.Errorf("carry: size step is required")
+	}
+	if c.basis.Spot <= 0 {
+		b, err := c.opts.Source.Basis(ctx)
+		if err != nil {
+			return err
+		}
+		c.basis = b
+	}
+	size := common.RoundDownToStep(c.opts.Notional/c.basis.Spot, c.opts.SizeStep)
+	if size <= 0 {
+		return fmt.Errorf("carry: notional %v is below the minimum size", c.opts.Notional)
+	}
+	pos := &CarryPosition{Size: common.FormatToStep(size, c.opts.SizeStep), OpenedAt: c.basis.Time}
+
+	var err error
+	if pos.PerpPrice, err = c.perp().Open(ctx, trading.SideSell, pos.Size); err != nil {
+		return c.event("open", *pos, fmt.Errorf("carry: open %s: %w", c.opts.Symbol, err))
+	}
+	if pos.SpotPrice, err = c.opts.Spot.Open(ctx, trading.SideBuy, pos.Size); err != nil {
+		// Never keep the short perpetual without the spot it is carried against
+		if _, closeErr := c.perp().Close(ctx, trading.SideSell, pos.Size); closeErr != nil {
+			err = fmt.Errorf("%w; unwinding %s also failed: %v", err, c.opts.Symbol, closeErr)
+		}
+		return c.event("open", *pos, fmt.Errorf("carry: open spot: %w", err))
+	}
+	if pos.SpotPrice > 0 && pos.PerpPrice > 0 {
+		pos.EntryBasis = (pos.PerpPrice - pos.SpotPrice) / pos.SpotPrice
+	}
+	c.position = pos
+	return c.event("open", *pos, nil)
+}
+
+func (c *CarryTrade) exitLocked(ctx context.Context) error {
+	if c.position == nil {
+		return nil
+	}
+	pos := c.position
+
+	var errs []error
+	if !pos.perpClosed {
+		if _, err := c.perp().Close(ctx, trading.SideSell, pos.Size); err != nil {
+			errs = append(errs, fmt.Errorf("carry: close %s: %w", c.opts.Symbol, err))
+		} else {
+			pos.perpClosed = true
+		}
+	}
+	if !pos.spotClosed {
+		if _, err := c.opts.Spot.Close(ctx, trading.SideBuy, pos.Size); err != nil {
+			errs = append(errs, fmt.Errorf("carry: close spot: %w", err))
+		} else {
+			pos.spotClosed = true
+		}
+	}
+	err := errors.Join(errs...)
+	if err == nil {
+		c.position = nil
+	}
+	return c.event("close", *pos, err)
+}
+
+func (c *CarryTrade) event(action string, pos CarryPosition, err error) error {
+	if c.opts.OnEvent != nil {
+		c.opts.OnEvent(CarryEvent{Action: action, Basis: c.basis, Position: pos, Err: err})
+	}
+	return err
+}
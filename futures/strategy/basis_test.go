@@ -0,0 +1,132 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// perpClient quotes BTCUSDT at bid/ask and fills market orders at the bid.
+type perpClient struct {
+	bid, ask string
+	orders   []map[string]string
+}
+
+func (c *perpClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	ok := func(data string) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+		return &futures.ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil
+	}
+	switch endpoint {
+	case futures.EndpointTicker:
+		return ok(`[{"symbol":"BTCUSDT","bidPr":"` + c.bid + `","askPr":"` + c.ask + `"}]`)
+	case trading.EndpointPlaceOrder:
+		var req map[string]string
+		_ = json.Unmarshal(body, &req)
+		c.orders = append(c.orders, req)
+		return ok(`{"orderId":"1"}`)
+	case trading.EndpointOrderDetails:
+		return ok(`{"orderId":"1","priceAvg":"` + c.bid + `"}`)
+	}
+	return nil, nil, errors.New("unexpected endpoint " + endpoint)
+}
+
+// fakeSpot is a spot leg quoted at bid/ask.
+type fakeSpot struct {
+	fakeLeg
+	bid, ask float64
+}
+
+func (s *fakeSpot) Quote(ctx context.Context) (float64, float64, error) {
+	return s.bid, s.ask, nil
+}
+
+func TestComputeBasis(t *testing.T) {
+	b := ComputeBasis(49990, 50010, 50090, 50110, 8*time.Hour)
+	assert.Equal(t, 50000.0, b.Spot)
+	assert.Equal(t, 50100.0, b.Perp)
+	assert.InDelta(t, 0.002, b.Rate, 1e-12)
+	assert.InDelta(t, 80.0/50010, b.Entry, 1e-12)
+	assert.InDelta(t, 120.0/49990, b.Exit, 1e-12)
+	assert.InDelta(t, 0.002*3*365, b.Annualized, 1e-9)
+
+	assert.InDelta(t, 0.12, Annualize(0.01, 365*24*time.Hour/12), 1e-12)
+	assert.Zero(t, Annualize(0.01, 0))
+}
+
+func TestCarryTrade_EntersAndExits(t *testing.T) {
+	client := &perpClient{bid: "50100", ask: "50110"}
+	spot := &fakeSpot{bid: 49990, ask: 50000}
+	var events []CarryEvent
+	carry := NewCarryTrade(client, CarryOptions{
+		Symbol:      "BTCUSDT",
+		ProductType: futures.ProductTypeUSDTFutures,
+		MarginCoin:  "USDT",
+		MarginMode:  trading.MarginModeCrossed,
+		Spot:        spot,
+		Source:      NewBasisSource(client, "BTCUSDT", futures.ProductTypeUSDTFutures, spot, 0),
+		Notional:    5000,
+		SizeStep:    0.001,
+		EnterBasis:  0.002,
+		ExitBasis:   0.0005,
+		OnEvent:     func(e CarryEvent) { events = append(events, e) },
+	})
+	ctx := context.Background()
+
+	action, err := carry.Check(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "open", action)
+	pos := carry.Position()
+	require.NotNil(t, pos)
+	assert.Equal(t, "0.100", pos.Size)
+	require.Len(t, client.orders, 1)
+	assert.Equal(t, "sell", client.orders[0]["side"])
+	assert.Equal(t, []string{"open buy 0.100"}, spot.trades)
+	assert.InDelta(t, (50100.0-50010)/50010, pos.EntryBasis, 1e-12)
+
+	action, err = carry.Check(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, action, "the basis has not converged")
+
+	client.bid, client.ask = "50005", "50010"
+	action, err = carry.Check(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "close", action)
+	assert.Nil(t, carry.Position())
+	require.Len(t, client.orders, 2)
+	assert.Equal(t, "buy", client.orders[1]["side"])
+	assert.Equal(t, "YES", client.orders[1]["reduceOnly"])
+	assert.Equal(t, []string{"open buy 0.100", "close buy 0.100"}, spot.trades)
+	require.Len(t, events, 2)
+	assert.Equal(t, "close", events[1].Action)
+}
+
+func TestCarryTrade_UnwindsWhenSpotFails(t *testing.T) {
+	client := &perpClient{bid: "50200", ask: "50210"}
+	spot := &fakeSpot{fakeLeg: fakeLeg{fail: errors.New("insufficient balance")}, bid: 49990, ask: 50000}
+	carry := NewCarryTrade(client, CarryOptions{
+		Symbol:      "BTCUSDT",
+		ProductType: futures.ProductTypeUSDTFutures,
+		MarginCoin:  "USDT",
+		MarginMode:  trading.MarginModeCrossed,
+		Spot:        spot,
+		Source:      NewBasisSource(client, "BTCUSDT", futures.ProductTypeUSDTFutures, spot, 0),
+		Notional:    5000,
+		SizeStep:    0.001,
+		EnterBasis:  0.002,
+	})
+
+	_, err := carry.Check(context.Background())
+	assert.ErrorContains(t, err, "carry: open spot: insufficient balance")
+	assert.Nil(t, carry.Position())
+	require.Len(t, client.orders, 2)
+	assert.Equal(t, "buy", client.orders[1]["side"], "the short perpetual is bought back")
+}
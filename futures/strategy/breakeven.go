@@ -0,0 +1,346 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/khanbekov/go-bitget/ws"
+)
+
+// DefaultBreakEvenFeeRate is the fee rate per side assumed by BreakEvenStop when neither
+// the options nor the position provide one.
+const DefaultBreakEvenFeeRate = 0.0006
+
+// BreakEvenOptions configures a BreakEvenStop.
+type BreakEvenOptions struct {
+	Symbol      string
+	ProductType futures.ProductType
+	MarginCoin  string
+	// HoldSide is the side of the position to protect. Required.
+	HoldSide trading.HoldSide
+
+	// Risk is the price distance between the entry and the initial stop. Required.
+	Risk float64
+	// Multiple of Risk the price must move in favour of the position before the stop is
+	// moved. Defaults to 1.
+	Multiple float64
+	// FeeRate is the fee rate per side added to the entry when the position does not
+	// report a break-even price. Defaults to DefaultBreakEvenFeeRate.
+	FeeRate float64
+	// PriceTick rounds the stop price towards profit. Optional.
+	PriceTick float64
+	// TriggerType compares the stop against the last or the mark price. Defaults to the
+	// exchange default.
+	TriggerType trading.TriggerType
+
+	// StopOrderID is the stop-loss of the first position to move. When empty, and for
+	// every later position, a position stop-loss is placed at break-even instead.
+	StopOrderID string
+
+	// OnMove is called after the stop was moved. Optional.
+	OnMove func(BreakEvenMove)
+	// Clock stamps move events. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// BreakEvenMove describes a stop moved to break-even.
+type BreakEvenMove struct {
+	Symbol   string
+	HoldSide trading.HoldSide
+	OrderID  string
+	Entry    float64
+	Stop     float64
+	Price    float64 // Price that reached the threshold
+	Time     time.Time
+}
+
+// BreakEvenStop moves the stop-loss of a position to its entry plus fees once the price
+// has moved a multiple of the initial risk in its favour, so a winning trade can no
+// longer turn into a loss. It follows the position from a tracker and the price from the
+// ticker stream:
+//
+//	be := strategy.NewBreakEvenStop(client, strategy.BreakEvenOptions{
+//		Symbol:      "BTCUSDT",
+//		ProductType: futures.ProductTypeUSDTFutures,
+//		MarginCoin:  "USDT",
+//		HoldSide:    trading.HoldSideLong,
+//		Risk:        1000, // Entry 60000, initial stop 59000
+//		Multiple:    1.5,
+//		StopOrderID: stopID,
+//	})
+//	wsClient.SubscribeTicker("BTCUSDT", "USDT-FUTURES", be.HandleMessage)
+//	go be.Run(ctx, positions.Events(), log.Println)
+//
+// The stop is moved once per position; it is armed again when the position closes. It
+// is safe for concurrent use.
+type BreakEvenStop struct {
+	client futures.ClientInterface
+	opts   BreakEvenOptions
+	clock  common.Clock
+	prices chan float64
+
+	mu       sync.Mutex
+	position tracker.Position
+	stopID   string
+	moved    bool
+	moving   bool   // A move is in flight
+	epoch    uint64 // Incremented when a position closes
+}
+
+// NewBreakEvenStop creates a break-even stop. It acts once it has seen a position and a
+// price.
+func NewBreakEvenStop(client futures.ClientInterface, opts BreakEvenOptions) *BreakEvenStop {
+	if opts.Multiple <= 0 {
+		opts.Multiple = 1
+	}
+	if opts.FeeRate <= 0 {
+		opts.FeeRate = DefaultBreakEvenFeeRate
+	}
+	return &BreakEvenStop{
+		client: client,
+		opts:   opts,
+		clock:  common.ClockOrSystem(opts.Clock),
+		prices: make(chan float64, 1),
+		stopID: opts.StopOrderID,
+	}
+}
+
+// Moved reports whether the stop of the current position was moved.
+func (b *BreakEvenStop) Moved() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.moved
+}
+
+// StopOrderID returns the ID of the stop-loss being managed, empty until one is known.
+func (b *BreakEvenStop) StopOrderID() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stopID
+}
+
+// UpdatePosition records the current state of the position. A closed position re-arms
+// the stop for the next one.
+func (b *BreakEvenStop) UpdatePosition(p tracker.Position) {
+	if p.Symbol != b.opts.Symbol || p.HoldSide != string(b.opts.HoldSide) {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if p.Size <= 0 {
+		// The exchange cancels position stops with their position
+		b.position, b.stopID, b.moved = tracker.Position{}, "", false
+		b.epoch++
+		return
+	}
+	b.position = p
+}
+
+// HandlePositionEvent records a tracker event. Its signature matches
+// PositionTracker.OnEvent.
+func (b *BreakEvenStop) HandlePositionEvent(e tracker.PositionEvent) {
+	p := e.Position
+	if e.Type == tracker.PositionClosed {
+		p.Size = 0
+	}
+	b.UpdatePosition(p)
+}
+
+// HandleMessage queues the last price of a raw ticker channel message for Run. Its
+// signature matches ws.OnReceive so it can be passed to SubscribeTicker directly. Only
+// the newest price is kept.
+func (b *BreakEvenStop) HandleMessage(message string) {
+	var msg struct {
+		Arg  ws.SubscriptionArgs `json:"arg"`
+		Data []ws.TickerData     `json:"data"`
+	}
+	if json.Unmarshal([]byte(message), &msg) != nil || msg.Arg.Channel != ws.ChannelTicker {
+		return
+	}
+	for _, t := range msg.Data {
+		if t.InstId != b.opts.Symbol && t.Symbol != b.opts.Symbol {
+			continue
+		}
+		if price, _ := strconv.ParseFloat(t.LastPrice, 64); price > 0 {
+			b.push(price)
+		}
+	}
+}
+
+func (b *BreakEvenStop) push(price float64) {
+	for {
+		select {
+		case b.prices <- price:
+			return
+		default:
+		}
+		// Drop the stale price
+		select {
+		case <-b.prices:
+		default:
+		}
+	}
+}
+
+// Run applies position events and checks every price queued by HandleMessage until ctx
+// is cancelled. Errors are passed to onError when it is non-nil.
+func (b *BreakEvenStop) Run(ctx context.Context, events <-chan tracker.PositionEvent, onError func(error)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			b.HandlePositionEvent(e)
+		case price := <-b.prices:
+			if _, err := b.Check(ctx, price); err != nil && onError != nil && ctx.Err() == nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// Threshold returns the price at which the stop is moved, or zero without a position.
+func (b *BreakEvenStop) Threshold() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.threshold()
+}
+
+// StopPrice returns the break-even stop price of the current position, or zero without
+// one: its break-even price when reported, or the entry plus the fees of opening and
+// closing.
+func (b *BreakEvenStop) StopPrice() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stopPrice()
+}
+
+// Check moves the stop when price has reached the threshold and returns whether it did.
+// The exchange is called without holding the lock; a Check while a move is in flight
+// returns false.
+func (b *BreakEvenStop) Check(ctx context.Context, price float64) (bool, error) {
+	b.mu.Lock()
+	if b.opts.Risk <= 0 {
+		b.mu.Unlock()
+		return false, fmt.Errorf("break-even: Risk is required")
+	}
+	long := b.opts.HoldSide == trading.HoldSideLong
+	threshold, stop := b.threshold(), b.stopPrice()
+	if b.moved || b.moving || b.position.Size <= 0 || price <= 0 ||
+		long && (price < threshold || stop >= price) || !long && (price > threshold || stop <= price) {
+		b.mu.Unlock()
+		return false, nil
+	}
+	stopID, entry, epoch := b.stopID, b.position.AvgPrice, b.epoch
+	b.moving = true
+	b.mu.Unlock()
+
+	id, err := b.moveStop(ctx, stopID, b.formatPrice(stop))
+
+	b.mu.Lock()
+	b.moving = false
+	if err == nil && epoch == b.epoch {
+		// A position that closed meanwhile took its stop with it
+		b.stopID, b.moved = id, true
+	}
+	b.mu.Unlock()
+	if err != nil {
+		return false, fmt.Errorf("break-even: %s %s stop to %s: %w", b.opts.Symbol, b.opts.HoldSide, common.FormatFloat(stop), err)
+	}
+	if b.opts.OnMove != nil {
+		b.opts.OnMove(BreakEvenMove{
+			Symbol: b.opts.Symbol, HoldSide: b.opts.HoldSide, OrderID: id,
+			Entry: entry, Stop: stop, Price: price, Time: b.clock.Now(),
+		})
+	}
+	return true, nil
+}
+
+func (b *BreakEvenStop) threshold() float64 {
+	if b.position.Size <= 0 {
+		return 0
+	}
+	move := b.opts.Multiple * b.opts.Risk
+	if b.opts.HoldSide == trading.HoldSideShort {
+		return b.position.AvgPrice - move
+	}
+	return b.position.AvgPrice + move
+}
+
+func (b *BreakEvenStop) stopPrice() float64 {
+	p := b.position
+	if p.Size <= 0 {
+		return 0
+	}
+	long := b.opts.HoldSide == trading.HoldSideLong
+	stop := p.BreakEvenPrice
+	if stop <= 0 {
+		fees := 2 * b.opts.FeeRate * p.AvgPrice
+		stop = p.AvgPrice + fees
+		if !long {
+			stop = p.AvgPrice - fees
+		}
+	}
+	if tick := b.opts.PriceTick; tick > 0 {
+		if long {
+			stop = common.RoundUpToStep(stop, tick)
+		} else {
+			stop = common.RoundDownToStep(stop, tick)
+		}
+	}
+	return stop
+}
+
+// moveStop modifies the stop-loss stopID, or places a position stop-loss when it is
+// empty, and returns its ID.
+func (b *BreakEvenStop) moveStop(ctx context.Context, stopID, price string) (string, error) {
+	if stopID != "" {
+		modify := trading.NewModifyTPSLOrderService(b.client).
+			Symbol(b.opts.Symbol).
+			ProductType(trading.ProductType(b.opts.ProductType)).
+			MarginCoin(b.opts.MarginCoin).
+			OrderId(stopID).
+			TriggerPrice(price)
+		if b.opts.TriggerType != "" {
+			modify.TriggerType(b.opts.TriggerType)
+		}
+		if _, err := modify.Do(ctx); err != nil {
+			return "", err
+		}
+		return stopID, nil
+	}
+	place := trading.NewPlaceTPSLOrderService(b.client).
+		Symbol(b.opts.Symbol).
+		ProductType(trading.ProductType(b.opts.ProductType)).
+		MarginCoin(b.opts.MarginCoin).
+		PlanType(trading.PlanTypePosLoss).
+		HoldSide(b.opts.HoldSide).
+		TriggerPrice(price)
+	if b.opts.TriggerType != "" {
+		place.TriggerType(b.opts.TriggerType)
+	}
+	res, err := place.Do(ctx)
+	if err != nil {
+		return "", err
+	}
+	return res.OrderId, nil
+}
+
+func (b *BreakEvenStop) formatPrice(price float64) string {
+	if b.opts.PriceTick > 0 {
+		return common.FormatToStep(price, b.opts.PriceTick)
+	}
+	return common.FormatFloat(price)
+}
@@ -0,0 +1,179 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// stopClient records TP/SL requests.
+type stopClient struct {
+	block    chan struct{} // Holds requests until closed when set
+	mu       sync.Mutex
+	requests []map[string]string
+	paths    []string
+}
+
+func (c *stopClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	if c.block != nil {
+		<-c.block
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var req map[string]string
+	_ = json.Unmarshal(body, &req)
+	switch endpoint {
+	case trading.EndpointModifyTPSLOrder, trading.EndpointPlaceTPSLOrder:
+		c.requests = append(c.requests, req)
+		c.paths = append(c.paths, endpoint)
+		return &futures.ApiResponse{Code: "00000", Data: []byte(`{"orderId":"placed"}`)}, &fasthttp.ResponseHeader{}, nil
+	}
+	return nil, nil, &types.APIError{Code: 40000, Message: "unexpected endpoint " + endpoint}
+}
+
+func (c *stopClient) calls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.requests)
+}
+
+func breakEvenOptions() BreakEvenOptions {
+	return BreakEvenOptions{
+		Symbol:      "BTCUSDT",
+		ProductType: futures.ProductTypeUSDTFutures,
+		MarginCoin:  "USDT",
+		HoldSide:    trading.HoldSideLong,
+		Risk:        1000,
+		Multiple:    1.5,
+		PriceTick:   0.1,
+		StopOrderID: "sl1",
+	}
+}
+
+func TestBreakEvenStop_MovesStopAtThreshold(t *testing.T) {
+	c := &stopClient{}
+	var moves []BreakEvenMove
+	opts := breakEvenOptions()
+	opts.OnMove = func(m BreakEvenMove) { moves = append(moves, m) }
+	b := NewBreakEvenStop(c, opts)
+	ctx := context.Background()
+
+	moved, err := b.Check(ctx, 62000)
+	require.NoError(t, err)
+	assert.False(t, moved, "no position yet")
+
+	b.UpdatePosition(tracker.Position{Symbol: "BTCUSDT", HoldSide: "long", Size: 0.1, AvgPrice: 60000})
+	assert.Equal(t, 61500.0, b.Threshold())
+	assert.InDelta(t, 60072, b.StopPrice(), 1e-6, "entry plus fees of both sides")
+
+	moved, err = b.Check(ctx, 61499)
+	require.NoError(t, err)
+	assert.False(t, moved)
+
+	moved, err = b.Check(ctx, 61500)
+	require.NoError(t, err)
+	assert.True(t, moved)
+	require.Equal(t, 1, c.calls())
+	assert.Equal(t, trading.EndpointModifyTPSLOrder, c.paths[0])
+	assert.Equal(t, "sl1", c.requests[0]["orderId"])
+	assert.Equal(t, "60072.0", c.requests[0]["triggerPrice"])
+	require.Len(t, moves, 1)
+	assert.Equal(t, 60000.0, moves[0].Entry)
+
+	moved, err = b.Check(ctx, 63000)
+	require.NoError(t, err)
+	assert.False(t, moved, "the stop is moved once")
+	assert.Equal(t, 1, c.calls())
+}
+
+func TestBreakEvenStop_ShortUsesReportedBreakEven(t *testing.T) {
+	c := &stopClient{}
+	opts := breakEvenOptions()
+	opts.HoldSide = trading.HoldSideShort
+	opts.StopOrderID = ""
+	b := NewBreakEvenStop(c, opts)
+	b.UpdatePosition(tracker.Position{Symbol: "BTCUSDT", HoldSide: "short", Size: 0.1, AvgPrice: 60000, BreakEvenPrice: 59950.05})
+
+	moved, err := b.Check(context.Background(), 58500)
+	require.NoError(t, err)
+	assert.True(t, moved)
+	assert.Equal(t, trading.EndpointPlaceTPSLOrder, c.paths[0], "without a stop one is placed")
+	assert.Equal(t, "pos_loss", c.requests[0]["planType"])
+	assert.Equal(t, "short", c.requests[0]["holdSide"])
+	assert.Equal(t, "59950.0", c.requests[0]["triggerPrice"], "rounded towards profit")
+	assert.Equal(t, "placed", b.StopOrderID())
+}
+
+func TestBreakEvenStop_RunRearmsAfterClose(t *testing.T) {
+	c := &stopClient{}
+	b := NewBreakEvenStop(c, breakEvenOptions())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan tracker.PositionEvent, 4)
+	go b.Run(ctx, events, func(err error) { t.Error(err) })
+
+	pos := tracker.Position{Symbol: "BTCUSDT", HoldSide: "long", Size: 0.1, AvgPrice: 60000}
+	events <- tracker.PositionEvent{Type: tracker.PositionOpened, Position: pos}
+	require.Eventually(t, func() bool { return b.Threshold() > 0 }, time.Second, time.Millisecond)
+
+	ticker := `{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"ticker","instId":"BTCUSDT"},"data":[{"instId":"BTCUSDT","lastPr":"61600"}]}`
+	b.HandleMessage(ticker)
+	require.Eventually(t, b.Moved, time.Second, time.Millisecond)
+
+	events <- tracker.PositionEvent{Type: tracker.PositionClosed, Position: pos}
+	require.Eventually(t, func() bool { return !b.Moved() }, time.Second, time.Millisecond)
+	events <- tracker.PositionEvent{Type: tracker.PositionOpened, Position: pos}
+	require.Eventually(t, func() bool { return b.Threshold() > 0 }, time.Second, time.Millisecond)
+	b.HandleMessage(ticker)
+	require.Eventually(t, func() bool { return c.calls() == 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, trading.EndpointPlaceTPSLOrder, c.paths[1], "the next position gets a new stop")
+}
+
+func TestBreakEvenStop_UnlockedWhileMoving(t *testing.T) {
+	c := &stopClient{block: make(chan struct{})}
+	b := NewBreakEvenStop(c, breakEvenOptions())
+	b.UpdatePosition(tracker.Position{Symbol: "BTCUSDT", HoldSide: "long", Size: 0.1, AvgPrice: 60000})
+
+	done := make(chan bool)
+	go func() {
+		moved, err := b.Check(context.Background(), 61600)
+		assert.NoError(t, err)
+		done <- moved
+	}()
+	require.Eventually(t, func() bool {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return b.moving
+	}, time.Second, time.Millisecond)
+
+	// Accessors do not wait for the exchange, and a second check does not move again
+	assert.Equal(t, 61500.0, b.Threshold())
+	assert.False(t, b.Moved())
+	moved, err := b.Check(context.Background(), 61700)
+	require.NoError(t, err)
+	assert.False(t, moved)
+
+	close(c.block)
+	assert.True(t, <-done)
+	assert.True(t, b.Moved())
+	assert.Equal(t, 1, c.calls())
+}
+
+func TestBreakEvenStop_Validation(t *testing.T) {
+	opts := breakEvenOptions()
+	opts.Risk = 0
+	b := NewBreakEvenStop(&stopClient{}, opts)
+	_, err := b.Check(context.Background(), 60000)
+	assert.EqualError(t, err, "break-even: Risk is required")
+}
@@ -0,0 +1,311 @@
+// Package strategy provides building blocks for strategies running on top of the SDK.
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/candles"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/ws"
+)
+
+// Subscriber is the subset of ws.BaseWsClient used by DataContext.
+type Subscriber interface {
+	SubscribeTicker(symbol, productType string, handler ws.OnReceive)
+	SubscribeCandles(symbol, productType, timeframe string, handler ws.OnReceive)
+	SubscribeOrderBook5(symbol, productType string, handler ws.OnReceive)
+}
+
+// DataContextOptions configures a DataContext.
+type DataContextOptions struct {
+	// MaxCandles bounds the history kept per interval. Defaults to 500.
+	MaxCandles int
+	// OnUpdate is called after every applied stream message with the channel that changed.
+	OnUpdate func(channel string)
+}
+
+// DataContext keeps synchronized multi-timeframe candles plus the latest ticker and top of
+// book for one symbol. Strategies query it instead of managing subscriptions:
+//
+//	dc := strategy.NewDataContext("BTCUSDT", futures.ProductTypeUSDTFutures, []string{"15m", "4h"}, strategy.DataContextOptions{})
+//	dc.Load(ctx, restClient, 200)
+//	dc.Attach(wsClient)
+//	trend := dc.Candles("4h")
+//
+// It is safe for concurrent use.
+type DataContext struct {
+	symbol      string
+	productType futures.ProductType
+	intervals   []string
+	opts        DataContextOptions
+
+	mu      sync.RWMutex
+	candles map[string][]market.Candlestick
+	ticker  *ws.TickerData
+	book    *ws.OrderBookData
+	updated time.Time
+}
+
+// Snapshot is a consistent view of a DataContext at one instant.
+type Snapshot struct {
+	Symbol  string
+	Time    time.Time
+	Candles map[string][]market.Candlestick
+	Ticker  *ws.TickerData
+	Book    *ws.OrderBookData
+}
+
+// NewDataContext creates a data context for symbol and the given candle intervals
+// (WebSocket timeframes such as "1m", "15m", "4h", "1d").
+func NewDataContext(symbol string, productType futures.ProductType, intervals []string, opts DataContextOptions) *DataContext {
+	if opts.MaxCandles <= 0 {
+		opts.MaxCandles = 500
+	}
+	dc := &DataContext{
+		symbol:      symbol,
+		productType: productType,
+		intervals:   append([]string(nil), intervals...),
+		opts:        opts,
+		candles:     make(map[string][]market.Candlestick, len(intervals)),
+	}
+	return dc
+}
+
+// Symbol returns the symbol the context tracks.
+func (dc *DataContext) Symbol() string {
+	return dc.symbol
+}
+
+// Load seeds candle history for every interval from the REST API.
+func (dc *DataContext) Load(ctx context.Context, client futures.ClientInterface, limit int) error {
+	if limit <= 0 || limit > dc.opts.MaxCandles {
+		limit = dc.opts.MaxCandles
+	}
+	for _, interval := range dc.intervals {
+		history, err := market.NewCandlestickService(client).
+			Symbol(dc.symbol).
+			ProductType(market.ProductType(dc.productType)).
+			Granularity(restGranularity(interval)).
+			Limit(strconv.Itoa(limit)).
+			Do(ctx)
+		if err != nil {
+			return fmt.Errorf("load %s candles: %w", interval, err)
+		}
+
+		dc.mu.Lock()
+		for _, c := range history {
+			dc.upsertCandle(interval, c)
+		}
+		dc.mu.Unlock()
+	}
+	return nil
+}
+
+// Attach subscribes to the ticker, top-5 book and candle channels of every interval.
+func (dc *DataContext) Attach(sub Subscriber) {
+	pt := string(dc.productType)
+	sub.SubscribeTicker(dc.symbol, pt, dc.HandleMessage)
+	sub.SubscribeOrderBook5(dc.symbol, pt, dc.HandleMessage)
+	for _, interval := range dc.intervals {
+		sub.SubscribeCandles(dc.symbol, pt, interval, dc.HandleMessage)
+	}
+}
+
+// HandleMessage applies a raw WebSocket message. It is exported so messages from an
+// existing subscription handler or a replay can be fed in directly.
+func (dc *DataContext) HandleMessage(message string) {
+	var msg ws.WebSocketMessage
+	if err := json.Unmarshal([]byte(message), &msg); err != nil || len(msg.Data) == 0 {
+		return
+	}
+	if msg.Arg.Symbol != "" && msg.Arg.Symbol != dc.symbol {
+		return
+	}
+
+	channel := msg.Arg.Channel
+	dc.mu.Lock()
+	switch {
+	case channel == ws.ChannelTicker:
+		var tickers []ws.TickerData
+		if json.Unmarshal(msg.Data, &tickers) == nil && len(tickers) > 0 {
+			t := tickers[len(tickers)-1]
+			_ = t.ParseFloats()
+			dc.ticker = &t
+		}
+	case strings.HasPrefix(channel, ws.ChannelBooks):
+		var books []ws.OrderBookData
+		if json.Unmarshal(msg.Data, &books) == nil && len(books) > 0 {
+			b := books[len(books)-1]
+			for i := range b.Bids {
+				_ = b.Bids[i].ParseFloats()
+			}
+			for i := range b.Asks {
+				_ = b.Asks[i].ParseFloats()
+			}
+			_ = b.ParseTimestamp()
+			dc.book = &b
+		}
+	case strings.HasPrefix(channel, ws.ChannelCandle):
+		interval := strings.TrimPrefix(channel, ws.ChannelCandle)
+		var rows []ws.CandlestickData
+		if json.Unmarshal(msg.Data, &rows) == nil {
+			for _, r := range rows {
+				dc.upsertCandle(interval, market.Candlestick{
+					CloseTime:        r.TimestampDate.UnixMilli(),
+					Open:             r.OpenFloat,
+					High:             r.HighFloat,
+					Low:              r.LowFloat,
+					Close:            r.CloseFloat,
+					Volume:           r.BaseVolumeFloat,
+					QuoteAssetVolume: r.QuoteVolumeFloat,
+				})
+			}
+		}
+	default:
+		dc.mu.Unlock()
+		return
+	}
+	dc.updated = time.Now()
+	onUpdate := dc.opts.OnUpdate
+	dc.mu.Unlock()
+
+	if onUpdate != nil {
+		onUpdate(channel)
+	}
+}
+
+// Candles returns a copy of the candle history for interval, oldest first. The last
+// candle may still be forming; use ClosedCandles to exclude it.
+func (dc *DataContext) Candles(interval string) []market.Candlestick {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+	return append([]market.Candlestick(nil), dc.candles[interval]...)
+}
+
+// ClosedCandles returns the candles of interval whose period ended before now.
+func (dc *DataContext) ClosedCandles(interval string, now time.Time) []market.Candlestick {
+	series := dc.Candles(interval)
+	d, err := candles.GranularityDuration(interval)
+	if err != nil {
+		return series
+	}
+	for len(series) > 0 && series[len(series)-1].CloseTime+d.Milliseconds() > now.UnixMilli() {
+		series = series[:len(series)-1]
+	}
+	return series
+}
+
+// LastCandle returns the most recent candle of interval.
+func (dc *DataContext) LastCandle(interval string) (market.Candlestick, bool) {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+	series := dc.candles[interval]
+	if len(series) == 0 {
+		return market.Candlestick{}, false
+	}
+	return series[len(series)-1], true
+}
+
+// Ticker returns the latest ticker.
+func (dc *DataContext) Ticker() (ws.TickerData, bool) {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+	if dc.ticker == nil {
+		return ws.TickerData{}, false
+	}
+	return *dc.ticker, true
+}
+
+// Book returns the latest top-of-book snapshot.
+func (dc *DataContext) Book() (ws.OrderBookData, bool) {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+	if dc.book == nil {
+		return ws.OrderBookData{}, false
+	}
+	return *dc.book, true
+}
+
+// Price returns the best available current price: the ticker last price, else the close
+// of the most recent candle across intervals.
+func (dc *DataContext) Price() float64 {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+	if dc.ticker != nil && dc.ticker.LastPriceFloat > 0 {
+		return dc.ticker.LastPriceFloat
+	}
+	var latest market.Candlestick
+	for _, series := range dc.candles {
+		if n := len(series); n > 0 && series[n-1].CloseTime >= latest.CloseTime {
+			latest = series[n-1]
+		}
+	}
+	return latest.Close
+}
+
+// Snapshot returns a consistent copy of all data.
+func (dc *DataContext) Snapshot() Snapshot {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+	s := Snapshot{Symbol: dc.symbol, Time: dc.updated, Candles: make(map[string][]market.Candlestick, len(dc.candles))}
+	for interval, series := range dc.candles {
+		s.Candles[interval] = append([]market.Candlestick(nil), series...)
+	}
+	if dc.ticker != nil {
+		t := *dc.ticker
+		s.Ticker = &t
+	}
+	if dc.book != nil {
+		b := *dc.book
+		s.Book = &b
+	}
+	return s
+}
+
+// upsertCandle inserts or replaces a candle keeping the series sorted and bounded.
+// Callers must hold the write lock.
+func (dc *DataContext) upsertCandle(interval string, c market.Candlestick) {
+	series := dc.candles[interval]
+	n := len(series)
+	switch {
+	case n == 0 || c.CloseTime > series[n-1].CloseTime:
+		series = append(series, c)
+	case c.CloseTime == series[n-1].CloseTime:
+		series[n-1] = c
+	default:
+		i := sort.Search(n, func(i int) bool { return series[i].CloseTime >= c.CloseTime })
+		if series[i].CloseTime == c.CloseTime {
+			series[i] = c
+		} else {
+			series = append(series, market.Candlestick{})
+			copy(series[i+1:], series[i:])
+			series[i] = c
+		}
+	}
+	if len(series) > dc.opts.MaxCandles {
+		series = series[len(series)-dc.opts.MaxCandles:]
+	}
+	dc.candles[interval] = series
+}
+
+// restGranularity converts a WebSocket timeframe to the REST granularity format, which
+// uses upper case hour, day and week units ("4h" -> "4H").
+func restGranularity(interval string) string {
+	if interval == "" {
+		return interval
+	}
+	unit := interval[len(interval)-1]
+	switch unit {
+	case 'h', 'd', 'w':
+		return interval[:len(interval)-1] + strings.ToUpper(string(unit))
+	}
+	return interval
+}
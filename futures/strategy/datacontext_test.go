@@ -0,0 +1,94 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type recordingSubscriber struct {
+	channels []string
+}
+
+func (r *recordingSubscriber) SubscribeTicker(symbol, productType string, handler ws.OnReceive) {
+	r.channels = append(r.channels, "ticker")
+}
+
+func (r *recordingSubscriber) SubscribeCandles(symbol, productType, timeframe string, handler ws.OnReceive) {
+	r.channels = append(r.channels, "candle"+timeframe)
+}
+
+func (r *recordingSubscriber) SubscribeOrderBook5(symbol, productType string, handler ws.OnReceive) {
+	r.channels = append(r.channels, "books5")
+}
+
+type candleClient struct {
+	granularities []string
+}
+
+func (c *candleClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.granularities = append(c.granularities, query.Get("granularity"))
+	data, _ := json.Marshal([][]string{
+		{"1700000000000", "1", "2", "0.5", "1.5", "10", "15"},
+		{"1700000060000", "1.5", "2", "1", "1.8", "10", "15"},
+	})
+	return &futures.ApiResponse{Code: "00000", Data: data}, &fasthttp.ResponseHeader{}, nil
+}
+
+func TestDataContext_LoadAndAttach(t *testing.T) {
+	dc := NewDataContext("BTCUSDT", futures.ProductTypeUSDTFutures, []string{"1m", "4h"}, DataContextOptions{})
+
+	client := &candleClient{}
+	require.NoError(t, dc.Load(context.Background(), client, 100))
+	assert.Equal(t, []string{"1m", "4H"}, client.granularities)
+	assert.Len(t, dc.Candles("1m"), 2)
+
+	sub := &recordingSubscriber{}
+	dc.Attach(sub)
+	assert.Equal(t, []string{"ticker", "books5", "candle1m", "candle4h"}, sub.channels)
+}
+
+func TestDataContext_HandleMessage(t *testing.T) {
+	var updates []string
+	dc := NewDataContext("BTCUSDT", futures.ProductTypeUSDTFutures, []string{"1m"}, DataContextOptions{
+		MaxCandles: 2,
+		OnUpdate:   func(channel string) { updates = append(updates, channel) },
+	})
+
+	dc.HandleMessage(`{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"candle1m","instId":"BTCUSDT"},"data":[["1700000000000","1","2","0.5","1.5","10","15","15"],["1700000060000","1.5","2","1","1.8","10","15","15"]]}`)
+	// Update of the forming candle replaces it, a new candle evicts the oldest
+	dc.HandleMessage(`{"action":"update","arg":{"instType":"USDT-FUTURES","channel":"candle1m","instId":"BTCUSDT"},"data":[["1700000060000","1.5","2.5","1","2.2","12","18","18"]]}`)
+	dc.HandleMessage(`{"action":"update","arg":{"instType":"USDT-FUTURES","channel":"candle1m","instId":"BTCUSDT"},"data":[["1700000120000","2.2","2.3","2.1","2.25","1","2","2"]]}`)
+	dc.HandleMessage(`{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"ticker","instId":"BTCUSDT"},"data":[{"instId":"BTCUSDT","lastPr":"2.24"}]}`)
+	dc.HandleMessage(`{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"books5","instId":"BTCUSDT"},"data":[{"bids":[["2.23","5"]],"asks":[["2.25","3"]],"ts":"1700000125000"}]}`)
+	// Other symbols are ignored
+	dc.HandleMessage(`{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"ticker","instId":"ETHUSDT"},"data":[{"instId":"ETHUSDT","lastPr":"3000"}]}`)
+
+	series := dc.Candles("1m")
+	require.Len(t, series, 2)
+	assert.Equal(t, 2.2, series[0].Close)
+	assert.Equal(t, int64(1700000120000), series[1].CloseTime)
+
+	closed := dc.ClosedCandles("1m", time.UnixMilli(1700000150000))
+	require.Len(t, closed, 1)
+
+	assert.Equal(t, 2.24, dc.Price())
+	book, ok := dc.Book()
+	require.True(t, ok)
+	bid, _ := book.BestBid()
+	assert.Equal(t, 2.23, bid)
+
+	snap := dc.Snapshot()
+	assert.Equal(t, "BTCUSDT", snap.Symbol)
+	assert.Len(t, snap.Candles["1m"], 2)
+	assert.NotNil(t, snap.Ticker)
+	assert.Equal(t, []string{"candle1m", "candle1m", "candle1m", "ticker", "books5"}, updates)
+}
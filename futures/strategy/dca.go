@@ -0,0 +1,412 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/khanbekov/go-bitget/state"
+)
+
+// DCANamespace is the default store namespace of DCAExecutor progress.
+const DCANamespace = "dca"
+
+// ErrDCACapReached is returned by DCAExecutor.Execute when a cap leaves nothing to order.
+var ErrDCACapReached = errors.New("dca: position cap reached")
+
+// DCAOptions configures a DCAExecutor.
+type DCAOptions struct {
+	// ID identifies the plan in the store. Defaults to "<symbol>-<side>".
+	ID string
+
+	Symbol      string
+	ProductType futures.ProductType
+	MarginCoin  string
+	MarginMode  trading.MarginMode
+	Side        trading.Side
+	// TradeSide is set in hedge mode: "open" to accumulate, "close" to distribute.
+	TradeSide trading.PositionSideType
+	// ReduceOnly distributes an existing position in one-way mode.
+	ReduceOnly bool
+
+	// Notional is the value of each order in the margin coin. Required.
+	Notional float64
+	// SizeStep is the contract size step; order sizes are rounded down to it. Required.
+	SizeStep float64
+
+	// Interval places an order every interval since the previous one. Zero disables the
+	// schedule.
+	Interval time.Duration
+	// Triggers are price levels that each place one order the first time the price
+	// reaches them: at or below the level for buys, at or above it for sells.
+	Triggers []float64
+
+	// MaxSize caps the total filled size. Zero disables it.
+	MaxSize float64
+	// MaxNotional caps the total filled value. Zero disables it.
+	MaxNotional float64
+	// MaxOrders caps the number of orders. Zero disables it.
+	MaxOrders int
+
+	// Store persists progress so a restarted executor continues where it stopped.
+	// Optional; Namespace defaults to DCANamespace.
+	Store     state.Store
+	Namespace string
+
+	// OnFill is called after every filled order. Optional.
+	OnFill func(DCAFill)
+	// Clock paces interval orders and stamps fills. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// DCAFill is one executed order.
+type DCAFill struct {
+	OrderID   string
+	ClientOid string
+	Reason    string // "schedule", a trigger such as "trigger:61000", or "manual"
+	Size      float64
+	Price     float64
+	Time      time.Time
+}
+
+// DCAProgress is the persisted state of a plan.
+type DCAProgress struct {
+	Orders    int       `json:"orders"`
+	Size      float64   `json:"size"`
+	Notional  float64   `json:"notional"`
+	LastOrder time.Time `json:"lastOrder"`
+	// Hit lists the triggers that already placed their order.
+	Hit []float64 `json:"hit,omitempty"`
+	// Pending is the client ID of an order submitted but not yet recorded. Load resolves
+	// it, so an order placed right before a crash is neither lost nor repeated.
+	Pending string `json:"pending,omitempty"`
+}
+
+// AvgPrice returns the average fill price, or zero before the first fill.
+func (p DCAProgress) AvgPrice() float64 {
+	if p.Size <= 0 {
+		return 0
+	}
+	return p.Notional / p.Size
+}
+
+// DCAExecutor buys or sells a fixed notional on a schedule and/or at price triggers until
+// a cap is reached, with market orders:
+//
+//	dca := strategy.NewDCAExecutor(client, strategy.DCAOptions{
+//		Symbol:      "BTCUSDT",
+//		ProductType: futures.ProductTypeUSDTFutures,
+//		MarginCoin:  "USDT",
+//		MarginMode:  trading.MarginModeCrossed,
+//		Side:        trading.SideBuy,
+//		Notional:    100,
+//		SizeStep:    0.001,
+//		Interval:    24 * time.Hour,
+//		Triggers:    []float64{58000, 55000, 52000},
+//		MaxNotional: 5000,
+//		Store:       store,
+//	})
+//	if err := dca.Load(ctx); err != nil { ... }
+//	go dca.Run(ctx, time.Minute, log.Println)
+//
+// It is safe for concurrent use.
+type DCAExecutor struct {
+	client futures.ClientInterface
+	opts   DCAOptions
+	clock  common.Clock
+
+	mu       sync.Mutex
+	progress DCAProgress
+}
+
+// NewDCAExecutor creates a DCA executor. Call Load to resume persisted progress.
+func NewDCAExecutor(client futures.ClientInterface, opts DCAOptions) *DCAExecutor {
+	if opts.ID == "" {
+		opts.ID = opts.Symbol + "-" + string(opts.Side)
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = DCANamespace
+	}
+	return &DCAExecutor{client: client, opts: opts, clock: common.ClockOrSystem(opts.Clock)}
+}
+
+// Load restores progress from the store and resolves an order that was pending when the
+// previous process stopped. A missing entry starts a new plan.
+func (d *DCAExecutor) Load(ctx context.Context) error {
+	if d.opts.Store == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var p DCAProgress
+	err := state.GetJSON(ctx, d.opts.Store, d.opts.Namespace, d.opts.ID, &p)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("dca: load %s: %w", d.opts.ID, err)
+	}
+	d.progress = p
+
+	if p.Pending == "" {
+		return nil
+	}
+	detail, err := trading.NewGetOrderDetailsService(d.client).
+		Symbol(d.opts.Symbol).
+		ProductType(trading.ProductType(d.opts.ProductType)).
+		ClientOid(p.Pending).
+		Do(ctx)
+	if err != nil && !types.IsAPIError(err) {
+		return fmt.Errorf("dca: resolve pending order %s: %w", p.Pending, err)
+	}
+	if err == nil && detail.OrderId != "" {
+		size, _ := strconv.ParseFloat(detail.BaseVolume, 64)
+		price, _ := strconv.ParseFloat(detail.PriceAvg, 64)
+		if size > 0 {
+			d.record(DCAFill{OrderID: detail.OrderId, ClientOid: p.Pending, Reason: "recovered", Size: size, Price: price, Time: d.clock.Now()})
+		}
+	}
+	d.progress.Pending = ""
+	return d.saveLocked(ctx)
+}
+
+// Progress returns a copy of the current progress.
+func (d *DCAExecutor) Progress() DCAProgress {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	p := d.progress
+	p.Hit = append([]float64(nil), p.Hit...)
+	return p
+}
+
+// Done reports whether a cap has been reached and every trigger has fired, or no schedule
+// remains to run.
+func (d *DCAExecutor) Done() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.capReached() || (d.opts.Interval <= 0 && len(d.progress.Hit) >= len(d.opts.Triggers))
+}
+
+// Check places the orders that are due at price: one for the schedule when Interval has
+// passed since the last order, and one for every trigger the price has reached. It
+// returns the fills.
+func (d *DCAExecutor) Check(ctx context.Context, price float64) ([]DCAFill, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if price <= 0 {
+		return nil, fmt.Errorf("dca: invalid price %v", price)
+	}
+
+	var reasons []string
+	var levels []float64
+	if d.opts.Interval > 0 && (d.progress.LastOrder.IsZero() || d.clock.Now().Sub(d.progress.LastOrder) >= d.opts.Interval) {
+		reasons = append(reasons, "schedule")
+		levels = append(levels, 0)
+	}
+	for _, level := range d.dueTriggers(price) {
+		reasons = append(reasons, "trigger:"+common.FormatFloat(level))
+		levels = append(levels, level)
+	}
+
+	var fills []DCAFill
+	for i, reason := range reasons {
+		fill, err := d.executeLocked(ctx, reason, price)
+		if errors.Is(err, ErrDCACapReached) {
+			break
+		}
+		if err != nil {
+			return fills, err
+		}
+		if levels[i] > 0 {
+			d.progress.Hit = append(d.progress.Hit, levels[i])
+			if err := d.saveLocked(ctx); err != nil {
+				return append(fills, fill), err
+			}
+		}
+		fills = append(fills, fill)
+	}
+	return fills, nil
+}
+
+// Execute places one order immediately, outside of the schedule and triggers. price is
+// used for sizing.
+func (d *DCAExecutor) Execute(ctx context.Context, price float64) (DCAFill, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.executeLocked(ctx, "manual", price)
+}
+
+// Run checks the last price every interval until ctx is cancelled or the plan is done.
+// Errors are passed to onError when it is non-nil.
+func (d *DCAExecutor) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := d.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for !d.Done() {
+		price, err := d.lastPrice(ctx)
+		if err == nil {
+			_, err = d.Check(ctx, price)
+		}
+		if err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// OrderSize returns the size of the next order at price after applying the caps, rounded
+// down to the size step.
+func (d *DCAExecutor) OrderSize(price float64) float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.orderSize(price)
+}
+
+func (d *DCAExecutor) orderSize(price float64) float64 {
+	if price <= 0 || d.opts.SizeStep <= 0 || d.capReached() {
+		return 0
+	}
+	notional := d.opts.Notional
+	if max := d.opts.MaxNotional; max > 0 {
+		notional = math.Min(notional, max-d.progress.Notional)
+	}
+	size := notional / price
+	if max := d.opts.MaxSize; max > 0 {
+		size = math.Min(size, max-d.progress.Size)
+	}
+	return math.Max(0, common.RoundDownToStep(size, d.opts.SizeStep))
+}
+
+func (d *DCAExecutor) capReached() bool {
+	p, o := d.progress, d.opts
+	return (o.MaxOrders > 0 && p.Orders >= o.MaxOrders) ||
+		(o.MaxSize > 0 && p.Size >= o.MaxSize) ||
+		(o.MaxNotional > 0 && p.Notional >= o.MaxNotional)
+}
+
+// dueTriggers returns the unfired triggers reached at price, nearest first.
+func (d *DCAExecutor) dueTriggers(price float64) []float64 {
+	hit := make(map[float64]bool, len(d.progress.Hit))
+	for _, level := range d.progress.Hit {
+		hit[level] = true
+	}
+	var due []float64
+	for _, level := range d.opts.Triggers {
+		if hit[level] {
+			continue
+		}
+		if (d.opts.Side == trading.SideBuy && price <= level) || (d.opts.Side == trading.SideSell && price >= level) {
+			due = append(due, level)
+		}
+	}
+	// Buys reach the highest level first, sells the lowest
+	sort.Slice(due, func(i, j int) bool {
+		if d.opts.Side == trading.SideBuy {
+			return due[i] > due[j]
+		}
+		return due[i] < due[j]
+	})
+	return due
+}
+
+func (d *DCAExecutor) executeLocked(ctx context.Context, reason string, price float64) (DCAFill, error) {
+	size := d.orderSize(price)
+	if size <= 0 {
+		return DCAFill{}, ErrDCACapReached
+	}
+
+	// Persist the client ID first so an order placed right before a crash is found by Load
+	clientOid := fmt.Sprintf("dca%d%d", d.clock.Now().UnixMilli(), d.progress.Orders+1)
+	d.progress.Pending = clientOid
+	if err := d.saveLocked(ctx); err != nil {
+		d.progress.Pending = ""
+		return DCAFill{}, err
+	}
+
+	order := trading.NewCreateOrderService(d.client).
+		Symbol(d.opts.Symbol).
+		ProductType(trading.ProductType(d.opts.ProductType)).
+		MarginCoin(d.opts.MarginCoin).
+		MarginMode(d.opts.MarginMode).
+		SideType(d.opts.Side).
+		OrderType(trading.OrderTypeMarket).
+		Size(common.FormatToStep(size, d.opts.SizeStep)).
+		ClientOrderId(clientOid)
+	if d.opts.TradeSide != "" {
+		order.PositionSideType(d.opts.TradeSide)
+	} else if d.opts.ReduceOnly {
+		order.ReduceOnly(true)
+	}
+	info, err := order.Do(ctx)
+	if err != nil {
+		if types.IsAPIError(err) {
+			// Rejected: nothing was placed
+			d.progress.Pending = ""
+			_ = d.saveLocked(ctx)
+		}
+		return DCAFill{}, fmt.Errorf("dca: %s order for %s: %w", reason, d.opts.Symbol, err)
+	}
+
+	fill := DCAFill{OrderID: info.OrderId, ClientOid: clientOid, Reason: reason, Size: size, Price: price, Time: d.clock.Now()}
+	if detail, err := trading.NewGetOrderDetailsService(d.client).
+		Symbol(d.opts.Symbol).
+		ProductType(trading.ProductType(d.opts.ProductType)).
+		OrderId(info.OrderId).
+		Do(ctx); err == nil {
+		if v, err := strconv.ParseFloat(detail.BaseVolume, 64); err == nil && v > 0 {
+			fill.Size = v
+		}
+		if v, err := strconv.ParseFloat(detail.PriceAvg, 64); err == nil && v > 0 {
+			fill.Price = v
+		}
+	}
+	d.record(fill)
+	d.progress.Pending = ""
+	if err := d.saveLocked(ctx); err != nil {
+		return fill, err
+	}
+	if d.opts.OnFill != nil {
+		d.opts.OnFill(fill)
+	}
+	return fill, nil
+}
+
+func (d *DCAExecutor) record(fill DCAFill) {
+	d.progress.Orders++
+	d.progress.Size += fill.Size
+	d.progress.Notional += fill.Size * fill.Price
+	d.progress.LastOrder = fill.Time
+}
+
+func (d *DCAExecutor) saveLocked(ctx context.Context) error {
+	if d.opts.Store == nil {
+		return nil
+	}
+	if err := state.PutJSON(ctx, d.opts.Store, d.opts.Namespace, d.opts.ID, d.progress); err != nil {
+		return fmt.Errorf("dca: save %s: %w", d.opts.ID, err)
+	}
+	return nil
+}
+
+func (d *DCAExecutor) lastPrice(ctx context.Context) (float64, error) {
+	t, err := market.NewTickerService(d.client).Symbol(d.opts.Symbol).ProductType(string(d.opts.ProductType)).Do(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("dca: ticker %s: %w", d.opts.Symbol, err)
+	}
+	return strconv.ParseFloat(t.LastPr, 64)
+}
@@ -0,0 +1,175 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/khanbekov/go-bitget/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// dcaClient fills market orders at fillPrice and remembers them by client ID.
+type dcaClient struct {
+	mu        sync.Mutex
+	fillPrice string
+	orders    []map[string]string
+	known     map[string]string // clientOid -> baseVolume of orders placed earlier
+}
+
+func (c *dcaClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ok := func(data string) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+		return &futures.ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil
+	}
+	switch endpoint {
+	case trading.EndpointPlaceOrder:
+		var req map[string]string
+		_ = json.Unmarshal(body, &req)
+		c.orders = append(c.orders, req)
+		return ok(`{"orderId":"` + req["clientOid"] + `","clientOid":"` + req["clientOid"] + `"}`)
+	case trading.EndpointOrderDetails:
+		if oid := query.Get("clientOid"); oid != "" {
+			size, found := c.known[oid]
+			if !found {
+				return nil, nil, &types.APIError{Code: 40109, Message: "order does not exist"}
+			}
+			return ok(`{"orderId":"recovered","baseVolume":"` + size + `","priceAvg":"` + c.fillPrice + `"}`)
+		}
+		for _, o := range c.orders {
+			if o["clientOid"] == query.Get("orderId") {
+				return ok(`{"orderId":"` + o["clientOid"] + `","baseVolume":"` + o["size"] + `","priceAvg":"` + c.fillPrice + `"}`)
+			}
+		}
+	}
+	return nil, nil, &types.APIError{Code: 40000, Message: "unexpected endpoint " + endpoint}
+}
+
+func dcaOptions() DCAOptions {
+	return DCAOptions{
+		Symbol:      "BTCUSDT",
+		ProductType: futures.ProductTypeUSDTFutures,
+		MarginCoin:  "USDT",
+		MarginMode:  trading.MarginModeCrossed,
+		Side:        trading.SideBuy,
+		Notional:    1000,
+		SizeStep:    0.001,
+	}
+}
+
+func TestDCAExecutor_OrderSizeCaps(t *testing.T) {
+	opts := dcaOptions()
+	opts.MaxNotional = 2500
+	opts.MaxSize = 0.06
+	d := NewDCAExecutor(&dcaClient{}, opts)
+
+	assert.InDelta(t, 0.02, d.OrderSize(50000), 1e-9)
+
+	d.progress = DCAProgress{Orders: 2, Size: 0.04, Notional: 2000}
+	assert.InDelta(t, 0.01, d.OrderSize(50000), 1e-9, "capped by the remaining notional")
+
+	d.progress = DCAProgress{Orders: 2, Size: 0.055, Notional: 1000}
+	assert.InDelta(t, 0.005, d.OrderSize(50000), 1e-9, "capped by the remaining size")
+
+	d.progress = DCAProgress{Orders: 3, Size: 0.06, Notional: 2400}
+	assert.Zero(t, d.OrderSize(50000))
+	assert.True(t, d.Done())
+}
+
+func TestDCAExecutor_ScheduleAndTriggers(t *testing.T) {
+	client := &dcaClient{fillPrice: "50000"}
+	opts := dcaOptions()
+	opts.Interval = time.Hour
+	opts.Triggers = []float64{48000, 49000}
+	clock := common.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	opts.Clock = clock
+	d := NewDCAExecutor(client, opts)
+
+	fills, err := d.Check(context.Background(), 50000)
+	require.NoError(t, err)
+	require.Len(t, fills, 1)
+	assert.Equal(t, "schedule", fills[0].Reason)
+	assert.Equal(t, "0.020", client.orders[0]["size"])
+	assert.Equal(t, "buy", client.orders[0]["side"])
+
+	// Not due yet; the price reaches both triggers, nearest first
+	clock.Advance(30 * time.Minute)
+	fills, err = d.Check(context.Background(), 47900)
+	require.NoError(t, err)
+	require.Len(t, fills, 2)
+	assert.Equal(t, "trigger:49000", fills[0].Reason)
+	assert.Equal(t, "trigger:48000", fills[1].Reason)
+
+	// Triggers fire once
+	fills, err = d.Check(context.Background(), 47000)
+	require.NoError(t, err)
+	assert.Empty(t, fills)
+
+	p := d.Progress()
+	assert.Equal(t, 3, p.Orders)
+	assert.InDelta(t, 0.06, p.Size, 1e-9)
+	assert.InDelta(t, 50000, p.AvgPrice(), 1e-6)
+	assert.ElementsMatch(t, []float64{49000, 48000}, p.Hit)
+}
+
+func TestDCAExecutor_PersistsAndResumes(t *testing.T) {
+	store := state.NewMemoryStore()
+	client := &dcaClient{fillPrice: "50000"}
+	opts := dcaOptions()
+	opts.Store = store
+	opts.MaxOrders = 2
+	opts.Triggers = []float64{49000}
+
+	d := NewDCAExecutor(client, opts)
+	require.NoError(t, d.Load(context.Background()))
+	_, err := d.Check(context.Background(), 48000)
+	require.NoError(t, err)
+
+	resumed := NewDCAExecutor(client, opts)
+	require.NoError(t, resumed.Load(context.Background()))
+	want, got := d.Progress(), resumed.Progress()
+	assert.Equal(t, want.Orders, got.Orders)
+	assert.Equal(t, want.Hit, got.Hit)
+	assert.True(t, want.LastOrder.Equal(got.LastOrder))
+	assert.Empty(t, got.Pending)
+
+	_, err = resumed.Execute(context.Background(), 50000)
+	require.NoError(t, err)
+	_, err = resumed.Execute(context.Background(), 50000)
+	assert.ErrorIs(t, err, ErrDCACapReached)
+	assert.Len(t, client.orders, 2)
+}
+
+func TestDCAExecutor_LoadResolvesPendingOrder(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	opts := dcaOptions()
+	opts.Store = store
+	require.NoError(t, state.PutJSON(ctx, store, DCANamespace, "BTCUSDT-buy", DCAProgress{Orders: 1, Size: 0.02, Notional: 1000, Pending: "dca1"}))
+
+	// The pending order reached the exchange before the crash
+	client := &dcaClient{fillPrice: "50000", known: map[string]string{"dca1": "0.02"}}
+	d := NewDCAExecutor(client, opts)
+	require.NoError(t, d.Load(ctx))
+	p := d.Progress()
+	assert.Equal(t, 2, p.Orders)
+	assert.InDelta(t, 0.04, p.Size, 1e-9)
+	assert.Empty(t, p.Pending)
+
+	// It never reached the exchange
+	require.NoError(t, state.PutJSON(ctx, store, DCANamespace, "BTCUSDT-buy", DCAProgress{Orders: 1, Size: 0.02, Notional: 1000, Pending: "dca2"}))
+	d = NewDCAExecutor(client, opts)
+	require.NoError(t, d.Load(ctx))
+	assert.Equal(t, 1, d.Progress().Orders)
+	assert.Empty(t, d.Progress().Pending)
+}
@@ -0,0 +1,458 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/config"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/khanbekov/go-bitget/uta"
+)
+
+// ErrCannotShortHedge is returned by FundingHarvester.Open when negative funding would
+// require shorting a hedge leg that can only hold longs, such as spot.
+var ErrCannotShortHedge = errors.New("funding: hedge leg cannot be shorted")
+
+// HedgeLeg executes the side of a FundingHarvester that offsets the perpetual position.
+type HedgeLeg interface {
+	// Open opens size (in the base coin) on side with a market order and returns the
+	// average fill price.
+	Open(ctx context.Context, side trading.Side, size string) (float64, error)
+	// Close closes size of a position that was opened on side.
+	Close(ctx context.Context, side trading.Side, size string) (float64, error)
+	// CanShort reports whether Open accepts trading.SideSell.
+	CanShort() bool
+}
+
+// PerpLeg hedges with the opposite side of another perpetual contract, e.g. the USDC
+// margined contract of the same coin.
+type PerpLeg struct {
+	Client      futures.ClientInterface
+	Symbol      string
+	ProductType futures.ProductType
+	MarginCoin  string
+	MarginMode  trading.MarginMode
+	// Hedge is set when the account uses hedge position mode.
+	Hedge bool
+}
+
+// Open implements HedgeLeg.
+func (l PerpLeg) Open(ctx context.Context, side trading.Side, size string) (float64, error) {
+	order := l.order(side, size)
+	if l.Hedge {
+		order.PositionSideType(trading.PositionSideOpen)
+	}
+	return l.fill(ctx, order)
+}
+
+// Close implements HedgeLeg.
+func (l PerpLeg) Close(ctx context.Context, side trading.Side, size string) (float64, error) {
+	return l.fill(ctx, l.order(side, size).Close(side.HoldSide(), l.Hedge))
+}
+
+// CanShort implements HedgeLeg.
+func (l PerpLeg) CanShort() bool { return true }
+
+func (l PerpLeg) order(side trading.Side, size string) *trading.CreateOrderService {
+	return trading.NewCreateOrderService(l.Client).
+		Symbol(l.Symbol).
+		ProductType(trading.ProductType(l.ProductType)).
+		MarginCoin(l.MarginCoin).
+		MarginMode(l.MarginMode).
+		SideType(side).
+		OrderType(trading.OrderTypeMarket).
+		Size(size)
+}
+
+func (l PerpLeg) fill(ctx context.Context, order *trading.CreateOrderService) (float64, error) {
+	info, err := order.Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	detail, err := trading.NewGetOrderDetailsService(l.Client).
+		Symbol(l.Symbol).
+		ProductType(trading.ProductType(l.ProductType)).
+		OrderId(info.OrderId).
+		Do(ctx)
+	if err != nil {
+		// The order went through; only the fill price is unknown
+		return 0, nil
+	}
+	price, _ := strconv.ParseFloat(detail.PriceAvg, 64)
+	return price, nil
+}
+
+// SpotLeg hedges with spot holdings on a unified trading account. It can only hold
+// longs, so it hedges positive funding.
+type SpotLeg struct {
+	Client uta.ClientInterface
+	Symbol string
+}
+
+// Open implements HedgeLeg.
+func (l SpotLeg) Open(ctx context.Context, side trading.Side, size string) (float64, error) {
+	if side != trading.SideBuy {
+		return 0, ErrCannotShortHedge
+	}
+	return l.trade(ctx, uta.SideBuy, size)
+}
+
+// Close implements HedgeLeg.
+func (l SpotLeg) Close(ctx context.Context, side trading.Side, size string) (float64, error) {
+	return l.trade(ctx, uta.SideSell, size)
+}
+
+// CanShort implements HedgeLeg.
+func (l SpotLeg) CanShort() bool { return false }
+
+func (l SpotLeg) trade(ctx context.Context, side, size string) (float64, error) {
+	order, err := l.Client.NewPlaceOrderService().
+		Category(uta.CategorySpot).
+		Symbol(l.Symbol).
+		Side(side).
+		OrderType(uta.OrderTypeMarket).
+		Size(size).
+		Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	// The place-order response only carries the order IDs
+	detail, err := l.Client.NewGetOrderDetailsService().OrderId(order.OrderID).Do(ctx)
+	if err != nil {
+		// The order went through; only the fill price is unknown
+		return 0, nil
+	}
+	price, _ := strconv.ParseFloat(detail.AvgPrice, 64)
+	return price, nil
+}
+
+// UTATransfer returns a FundingOptions.Transfer function that moves coin between two
+// account types of a unified trading account, e.g. uta.AccountTypeSpot to
+// uta.AccountTypeUSDTFutures.
+func UTATransfer(client uta.ClientInterface, fromType, toType, coin string) func(ctx context.Context, amount float64) error {
+	return func(ctx context.Context, amount float64) error {
+		_, err := client.NewTransferService().
+			FromType(fromType).
+			ToType(toType).
+			Coin(coin).
+			Amount(common.FormatFloat(amount)).
+			Do(ctx)
+		return err
+	}
+}
+
+// FundingOptions configures a FundingHarvester.
+type FundingOptions struct {
+	// Symbol is the perpetual contract that pays funding.
+	Symbol      string
+	ProductType futures.ProductType
+	MarginCoin  string
+	MarginMode  trading.MarginMode
+	// Hedge is set when the account uses hedge position mode.
+	Hedge bool
+
+	// Leg offsets the perpetual position. Required.
+	Leg HedgeLeg
+
+	// Notional is the value of each side in the margin coin. Required.
+	Notional float64
+	// SizeStep is the size step shared by both legs; sizes are rounded down to it. Required.
+	SizeStep float64
+
+	// EnterRate is the minimum absolute funding rate per interval to open, e.g. 0.0003.
+	EnterRate float64
+	// ExitRate closes the position once the rate in the harvested direction falls below
+	// it. Zero closes when the sign of the rate flips.
+	ExitRate float64
+	// Negative also harvests negative funding (long perpetual, short hedge) when the
+	// hedge leg can be shorted.
+	Negative bool
+
+	// Transfer moves Margin into the perpetual account before opening. Optional; see
+	// UTATransfer.
+	Transfer func(ctx context.Context, amount float64) error
+	Margin   float64
+
+	// Limits apply MaxPositionNotional to the position before opening. Optional.
+	Limits config.RiskLimits
+
+	// OnEvent is called after every open and close. Optional.
+	OnEvent func(FundingEvent)
+	// Clock stamps positions. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// FundingPosition is an open delta-neutral position.
+type FundingPosition struct {
+	PerpSide  trading.Side // SideSell harvests positive funding
+	Size      string
+	PerpPrice float64
+	LegPrice  float64
+	EntryRate float64
+	OpenedAt  time.Time
+
+	perpClosed, legClosed bool // Set by a partially failed close so a retry skips them
+}
+
+// FundingEvent reports an open or close of a FundingHarvester.
+type FundingEvent struct {
+	Action   string // "open" or "close"
+	Rate     float64
+	Position FundingPosition
+	Err      error
+}
+
+// FundingHarvester holds a perpetual position against an offsetting hedge leg while the
+// funding rate pays that side, so the position collects funding with no net exposure to
+// the price:
+//
+//	h := strategy.NewFundingHarvester(client, strategy.FundingOptions{
+//		Symbol:      "BTCUSDT",
+//		ProductType: futures.ProductTypeUSDTFutures,
+//		MarginCoin:  "USDT",
+//		MarginMode:  trading.MarginModeCrossed,
+//		Leg:         strategy.SpotLeg{Client: utaClient, Symbol: "BTCUSDT"},
+//		Notional:    5000,
+//		SizeStep:    0.001,
+//		EnterRate:   0.0003,
+//		ExitRate:    0.00005,
+//		Transfer:    strategy.UTATransfer(utaClient, uta.AccountTypeSpot, uta.AccountTypeUSDTFutures, "USDT"),
+//		Margin:      1000,
+//	})
+//	go h.Run(ctx, time.Minute, log.Println)
+//
+// It is safe for concurrent use.
+type FundingHarvester struct {
+	client futures.ClientInterface
+	opts   FundingOptions
+	clock  common.Clock
+
+	mu       sync.Mutex
+	position *FundingPosition
+	rate     float64
+}
+
+// NewFundingHarvester creates a funding harvester.
+func NewFundingHarvester(client futures.ClientInterface, opts FundingOptions) *FundingHarvester {
+	return &FundingHarvester{client: client, opts: opts, clock: common.ClockOrSystem(opts.Clock)}
+}
+
+// Rate returns the current funding rate of the perpetual contract.
+func (h *FundingHarvester) Rate(ctx context.Context) (float64, error) {
+	res, err := market.NewCurrentFundingRateService(h.client).
+		Symbol(h.opts.Symbol).
+		ProductType(market.ProductType(h.opts.ProductType)).
+		Do(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("funding: rate %s: %w", h.opts.Symbol, err)
+	}
+	if len(res.FundingRates) == 0 {
+		return 0, fmt.Errorf("funding: no rate for %s", h.opts.Symbol)
+	}
+	return strconv.ParseFloat(res.FundingRates[0].FundingRate, 64)
+}
+
+// Position returns the open position, or nil.
+func (h *FundingHarvester) Position() *FundingPosition {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.position == nil {
+		return nil
+	}
+	p := *h.position
+	return &p
+}
+
+// Check reads the funding rate and opens or closes the position when the thresholds are
+// crossed. It returns "open", "close" or "" when nothing changed.
+func (h *FundingHarvester) Check(ctx context.Context) (string, error) {
+	rate, err := h.Rate(ctx)
+	if err != nil {
+		return "", err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.rate = rate
+
+	if h.position == nil {
+		if !h.worthOpening(rate) {
+			return "", nil
+		}
+		return "open", h.openLocked(ctx, rate)
+	}
+	// Funding the position still earns, signed so that positive is income
+	earned := rate
+	if h.position.PerpSide == trading.SideBuy {
+		earned = -rate
+	}
+	if earned > 0 && earned >= h.opts.ExitRate {
+		return "", nil
+	}
+	return "close", h.closeLocked(ctx)
+}
+
+// Open opens the position for a funding rate regardless of the thresholds.
+func (h *FundingHarvester) Open(ctx context.Context, rate float64) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.openLocked(ctx, rate)
+}
+
+// Close closes both legs.
+func (h *FundingHarvester) Close(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.closeLocked(ctx)
+}
+
+// Run calls Check every interval until ctx is cancelled. Errors are passed to onError
+// when it is non-nil.
+func (h *FundingHarvester) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := h.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := h.Check(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// Shutdown closes the position. It implements lifecycle.Component.
+func (h *FundingHarvester) Shutdown(ctx context.Context) error {
+	return h.Close(ctx)
+}
+
+func (h *FundingHarvester) worthOpening(rate float64) bool {
+	if math.Abs(rate) < h.opts.EnterRate || rate == 0 {
+		return false
+	}
+	return rate > 0 || (h.opts.Negative && h.opts.Leg.CanShort())
+}
+
+func (h *FundingHarvester) openLocked(ctx context.Context, rate float64) error {
+	if h.position != nil {
+		return nil
+	}
+	// Positive funding is paid by longs to shorts
+	perpSide, legSide := trading.SideSell, trading.SideBuy
+	if rate < 0 {
+		perpSide, legSide = trading.SideBuy, trading.SideSell
+	}
+	if legSide == trading.SideSell && !h.opts.Leg.CanShort() {
+		return ErrCannotShortHedge
+	}
+
+	price, err := h.lastPrice(ctx)
+	if err != nil {
+		return err
+	}
+	if h.opts.SizeStep <= 0 {
+		return fmt.Errorf("funding: size step is required")
+	}
+	size := common.RoundDownToStep(h.opts.Notional/price, h.opts.SizeStep)
+	if size <= 0 {
+		return fmt.Errorf("funding: notional %v is below the minimum size", h.opts.Notional)
+	}
+	if max := h.opts.Limits.MaxPositionNotional; max > 0 && size*price > max {
+		return fmt.Errorf("funding: notional %.2f exceeds the limit %.2f", size*price, max)
+	}
+	pos := &FundingPosition{PerpSide: perpSide, Size: common.FormatToStep(size, h.opts.SizeStep), EntryRate: rate, OpenedAt: h.clock.Now()}
+
+	if h.opts.Transfer != nil && h.opts.Margin > 0 {
+		if err := h.opts.Transfer(ctx, h.opts.Margin); err != nil {
+			return h.event("open", rate, *pos, fmt.Errorf("funding: transfer margin: %w", err))
+		}
+	}
+
+	perp := h.perpOrder(perpSide, pos.Size)
+	if h.opts.Hedge {
+		perp.PositionSideType(trading.PositionSideOpen)
+	}
+	if pos.PerpPrice, err = h.fill(ctx, perp); err != nil {
+		return h.event("open", rate, *pos, fmt.Errorf("funding: open %s: %w", h.opts.Symbol, err))
+	}
+	if pos.LegPrice, err = h.opts.Leg.Open(ctx, legSide, pos.Size); err != nil {
+		// Never keep the perpetual side without its hedge
+		if _, closeErr := h.fill(ctx, h.perpClose(perpSide, pos.Size)); closeErr != nil {
+			err = fmt.Errorf("%w; unwinding %s also failed: %v", err, h.opts.Symbol, closeErr)
+		}
+		return h.event("open", rate, *pos, fmt.Errorf("funding: open hedge: %w", err))
+	}
+	h.position = pos
+	return h.event("open", rate, *pos, nil)
+}
+
+func (h *FundingHarvester) closeLocked(ctx context.Context) error {
+	if h.position == nil {
+		return nil
+	}
+	pos := h.position
+
+	var errs []error
+	if !pos.perpClosed {
+		if _, err := h.fill(ctx, h.perpClose(pos.PerpSide, pos.Size)); err != nil {
+			errs = append(errs, fmt.Errorf("funding: close %s: %w", h.opts.Symbol, err))
+		} else {
+			pos.perpClosed = true
+		}
+	}
+	if !pos.legClosed {
+		if _, err := h.opts.Leg.Close(ctx, pos.PerpSide.Opposite(), pos.Size); err != nil {
+			errs = append(errs, fmt.Errorf("funding: close hedge: %w", err))
+		} else {
+			pos.legClosed = true
+		}
+	}
+	err := errors.Join(errs...)
+	if err == nil {
+		h.position = nil
+	}
+	return h.event("close", h.rate, *pos, err)
+}
+
+func (h *FundingHarvester) event(action string, rate float64, pos FundingPosition, err error) error {
+	if h.opts.OnEvent != nil {
+		h.opts.OnEvent(FundingEvent{Action: action, Rate: rate, Position: pos, Err: err})
+	}
+	return err
+}
+
+func (h *FundingHarvester) perpOrder(side trading.Side, size string) *trading.CreateOrderService {
+	return PerpLeg{
+		Client:      h.client,
+		Symbol:      h.opts.Symbol,
+		ProductType: h.opts.ProductType,
+		MarginCoin:  h.opts.MarginCoin,
+		MarginMode:  h.opts.MarginMode,
+	}.order(side, size)
+}
+
+func (h *FundingHarvester) perpClose(side trading.Side, size string) *trading.CreateOrderService {
+	return h.perpOrder(side, size).Close(side.HoldSide(), h.opts.Hedge)
+}
+
+func (h *FundingHarvester) fill(ctx context.Context, order *trading.CreateOrderService) (float64, error) {
+	return PerpLeg{Client: h.client, Symbol: h.opts.Symbol, ProductType: h.opts.ProductType}.fill(ctx, order)
+}
+
+func (h *FundingHarvester) lastPrice(ctx context.Context) (float64, error) {
+	t, err := market.NewTickerService(h.client).Symbol(h.opts.Symbol).ProductType(string(h.opts.ProductType)).Do(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("funding: ticker %s: %w", h.opts.Symbol, err)
+	}
+	return strconv.ParseFloat(t.LastPr, 64)
+}
@@ -0,0 +1,194 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/khanbekov/go-bitget/uta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// fundingClient quotes BTCUSDT at 50000 with a configurable funding rate and fills
+// market orders at 50000.
+type fundingClient struct {
+	mu     sync.Mutex
+	rate   string
+	orders []map[string]string
+}
+
+func (c *fundingClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ok := func(data string) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+		return &futures.ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil
+	}
+	switch endpoint {
+	case market.EndpointCurrentFundingRate:
+		return ok(`[{"symbol":"BTCUSDT","fundingRate":"` + c.rate + `"}]`)
+	case futures.EndpointTicker:
+		return ok(`[{"symbol":"BTCUSDT","lastPr":"50000"}]`)
+	case trading.EndpointPlaceOrder:
+		var req map[string]string
+		_ = json.Unmarshal(body, &req)
+		c.orders = append(c.orders, req)
+		return ok(`{"orderId":"1"}`)
+	case trading.EndpointOrderDetails:
+		return ok(`{"orderId":"1","priceAvg":"50000"}`)
+	}
+	return nil, nil, errors.New("unexpected endpoint " + endpoint)
+}
+
+type fakeLeg struct {
+	canShort bool
+	fail     error
+	trades   []string
+}
+
+func (l *fakeLeg) Open(ctx context.Context, side trading.Side, size string) (float64, error) {
+	if l.fail != nil {
+		return 0, l.fail
+	}
+	l.trades = append(l.trades, "open "+string(side)+" "+size)
+	return 50010, nil
+}
+
+func (l *fakeLeg) Close(ctx context.Context, side trading.Side, size string) (float64, error) {
+	l.trades = append(l.trades, "close "+string(side)+" "+size)
+	return 50000, nil
+}
+
+func (l *fakeLeg) CanShort() bool { return l.canShort }
+
+func fundingOptions(leg HedgeLeg) FundingOptions {
+	return FundingOptions{
+		Symbol:      "BTCUSDT",
+		ProductType: futures.ProductTypeUSDTFutures,
+		MarginCoin:  "USDT",
+		MarginMode:  trading.MarginModeCrossed,
+		Leg:         leg,
+		Notional:    5000,
+		SizeStep:    0.001,
+		EnterRate:   0.0003,
+		ExitRate:    0.0001,
+	}
+}
+
+func TestFundingHarvester_OpensAndClosesOnRate(t *testing.T) {
+	client := &fundingClient{rate: "0.0001"}
+	leg := &fakeLeg{}
+	var transferred float64
+	opts := fundingOptions(leg)
+	opts.Margin = 1000
+	opts.Transfer = func(ctx context.Context, amount float64) error {
+		transferred += amount
+		return nil
+	}
+	h := NewFundingHarvester(client, opts)
+
+	action, err := h.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, action, "below the entry rate")
+
+	client.rate = "0.0005"
+	action, err = h.Check(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "open", action)
+	assert.Equal(t, 1000.0, transferred)
+	require.Len(t, client.orders, 1)
+	assert.Equal(t, "sell", client.orders[0]["side"], "positive funding is collected by shorts")
+	assert.Equal(t, "0.100", client.orders[0]["size"])
+	assert.Equal(t, []string{"open buy 0.100"}, leg.trades)
+	pos := h.Position()
+	require.NotNil(t, pos)
+	assert.Equal(t, 50010.0, pos.LegPrice)
+
+	// Still above the exit rate
+	client.rate = "0.0002"
+	action, err = h.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, action)
+
+	client.rate = "0.00005"
+	action, err = h.Check(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "close", action)
+	assert.Equal(t, "buy", client.orders[1]["side"])
+	assert.Equal(t, "YES", client.orders[1]["reduceOnly"])
+	assert.Equal(t, "close buy 0.100", leg.trades[1])
+	assert.Nil(t, h.Position())
+}
+
+func TestFundingHarvester_NegativeFundingNeedsShortableLeg(t *testing.T) {
+	client := &fundingClient{rate: "-0.0005"}
+	opts := fundingOptions(&fakeLeg{})
+	opts.Negative = true
+	h := NewFundingHarvester(client, opts)
+
+	action, err := h.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, action, "a long-only leg cannot hedge a long perpetual")
+	assert.ErrorIs(t, h.Open(context.Background(), -0.0005), ErrCannotShortHedge)
+
+	leg := &fakeLeg{canShort: true}
+	opts.Leg = leg
+	h = NewFundingHarvester(client, opts)
+	action, err = h.Check(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "open", action)
+	assert.Equal(t, "buy", client.orders[0]["side"])
+	assert.Equal(t, []string{"open sell 0.100"}, leg.trades)
+}
+
+func TestFundingHarvester_UnwindsPerpWhenHedgeFails(t *testing.T) {
+	client := &fundingClient{rate: "0.001"}
+	var events []FundingEvent
+	opts := fundingOptions(&fakeLeg{fail: errors.New("insufficient balance")})
+	opts.OnEvent = func(ev FundingEvent) { events = append(events, ev) }
+	h := NewFundingHarvester(client, opts)
+
+	_, err := h.Check(context.Background())
+	assert.ErrorContains(t, err, "insufficient balance")
+	require.Len(t, client.orders, 2)
+	assert.Equal(t, "sell", client.orders[0]["side"])
+	assert.Equal(t, "buy", client.orders[1]["side"])
+	assert.Equal(t, "YES", client.orders[1]["reduceOnly"])
+	assert.Nil(t, h.Position())
+	require.Len(t, events, 1)
+	assert.Error(t, events[0].Err)
+}
+
+func TestSpotLeg_FillPriceFromOrderDetails(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case uta.EndpointTradePlaceOrder:
+			// The real response only carries the order IDs
+			fmt.Fprint(w, `{"code":"00000","msg":"success","data":{"orderId":"88","clientOid":"c1"}}`)
+		case uta.EndpointTradeOrderInfo:
+			assert.Equal(t, "88", r.URL.Query().Get("orderId"))
+			fmt.Fprint(w, `{"code":"00000","msg":"success","data":{"orderId":"88","symbol":"BTCUSDT","avgPrice":"50012.5","status":"filled"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	leg := SpotLeg{Client: uta.NewClient("key", "secret", "pass").SetBaseURL(server.URL), Symbol: "BTCUSDT"}
+	price, err := leg.Open(context.Background(), trading.SideBuy, "0.1")
+	require.NoError(t, err)
+	assert.Equal(t, 50012.5, price)
+	assert.Equal(t, []string{uta.EndpointTradePlaceOrder, uta.EndpointTradeOrderInfo}, paths)
+}
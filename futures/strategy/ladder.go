@@ -0,0 +1,328 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/khanbekov/go-bitget/futures/trading"
+)
+
+// TPLevel is one rung of a take-profit ladder.
+type TPLevel struct {
+	// Price is the limit price of the rung.
+	Price float64
+	// Fraction is the share of the position closed at Price, e.g. 0.5 for half.
+	Fraction float64
+}
+
+// TPLadderOptions configures a TPLadder.
+type TPLadderOptions struct {
+	Symbol      string
+	ProductType futures.ProductType
+	MarginCoin  string
+	MarginMode  trading.MarginMode
+	// HoldSide is the side of the position to take profit on. Required.
+	HoldSide trading.HoldSide
+	// Hedge is set when the account uses hedge position mode; orders then close the
+	// position instead of being reduce-only.
+	Hedge bool
+
+	// Levels are the rungs, nearest to the entry first. Fractions should add up to at
+	// most 1; when they add up to 1 the last rung also takes the rounding remainder.
+	Levels []TPLevel
+	// SizeStep is the contract size step; rung sizes are rounded down to it. Required.
+	SizeStep float64
+	// PriceTick formats prices with its number of decimals. Optional.
+	PriceTick float64
+	// MinSize is the smallest order placed; smaller rungs are left empty. Defaults to
+	// SizeStep.
+	MinSize float64
+	// Clock seeds the rung client order IDs. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// TPRung is the state of one rung of a TPLadder.
+type TPRung struct {
+	TPLevel
+	// OrderID and ClientOid identify the resting order; empty when none rests.
+	OrderID   string
+	ClientOid string
+	// Size is the size of the resting order, Filled the size it has filled.
+	Size   float64
+	Filled float64
+	// Closed is the size filled at this rung by orders that no longer rest.
+	Closed float64
+}
+
+// Remaining returns the unfilled size of the resting order.
+func (r TPRung) Remaining() float64 {
+	return math.Max(0, r.Size-r.Filled)
+}
+
+// TPLadder keeps a ladder of reduce-only limit orders taking profit on a position. Sync
+// resizes the rungs whenever the position changes: fills at a rung count against that
+// rung only, so a partially filled rung keeps resting with what is left of it, while a
+// position increased or reduced elsewhere rescales every rung. When the position closes
+// the remaining orders are cancelled:
+//
+//	ladder := strategy.NewTPLadder(client, strategy.TPLadderOptions{
+//		Symbol:      "BTCUSDT",
+//		ProductType: futures.ProductTypeUSDTFutures,
+//		MarginCoin:  "USDT",
+//		MarginMode:  trading.MarginModeCrossed,
+//		HoldSide:    trading.HoldSideLong,
+//		Levels:      []strategy.TPLevel{{Price: 62000, Fraction: 0.5}, {Price: 65000, Fraction: 0.5}},
+//		SizeStep:    0.001,
+//	})
+//	go ladder.Run(ctx, positions.Events(), log.Println)
+//
+// It is safe for concurrent use.
+type TPLadder struct {
+	client futures.ClientInterface
+	opts   TPLadderOptions
+	clock  common.Clock
+
+	mu     sync.Mutex
+	rungs  []TPRung
+	closed bool
+}
+
+// NewTPLadder creates a take-profit ladder. No order is placed before the first Sync.
+func NewTPLadder(client futures.ClientInterface, opts TPLadderOptions) *TPLadder {
+	if opts.MinSize < opts.SizeStep {
+		opts.MinSize = opts.SizeStep
+	}
+	rungs := make([]TPRung, len(opts.Levels))
+	for i, level := range opts.Levels {
+		rungs[i].TPLevel = level
+	}
+	return &TPLadder{client: client, opts: opts, clock: common.ClockOrSystem(opts.Clock), rungs: rungs}
+}
+
+// Rungs returns a copy of the state of every rung.
+func (l *TPLadder) Rungs() []TPRung {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]TPRung(nil), l.rungs...)
+}
+
+// Closed reports whether the position closed and the ladder was cancelled.
+func (l *TPLadder) Closed() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.closed
+}
+
+// Sync brings the ladder in line with the current position size. It refreshes the fills
+// of the resting orders, then cancels and re-places every rung whose remaining size no
+// longer matches its share. A size of zero cancels the ladder, see Cancel.
+func (l *TPLadder) Sync(ctx context.Context, size float64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.opts.SizeStep <= 0 {
+		return fmt.Errorf("tp ladder: SizeStep is required")
+	}
+	if l.opts.HoldSide != trading.HoldSideLong && l.opts.HoldSide != trading.HoldSideShort {
+		return fmt.Errorf("tp ladder: invalid hold side %q", l.opts.HoldSide)
+	}
+	if size <= 0 {
+		return l.cancelLocked(ctx)
+	}
+	l.closed = false
+
+	for i := range l.rungs {
+		if err := l.refresh(ctx, i); err != nil {
+			return err
+		}
+	}
+	targets := l.targets(size)
+	var errs []error
+	for i := range l.rungs {
+		r := &l.rungs[i]
+		if r.OrderID != "" && sameSize(r.Remaining(), targets[i], l.opts.SizeStep) {
+			continue
+		}
+		if r.OrderID != "" {
+			if err := l.cancel(ctx, i); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+		if targets[i] >= l.opts.MinSize {
+			if err := l.place(ctx, i, targets[i]); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Cancel cancels every resting order, keeping what they filled, and marks the ladder
+// closed.
+func (l *TPLadder) Cancel(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.cancelLocked(ctx)
+}
+
+// Run syncs the ladder with every event of its position until the position closes, ctx
+// is cancelled or events is closed. Errors are passed to onError when it is non-nil.
+func (l *TPLadder) Run(ctx context.Context, events <-chan tracker.PositionEvent, onError func(error)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if e.Position.Symbol != l.opts.Symbol || e.Position.HoldSide != string(l.opts.HoldSide) {
+				continue
+			}
+			size := e.Position.Size
+			if e.Type == tracker.PositionClosed {
+				size = 0
+			}
+			if err := l.Sync(ctx, size); err != nil && onError != nil && ctx.Err() == nil {
+				onError(err)
+			}
+			if size <= 0 && l.Closed() {
+				return
+			}
+		}
+	}
+}
+
+// targets returns the size each rung should still rest with: its share of the position
+// including what the ladder already closed, less what the rung already filled. The
+// total never exceeds size.
+func (l *TPLadder) targets(size float64) []float64 {
+	total, fractions := size, 0.0
+	for _, r := range l.rungs {
+		total += r.Closed + r.Filled
+		fractions += r.Fraction
+	}
+	targets := make([]float64, len(l.rungs))
+	left := size
+	for i, r := range l.rungs {
+		t := l.round(math.Min(r.Fraction*total-r.Closed-r.Filled, left))
+		if i == len(l.rungs)-1 && fractions >= 1-1e-9 {
+			t = l.round(left)
+		}
+		targets[i] = math.Max(0, t)
+		left -= targets[i]
+	}
+	return targets
+}
+
+// refresh reads the fills of the resting order of rung i and retires it when it no
+// longer rests.
+func (l *TPLadder) refresh(ctx context.Context, i int) error {
+	r := &l.rungs[i]
+	if r.OrderID == "" {
+		return nil
+	}
+	detail, err := trading.NewGetOrderDetailsService(l.client).
+		Symbol(l.opts.Symbol).
+		ProductType(trading.ProductType(l.opts.ProductType)).
+		OrderId(r.OrderID).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("tp ladder: order %s: %w", r.OrderID, err)
+	}
+	r.Filled, _ = strconv.ParseFloat(detail.BaseVolume, 64)
+	if detail.State.IsTerminal() {
+		l.retire(i)
+	}
+	return nil
+}
+
+// cancel cancels the resting order of rung i and retires it with its final fills.
+func (l *TPLadder) cancel(ctx context.Context, i int) error {
+	r := &l.rungs[i]
+	_, err := trading.NewCancelOrderService(l.client).
+		Symbol(l.opts.Symbol).
+		ProductType(trading.ProductType(l.opts.ProductType)).
+		MarginCoin(l.opts.MarginCoin).
+		OrderId(r.OrderID).
+		Do(ctx)
+	if err != nil {
+		// The order may have filled in the meantime
+		if rerr := l.refresh(ctx, i); rerr == nil && r.OrderID == "" {
+			return nil
+		}
+		return fmt.Errorf("tp ladder: cancel %s: %w", r.OrderID, err)
+	}
+	if err := l.refresh(ctx, i); err != nil {
+		return err
+	}
+	l.retire(i)
+	return nil
+}
+
+func (l *TPLadder) retire(i int) {
+	r := &l.rungs[i]
+	r.Closed += r.Filled
+	r.OrderID, r.ClientOid, r.Size, r.Filled = "", "", 0, 0
+}
+
+func (l *TPLadder) place(ctx context.Context, i int, size float64) error {
+	r := &l.rungs[i]
+	clientOid := fmt.Sprintf("tp%dr%d", l.clock.Now().UnixMilli(), i+1)
+	order := trading.NewCreateOrderService(l.client).
+		Symbol(l.opts.Symbol).
+		ProductType(trading.ProductType(l.opts.ProductType)).
+		MarginCoin(l.opts.MarginCoin).
+		MarginMode(l.opts.MarginMode).
+		OrderType(trading.OrderTypeLimit).
+		Size(common.FormatToStep(size, l.opts.SizeStep)).
+		Price(l.formatPrice(r.Price)).
+		ClientOrderId(clientOid).
+		Close(l.opts.HoldSide, l.opts.Hedge)
+	info, err := order.Do(ctx)
+	if err != nil {
+		return fmt.Errorf("tp ladder: place rung %d at %s: %w", i+1, common.FormatFloat(r.Price), err)
+	}
+	r.OrderID, r.ClientOid, r.Size, r.Filled = info.OrderId, clientOid, size, 0
+	return nil
+}
+
+func (l *TPLadder) cancelLocked(ctx context.Context) error {
+	var errs []error
+	for i := range l.rungs {
+		if l.rungs[i].OrderID == "" {
+			continue
+		}
+		if err := l.cancel(ctx, i); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	l.closed = true
+	return nil
+}
+
+func (l *TPLadder) round(size float64) float64 {
+	return common.RoundDownToStep(size, l.opts.SizeStep)
+}
+
+func (l *TPLadder) formatPrice(price float64) string {
+	if l.opts.PriceTick > 0 {
+		return common.FormatToStep(price, l.opts.PriceTick)
+	}
+	return common.FormatFloat(price)
+}
+
+// sameSize reports whether a and b are equal up to half a size step.
+func sameSize(a, b, step float64) bool {
+	return math.Abs(a-b) < step/2
+}
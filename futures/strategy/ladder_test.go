@@ -0,0 +1,214 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type ladderOrder struct {
+	req    map[string]string
+	filled float64
+	state  trading.OrderStatus
+}
+
+// ladderClient rests limit orders until fill or cancel marks them.
+type ladderClient struct {
+	mu     sync.Mutex
+	orders map[string]*ladderOrder
+	seq    int
+}
+
+func (c *ladderClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ok := func(data string) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+		return &futures.ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil
+	}
+	var req map[string]string
+	_ = json.Unmarshal(body, &req)
+	switch endpoint {
+	case trading.EndpointPlaceOrder:
+		c.seq++
+		id := strconv.Itoa(c.seq)
+		if c.orders == nil {
+			c.orders = map[string]*ladderOrder{}
+		}
+		c.orders[id] = &ladderOrder{req: req, state: trading.OrderStatusLive}
+		return ok(`{"orderId":"` + id + `"}`)
+	case trading.EndpointOrderDetails:
+		o := c.orders[query.Get("orderId")]
+		return ok(fmt.Sprintf(`{"orderId":%q,"baseVolume":"%g","state":%q}`, query.Get("orderId"), o.filled, o.state))
+	case trading.EndpointCancelOrder:
+		o := c.orders[req["orderId"]]
+		if o.state.IsTerminal() {
+			return nil, nil, &types.APIError{Code: 40768, Message: "order does not exist"}
+		}
+		o.state = trading.OrderStatusCanceled
+		return ok(`{"orderId":"` + req["orderId"] + `"}`)
+	}
+	return nil, nil, &types.APIError{Code: 40000, Message: "unexpected endpoint " + endpoint}
+}
+
+// fill fills size of order id.
+func (c *ladderClient) fill(id string, size float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	o := c.orders[id]
+	o.filled += size
+	o.state = trading.OrderStatusPartiallyFilled
+	if s, _ := strconv.ParseFloat(o.req["size"], 64); o.filled >= s-1e-9 {
+		o.state = trading.OrderStatusFilled
+	}
+}
+
+func (c *ladderClient) live() map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := map[string]string{}
+	for _, o := range c.orders {
+		if o.state.IsLive() {
+			out[o.req["price"]] = o.req["size"]
+		}
+	}
+	return out
+}
+
+func ladderOptions() TPLadderOptions {
+	return TPLadderOptions{
+		Symbol:      "BTCUSDT",
+		ProductType: futures.ProductTypeUSDTFutures,
+		MarginCoin:  "USDT",
+		MarginMode:  trading.MarginModeCrossed,
+		HoldSide:    trading.HoldSideLong,
+		Levels:      []TPLevel{{Price: 61000, Fraction: 0.5}, {Price: 62000, Fraction: 0.3}, {Price: 63000, Fraction: 0.2}},
+		SizeStep:    0.001,
+	}
+}
+
+func TestTPLadder_PlacesReduceOnlyRungs(t *testing.T) {
+	c := &ladderClient{}
+	l := NewTPLadder(c, ladderOptions())
+	require.NoError(t, l.Sync(context.Background(), 1))
+
+	assert.Equal(t, map[string]string{"61000": "0.500", "62000": "0.300", "63000": "0.200"}, c.live())
+	for _, o := range c.orders {
+		assert.Equal(t, "sell", o.req["side"])
+		assert.Equal(t, "YES", o.req["reduceOnly"])
+		assert.Equal(t, "limit", o.req["orderType"])
+	}
+
+	// Nothing changed: no order is replaced
+	require.NoError(t, l.Sync(context.Background(), 1))
+	assert.Len(t, c.orders, 3)
+}
+
+func TestTPLadder_PartialFillKeepsOtherRungs(t *testing.T) {
+	c := &ladderClient{}
+	l := NewTPLadder(c, ladderOptions())
+	ctx := context.Background()
+	require.NoError(t, l.Sync(ctx, 1))
+
+	c.fill(l.Rungs()[0].OrderID, 0.2)
+	require.NoError(t, l.Sync(ctx, 0.8))
+	assert.Len(t, c.orders, 3, "a partially filled rung keeps resting")
+	rungs := l.Rungs()
+	assert.InDelta(t, 0.2, rungs[0].Filled, 1e-9)
+	assert.InDelta(t, 0.3, rungs[0].Remaining(), 1e-9)
+
+	c.fill(rungs[0].OrderID, 0.3)
+	require.NoError(t, l.Sync(ctx, 0.5))
+	assert.Equal(t, map[string]string{"62000": "0.300", "63000": "0.200"}, c.live())
+	assert.InDelta(t, 0.5, l.Rungs()[0].Closed, 1e-9)
+}
+
+func TestTPLadder_ResizesWithPosition(t *testing.T) {
+	c := &ladderClient{}
+	l := NewTPLadder(c, ladderOptions())
+	ctx := context.Background()
+	require.NoError(t, l.Sync(ctx, 1))
+	c.fill(l.Rungs()[0].OrderID, 0.5)
+	require.NoError(t, l.Sync(ctx, 0.5))
+
+	// Added 1 to the position: rungs grow to their share of 2, less what already closed
+	require.NoError(t, l.Sync(ctx, 1.5))
+	assert.Equal(t, map[string]string{"61000": "0.500", "62000": "0.600", "63000": "0.400"}, c.live())
+
+	// Reduced by hand to 0.5: what rests never exceeds the position
+	require.NoError(t, l.Sync(ctx, 0.5))
+	live := c.live()
+	var total float64
+	for _, size := range live {
+		s, _ := strconv.ParseFloat(size, 64)
+		total += s
+	}
+	assert.LessOrEqual(t, total, 0.5+1e-9)
+}
+
+func TestTPLadder_HedgeShort(t *testing.T) {
+	c := &ladderClient{}
+	opts := ladderOptions()
+	opts.HoldSide = trading.HoldSideShort
+	opts.Hedge = true
+	opts.Levels = []TPLevel{{Price: 59000.5, Fraction: 1}}
+	opts.PriceTick = 0.1
+	require.NoError(t, NewTPLadder(c, opts).Sync(context.Background(), 0.0105))
+
+	require.Len(t, c.orders, 1)
+	o := c.orders["1"].req
+	assert.Equal(t, "sell", o["side"], "hedge close orders carry the position side")
+	assert.Equal(t, "close", o["tradeSide"])
+	assert.Equal(t, "59000.5", o["price"])
+	assert.Equal(t, "0.010", o["size"])
+}
+
+func TestTPLadder_RunCancelsOnClose(t *testing.T) {
+	c := &ladderClient{}
+	l := NewTPLadder(c, ladderOptions())
+	events := make(chan tracker.PositionEvent, 4)
+	done := make(chan struct{})
+	go func() {
+		l.Run(context.Background(), events, func(err error) { t.Error(err) })
+		close(done)
+	}()
+
+	pos := tracker.Position{Symbol: "BTCUSDT", HoldSide: "long", Size: 1}
+	events <- tracker.PositionEvent{Type: tracker.PositionOpened, Position: pos}
+	events <- tracker.PositionEvent{Type: tracker.PositionOpened, Position: tracker.Position{Symbol: "ETHUSDT", HoldSide: "long", Size: 1}}
+	require.Eventually(t, func() bool { return len(c.live()) == 3 }, time.Second, time.Millisecond)
+
+	c.fill(l.Rungs()[0].OrderID, 0.5)
+	pos.Size = 0.5
+	events <- tracker.PositionEvent{Type: tracker.PositionReduced, Position: pos}
+	events <- tracker.PositionEvent{Type: tracker.PositionClosed, Position: pos}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after the position closed")
+	}
+	assert.Empty(t, c.live(), "remnants are cancelled")
+	assert.True(t, l.Closed())
+}
+
+func TestTPLadder_Validation(t *testing.T) {
+	opts := ladderOptions()
+	opts.SizeStep = 0
+	assert.EqualError(t, NewTPLadder(&ladderClient{}, opts).Sync(context.Background(), 1), "tp ladder: SizeStep is required")
+	opts = ladderOptions()
+	opts.HoldSide = ""
+	assert.EqualError(t, NewTPLadder(&ladderClient{}, opts).Sync(context.Background(), 1), `tp ladder: invalid hold side ""`)
+}
@@ -0,0 +1,259 @@
+// Package stress runs price-shock scenarios against the current futures portfolio.
+//
+// A Scenario moves prices by fixed fractions per symbol, or propagates one driver shock
+// to every other symbol through a correlation matrix. Run revalues the positions at the
+// shocked prices, recomputes the maintenance margin from the contracts' position tiers
+// and reports the resulting equity, margin ratio and distance to liquidation:
+//
+//	portfolio, err := stress.Load(ctx, client, futures.ProductTypeUSDTFutures, "USDT")
+//	results := stress.Run(portfolio,
+//		stress.Scenario{Name: "BTC -20%", Driver: "BTCUSDT", DriverShock: -0.2, Correlations: matrix},
+//		stress.Scenario{Name: "alts -35%", Uniform: -0.35, Shocks: map[string]float64{"BTCUSDT": -0.15}},
+//	)
+//	for _, r := range results {
+//		fmt.Printf("%s equity=%.2f margin ratio=%.1f%% liquidated=%v\n", r.Scenario, r.Equity, r.MarginRatio*100, r.Liquidated)
+//	}
+//
+// The margin ratio follows Bitget's cross margin risk rate: maintenance margin divided by
+// equity, with liquidation at 1. Isolated positions are judged against their own
+// liquidation price only: they are left out of the cross maintenance margin and the
+// Liquidated verdict, and a loss on one is capped at the margin it holds.
+package stress
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/account"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/futures/position"
+)
+
+// Portfolio is the state a scenario is applied to.
+type Portfolio struct {
+	// Equity is the account equity in the margin coin.
+	Equity    float64
+	Positions []*position.Position
+	// Tiers are the position tiers by symbol. Positions without tiers use the maintenance
+	// margin rate reported with the position.
+	Tiers map[string]market.PositionTiers
+}
+
+// Correlations is a correlation matrix keyed by symbol. Only one of the two entries of a
+// pair needs to be set.
+type Correlations map[string]map[string]float64
+
+// Get returns the correlation of a and b. A symbol is fully correlated with itself.
+func (c Correlations) Get(a, b string) (float64, bool) {
+	if a == b {
+		return 1, true
+	}
+	if v, ok := c[a][b]; ok {
+		return v, true
+	}
+	v, ok := c[b][a]
+	return v, ok
+}
+
+// Scenario describes a price shock. For each symbol the first rule that applies wins:
+// an entry in Shocks, the correlated driver shock, then Uniform.
+type Scenario struct {
+	Name string
+
+	// Shocks are price changes per symbol as fractions, e.g. -0.2 for a 20% drop.
+	Shocks map[string]float64
+
+	// DriverShock moves Driver and every symbol correlated with it by the correlation
+	// times DriverShock.
+	Driver       string
+	DriverShock  float64
+	Correlations Correlations
+
+	// Uniform is the shock of the remaining symbols.
+	Uniform float64
+}
+
+// Shock returns the price change of symbol as a fraction.
+func (s Scenario) Shock(symbol string) float64 {
+	if v, ok := s.Shocks[symbol]; ok {
+		return v
+	}
+	if s.Driver != "" {
+		if corr, ok := s.Correlations.Get(s.Driver, symbol); ok {
+			return corr * s.DriverShock
+		}
+	}
+	return s.Uniform
+}
+
+// PositionResult is one position after a shock.
+type PositionResult struct {
+	Symbol     string
+	HoldSide   string
+	MarginMode string
+	Size       float64
+
+	MarkPrice    float64
+	Shock        float64
+	ShockedPrice float64
+	// PnL is the change of the unrealized PnL caused by the shock.
+	PnL               float64
+	Value             float64 // Position value at the shocked price
+	MaintenanceMargin float64
+
+	// LiquidationPrice is the price reported by the exchange. For crossed positions it
+	// assumes the other positions do not move.
+	LiquidationPrice float64
+	// LiquidationDistance is the fraction the shocked price can still move against the
+	// position before reaching LiquidationPrice; negative once it has been crossed and
+	// zero when no liquidation price is known.
+	LiquidationDistance float64
+	Liquidated          bool
+}
+
+// Result is the portfolio after one scenario.
+type Result struct {
+	Scenario string
+
+	Equity float64 // Account equity after the shock
+	// PnL is the change of equity. Isolated losses are capped at the position margin.
+	PnL float64
+	// MaintenanceMargin is the maintenance margin of the crossed positions.
+	MaintenanceMargin float64
+	// MarginRatio is MaintenanceMargin divided by the cross margin equity, which excludes
+	// the margin and PnL of isolated positions; the account is liquidated at 1.
+	MarginRatio float64
+	// Liquidated is set when the margin ratio reached 1 or a crossed position crossed its
+	// liquidation price.
+	Liquidated bool
+	// IsolatedLiquidated is set when an isolated position crossed its liquidation price.
+	// It costs that position's margin but does not liquidate the cross account.
+	IsolatedLiquidated bool
+
+	Positions []PositionResult
+}
+
+// Run applies each scenario to the portfolio.
+func Run(p Portfolio, scenarios ...Scenario) []Result {
+	results := make([]Result, 0, len(scenarios))
+	for _, s := range scenarios {
+		results = append(results, apply(p, s))
+	}
+	return results
+}
+
+func apply(p Portfolio, s Scenario) Result {
+	r := Result{Scenario: s.Name}
+	crossEquity := p.Equity
+	for _, pos := range p.Positions {
+		if pos == nil || pos.Total <= 0 || pos.MarkPrice <= 0 {
+			continue
+		}
+		pr := PositionResult{
+			Symbol:           pos.Symbol,
+			HoldSide:         string(pos.HoldSide),
+			MarginMode:       pos.MarginMode,
+			Size:             pos.Total,
+			MarkPrice:        pos.MarkPrice,
+			Shock:            s.Shock(pos.Symbol),
+			LiquidationPrice: pos.LiquidationPrice,
+		}
+		pr.ShockedPrice = pos.MarkPrice * (1 + pr.Shock)
+		direction := 1.0
+		if pos.HoldSide == futures.HoldSideShort {
+			direction = -1
+		}
+		pr.PnL = direction * (pr.ShockedPrice - pos.MarkPrice) * pos.Total
+		pr.Value = pr.ShockedPrice * pos.Total
+		if tiers := p.Tiers[pos.Symbol]; len(tiers) > 0 {
+			pr.MaintenanceMargin = tiers.MaintenanceMargin(pr.Value)
+		} else {
+			pr.MaintenanceMargin = pr.Value * pos.KeepMarginRate
+		}
+		if pos.LiquidationPrice > 0 && pr.ShockedPrice > 0 {
+			// Positive while the liquidation price is still on the adverse side
+			pr.LiquidationDistance = direction * (pr.ShockedPrice - pos.LiquidationPrice) / pr.ShockedPrice
+			pr.Liquidated = pr.LiquidationDistance <= 0
+		}
+
+		r.Positions = append(r.Positions, pr)
+		if strings.EqualFold(pos.MarginMode, string(futures.MarginModeIsolated)) {
+			// The position only risks its own margin, which is not part of the cross equity
+			pnl := pr.PnL
+			if held := pos.MarginSize + pos.UnrealizedPL; pos.MarginSize > 0 && pnl < -held {
+				pnl = -held
+			}
+			r.PnL += pnl
+			crossEquity -= pos.MarginSize + pos.UnrealizedPL
+			r.IsolatedLiquidated = r.IsolatedLiquidated || pr.Liquidated
+			continue
+		}
+		r.PnL += pr.PnL
+		crossEquity += pr.PnL
+		r.MaintenanceMargin += pr.MaintenanceMargin
+		r.Liquidated = r.Liquidated || pr.Liquidated
+	}
+
+	r.Equity = p.Equity + r.PnL
+	if crossEquity > 0 {
+		r.MarginRatio = r.MaintenanceMargin / crossEquity
+	} else {
+		r.MarginRatio = math.Inf(1)
+	}
+	r.Liquidated = r.Liquidated || r.MarginRatio >= 1
+	return r
+}
+
+// Load fetches the positions, the equity of the marginCoin account and the position
+// tiers of every held symbol.
+func Load(ctx context.Context, client futures.ClientInterface, productType futures.ProductType, marginCoin string) (Portfolio, error) {
+	var p Portfolio
+	positions, err := position.NewAllPositionsService(client).
+		ProductType(productType).
+		MarginCoin(marginCoin).
+		Do(ctx)
+	if err != nil {
+		return p, fmt.Errorf("stress: positions: %w", err)
+	}
+	p.Positions = positions
+
+	accounts, err := account.NewAccountListService(client).ProductType(productType).Do(ctx)
+	if err != nil {
+		return p, fmt.Errorf("stress: accounts: %w", err)
+	}
+	found := false
+	for _, a := range accounts.Accounts {
+		if a.MarginCoin == marginCoin {
+			if p.Equity, err = strconv.ParseFloat(a.AccountEquity, 64); err != nil {
+				return p, fmt.Errorf("stress: equity %q: %w", a.AccountEquity, err)
+			}
+			found = true
+		}
+	}
+	if !found {
+		return p, fmt.Errorf("stress: no %s account", marginCoin)
+	}
+
+	p.Tiers = make(map[string]market.PositionTiers)
+	for _, pos := range positions {
+		if pos == nil {
+			continue
+		}
+		if _, ok := p.Tiers[pos.Symbol]; ok {
+			continue
+		}
+		tiers, err := market.NewPositionTierService(client).
+			Symbol(pos.Symbol).
+			ProductType(market.ProductType(productType)).
+			Do(ctx)
+		if err != nil {
+			return p, fmt.Errorf("stress: position tiers %s: %w", pos.Symbol, err)
+		}
+		p.Tiers[pos.Symbol] = tiers
+	}
+	return p, nil
+}
@@ -0,0 +1,157 @@
+package stress
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/url"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/futures/position"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func portfolio() Portfolio {
+	return Portfolio{
+		Equity: 10000,
+		Positions: []*position.Position{
+			{Symbol: "BTCUSDT", HoldSide: futures.HoldSideLong, MarginMode: "crossed", Total: 1, MarkPrice: 50000, LiquidationPrice: 42000},
+			{Symbol: "ETHUSDT", HoldSide: futures.HoldSideShort, MarginMode: "isolated", Total: 10, MarkPrice: 3000, LiquidationPrice: 3300, KeepMarginRate: 0.01},
+		},
+		Tiers: map[string]market.PositionTiers{
+			"BTCUSDT": {
+				{Level: 1, StartUnit: 0, EndUnit: 45000, KeepMarginRate: 0.004},
+				{Level: 2, StartUnit: 45000, EndUnit: 1e6, KeepMarginRate: 0.01},
+			},
+		},
+	}
+}
+
+func TestScenario_Shock(t *testing.T) {
+	corr := Correlations{"BTCUSDT": {"ETHUSDT": 0.8}}
+	s := Scenario{
+		Shocks:       map[string]float64{"SOLUSDT": -0.5},
+		Driver:       "BTCUSDT",
+		DriverShock:  -0.1,
+		Correlations: corr,
+		Uniform:      -0.02,
+	}
+	assert.InDelta(t, -0.5, s.Shock("SOLUSDT"), 1e-12, "explicit shock wins")
+	assert.InDelta(t, -0.1, s.Shock("BTCUSDT"), 1e-12)
+	assert.InDelta(t, -0.08, s.Shock("ETHUSDT"), 1e-12)
+	assert.InDelta(t, -0.02, s.Shock("XRPUSDT"), 1e-12, "uncorrelated symbols fall back to uniform")
+
+	v, ok := Correlations{"ETHUSDT": {"BTCUSDT": 0.8}}.Get("BTCUSDT", "ETHUSDT")
+	assert.True(t, ok)
+	assert.Equal(t, 0.8, v, "matrix is symmetric")
+}
+
+func TestRun(t *testing.T) {
+	results := Run(portfolio(),
+		Scenario{Name: "flat"},
+		Scenario{Name: "BTC -10%", Driver: "BTCUSDT", DriverShock: -0.1, Correlations: Correlations{"BTCUSDT": {"ETHUSDT": 1}}},
+		Scenario{Name: "ETH squeeze", Shocks: map[string]float64{"ETHUSDT": 0.15}},
+	)
+	require.Len(t, results, 3)
+
+	flat := results[0]
+	assert.Zero(t, flat.PnL)
+	assert.InDelta(t, 10000, flat.Equity, 1e-9)
+	// BTC 50000 in tier 2 at 1%; the isolated ETH position is not part of the cross margin
+	assert.InDelta(t, 500, flat.MaintenanceMargin, 1e-9)
+	assert.InDelta(t, 0.05, flat.MarginRatio, 1e-12)
+	assert.InDelta(t, 300, flat.Positions[1].MaintenanceMargin, 1e-9, "ETH 30000 at the position's own 1%")
+	assert.InDelta(t, 0.16, flat.Positions[0].LiquidationDistance, 1e-12)
+	assert.InDelta(t, 0.1, flat.Positions[1].LiquidationDistance, 1e-12)
+	assert.False(t, flat.Liquidated)
+
+	down := results[1]
+	btc, eth := down.Positions[0], down.Positions[1]
+	assert.InDelta(t, 45000, btc.ShockedPrice, 1e-9)
+	assert.InDelta(t, -5000, btc.PnL, 1e-9)
+	assert.InDelta(t, 3000, eth.PnL, 1e-9, "the short gains")
+	assert.InDelta(t, 8000, down.Equity, 1e-9)
+	assert.InDelta(t, 450, down.MaintenanceMargin, 1e-9)
+	assert.InDelta(t, 270, eth.MaintenanceMargin, 1e-9)
+	assert.InDelta(t, 3000.0/45000, btc.LiquidationDistance, 1e-12)
+	assert.False(t, down.Liquidated)
+
+	squeeze := results[2]
+	assert.True(t, squeeze.Positions[1].Liquidated)
+	assert.Negative(t, squeeze.Positions[1].LiquidationDistance)
+	assert.True(t, squeeze.IsolatedLiquidated)
+	assert.False(t, squeeze.Liquidated, "an isolated position does not liquidate the cross account")
+}
+
+func TestRun_MixedCrossAndIsolated(t *testing.T) {
+	p := Portfolio{
+		Equity: 3000,
+		Positions: []*position.Position{
+			{Symbol: "BTCUSDT", HoldSide: futures.HoldSideLong, MarginMode: "crossed", Total: 0.1, MarkPrice: 50000, KeepMarginRate: 0.004},
+			{Symbol: "ETHUSDT", HoldSide: futures.HoldSideShort, MarginMode: "isolated", Total: 10, MarkPrice: 3000, LiquidationPrice: 3300, KeepMarginRate: 0.01, MarginSize: 1000},
+		},
+	}
+	r := Run(p, Scenario{Name: "ETH +50%", Shocks: map[string]float64{"ETHUSDT": 0.5}})[0]
+
+	eth := r.Positions[1]
+	assert.InDelta(t, -15000, eth.PnL, 1e-9, "the position result shows the full move")
+	assert.True(t, eth.Liquidated)
+	assert.True(t, r.IsolatedLiquidated)
+
+	assert.InDelta(t, -1000, r.PnL, 1e-9, "the isolated loss is capped at its margin")
+	assert.InDelta(t, 2000, r.Equity, 1e-9)
+	assert.InDelta(t, 20, r.MaintenanceMargin, 1e-9, "only the crossed BTC position")
+	assert.InDelta(t, 0.01, r.MarginRatio, 1e-12)
+	assert.False(t, r.Liquidated)
+
+	r = Run(p, Scenario{Name: "BTC -50%", Shocks: map[string]float64{"BTCUSDT": -0.5}})[0]
+	assert.InDelta(t, -2500, r.PnL, 1e-9)
+	assert.True(t, math.IsInf(r.MarginRatio, 1), "the cross equity excludes the isolated margin")
+	assert.True(t, r.Liquidated)
+	assert.False(t, r.IsolatedLiquidated)
+}
+
+func TestRun_MarginRatioLiquidation(t *testing.T) {
+	p := portfolio()
+	p.Equity = 1000
+	r := Run(p, Scenario{Name: "crash", Uniform: -0.1})[0]
+	assert.True(t, math.IsInf(r.MarginRatio, 1), "equity wiped out")
+	assert.True(t, r.Liquidated)
+}
+
+type fakeClient struct{}
+
+func (fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	ok := func(data string) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+		return &futures.ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil
+	}
+	switch endpoint {
+	case futures.EndpointAllPositions:
+		return ok(`[{"symbol":"BTCUSDT","marginCoin":"USDT","holdSide":"long","total":"1","markPrice":"50000","liquidationPrice":"42000"},
+			{"symbol":"BTCUSDT","marginCoin":"USDT","holdSide":"short","total":"0.5","markPrice":"50000","liquidationPrice":"70000"}]`)
+	case futures.EndpointAccountList:
+		return ok(`[{"marginCoin":"USDC","accountEquity":"5"},{"marginCoin":"USDT","accountEquity":"12000.5"}]`)
+	case market.EndpointPositionTier:
+		if query.Get("symbol") != "BTCUSDT" {
+			return nil, nil, errors.New("unexpected symbol")
+		}
+		return ok(`[{"symbol":"BTCUSDT","level":"1","startUnit":"0","endUnit":"150000","leverage":"125","keepMarginRate":"0.004"}]`)
+	}
+	return nil, nil, errors.New("unexpected endpoint " + endpoint)
+}
+
+func TestLoad(t *testing.T) {
+	p, err := Load(context.Background(), fakeClient{}, futures.ProductTypeUSDTFutures, "USDT")
+	require.NoError(t, err)
+	assert.Equal(t, 12000.5, p.Equity)
+	assert.Len(t, p.Positions, 2)
+	require.Len(t, p.Tiers["BTCUSDT"], 1)
+	assert.Equal(t, 0.004, p.Tiers["BTCUSDT"][0].KeepMarginRate)
+
+	_, err = Load(context.Background(), fakeClient{}, futures.ProductTypeUSDTFutures, "BTC")
+	assert.ErrorContains(t, err, "no BTC account")
+}
@@ -0,0 +1,106 @@
+package timeseries
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// InfluxOptions configures an InfluxWriter. Set Org and Bucket for the InfluxDB 2 write
+// API, or Database for the 1.x API.
+type InfluxOptions struct {
+	URL string // Server address, defaults to "http://127.0.0.1:8086"
+
+	// InfluxDB 2.x
+	Org    string
+	Bucket string
+	Token  string
+
+	// InfluxDB 1.x
+	Database        string
+	RetentionPolicy string
+	Username        string
+	Password        string
+
+	// Timeout bounds each write. Defaults to 10 seconds.
+	Timeout time.Duration
+	// HTTPClient overrides the default client. Optional.
+	HTTPClient *http.Client
+}
+
+// InfluxWriter writes points to InfluxDB in line protocol.
+type InfluxWriter struct {
+	opts     InfluxOptions
+	client   *http.Client
+	endpoint string
+}
+
+// NewInfluxWriter creates a writer.
+func NewInfluxWriter(opts InfluxOptions) *InfluxWriter {
+	if opts.URL == "" {
+		opts.URL = "http://127.0.0.1:8086"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: opts.Timeout}
+	}
+
+	q := url.Values{}
+	q.Set("precision", "ms")
+	path := "/api/v2/write"
+	if opts.Database != "" {
+		path = "/write"
+		q.Set("db", opts.Database)
+		if opts.RetentionPolicy != "" {
+			q.Set("rp", opts.RetentionPolicy)
+		}
+	} else {
+		q.Set("org", opts.Org)
+		q.Set("bucket", opts.Bucket)
+	}
+	return &InfluxWriter{
+		opts:     opts,
+		client:   client,
+		endpoint: strings.TrimRight(opts.URL, "/") + path + "?" + q.Encode(),
+	}
+}
+
+// Write implements Writer.
+func (w *InfluxWriter) Write(ctx context.Context, points []Point) error {
+	if len(points) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, w.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.endpoint, bytes.NewReader(LineProtocol(points)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	switch {
+	case w.opts.Token != "":
+		req.Header.Set("Authorization", "Token "+w.opts.Token)
+	case w.opts.Username != "":
+		req.SetBasicAuth(w.opts.Username, w.opts.Password)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("influx: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("influx: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
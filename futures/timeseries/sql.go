@@ -0,0 +1,97 @@
+package timeseries
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SQLOptions configures an SQLWriter.
+type SQLOptions struct {
+	// Table is the target table. Defaults to "bitget_metrics".
+	Table string
+}
+
+// SQLWriter inserts points into a narrow PostgreSQL table with one row per field:
+//
+//	time timestamptz, measurement text, tags jsonb, field text, value double precision
+//
+// CreateTable creates it and, on TimescaleDB, turns it into a hypertable. The caller
+// opens db with the driver of their choice (pgx, lib/pq), so the SDK does not depend on
+// one.
+type SQLWriter struct {
+	db    *sql.DB
+	table string
+}
+
+var tableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// NewSQLWriter creates a writer.
+func NewSQLWriter(db *sql.DB, opts SQLOptions) (*SQLWriter, error) {
+	if opts.Table == "" {
+		opts.Table = "bitget_metrics"
+	}
+	if !tableName.MatchString(opts.Table) {
+		return nil, fmt.Errorf("timeseries: invalid table name %q", opts.Table)
+	}
+	return &SQLWriter{db: db, table: opts.Table}, nil
+}
+
+// CreateTable creates the table and its index if missing. With hypertable set it also
+// calls TimescaleDB's create_hypertable, which fails on plain PostgreSQL.
+func (w *SQLWriter) CreateTable(ctx context.Context, hypertable bool) error {
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS ` + w.table + ` (
+			time timestamptz NOT NULL,
+			measurement text NOT NULL,
+			tags jsonb NOT NULL,
+			field text NOT NULL,
+			value double precision NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS ` + strings.ReplaceAll(w.table, ".", "_") + `_measurement_time_idx ON ` +
+			w.table + ` (measurement, time DESC)`,
+	}
+	if hypertable {
+		stmts = append(stmts, `SELECT create_hypertable('`+w.table+`', 'time', if_not_exists => TRUE)`)
+	}
+	for _, stmt := range stmts {
+		if _, err := w.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("timeseries: create table: %w", err)
+		}
+	}
+	return nil
+}
+
+// Write implements Writer. Each call inserts its rows in one transaction.
+func (w *SQLWriter) Write(ctx context.Context, points []Point) error {
+	if len(points) == 0 {
+		return nil
+	}
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO `+w.table+` (time, measurement, tags, field, value) VALUES ($1, $2, $3, $4, $5)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, p := range points {
+		tags, err := json.Marshal(p.Tags)
+		if err != nil {
+			return err
+		}
+		for _, field := range sortedFields(p.Fields) {
+			if _, err := stmt.ExecContext(ctx, p.Time.UTC(), p.Measurement, string(tags), field, p.Fields[field]); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,370 @@
+// Package timeseries writes ticker snapshots, account equity and position metrics to a
+// time series database for Grafana dashboards.
+//
+// A Sink polls REST at configurable intervals and hands the resulting points to a
+// Writer. InfluxWriter posts InfluxDB line protocol over HTTP (v1 or v2 write API);
+// SQLWriter inserts into a TimescaleDB or plain PostgreSQL table through any
+// database/sql driver:
+//
+//	w := timeseries.NewInfluxWriter(timeseries.InfluxOptions{
+//		URL: "http://influx:8086", Org: "trading", Bucket: "bitget", Token: token,
+//	})
+//	sink := timeseries.NewSink(client, w, timeseries.Options{
+//		ProductType:     futures.ProductTypeUSDTFutures,
+//		MarginCoin:      "USDT",
+//		Symbols:         []string{"BTCUSDT", "ETHUSDT"},
+//		TickerInterval:  10 * time.Second,
+//		AccountInterval: time.Minute,
+//	})
+//	go sink.Run(ctx, onError)
+//
+// Points use three measurements: "ticker" (tags symbol, product_type), "equity" (tags
+// margin_coin, product_type) and "position" (tags symbol, hold_side, margin_mode,
+// product_type). Options.Prefix is prepended to each, and Options.Tenant adds a "tenant"
+// tag to every point.
+package timeseries
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/account"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/futures/position"
+)
+
+// Measurement names, before Options.Prefix.
+const (
+	MeasurementTicker   = "ticker"
+	MeasurementEquity   = "equity"
+	MeasurementPosition = "position"
+)
+
+// TagTenant is the tag set by Options.Tenant.
+const TagTenant = "tenant"
+
+// Point is one time series sample.
+type Point struct {
+	Measurement string
+	Tags        map[string]string
+	Fields      map[string]float64
+	Time        time.Time
+}
+
+// Writer stores points. InfluxWriter and SQLWriter implement it.
+type Writer interface {
+	Write(ctx context.Context, points []Point) error
+}
+
+// WriterFunc adapts a function to Writer.
+type WriterFunc func(ctx context.Context, points []Point) error
+
+// Write implements Writer.
+func (f WriterFunc) Write(ctx context.Context, points []Point) error {
+	return f(ctx, points)
+}
+
+// Options configures a Sink.
+type Options struct {
+	ProductType futures.ProductType
+	// MarginCoin restricts equity and positions to one margin coin. Optional.
+	MarginCoin string
+	// Symbols restricts ticker points to these symbols. Empty records every symbol.
+	Symbols []string
+
+	// TickerInterval is the ticker sampling interval. Defaults to 10 seconds; negative
+	// disables ticker points.
+	TickerInterval time.Duration
+	// AccountInterval is the equity and position sampling interval. Defaults to one
+	// minute; negative disables account points.
+	AccountInterval time.Duration
+
+	// Prefix is prepended to measurement names, e.g. "bitget_". Optional.
+	Prefix string
+	// Tags are added to every point, e.g. {"account": "main"}. Optional.
+	Tags map[string]string
+	// Tenant sets the "tenant" tag of every point, matching tracker.Options.Tenant, so the
+	// sinks of several accounts can write to one database. Optional.
+	Tenant string
+	// Clock stamps points. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// Sink samples tickers, equity and positions and writes them to a Writer.
+type Sink struct {
+	client futures.ClientInterface
+	w      Writer
+	opts   Options
+
+	symbols map[string]bool
+	clock   common.Clock
+
+	mu      sync.Mutex
+	written int64
+}
+
+// NewSink creates a sink writing to w.
+func NewSink(client futures.ClientInterface, w Writer, opts Options) *Sink {
+	if opts.TickerInterval == 0 {
+		opts.TickerInterval = 10 * time.Second
+	}
+	if opts.AccountInterval == 0 {
+		opts.AccountInterval = time.Minute
+	}
+	s := &Sink{client: client, w: w, opts: opts, clock: common.ClockOrSystem(opts.Clock)}
+	if len(opts.Symbols) > 0 {
+		s.symbols = make(map[string]bool, len(opts.Symbols))
+		for _, sym := range opts.Symbols {
+			s.symbols[sym] = true
+		}
+	}
+	return s
+}
+
+// TickerPoints fetches tickers and converts them to points.
+func (s *Sink) TickerPoints(ctx context.Context) ([]Point, error) {
+	tickers, err := market.NewAllTickersService(s.client).ProductType(s.opts.ProductType).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("timeseries: tickers: %w", err)
+	}
+	now := s.clock.Now()
+	points := make([]Point, 0, len(tickers))
+	for _, t := range tickers {
+		if t == nil || (s.symbols != nil && !s.symbols[t.Symbol]) {
+			continue
+		}
+		ts := now
+		if ms, err := strconv.ParseInt(t.Ts, 10, 64); err == nil && ms > 0 {
+			ts = time.UnixMilli(ms)
+		}
+		points = append(points, s.point(MeasurementTicker, map[string]string{"symbol": t.Symbol}, ts, map[string]float64{
+			"last":          parseFloat(t.LastPr),
+			"bid":           parseFloat(t.BidPr),
+			"ask":           parseFloat(t.AskPr),
+			"mark_price":    parseFloat(t.MarkPrice),
+			"index_price":   parseFloat(t.IndexPrice),
+			"funding_rate":  parseFloat(t.FundingRate),
+			"change_24h":    parseFloat(t.Change24h),
+			"quote_volume":  parseFloat(t.QuoteVolume),
+			"open_interest": parseFloat(t.OpenI),
+		}))
+	}
+	return points, nil
+}
+
+// AccountPoints fetches account equity and open positions and converts them to points.
+func (s *Sink) AccountPoints(ctx context.Context) ([]Point, error) {
+	accounts, err := account.NewAccountListService(s.client).ProductType(s.opts.ProductType).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("timeseries: accounts: %w", err)
+	}
+	svc := position.NewAllPositionsService(s.client).ProductType(s.opts.ProductType)
+	if s.opts.MarginCoin != "" {
+		svc.MarginCoin(s.opts.MarginCoin)
+	}
+	positions, err := svc.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("timeseries: positions: %w", err)
+	}
+
+	now := s.clock.Now()
+	var points []Point
+	for _, a := range accounts.Accounts {
+		if s.opts.MarginCoin != "" && a.MarginCoin != s.opts.MarginCoin {
+			continue
+		}
+		points = append(points, s.point(MeasurementEquity, map[string]string{"margin_coin": a.MarginCoin}, now, map[string]float64{
+			"equity":          parseFloat(a.AccountEquity),
+			"usdt_equity":     parseFloat(a.UsdtEquity),
+			"available":       parseFloat(a.Available),
+			"locked":          parseFloat(a.Locked),
+			"unrealized_pl":   parseFloat(a.UnrealizedPL),
+			"cross_risk_rate": parseFloat(a.CrossRiskRate),
+		}))
+	}
+	for _, p := range positions {
+		if p == nil || p.Total <= 0 {
+			continue
+		}
+		tags := map[string]string{"symbol": p.Symbol, "hold_side": string(p.HoldSide), "margin_mode": p.MarginMode}
+		points = append(points, s.point(MeasurementPosition, tags, now, map[string]float64{
+			"size":              p.Total,
+			"open_price":        p.AverageOpenPrice,
+			"mark_price":        p.MarkPrice,
+			"margin":            p.MarginSize,
+			"leverage":          p.Leverage,
+			"unrealized_pl":     p.UnrealizedPL,
+			"realized_pl":       p.AchievedProfits,
+			"liquidation_price": p.LiquidationPrice,
+			"margin_ratio":      p.MarginRatio,
+		}))
+	}
+	return points, nil
+}
+
+// Collect fetches every enabled kind of point and writes them in one batch.
+func (s *Sink) Collect(ctx context.Context) error {
+	var points []Point
+	if s.opts.TickerInterval > 0 {
+		p, err := s.TickerPoints(ctx)
+		if err != nil {
+			return err
+		}
+		points = append(points, p...)
+	}
+	if s.opts.AccountInterval > 0 {
+		p, err := s.AccountPoints(ctx)
+		if err != nil {
+			return err
+		}
+		points = append(points, p...)
+	}
+	return s.write(ctx, points)
+}
+
+// Run samples tickers every TickerInterval and the account every AccountInterval,
+// writing each sample as it is taken, until ctx is cancelled. Errors are reported to
+// onError and the loop continues.
+func (s *Sink) Run(ctx context.Context, onError func(error)) {
+	var wg sync.WaitGroup
+	loop := func(interval time.Duration, collect func(context.Context) ([]Point, error)) {
+		defer wg.Done()
+		ticker := s.clock.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			points, err := collect(ctx)
+			if err == nil {
+				err = s.write(ctx, points)
+			}
+			if err != nil && onError != nil && ctx.Err() == nil {
+				onError(err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C():
+			}
+		}
+	}
+	if s.opts.TickerInterval > 0 {
+		wg.Add(1)
+		go loop(s.opts.TickerInterval, s.TickerPoints)
+	}
+	if s.opts.AccountInterval > 0 {
+		wg.Add(1)
+		go loop(s.opts.AccountInterval, s.AccountPoints)
+	}
+	wg.Wait()
+}
+
+// Written returns the number of points written so far.
+func (s *Sink) Written() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.written
+}
+
+func (s *Sink) write(ctx context.Context, points []Point) error {
+	if len(points) == 0 {
+		return nil
+	}
+	if err := s.w.Write(ctx, points); err != nil {
+		return fmt.Errorf("timeseries: write: %w", err)
+	}
+	s.mu.Lock()
+	s.written += int64(len(points))
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Sink) point(measurement string, tags map[string]string, ts time.Time, fields map[string]float64) Point {
+	tags["product_type"] = string(s.opts.ProductType)
+	for k, v := range s.opts.Tags {
+		tags[k] = v
+	}
+	if s.opts.Tenant != "" {
+		tags[TagTenant] = s.opts.Tenant
+	}
+	return Point{Measurement: s.opts.Prefix + measurement, Tags: tags, Fields: fields, Time: ts}
+}
+
+// AppendLine appends p in InfluxDB line protocol with millisecond precision, without
+// the trailing newline. Tags and fields are sorted by key; empty tag values are omitted.
+func AppendLine(b []byte, p Point) []byte {
+	b = append(b, escape(p.Measurement, ", ")...)
+	for _, k := range sortedKeys(p.Tags) {
+		if p.Tags[k] == "" {
+			continue
+		}
+		b = append(b, ',')
+		b = append(b, escape(k, ",= ")...)
+		b = append(b, '=')
+		b = append(b, escape(p.Tags[k], ",= ")...)
+	}
+	for i, k := range sortedFields(p.Fields) {
+		if i == 0 {
+			b = append(b, ' ')
+		} else {
+			b = append(b, ',')
+		}
+		b = append(b, escape(k, ",= ")...)
+		b = append(b, '=')
+		b = strconv.AppendFloat(b, p.Fields[k], 'f', -1, 64)
+	}
+	b = append(b, ' ')
+	return strconv.AppendInt(b, p.Time.UnixMilli(), 10)
+}
+
+// LineProtocol encodes points in InfluxDB line protocol, one per line.
+func LineProtocol(points []Point) []byte {
+	var b []byte
+	for _, p := range points {
+		b = AppendLine(b, p)
+		b = append(b, '\n')
+	}
+	return b
+}
+
+func escape(s, chars string) string {
+	if !strings.ContainsAny(s, chars+`\`) {
+		return s
+	}
+	var sb strings.Builder
+	for _, r := range s {
+		if r == '\\' || strings.ContainsRune(chars, r) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedFields(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func parseFloat(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
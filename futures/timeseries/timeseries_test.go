@@ -0,0 +1,264 @@
+package timeseries
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeClient struct{}
+
+func (c *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	ok := func(data string) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+		return &futures.ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil
+	}
+	switch endpoint {
+	case futures.EndpointAllTickers:
+		return ok(`[{"symbol":"BTCUSDT","lastPr":"65000.5","bidPr":"65000","askPr":"65001","markPrice":"65000.2",
+			"indexPrice":"64999.8","fundingRate":"0.0001","openI":"1200","ts":"1700000000000"},
+			{"symbol":"ETHUSDT","lastPr":"3000","ts":"1700000000000"}]`)
+	case futures.EndpointAccountList:
+		return ok(`[{"marginCoin":"USDT","accountEquity":"10500.5","available":"8000","unrealizedPL":"500.5","crossRiskRate":"0.02"},
+			{"marginCoin":"USDC","accountEquity":"100"}]`)
+	case futures.EndpointAllPositions:
+		return ok(`[{"symbol":"BTCUSDT","holdSide":"long","marginMode":"crossed","total":"0.1","openPriceAvg":"60000",
+			"markPrice":"65000","unrealizedPL":"500","leverage":"10"},
+			{"symbol":"ETHUSDT","holdSide":"short","marginMode":"crossed","total":"0"}]`)
+	}
+	return nil, nil, errors.New("unexpected endpoint " + endpoint)
+}
+
+func newTestSink(w Writer, opts Options) *Sink {
+	opts.ProductType = futures.ProductTypeUSDTFutures
+	if opts.Clock == nil {
+		opts.Clock = common.NewFakeClock(time.UnixMilli(1700000060000))
+	}
+	return NewSink(&fakeClient{}, w, opts)
+}
+
+func TestSink_Points(t *testing.T) {
+	s := newTestSink(nil, Options{MarginCoin: "USDT", Symbols: []string{"BTCUSDT"}, Prefix: "bitget_", Tags: map[string]string{"account": "main"}})
+
+	tickers, err := s.TickerPoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, tickers, 1)
+	assert.Equal(t, "bitget_ticker", tickers[0].Measurement)
+	assert.Equal(t, map[string]string{"symbol": "BTCUSDT", "product_type": "USDT-FUTURES", "account": "main"}, tickers[0].Tags)
+	assert.Equal(t, 65000.5, tickers[0].Fields["last"])
+	assert.Equal(t, 1200.0, tickers[0].Fields["open_interest"])
+	assert.Equal(t, time.UnixMilli(1700000000000), tickers[0].Time)
+
+	account, err := s.AccountPoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, account, 2)
+	assert.Equal(t, "bitget_equity", account[0].Measurement)
+	assert.Equal(t, 10500.5, account[0].Fields["equity"])
+	assert.Equal(t, "bitget_position", account[1].Measurement)
+	assert.Equal(t, "long", account[1].Tags["hold_side"])
+	assert.Equal(t, 0.1, account[1].Fields["size"])
+	assert.Equal(t, 500.0, account[1].Fields["unrealized_pl"])
+	assert.Equal(t, time.UnixMilli(1700000060000), account[1].Time)
+
+	s = newTestSink(nil, Options{MarginCoin: "USDT", Tenant: "alice"})
+	account, err = s.AccountPoints(context.Background())
+	require.NoError(t, err)
+	for _, p := range account {
+		assert.Equal(t, "alice", p.Tags[TagTenant])
+	}
+}
+
+func TestSink_Collect(t *testing.T) {
+	var batches [][]Point
+	w := WriterFunc(func(ctx context.Context, points []Point) error {
+		batches = append(batches, points)
+		return nil
+	})
+	s := newTestSink(w, Options{AccountInterval: -1})
+	require.NoError(t, s.Collect(context.Background()))
+	require.Len(t, batches, 1)
+	assert.Len(t, batches[0], 2, "only tickers when account points are disabled")
+	assert.Equal(t, int64(2), s.Written())
+
+	failing := newTestSink(WriterFunc(func(context.Context, []Point) error { return errors.New("down") }), Options{})
+	assert.ErrorContains(t, failing.Collect(context.Background()), "timeseries: write: down")
+}
+
+func TestSink_Run(t *testing.T) {
+	var mu sync.Mutex
+	measurements := map[string]int{}
+	w := WriterFunc(func(ctx context.Context, points []Point) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, p := range points {
+			measurements[p.Measurement]++
+		}
+		return nil
+	})
+	clock := common.NewFakeClock(time.UnixMilli(1700000060000))
+	s := newTestSink(w, Options{TickerInterval: 5 * time.Millisecond, AccountInterval: time.Hour, Clock: clock})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx, nil)
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		clock.Advance(5 * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		return measurements[MeasurementTicker] >= 6
+	}, time.Second, time.Millisecond)
+	cancel()
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, measurements[MeasurementEquity], "one account sample of two margin coins")
+}
+
+func TestLineProtocol(t *testing.T) {
+	points := []Point{
+		{
+			Measurement: "ticker",
+			Tags:        map[string]string{"symbol": "BTCUSDT", "product_type": "USDT-FUTURES", "empty": ""},
+			Fields:      map[string]float64{"last": 65000.5, "bid": 65000},
+			Time:        time.UnixMilli(1700000000000),
+		},
+		{
+			Measurement: "my metric,x",
+			Tags:        map[string]string{"note": "a b=c"},
+			Fields:      map[string]float64{"v": -1e-7},
+			Time:        time.UnixMilli(1),
+		},
+	}
+	assert.Equal(t,
+		"ticker,product_type=USDT-FUTURES,symbol=BTCUSDT bid=65000,last=65000.5 1700000000000\n"+
+			`my\ metric\,x,note=a\ b\=c v=-0.0000001 1`+"\n",
+		string(LineProtocol(points)))
+}
+
+func TestInfluxWriter(t *testing.T) {
+	var got *http.Request
+	var body string
+	status := http.StatusNoContent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(status)
+		if status != http.StatusNoContent {
+			w.Write([]byte(`{"code":"invalid","message":"bad line"}`))
+		}
+	}))
+	defer srv.Close()
+
+	p := []Point{{Measurement: "equity", Fields: map[string]float64{"equity": 100}, Time: time.UnixMilli(5)}}
+	w := NewInfluxWriter(InfluxOptions{URL: srv.URL, Org: "o", Bucket: "b", Token: "tok"})
+	require.NoError(t, w.Write(context.Background(), p))
+	assert.Equal(t, "/api/v2/write", got.URL.Path)
+	assert.Equal(t, "b", got.URL.Query().Get("bucket"))
+	assert.Equal(t, "ms", got.URL.Query().Get("precision"))
+	assert.Equal(t, "Token tok", got.Header.Get("Authorization"))
+	assert.Equal(t, "equity equity=100 5\n", body)
+
+	v1 := NewInfluxWriter(InfluxOptions{URL: srv.URL, Database: "metrics", Username: "u", Password: "p"})
+	require.NoError(t, v1.Write(context.Background(), p))
+	assert.Equal(t, "/write", got.URL.Path)
+	assert.Equal(t, "metrics", got.URL.Query().Get("db"))
+	user, pass, _ := got.BasicAuth()
+	assert.Equal(t, "u:p", user+":"+pass)
+
+	status = http.StatusBadRequest
+	assert.ErrorContains(t, w.Write(context.Background(), p), "bad line")
+}
+
+// recordingDriver is a database/sql driver that records executed statements.
+type recordingDriver struct {
+	mu    sync.Mutex
+	execs []string
+	args  [][]driver.Value
+	fail  bool
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return &recordingConn{d: d}, nil }
+
+func (d *recordingDriver) Connect(context.Context) (driver.Conn, error) { return d.Open("") }
+func (d *recordingDriver) Driver() driver.Driver                        { return d }
+
+type recordingConn struct{ d *recordingDriver }
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return &recordingStmt{d: c.d, query: query}, nil
+}
+func (c *recordingConn) Close() error              { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *recordingConn) Commit() error             { return nil }
+func (c *recordingConn) Rollback() error           { return nil }
+
+type recordingStmt struct {
+	d     *recordingDriver
+	query string
+}
+
+func (s *recordingStmt) Close() error  { return nil }
+func (s *recordingStmt) NumInput() int { return -1 }
+func (s *recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	if s.d.fail {
+		return nil, errors.New("relation does not exist")
+	}
+	s.d.execs = append(s.d.execs, s.query)
+	s.d.args = append(s.d.args, args)
+	return driver.RowsAffected(1), nil
+}
+func (s *recordingStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, errors.New("not supported")
+}
+
+func TestSQLWriter(t *testing.T) {
+	d := &recordingDriver{}
+	db := sql.OpenDB(d)
+	defer db.Close()
+
+	_, err := NewSQLWriter(db, SQLOptions{Table: "metrics; DROP TABLE x"})
+	assert.Error(t, err)
+
+	w, err := NewSQLWriter(db, SQLOptions{Table: "public.metrics"})
+	require.NoError(t, err)
+	require.NoError(t, w.CreateTable(context.Background(), true))
+	require.Len(t, d.execs, 3)
+	assert.Contains(t, d.execs[0], "CREATE TABLE IF NOT EXISTS public.metrics")
+	assert.Contains(t, d.execs[1], "public_metrics_measurement_time_idx")
+	assert.Equal(t, "SELECT create_hypertable('public.metrics', 'time', if_not_exists => TRUE)", d.execs[2])
+
+	d.execs, d.args = nil, nil
+	ts := time.UnixMilli(1700000000000)
+	require.NoError(t, w.Write(context.Background(), []Point{{
+		Measurement: "equity",
+		Tags:        map[string]string{"margin_coin": "USDT"},
+		Fields:      map[string]float64{"equity": 100, "available": 80},
+		Time:        ts,
+	}}))
+	require.Len(t, d.args, 2)
+	assert.Equal(t, []driver.Value{ts.UTC(), "equity", `{"margin_coin":"USDT"}`, "available", 80.0}, d.args[0])
+	assert.Equal(t, "equity", d.args[1][3])
+
+	d.fail = true
+	assert.Error(t, w.Write(context.Background(), []Point{{Measurement: "x", Fields: map[string]float64{"v": 1}}}))
+}
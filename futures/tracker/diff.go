@@ -0,0 +1,246 @@
+package tracker
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/account"
+	"github.com/khanbekov/go-bitget/futures/position"
+)
+
+// Balance is the state of one margin coin of the futures account.
+type Balance struct {
+	MarginCoin   string  `json:"marginCoin"`
+	Equity       float64 `json:"equity"`
+	Available    float64 `json:"available"`
+	Locked       float64 `json:"locked"`
+	UnrealizedPL float64 `json:"unrealizedPL"`
+}
+
+// AccountSnapshot is the balances and open positions of an account at one instant, as
+// taken by TakeSnapshot or assembled from trackers.
+type AccountSnapshot struct {
+	Time      time.Time  `json:"time"`
+	Balances  []Balance  `json:"balances"`
+	Positions []Position `json:"positions"`
+}
+
+// TakeSnapshot fetches the balances and open positions of productType from REST.
+func TakeSnapshot(ctx context.Context, client futures.ClientInterface, productType futures.ProductType) (AccountSnapshot, error) {
+	accounts, err := account.NewAccountListService(client).ProductType(productType).Do(ctx)
+	if err != nil {
+		return AccountSnapshot{}, err
+	}
+	open, err := position.NewAllPositionsService(client).ProductType(productType).Do(ctx)
+	if err != nil {
+		return AccountSnapshot{}, err
+	}
+	snap := AccountSnapshot{Time: time.Now(), Positions: restPositions(open)}
+	for _, a := range accounts.Accounts {
+		snap.Balances = append(snap.Balances, Balance{
+			MarginCoin:   a.MarginCoin,
+			Equity:       parseFloat(a.AccountEquity),
+			Available:    parseFloat(a.Available),
+			Locked:       parseFloat(a.Locked),
+			UnrealizedPL: parseFloat(a.UnrealizedPL),
+		})
+	}
+	return snap, nil
+}
+
+// ChangeType classifies the changes reported by Diff.
+type ChangeType string
+
+const (
+	ChangeOpened  ChangeType = "opened"
+	ChangeClosed  ChangeType = "closed"
+	ChangeResized ChangeType = "resized"
+	// ChangePnL is a position of unchanged size whose PnL moved by at least
+	// DiffOptions.MinPnLDelta.
+	ChangePnL ChangeType = "pnl"
+	// ChangeBalance is a balance whose equity or available amount changed.
+	ChangeBalance ChangeType = "balance"
+)
+
+// PositionChange is the difference of one position between two snapshots. Before is nil
+// for opened positions and After is nil for closed ones; missing sides count as zero in
+// the deltas.
+type PositionChange struct {
+	Type      ChangeType
+	Key       string // Position.Key, "SYMBOL:holdSide"
+	Before    *Position
+	After     *Position
+	SizeDelta float64
+	// PnLDelta is the change in unrealized PnL, RealizedDelta in achieved profits.
+	PnLDelta      float64
+	RealizedDelta float64
+}
+
+// String returns a compact one-line summary.
+func (c PositionChange) String() string {
+	return common.Describe("PositionChange", string(c.Type), c.Key,
+		"size"+signed(c.SizeDelta), "pnl"+signed(c.PnLDelta), "realized"+signed(c.RealizedDelta))
+}
+
+// BalanceChange is the difference of one margin coin between two snapshots. Before or
+// After is nil when the coin appears or disappears.
+type BalanceChange struct {
+	Type              ChangeType
+	MarginCoin        string
+	Before            *Balance
+	After             *Balance
+	EquityDelta       float64
+	AvailableDelta    float64
+	UnrealizedPLDelta float64
+}
+
+// String returns a compact one-line summary.
+func (c BalanceChange) String() string {
+	return common.Describe("BalanceChange", c.MarginCoin,
+		"equity"+signed(c.EquityDelta), "available"+signed(c.AvailableDelta), "upl"+signed(c.UnrealizedPLDelta))
+}
+
+// SnapshotDiff is the result of Diff.
+type SnapshotDiff struct {
+	Positions []PositionChange
+	Balances  []BalanceChange
+}
+
+// Empty reports whether nothing changed.
+func (d SnapshotDiff) Empty() bool {
+	return len(d.Positions) == 0 && len(d.Balances) == 0
+}
+
+// DiffOptions filters the changes reported by Diff.
+type DiffOptions struct {
+	// MinPnLDelta is the smallest absolute PnL change reported for a position of
+	// unchanged size, and the smallest equity or available change reported for a
+	// balance. Zero reports every change.
+	MinPnLDelta float64
+}
+
+// Diff compares two account snapshots. Changes are sorted by position key and margin
+// coin, so the result is stable for reports:
+//
+//	diff := tracker.Diff(yesterday, today, tracker.DiffOptions{MinPnLDelta: 10})
+//	for _, c := range diff.Positions {
+//		alert(c.String())
+//	}
+func Diff(before, after AccountSnapshot, opts DiffOptions) SnapshotDiff {
+	return SnapshotDiff{
+		Positions: DiffPositions(before.Positions, after.Positions, opts),
+		Balances:  DiffBalances(before.Balances, after.Balances, opts),
+	}
+}
+
+// DiffPositions compares two sets of open positions, matched by Position.Key. Positions
+// of zero size count as closed.
+func DiffPositions(before, after []Position, opts DiffOptions) []PositionChange {
+	prev := indexPositions(before)
+	next := indexPositions(after)
+	var changes []PositionChange
+	for _, key := range unionKeys(prev, next) {
+		b, hadBefore := prev[key]
+		a, hasAfter := next[key]
+		c := PositionChange{Key: key}
+		var bSize, bPnL, bRealized, aSize, aPnL, aRealized float64
+		if hadBefore {
+			c.Before = &b
+			bSize, bPnL, bRealized = b.Size, b.UnrealizedPL, b.AchievedProfits
+		}
+		if hasAfter {
+			c.After = &a
+			aSize, aPnL, aRealized = a.Size, a.UnrealizedPL, a.AchievedProfits
+		}
+		c.SizeDelta = aSize - bSize
+		c.PnLDelta = aPnL - bPnL
+		c.RealizedDelta = aRealized - bRealized
+		switch {
+		case !hadBefore:
+			c.Type = ChangeOpened
+		case !hasAfter:
+			c.Type = ChangeClosed
+		case c.SizeDelta != 0:
+			c.Type = ChangeResized
+		case c.PnLDelta != 0 && math.Abs(c.PnLDelta) >= opts.MinPnLDelta:
+			c.Type = ChangePnL
+		default:
+			continue
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// DiffBalances compares two sets of balances, matched by margin coin.
+func DiffBalances(before, after []Balance, opts DiffOptions) []BalanceChange {
+	prev := make(map[string]Balance, len(before))
+	for _, b := range before {
+		prev[b.MarginCoin] = b
+	}
+	next := make(map[string]Balance, len(after))
+	for _, b := range after {
+		next[b.MarginCoin] = b
+	}
+	var changes []BalanceChange
+	for _, coin := range unionKeys(prev, next) {
+		b, hadBefore := prev[coin]
+		a, hasAfter := next[coin]
+		c := BalanceChange{Type: ChangeBalance, MarginCoin: coin}
+		if hadBefore {
+			c.Before = &b
+		}
+		if hasAfter {
+			c.After = &a
+		}
+		c.EquityDelta = a.Equity - b.Equity
+		c.AvailableDelta = a.Available - b.Available
+		c.UnrealizedPLDelta = a.UnrealizedPL - b.UnrealizedPL
+		if hadBefore && hasAfter && !exceeds(c.EquityDelta, opts.MinPnLDelta) && !exceeds(c.AvailableDelta, opts.MinPnLDelta) {
+			continue
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// exceeds reports whether delta is non-zero and at least min in absolute value.
+func exceeds(delta, min float64) bool {
+	return delta != 0 && math.Abs(delta) >= min
+}
+
+func indexPositions(positions []Position) map[string]Position {
+	out := make(map[string]Position, len(positions))
+	for _, p := range positions {
+		if p.Size != 0 {
+			out[p.Key()] = p
+		}
+	}
+	return out
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// signed formats a delta with an explicit sign, e.g. "=+1.5" or "=-2".
+func signed(v float64) string {
+	if v >= 0 {
+		return "=+" + common.FormatFloat(v)
+	}
+	return "=" + common.FormatFloat(v)
+}
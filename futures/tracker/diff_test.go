@@ -0,0 +1,81 @@
+package tracker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffPositions(t *testing.T) {
+	before := []Position{
+		{Symbol: "BTCUSDT", HoldSide: "long", Size: 1, UnrealizedPL: 100},
+		{Symbol: "ETHUSDT", HoldSide: "short", Size: 2, UnrealizedPL: -5},
+		{Symbol: "SOLUSDT", HoldSide: "long", Size: 10, UnrealizedPL: 20},
+		{Symbol: "XRPUSDT", HoldSide: "long", Size: 5, UnrealizedPL: 1},
+	}
+	after := []Position{
+		{Symbol: "BTCUSDT", HoldSide: "long", Size: 1.5, UnrealizedPL: 120, AchievedProfits: 0},
+		{Symbol: "ETHUSDT", HoldSide: "short", Size: 2, UnrealizedPL: 15},
+		{Symbol: "XRPUSDT", HoldSide: "long", Size: 5, UnrealizedPL: 1.5},
+		{Symbol: "ADAUSDT", HoldSide: "long", Size: 100},
+		{Symbol: "DOGEUSDT", HoldSide: "long", Size: 0},
+	}
+
+	changes := DiffPositions(before, after, DiffOptions{MinPnLDelta: 1})
+	require.Len(t, changes, 4, "the XRP PnL move is below the threshold")
+
+	assert.Equal(t, ChangeOpened, changes[0].Type)
+	assert.Equal(t, "ADAUSDT:long", changes[0].Key)
+	assert.Nil(t, changes[0].Before)
+
+	assert.Equal(t, ChangeResized, changes[1].Type)
+	assert.Equal(t, 0.5, changes[1].SizeDelta)
+	assert.Equal(t, 20.0, changes[1].PnLDelta)
+
+	assert.Equal(t, ChangePnL, changes[2].Type)
+	assert.Equal(t, "ETHUSDT:short", changes[2].Key)
+	assert.Equal(t, 20.0, changes[2].PnLDelta)
+
+	assert.Equal(t, ChangeClosed, changes[3].Type)
+	assert.Nil(t, changes[3].After)
+	assert.Equal(t, -10.0, changes[3].SizeDelta)
+	assert.Equal(t, "PositionChange{closed SOLUSDT:long size=-10 pnl=-20 realized=+0}", changes[3].String())
+
+	assert.Len(t, DiffPositions(before, after, DiffOptions{}), 5, "every PnL move without a threshold")
+	assert.Empty(t, DiffPositions(before, before, DiffOptions{}))
+}
+
+func TestDiffBalances(t *testing.T) {
+	before := []Balance{{MarginCoin: "USDT", Equity: 1000, Available: 800}, {MarginCoin: "USDC", Equity: 10}}
+	after := []Balance{{MarginCoin: "USDT", Equity: 1000.5, Available: 800}, {MarginCoin: "BTC", Equity: 0.1}}
+
+	changes := DiffBalances(before, after, DiffOptions{MinPnLDelta: 1})
+	require.Len(t, changes, 2)
+	assert.Equal(t, "BTC", changes[0].MarginCoin)
+	assert.Nil(t, changes[0].Before)
+	assert.Equal(t, "USDC", changes[1].MarginCoin)
+	assert.Nil(t, changes[1].After)
+	assert.Equal(t, -10.0, changes[1].EquityDelta)
+
+	changes = DiffBalances(before, after, DiffOptions{})
+	require.Len(t, changes, 3)
+	assert.Equal(t, 0.5, changes[2].EquityDelta)
+}
+
+func TestTakeSnapshotAndDiff(t *testing.T) {
+	snap, err := TakeSnapshot(context.Background(), fakeClient{}, futures.ProductTypeUSDTFutures)
+	require.NoError(t, err)
+	require.Len(t, snap.Balances, 1)
+	assert.Equal(t, Balance{MarginCoin: "USDT", Equity: 1050, Available: 900, Locked: 100, UnrealizedPL: 50}, snap.Balances[0])
+	require.Len(t, snap.Positions, 1)
+	assert.Equal(t, "ETHUSDT:short", snap.Positions[0].Key())
+
+	diff := Diff(AccountSnapshot{}, snap, DiffOptions{})
+	require.Len(t, diff.Positions, 1)
+	assert.Equal(t, ChangeOpened, diff.Positions[0].Type)
+	require.Len(t, diff.Balances, 1)
+	assert.True(t, Diff(snap, snap, DiffOptions{}).Empty())
+}
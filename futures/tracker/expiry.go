@@ -0,0 +1,231 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/trading"
+)
+
+// ExpiryAction is what ExpiryWatcher does with a stale order.
+type ExpiryAction string
+
+const (
+	ExpiryCancel  ExpiryAction = "cancel"
+	ExpiryReprice ExpiryAction = "reprice"
+)
+
+// ExpiryRule sets the maximum lifetime of resting orders.
+type ExpiryRule struct {
+	// MaxAge is the lifetime after which an order is stale. Zero disables the rule.
+	MaxAge time.Duration
+
+	// Action defaults to ExpiryCancel.
+	Action ExpiryAction
+
+	// Reprice returns the new limit price of a stale order for ExpiryReprice; returning
+	// false cancels the order instead. Required for ExpiryReprice.
+	Reprice func(o Order) (price float64, ok bool)
+
+	// MaxReprices cancels an order once it has been repriced this many times. Zero is
+	// unlimited.
+	MaxReprices int
+}
+
+// ExpiryOptions configures an ExpiryWatcher.
+type ExpiryOptions struct {
+	ProductType futures.ProductType
+	// MarginCoin is required for ExpiryReprice.
+	MarginCoin string
+
+	// Default applies to symbols without an entry in Symbols.
+	Default ExpiryRule
+	Symbols map[string]ExpiryRule
+
+	// OnExpire is called after every action with its outcome. Optional.
+	OnExpire func(ExpiryEvent)
+	// Clock decides when orders are stale. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// ExpiryEvent reports an action taken on a stale order.
+type ExpiryEvent struct {
+	Order       Order
+	Age         time.Duration
+	Action      ExpiryAction
+	Replacement *trading.OrderInfo // Set for successful reprices
+	Err         error
+}
+
+// ExpiryWatcher cancels or reprices resting orders that outlive their rule. It reads the
+// open orders of an OrderTracker, so the tracker must be kept up to date from the orders
+// channel. Only GTC and post-only limit orders are considered; IOC and FOK orders never
+// rest on the book.
+//
+//	w := tracker.NewExpiryWatcher(client, orders, tracker.ExpiryOptions{
+//		ProductType: futures.ProductTypeUSDTFutures,
+//		Default:     tracker.ExpiryRule{MaxAge: 30 * time.Minute},
+//	})
+//	go w.Run(ctx, time.Minute, log.Println)
+type ExpiryWatcher struct {
+	client  futures.ClientInterface
+	tracker *OrderTracker
+	opts    ExpiryOptions
+	clock   common.Clock
+
+	mu    sync.Mutex
+	acted map[string]bool // orders already cancelled or repriced, until they close
+}
+
+// NewExpiryWatcher creates an expiry watcher for the orders tracked by tracker.
+func NewExpiryWatcher(client futures.ClientInterface, tracker *OrderTracker, opts ExpiryOptions) *ExpiryWatcher {
+	return &ExpiryWatcher{
+		client:  client,
+		tracker: tracker,
+		opts:    opts,
+		clock:   common.ClockOrSystem(opts.Clock),
+		acted:   make(map[string]bool),
+	}
+}
+
+// Check acts on every stale order once and returns the outcomes. Errors are reported in
+// the events; a failed order is retried on the next Check.
+func (w *ExpiryWatcher) Check(ctx context.Context) []ExpiryEvent {
+	open := w.tracker.Open("")
+	now := w.clock.Now()
+
+	w.mu.Lock()
+	live := make(map[string]bool, len(open))
+	for _, o := range open {
+		live[o.OrderID] = true
+	}
+	for id := range w.acted {
+		if !live[id] {
+			delete(w.acted, id)
+		}
+	}
+	var stale []Order
+	for _, o := range open {
+		rule := w.rule(o.Symbol)
+		if rule.MaxAge <= 0 || w.acted[o.OrderID] || !resting(o) || o.CreatedAt.IsZero() {
+			continue
+		}
+		if now.Sub(o.CreatedAt) >= rule.MaxAge {
+			stale = append(stale, o)
+		}
+	}
+	w.mu.Unlock()
+
+	events := make([]ExpiryEvent, 0, len(stale))
+	for _, o := range stale {
+		ev := w.expire(ctx, o, now.Sub(o.CreatedAt))
+		if w.opts.OnExpire != nil {
+			w.opts.OnExpire(ev)
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+// Run checks immediately and then every interval until ctx is cancelled. Failed actions
+// are passed to onError when it is non-nil.
+func (w *ExpiryWatcher) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := w.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, ev := range w.Check(ctx) {
+			if ev.Err != nil && onError != nil && ctx.Err() == nil {
+				onError(ev.Err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+func (w *ExpiryWatcher) rule(symbol string) ExpiryRule {
+	if rule, ok := w.opts.Symbols[symbol]; ok {
+		return rule
+	}
+	return w.opts.Default
+}
+
+// resting reports whether the order rests on the book until cancelled.
+func resting(o Order) bool {
+	if o.OrderType != string(trading.OrderTypeLimit) {
+		return false
+	}
+	switch strings.ToLower(o.Force) {
+	case "", "gtc", string(trading.TimeInForcePostOnly):
+		return true
+	}
+	return false
+}
+
+func (w *ExpiryWatcher) expire(ctx context.Context, o Order, age time.Duration) ExpiryEvent {
+	rule := w.rule(o.Symbol)
+	root, count := repriceLineage(o)
+
+	ev := ExpiryEvent{Order: o, Age: age, Action: ExpiryCancel}
+	var price float64
+	if rule.Action == ExpiryReprice && rule.Reprice != nil && (rule.MaxReprices <= 0 || count < rule.MaxReprices) {
+		if p, ok := rule.Reprice(o); ok && p > 0 {
+			ev.Action, price = ExpiryReprice, p
+		}
+	}
+
+	productType := trading.ProductType(w.opts.ProductType)
+	if ev.Action == ExpiryReprice {
+		res, err := trading.NewCancelReplaceService(w.client).
+			Symbol(o.Symbol).
+			ProductType(productType).
+			MarginCoin(w.opts.MarginCoin).
+			OrderId(o.OrderID).
+			NewPrice(common.FormatFloat(price)).
+			NewClientOid(fmt.Sprintf("%sx%d", root, count+1)).
+			Do(ctx)
+		if res != nil {
+			ev.Replacement = res.Replacement
+		}
+		ev.Err = err
+	} else {
+		_, ev.Err = trading.NewCancelOrderService(w.client).
+			Symbol(o.Symbol).
+			ProductType(productType).
+			MarginCoin(w.opts.MarginCoin).
+			OrderId(o.OrderID).
+			Do(ctx)
+	}
+
+	if ev.Err == nil || errors.Is(ev.Err, trading.ErrOrderFilled) {
+		w.mu.Lock()
+		w.acted[o.OrderID] = true
+		w.mu.Unlock()
+	}
+	return ev
+}
+
+// repriceLineage returns the ID of the order a chain of reprices started from and the
+// number of reprices so far. Replacements carry the client ID "<root>x<count>", so the
+// count survives restarts without extra state.
+func repriceLineage(o Order) (root string, count int) {
+	if i := strings.LastIndexByte(o.ClientOid, 'x'); i > 0 {
+		root := o.ClientOid[:i]
+		_, rootErr := strconv.ParseUint(root, 10, 64)
+		if n, err := strconv.Atoi(o.ClientOid[i+1:]); err == nil && rootErr == nil && n > 0 {
+			return root, n
+		}
+	}
+	return o.OrderID, 0
+}
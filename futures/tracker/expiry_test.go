@@ -0,0 +1,171 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// orderClient answers the trading endpoints used by ExpiryWatcher and records requests.
+type orderClient struct {
+	mu       sync.Mutex
+	requests []map[string]string
+	cancel   error
+}
+
+func (c *orderClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	req := map[string]string{"endpoint": endpoint}
+	_ = json.Unmarshal(body, &req)
+	for k := range query {
+		req[k] = query.Get(k)
+	}
+	c.requests = append(c.requests, req)
+
+	ok := func(data string) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+		return &futures.ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil
+	}
+	switch endpoint {
+	case trading.EndpointCancelOrder:
+		if c.cancel != nil {
+			return nil, nil, c.cancel
+		}
+		return ok(`{"orderId":"` + req["orderId"] + `"}`)
+	case trading.EndpointOrderDetails:
+		if req["orderId"] == "" {
+			return nil, nil, &types.APIError{Code: 40109, Message: "order does not exist"}
+		}
+		return ok(`{"orderId":"` + req["orderId"] + `","size":"0.01","baseVolume":"0","price":"60000","state":"live","side":"buy","orderType":"limit","force":"gtc","marginMode":"crossed"}`)
+	case trading.EndpointModifyOrder:
+		return ok(`{"orderId":"9","clientOId":"` + req["newClientOid"] + `"}`)
+	}
+	return nil, nil, errors.New("unexpected endpoint " + endpoint)
+}
+
+func (c *orderClient) endpoints() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []string
+	for _, r := range c.requests {
+		out = append(out, r["endpoint"])
+	}
+	return out
+}
+
+func trackedOrders(created time.Time, orders ...Order) *OrderTracker {
+	tr := NewOrderTracker(Options{})
+	for i := range orders {
+		orders[i].Symbol, orders[i].Status, orders[i].CreatedAt = "BTCUSDT", StatusLive, created
+		if orders[i].OrderType == "" {
+			orders[i].OrderType = "limit"
+		}
+	}
+	tr.Update(orders...)
+	return tr
+}
+
+func TestExpiryWatcher_CancelsStaleRestingOrders(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tr := trackedOrders(now.Add(-2*time.Hour),
+		Order{OrderID: "1", Force: "gtc", Price: 60000, Size: 0.01},
+		Order{OrderID: "2", Force: "ioc", Price: 60000, Size: 0.01},
+		Order{OrderID: "3", Force: "post_only", Price: 60000, Size: 0.01},
+	)
+	tr.Update(Order{OrderID: "4", Symbol: "BTCUSDT", Status: StatusLive, OrderType: "limit", Force: "gtc", CreatedAt: now.Add(-time.Minute)})
+
+	client := &orderClient{}
+	w := NewExpiryWatcher(client, tr, ExpiryOptions{ProductType: futures.ProductTypeUSDTFutures, Default: ExpiryRule{MaxAge: time.Hour}, Clock: common.NewFakeClock(now)})
+
+	events := w.Check(context.Background())
+	require.Len(t, events, 2)
+	assert.Equal(t, "1", events[0].Order.OrderID)
+	assert.Equal(t, "3", events[1].Order.OrderID)
+	for _, ev := range events {
+		assert.Equal(t, ExpiryCancel, ev.Action)
+		assert.NoError(t, ev.Err)
+		assert.Equal(t, 2*time.Hour, ev.Age)
+	}
+
+	// Cancelled orders are not retried while the tracker still shows them open
+	assert.Empty(t, w.Check(context.Background()))
+	assert.Len(t, client.endpoints(), 2)
+}
+
+func TestExpiryWatcher_RetriesFailedCancel(t *testing.T) {
+	now := time.Now()
+	tr := trackedOrders(now.Add(-time.Hour), Order{OrderID: "1", Force: "gtc"})
+	client := &orderClient{cancel: errors.New("timeout")}
+	w := NewExpiryWatcher(client, tr, ExpiryOptions{ProductType: futures.ProductTypeUSDTFutures, Default: ExpiryRule{MaxAge: time.Minute}})
+
+	events := w.Check(context.Background())
+	require.Len(t, events, 1)
+	assert.EqualError(t, events[0].Err, "timeout")
+
+	client.cancel = nil
+	events = w.Check(context.Background())
+	require.Len(t, events, 1)
+	assert.NoError(t, events[0].Err)
+}
+
+func TestExpiryWatcher_Reprice(t *testing.T) {
+	now := time.Now()
+	tr := trackedOrders(now.Add(-time.Hour),
+		Order{OrderID: "1", Force: "gtc", Price: 60000},
+		Order{OrderID: "5", ClientOid: "5x2", Force: "gtc", Price: 60000},
+	)
+	client := &orderClient{}
+	var expired []ExpiryEvent
+	w := NewExpiryWatcher(client, tr, ExpiryOptions{
+		ProductType: futures.ProductTypeUSDTFutures,
+		MarginCoin:  "USDT",
+		Symbols: map[string]ExpiryRule{"BTCUSDT": {
+			MaxAge:      time.Minute,
+			Action:      ExpiryReprice,
+			Reprice:     func(o Order) (float64, bool) { return o.Price - 100, true },
+			MaxReprices: 2,
+		}},
+		OnExpire: func(ev ExpiryEvent) { expired = append(expired, ev) },
+	})
+
+	events := w.Check(context.Background())
+	require.Len(t, events, 2)
+	assert.Equal(t, events, expired)
+
+	assert.Equal(t, ExpiryReprice, events[0].Action)
+	require.NoError(t, events[0].Err)
+	assert.Equal(t, "1x1", events[0].Replacement.ClientOrderId)
+
+	// The second order was already repriced twice, so it is cancelled
+	assert.Equal(t, ExpiryCancel, events[1].Action)
+
+	var modify map[string]string
+	for _, r := range client.requests {
+		if r["endpoint"] == trading.EndpointModifyOrder {
+			modify = r
+		}
+	}
+	assert.Equal(t, "59900", modify["newPrice"])
+}
+
+func TestRepriceLineage(t *testing.T) {
+	root, n := repriceLineage(Order{OrderID: "7", ClientOid: "123x3"})
+	assert.Equal(t, "123", root)
+	assert.Equal(t, 3, n)
+
+	root, n = repriceLineage(Order{OrderID: "7", ClientOid: "box1"})
+	assert.Equal(t, "7", root)
+	assert.Equal(t, 0, n)
+}
@@ -0,0 +1,63 @@
+package tracker
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/replay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal_ReplayRebuildsTrackers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "private.jsonl")
+	journal, err := OpenJournal(path)
+	require.NoError(t, err)
+
+	orders := NewOrderTracker(Options{})
+	positions := NewPositionTracker(Options{})
+	onOrders := journal.Wrap(orders.HandleMessage)
+	onPositions := journal.Wrap(positions.HandleMessage)
+
+	short := `{"instId":"ETHUSDT","holdSide":"short","total":"1","openPriceAvg":"3000","leverage":"5"}`
+	onOrders(orderMessage("live", "0"))
+	onPositions(positionMessage("snapshot", short))
+	onOrders(orderMessage("partially_filled", "0.01"))
+	require.NoError(t, journal.Append(`{"event":"subscribe","arg":{"channel":"orders"}}`))
+	require.NoError(t, journal.Close())
+
+	// A fresh process rebuilds the same state from the log
+	restoredOrders := NewOrderTracker(Options{})
+	restoredPositions := NewPositionTracker(Options{})
+	n, err := ReplayFile(path, time.Time{}, restoredOrders, restoredPositions)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n, "acknowledgements are skipped")
+	assert.Equal(t, orders.Open(""), restoredOrders.Open(""))
+	assert.Equal(t, positions.Open(""), restoredPositions.Open(""))
+
+	o, ok := restoredOrders.Get("1")
+	require.True(t, ok)
+	assert.True(t, o.Status == StatusPartiallyFilled)
+
+	n, err = ReplayFile(filepath.Join(t.TempDir(), "missing.jsonl"), time.Time{}, restoredOrders, nil)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestReplay_From(t *testing.T) {
+	var buf bytes.Buffer
+	rec := replay.NewRecorder(&buf)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rec.Record(replay.Frame{Time: start, Data: orderMessage("live", "0")})
+	rec.Record(replay.Frame{Time: start.Add(time.Minute), Data: orderMessage("filled", "0.02")})
+	require.NoError(t, rec.Flush())
+
+	orders := NewOrderTracker(Options{})
+	orders.Update(Order{OrderID: "1", Symbol: "BTCUSDT", Status: StatusLive})
+	n, err := Replay(&buf, start.Add(time.Second), orders, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Empty(t, orders.Open(""), "only the fill after from is applied")
+}
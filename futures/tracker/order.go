@@ -0,0 +1,356 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/khanbekov/go-bitget/state"
+)
+
+// Order statuses reported by Bitget, untyped so that they also compare with raw
+// strings. See trading.OrderStatus.
+const (
+	StatusLive            = "live"
+	StatusPartiallyFilled = "partially_filled"
+	StatusFilled          = "filled"
+	StatusCanceled        = "canceled"
+)
+
+// Order is the tracked state of an order.
+type Order struct {
+	OrderID    string              `json:"orderId"`
+	ClientOid  string              `json:"clientOid,omitempty"`
+	Symbol     string              `json:"symbol"`
+	Side       string              `json:"side"`
+	TradeSide  string              `json:"tradeSide,omitempty"`
+	PosSide    string              `json:"posSide,omitempty"`
+	OrderType  string              `json:"orderType"`
+	Force      string              `json:"force,omitempty"`
+	Price      float64             `json:"price"`
+	Size       float64             `json:"size"`
+	FilledSize float64             `json:"filledSize"`
+	AvgPrice   float64             `json:"avgPrice"`
+	Status     trading.OrderStatus `json:"status"`
+	ReduceOnly bool                `json:"reduceOnly,omitempty"`
+	CreatedAt  time.Time           `json:"createdAt"`
+	UpdatedAt  time.Time           `json:"updatedAt"`
+}
+
+// Terminal reports whether the order can no longer change.
+func (o Order) Terminal() bool {
+	return o.Status.IsTerminal()
+}
+
+// Remaining returns the unfilled size.
+func (o Order) Remaining() float64 {
+	return o.Size - o.FilledSize
+}
+
+// String returns a compact one-line summary.
+func (o Order) String() string {
+	size := common.FormatFloat(o.Size)
+	if o.Price > 0 {
+		size += "@" + common.FormatFloat(o.Price)
+	}
+	return common.Describe("Order", o.Symbol, o.Side, o.TradeSide, o.OrderType, size,
+		common.KV("status", string(o.Status)), "filled="+common.FormatFloat(o.FilledSize),
+		common.KV("id", o.OrderID), common.KV("clientOid", o.ClientOid))
+}
+
+// OrderEventType classifies order events.
+type OrderEventType string
+
+const (
+	OrderNew             OrderEventType = "new"
+	OrderPartiallyFilled OrderEventType = "partially_filled"
+	OrderFilled          OrderEventType = "filled"
+	OrderCanceled        OrderEventType = "canceled"
+	// OrderUpdated is any other change, e.g. a modified price or size.
+	OrderUpdated OrderEventType = "updated"
+)
+
+// OrderEvent is a change to a tracked order. Previous is nil for the first update of an
+// order.
+type OrderEvent struct {
+	Type     OrderEventType
+	Order    Order
+	Previous *Order
+	// Tenant is the Options.Tenant of the tracker.
+	Tenant string
+}
+
+// OrderTracker keeps the open orders of the account up to date. Terminal orders emit
+// their final event and are removed. It is safe for concurrent use.
+type OrderTracker struct {
+	opts   Options
+	mu     sync.RWMutex
+	orders map[string]Order
+	stream *stream[OrderEvent]
+}
+
+// NewOrderTracker creates an order tracker.
+func NewOrderTracker(opts Options) *OrderTracker {
+	if opts.Namespace == "" {
+		opts.Namespace = "orders"
+	}
+	opts.Store = state.ForTenant(opts.Store, opts.Tenant)
+	return &OrderTracker{opts: opts, orders: make(map[string]Order), stream: newStream[OrderEvent](opts.Buffer)}
+}
+
+// Tenant returns Options.Tenant.
+func (t *OrderTracker) Tenant() string {
+	return t.opts.Tenant
+}
+
+// Events returns the buffered event channel. It is closed by Shutdown. Every call
+// returns the same channel, so events are shared by all readers.
+func (t *OrderTracker) Events() <-chan OrderEvent {
+	return t.stream.events()
+}
+
+// OnEvent sets the handler called for every event, after it is queued on Events.
+func (t *OrderTracker) OnEvent(handler func(OrderEvent)) {
+	t.stream.onEvent(handler)
+}
+
+// Dropped returns the number of events discarded because the channel buffer was full.
+func (t *OrderTracker) Dropped() uint64 {
+	return t.stream.dropped.Load()
+}
+
+// Shutdown closes the event channel. It implements lifecycle.Component.
+func (t *OrderTracker) Shutdown(ctx context.Context) error {
+	t.stream.close()
+	return nil
+}
+
+// Get returns an open order by ID.
+func (t *OrderTracker) Get(orderID string) (Order, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	o, ok := t.orders[orderID]
+	return o, ok
+}
+
+// Open returns the open orders sorted by creation time, optionally filtered by symbol.
+func (t *OrderTracker) Open(symbol string) []Order {
+	t.mu.RLock()
+	out := make([]Order, 0, len(t.orders))
+	for _, o := range t.orders {
+		if symbol == "" || o.Symbol == symbol {
+			out = append(out, o)
+		}
+	}
+	t.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].OrderID < out[j].OrderID
+	})
+	return out
+}
+
+// Update applies order snapshots and returns the resulting events. Updates identical to
+// the tracked state produce no event.
+func (t *OrderTracker) Update(orders ...Order) []OrderEvent {
+	var events []OrderEvent
+	t.mu.Lock()
+	for _, o := range orders {
+		if o.OrderID == "" {
+			continue
+		}
+		prev, known := t.orders[o.OrderID]
+		if known && sameOrder(prev, o) {
+			continue
+		}
+		ev := OrderEvent{Type: orderEventType(o, prev, known), Order: o, Tenant: t.opts.Tenant}
+		if known {
+			p := prev
+			ev.Previous = &p
+		}
+		if o.Terminal() {
+			delete(t.orders, o.OrderID)
+		} else {
+			t.orders[o.OrderID] = o
+		}
+		events = append(events, ev)
+	}
+	t.mu.Unlock()
+
+	t.persist(events)
+	t.stream.publish(events)
+	return events
+}
+
+// sameOrder ignores UpdatedAt so refreshed timestamps alone do not produce events.
+func sameOrder(a, b Order) bool {
+	return a.CreatedAt.Equal(b.CreatedAt) &&
+		a.Status == b.Status && a.FilledSize == b.FilledSize && a.AvgPrice == b.AvgPrice &&
+		a.Price == b.Price && a.Size == b.Size && a.OrderType == b.OrderType && a.Force == b.Force
+}
+
+func orderEventType(o, prev Order, known bool) OrderEventType {
+	switch o.Status {
+	case StatusFilled:
+		return OrderFilled
+	case StatusCanceled:
+		return OrderCanceled
+	case StatusPartiallyFilled:
+		if !known || o.FilledSize > prev.FilledSize {
+			return OrderPartiallyFilled
+		}
+	default:
+		if !known {
+			return OrderNew
+		}
+	}
+	return OrderUpdated
+}
+
+// HandleMessage ingests a message from the private orders channel. Its signature matches
+// ws.OnReceive so it can be passed to SubscribeOrders directly.
+func (t *OrderTracker) HandleMessage(message string) {
+	var msg struct {
+		Data []wsOrder `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(message), &msg); err != nil {
+		t.error(fmt.Errorf("tracker: decode order message: %w", err))
+		return
+	}
+	orders := make([]Order, 0, len(msg.Data))
+	for _, o := range msg.Data {
+		orders = append(orders, o.order())
+	}
+	t.Update(orders...)
+}
+
+// Sync seeds the tracker with the pending orders returned by REST. Tracked orders missing
+// from the response are left untouched, as their final state is unknown.
+func (t *OrderTracker) Sync(ctx context.Context, client futures.ClientInterface, productType futures.ProductType) error {
+	pending, err := trading.NewPendingOrdersService(client).ProductType(trading.ProductType(productType)).Do(ctx)
+	if err != nil {
+		return err
+	}
+	orders := make([]Order, 0, len(pending))
+	for _, p := range pending {
+		orders = append(orders, Order{
+			OrderID:    p.OrderId,
+			ClientOid:  p.ClientOid,
+			Symbol:     p.Symbol,
+			Side:       string(p.Side),
+			TradeSide:  p.TradeSide,
+			PosSide:    string(p.PosSide),
+			OrderType:  p.OrderType,
+			Force:      p.Force,
+			Price:      parseFloat(p.Price),
+			Size:       parseFloat(p.Size),
+			FilledSize: parseFloat(p.BaseVolume),
+			AvgPrice:   parseFloat(p.PriceAvg),
+			Status:     p.Status,
+			CreatedAt:  common.ParseMs(p.CTime),
+			UpdatedAt:  common.ParseMs(p.UTime),
+		})
+	}
+	t.Update(orders...)
+	return nil
+}
+
+// Restore loads the orders persisted in Options.Store without emitting events.
+func (t *OrderTracker) Restore(ctx context.Context) error {
+	if t.opts.Store == nil {
+		return nil
+	}
+	values, err := t.opts.Store.List(ctx, t.opts.Namespace)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, data := range values {
+		var o Order
+		if err := json.Unmarshal(data, &o); err != nil {
+			return fmt.Errorf("tracker: restore order %s: %w", key, err)
+		}
+		t.orders[o.OrderID] = o
+	}
+	return nil
+}
+
+func (t *OrderTracker) persist(events []OrderEvent) {
+	if t.opts.Store == nil {
+		return
+	}
+	ctx := context.Background()
+	for _, ev := range events {
+		var err error
+		if ev.Order.Terminal() {
+			err = t.opts.Store.Delete(ctx, t.opts.Namespace, ev.Order.OrderID)
+		} else {
+			err = state.PutJSON(ctx, t.opts.Store, t.opts.Namespace, ev.Order.OrderID, ev.Order)
+		}
+		if err != nil {
+			t.error(fmt.Errorf("tracker: persist order %s: %w", ev.Order.OrderID, err))
+		}
+	}
+}
+
+func (t *OrderTracker) error(err error) {
+	if t.opts.OnError != nil {
+		t.opts.OnError(err)
+	}
+}
+
+// wsOrder is an entry of the private orders channel.
+type wsOrder struct {
+	OrderID       string              `json:"orderId"`
+	ClientOid     string              `json:"clientOid"`
+	InstID        string              `json:"instId"`
+	Side          string              `json:"side"`
+	TradeSide     string              `json:"tradeSide"`
+	PosSide       string              `json:"posSide"`
+	OrderType     string              `json:"orderType"`
+	Force         string              `json:"force"`
+	Price         string              `json:"price"`
+	Size          string              `json:"size"`
+	AccBaseVolume string              `json:"accBaseVolume"`
+	PriceAvg      string              `json:"priceAvg"`
+	Status        trading.OrderStatus `json:"status"`
+	ReduceOnly    string              `json:"reduceOnly"`
+	CTime         string              `json:"cTime"`
+	UTime         string              `json:"uTime"`
+}
+
+func (o wsOrder) order() Order {
+	return Order{
+		OrderID:    o.OrderID,
+		ClientOid:  o.ClientOid,
+		Symbol:     o.InstID,
+		Side:       o.Side,
+		TradeSide:  o.TradeSide,
+		PosSide:    o.PosSide,
+		OrderType:  o.OrderType,
+		Force:      o.Force,
+		Price:      parseFloat(o.Price),
+		Size:       parseFloat(o.Size),
+		FilledSize: parseFloat(o.AccBaseVolume),
+		AvgPrice:   parseFloat(o.PriceAvg),
+		Status:     o.Status,
+		ReduceOnly: o.ReduceOnly == "yes",
+		CreatedAt:  common.ParseMs(o.CTime),
+		UpdatedAt:  common.ParseMs(o.UTime),
+	}
+}
+
+func parseFloat(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
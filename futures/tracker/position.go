@@ -0,0 +1,358 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/position"
+	"github.com/khanbekov/go-bitget/state"
+	"github.com/khanbekov/go-bitget/ws"
+)
+
+// Position is the tracked state of a position.
+type Position struct {
+	Symbol           string    `json:"symbol"`
+	HoldSide         string    `json:"holdSide"`
+	MarginCoin       string    `json:"marginCoin,omitempty"`
+	MarginMode       string    `json:"marginMode,omitempty"`
+	Size             float64   `json:"size"`
+	Available        float64   `json:"available"`
+	AvgPrice         float64   `json:"avgPrice"`
+	MarkPrice        float64   `json:"markPrice,omitempty"`
+	Leverage         float64   `json:"leverage"`
+	UnrealizedPL     float64   `json:"unrealizedPL"`
+	AchievedProfits  float64   `json:"achievedProfits"`
+	LiquidationPrice float64   `json:"liquidationPrice"`
+	BreakEvenPrice   float64   `json:"breakEvenPrice,omitempty"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// Key identifies the position: "SYMBOL:holdSide".
+func (p Position) Key() string {
+	return p.Symbol + ":" + p.HoldSide
+}
+
+// String returns a compact one-line summary.
+func (p Position) String() string {
+	return common.Describe("Position", p.Symbol, p.HoldSide,
+		common.FormatFloat(p.Size)+"@"+common.FormatFloat(p.AvgPrice),
+		"upl="+common.FormatFloat(p.UnrealizedPL), "lev="+common.FormatFloat(p.Leverage)+"x", p.MarginMode)
+}
+
+// PositionEventType classifies position events.
+type PositionEventType string
+
+const (
+	PositionOpened    PositionEventType = "opened"
+	PositionIncreased PositionEventType = "increased"
+	PositionReduced   PositionEventType = "reduced"
+	PositionClosed    PositionEventType = "closed"
+	// PositionUpdated is a change that leaves the size unchanged, e.g. unrealized PnL.
+	PositionUpdated PositionEventType = "updated"
+)
+
+// PositionEvent is a change to a tracked position. Previous is nil for opened positions.
+// For closed positions Position holds the last known state with Size 0.
+type PositionEvent struct {
+	Type     PositionEventType
+	Position Position
+	Previous *Position
+	// Tenant is the Options.Tenant of the tracker.
+	Tenant string
+}
+
+// PositionTracker keeps the open positions of the account up to date. It is safe for
+// concurrent use.
+type PositionTracker struct {
+	opts      Options
+	mu        sync.RWMutex
+	positions map[string]Position
+	stream    *stream[PositionEvent]
+}
+
+// NewPositionTracker creates a position tracker.
+func NewPositionTracker(opts Options) *PositionTracker {
+	if opts.Namespace == "" {
+		opts.Namespace = "positions"
+	}
+	opts.Store = state.ForTenant(opts.Store, opts.Tenant)
+	return &PositionTracker{opts: opts, positions: make(map[string]Position), stream: newStream[PositionEvent](opts.Buffer)}
+}
+
+// Tenant returns Options.Tenant.
+func (t *PositionTracker) Tenant() string {
+	return t.opts.Tenant
+}
+
+// Events returns the buffered event channel. It is closed by Shutdown. Every call
+// returns the same channel, so events are shared by all readers.
+func (t *PositionTracker) Events() <-chan PositionEvent {
+	return t.stream.events()
+}
+
+// OnEvent sets the handler called for every event, after it is queued on Events.
+func (t *PositionTracker) OnEvent(handler func(PositionEvent)) {
+	t.stream.onEvent(handler)
+}
+
+// Dropped returns the number of events discarded because the channel buffer was full.
+func (t *PositionTracker) Dropped() uint64 {
+	return t.stream.dropped.Load()
+}
+
+// Shutdown closes the event channel. It implements lifecycle.Component.
+func (t *PositionTracker) Shutdown(ctx context.Context) error {
+	t.stream.close()
+	return nil
+}
+
+// Get returns the open position for symbol and hold side ("long", "short" or "net").
+func (t *PositionTracker) Get(symbol, holdSide string) (Position, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	p, ok := t.positions[symbol+":"+holdSide]
+	return p, ok
+}
+
+// Open returns the open positions sorted by key, optionally filtered by symbol.
+func (t *PositionTracker) Open(symbol string) []Position {
+	t.mu.RLock()
+	out := make([]Position, 0, len(t.positions))
+	for _, p := range t.positions {
+		if symbol == "" || p.Symbol == symbol {
+			out = append(out, p)
+		}
+	}
+	t.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].Key() < out[j].Key() })
+	return out
+}
+
+// Update applies incremental position updates. A position with Size 0 is closed.
+func (t *PositionTracker) Update(positions ...Position) []PositionEvent {
+	return t.apply(positions, false)
+}
+
+// Replace applies a full snapshot of open positions: tracked positions missing from it
+// are closed.
+func (t *PositionTracker) Replace(positions []Position) []PositionEvent {
+	return t.apply(positions, true)
+}
+
+func (t *PositionTracker) apply(positions []Position, snapshot bool) []PositionEvent {
+	var events []PositionEvent
+	t.mu.Lock()
+	seen := make(map[string]bool, len(positions))
+	for _, p := range positions {
+		key := p.Key()
+		seen[key] = true
+		prev, known := t.positions[key]
+		if ev, ok := positionEvent(p, prev, known); ok {
+			ev.Tenant = t.opts.Tenant
+			events = append(events, ev)
+		}
+		if p.Size == 0 {
+			delete(t.positions, key)
+		} else {
+			t.positions[key] = p
+		}
+	}
+	if snapshot {
+		keys := make([]string, 0)
+		for key := range t.positions {
+			if !seen[key] {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			prev := t.positions[key]
+			closed := prev
+			closed.Size, closed.Available, closed.UnrealizedPL = 0, 0, 0
+			events = append(events, PositionEvent{Type: PositionClosed, Position: closed, Previous: &prev, Tenant: t.opts.Tenant})
+			delete(t.positions, key)
+		}
+	}
+	t.mu.Unlock()
+
+	t.persist(events)
+	t.stream.publish(events)
+	return events
+}
+
+func positionEvent(p, prev Position, known bool) (PositionEvent, bool) {
+	ev := PositionEvent{Position: p}
+	if known {
+		ev.Previous = &prev
+	}
+	switch {
+	case !known && p.Size == 0:
+		return ev, false
+	case !known:
+		ev.Type = PositionOpened
+	case p.Size == 0:
+		ev.Type = PositionClosed
+	case p.Size > prev.Size:
+		ev.Type = PositionIncreased
+	case p.Size < prev.Size:
+		ev.Type = PositionReduced
+	case samePosition(p, prev):
+		return ev, false
+	default:
+		ev.Type = PositionUpdated
+	}
+	return ev, true
+}
+
+// samePosition ignores UpdatedAt so refreshed timestamps alone do not produce events.
+func samePosition(a, b Position) bool {
+	a.UpdatedAt, b.UpdatedAt = time.Time{}, time.Time{}
+	a.CreatedAt, b.CreatedAt = a.CreatedAt.UTC(), b.CreatedAt.UTC()
+	return a == b
+}
+
+// HandleMessage ingests a message from the private positions channel. Snapshot pushes
+// list every open position, so positions missing from them are closed. Its signature
+// matches ws.OnReceive so it can be passed to SubscribePositions directly.
+func (t *PositionTracker) HandleMessage(message string) {
+	var msg struct {
+		Action string       `json:"action"`
+		Data   []wsPosition `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(message), &msg); err != nil {
+		t.error(fmt.Errorf("tracker: decode position message: %w", err))
+		return
+	}
+	positions := make([]Position, 0, len(msg.Data))
+	for _, p := range msg.Data {
+		positions = append(positions, p.position())
+	}
+	t.apply(positions, msg.Action == ws.ActionSnapshot)
+}
+
+// Sync replaces the tracked positions with the open positions returned by REST.
+func (t *PositionTracker) Sync(ctx context.Context, client futures.ClientInterface, productType futures.ProductType) error {
+	open, err := position.NewAllPositionsService(client).ProductType(productType).Do(ctx)
+	if err != nil {
+		return err
+	}
+	t.Replace(restPositions(open))
+	return nil
+}
+
+// restPositions converts positions returned by REST.
+func restPositions(open []*position.Position) []Position {
+	positions := make([]Position, 0, len(open))
+	for _, p := range open {
+		positions = append(positions, Position{
+			Symbol:           p.Symbol,
+			HoldSide:         string(p.HoldSide),
+			MarginCoin:       p.MarginCoin,
+			MarginMode:       p.MarginMode,
+			Size:             p.Total,
+			Available:        p.Available,
+			AvgPrice:         p.AverageOpenPrice,
+			MarkPrice:        p.MarkPrice,
+			Leverage:         p.Leverage,
+			UnrealizedPL:     p.UnrealizedPL,
+			AchievedProfits:  p.AchievedProfits,
+			LiquidationPrice: p.LiquidationPrice,
+			BreakEvenPrice:   p.BreakEvenPrice,
+			CreatedAt:        common.ParseMs(p.Ctime),
+			UpdatedAt:        common.ParseMs(p.Utime),
+		})
+	}
+	return positions
+}
+
+// Restore loads the positions persisted in Options.Store without emitting events.
+func (t *PositionTracker) Restore(ctx context.Context) error {
+	if t.opts.Store == nil {
+		return nil
+	}
+	values, err := t.opts.Store.List(ctx, t.opts.Namespace)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, data := range values {
+		var p Position
+		if err := json.Unmarshal(data, &p); err != nil {
+			return fmt.Errorf("tracker: restore position %s: %w", key, err)
+		}
+		t.positions[p.Key()] = p
+	}
+	return nil
+}
+
+func (t *PositionTracker) persist(events []PositionEvent) {
+	if t.opts.Store == nil {
+		return
+	}
+	ctx := context.Background()
+	for _, ev := range events {
+		key := ev.Position.Key()
+		var err error
+		if ev.Type == PositionClosed {
+			err = t.opts.Store.Delete(ctx, t.opts.Namespace, key)
+		} else {
+			err = state.PutJSON(ctx, t.opts.Store, t.opts.Namespace, key, ev.Position)
+		}
+		if err != nil {
+			t.error(fmt.Errorf("tracker: persist position %s: %w", key, err))
+		}
+	}
+}
+
+func (t *PositionTracker) error(err error) {
+	if t.opts.OnError != nil {
+		t.opts.OnError(err)
+	}
+}
+
+// wsPosition is an entry of the private positions channel.
+type wsPosition struct {
+	InstID           string `json:"instId"`
+	HoldSide         string `json:"holdSide"`
+	MarginCoin       string `json:"marginCoin"`
+	MarginMode       string `json:"marginMode"`
+	Total            string `json:"total"`
+	Available        string `json:"available"`
+	OpenPriceAvg     string `json:"openPriceAvg"`
+	MarkPrice        string `json:"markPrice"`
+	Leverage         string `json:"leverage"`
+	UnrealizedPL     string `json:"unrealizedPL"`
+	AchievedProfits  string `json:"achievedProfits"`
+	LiquidationPrice string `json:"liquidationPrice"`
+	BreakEvenPrice   string `json:"breakEvenPrice"`
+	CTime            string `json:"cTime"`
+	UTime            string `json:"uTime"`
+}
+
+func (p wsPosition) position() Position {
+	return Position{
+		Symbol:           p.InstID,
+		HoldSide:         p.HoldSide,
+		MarginCoin:       p.MarginCoin,
+		MarginMode:       p.MarginMode,
+		Size:             parseFloat(p.Total),
+		Available:        parseFloat(p.Available),
+		AvgPrice:         parseFloat(p.OpenPriceAvg),
+		MarkPrice:        parseFloat(p.MarkPrice),
+		Leverage:         parseFloat(p.Leverage),
+		UnrealizedPL:     parseFloat(p.UnrealizedPL),
+		AchievedProfits:  parseFloat(p.AchievedProfits),
+		LiquidationPrice: parseFloat(p.LiquidationPrice),
+		BreakEvenPrice:   parseFloat(p.BreakEvenPrice),
+		CreatedAt:        common.ParseMs(p.CTime),
+		UpdatedAt:        common.ParseMs(p.UTime),
+	}
+}
@@ -0,0 +1,140 @@
+// Package tracker maintains live views of open orders and positions from the private
+// WebSocket channels, seeded from REST, and publishes changes as events.
+//
+// Events are delivered both to a callback and to a buffered channel, so callers can use
+// whichever style fits:
+//
+//	orders := tracker.NewOrderTracker(tracker.Options{})
+//	wsClient.SubscribeOrders("USDT-FUTURES", orders.HandleMessage)
+//
+//	for {
+//		select {
+//		case ev, ok := <-orders.Events():
+//			if !ok {
+//				return // tracker shut down
+//			}
+//			log.Println(ev.Type, ev.Order)
+//		case <-ctx.Done():
+//			return
+//		}
+//	}
+//
+// Trackers implement lifecycle.Component; Shutdown closes the event channel. A Journal
+// logs the private messages so that Replay can rebuild trackers after a crash.
+//
+// Diff compares two AccountSnapshots, e.g. from TakeSnapshot, and reports opened,
+// closed and resized positions, PnL moves and balance changes for alerting and
+// reconciliation reports.
+//
+// To track several accounts in one process, give each tracker its Options.Tenant; they
+// can then share a Store, and events report which account they belong to.
+package tracker
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/khanbekov/go-bitget/state"
+)
+
+// DefaultBuffer is the default capacity of the event channel.
+const DefaultBuffer = 256
+
+// Options configures a tracker.
+type Options struct {
+	// Buffer is the capacity of the channel returned by Events. Defaults to DefaultBuffer.
+	// When the buffer is full new events are dropped rather than blocking the WebSocket
+	// reader; see Dropped.
+	Buffer int
+
+	// Store persists the tracked state so it can be restored after a restart. Optional.
+	Store state.Store
+	// Namespace overrides the store namespace, "orders" or "positions" by default.
+	Namespace string
+	// Tenant labels the account or user the tracker belongs to. Its state is stored under
+	// state.TenantNamespace and its events carry it, so trackers of several accounts can
+	// share a process and a store without collisions. Optional.
+	Tenant string
+
+	// OnError receives persistence and decoding errors. Optional.
+	OnError func(error)
+}
+
+// stream fans events out to a callback and a lazily created buffered channel.
+type stream[E any] struct {
+	mu      sync.Mutex
+	buffer  int
+	ch      chan E
+	handler func(E)
+	closed  bool
+	dropped atomic.Uint64
+}
+
+func newStream[E any](buffer int) *stream[E] {
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+	return &stream[E]{buffer: buffer}
+}
+
+// events returns the event channel, creating it on first use so trackers used only with
+// callbacks do not accumulate dropped events.
+func (s *stream[E]) events() <-chan E {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ch == nil {
+		s.ch = make(chan E, s.buffer)
+		if s.closed {
+			close(s.ch)
+		}
+	}
+	return s.ch
+}
+
+func (s *stream[E]) onEvent(handler func(E)) {
+	s.mu.Lock()
+	s.handler = handler
+	s.mu.Unlock()
+}
+
+// publish delivers events to the channel without blocking, then to the handler.
+func (s *stream[E]) publish(events []E) {
+	if len(events) == 0 {
+		return
+	}
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	if s.ch != nil {
+		for _, e := range events {
+			select {
+			case s.ch <- e:
+			default:
+				s.dropped.Add(1)
+			}
+		}
+	}
+	handler := s.handler
+	s.mu.Unlock()
+
+	if handler != nil {
+		for _, e := range events {
+			handler(e)
+		}
+	}
+}
+
+// close closes the event channel once. Later events are discarded.
+func (s *stream[E]) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	if s.ch != nil {
+		close(s.ch)
+	}
+}
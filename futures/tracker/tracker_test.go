@@ -0,0 +1,231 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeClient struct{}
+
+func (fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	switch endpoint {
+	case futures.EndpointPendingOrders:
+		data := `{"entrustedList":[{"symbol":"BTCUSDT","orderId":"1","size":"0.02","price":"60000","baseVolume":"0","status":"live","side":"buy","orderType":"limit","cTime":"1700000000000"}],"endId":"1"}`
+		return &futures.ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil
+	case futures.EndpointAllPositions:
+		data := `[{"symbol":"ETHUSDT","holdSide":"short","total":"2","available":"2","openPriceAvg":"3000","leverage":"5","marginMode":"crossed","ctime":"1700000000000"}]`
+		return &futures.ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil
+	case futures.EndpointAccountList:
+		data := `[{"marginCoin":"USDT","available":"900","locked":"100","accountEquity":"1050","unrealizedPL":"50"}]`
+		return &futures.ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil
+	}
+	return nil, nil, errors.New("unexpected endpoint")
+}
+
+func orderMessage(status, filled string) string {
+	return `{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"orders","instId":"default"},"data":[{` +
+		`"orderId":"1","clientOid":"c1","instId":"BTCUSDT","side":"buy","tradeSide":"open","orderType":"limit","force":"gtc",` +
+		`"price":"60000","size":"0.02","accBaseVolume":"` + filled + `","priceAvg":"60000","status":"` + status + `",` +
+		`"reduceOnly":"no","cTime":"1700000000000","uTime":"1700000001000"}]}`
+}
+
+func TestOrderTracker_Lifecycle(t *testing.T) {
+	tr := NewOrderTracker(Options{})
+	events := tr.Events()
+	var handled []OrderEventType
+	tr.OnEvent(func(ev OrderEvent) { handled = append(handled, ev.Type) })
+
+	tr.HandleMessage(orderMessage(StatusLive, "0"))
+	tr.HandleMessage(orderMessage(StatusLive, "0")) // duplicate push
+	tr.HandleMessage(orderMessage(StatusPartiallyFilled, "0.01"))
+
+	o, ok := tr.Get("1")
+	require.True(t, ok)
+	assert.Equal(t, "BTCUSDT", o.Symbol)
+	assert.InDelta(t, 0.01, o.Remaining(), 1e-12)
+	assert.Len(t, tr.Open("BTCUSDT"), 1)
+
+	tr.HandleMessage(orderMessage(StatusFilled, "0.02"))
+	_, ok = tr.Get("1")
+	assert.False(t, ok, "terminal orders are removed")
+	assert.Empty(t, tr.Open(""))
+
+	want := []OrderEventType{OrderNew, OrderPartiallyFilled, OrderFilled}
+	assert.Equal(t, want, handled)
+	for i, typ := range want {
+		ev := <-events
+		assert.Equal(t, typ, ev.Type)
+		if i == 0 {
+			assert.Nil(t, ev.Previous)
+		} else {
+			require.NotNil(t, ev.Previous)
+		}
+	}
+	last := OrderEvent{}
+	select {
+	case last = <-events:
+	default:
+	}
+	assert.Empty(t, last.Type, "no extra events")
+}
+
+func TestOrderTracker_BufferAndShutdown(t *testing.T) {
+	tr := NewOrderTracker(Options{Buffer: 1})
+	events := tr.Events()
+	tr.Update(Order{OrderID: "1", Status: StatusLive}, Order{OrderID: "2", Status: StatusLive})
+	assert.Equal(t, uint64(1), tr.Dropped())
+
+	require.NoError(t, tr.Shutdown(context.Background()))
+	require.NoError(t, tr.Shutdown(context.Background()), "idempotent")
+	tr.Update(Order{OrderID: "3", Status: StatusLive}) // must not panic
+
+	ev, ok := <-events
+	assert.True(t, ok)
+	assert.Equal(t, "1", ev.Order.OrderID)
+	_, ok = <-events
+	assert.False(t, ok, "channel closed on shutdown")
+
+	select {
+	case _, ok := <-closedOrderTracker().Events():
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("Events after Shutdown must return a closed channel")
+	}
+}
+
+func closedOrderTracker() *OrderTracker {
+	tr := NewOrderTracker(Options{})
+	_ = tr.Shutdown(context.Background())
+	return tr
+}
+
+func TestOrderTracker_StoreAndSync(t *testing.T) {
+	store := state.NewMemoryStore()
+	tr := NewOrderTracker(Options{Store: store})
+	require.NoError(t, tr.Sync(context.Background(), fakeClient{}, futures.ProductTypeUSDTFutures))
+	o, ok := tr.Get("1")
+	require.True(t, ok)
+	assert.Equal(t, 60000.0, o.Price)
+	assert.Equal(t, int64(1700000000000), o.CreatedAt.UnixMilli())
+
+	restored := NewOrderTracker(Options{Store: store})
+	require.NoError(t, restored.Restore(context.Background()))
+	r, ok := restored.Get("1")
+	require.True(t, ok)
+	assert.True(t, o.CreatedAt.Equal(r.CreatedAt))
+	assert.Empty(t, restored.Update(r), "restored state matches")
+
+	restored.Update(Order{OrderID: "1", Status: StatusCanceled})
+	keys, err := state.Keys(context.Background(), store, "orders")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestTrackers_TenantsShareStore(t *testing.T) {
+	ctx := context.Background()
+	store := state.NewMemoryStore()
+	alice := NewOrderTracker(Options{Store: store, Tenant: "alice"})
+	bob := NewOrderTracker(Options{Store: store, Tenant: "bob"})
+	assert.Equal(t, "alice", alice.Tenant())
+
+	events := alice.Update(Order{OrderID: "1", Symbol: "BTCUSDT", Status: StatusLive})
+	require.Len(t, events, 1)
+	assert.Equal(t, "alice", events[0].Tenant)
+	bob.Update(Order{OrderID: "1", Symbol: "ETHUSDT", Status: StatusLive})
+
+	restored := NewOrderTracker(Options{Store: store, Tenant: "alice"})
+	require.NoError(t, restored.Restore(ctx))
+	o, ok := restored.Get("1")
+	require.True(t, ok)
+	assert.Equal(t, "BTCUSDT", o.Symbol, "the same order ID of another tenant does not collide")
+	keys, err := state.Keys(ctx, store, "orders")
+	require.NoError(t, err)
+	assert.Empty(t, keys, "nothing is stored outside the tenant namespaces")
+
+	positions := NewPositionTracker(Options{Store: store, Tenant: "bob"})
+	pe := positions.Update(Position{Symbol: "BTCUSDT", HoldSide: "long", Size: 1})
+	require.Len(t, pe, 1)
+	assert.Equal(t, "bob", pe[0].Tenant)
+	keys, err = state.Keys(ctx, store, state.TenantNamespace("bob", "positions"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BTCUSDT:long"}, keys)
+}
+
+func positionMessage(action string, positions ...string) string {
+	msg := `{"action":"` + action + `","arg":{"instType":"USDT-FUTURES","channel":"positions","instId":"default"},"data":[`
+	for i, p := range positions {
+		if i > 0 {
+			msg += ","
+		}
+		msg += p
+	}
+	return msg + "]}"
+}
+
+func TestPositionTracker_Events(t *testing.T) {
+	tr := NewPositionTracker(Options{})
+	events := tr.Events()
+
+	long := func(total, upl string) string {
+		return `{"instId":"BTCUSDT","holdSide":"long","total":"` + total + `","available":"` + total +
+			`","openPriceAvg":"60000","leverage":"10","unrealizedPL":"` + upl + `","cTime":"1700000000000"}`
+	}
+	short := `{"instId":"ETHUSDT","holdSide":"short","total":"1","openPriceAvg":"3000","leverage":"5"}`
+
+	tr.HandleMessage(positionMessage("snapshot", long("0.01", "0"), short))
+	tr.HandleMessage(positionMessage("snapshot", long("0.02", "0"), short))
+	tr.HandleMessage(positionMessage("snapshot", long("0.02", "5"), short))
+	tr.HandleMessage(positionMessage("snapshot", long("0.01", "5"), short))
+	tr.HandleMessage(positionMessage("snapshot", long("0.01", "5")))
+
+	p, ok := tr.Get("BTCUSDT", "long")
+	require.True(t, ok)
+	assert.Equal(t, 0.01, p.Size)
+	assert.Len(t, tr.Open(""), 1)
+
+	var got []string
+	for i := 0; i < 6; i++ {
+		ev := <-events
+		got = append(got, string(ev.Type)+" "+ev.Position.Key())
+	}
+	assert.Equal(t, []string{
+		"opened BTCUSDT:long", "opened ETHUSDT:short",
+		"increased BTCUSDT:long", "updated BTCUSDT:long", "reduced BTCUSDT:long",
+		"closed ETHUSDT:short",
+	}, got)
+
+	tr.Update(Position{Symbol: "BTCUSDT", HoldSide: "long"})
+	ev := <-events
+	assert.Equal(t, PositionClosed, ev.Type)
+	require.NotNil(t, ev.Previous)
+	assert.Equal(t, 0.01, ev.Previous.Size)
+
+	require.NoError(t, tr.Shutdown(context.Background()))
+	_, ok = <-events
+	assert.False(t, ok)
+}
+
+func TestPositionTracker_Sync(t *testing.T) {
+	store := state.NewMemoryStore()
+	tr := NewPositionTracker(Options{Store: store})
+	tr.Update(Position{Symbol: "BTCUSDT", HoldSide: "long", Size: 1})
+
+	require.NoError(t, tr.Sync(context.Background(), fakeClient{}, futures.ProductTypeUSDTFutures))
+	open := tr.Open("")
+	require.Len(t, open, 1)
+	assert.Equal(t, "ETHUSDT:short", open[0].Key())
+	assert.Equal(t, 3000.0, open[0].AvgPrice)
+
+	keys, err := state.Keys(context.Background(), store, "positions")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ETHUSDT:short"}, keys)
+}
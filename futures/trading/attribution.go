@@ -0,0 +1,145 @@
+package trading
+
+import (
+	"sort"
+	"strconv"
+)
+
+// TagStats sums the fills of one tag.
+type TagStats struct {
+	Tag      Tag
+	Fills    int
+	Orders   int
+	Volume   float64 // Filled size
+	Notional float64 // Filled amount in the quote coin
+	// Fees are summed as reported by the exchange: negative for fees paid.
+	Fees        float64
+	RealizedPnL float64
+	// NetPnL is RealizedPnL + Fees.
+	NetPnL  float64
+	Symbols []string
+}
+
+// Attribution splits fills per tag. Fills of orders without a tag are collected in
+// Untagged.
+type Attribution struct {
+	Tags     []TagStats // Sorted by strategy, then session
+	Untagged TagStats
+}
+
+// Get returns the stats of tag.
+func (a Attribution) Get(tag Tag) (TagStats, bool) {
+	for _, s := range a.Tags {
+		if s.Tag == tag {
+			return s, true
+		}
+	}
+	return TagStats{}, false
+}
+
+// ByStrategy merges the sessions of each strategy.
+func (a Attribution) ByStrategy() Attribution {
+	merged := make(map[string]*TagStats)
+	var order []string
+	for _, s := range a.Tags {
+		m, ok := merged[s.Tag.Strategy]
+		if !ok {
+			m = &TagStats{Tag: Tag{Strategy: s.Tag.Strategy}}
+			merged[s.Tag.Strategy] = m
+			order = append(order, s.Tag.Strategy)
+		}
+		m.Fills += s.Fills
+		m.Orders += s.Orders
+		m.Volume += s.Volume
+		m.Notional += s.Notional
+		m.Fees += s.Fees
+		m.RealizedPnL += s.RealizedPnL
+		m.NetPnL += s.NetPnL
+		m.Symbols = mergeSymbols(m.Symbols, s.Symbols)
+	}
+	out := Attribution{Untagged: a.Untagged}
+	for _, strategy := range order {
+		out.Tags = append(out.Tags, *merged[strategy])
+	}
+	return out
+}
+
+// OrderTags maps the order IDs of orders with a tagged clientOid to their tag. Fills
+// only carry the order ID, so the map connects them to the tag:
+//
+//	orders, _ := common.Collect(trading.NewOrderHistoryService(client).ProductType(pt).Iter(ctx), 0)
+//	fills, _ := common.Collect(trading.NewFillHistoryService(client).ProductType(pt).Iter(ctx), 0)
+//	report := trading.Attribute(fills, trading.OrderTags(orders))
+func OrderTags(orders []HistoricalOrder) map[string]Tag {
+	tags := make(map[string]Tag, len(orders))
+	for _, o := range orders {
+		if tag, ok := ParseTag(o.ClientOid); ok {
+			tags[o.OrderId] = tag
+		}
+	}
+	return tags
+}
+
+// Attribute sums fills, fees and realized PnL per tag. orderTags maps order IDs to
+// tags, see OrderTags.
+func Attribute(fills []FillRecord, orderTags map[string]Tag) Attribution {
+	stats := make(map[Tag]*TagStats)
+	orders := make(map[Tag]map[string]bool)
+	var untagged TagStats
+	untaggedOrders := make(map[string]bool)
+
+	for _, f := range fills {
+		s, seen := &untagged, untaggedOrders
+		if tag, ok := orderTags[f.OrderId]; ok {
+			if stats[tag] == nil {
+				stats[tag] = &TagStats{Tag: tag}
+				orders[tag] = make(map[string]bool)
+			}
+			s, seen = stats[tag], orders[tag]
+		}
+		fee := parseAmount(f.Fee)
+		pnl := parseAmount(f.Profit)
+		s.Fills++
+		s.Volume += parseAmount(f.Size)
+		s.Notional += parseAmount(f.Amount)
+		s.Fees += fee
+		s.RealizedPnL += pnl
+		s.NetPnL += pnl + fee
+		s.Symbols = mergeSymbols(s.Symbols, []string{f.Symbol})
+		if !seen[f.OrderId] {
+			seen[f.OrderId] = true
+			s.Orders++
+		}
+	}
+
+	a := Attribution{Untagged: untagged}
+	for _, s := range stats {
+		a.Tags = append(a.Tags, *s)
+	}
+	sort.Slice(a.Tags, func(i, j int) bool {
+		if a.Tags[i].Tag.Strategy != a.Tags[j].Tag.Strategy {
+			return a.Tags[i].Tag.Strategy < a.Tags[j].Tag.Strategy
+		}
+		return a.Tags[i].Tag.Session < a.Tags[j].Tag.Session
+	})
+	return a
+}
+
+// mergeSymbols adds the missing symbols of add to a sorted list.
+func mergeSymbols(symbols, add []string) []string {
+	for _, symbol := range add {
+		i := sort.SearchStrings(symbols, symbol)
+		if i < len(symbols) && symbols[i] == symbol {
+			continue
+		}
+		symbols = append(symbols, "")
+		copy(symbols[i+1:], symbols[i:])
+		symbols[i] = symbol
+	}
+	return symbols
+}
+
+func parseAmount(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
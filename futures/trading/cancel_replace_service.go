@@ -0,0 +1,309 @@
+package trading
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/khanbekov/go-bitget/common/types"
+)
+
+// ErrOrderFilled is returned by CancelReplaceService when the original order filled
+// before it could be replaced. The result still reports the filled quantity.
+var ErrOrderFilled = errors.New("order filled before it could be replaced")
+
+// ReplaceMethod reports how CancelReplaceService replaced an order.
+type ReplaceMethod string
+
+const (
+	ReplaceMethodAmend         ReplaceMethod = "amend"          // Native modify-order
+	ReplaceMethodCancelReplace ReplaceMethod = "cancel_replace" // Cancel followed by a new order
+	ReplaceMethodExisting      ReplaceMethod = "existing"       // A previous attempt already placed the replacement
+)
+
+// CancelReplaceResult describes the outcome of CancelReplaceService.Do.
+type CancelReplaceResult struct {
+	Method      ReplaceMethod
+	Original    *OrderDetail // Original order as last seen
+	Filled      string       // Quantity of the original order filled before it was replaced
+	Replacement *OrderInfo   // Replacement order; nil when nothing was left to place
+}
+
+// CancelReplaceService moves a resting order to a new price and/or size.
+//
+// It prefers the native modify-order endpoint for limit orders. When the exchange rejects
+// the amendment, the order is cancelled and a new order with the same parameters is placed
+// for the remaining quantity; fills that land while the order is being cancelled are
+// subtracted from the replacement, and ErrOrderFilled is returned when nothing is left.
+//
+// The replacement carries NewClientOid. Calling Do again with the same NewClientOid after
+// a failure is safe: an existing replacement is returned instead of placing a second one,
+// and an original that was already cancelled is simply re-placed.
+type CancelReplaceService struct {
+	c            ClientInterface
+	symbol       string
+	productType  ProductType
+	marginCoin   string
+	orderId      string
+	clientOid    string
+	newPrice     string
+	newSize      string
+	newClientOid string
+	noAmend      bool
+}
+
+// Symbol sets the trading pair (required).
+func (s *CancelReplaceService) Symbol(symbol string) *CancelReplaceService {
+	s.symbol = symbol
+	return s
+}
+
+// ProductType sets the product type (required).
+func (s *CancelReplaceService) ProductType(productType ProductType) *CancelReplaceService {
+	s.productType = productType
+	return s
+}
+
+// MarginCoin sets the margin coin (required).
+func (s *CancelReplaceService) MarginCoin(marginCoin string) *CancelReplaceService {
+	s.marginCoin = marginCoin
+	return s
+}
+
+// OrderId sets the ID of the order to replace (either orderId or clientOid required).
+func (s *CancelReplaceService) OrderId(orderId string) *CancelReplaceService {
+	s.orderId = orderId
+	return s
+}
+
+// ClientOid sets the custom ID of the order to replace (either orderId or clientOid required).
+func (s *CancelReplaceService) ClientOid(clientOid string) *CancelReplaceService {
+	s.clientOid = clientOid
+	return s
+}
+
+// NewPrice sets the new limit price. Defaults to the original price.
+func (s *CancelReplaceService) NewPrice(newPrice string) *CancelReplaceService {
+	s.newPrice = newPrice
+	return s
+}
+
+// NewSize sets the new total order size, including any quantity already filled.
+// Defaults to the original size.
+func (s *CancelReplaceService) NewSize(newSize string) *CancelReplaceService {
+	s.newSize = newSize
+	return s
+}
+
+// NewClientOid sets the custom ID of the replacement order. Set it to make retries safe;
+// a random ID is used otherwise.
+func (s *CancelReplaceService) NewClientOid(newClientOid string) *CancelReplaceService {
+	s.newClientOid = newClientOid
+	return s
+}
+
+// DisableAmend always cancels and re-places instead of trying modify-order first.
+func (s *CancelReplaceService) DisableAmend() *CancelReplaceService {
+	s.noAmend = true
+	return s
+}
+
+// checkRequiredParams validates required parameters.
+func (s *CancelReplaceService) checkRequiredParams() error {
+	if s.symbol == "" {
+		return fmt.Errorf("symbol is required")
+	}
+	if s.productType == "" {
+		return fmt.Errorf("productType is required")
+	}
+	if s.marginCoin == "" {
+		return fmt.Errorf("marginCoin is required")
+	}
+	if s.orderId == "" && s.clientOid == "" {
+		return fmt.Errorf("either orderId or clientOid must be provided")
+	}
+	if s.newPrice == "" && s.newSize == "" {
+		return fmt.Errorf("at least one of newPrice or newSize is required")
+	}
+	return nil
+}
+
+// Do replaces the order.
+func (s *CancelReplaceService) Do(ctx context.Context) (*CancelReplaceResult, error) {
+	if err := s.checkRequiredParams(); err != nil {
+		return nil, err
+	}
+	newClientOid := s.newClientOid
+	if newClientOid == "" {
+		newClientOid = strings.ReplaceAll(uuid.NewString(), "-", "")
+	}
+
+	// A previous attempt may already have placed the replacement
+	if s.newClientOid != "" {
+		existing, err := s.details(ctx, "", newClientOid)
+		if err == nil && existing.OrderId != "" {
+			return &CancelReplaceResult{
+				Method:      ReplaceMethodExisting,
+				Replacement: &OrderInfo{OrderId: existing.OrderId, ClientOrderId: existing.ClientOid},
+			}, nil
+		}
+		if err != nil && !types.IsAPIError(err) {
+			return nil, err
+		}
+	}
+
+	original, err := s.details(ctx, s.orderId, s.clientOid)
+	if err != nil {
+		return nil, err
+	}
+	result := &CancelReplaceResult{Original: original, Filled: original.BaseVolume}
+	price := firstNonEmpty(s.newPrice, original.Price)
+	size := firstNonEmpty(s.newSize, original.Size)
+
+	switch original.State {
+	case OrderStateFilled:
+		return result, ErrOrderFilled
+	case OrderStateCanceled:
+		// Cancelled by an earlier attempt (or externally): only the placement is left
+	default:
+		if !s.noAmend && original.OrderType == string(OrderTypeLimit) {
+			info, err := NewModifyOrderService(s.c).
+				Symbol(s.symbol).
+				ProductType(s.productType).
+				MarginCoin(s.marginCoin).
+				OrderId(original.OrderId).
+				NewClientOrderId(newClientOid).
+				NewPrice(price).
+				NewSize(size).
+				Do(ctx)
+			if err == nil {
+				result.Method = ReplaceMethodAmend
+				result.Replacement = info
+				return result, nil
+			}
+			// Only a definite rejection is safe to fall back from; on a transport error
+			// the amendment may have been applied
+			if !types.IsAPIError(err) {
+				return nil, err
+			}
+		}
+
+		if _, err := NewCancelOrderService(s.c).
+			Symbol(s.symbol).
+			ProductType(s.productType).
+			MarginCoin(s.marginCoin).
+			OrderId(original.OrderId).
+			Do(ctx); err != nil {
+			// The cancel is rejected when the order filled in the meantime
+			latest, detailErr := s.details(ctx, original.OrderId, "")
+			if detailErr != nil {
+				return nil, err
+			}
+			result.Original, result.Filled = latest, latest.BaseVolume
+			if latest.State == OrderStateFilled {
+				return result, ErrOrderFilled
+			}
+			if latest.State != OrderStateCanceled {
+				return nil, err
+			}
+		}
+
+		// Re-read the order so that fills which landed before the cancel are counted
+		latest, err := s.details(ctx, original.OrderId, "")
+		if err != nil {
+			return nil, err
+		}
+		result.Original, result.Filled = latest, latest.BaseVolume
+	}
+
+	remaining := subtractDecimal(size, result.Filled)
+	if v, _ := strconv.ParseFloat(remaining, 64); v <= 0 {
+		return result, ErrOrderFilled
+	}
+
+	info, err := s.replacement(result.Original, price, remaining, newClientOid).Do(ctx)
+	if err != nil {
+		return result, fmt.Errorf("order %s cancelled but replacement failed: %w", result.Original.OrderId, err)
+	}
+	result.Method = ReplaceMethodCancelReplace
+	result.Replacement = info
+	return result, nil
+}
+
+// details fetches an order by ID or custom ID.
+func (s *CancelReplaceService) details(ctx context.Context, orderId, clientOid string) (*OrderDetail, error) {
+	return NewGetOrderDetailsService(s.c).
+		Symbol(s.symbol).
+		ProductType(s.productType).
+		OrderId(orderId).
+		ClientOid(clientOid).
+		Do(ctx)
+}
+
+// replacement builds an order with the parameters of the original.
+func (s *CancelReplaceService) replacement(original *OrderDetail, price, size, clientOid string) *CreateOrderService {
+	order := NewCreateOrderService(s.c).
+		Symbol(s.symbol).
+		ProductType(s.productType).
+		MarginCoin(s.marginCoin).
+		MarginMode(MarginMode(original.MarginMode)).
+		SideType(SideType(original.Side)).
+		OrderType(OrderType(original.OrderType)).
+		Size(size).
+		ClientOrderId(clientOid)
+	if original.OrderType == string(OrderTypeLimit) {
+		order.Price(price)
+	}
+	switch force := strings.ToLower(original.Force); force {
+	case "gtc", "ioc", "fok":
+		order.TimeInForce(TimeInForce(strings.ToUpper(force)))
+	case string(TimeInForcePostOnly):
+		order.TimeInForce(TimeInForcePostOnly)
+	}
+	closing := false
+	switch PositionSideType(original.TradeSide) {
+	case PositionSideOpen, PositionSideClose:
+		order.PositionSideType(PositionSideType(original.TradeSide))
+		closing = original.TradeSide == string(PositionSideClose)
+	default:
+		if original.ReduceOnly == string(ReduceOnlyTrue) {
+			order.ReduceOnlyType(ReduceOnlyTrue)
+			closing = true
+		}
+	}
+	if !closing {
+		order.PresetStopSurplusPrice(original.PresetStopSurplusPrice)
+		order.PresetStopLossPrice(original.PresetStopLossPrice)
+	}
+	if original.StpMode != "" {
+		order.StpMode(SelfTradePreventionType(original.StpMode))
+	}
+	return order
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// subtractDecimal returns a - b formatted with the larger number of decimals of the two,
+// avoiding float artefacts such as 0.30000000000000004.
+func subtractDecimal(a, b string) string {
+	x, _ := strconv.ParseFloat(a, 64)
+	y, _ := strconv.ParseFloat(b, 64)
+	places := 0
+	for _, s := range []string{a, b} {
+		if i := strings.IndexByte(s, '.'); i >= 0 && len(s)-i-1 > places {
+			places = len(s) - i - 1
+		}
+	}
+	return strconv.FormatFloat(x-y, 'f', places, 64)
+}
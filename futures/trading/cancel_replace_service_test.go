@@ -0,0 +1,152 @@
+package trading
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/khanbekov/go-bitget/common/types"
+)
+
+var rejected = &types.APIError{Code: 40109, Message: "rejected"}
+
+func detailQuery(key, value string) interface{} {
+	return mock.MatchedBy(func(v url.Values) bool { return v.Get(key) == value })
+}
+
+func bodyWith(key, value string) interface{} {
+	return mock.MatchedBy(func(b []byte) bool {
+		var m map[string]string
+		return jsoniter.Unmarshal(b, &m) == nil && m[key] == value
+	})
+}
+
+func okResponse(data string) *ApiResponse {
+	return &ApiResponse{Code: "00000", Data: []byte(data)}
+}
+
+func newCancelReplace(c ClientInterface) *CancelReplaceService {
+	return NewCancelReplaceService(c).
+		Symbol("BTCUSDT").
+		ProductType(ProductTypeUSDTFutures).
+		MarginCoin("USDT").
+		OrderId("1").
+		NewClientOid("r-1")
+}
+
+const liveOrder = `{"orderId":"1","symbol":"BTCUSDT","size":"0.01","baseVolume":"0","price":"50000","state":"live",
+	"side":"buy","orderType":"limit","force":"gtc","marginMode":"crossed","tradeSide":"open","posMode":"hedge_mode"}`
+
+func TestCancelReplaceService_Validation(t *testing.T) {
+	_, err := NewCancelReplaceService(&MockClient{}).Symbol("BTCUSDT").ProductType(ProductTypeUSDTFutures).
+		MarginCoin("USDT").NewPrice("1").Do(context.Background())
+	assert.EqualError(t, err, "either orderId or clientOid must be provided")
+
+	_, err = NewCancelReplaceService(&MockClient{}).Symbol("BTCUSDT").ProductType(ProductTypeUSDTFutures).
+		MarginCoin("USDT").OrderId("1").Do(context.Background())
+	assert.EqualError(t, err, "at least one of newPrice or newSize is required")
+}
+
+func TestCancelReplaceService_Amend(t *testing.T) {
+	m := &MockClient{}
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, detailQuery("clientOid", "r-1"), []byte(nil), true).
+		Return(nil, &fasthttp.ResponseHeader{}, rejected)
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, detailQuery("orderId", "1"), []byte(nil), true).
+		Return(okResponse(liveOrder), &fasthttp.ResponseHeader{}, nil)
+	m.On("CallAPI", mock.Anything, "POST", EndpointModifyOrder, url.Values(nil), bodyWith("newPrice", "49000"), true).
+		Return(okResponse(`{"orderId":"2","clientOId":"r-1"}`), &fasthttp.ResponseHeader{}, nil)
+
+	res, err := newCancelReplace(m).NewPrice("49000").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, ReplaceMethodAmend, res.Method)
+	assert.Equal(t, "2", res.Replacement.OrderId)
+	m.AssertExpectations(t)
+	m.AssertNotCalled(t, "CallAPI", mock.Anything, "POST", EndpointCancelOrder, mock.Anything, mock.Anything, true)
+}
+
+func TestCancelReplaceService_FallbackSubtractsFills(t *testing.T) {
+	m := &MockClient{}
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, detailQuery("clientOid", "r-1"), []byte(nil), true).
+		Return(nil, &fasthttp.ResponseHeader{}, rejected)
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, detailQuery("orderId", "1"), []byte(nil), true).
+		Return(okResponse(liveOrder), &fasthttp.ResponseHeader{}, nil).Once()
+	m.On("CallAPI", mock.Anything, "POST", EndpointModifyOrder, url.Values(nil), mock.Anything, true).
+		Return(nil, &fasthttp.ResponseHeader{}, rejected)
+	m.On("CallAPI", mock.Anything, "POST", EndpointCancelOrder, url.Values(nil), bodyWith("orderId", "1"), true).
+		Return(okResponse(`{"orderId":"1"}`), &fasthttp.ResponseHeader{}, nil)
+	// 0.003 filled while the cancel was in flight
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, detailQuery("orderId", "1"), []byte(nil), true).
+		Return(okResponse(`{"orderId":"1","size":"0.01","baseVolume":"0.003","price":"50000","state":"canceled",
+			"side":"buy","orderType":"limit","force":"post_only","marginMode":"crossed","tradeSide":"open"}`), &fasthttp.ResponseHeader{}, nil).Once()
+	m.On("CallAPI", mock.Anything, "POST", EndpointPlaceOrder, url.Values(nil), mock.MatchedBy(func(b []byte) bool {
+		var body map[string]string
+		return jsoniter.Unmarshal(b, &body) == nil && body["size"] == "0.007" && body["price"] == "49000" &&
+			body["clientOid"] == "r-1" && body["force"] == "post_only" && body["tradeSide"] == "open"
+	}), true).
+		Return(okResponse(`{"orderId":"3","clientOId":"r-1"}`), &fasthttp.ResponseHeader{}, nil)
+
+	res, err := newCancelReplace(m).NewPrice("49000").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, ReplaceMethodCancelReplace, res.Method)
+	assert.Equal(t, "0.003", res.Filled)
+	assert.Equal(t, "3", res.Replacement.OrderId)
+	m.AssertExpectations(t)
+}
+
+func TestCancelReplaceService_FilledDuringCancel(t *testing.T) {
+	m := &MockClient{}
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, detailQuery("clientOid", "r-1"), []byte(nil), true).
+		Return(nil, &fasthttp.ResponseHeader{}, rejected)
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, detailQuery("orderId", "1"), []byte(nil), true).
+		Return(okResponse(liveOrder), &fasthttp.ResponseHeader{}, nil).Once()
+	m.On("CallAPI", mock.Anything, "POST", EndpointCancelOrder, url.Values(nil), mock.Anything, true).
+		Return(nil, &fasthttp.ResponseHeader{}, rejected)
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, detailQuery("orderId", "1"), []byte(nil), true).
+		Return(okResponse(`{"orderId":"1","size":"0.01","baseVolume":"0.01","state":"filled"}`), &fasthttp.ResponseHeader{}, nil).Once()
+
+	res, err := newCancelReplace(m).DisableAmend().NewPrice("49000").Do(context.Background())
+	assert.ErrorIs(t, err, ErrOrderFilled)
+	require.NotNil(t, res)
+	assert.Equal(t, "0.01", res.Filled)
+	assert.Nil(t, res.Replacement)
+	m.AssertNotCalled(t, "CallAPI", mock.Anything, "POST", EndpointPlaceOrder, mock.Anything, mock.Anything, true)
+}
+
+func TestCancelReplaceService_RetryReturnsExistingReplacement(t *testing.T) {
+	m := &MockClient{}
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, detailQuery("clientOid", "r-1"), []byte(nil), true).
+		Return(okResponse(`{"orderId":"3","clientOid":"r-1","state":"live"}`), &fasthttp.ResponseHeader{}, nil)
+
+	res, err := newCancelReplace(m).NewPrice("49000").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, ReplaceMethodExisting, res.Method)
+	assert.Equal(t, "3", res.Replacement.OrderId)
+	m.AssertNumberOfCalls(t, "CallAPI", 1)
+}
+
+func TestCancelReplaceService_TransportErrorDuringAmend(t *testing.T) {
+	m := &MockClient{}
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, detailQuery("clientOid", "r-1"), []byte(nil), true).
+		Return(nil, &fasthttp.ResponseHeader{}, rejected)
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, detailQuery("orderId", "1"), []byte(nil), true).
+		Return(okResponse(liveOrder), &fasthttp.ResponseHeader{}, nil)
+	m.On("CallAPI", mock.Anything, "POST", EndpointModifyOrder, url.Values(nil), mock.Anything, true).
+		Return(nil, &fasthttp.ResponseHeader{}, errors.New("timeout"))
+
+	_, err := newCancelReplace(m).NewPrice("49000").Do(context.Background())
+	assert.EqualError(t, err, "timeout")
+	m.AssertNotCalled(t, "CallAPI", mock.Anything, "POST", EndpointCancelOrder, mock.Anything, mock.Anything, true)
+}
+
+func TestSubtractDecimal(t *testing.T) {
+	assert.Equal(t, "0.2", subtractDecimal("0.3", "0.1"))
+	assert.Equal(t, "0.007", subtractDecimal("0.01", "0.003"))
+	assert.Equal(t, "5", subtractDecimal("5", "0"))
+}
@@ -0,0 +1,253 @@
+package trading
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures/market"
+)
+
+// ErrChaseExhausted is returned by ChaseOrderService when the order is still open after
+// the last re-price. The order is left resting at its last price.
+var ErrChaseExhausted = errors.New("order not filled within the allowed re-prices")
+
+// SlippagePrice returns the limit price that bounds an order on side at maxSlippage (a
+// fraction, e.g. 0.005) from price: below it for sells, above it for buys. The result
+// keeps the decimals of price and is rounded toward it, so the bound is never exceeded.
+func SlippagePrice(price string, side Side, maxSlippage float64) (string, error) {
+	p, err := strconv.ParseFloat(price, 64)
+	if err != nil || p <= 0 {
+		return "", fmt.Errorf("invalid price %q", price)
+	}
+	if maxSlippage < 0 || maxSlippage >= 1 {
+		return "", fmt.Errorf("max slippage %v is outside [0, 1)", maxSlippage)
+	}
+	places := 0
+	if i := strings.IndexByte(price, '.'); i >= 0 {
+		places = len(price) - i - 1
+	}
+	scale := math.Pow10(places)
+	var bound float64
+	if strings.EqualFold(string(side), string(SideSell)) {
+		bound = math.Ceil(p*(1-maxSlippage)*scale-1e-6) / scale
+	} else {
+		bound = math.Floor(p*(1+maxSlippage)*scale+1e-6) / scale
+	}
+	return strconv.FormatFloat(bound, 'f', places, 64), nil
+}
+
+// ChaseResult describes the outcome of ChaseOrderService.Do.
+type ChaseResult struct {
+	OrderId  string      // Latest order; a re-price replaces the order
+	State    OrderStatus // State of the latest order
+	Price    string      // Price of the latest order
+	Filled   string      // Quantity filled over all the orders
+	Reprices int
+}
+
+// ChaseOrderService follows an unfilled limit order, typically one placed by a stop
+// with a slippage bound, to the best opposite price until it fills: every Interval the
+// order is re-priced with CancelReplaceService to the best bid (sells) or ask (buys),
+// but never beyond LimitPrice. After MaxReprices re-prices the order is left resting and
+// ErrChaseExhausted is returned, so a thin book is not chased to any price.
+type ChaseOrderService struct {
+	c           ClientInterface
+	symbol      string
+	productType ProductType
+	marginCoin  string
+	orderId     string
+	side        Side
+	limitPrice  string
+	maxReprices int
+	interval    time.Duration
+	sleep       func(ctx context.Context, d time.Duration) error
+}
+
+// Symbol sets the trading pair (required).
+func (s *ChaseOrderService) Symbol(symbol string) *ChaseOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// ProductType sets the product type (required).
+func (s *ChaseOrderService) ProductType(productType ProductType) *ChaseOrderService {
+	s.productType = productType
+	return s
+}
+
+// MarginCoin sets the margin coin (required).
+func (s *ChaseOrderService) MarginCoin(marginCoin string) *ChaseOrderService {
+	s.marginCoin = marginCoin
+	return s
+}
+
+// OrderId sets the ID of the order to chase (required).
+func (s *ChaseOrderService) OrderId(orderId string) *ChaseOrderService {
+	s.orderId = orderId
+	return s
+}
+
+// Side sets the direction the order trades in (required). In hedge mode this is the
+// direction of the fill, e.g. SideSell for an order closing a long.
+func (s *ChaseOrderService) Side(side Side) *ChaseOrderService {
+	s.side = side
+	return s
+}
+
+// LimitPrice sets the worst price the order may be moved to (required), usually from
+// SlippagePrice.
+func (s *ChaseOrderService) LimitPrice(limitPrice string) *ChaseOrderService {
+	s.limitPrice = limitPrice
+	return s
+}
+
+// MaxReprices sets the number of re-prices. Defaults to 3.
+func (s *ChaseOrderService) MaxReprices(maxReprices int) *ChaseOrderService {
+	s.maxReprices = maxReprices
+	return s
+}
+
+// Interval sets the time the order is given to fill before each re-price. Defaults to
+// one second.
+func (s *ChaseOrderService) Interval(interval time.Duration) *ChaseOrderService {
+	s.interval = interval
+	return s
+}
+
+// checkRequiredParams validates required parameters.
+func (s *ChaseOrderService) checkRequiredParams() error {
+	if s.symbol == "" {
+		return fmt.Errorf("symbol is required")
+	}
+	if s.productType == "" {
+		return fmt.Errorf("productType is required")
+	}
+	if s.marginCoin == "" {
+		return fmt.Errorf("marginCoin is required")
+	}
+	if s.orderId == "" {
+		return fmt.Errorf("orderId is required")
+	}
+	if s.side == "" {
+		return fmt.Errorf("side is required")
+	}
+	if v, err := strconv.ParseFloat(s.limitPrice, 64); err != nil || v <= 0 {
+		return fmt.Errorf("limitPrice is required")
+	}
+	return nil
+}
+
+// Do chases the order until it fills, is canceled or the re-prices run out.
+func (s *ChaseOrderService) Do(ctx context.Context) (*ChaseResult, error) {
+	if err := s.checkRequiredParams(); err != nil {
+		return nil, err
+	}
+	maxReprices, interval, sleep := s.maxReprices, s.interval, s.sleep
+	if maxReprices <= 0 {
+		maxReprices = 3
+	}
+	if interval <= 0 {
+		interval = time.Second
+	}
+	if sleep == nil {
+		sleep = sleepContext
+	}
+	sells := strings.EqualFold(string(s.side), string(SideSell))
+	limit, _ := strconv.ParseFloat(s.limitPrice, 64)
+
+	result := &ChaseResult{OrderId: s.orderId}
+	carried := "0" // Filled by orders that were cancelled and replaced
+	for attempt := 0; ; attempt++ {
+		if err := sleep(ctx, interval); err != nil {
+			return result, err
+		}
+		order, err := NewGetOrderDetailsService(s.c).
+			Symbol(s.symbol).
+			ProductType(s.productType).
+			OrderId(result.OrderId).
+			Do(ctx)
+		if err != nil {
+			return result, err
+		}
+		result.State, result.Price = order.State, order.Price
+		result.Filled = addDecimal(carried, firstNonEmpty(order.BaseVolume, "0"))
+		switch order.State {
+		case OrderStateFilled:
+			return result, nil
+		case OrderStateCanceled:
+			return result, fmt.Errorf("order %s was canceled", result.OrderId)
+		}
+		if attempt >= maxReprices {
+			return result, ErrChaseExhausted
+		}
+
+		ticker, err := market.NewTickerService(s.c).Symbol(s.symbol).ProductType(string(s.productType)).Do(ctx)
+		if err != nil {
+			return result, err
+		}
+		price := ticker.AskPr
+		if sells {
+			price = ticker.BidPr
+		}
+		best, err := strconv.ParseFloat(price, 64)
+		if err != nil || best <= 0 {
+			return result, fmt.Errorf("no quote for %s", s.symbol)
+		}
+		if (sells && best < limit) || (!sells && best > limit) {
+			price = s.limitPrice
+		}
+		current, _ := strconv.ParseFloat(order.Price, 64)
+		if next, _ := strconv.ParseFloat(price, 64); current == next {
+			continue // Already at the best price the bound allows
+		}
+
+		replaced, err := NewCancelReplaceService(s.c).
+			Symbol(s.symbol).
+			ProductType(s.productType).
+			MarginCoin(s.marginCoin).
+			OrderId(result.OrderId).
+			NewPrice(price).
+			Do(ctx)
+		if errors.Is(err, ErrOrderFilled) {
+			result.State, result.Filled = OrderStateFilled, addDecimal(carried, replaced.Filled)
+			return result, nil
+		}
+		if err != nil {
+			return result, err
+		}
+		if replaced.Method == ReplaceMethodCancelReplace {
+			carried = addDecimal(carried, replaced.Filled)
+		}
+		result.OrderId, result.Price = replaced.Replacement.OrderId, price
+		result.Reprices++
+	}
+}
+
+// addDecimal returns a + b formatted with the larger number of decimals of the two.
+func addDecimal(a, b string) string {
+	x, _ := strconv.ParseFloat(a, 64)
+	y, _ := strconv.ParseFloat(b, 64)
+	places := 0
+	for _, s := range []string{a, b} {
+		if i := strings.IndexByte(s, '.'); i >= 0 && len(s)-i-1 > places {
+			places = len(s) - i - 1
+		}
+	}
+	return strconv.FormatFloat(x+y, 'f', places, 64)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
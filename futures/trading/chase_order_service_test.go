@@ -0,0 +1,137 @@
+package trading
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/khanbekov/go-bitget/futures"
+)
+
+func TestSlippagePrice(t *testing.T) {
+	price, err := SlippagePrice("50000.0", SideSell, 0.005)
+	require.NoError(t, err)
+	assert.Equal(t, "49750.0", price)
+
+	price, err = SlippagePrice("3000.15", SideBuy, 0.001)
+	require.NoError(t, err)
+	assert.Equal(t, "3003.15", price, "rounded down to stay within the bound")
+
+	price, err = SlippagePrice("0.1234", SideSell, 0.01)
+	require.NoError(t, err)
+	assert.Equal(t, "0.1222", price, "rounded up to stay within the bound")
+
+	_, err = SlippagePrice("", SideSell, 0.01)
+	assert.EqualError(t, err, `invalid price ""`)
+	_, err = SlippagePrice("100", SideSell, 1)
+	assert.EqualError(t, err, "max slippage 1 is outside [0, 1)")
+}
+
+func TestCreateOrderService_PresetSlippage(t *testing.T) {
+	s := NewCreateOrderService(nil).
+		ProductType(ProductTypeUSDTFutures).
+		Symbol("BTCUSDT").
+		MarginMode(MarginModeCrossed).
+		MarginCoin("USDT").
+		SideType(SideBuy).
+		OrderType(OrderTypeLimit).
+		Size("0.01").
+		Price("50000").
+		TakeProfit("55000", "").
+		StopLoss("48000", "").
+		PresetSlippage(0.01)
+	require.NoError(t, s.checkRequiredParams())
+
+	body := s.createOrderRequrestBody()
+	assert.Equal(t, "54450", body["presetStopSurplusExecutePrice"], "a long is closed by selling")
+	assert.Equal(t, "47520", body["presetStopLossExecutePrice"])
+
+	body = s.StopLoss("48000", "47900").createOrderRequrestBody()
+	assert.Equal(t, "47900", body["presetStopLossExecutePrice"], "an explicit execute price is kept")
+
+	assert.EqualError(t, s.PresetSlippage(-0.1).checkRequiredParams(), "preset slippage -0.1 is outside [0, 1)")
+}
+
+func TestCreatePlanOrderService_MaxSlippage(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("CallAPI", mock.Anything, "POST", EndpointCreatePlanOrder, mock.Anything, mock.MatchedBy(func(body []byte) bool {
+		var m map[string]interface{}
+		return json.Unmarshal(body, &m) == nil && m["orderType"] == "limit" && m["price"] == "49500"
+	}), true).Return(okResponse(`{"orderId":"1"}`), &fasthttp.ResponseHeader{}, nil)
+
+	_, err := NewCreatePlanOrderService(mockClient).
+		Symbol("BTCUSDT").
+		ProductType(ProductTypeUSDTFutures).
+		PlanType(PlanTypeNormalPlan).
+		TriggerPrice("50000").
+		Side(SideSell).
+		OrderType(OrderTypeMarket).
+		Size("0.01").
+		MaxSlippage(0.01).
+		Do(context.Background())
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func newChase(c ClientInterface) *ChaseOrderService {
+	s := NewChaseOrderService(c).
+		Symbol("BTCUSDT").
+		ProductType(ProductTypeUSDTFutures).
+		MarginCoin("USDT").
+		OrderId("1").
+		Side(SideSell).
+		LimitPrice("49500")
+	s.sleep = func(context.Context, time.Duration) error { return nil }
+	return s
+}
+
+const liveSell = `{"orderId":"1","symbol":"BTCUSDT","size":"0.01","baseVolume":"0","price":"50000","state":"live",
+	"side":"sell","orderType":"limit","force":"gtc","marginMode":"crossed","tradeSide":"close","posMode":"one_way_mode"}`
+
+func TestChaseOrderService_RepricesToBid(t *testing.T) {
+	m := &MockClient{}
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, detailQuery("orderId", "1"), []byte(nil), true).
+		Return(okResponse(liveSell), &fasthttp.ResponseHeader{}, nil)
+	m.On("CallAPI", mock.Anything, "GET", futures.EndpointTicker, mock.Anything, []byte(nil), false).
+		Return(okResponse(`[{"symbol":"BTCUSDT","bidPr":"49900","askPr":"49910"}]`), &fasthttp.ResponseHeader{}, nil)
+	m.On("CallAPI", mock.Anything, "POST", EndpointModifyOrder, url.Values(nil), bodyWith("newPrice", "49900"), true).
+		Return(okResponse(`{"orderId":"2"}`), &fasthttp.ResponseHeader{}, nil)
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, detailQuery("orderId", "2"), []byte(nil), true).
+		Return(okResponse(`{"orderId":"2","size":"0.01","baseVolume":"0.01","price":"49900","state":"filled"}`), &fasthttp.ResponseHeader{}, nil)
+
+	res, err := newChase(m).Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "2", res.OrderId)
+	assert.Equal(t, OrderStateFilled, res.State)
+	assert.Equal(t, "0.01", res.Filled)
+	assert.Equal(t, 1, res.Reprices)
+	m.AssertExpectations(t)
+}
+
+func TestChaseOrderService_StopsAtLimit(t *testing.T) {
+	m := &MockClient{}
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, detailQuery("orderId", "1"), []byte(nil), true).
+		Return(okResponse(liveSell), &fasthttp.ResponseHeader{}, nil)
+	m.On("CallAPI", mock.Anything, "GET", futures.EndpointTicker, mock.Anything, []byte(nil), false).
+		Return(okResponse(`[{"symbol":"BTCUSDT","bidPr":"49000","askPr":"49010"}]`), &fasthttp.ResponseHeader{}, nil)
+	m.On("CallAPI", mock.Anything, "POST", EndpointModifyOrder, url.Values(nil), bodyWith("newPrice", "49500"), true).
+		Return(okResponse(`{"orderId":"1"}`), &fasthttp.ResponseHeader{}, nil)
+
+	res, err := newChase(m).MaxReprices(1).Do(context.Background())
+	assert.ErrorIs(t, err, ErrChaseExhausted)
+	require.NotNil(t, res)
+	assert.Equal(t, 1, res.Reprices)
+	m.AssertNumberOfCalls(t, "CallAPI", 5)
+}
+
+func TestChaseOrderService_Validation(t *testing.T) {
+	_, err := newChase(&MockClient{}).LimitPrice("").Do(context.Background())
+	assert.EqualError(t, err, "limitPrice is required")
+}
@@ -0,0 +1,113 @@
+package trading
+
+import "slices"
+
+// Clone returns an independent copy of s and its parameters.
+func (s *BatchCancelOrdersService) Clone() *BatchCancelOrdersService {
+	c := *s
+	c.orderIdList = slices.Clone(s.orderIdList)
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CancelAllOrdersService) Clone() *CancelAllOrdersService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CancelOrderService) Clone() *CancelOrderService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CancelPlanOrderService) Clone() *CancelPlanOrderService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CancelReplaceService) Clone() *CancelReplaceService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *ChaseOrderService) Clone() *ChaseOrderService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CreateBatchOrdersService) Clone() *CreateBatchOrdersService {
+	c := *s
+	c.orders = slices.Clone(s.orders)
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CreateOrderService) Clone() *CreateOrderService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CreatePlanOrderService) Clone() *CreatePlanOrderService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *FillHistoryService) Clone() *FillHistoryService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetOrderDetailsService) Clone() *GetOrderDetailsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *ModifyOrderService) Clone() *ModifyOrderService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *ModifyPlanOrderService) Clone() *ModifyPlanOrderService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *ModifyTPSLOrderService) Clone() *ModifyTPSLOrderService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *OrderHistoryService) Clone() *OrderHistoryService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *PendingOrdersService) Clone() *PendingOrdersService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *PendingPlanOrdersService) Clone() *PendingPlanOrdersService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *PlaceTPSLOrderService) Clone() *PlaceTPSLOrderService {
+	c := *s
+	return &c
+}
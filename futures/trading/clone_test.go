@@ -0,0 +1,89 @@
+package trading
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClone_Independent(t *testing.T) {
+	batch := NewCreateBatchOrdersService(nil).Symbol("BTCUSDT").AddOrder(BatchOrderInfo{Size: "1"})
+	clone := batch.Clone().Symbol("ETHUSDT").AddOrder(BatchOrderInfo{Size: "2"})
+	assert.Equal(t, "BTCUSDT", batch.symbol)
+	assert.Len(t, batch.orders, 1, "appending to a clone leaves the original batch alone")
+	assert.Len(t, clone.orders, 2)
+
+	history := NewOrderHistoryService(nil).Symbol("BTCUSDT")
+	assert.Equal(t, history, history.Clone())
+	assert.NotSame(t, history, history.Clone())
+}
+
+// TestClone_ConcurrentUse shares one client between goroutines that each configure
+// their own service instances. Run with -race.
+func TestClone_ConcurrentUse(t *testing.T) {
+	var mu sync.Mutex
+	sizes := map[string]bool{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data := `[]`
+		switch r.URL.Path {
+		case EndpointPlaceOrder:
+			body, _ := io.ReadAll(r.Body)
+			var order map[string]string
+			_ = json.Unmarshal(body, &order)
+			mu.Lock()
+			sizes[order["size"]] = true
+			mu.Unlock()
+			data = `{"orderId":"1","clientOid":"` + order["clientOid"] + `"}`
+		case EndpointPendingOrders:
+			data = `{"entrustedList":[],"endId":""}`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"code":"00000","msg":"success","requestTime":1,"data":` + data + `}`))
+	}))
+	defer srv.Close()
+
+	client := futures.NewClient("key", "secret", "passphrase")
+	client.BaseURL = srv.URL
+	template := NewCreateOrderService(client).
+		ProductType(ProductTypeUSDTFutures).
+		Symbol("BTCUSDT").
+		MarginMode(MarginModeCrossed).
+		MarginCoin("USDT").
+		SideType(SideBuy).
+		OrderType(OrderTypeMarket)
+
+	const workers = 16
+	var wg sync.WaitGroup
+	errs := make(chan error, 3*workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := context.Background()
+			size := strconv.Itoa(i + 1)
+			_, err := template.Clone().Size(size).ClientOrderId("c" + size).Do(ctx)
+			errs <- err
+			_, err = NewPendingOrdersService(client).ProductType(ProductTypeUSDTFutures).Symbol("BTCUSDT").Do(ctx)
+			errs <- err
+			_, err = market.NewAllTickersService(client).ProductType(futures.ProductTypeUSDTFutures).Do(ctx)
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Len(t, sizes, workers, "every goroutine sent its own parameters")
+	assert.Empty(t, template.size, "the template is not modified")
+}
@@ -36,6 +36,7 @@ type CreateBatchOrdersService struct {
 	symbol      string
 	marginMode  MarginModeType
 	marginCoin  string
+	stpMode     SelfTradePreventionType
 	orders      []BatchOrderInfo
 }
 
@@ -63,6 +64,13 @@ func (s *CreateBatchOrdersService) MarginCoin(marginCoin string) *CreateBatchOrd
 	return s
 }
 
+// StpMode sets the self-trade prevention mode for orders in the batch that do not set
+// their own SelfTradePreventionType.
+func (s *CreateBatchOrdersService) StpMode(stpMode SelfTradePreventionType) *CreateBatchOrdersService {
+	s.stpMode = stpMode
+	return s
+}
+
 // AddOrder adds a single order to the batch. Multiple calls build up the batch.
 func (s *CreateBatchOrdersService) AddOrder(order BatchOrderInfo) *CreateBatchOrdersService {
 	s.orders = append(s.orders, order)
@@ -97,6 +105,9 @@ func (s *CreateBatchOrdersService) validateOrder(order BatchOrderInfo) error {
 	if order.OrderType == "" {
 		return fmt.Errorf("orderType is required for all orders")
 	}
+	if !validSTP(order.SelfTradePreventionType) {
+		return fmt.Errorf("unsupported stpMode %q", order.SelfTradePreventionType)
+	}
 	return nil
 }
 
@@ -120,6 +131,9 @@ func (s *CreateBatchOrdersService) checkRequiredParams() error {
 	if len(s.orders) > 20 {
 		return fmt.Errorf("maximum 20 orders allowed per batch")
 	}
+	if !validSTP(s.stpMode) {
+		return fmt.Errorf("unsupported stpMode %q", s.stpMode)
+	}
 
 	// Validate each order in the batch
 	for i, order := range s.orders {
@@ -215,6 +229,8 @@ func (s *CreateBatchOrdersService) createBatchOrderRequestBody() map[string]inte
 		}
 		if order.SelfTradePreventionType != "" {
 			orderMap["stpMode"] = string(order.SelfTradePreventionType)
+		} else if s.stpMode != "" {
+			orderMap["stpMode"] = string(s.stpMode)
 		}
 
 		orderList[i] = orderMap
@@ -2,6 +2,10 @@ package trading
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	jsoniter "github.com/json-iterator/go"
 	"golang.org/x/net/context"
 )
@@ -25,7 +29,9 @@ type CreateOrderService struct {
 	presetStopLossPrice           string
 	presetStopSurplusExecutePrice string
 	presetStopLossExecutePrice    string
+	presetSlippage                float64
 	selfTradePreventionType       SelfTradePreventionType
+	deadline                      time.Duration
 }
 
 // ProductType sets type of market on bitget (USDT-FUTURES, COIN-FUTURES etc.) REQUIRED
@@ -82,12 +88,39 @@ func (s *CreateOrderService) PositionSideType(positionSideType PositionSideType)
 	return s
 }
 
+// Close turns the order into one that closes a holdSide position: it sets the side from
+// CloseSide and marks the order PositionSideType(PositionSideClose) in hedge mode or
+// ReduceOnly in one-way mode.
+func (s *CreateOrderService) Close(holdSide HoldSide, hedge bool) *CreateOrderService {
+	s.sideType = CloseSide(holdSide, hedge)
+	if hedge {
+		s.positionSideType = PositionSideClose
+	} else {
+		s.reduceOnlyType = ReduceOnlyTrue
+	}
+	return s
+}
+
 // TimeInForceType sets the time in force (GTC/IOC/etc)
 func (s *CreateOrderService) TimeInForceType(timeInForceType TimeInForceType) *CreateOrderService {
 	s.timeInForceType = timeInForceType
 	return s
 }
 
+// TimeInForce sets the time in force: TimeInForceGTC, TimeInForceIOC, TimeInForceFOK or
+// TimeInForcePostOnly.
+func (s *CreateOrderService) TimeInForce(timeInForce TimeInForce) *CreateOrderService {
+	s.timeInForceType = timeInForce
+	return s
+}
+
+// PostOnly makes the order maker-only; it is rejected instead of taking liquidity.
+// Shorthand for TimeInForce(TimeInForcePostOnly), limit orders only.
+func (s *CreateOrderService) PostOnly() *CreateOrderService {
+	s.timeInForceType = TimeInForcePostOnly
+	return s
+}
+
 // ClientOrderId sets custom order id
 func (s *CreateOrderService) ClientOrderId(clientOrderId string) *CreateOrderService {
 	s.clientOrderId = clientOrderId
@@ -100,6 +133,42 @@ func (s *CreateOrderService) ReduceOnlyType(reduceOnlyType ReduceOnlyType) *Crea
 	return s
 }
 
+// ReduceOnly marks the order as reduce-only. One-way position mode only; in hedge mode
+// close positions with PositionSideType(PositionSideClose) instead.
+func (s *CreateOrderService) ReduceOnly(reduceOnly bool) *CreateOrderService {
+	if reduceOnly {
+		s.reduceOnlyType = ReduceOnlyTrue
+	} else {
+		s.reduceOnlyType = ReduceOnlyFalse
+	}
+	return s
+}
+
+// TakeProfit sets the preset take-profit trigger price and, optionally, its limit
+// execution price (empty executes at market).
+func (s *CreateOrderService) TakeProfit(triggerPrice, executePrice string) *CreateOrderService {
+	s.presetStopSurplusPrice = triggerPrice
+	s.presetStopSurplusExecutePrice = executePrice
+	return s
+}
+
+// StopLoss sets the preset stop-loss trigger price and, optionally, its limit execution
+// price (empty executes at market).
+func (s *CreateOrderService) StopLoss(triggerPrice, executePrice string) *CreateOrderService {
+	s.presetStopLossPrice = triggerPrice
+	s.presetStopLossExecutePrice = executePrice
+	return s
+}
+
+// PresetSlippage executes the preset take-profit and stop-loss as limit orders priced
+// maxSlippage (a fraction, e.g. 0.005) beyond their trigger prices instead of at market,
+// so a trigger in a thin book cannot fill at any price. Execution prices set explicitly
+// are kept.
+func (s *CreateOrderService) PresetSlippage(maxSlippage float64) *CreateOrderService {
+	s.presetSlippage = maxSlippage
+	return s
+}
+
 // PresetStopSurplusPrice sets the preset stop surplus price
 func (s *CreateOrderService) PresetStopSurplusPrice(presetStopSurplusPrice string) *CreateOrderService {
 	s.presetStopSurplusPrice = presetStopSurplusPrice
@@ -124,12 +193,19 @@ func (s *CreateOrderService) PresetStopLossExecutePrice(presetStopLossExecutePri
 	return s
 }
 
-// StpMode sets the self-trade prevention mode
+// StpMode sets the self-trade prevention mode: STPNone, STPCancelTaker, STPCancelMaker or
+// STPCancelBoth. It decides which side is canceled when the order would match another
+// order of the same account.
 func (s *CreateOrderService) StpMode(stpMode SelfTradePreventionType) *CreateOrderService {
 	s.selfTradePreventionType = stpMode
 	return s
 }
 
+// SelfTradePrevention is an alias of StpMode.
+func (s *CreateOrderService) SelfTradePrevention(stpMode SelfTradePreventionType) *CreateOrderService {
+	return s.StpMode(stpMode)
+}
+
 func (s *CreateOrderService) checkRequiredParams() error {
 	if s.productType == "" {
 		return fmt.Errorf("productType is required")
@@ -152,6 +228,80 @@ func (s *CreateOrderService) checkRequiredParams() error {
 	if s.orderType == "" {
 		return fmt.Errorf("orderType is required")
 	}
+	return s.checkCombinations()
+}
+
+// knownTimeInForce matches tif case-insensitively against the supported values, since
+// Bitget documents them in lowercase ("gtc", "ioc", "fok"). An empty tif is accepted.
+func knownTimeInForce(tif TimeInForce) (TimeInForce, bool) {
+	if tif == "" {
+		return "", true
+	}
+	for _, known := range []TimeInForce{TimeInForceGTC, TimeInForceIOC, TimeInForceFOK, TimeInForcePostOnly} {
+		if strings.EqualFold(string(tif), string(known)) {
+			return known, true
+		}
+	}
+	return tif, false
+}
+
+// checkCombinations rejects flag combinations the API would refuse.
+func (s *CreateOrderService) checkCombinations() error {
+	force, ok := knownTimeInForce(s.timeInForceType)
+	if !ok {
+		return fmt.Errorf("unsupported timeInForce %q", s.timeInForceType)
+	}
+	if !validSTP(s.selfTradePreventionType) {
+		return fmt.Errorf("unsupported stpMode %q", s.selfTradePreventionType)
+	}
+	switch s.reduceOnlyType {
+	case "", ReduceOnlyTrue, ReduceOnlyFalse:
+	default:
+		return fmt.Errorf("unsupported reduceOnly %q", s.reduceOnlyType)
+	}
+
+	if s.orderType == OrderTypeLimit && s.price == "" {
+		return fmt.Errorf("price is required for limit orders")
+	}
+	if s.orderType == OrderTypeMarket && force == TimeInForcePostOnly {
+		return fmt.Errorf("post-only is only valid for limit orders")
+	}
+
+	reduceOnly := s.reduceOnlyType == ReduceOnlyTrue
+	if reduceOnly && s.positionSideType != "" {
+		return fmt.Errorf("reduceOnly applies to one-way mode only; use PositionSideType(close) in hedge mode")
+	}
+
+	if s.presetSlippage < 0 || s.presetSlippage >= 1 {
+		return fmt.Errorf("preset slippage %v is outside [0, 1)", s.presetSlippage)
+	}
+
+	hasTP, hasSL := s.presetStopSurplusPrice != "", s.presetStopLossPrice != ""
+	if s.presetStopSurplusExecutePrice != "" && !hasTP {
+		return fmt.Errorf("presetStopSurplusExecutePrice requires presetStopSurplusPrice")
+	}
+	if s.presetStopLossExecutePrice != "" && !hasSL {
+		return fmt.Errorf("presetStopLossExecutePrice requires presetStopLossPrice")
+	}
+	if !hasTP && !hasSL {
+		return nil
+	}
+	if reduceOnly || s.positionSideType == PositionSideClose {
+		return fmt.Errorf("preset take-profit/stop-loss cannot be attached to a closing order")
+	}
+
+	// With a limit price the presets must sit on the profitable and losing sides of it
+	price, err := strconv.ParseFloat(s.price, 64)
+	if err != nil || price <= 0 {
+		return nil
+	}
+	long := strings.EqualFold(string(s.sideType), string(SideBuy))
+	if tp, err := strconv.ParseFloat(s.presetStopSurplusPrice, 64); hasTP && err == nil && (tp > price) != long {
+		return fmt.Errorf("take-profit %s is on the wrong side of price %s for a %s order", s.presetStopSurplusPrice, s.price, strings.ToLower(string(s.sideType)))
+	}
+	if sl, err := strconv.ParseFloat(s.presetStopLossPrice, 64); hasSL && err == nil && (sl < price) != long {
+		return fmt.Errorf("stop-loss %s is on the wrong side of price %s for a %s order", s.presetStopLossPrice, s.price, strings.ToLower(string(s.sideType)))
+	}
 	return nil
 }
 
@@ -160,6 +310,9 @@ func (s *CreateOrderService) Do(ctx context.Context) (createOrderResponse *Order
 	if err = s.checkRequiredParams(); err != nil {
 		return nil, err
 	}
+	if s.deadline > 0 {
+		return s.doWithDeadline(ctx)
+	}
 
 	body := s.createOrderRequrestBody()
 
@@ -230,6 +383,21 @@ func (s *CreateOrderService) createOrderRequrestBody() map[string]string {
 	if s.presetStopLossExecutePrice != "" {
 		body["presetStopLossExecutePrice"] = s.presetStopLossExecutePrice
 	}
+	if s.presetSlippage > 0 {
+		// The presets close the position, trading against the side of this order
+		closing := SideBuy
+		if strings.EqualFold(string(s.sideType), string(SideBuy)) {
+			closing = SideSell
+		}
+		for trigger, execute := range map[string]string{"presetStopSurplusPrice": "presetStopSurplusExecutePrice", "presetStopLossPrice": "presetStopLossExecutePrice"} {
+			if body[trigger] == "" || body[execute] != "" {
+				continue
+			}
+			if price, err := SlippagePrice(body[trigger], closing, s.presetSlippage); err == nil {
+				body[execute] = price
+			}
+		}
+	}
 	if s.selfTradePreventionType != "" {
 		body["stpMode"] = string(s.selfTradePreventionType)
 	}
@@ -174,3 +174,128 @@ func TestCreateOrderService_Integration(t *testing.T) {
 	assert.Equal(t, SideTypeBuy, service.sideType)
 	assert.Equal(t, OrderTypeLimit, service.orderType)
 }
+
+func TestCreateOrderService_TypedFlags(t *testing.T) {
+	s := (&CreateOrderService{}).TimeInForce(TimeInForceIOC).ReduceOnly(true).TakeProfit("60000", "59900").StopLoss("45000", "")
+	assert.Equal(t, TimeInForceIOC, s.timeInForceType)
+	assert.Equal(t, ReduceOnlyTrue, s.reduceOnlyType)
+	assert.Equal(t, "60000", s.presetStopSurplusPrice)
+	assert.Equal(t, "59900", s.presetStopSurplusExecutePrice)
+	assert.Equal(t, "45000", s.presetStopLossPrice)
+	assert.Empty(t, s.presetStopLossExecutePrice)
+
+	s.PostOnly().ReduceOnly(false)
+	assert.Equal(t, TimeInForcePostOnly, s.timeInForceType)
+	assert.Equal(t, ReduceOnlyFalse, s.reduceOnlyType)
+	assert.Equal(t, "post_only", s.createOrderRequrestBody()["force"])
+}
+
+func TestCreateOrderService_Close(t *testing.T) {
+	s := (&CreateOrderService{}).Close(HoldSideLong, false)
+	assert.Equal(t, SideSell, s.sideType)
+	assert.Equal(t, ReduceOnlyTrue, s.reduceOnlyType)
+	assert.Empty(t, s.positionSideType)
+
+	s = (&CreateOrderService{}).Close(HoldSideShort, false)
+	assert.Equal(t, SideBuy, s.sideType)
+
+	s = (&CreateOrderService{}).Close(HoldSideLong, true)
+	assert.Equal(t, SideBuy, s.sideType, "hedge mode closes carry the position's side")
+	assert.Equal(t, PositionSideClose, s.positionSideType)
+	assert.Empty(t, s.reduceOnlyType)
+
+	assert.Equal(t, SideSell, CloseSide(HoldSideShort, true))
+	assert.Equal(t, HoldSideShort, SideSell.HoldSide())
+	assert.Equal(t, SideBuy, SideSell.Opposite())
+}
+
+func TestCreateOrderService_CheckCombinations(t *testing.T) {
+	base := func() *CreateOrderService {
+		return (&CreateOrderService{}).
+			ProductType(ProductTypeUSDTFutures).
+			Symbol("BTCUSDT").
+			MarginMode(MarginModeCrossed).
+			MarginCoin("USDT").
+			SideType(SideBuy).
+			OrderType(OrderTypeLimit).
+			Size("0.01").
+			Price("50000")
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(s *CreateOrderService)
+		wantErr string
+	}{
+		{name: "plain limit", setup: func(s *CreateOrderService) {}},
+		{name: "post-only limit", setup: func(s *CreateOrderService) { s.PostOnly() }},
+		{name: "lowercase time in force", setup: func(s *CreateOrderService) { s.TimeInForceType("ioc") }},
+		{name: "lowercase gtc and fok", setup: func(s *CreateOrderService) { s.TimeInForce("gtc").TimeInForce("fok") }},
+		{
+			name:    "lowercase post-only market",
+			setup:   func(s *CreateOrderService) { s.OrderType(OrderTypeMarket).Price("").TimeInForce("POST_ONLY") },
+			wantErr: "post-only is only valid for limit orders",
+		},
+		{name: "long presets", setup: func(s *CreateOrderService) { s.TakeProfit("55000", "").StopLoss("48000", "47900") }},
+		{name: "short presets", setup: func(s *CreateOrderService) { s.SideType(SideTypeSell).TakeProfit("45000", "").StopLoss("52000", "") }},
+		{name: "reduce-only market", setup: func(s *CreateOrderService) { s.OrderType(OrderTypeMarket).Price("").ReduceOnly(true) }},
+		{
+			name:    "unknown time in force",
+			setup:   func(s *CreateOrderService) { s.TimeInForce("GTD") },
+			wantErr: `unsupported timeInForce "GTD"`,
+		},
+		{
+			name:    "limit without price",
+			setup:   func(s *CreateOrderService) { s.Price("") },
+			wantErr: "price is required for limit orders",
+		},
+		{
+			name:    "post-only market",
+			setup:   func(s *CreateOrderService) { s.OrderType(OrderTypeMarket).PostOnly() },
+			wantErr: "post-only is only valid for limit orders",
+		},
+		{
+			name:    "reduce-only in hedge mode",
+			setup:   func(s *CreateOrderService) { s.ReduceOnly(true).PositionSideType(PositionSideClose) },
+			wantErr: "reduceOnly applies to one-way mode only; use PositionSideType(close) in hedge mode",
+		},
+		{
+			name:    "presets on reduce-only order",
+			setup:   func(s *CreateOrderService) { s.ReduceOnly(true).StopLoss("48000", "") },
+			wantErr: "preset take-profit/stop-loss cannot be attached to a closing order",
+		},
+		{
+			name:    "presets on hedge close",
+			setup:   func(s *CreateOrderService) { s.PositionSideType(PositionSideClose).TakeProfit("55000", "") },
+			wantErr: "preset take-profit/stop-loss cannot be attached to a closing order",
+		},
+		{
+			name:    "execute price without trigger",
+			setup:   func(s *CreateOrderService) { s.PresetStopLossExecutePrice("47900") },
+			wantErr: "presetStopLossExecutePrice requires presetStopLossPrice",
+		},
+		{
+			name:    "long take-profit below price",
+			setup:   func(s *CreateOrderService) { s.TakeProfit("49000", "") },
+			wantErr: "take-profit 49000 is on the wrong side of price 50000 for a buy order",
+		},
+		{
+			name:    "short stop-loss below price",
+			setup:   func(s *CreateOrderService) { s.SideType(SideTypeSell).StopLoss("49000", "") },
+			wantErr: "stop-loss 49000 is on the wrong side of price 50000 for a sell order",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := base()
+			tt.setup(s)
+			err := s.checkRequiredParams()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package trading
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 )
 
 // CreatePlanOrderService handles placing trigger/conditional orders (plan orders).
@@ -26,6 +27,8 @@ type CreatePlanOrderService struct {
 	clientOid   *string
 	reduceOnly  *bool
 	marginCoin  *string
+	stpMode     *SelfTradePreventionType
+	maxSlippage float64
 }
 
 // Symbol sets the trading symbol (e.g., "BTCUSDT").
@@ -106,6 +109,22 @@ func (s *CreatePlanOrderService) MarginCoin(marginCoin string) *CreatePlanOrderS
 	return s
 }
 
+// StpMode sets the self-trade prevention mode applied when the triggered order is placed.
+func (s *CreatePlanOrderService) StpMode(stpMode SelfTradePreventionType) *CreatePlanOrderService {
+	s.stpMode = &stpMode
+	return s
+}
+
+// MaxSlippage executes the triggered order as a limit order priced maxSlippage (a
+// fraction, e.g. 0.005) beyond the trigger price instead of at market: below it for
+// sells, above it for buys. A stop in a thin book then fills partially or not at all
+// rather than at any price; ChaseOrderService can follow the unfilled order. It is
+// ignored when Price is set.
+func (s *CreatePlanOrderService) MaxSlippage(maxSlippage float64) *CreatePlanOrderService {
+	s.maxSlippage = maxSlippage
+	return s
+}
+
 // CreatePlanOrderResponse represents the response from placing a plan order.
 type CreatePlanOrderResponse struct {
 	OrderId   string `json:"orderId"`   // Plan order ID
@@ -114,6 +133,10 @@ type CreatePlanOrderResponse struct {
 
 // Do executes the create plan order request.
 func (s *CreatePlanOrderService) Do(ctx context.Context) (*CreatePlanOrderResponse, error) {
+	if s.stpMode != nil && !validSTP(*s.stpMode) {
+		return nil, fmt.Errorf("unsupported stpMode %q", *s.stpMode)
+	}
+
 	// Build request body
 	params := map[string]interface{}{
 		"symbol":       s.symbol,
@@ -129,6 +152,12 @@ func (s *CreatePlanOrderService) Do(ctx context.Context) (*CreatePlanOrderRespon
 	// Add optional parameters
 	if s.price != nil {
 		params["price"] = *s.price
+	} else if s.maxSlippage > 0 {
+		price, err := SlippagePrice(s.triggerPrice, s.side, s.maxSlippage)
+		if err != nil {
+			return nil, fmt.Errorf("max slippage: %w", err)
+		}
+		params["orderType"], params["price"] = string(OrderTypeLimit), price
 	}
 	if s.timeInForce != nil {
 		params["timeInForce"] = string(*s.timeInForce)
@@ -142,6 +171,9 @@ func (s *CreatePlanOrderService) Do(ctx context.Context) (*CreatePlanOrderRespon
 	if s.marginCoin != nil {
 		params["marginCoin"] = *s.marginCoin
 	}
+	if s.stpMode != nil {
+		params["stpMode"] = string(*s.stpMode)
+	}
 
 	body, err := json.Marshal(params)
 	if err != nil {
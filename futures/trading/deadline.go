@@ -0,0 +1,123 @@
+package trading
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/khanbekov/go-bitget/common/types"
+)
+
+// ErrOrderDeadline is matched by every *TimeoutOutcome.
+var ErrOrderDeadline = errors.New("order deadline exceeded")
+
+// TimeoutOutcome is returned by CreateOrderService.Do when the order could not be
+// placed within its Deadline. The attempt is abandoned and the order is looked up by
+// its client order ID, so the caller knows whether it reached the exchange:
+//
+//	info, err := order.Deadline(200 * time.Millisecond).Do(ctx)
+//	var timeout *trading.TimeoutOutcome
+//	if errors.As(err, &timeout) {
+//		switch {
+//		case timeout.Exists:
+//			// the order is live (or already filled), info identifies it
+//		case timeout.Verified:
+//			// not placed; safe to retry with the same ClientOrderId
+//		default:
+//			// unknown, timeout.Err is the lookup error
+//		}
+//	}
+//
+// A request still in flight may reach the exchange after the lookup. Retrying with the
+// same ClientOrderId stays safe, as the exchange rejects a duplicate client order ID.
+type TimeoutOutcome struct {
+	ClientOid string
+	Budget    time.Duration
+	// Verified reports whether the lookup settled the state of the order: it found the
+	// order, or the exchange reported it unknown. Exists reports whether it was found.
+	Verified bool
+	Exists   bool
+	Order    *OrderDetail // Set when Exists
+	Err      error        // Lookup error when not Verified
+}
+
+func (o *TimeoutOutcome) Error() string {
+	var state string
+	switch {
+	case o.Exists:
+		state = "order exists"
+	case o.Verified:
+		state = "order was not placed"
+	default:
+		state = fmt.Sprintf("order state unknown: %v", o.Err)
+	}
+	return fmt.Sprintf("order %s: deadline of %s exceeded, %s", o.ClientOid, o.Budget, state)
+}
+
+// Is makes errors.Is(err, ErrOrderDeadline) and errors.Is(err, context.DeadlineExceeded)
+// match.
+func (o *TimeoutOutcome) Is(target error) bool {
+	return target == ErrOrderDeadline || target == context.DeadlineExceeded
+}
+
+// Unwrap returns the lookup error, if any.
+func (o *TimeoutOutcome) Unwrap() error {
+	return o.Err
+}
+
+// Deadline bounds the placement, including the client's retries, to budget. When it
+// runs out the attempt is cancelled and Do returns a *TimeoutOutcome after looking the
+// order up by its client order ID; a random ID is used when none is set. Zero disables
+// the deadline.
+func (s *CreateOrderService) Deadline(budget time.Duration) *CreateOrderService {
+	s.deadline = budget
+	return s
+}
+
+// doWithDeadline places the order within s.deadline and verifies it on timeout.
+func (s *CreateOrderService) doWithDeadline(ctx context.Context) (*OrderInfo, error) {
+	order := *s
+	order.deadline = 0
+	if order.clientOrderId == "" {
+		order.clientOrderId = strings.ReplaceAll(uuid.NewString(), "-", "")
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, s.deadline)
+	info, err := order.Do(attemptCtx)
+	cancel()
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+		return info, err
+	}
+
+	outcome := &TimeoutOutcome{ClientOid: order.clientOrderId, Budget: s.deadline}
+	detail, err := NewGetOrderDetailsService(s.c).
+		Symbol(s.symbol).
+		ProductType(s.productType).
+		ClientOid(order.clientOrderId).
+		Do(ctx)
+	switch {
+	case err == nil && detail != nil && detail.OrderId != "":
+		outcome.Verified, outcome.Exists, outcome.Order = true, true, detail
+		return &OrderInfo{OrderId: detail.OrderId, ClientOrderId: detail.ClientOid}, outcome
+	case err == nil:
+		outcome.Err = errors.New("order lookup returned no order")
+	case isOrderNotFound(err):
+		outcome.Verified = true
+	default:
+		// Rate limits, auth failures and the like say nothing about the order
+		outcome.Err = err
+	}
+	return nil, outcome
+}
+
+// codeOrderNotFound is the Bitget code for lookups of unknown orders.
+const codeOrderNotFound = 40109
+
+func isOrderNotFound(err error) bool {
+	var apiErr *types.APIError
+	return errors.As(err, &apiErr) && apiErr.Code == codeOrderNotFound
+}
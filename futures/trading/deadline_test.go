@@ -0,0 +1,112 @@
+package trading
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// stallPlacement makes place-order hang until its context expires.
+func stallPlacement(m *MockClient) {
+	m.On("CallAPI", mock.Anything, "POST", EndpointPlaceOrder, mock.Anything, bodyWith("clientOid", "d1"), true).
+		Run(func(args mock.Arguments) { <-args.Get(0).(context.Context).Done() }).
+		Return(nil, &fasthttp.ResponseHeader{}, context.DeadlineExceeded)
+}
+
+func deadlineOrder(c ClientInterface) *CreateOrderService {
+	return NewCreateOrderService(c).
+		ProductType(ProductTypeUSDTFutures).
+		Symbol("BTCUSDT").
+		MarginMode(MarginModeCrossed).
+		MarginCoin("USDT").
+		SideType(SideBuy).
+		OrderType(OrderTypeMarket).
+		Size("0.01").
+		ClientOrderId("d1").
+		Deadline(20 * time.Millisecond)
+}
+
+func TestDeadline_OrderExists(t *testing.T) {
+	m := &MockClient{}
+	stallPlacement(m)
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, detailQuery("clientOid", "d1"), mock.Anything, true).
+		Return(okResponse(`{"orderId":"42","clientOid":"d1","state":"live"}`), &fasthttp.ResponseHeader{}, nil)
+
+	start := time.Now()
+	info, err := deadlineOrder(m).Do(context.Background())
+	assert.Less(t, time.Since(start), time.Second)
+	require.ErrorIs(t, err, ErrOrderDeadline)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	var outcome *TimeoutOutcome
+	require.ErrorAs(t, err, &outcome)
+	assert.True(t, outcome.Verified)
+	assert.True(t, outcome.Exists)
+	assert.Equal(t, "42", outcome.Order.OrderId)
+	require.NotNil(t, info)
+	assert.Equal(t, "42", info.OrderId)
+	assert.EqualError(t, err, "order d1: deadline of 20ms exceeded, order exists")
+}
+
+func TestDeadline_OrderMissing(t *testing.T) {
+	m := &MockClient{}
+	stallPlacement(m)
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, mock.Anything, mock.Anything, true).
+		Return(nil, &fasthttp.ResponseHeader{}, &types.APIError{Code: 40109, Message: "The data of the order cannot be found"})
+
+	info, err := deadlineOrder(m).Do(context.Background())
+	assert.Nil(t, info)
+	var outcome *TimeoutOutcome
+	require.ErrorAs(t, err, &outcome)
+	assert.True(t, outcome.Verified)
+	assert.False(t, outcome.Exists)
+	assert.NoError(t, outcome.Err)
+}
+
+func TestDeadline_LookupFails(t *testing.T) {
+	m := &MockClient{}
+	stallPlacement(m)
+	lookupErr := errors.New("connection reset")
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, mock.Anything, mock.Anything, true).
+		Return(nil, &fasthttp.ResponseHeader{}, lookupErr)
+
+	_, err := deadlineOrder(m).Do(context.Background())
+	var outcome *TimeoutOutcome
+	require.ErrorAs(t, err, &outcome)
+	assert.False(t, outcome.Verified)
+	assert.ErrorIs(t, err, lookupErr)
+}
+
+func TestDeadline_LookupRejected(t *testing.T) {
+	m := &MockClient{}
+	stallPlacement(m)
+	m.On("CallAPI", mock.Anything, "GET", EndpointOrderDetails, mock.Anything, mock.Anything, true).
+		Return(nil, &fasthttp.ResponseHeader{}, &types.APIError{Code: 429, Message: "Too Many Requests"})
+
+	_, err := deadlineOrder(m).Do(context.Background())
+	var outcome *TimeoutOutcome
+	require.ErrorAs(t, err, &outcome)
+	assert.False(t, outcome.Verified, "only order-not-found proves the order was not placed")
+	assert.True(t, types.IsAPIError(outcome.Err))
+}
+
+func TestDeadline_FastPathAndClientOid(t *testing.T) {
+	m := &MockClient{}
+	m.On("CallAPI", mock.Anything, "POST", EndpointPlaceOrder, mock.Anything,
+		mock.MatchedBy(func(b []byte) bool { return len(b) > 0 }), true).
+		Return(okResponse(`{"orderId":"7","clientOid":"generated"}`), &fasthttp.ResponseHeader{}, nil)
+
+	order := deadlineOrder(m).ClientOrderId("")
+	info, err := order.Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "7", info.OrderId)
+	assert.Empty(t, order.clientOrderId, "the generated client order ID is not kept on the service")
+	placed := m.Calls[0].Arguments.Get(4).([]byte)
+	assert.Contains(t, string(placed), `"clientOid":"`)
+}
@@ -2,6 +2,7 @@ package trading
 
 import (
 	jsoniter "github.com/json-iterator/go"
+	"github.com/khanbekov/go-bitget/common"
 	"golang.org/x/net/context"
 	"net/url"
 )
@@ -97,6 +98,22 @@ func (s *FillHistoryService) Do(ctx context.Context) (*FillHistoryResponse, erro
 	return response, nil
 }
 
+// Iter iterates over all matching fills, following EndId across pages. The page size and
+// filters of the service apply to every page.
+func (s *FillHistoryService) Iter(ctx context.Context) *common.Iter[FillRecord] {
+	return common.NewIter(ctx, func(ctx context.Context, cursor string) (common.Page[FillRecord], error) {
+		page := *s
+		if cursor != "" {
+			page.lastEndId = cursor
+		}
+		res, err := page.Do(ctx)
+		if err != nil || res == nil {
+			return common.Page[FillRecord]{}, err
+		}
+		return common.Page[FillRecord]{Items: common.Values(res.List), Cursor: res.EndId}, nil
+	})
+}
+
 type FillHistoryResponse struct {
 	// List of fill records
 	List []*FillRecord `json:"list"`
@@ -122,7 +139,7 @@ type FillRecord struct {
 	Price string `json:"price"`
 
 	// Order side (buy/sell)
-	Side string `json:"side"`
+	Side Side `json:"side"`
 
 	// Fill amount (size * price)
 	Amount string `json:"amount"`
@@ -137,7 +154,7 @@ type FillRecord struct {
 	Profit string `json:"profit"`
 
 	// Position side
-	PosSide string `json:"posSide"`
+	PosSide HoldSide `json:"posSide"`
 
 	// Margin coin
 	MarginCoin string `json:"marginCoin"`
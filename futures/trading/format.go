@@ -0,0 +1,68 @@
+package trading
+
+import (
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// sizeAtPrice formats "size@price", or just the size for market orders.
+func sizeAtPrice(size, price string) string {
+	if price == "" || price == "0" {
+		return size
+	}
+	return size + "@" + price
+}
+
+// String returns a compact one-line summary, e.g.
+// Order{BTCUSDT buy open limit 0.01@65000 state=filled filled=0.01 avg=64990 id=1 clientOid=a}.
+func (o OrderDetail) String() string {
+	return common.Describe("Order", o.Symbol, string(o.Side), o.TradeSide, o.OrderType,
+		sizeAtPrice(o.Size, o.Price), common.KV("state", string(o.State)), common.KV("filled", o.BaseVolume),
+		common.KV("avg", o.PriceAvg), common.KV("id", o.OrderId), common.KV("clientOid", o.ClientOid))
+}
+
+// MarshalJSON encodes the wire fields plus createdAt/updatedAt parsed from cTime/uTime.
+func (o OrderDetail) MarshalJSON() ([]byte, error) {
+	return common.MarshalFields(o, common.TimeField("createdAt", o.CTime), common.TimeField("updatedAt", o.UTime))
+}
+
+// String returns a compact one-line summary in the format of OrderDetail.String.
+func (o PendingOrder) String() string {
+	return common.Describe("PendingOrder", o.Symbol, string(o.Side), o.TradeSide, o.OrderType,
+		sizeAtPrice(o.Size, o.Price), common.KV("status", string(o.Status)), common.KV("filled", o.BaseVolume),
+		common.KV("avg", o.PriceAvg), common.KV("id", o.OrderId), common.KV("clientOid", o.ClientOid))
+}
+
+// MarshalJSON encodes the wire fields plus createdAt/updatedAt parsed from cTime/uTime.
+func (o PendingOrder) MarshalJSON() ([]byte, error) {
+	return common.MarshalFields(o, common.TimeField("createdAt", o.CTime), common.TimeField("updatedAt", o.UTime))
+}
+
+// String returns a compact one-line summary in the format of OrderDetail.String.
+func (o HistoricalOrder) String() string {
+	return common.Describe("HistoricalOrder", o.Symbol, string(o.Side), o.TradeSide, o.OrderType,
+		sizeAtPrice(o.Size, o.Price), common.KV("state", string(o.State)), common.KV("filled", o.FilledQty),
+		common.KV("avg", o.PriceAvg), common.KV("pnl", o.TotalProfits), common.KV("id", o.OrderId),
+		common.KV("clientOid", o.ClientOid))
+}
+
+// MarshalJSON encodes the wire fields plus createdAt/updatedAt parsed from cTime/uTime.
+func (o HistoricalOrder) MarshalJSON() ([]byte, error) {
+	return common.MarshalFields(o, common.TimeField("createdAt", o.CTime), common.TimeField("updatedAt", o.UTime))
+}
+
+// String returns a compact one-line summary, e.g.
+// Fill{BTCUSDT buy close 0.01@65000 fee=-0.39 USDT pnl=12.5 role=taker order=1 trade=2}.
+func (f FillRecord) String() string {
+	fee := f.Fee
+	if fee != "" && f.FeeCcy != "" {
+		fee += " " + f.FeeCcy
+	}
+	return common.Describe("Fill", f.Symbol, string(f.Side), f.TradeSide, sizeAtPrice(f.Size, f.Price),
+		common.KV("fee", fee), common.KV("pnl", f.Profit), common.KV("role", f.Role),
+		common.KV("order", f.OrderId), common.KV("trade", f.TradeId))
+}
+
+// MarshalJSON encodes the wire fields plus createdAt parsed from cTime.
+func (f FillRecord) MarshalJSON() ([]byte, error) {
+	return common.MarshalFields(f, common.TimeField("createdAt", f.CTime))
+}
@@ -0,0 +1,37 @@
+package trading
+
+import (
+	"fmt"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderDetail_StringAndJSON(t *testing.T) {
+	order := OrderDetail{
+		Symbol: "BTCUSDT", Size: "0.01", OrderId: "1", ClientOid: "my-1", BaseVolume: "0.01", PriceAvg: "64990",
+		Price: "65000", State: "filled", Side: "buy", OrderType: "limit", TradeSide: "open",
+		CTime: "1700000000000", UTime: "1700000001000",
+	}
+	assert.Equal(t, "Order{BTCUSDT buy open limit 0.01@65000 state=filled filled=0.01 avg=64990 id=1 clientOid=my-1}", order.String())
+	assert.Equal(t, order.String(), fmt.Sprint(&order))
+
+	data, err := jsoniter.Marshal(order)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"createdAt":"2023-11-14T22:13:20.000Z","updatedAt":"2023-11-14T22:13:21.000Z"}`)
+
+	var decoded OrderDetail
+	require.NoError(t, jsoniter.Unmarshal(data, &decoded))
+	assert.Equal(t, order, decoded)
+}
+
+func TestFillRecord_String(t *testing.T) {
+	fill := FillRecord{TradeId: "2", OrderId: "1", Symbol: "BTCUSDT", Size: "0.01", Price: "65000", Side: "sell",
+		Fee: "-0.39", FeeCcy: "USDT", Profit: "12.5", TradeSide: "close", Role: "taker"}
+	assert.Equal(t, "Fill{BTCUSDT sell close 0.01@65000 fee=-0.39 USDT pnl=12.5 role=taker order=1 trade=2}", fill.String())
+
+	market := PendingOrder{Symbol: "BTCUSDT", Side: "buy", OrderType: "market", Size: "1", Price: "0", Status: "live"}
+	assert.Equal(t, "PendingOrder{BTCUSDT buy market 1 status=live}", market.String())
+}
@@ -0,0 +1,40 @@
+package trading
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestOrderHistoryService_Iter(t *testing.T) {
+	mockClient := &MockClient{}
+	page := func(cursor, data string) {
+		query := url.Values{"productType": {"USDT-FUTURES"}, "pageSize": {"2"}}
+		if cursor != "" {
+			query.Set("lastEndId", cursor)
+		}
+		mockClient.On("CallAPI", mock.Anything, "GET", EndpointOrderHistory, query, []byte(nil), true).
+			Return(&ApiResponse{Code: "00000", Data: []byte(data)}, &fasthttp.ResponseHeader{}, nil).Once()
+	}
+	page("", `{"list":[{"orderId":"3"},{"orderId":"2"}],"endId":"2"}`)
+	page("2", `{"list":[{"orderId":"1"}],"endId":"1"}`)
+	page("1", `{"list":[],"endId":""}`)
+
+	service := NewOrderHistoryService(mockClient).ProductType(ProductTypeUSDTFutures).PageSize("2")
+	orders, err := common.Collect(service.Iter(context.Background()), 0)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, o := range orders {
+		ids = append(ids, o.OrderId)
+	}
+	assert.Equal(t, []string{"3", "2", "1"}, ids)
+	assert.Empty(t, service.lastEndId, "the service itself is not modified")
+	mockClient.AssertExpectations(t)
+}
@@ -7,6 +7,8 @@ import (
 )
 
 // ModifyOrderService provides methods to modify an existing order.
+// The order is identified by OrderId or ClientOrderId, and at least one of the new size
+// and price (set together) or the preset take-profit and stop-loss prices must be given.
 type ModifyOrderService struct {
 	c                         ClientInterface
 	orderId                   string
@@ -57,13 +59,13 @@ func (s *ModifyOrderService) NewClientOrderId(newClientOrderId string) *ModifyOr
 	return s
 }
 
-// NewSize sets the new order size.
+// NewSize sets the new order size. Must be set together with NewPrice.
 func (s *ModifyOrderService) NewSize(newSize string) *ModifyOrderService {
 	s.newSize = newSize
 	return s
 }
 
-// NewPrice sets the new order price.
+// NewPrice sets the new order price. Must be set together with NewSize.
 func (s *ModifyOrderService) NewPrice(newPrice string) *ModifyOrderService {
 	s.newPrice = newPrice
 	return s
@@ -95,6 +97,16 @@ func (s *ModifyOrderService) checkRequiredParams() error {
 	if s.newClientOrderId == "" {
 		return fmt.Errorf("newClientOrderId is required")
 	}
+	if s.orderId == "" && s.clientOrderId == "" {
+		return fmt.Errorf("either orderId or clientOrderId is required")
+	}
+	if s.newSize == "" && s.newPrice == "" && s.newPresetStopSurplusPrice == "" && s.newPresetStopLossPrice == "" {
+		return fmt.Errorf("at least one of newSize, newPrice, newPresetStopSurplusPrice or newPresetStopLossPrice is required")
+	}
+	// Bitget re-prices the order as a whole, so size and price are amended together
+	if (s.newSize == "") != (s.newPrice == "") {
+		return fmt.Errorf("newSize and newPrice must be set together")
+	}
 	return nil
 }
 
@@ -0,0 +1,101 @@
+package trading
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func newModifyOrderService(c ClientInterface) *ModifyOrderService {
+	return NewModifyOrderService(c).
+		Symbol("BTCUSDT").
+		ProductType(ProductTypeUSDTFutures).
+		MarginCoin("USDT").
+		NewClientOrderId("amended-1")
+}
+
+func TestModifyOrderService_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(s *ModifyOrderService)
+		wantErr string
+	}{
+		{
+			name:    "missing order identifier",
+			setup:   func(s *ModifyOrderService) { s.NewPrice("50000").NewSize("0.01") },
+			wantErr: "either orderId or clientOrderId is required",
+		},
+		{
+			name:    "nothing to change",
+			setup:   func(s *ModifyOrderService) { s.OrderId("123") },
+			wantErr: "at least one of newSize, newPrice, newPresetStopSurplusPrice or newPresetStopLossPrice is required",
+		},
+		{
+			name:    "price without size",
+			setup:   func(s *ModifyOrderService) { s.OrderId("123").NewPrice("50000") },
+			wantErr: "newSize and newPrice must be set together",
+		},
+		{
+			name:    "size without price",
+			setup:   func(s *ModifyOrderService) { s.ClientOrderId("c-1").NewSize("0.01") },
+			wantErr: "newSize and newPrice must be set together",
+		},
+		{
+			name:  "take-profit only",
+			setup: func(s *ModifyOrderService) { s.OrderId("123").NewPresetStopSurplusPrice("60000") },
+		},
+		{
+			name:  "price and size",
+			setup: func(s *ModifyOrderService) { s.ClientOrderId("c-1").NewPrice("50000").NewSize("0.01") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newModifyOrderService(&MockClient{})
+			tt.setup(s)
+			err := s.checkRequiredParams()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModifyOrderService_Do_NoChangeSkipsRequest(t *testing.T) {
+	mockClient := &MockClient{}
+
+	_, err := newModifyOrderService(mockClient).OrderId("123").Do(context.Background())
+
+	assert.Error(t, err)
+	mockClient.AssertNotCalled(t, "CallAPI")
+}
+
+func TestModifyOrderService_Do_Success(t *testing.T) {
+	mockClient := &MockClient{}
+	data, _ := json.Marshal(map[string]string{"orderId": "123", "clientOid": "amended-1"})
+
+	mockClient.On("CallAPI", mock.Anything, "POST", EndpointModifyOrder, mock.Anything, mock.MatchedBy(func(body []byte) bool {
+		var m map[string]string
+		if json.Unmarshal(body, &m) != nil {
+			return false
+		}
+		return m["orderId"] == "123" && m["newPresetStopLossPrice"] == "45000" && m["newClientOid"] == "amended-1" && m["newPrice"] == ""
+	}), true).Return(&ApiResponse{Code: "00000", Data: data}, &fasthttp.ResponseHeader{}, nil)
+
+	result, err := newModifyOrderService(mockClient).
+		OrderId("123").
+		NewPresetStopLossPrice("45000").
+		Do(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "123", result.OrderId)
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,164 @@
+package trading
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ModifyTPSLOrderService modifies a take-profit or stop-loss order, e.g. to move the stop
+// of a position.
+type ModifyTPSLOrderService struct {
+	c ClientInterface
+
+	// Required parameters
+	symbol       string
+	productType  ProductType
+	marginCoin   string
+	triggerPrice string
+
+	// Either orderId or clientOid is required
+	orderId   string
+	clientOid string
+
+	// Optional parameters
+	triggerType  *TriggerType
+	executePrice *string
+	size         *string
+	rangeRate    *string
+}
+
+// Symbol sets the trading symbol (e.g., "BTCUSDT").
+func (s *ModifyTPSLOrderService) Symbol(symbol string) *ModifyTPSLOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// ProductType sets the product type of the position.
+func (s *ModifyTPSLOrderService) ProductType(productType ProductType) *ModifyTPSLOrderService {
+	s.productType = productType
+	return s
+}
+
+// MarginCoin sets the margin coin of the position.
+func (s *ModifyTPSLOrderService) MarginCoin(marginCoin string) *ModifyTPSLOrderService {
+	s.marginCoin = marginCoin
+	return s
+}
+
+// OrderId sets the ID of the order to modify.
+func (s *ModifyTPSLOrderService) OrderId(orderId string) *ModifyTPSLOrderService {
+	s.orderId = orderId
+	return s
+}
+
+// ClientOid sets the client order ID of the order to modify.
+func (s *ModifyTPSLOrderService) ClientOid(clientOid string) *ModifyTPSLOrderService {
+	s.clientOid = clientOid
+	return s
+}
+
+// TriggerPrice sets the new trigger price.
+func (s *ModifyTPSLOrderService) TriggerPrice(triggerPrice string) *ModifyTPSLOrderService {
+	s.triggerPrice = triggerPrice
+	return s
+}
+
+// TriggerType sets how the trigger price is compared (fill_price or mark_price).
+func (s *ModifyTPSLOrderService) TriggerType(triggerType TriggerType) *ModifyTPSLOrderService {
+	s.triggerType = &triggerType
+	return s
+}
+
+// ExecutePrice sets the limit price of the triggered order; empty or "0" executes at
+// market.
+func (s *ModifyTPSLOrderService) ExecutePrice(executePrice string) *ModifyTPSLOrderService {
+	s.executePrice = &executePrice
+	return s
+}
+
+// Size sets the new size to close; leave unset for position-wide orders.
+func (s *ModifyTPSLOrderService) Size(size string) *ModifyTPSLOrderService {
+	s.size = &size
+	return s
+}
+
+// RangeRate sets the new callback rate of a trailing stop.
+func (s *ModifyTPSLOrderService) RangeRate(rangeRate string) *ModifyTPSLOrderService {
+	s.rangeRate = &rangeRate
+	return s
+}
+
+// checkRequiredParams validates required parameters.
+func (s *ModifyTPSLOrderService) checkRequiredParams() error {
+	if s.symbol == "" {
+		return fmt.Errorf("symbol is required")
+	}
+	if s.productType == "" {
+		return fmt.Errorf("productType is required")
+	}
+	if s.marginCoin == "" {
+		return fmt.Errorf("marginCoin is required")
+	}
+	if s.orderId == "" && s.clientOid == "" {
+		return fmt.Errorf("either orderId or clientOid must be provided")
+	}
+	if s.triggerPrice == "" {
+		return fmt.Errorf("triggerPrice is required")
+	}
+	return nil
+}
+
+// Do executes the modify take-profit/stop-loss order request.
+func (s *ModifyTPSLOrderService) Do(ctx context.Context) (*TPSLOrderResponse, error) {
+	if err := s.checkRequiredParams(); err != nil {
+		return nil, err
+	}
+
+	// Build request body
+	params := map[string]interface{}{
+		"symbol":       s.symbol,
+		"productType":  string(s.productType),
+		"marginCoin":   s.marginCoin,
+		"triggerPrice": s.triggerPrice,
+	}
+	if s.orderId != "" {
+		params["orderId"] = s.orderId
+	}
+	if s.clientOid != "" {
+		params["clientOid"] = s.clientOid
+	}
+
+	// Add optional parameters
+	if s.triggerType != nil {
+		params["triggerType"] = string(*s.triggerType)
+	}
+	if s.executePrice != nil {
+		params["executePrice"] = *s.executePrice
+	}
+	if s.size != nil {
+		params["size"] = *s.size
+	}
+	if s.rangeRate != nil {
+		params["rangeRate"] = *s.rangeRate
+	}
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make API call
+	res, _, err := s.c.CallAPI(ctx, "POST", EndpointModifyTPSLOrder, nil, body, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var result TPSLOrderResponse
+	if err := json.Unmarshal(res.Data, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
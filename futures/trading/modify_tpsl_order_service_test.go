@@ -0,0 +1,34 @@
+package trading
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestModifyTPSLOrderService_Do(t *testing.T) {
+	m := &MockClient{}
+	m.On("CallAPI", mock.Anything, "POST", EndpointModifyTPSLOrder, mock.Anything, bodyWith("triggerPrice", "60030"), true).
+		Return(okResponse(`{"orderId":"sl1"}`), &fasthttp.ResponseHeader{}, nil)
+
+	res, err := NewModifyTPSLOrderService(m).
+		Symbol("BTCUSDT").
+		ProductType(ProductTypeUSDTFutures).
+		MarginCoin("USDT").
+		OrderId("sl1").
+		TriggerPrice("60030").
+		Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "sl1", res.OrderId)
+	assert.Contains(t, string(m.Calls[0].Arguments.Get(4).([]byte)), `"orderId":"sl1"`)
+}
+
+func TestModifyTPSLOrderService_Validation(t *testing.T) {
+	_, err := NewModifyTPSLOrderService(nil).Symbol("BTCUSDT").ProductType(ProductTypeUSDTFutures).
+		MarginCoin("USDT").TriggerPrice("60030").Do(context.Background())
+	assert.EqualError(t, err, "either orderId or clientOid must be provided")
+}
@@ -10,34 +10,35 @@ import (
 
 // OrderDetail represents detailed order information
 type OrderDetail struct {
-	Symbol                 string `json:"symbol"`
-	Size                   string `json:"size"`
-	OrderId                string `json:"orderId"`
-	ClientOid              string `json:"clientOid"`
-	BaseVolume             string `json:"baseVolume"`
-	PriceAvg               string `json:"priceAvg"`
-	Fee                    string `json:"fee"`
-	Price                  string `json:"price"`
-	State                  string `json:"state"`
-	Side                   string `json:"side"`
-	Force                  string `json:"force"`
-	TotalProfits           string `json:"totalProfits"`
-	PosSide                string `json:"posSide"`
-	MarginCoin             string `json:"marginCoin"`
-	PresetStopSurplusPrice string `json:"presetStopSurplusPrice"`
-	PresetStopLossPrice    string `json:"presetStopLossPrice"`
-	QuoteVolume            string `json:"quoteVolume"`
-	OrderType              string `json:"orderType"`
-	Leverage               string `json:"leverage"`
-	MarginMode             string `json:"marginMode"`
-	ReduceOnly             string `json:"reduceOnly"`
-	EnterPointSource       string `json:"enterPointSource"`
-	TradeSide              string `json:"tradeSide"`
-	PosMode                string `json:"posMode"`
-	OrderSource            string `json:"orderSource"`
-	CancelReason           string `json:"cancelReason"`
-	CTime                  string `json:"cTime"`
-	UTime                  string `json:"uTime"`
+	Symbol                 string      `json:"symbol"`
+	Size                   string      `json:"size"`
+	OrderId                string      `json:"orderId"`
+	ClientOid              string      `json:"clientOid"`
+	BaseVolume             string      `json:"baseVolume"`
+	PriceAvg               string      `json:"priceAvg"`
+	Fee                    string      `json:"fee"`
+	Price                  string      `json:"price"`
+	State                  OrderStatus `json:"state"`
+	Side                   Side        `json:"side"`
+	Force                  string      `json:"force"`
+	TotalProfits           string      `json:"totalProfits"`
+	PosSide                HoldSide    `json:"posSide"`
+	MarginCoin             string      `json:"marginCoin"`
+	PresetStopSurplusPrice string      `json:"presetStopSurplusPrice"`
+	PresetStopLossPrice    string      `json:"presetStopLossPrice"`
+	QuoteVolume            string      `json:"quoteVolume"`
+	OrderType              string      `json:"orderType"`
+	Leverage               string      `json:"leverage"`
+	MarginMode             string      `json:"marginMode"`
+	ReduceOnly             string      `json:"reduceOnly"`
+	EnterPointSource       string      `json:"enterPointSource"`
+	TradeSide              string      `json:"tradeSide"`
+	PosMode                string      `json:"posMode"`
+	OrderSource            string      `json:"orderSource"`
+	CancelReason           string      `json:"cancelReason"`
+	StpMode                string      `json:"stpMode"`
+	CTime                  string      `json:"cTime"`
+	UTime                  string      `json:"uTime"`
 }
 
 // GetOrderDetailsService provides methods to retrieve order details
@@ -108,11 +109,18 @@ func (s *GetOrderDetailsService) Do(ctx context.Context) (orderDetail *OrderDeta
 		return nil, err
 	}
 
-	var wrapper struct {
-		Data OrderDetail `json:"data"`
-	}
-	if err := jsoniter.Unmarshal(res.Data, &wrapper); err != nil {
+	var detail OrderDetail
+	if err := jsoniter.Unmarshal(res.Data, &detail); err != nil {
 		return nil, err
 	}
-	return &wrapper.Data, nil
+	if detail.OrderId == "" {
+		// Tolerate a payload that is still wrapped in its data envelope
+		var wrapper struct {
+			Data OrderDetail `json:"data"`
+		}
+		if err := jsoniter.Unmarshal(res.Data, &wrapper); err == nil && wrapper.Data.OrderId != "" {
+			detail = wrapper.Data
+		}
+	}
+	return &detail, nil
 }
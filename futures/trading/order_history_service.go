@@ -2,6 +2,7 @@ package trading
 
 import (
 	jsoniter "github.com/json-iterator/go"
+	"github.com/khanbekov/go-bitget/common"
 	"golang.org/x/net/context"
 	"net/url"
 )
@@ -88,6 +89,22 @@ func (s *OrderHistoryService) Do(ctx context.Context) (*OrderHistoryResponse, er
 	return response, nil
 }
 
+// Iter iterates over all matching orders, following EndId across pages. The page size and
+// filters of the service apply to every page.
+func (s *OrderHistoryService) Iter(ctx context.Context) *common.Iter[HistoricalOrder] {
+	return common.NewIter(ctx, func(ctx context.Context, cursor string) (common.Page[HistoricalOrder], error) {
+		page := *s
+		if cursor != "" {
+			page.lastEndId = cursor
+		}
+		res, err := page.Do(ctx)
+		if err != nil || res == nil {
+			return common.Page[HistoricalOrder]{}, err
+		}
+		return common.Page[HistoricalOrder]{Items: common.Values(res.List), Cursor: res.EndId}, nil
+	})
+}
+
 type OrderHistoryResponse struct {
 	// List of historical orders
 	List []*HistoricalOrder `json:"list"`
@@ -122,10 +139,10 @@ type HistoricalOrder struct {
 	Price string `json:"price"`
 
 	// Order state (filled, cancelled, rejected, etc.)
-	State string `json:"state"`
+	State OrderStatus `json:"state"`
 
 	// Order side (buy/sell)
-	Side string `json:"side"`
+	Side Side `json:"side"`
 
 	// Time in force
 	Force string `json:"force"`
@@ -134,7 +151,7 @@ type HistoricalOrder struct {
 	TotalProfits string `json:"totalProfits"`
 
 	// Position side
-	PosSide string `json:"posSide"`
+	PosSide HoldSide `json:"posSide"`
 
 	// Margin coin
 	MarginCoin string `json:"marginCoin"`
@@ -169,6 +186,9 @@ type HistoricalOrder struct {
 	// Cancel reason (if cancelled)
 	CancelReason string `json:"cancelReason"`
 
+	// Self-trade prevention mode
+	StpMode string `json:"stpMode"`
+
 	// Create time
 	CTime string `json:"cTime"`
 
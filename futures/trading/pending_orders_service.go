@@ -99,10 +99,10 @@ type PendingOrder struct {
 	PriceAvg string `json:"priceAvg"`
 
 	// Order status
-	Status string `json:"status"`
+	Status OrderStatus `json:"status"`
 
 	// Order side (buy/sell)
-	Side string `json:"side"`
+	Side Side `json:"side"`
 
 	// Time in force
 	Force string `json:"force"`
@@ -111,7 +111,7 @@ type PendingOrder struct {
 	TotalProfits string `json:"totalProfits"`
 
 	// Position side
-	PosSide string `json:"posSide"`
+	PosSide HoldSide `json:"posSide"`
 
 	// Margin coin
 	MarginCoin string `json:"marginCoin"`
@@ -140,6 +140,9 @@ type PendingOrder struct {
 	// Order source
 	OrderSource string `json:"orderSource"`
 
+	// Self-trade prevention mode
+	StpMode string `json:"stpMode"`
+
 	// Created time
 	CTime string `json:"cTime"`
 
@@ -53,23 +53,23 @@ func (s *PendingPlanOrdersService) IdLessThan(idLessThan string) *PendingPlanOrd
 
 // PendingPlanOrder represents a pending plan order.
 type PendingPlanOrder struct {
-	OrderId      string `json:"orderId"`      // Plan order ID
-	ClientOid    string `json:"clientOid"`    // Client order ID
-	Symbol       string `json:"symbol"`       // Trading symbol
-	ProductType  string `json:"productType"`  // Product type
-	PlanType     string `json:"planType"`     // Plan type
-	TriggerPrice string `json:"triggerPrice"` // Trigger price
-	TriggerType  string `json:"triggerType"`  // Trigger type
-	Side         string `json:"side"`         // Order side
-	OrderType    string `json:"orderType"`    // Order type
-	Size         string `json:"size"`         // Order size
-	Price        string `json:"price"`        // Order price
-	TimeInForce  string `json:"timeInForce"`  // Time in force
-	Status       string `json:"status"`       // Order status
-	CreateTime   string `json:"createTime"`   // Creation time
-	UpdateTime   string `json:"updateTime"`   // Last update time
-	ReduceOnly   string `json:"reduceOnly"`   // Reduce only flag
-	MarginCoin   string `json:"marginCoin"`   // Margin coin
+	OrderId      string          `json:"orderId"`      // Plan order ID
+	ClientOid    string          `json:"clientOid"`    // Client order ID
+	Symbol       string          `json:"symbol"`       // Trading symbol
+	ProductType  string          `json:"productType"`  // Product type
+	PlanType     string          `json:"planType"`     // Plan type
+	TriggerPrice string          `json:"triggerPrice"` // Trigger price
+	TriggerType  string          `json:"triggerType"`  // Trigger type
+	Side         Side            `json:"side"`         // Order side
+	OrderType    string          `json:"orderType"`    // Order type
+	Size         string          `json:"size"`         // Order size
+	Price        string          `json:"price"`        // Order price
+	TimeInForce  string          `json:"timeInForce"`  // Time in force
+	Status       PlanOrderStatus `json:"status"`       // Order status
+	CreateTime   string          `json:"createTime"`   // Creation time
+	UpdateTime   string          `json:"updateTime"`   // Last update time
+	ReduceOnly   string          `json:"reduceOnly"`   // Reduce only flag
+	MarginCoin   string          `json:"marginCoin"`   // Margin coin
 }
 
 // PendingPlanOrdersResponse represents the response from getting pending plan orders.
@@ -0,0 +1,179 @@
+package trading
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// PlaceTPSLOrderService places a take-profit or stop-loss order on an open position.
+// Position-wide plan types (pos_profit, pos_loss) close the whole position when
+// triggered and take no size; partial ones (profit_plan, loss_plan) close Size.
+type PlaceTPSLOrderService struct {
+	c ClientInterface
+
+	// Required parameters
+	symbol       string
+	productType  ProductType
+	marginCoin   string
+	planType     PlanType
+	triggerPrice string
+	holdSide     HoldSide
+
+	// Optional parameters
+	triggerType  *TriggerType
+	executePrice *string
+	size         *string
+	rangeRate    *string
+	clientOid    *string
+}
+
+// Symbol sets the trading symbol (e.g., "BTCUSDT").
+func (s *PlaceTPSLOrderService) Symbol(symbol string) *PlaceTPSLOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// ProductType sets the product type of the position.
+func (s *PlaceTPSLOrderService) ProductType(productType ProductType) *PlaceTPSLOrderService {
+	s.productType = productType
+	return s
+}
+
+// MarginCoin sets the margin coin of the position.
+func (s *PlaceTPSLOrderService) MarginCoin(marginCoin string) *PlaceTPSLOrderService {
+	s.marginCoin = marginCoin
+	return s
+}
+
+// PlanType sets the kind of order (profit_plan, loss_plan, moving_plan, pos_profit, pos_loss).
+func (s *PlaceTPSLOrderService) PlanType(planType PlanType) *PlaceTPSLOrderService {
+	s.planType = planType
+	return s
+}
+
+// TriggerPrice sets the price that triggers the order.
+func (s *PlaceTPSLOrderService) TriggerPrice(triggerPrice string) *PlaceTPSLOrderService {
+	s.triggerPrice = triggerPrice
+	return s
+}
+
+// HoldSide sets the side of the position to close (long or short).
+func (s *PlaceTPSLOrderService) HoldSide(holdSide HoldSide) *PlaceTPSLOrderService {
+	s.holdSide = holdSide
+	return s
+}
+
+// TriggerType sets how the trigger price is compared (fill_price or mark_price).
+func (s *PlaceTPSLOrderService) TriggerType(triggerType TriggerType) *PlaceTPSLOrderService {
+	s.triggerType = &triggerType
+	return s
+}
+
+// ExecutePrice sets the limit price of the triggered order; empty or "0" executes at
+// market.
+func (s *PlaceTPSLOrderService) ExecutePrice(executePrice string) *PlaceTPSLOrderService {
+	s.executePrice = &executePrice
+	return s
+}
+
+// Size sets the size to close (required for profit_plan, loss_plan and moving_plan).
+func (s *PlaceTPSLOrderService) Size(size string) *PlaceTPSLOrderService {
+	s.size = &size
+	return s
+}
+
+// RangeRate sets the callback rate of a trailing stop (moving_plan only).
+func (s *PlaceTPSLOrderService) RangeRate(rangeRate string) *PlaceTPSLOrderService {
+	s.rangeRate = &rangeRate
+	return s
+}
+
+// ClientOid sets the client order ID for tracking.
+func (s *PlaceTPSLOrderService) ClientOid(clientOid string) *PlaceTPSLOrderService {
+	s.clientOid = &clientOid
+	return s
+}
+
+// TPSLOrderResponse represents the response from placing or modifying a take-profit or
+// stop-loss order.
+type TPSLOrderResponse struct {
+	OrderId   string `json:"orderId"`   // Plan order ID
+	ClientOid string `json:"clientOid"` // Client order ID
+}
+
+// checkRequiredParams validates required parameters.
+func (s *PlaceTPSLOrderService) checkRequiredParams() error {
+	if s.symbol == "" {
+		return fmt.Errorf("symbol is required")
+	}
+	if s.productType == "" {
+		return fmt.Errorf("productType is required")
+	}
+	if s.marginCoin == "" {
+		return fmt.Errorf("marginCoin is required")
+	}
+	if s.planType == "" {
+		return fmt.Errorf("planType is required")
+	}
+	if s.triggerPrice == "" {
+		return fmt.Errorf("triggerPrice is required")
+	}
+	if s.holdSide == "" {
+		return fmt.Errorf("holdSide is required")
+	}
+	return nil
+}
+
+// Do executes the place take-profit/stop-loss order request.
+func (s *PlaceTPSLOrderService) Do(ctx context.Context) (*TPSLOrderResponse, error) {
+	if err := s.checkRequiredParams(); err != nil {
+		return nil, err
+	}
+
+	// Build request body
+	params := map[string]interface{}{
+		"symbol":       s.symbol,
+		"productType":  string(s.productType),
+		"marginCoin":   s.marginCoin,
+		"planType":     string(s.planType),
+		"triggerPrice": s.triggerPrice,
+		"holdSide":     string(s.holdSide),
+	}
+
+	// Add optional parameters
+	if s.triggerType != nil {
+		params["triggerType"] = string(*s.triggerType)
+	}
+	if s.executePrice != nil {
+		params["executePrice"] = *s.executePrice
+	}
+	if s.size != nil {
+		params["size"] = *s.size
+	}
+	if s.rangeRate != nil {
+		params["rangeRate"] = *s.rangeRate
+	}
+	if s.clientOid != nil {
+		params["clientOid"] = *s.clientOid
+	}
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make API call
+	res, _, err := s.c.CallAPI(ctx, "POST", EndpointPlaceTPSLOrder, nil, body, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var result TPSLOrderResponse
+	if err := json.Unmarshal(res.Data, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,44 @@
+package trading
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestPlaceTPSLOrderService_Do(t *testing.T) {
+	m := &MockClient{}
+	m.On("CallAPI", mock.Anything, "POST", EndpointPlaceTPSLOrder, mock.Anything, mock.Anything, true).
+		Return(okResponse(`{"orderId":"sl1","clientOid":"c1"}`), &fasthttp.ResponseHeader{}, nil)
+
+	res, err := NewPlaceTPSLOrderService(m).
+		Symbol("BTCUSDT").
+		ProductType(ProductTypeUSDTFutures).
+		MarginCoin("USDT").
+		PlanType(PlanTypePosLoss).
+		TriggerPrice("58000").
+		TriggerType(TriggerTypeMarkPrice).
+		HoldSide(HoldSideLong).
+		ClientOid("c1").
+		Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "sl1", res.OrderId)
+
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(m.Calls[0].Arguments.Get(4).([]byte), &body))
+	assert.Equal(t, map[string]string{
+		"symbol": "BTCUSDT", "productType": "USDT-FUTURES", "marginCoin": "USDT", "planType": "pos_loss",
+		"triggerPrice": "58000", "triggerType": "mark_price", "holdSide": "long", "clientOid": "c1",
+	}, body, "position-wide orders carry no size")
+}
+
+func TestPlaceTPSLOrderService_Validation(t *testing.T) {
+	_, err := NewPlaceTPSLOrderService(nil).Symbol("BTCUSDT").ProductType(ProductTypeUSDTFutures).
+		MarginCoin("USDT").PlanType(PlanTypePosLoss).TriggerPrice("58000").Do(context.Background())
+	assert.EqualError(t, err, "holdSide is required")
+}
@@ -0,0 +1,44 @@
+package trading
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyClient(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"code":"00000","msg":"success","requestTime":1,"data":{"entrustedList":[],"endId":""}}`))
+	}))
+	defer srv.Close()
+
+	client := futures.NewClient("key", "secret", "passphrase", futures.WithReadOnly())
+	client.BaseURL = srv.URL
+	require.True(t, client.ReadOnly())
+	ctx := context.Background()
+
+	_, err := NewPendingOrdersService(client).ProductType(ProductTypeUSDTFutures).Symbol("BTCUSDT").Do(ctx)
+	require.NoError(t, err)
+
+	_, err = NewCreateOrderService(client).ProductType(ProductTypeUSDTFutures).Symbol("BTCUSDT").
+		MarginMode(MarginModeCrossed).MarginCoin("USDT").SideType(SideBuy).OrderType(OrderTypeMarket).
+		Size("0.01").Do(ctx)
+	assert.ErrorIs(t, err, common.ErrReadOnly)
+	var roErr *common.ReadOnlyError
+	require.ErrorAs(t, err, &roErr)
+	assert.Equal(t, EndpointPlaceOrder, roErr.Endpoint)
+
+	_, err = NewCancelOrderService(client).ProductType(ProductTypeUSDTFutures).Symbol("BTCUSDT").OrderId("1").Do(ctx)
+	assert.ErrorIs(t, err, common.ErrReadOnly)
+	assert.Equal(t, int32(1), requests.Load(), "mutating requests never reach the exchange")
+}
@@ -0,0 +1,52 @@
+package trading
+
+// OrderStatus is the state of an order as reported by the order detail, pending and
+// history endpoints.
+type OrderStatus string
+
+const (
+	OrderStatusLive            OrderStatus = "live"             // Resting, nothing filled
+	OrderStatusPartiallyFilled OrderStatus = "partially_filled" // Resting, partly filled
+	OrderStatusFilled          OrderStatus = "filled"           // Completely filled
+	OrderStatusCanceled        OrderStatus = "canceled"         // Canceled, possibly after partial fills
+)
+
+// Order states reported by the order detail endpoint. Kept for compatibility; prefer
+// the OrderStatus constants.
+const (
+	OrderStateLive            = OrderStatusLive
+	OrderStatePartiallyFilled = OrderStatusPartiallyFilled
+	OrderStateFilled          = OrderStatusFilled
+	OrderStateCanceled        = OrderStatusCanceled
+)
+
+// IsLive reports whether the order is still resting on the book.
+func (s OrderStatus) IsLive() bool {
+	return s == OrderStatusLive || s == OrderStatusPartiallyFilled
+}
+
+// IsTerminal reports whether the order can no longer change.
+func (s OrderStatus) IsTerminal() bool {
+	return s == OrderStatusFilled || s == OrderStatusCanceled
+}
+
+// PlanOrderStatus is the state of a trigger order.
+type PlanOrderStatus string
+
+const (
+	PlanOrderStatusLive        PlanOrderStatus = "live"         // Waiting for the trigger
+	PlanOrderStatusExecuting   PlanOrderStatus = "executing"    // Triggered, order being placed
+	PlanOrderStatusExecuted    PlanOrderStatus = "executed"     // Triggered and placed
+	PlanOrderStatusFailExecute PlanOrderStatus = "fail_execute" // Triggered but placement failed
+	PlanOrderStatusCancelled   PlanOrderStatus = "cancelled"    // Canceled before the trigger
+)
+
+// IsLive reports whether the trigger order is still waiting or being executed.
+func (s PlanOrderStatus) IsLive() bool {
+	return s == PlanOrderStatusLive || s == PlanOrderStatusExecuting
+}
+
+// IsTerminal reports whether the trigger order can no longer change.
+func (s PlanOrderStatus) IsTerminal() bool {
+	return s == PlanOrderStatusExecuted || s == PlanOrderStatusFailExecute || s == PlanOrderStatusCancelled
+}
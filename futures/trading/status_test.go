@@ -0,0 +1,31 @@
+package trading
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderStatus(t *testing.T) {
+	var order OrderDetail
+	require.NoError(t, json.Unmarshal([]byte(`{"orderId":"1","state":"partially_filled","side":"buy","posSide":"long"}`), &order))
+	assert.Equal(t, OrderStatusPartiallyFilled, order.State)
+	assert.Equal(t, SideBuy, order.Side)
+	assert.Equal(t, HoldSideLong, order.PosSide)
+	assert.True(t, order.State.IsLive())
+	assert.False(t, order.State.IsTerminal())
+
+	for _, s := range []OrderStatus{OrderStatusFilled, OrderStatusCanceled} {
+		assert.True(t, s.IsTerminal(), s)
+		assert.False(t, s.IsLive(), s)
+	}
+	assert.False(t, OrderStatus("").IsTerminal())
+}
+
+func TestPlanOrderStatus(t *testing.T) {
+	assert.True(t, PlanOrderStatusExecuting.IsLive())
+	assert.True(t, PlanOrderStatusFailExecute.IsTerminal())
+	assert.False(t, PlanOrderStatusLive.IsTerminal())
+}
@@ -0,0 +1,77 @@
+package trading
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestCreateOrderService_StpMode(t *testing.T) {
+	s := (&CreateOrderService{}).
+		ProductType(ProductTypeUSDTFutures).
+		Symbol("BTCUSDT").
+		MarginMode(MarginModeCrossed).
+		MarginCoin("USDT").
+		SideType(SideBuy).
+		OrderType(OrderTypeMarket).
+		Size("0.01").
+		SelfTradePrevention(STPCancelMaker)
+
+	require.NoError(t, s.checkRequiredParams())
+	assert.Equal(t, "cancel_maker", s.createOrderRequrestBody()["stpMode"])
+
+	s.StpMode("cancel_everything")
+	assert.EqualError(t, s.checkRequiredParams(), `unsupported stpMode "cancel_everything"`)
+}
+
+func TestCreateBatchOrdersService_StpMode(t *testing.T) {
+	s := (&CreateBatchOrdersService{}).
+		ProductType(ProductTypeUSDTFutures).
+		Symbol("BTCUSDT").
+		MarginMode(MarginModeCrossed).
+		MarginCoin("USDT").
+		StpMode(STPCancelTaker).
+		AddOrder(BatchOrderInfo{Size: "1", SideType: SideBuy, OrderType: OrderTypeLimit, Price: "100"}).
+		AddOrder(BatchOrderInfo{Size: "1", SideType: SideSell, OrderType: OrderTypeLimit, Price: "101", SelfTradePreventionType: STPCancelBoth})
+
+	require.NoError(t, s.checkRequiredParams())
+	orders := s.createBatchOrderRequestBody()["orderList"].([]map[string]interface{})
+	assert.Equal(t, "cancel_taker", orders[0]["stpMode"], "batch default applies")
+	assert.Equal(t, "cancel_both", orders[1]["stpMode"], "per-order mode wins")
+
+	s.AddOrder(BatchOrderInfo{Size: "1", SideType: SideBuy, OrderType: OrderTypeMarket, SelfTradePreventionType: "bogus"})
+	assert.EqualError(t, s.checkRequiredParams(), `order 3: unsupported stpMode "bogus"`)
+
+	s.StpMode("bogus").ClearOrders().AddOrder(BatchOrderInfo{Size: "1", SideType: SideBuy, OrderType: OrderTypeMarket})
+	assert.EqualError(t, s.checkRequiredParams(), `unsupported stpMode "bogus"`)
+}
+
+func TestCreatePlanOrderService_StpMode(t *testing.T) {
+	mockClient := &MockClient{}
+	data, _ := json.Marshal(map[string]string{"orderId": "1"})
+	mockClient.On("CallAPI", mock.Anything, "POST", EndpointCreatePlanOrder, mock.Anything, mock.MatchedBy(func(body []byte) bool {
+		var m map[string]interface{}
+		return json.Unmarshal(body, &m) == nil && m["stpMode"] == "cancel_both"
+	}), true).Return(&ApiResponse{Code: "00000", Data: data}, &fasthttp.ResponseHeader{}, nil)
+
+	_, err := NewCreatePlanOrderService(mockClient).
+		Symbol("BTCUSDT").
+		ProductType(ProductTypeUSDTFutures).
+		PlanType(PlanTypeNormalPlan).
+		TriggerPrice("50000").
+		Side(SideBuy).
+		OrderType(OrderTypeMarket).
+		Size("0.01").
+		StpMode(STPCancelBoth).
+		Do(context.Background())
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+
+	_, err = NewCreatePlanOrderService(mockClient).StpMode("bogus").Do(context.Background())
+	assert.EqualError(t, err, `unsupported stpMode "bogus"`)
+}
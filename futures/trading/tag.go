@@ -0,0 +1,112 @@
+package trading
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Limits of the parts of a tagged clientOid. Bitget accepts client order IDs of up to
+// 50 characters.
+const (
+	MaxTagStrategyLen = 16
+	MaxTagSessionLen  = 12
+)
+
+// tagSeparator splits the strategy, session and sequence of a tagged clientOid.
+const tagSeparator = "_"
+
+// Tag identifies the strategy and session that placed an order.
+type Tag struct {
+	Strategy string
+	Session  string
+}
+
+// String returns "strategy/session".
+func (t Tag) String() string {
+	return t.Strategy + "/" + t.Session
+}
+
+// Tagger generates clientOids of the form "strategy_session_sequence" so fills can be
+// attributed to the strategy that placed them when several strategies share one account:
+//
+//	tagger, err := trading.NewTagger("grid1", "")
+//	svc.ClientOrderId(tagger.Next()) // grid1_k3x9q2_lz4k1p0a1
+//	tag, ok := trading.ParseTag(order.ClientOid)
+//
+// It is safe for concurrent use.
+type Tagger struct {
+	tag   Tag
+	start string
+	seq   atomic.Uint64
+}
+
+// NewTagger creates a tagger for strategy. Strategy and session may only contain
+// letters and digits; an empty session is replaced by a random one, so restarts of the
+// same strategy can be told apart.
+func NewTagger(strategy, session string) (*Tagger, error) {
+	if session == "" {
+		session = NewSessionID()
+	}
+	if err := validTagPart("strategy", strategy, MaxTagStrategyLen); err != nil {
+		return nil, err
+	}
+	if err := validTagPart("session", session, MaxTagSessionLen); err != nil {
+		return nil, err
+	}
+	return &Tagger{
+		tag:   Tag{Strategy: strategy, Session: session},
+		start: strconv.FormatInt(time.Now().UnixMilli(), 36),
+	}, nil
+}
+
+// Tag returns the strategy and session of the tagger.
+func (t *Tagger) Tag() Tag {
+	return t.tag
+}
+
+// Next returns a new unique clientOid carrying the tag.
+func (t *Tagger) Next() string {
+	seq := strconv.FormatUint(t.seq.Add(1), 36)
+	return t.tag.Strategy + tagSeparator + t.tag.Session + tagSeparator + t.start + seq
+}
+
+// ParseTag extracts the tag of a clientOid generated by a Tagger.
+func ParseTag(clientOid string) (Tag, bool) {
+	parts := strings.Split(clientOid, tagSeparator)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return Tag{}, false
+	}
+	return Tag{Strategy: parts[0], Session: parts[1]}, true
+}
+
+// NewSessionID returns a random 8-character session ID.
+func NewSessionID() string {
+	const alphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)[:8]
+	}
+	for i := range b {
+		b[i] = alphabet[int(b[i])%len(alphabet)]
+	}
+	return string(b)
+}
+
+func validTagPart(name, value string, maxLen int) error {
+	if value == "" {
+		return fmt.Errorf("tag %s is required", name)
+	}
+	if len(value) > maxLen {
+		return fmt.Errorf("tag %s %q is longer than %d characters", name, value, maxLen)
+	}
+	for _, r := range value {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return fmt.Errorf("tag %s %q may only contain letters and digits", name, value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package trading
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagger(t *testing.T) {
+	tagger, err := NewTagger("grid1", "s1")
+	require.NoError(t, err)
+
+	a, b := tagger.Next(), tagger.Next()
+	assert.NotEqual(t, a, b)
+	assert.True(t, strings.HasPrefix(a, "grid1_s1_"))
+	assert.LessOrEqual(t, len(a), 50)
+
+	tag, ok := ParseTag(a)
+	require.True(t, ok)
+	assert.Equal(t, Tag{Strategy: "grid1", Session: "s1"}, tag)
+	assert.Equal(t, "grid1/s1", tag.String())
+
+	_, ok = ParseTag("q1697000000000")
+	assert.False(t, ok, "untagged clientOid")
+	_, ok = ParseTag("a__1")
+	assert.False(t, ok)
+}
+
+func TestNewTagger_Validation(t *testing.T) {
+	tagger, err := NewTagger("dca", "")
+	require.NoError(t, err)
+	assert.Len(t, tagger.Tag().Session, 8, "random session")
+
+	_, err = NewTagger("", "s1")
+	assert.ErrorContains(t, err, "strategy is required")
+	_, err = NewTagger("my_grid", "s1")
+	assert.ErrorContains(t, err, "letters and digits")
+	_, err = NewTagger("averyveryverylongstrategy", "s1")
+	assert.ErrorContains(t, err, "longer than 16")
+
+	long, err := NewTagger(strings.Repeat("s", MaxTagStrategyLen), strings.Repeat("x", MaxTagSessionLen))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(long.Next()), 50)
+}
+
+func TestAttribute(t *testing.T) {
+	orders := []HistoricalOrder{
+		{OrderId: "1", ClientOid: "grid1_s1_abc1"},
+		{OrderId: "2", ClientOid: "grid1_s2_abc1"},
+		{OrderId: "3", ClientOid: "dca_s1_abc1"},
+		{OrderId: "4", ClientOid: "manual-order"},
+	}
+	fills := []FillRecord{
+		{OrderId: "1", Symbol: "BTCUSDT", Size: "0.01", Amount: "500", Fee: "-0.3", Profit: "0"},
+		{OrderId: "1", Symbol: "BTCUSDT", Size: "0.01", Amount: "510", Fee: "-0.3", Profit: "10"},
+		{OrderId: "2", Symbol: "ETHUSDT", Size: "1", Amount: "3000", Fee: "-1.8", Profit: "-5"},
+		{OrderId: "3", Symbol: "BTCUSDT", Size: "0.02", Amount: "1000", Fee: "-0.6", Profit: "0"},
+		{OrderId: "4", Symbol: "BTCUSDT", Size: "0.1", Amount: "5000", Fee: "-3", Profit: "20"},
+		{OrderId: "9", Symbol: "SOLUSDT", Size: "1", Amount: "150", Fee: "-0.1", Profit: "0"},
+	}
+
+	report := Attribute(fills, OrderTags(orders))
+	require.Len(t, report.Tags, 3)
+	assert.Equal(t, "dca", report.Tags[0].Tag.Strategy, "sorted by strategy")
+
+	grid, ok := report.Get(Tag{Strategy: "grid1", Session: "s1"})
+	require.True(t, ok)
+	assert.Equal(t, 2, grid.Fills)
+	assert.Equal(t, 1, grid.Orders)
+	assert.InDelta(t, 0.02, grid.Volume, 1e-12)
+	assert.InDelta(t, 1010, grid.Notional, 1e-9)
+	assert.InDelta(t, -0.6, grid.Fees, 1e-12)
+	assert.InDelta(t, 10, grid.RealizedPnL, 1e-12)
+	assert.InDelta(t, 9.4, grid.NetPnL, 1e-12)
+
+	assert.Equal(t, 2, report.Untagged.Fills)
+	assert.Equal(t, 2, report.Untagged.Orders)
+	assert.Equal(t, []string{"BTCUSDT", "SOLUSDT"}, report.Untagged.Symbols)
+
+	byStrategy := report.ByStrategy()
+	require.Len(t, byStrategy.Tags, 2)
+	merged := byStrategy.Tags[1]
+	assert.Equal(t, "grid1", merged.Tag.Strategy)
+	assert.Equal(t, 3, merged.Fills)
+	assert.InDelta(t, 2.6, merged.NetPnL, 1e-9)
+	assert.Equal(t, []string{"BTCUSDT", "ETHUSDT"}, merged.Symbols)
+}
@@ -39,6 +39,22 @@ const (
 	SideTypeSell SideType = "SELL"
 )
 
+// Opposite returns the other order side.
+func (s Side) Opposite() Side {
+	if s == SideBuy {
+		return SideSell
+	}
+	return SideBuy
+}
+
+// HoldSide returns the side of the position an opening order on s builds.
+func (s Side) HoldSide() HoldSide {
+	if s == SideSell {
+		return HoldSideShort
+	}
+	return HoldSideLong
+}
+
 // Trigger types for plan orders
 type TriggerType string
 
@@ -54,6 +70,20 @@ const (
 	HoldSideShort HoldSide = "short"
 )
 
+// CloseSide returns the order side that closes a holdSide position. In hedge mode the
+// close order carries the side of the position it closes; in one-way mode it trades
+// against it.
+func CloseSide(holdSide HoldSide, hedge bool) Side {
+	side := SideBuy
+	if holdSide == HoldSideShort {
+		side = SideSell
+	}
+	if hedge {
+		return side
+	}
+	return side.Opposite()
+}
+
 // Plan order types
 type PlanType string
 
@@ -63,6 +93,13 @@ const (
 	PlanTypeStopLoss    PlanType = "stop_loss"    // Stop loss order
 	PlanTypeTakeProfit  PlanType = "take_profit"  // Take profit order
 	PlanTypeStopSurplus PlanType = "stop_surplus" // Stop surplus order
+
+	// Take-profit and stop-loss plan types of PlaceTPSLOrderService
+	PlanTypeProfitPlan PlanType = "profit_plan" // Partial take profit
+	PlanTypeLossPlan   PlanType = "loss_plan"   // Partial stop loss
+	PlanTypeMovingPlan PlanType = "moving_plan" // Trailing stop
+	PlanTypePosProfit  PlanType = "pos_profit"  // Take profit of the whole position
+	PlanTypePosLoss    PlanType = "pos_loss"    // Stop loss of the whole position
 )
 
 // Time in force options
@@ -109,6 +146,15 @@ const (
 	STPCancelBoth  SelfTradePreventionType = "cancel_both"
 )
 
+// validSTP reports whether mode is empty or one of the STP constants.
+func validSTP(mode SelfTradePreventionType) bool {
+	switch mode {
+	case "", STPNone, STPCancelTaker, STPCancelMaker, STPCancelBoth:
+		return true
+	}
+	return false
+}
+
 // Order info models for responses
 type OrderInfo struct {
 	OrderId       string `json:"orderId"`
@@ -149,6 +195,8 @@ const (
 	EndpointCreatePlanOrder   = "/api/v2/mix/order/place-plan-order"
 	EndpointModifyPlanOrder   = "/api/v2/mix/order/modify-plan-order"
 	EndpointPendingPlanOrders = "/api/v2/mix/order/plan-current"
+	EndpointPlaceTPSLOrder    = "/api/v2/mix/order/place-tpsl-order"
+	EndpointModifyTPSLOrder   = "/api/v2/mix/order/modify-tpsl-order"
 )
 
 // Service Constructor Functions
@@ -222,3 +270,23 @@ func NewCreateBatchOrdersService(client ClientInterface) *CreateBatchOrdersServi
 func NewBatchCancelOrdersService(client ClientInterface) *BatchCancelOrdersService {
 	return &BatchCancelOrdersService{c: client}
 }
+
+// NewCancelReplaceService creates a new cancel-replace service.
+func NewCancelReplaceService(client ClientInterface) *CancelReplaceService {
+	return &CancelReplaceService{c: client}
+}
+
+// NewChaseOrderService creates a new chase order service.
+func NewChaseOrderService(client ClientInterface) *ChaseOrderService {
+	return &ChaseOrderService{c: client}
+}
+
+// NewPlaceTPSLOrderService creates a new take-profit/stop-loss order service.
+func NewPlaceTPSLOrderService(client ClientInterface) *PlaceTPSLOrderService {
+	return &PlaceTPSLOrderService{c: client}
+}
+
+// NewModifyTPSLOrderService creates a new modify take-profit/stop-loss order service.
+func NewModifyTPSLOrderService(client ClientInterface) *ModifyTPSLOrderService {
+	return &ModifyTPSLOrderService{c: client}
+}
@@ -0,0 +1,277 @@
+// Package universe defines the tradable symbol set by rules instead of hard-coded lists.
+//
+// A Universe refreshes contract specifications and 24h tickers, applies its Rules and
+// notifies listeners when symbols enter or leave the set:
+//
+//	u := universe.New(client, futures.ProductTypeUSDTFutures, universe.Rules{
+//		QuoteCoins:     []string{"USDT"},
+//		MinQuoteVolume: 20_000_000,
+//		Exclude:        []string{"USDCUSDT"},
+//		MaxSymbols:     30,
+//	})
+//	u.OnChange(func(c universe.Change) { subscribe(c.Added); unsubscribe(c.Removed) })
+//	go u.Run(ctx, 10*time.Minute, nil)
+package universe
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+)
+
+// Rules select the symbols that belong to the universe. Empty fields do not filter.
+type Rules struct {
+	// QuoteCoins restricts contracts to these quote coins (e.g. "USDT").
+	QuoteCoins []string
+
+	// SymbolTypes restricts contracts to these types (e.g. "perpetual", "delivery").
+	SymbolTypes []string
+
+	// Statuses lists accepted contract statuses. Defaults to "normal" so symbols in
+	// maintenance, listing or delisting are left out.
+	Statuses []string
+
+	// MinQuoteVolume is the minimum 24h quote volume.
+	MinQuoteVolume float64
+
+	// Include always adds these symbols when they exist, bypassing the other rules.
+	Include []string
+
+	// Exclude removes these symbols.
+	Exclude []string
+
+	// MaxSymbols keeps only the most liquid symbols by 24h quote volume. Zero is unlimited.
+	MaxSymbols int
+
+	// Predicate is an optional custom rule evaluated last. ticker may be nil.
+	Predicate func(contract *market.Contract, ticker *market.Ticker) bool
+}
+
+// Change describes symbols entering and leaving the universe on a refresh.
+type Change struct {
+	Added   []string
+	Removed []string
+}
+
+// Empty reports whether the refresh changed nothing.
+func (c Change) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0
+}
+
+// Universe maintains the current symbol set. It is safe for concurrent use.
+type Universe struct {
+	client      futures.ClientInterface
+	productType futures.ProductType
+	rules       Rules
+
+	mu        sync.RWMutex
+	symbols   map[string]*market.Contract
+	listeners []func(Change)
+	refreshed time.Time
+}
+
+// New creates a universe. It is empty until the first Refresh.
+func New(client futures.ClientInterface, productType futures.ProductType, rules Rules) *Universe {
+	if len(rules.Statuses) == 0 {
+		rules.Statuses = []string{"normal"}
+	}
+	return &Universe{
+		client:      client,
+		productType: productType,
+		rules:       rules,
+		symbols:     make(map[string]*market.Contract),
+	}
+}
+
+// OnChange registers a listener called after every refresh that changed the set.
+func (u *Universe) OnChange(fn func(Change)) {
+	u.mu.Lock()
+	u.listeners = append(u.listeners, fn)
+	u.mu.Unlock()
+}
+
+// Refresh reloads contracts and tickers, applies the rules and notifies listeners.
+func (u *Universe) Refresh(ctx context.Context) (Change, error) {
+	contracts, err := market.NewContractsService(u.client).ProductType(u.productType).Do(ctx)
+	if err != nil {
+		return Change{}, fmt.Errorf("fetch contracts: %w", err)
+	}
+
+	var tickers []*market.Ticker
+	if u.rules.MinQuoteVolume > 0 || u.rules.MaxSymbols > 0 || u.rules.Predicate != nil {
+		tickers, err = market.NewAllTickersService(u.client).ProductType(u.productType).Do(ctx)
+		if err != nil {
+			return Change{}, fmt.Errorf("fetch tickers: %w", err)
+		}
+	}
+
+	next := u.apply(contracts, tickers)
+
+	u.mu.Lock()
+	var change Change
+	for sym := range next {
+		if _, ok := u.symbols[sym]; !ok {
+			change.Added = append(change.Added, sym)
+		}
+	}
+	for sym := range u.symbols {
+		if _, ok := next[sym]; !ok {
+			change.Removed = append(change.Removed, sym)
+		}
+	}
+	sort.Strings(change.Added)
+	sort.Strings(change.Removed)
+	u.symbols = next
+	u.refreshed = time.Now()
+	listeners := append([]func(Change){}, u.listeners...)
+	u.mu.Unlock()
+
+	if !change.Empty() {
+		for _, fn := range listeners {
+			fn(change)
+		}
+	}
+	return change, nil
+}
+
+// Run refreshes immediately and then every interval until ctx is cancelled.
+// Refresh errors are passed to onError when it is non-nil; the previous set is kept.
+func (u *Universe) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := u.Refresh(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Symbols returns the current symbols in alphabetical order.
+func (u *Universe) Symbols() []string {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	out := make([]string, 0, len(u.symbols))
+	for sym := range u.symbols {
+		out = append(out, sym)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Contains reports whether symbol is in the universe.
+func (u *Universe) Contains(symbol string) bool {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	_, ok := u.symbols[symbol]
+	return ok
+}
+
+// Contract returns the contract specification of a symbol in the universe.
+func (u *Universe) Contract(symbol string) (*market.Contract, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	c, ok := u.symbols[symbol]
+	return c, ok
+}
+
+// LastRefresh returns the time of the last successful refresh.
+func (u *Universe) LastRefresh() time.Time {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return u.refreshed
+}
+
+// apply evaluates the rules against the fetched data.
+func (u *Universe) apply(contracts []*market.Contract, tickers []*market.Ticker) map[string]*market.Contract {
+	r := u.rules
+	byTicker := make(map[string]*market.Ticker, len(tickers))
+	for _, t := range tickers {
+		byTicker[t.Symbol] = t
+	}
+	excluded := set(r.Exclude)
+	included := set(r.Include)
+
+	type entry struct {
+		contract *market.Contract
+		volume   float64
+	}
+	var selected []entry
+	forced := make(map[string]*market.Contract)
+
+	for _, c := range contracts {
+		if excluded[c.Symbol] {
+			continue
+		}
+		ticker := byTicker[c.Symbol]
+		if included[c.Symbol] {
+			forced[c.Symbol] = c
+			continue
+		}
+
+		if len(r.QuoteCoins) > 0 && !containsFold(r.QuoteCoins, c.QuoteCoin) {
+			continue
+		}
+		if len(r.SymbolTypes) > 0 && !containsFold(r.SymbolTypes, c.SymbolType) {
+			continue
+		}
+		if !containsFold(r.Statuses, c.SymbolStatus) {
+			continue
+		}
+
+		var volume float64
+		if ticker != nil {
+			volume, _ = strconv.ParseFloat(ticker.QuoteVolume, 64)
+		}
+		if r.MinQuoteVolume > 0 && volume < r.MinQuoteVolume {
+			continue
+		}
+		if r.Predicate != nil && !r.Predicate(c, ticker) {
+			continue
+		}
+		selected = append(selected, entry{contract: c, volume: volume})
+	}
+
+	if r.MaxSymbols > 0 && len(selected) > r.MaxSymbols {
+		sort.SliceStable(selected, func(i, j int) bool { return selected[i].volume > selected[j].volume })
+		selected = selected[:r.MaxSymbols]
+	}
+
+	out := make(map[string]*market.Contract, len(selected)+len(forced))
+	for _, e := range selected {
+		out[e.contract.Symbol] = e.contract
+	}
+	for sym, c := range forced {
+		out[sym] = c
+	}
+	return out
+}
+
+func set(values []string) map[string]bool {
+	out := make(map[string]bool, len(values))
+	for _, v := range values {
+		out[v] = true
+	}
+	return out
+}
+
+func containsFold(values []string, v string) bool {
+	for _, x := range values {
+		if strings.EqualFold(x, v) {
+			return true
+		}
+	}
+	return false
+}
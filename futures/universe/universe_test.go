@@ -0,0 +1,109 @@
+package universe
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeClient struct {
+	contracts []map[string]string
+	tickers   []map[string]string
+}
+
+func (f *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	var data []byte
+	switch endpoint {
+	case futures.EndpointContracts:
+		data, _ = json.Marshal(f.contracts)
+	case futures.EndpointAllTickers:
+		data, _ = json.Marshal(f.tickers)
+	}
+	return &futures.ApiResponse{Code: "00000", Data: data}, &fasthttp.ResponseHeader{}, nil
+}
+
+func contract(symbol, quote, status string) map[string]string {
+	return map[string]string{"symbol": symbol, "quoteCoin": quote, "symbolStatus": status, "symbolType": "perpetual"}
+}
+
+func newFake() *fakeClient {
+	return &fakeClient{
+		contracts: []map[string]string{
+			contract("BTCUSDT", "USDT", "normal"),
+			contract("ETHUSDT", "USDT", "normal"),
+			contract("XRPUSDT", "USDT", "maintain"),
+			contract("SOLUSDT", "USDT", "normal"),
+			contract("BTCPERP", "USDC", "normal"),
+		},
+		tickers: []map[string]string{
+			{"symbol": "BTCUSDT", "quoteVolume": "900"},
+			{"symbol": "ETHUSDT", "quoteVolume": "500"},
+			{"symbol": "XRPUSDT", "quoteVolume": "800"},
+			{"symbol": "SOLUSDT", "quoteVolume": "50"},
+			{"symbol": "BTCPERP", "quoteVolume": "700"},
+		},
+	}
+}
+
+func TestUniverse_Rules(t *testing.T) {
+	u := New(newFake(), futures.ProductTypeUSDTFutures, Rules{
+		QuoteCoins:     []string{"usdt"},
+		MinQuoteVolume: 100,
+	})
+
+	change, err := u.Refresh(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BTCUSDT", "ETHUSDT"}, change.Added)
+	assert.Equal(t, []string{"BTCUSDT", "ETHUSDT"}, u.Symbols())
+	assert.False(t, u.Contains("XRPUSDT"), "maintenance symbols are excluded by default")
+	assert.False(t, u.LastRefresh().IsZero())
+
+	c, ok := u.Contract("BTCUSDT")
+	require.True(t, ok)
+	assert.Equal(t, "USDT", c.QuoteCoin)
+}
+
+func TestUniverse_IncludeExcludeMax(t *testing.T) {
+	u := New(newFake(), futures.ProductTypeUSDTFutures, Rules{
+		Include:    []string{"SOLUSDT"},
+		Exclude:    []string{"BTCUSDT"},
+		MaxSymbols: 1,
+		Predicate: func(c *market.Contract, t *market.Ticker) bool {
+			return c.QuoteCoin == "USDT"
+		},
+	})
+
+	_, err := u.Refresh(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ETHUSDT", "SOLUSDT"}, u.Symbols())
+}
+
+func TestUniverse_NotifiesChanges(t *testing.T) {
+	client := newFake()
+	u := New(client, futures.ProductTypeUSDTFutures, Rules{MinQuoteVolume: 100, QuoteCoins: []string{"USDT"}})
+
+	var changes []Change
+	u.OnChange(func(c Change) { changes = append(changes, c) })
+
+	_, err := u.Refresh(context.Background())
+	require.NoError(t, err)
+
+	// ETH volume drops and SOL picks up
+	client.tickers[1]["quoteVolume"] = "10"
+	client.tickers[3]["quoteVolume"] = "200"
+	change, err := u.Refresh(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Change{Added: []string{"SOLUSDT"}, Removed: []string{"ETHUSDT"}}, change)
+
+	// Unchanged refresh does not notify
+	_, err = u.Refresh(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, changes, 2)
+}
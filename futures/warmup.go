@@ -0,0 +1,39 @@
+package futures
+
+import (
+	"context"
+	"fmt"
+)
+
+// WarmUp opens conns connections to the REST host, TLS handshakes included, by sending
+// concurrent requests to the public server time endpoint, and keys conns signing states,
+// so the first orders after start-up or an idle period do not pay for either. conns
+// below one opens a single connection.
+//
+// Idle connections are closed after the idle timeout (see WithIdleConnTimeout), so call
+// WarmUp again before trading after a longer pause. health.ProbeLatency shows the
+// difference: the first samples of a cold client include the handshake.
+func (c *Client) WarmUp(ctx context.Context, conns int) error {
+	if conns < 1 {
+		conns = 1
+	}
+	c.signer.Prepare(conns)
+
+	errs := make(chan error, conns)
+	for i := 0; i < conns; i++ {
+		go func() {
+			_, _, err := c.CallAPI(ctx, "GET", EndpointServerTime, nil, nil, false)
+			errs <- err
+		}()
+	}
+	var first error
+	for i := 0; i < conns; i++ {
+		if err := <-errs; err != nil && first == nil {
+			first = err
+		}
+	}
+	if first != nil {
+		return fmt.Errorf("warm-up: %w", first)
+	}
+	return nil
+}
@@ -0,0 +1,356 @@
+// Package webhook forwards account, position and order events to external systems as
+// signed HTTP webhooks, so CRMs, risk dashboards and the like can react to account
+// activity without connecting to Bitget.
+//
+// An Emitter queues events from the trackers and the account channel and delivers them
+// in order to a single URL, retrying failed deliveries with exponential backoff:
+//
+//	hooks := webhook.New(webhook.Options{
+//		URL:    "https://risk.example.com/bitget",
+//		Secret: []byte(os.Getenv("WEBHOOK_SECRET")),
+//	})
+//	orders.OnEvent(hooks.HandleOrderEvent)       // tracker.OrderTracker
+//	positions.OnEvent(hooks.HandlePositionEvent) // tracker.PositionTracker
+//	wsClient.SubscribeAccount("default", "USDT-FUTURES", hooks.HandleAccountMessage)
+//	go hooks.Run(ctx)
+//	defer hooks.Shutdown(context.Background()) // delivers the queued events
+//
+// Each delivery is a POST of the JSON Event with these headers:
+//
+//	X-Webhook-Id         the event ID, unchanged across retries for deduplication
+//	X-Webhook-Timestamp  the event time in Unix milliseconds
+//	X-Signature          "sha256=" and the hex HMAC-SHA256 of the body with Secret
+//
+// Receivers check the signature with Verify.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures/signals"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+)
+
+// Event types are "order." or "position." followed by the tracker event type, e.g.
+// "order.filled" or "position.closed", and TypeAccount for balance updates.
+const (
+	TypeAccount = "account.updated"
+)
+
+// Event is the body of a webhook.
+type Event struct {
+	ID     string          `json:"id"`
+	Type   string          `json:"type"`
+	Time   time.Time       `json:"time"`
+	Tenant string          `json:"tenant,omitempty"` // Account or user, see Options.Tenant
+	Data   json.RawMessage `json:"data"`
+}
+
+// Options configures an Emitter.
+type Options struct {
+	// URL receives the webhooks. Required.
+	URL string
+	// Secret signs the body in the X-Signature header. Deliveries are unsigned when empty.
+	Secret []byte
+	// Headers are added to every request, e.g. an Authorization header. Optional.
+	Headers map[string]string
+
+	// Retries is the number of retries of a failed delivery. Defaults to 3; negative
+	// disables retries. Responses other than 408, 429 and 5xx are not retried.
+	Retries int
+	// Backoff is the delay before the first retry, doubled for each further retry.
+	// Defaults to one second.
+	Backoff time.Duration
+	// Timeout bounds each request. Defaults to 10 seconds.
+	Timeout time.Duration
+	// HTTPClient overrides the default client. Optional.
+	HTTPClient *http.Client
+
+	// Buffer is the number of queued events. Defaults to 256. When the queue is full new
+	// events are dropped rather than blocking the caller; see Dropped.
+	Buffer int
+
+	// Types restricts the forwarded events, e.g. {"order.filled", "position.closed"}.
+	// Empty forwards every event.
+	Types []string
+
+	// Tenant labels events passed to Emit and HandleAccountMessage. Tracker events carry
+	// the tenant of their tracker instead, so one emitter can serve several accounts.
+	// Optional.
+	Tenant string
+
+	// OnError receives deliveries that failed after all retries. Optional.
+	OnError func(error)
+	// Clock stamps events and times the retry backoff. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// Emitter delivers events to a webhook URL. It is safe for concurrent use.
+type Emitter struct {
+	opts   Options
+	client *http.Client
+	types  map[string]bool
+	queue  chan Event
+	clock  common.Clock
+
+	seq       atomic.Uint64
+	dropped   atomic.Uint64
+	delivered atomic.Uint64
+
+	mu      sync.Mutex
+	closed  bool
+	running sync.WaitGroup
+}
+
+// New creates an emitter. Events are queued until Run delivers them.
+func New(opts Options) *Emitter {
+	if opts.Retries == 0 {
+		opts.Retries = 3
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.Buffer <= 0 {
+		opts.Buffer = 256
+	}
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: opts.Timeout}
+	}
+	e := &Emitter{opts: opts, client: client, queue: make(chan Event, opts.Buffer), clock: common.ClockOrSystem(opts.Clock)}
+	if len(opts.Types) > 0 {
+		e.types = make(map[string]bool, len(opts.Types))
+		for _, t := range opts.Types {
+			e.types[t] = true
+		}
+	}
+	return e
+}
+
+// Emit queues an event of type typ with data encoded as JSON. It reports whether the
+// event was queued; filtered events and events dropped on a full queue return false.
+func (e *Emitter) Emit(typ string, data interface{}) bool {
+	return e.emit(typ, e.opts.Tenant, data)
+}
+
+func (e *Emitter) emit(typ, tenant string, data interface{}) bool {
+	if e.types != nil && !e.types[typ] {
+		return false
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		e.error(fmt.Errorf("webhook: encode %s: %w", typ, err))
+		return false
+	}
+	now := e.clock.Now()
+	ev := Event{
+		ID:     strconv.FormatInt(now.UnixMilli(), 10) + "-" + strconv.FormatUint(e.seq.Add(1), 10),
+		Type:   typ,
+		Time:   now,
+		Tenant: tenant,
+		Data:   raw,
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return false
+	}
+	select {
+	case e.queue <- ev:
+		return true
+	default:
+		e.dropped.Add(1)
+		return false
+	}
+}
+
+// HandleOrderEvent forwards an order event. Pass it to tracker.OrderTracker.OnEvent.
+func (e *Emitter) HandleOrderEvent(ev tracker.OrderEvent) {
+	e.emit("order."+string(ev.Type), e.tenant(ev.Tenant), ev.Order)
+}
+
+// HandlePositionEvent forwards a position event. Pass it to
+// tracker.PositionTracker.OnEvent.
+func (e *Emitter) HandlePositionEvent(ev tracker.PositionEvent) {
+	e.emit("position."+string(ev.Type), e.tenant(ev.Tenant), ev.Position)
+}
+
+// HandleAccountMessage forwards each entry of an account channel push as a TypeAccount
+// event, with the fields as pushed. Its signature matches ws.OnReceive.
+func (e *Emitter) HandleAccountMessage(message string) {
+	var msg struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(message), &msg); err != nil {
+		e.error(fmt.Errorf("webhook: decode account message: %w", err))
+		return
+	}
+	for _, data := range msg.Data {
+		e.Emit(TypeAccount, data)
+	}
+}
+
+// Run delivers queued events in order until ctx is cancelled or Shutdown is called.
+func (e *Emitter) Run(ctx context.Context) {
+	e.running.Add(1)
+	defer e.running.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-e.queue:
+			if !ok {
+				return
+			}
+			if err := e.Deliver(ctx, ev); err != nil {
+				e.error(err)
+			}
+		}
+	}
+}
+
+// Shutdown stops accepting events and delivers those still queued until ctx expires.
+// It implements lifecycle.Component.
+func (e *Emitter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	if !e.closed {
+		e.closed = true
+		close(e.queue)
+	}
+	e.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.running.Wait() // A running Run drains the queue first
+		for ev := range e.queue {
+			if ctx.Err() != nil {
+				return
+			}
+			if err := e.Deliver(ctx, ev); err != nil {
+				e.error(err)
+			}
+		}
+	}()
+	select {
+	case <-done:
+		if ctx.Err() != nil {
+			return fmt.Errorf("webhook: %d events not delivered: %w", len(e.queue), ctx.Err())
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("webhook: %d events not delivered: %w", len(e.queue), ctx.Err())
+	}
+}
+
+// Deliver posts ev, retrying failures as configured.
+func (e *Emitter) Deliver(ctx context.Context, ev Event) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("webhook: encode %s: %w", ev.ID, err)
+	}
+	backoff := e.opts.Backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := e.post(ctx, ev, body)
+		if err == nil {
+			e.delivered.Add(1)
+			return nil
+		}
+		if !retry || attempt >= e.opts.Retries {
+			return fmt.Errorf("webhook: deliver %s %s: %w", ev.Type, ev.ID, err)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("webhook: deliver %s %s: %w", ev.Type, ev.ID, ctx.Err())
+		case <-e.clock.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post sends one request and reports whether a failure may be retried.
+func (e *Emitter) post(ctx context.Context, ev Event, body []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.opts.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("X-Webhook-Id", ev.ID)
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(ev.Time.UnixMilli(), 10))
+	if len(e.opts.Secret) > 0 {
+		req.Header.Set("X-Signature", signals.Sign(e.opts.Secret, body))
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests
+	return retry, err
+}
+
+// Dropped returns the number of events discarded because the queue was full.
+func (e *Emitter) Dropped() uint64 {
+	return e.dropped.Load()
+}
+
+// Delivered returns the number of events delivered.
+func (e *Emitter) Delivered() uint64 {
+	return e.delivered.Load()
+}
+
+// tenant returns the tenant of a tracker event, defaulting to Options.Tenant.
+func (e *Emitter) tenant(tenant string) string {
+	if tenant == "" {
+		return e.opts.Tenant
+	}
+	return tenant
+}
+
+func (e *Emitter) error(err error) {
+	if e.opts.OnError != nil {
+		e.opts.OnError(err)
+	}
+}
+
+// Verify reports whether signature, the X-Signature header of a delivery, matches body
+// under secret.
+func Verify(secret, body []byte, signature string) bool {
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
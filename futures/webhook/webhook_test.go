@@ -0,0 +1,153 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures/signals"
+	"github.com/khanbekov/go-bitget/futures/tracker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type received struct {
+	Event
+	Signature string
+	Header    http.Header
+}
+
+// receiver records deliveries and answers with the queued status codes, then 200.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	events   []received
+	attempts int
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts++
+	if len(r.statuses) > 0 {
+		status := r.statuses[0]
+		r.statuses = r.statuses[1:]
+		w.WriteHeader(status)
+		return
+	}
+	var ev Event
+	_ = json.Unmarshal(body, &ev)
+	r.events = append(r.events, received{Event: ev, Signature: req.Header.Get("X-Signature"), Header: req.Header.Clone()})
+	if !Verify([]byte("secret"), body, req.Header.Get("X-Signature")) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+}
+
+func (r *receiver) received() []received {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]received(nil), r.events...)
+}
+
+func newEmitter(t *testing.T, r *receiver, opts Options) *Emitter {
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	opts.URL = srv.URL
+	opts.Secret = []byte("secret")
+	if opts.Backoff == 0 {
+		opts.Backoff = time.Millisecond
+	}
+	return New(opts)
+}
+
+func TestEmitter_DeliversSignedEvents(t *testing.T) {
+	r := &receiver{}
+	e := newEmitter(t, r, Options{Headers: map[string]string{"Authorization": "Bearer token"}})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go e.Run(ctx)
+
+	e.HandleOrderEvent(tracker.OrderEvent{Type: tracker.OrderFilled, Order: tracker.Order{OrderID: "1", Symbol: "BTCUSDT"}})
+	e.HandlePositionEvent(tracker.PositionEvent{Type: tracker.PositionOpened, Position: tracker.Position{Symbol: "BTCUSDT", HoldSide: "long", Size: 0.01}})
+	e.HandleAccountMessage(`{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"account","coin":"default"},"data":[{"marginCoin":"USDT","available":"100"}]}`)
+	require.Eventually(t, func() bool { return e.Delivered() == 3 }, time.Second, time.Millisecond)
+
+	events := r.received()
+	require.Len(t, events, 3)
+	assert.Equal(t, "order.filled", events[0].Type)
+	assert.Equal(t, "position.opened", events[1].Type)
+	assert.Equal(t, TypeAccount, events[2].Type)
+	assert.JSONEq(t, `{"marginCoin":"USDT","available":"100"}`, string(events[2].Data))
+
+	var order tracker.Order
+	require.NoError(t, json.Unmarshal(events[0].Data, &order))
+	assert.Equal(t, "1", order.OrderID)
+	assert.Equal(t, events[0].ID, events[0].Header.Get("X-Webhook-Id"))
+	assert.Equal(t, "Bearer token", events[0].Header.Get("Authorization"))
+	assert.Contains(t, events[0].Signature, "sha256=")
+}
+
+func TestEmitter_Tenant(t *testing.T) {
+	r := &receiver{}
+	e := newEmitter(t, r, Options{Tenant: "main"})
+	e.HandleOrderEvent(tracker.OrderEvent{Type: tracker.OrderNew, Order: tracker.Order{OrderID: "1"}, Tenant: "alice"})
+	e.HandlePositionEvent(tracker.PositionEvent{Type: tracker.PositionOpened})
+	require.NoError(t, e.Shutdown(context.Background()))
+
+	events := r.received()
+	require.Len(t, events, 2)
+	assert.Equal(t, "alice", events[0].Tenant, "the tracker tenant wins")
+	assert.Equal(t, "main", events[1].Tenant)
+}
+
+func TestEmitter_RetriesServerErrors(t *testing.T) {
+	r := &receiver{statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}}
+	e := newEmitter(t, r, Options{})
+
+	require.NoError(t, e.Deliver(context.Background(), Event{ID: "1", Type: "order.new", Data: json.RawMessage(`{}`)}))
+	assert.Equal(t, 3, r.attempts)
+	assert.Len(t, r.received(), 1)
+}
+
+func TestEmitter_GivesUp(t *testing.T) {
+	r := &receiver{statuses: []int{http.StatusBadRequest}}
+	e := newEmitter(t, r, Options{})
+	err := e.Deliver(context.Background(), Event{ID: "1", Type: "order.new", Data: json.RawMessage(`{}`)})
+	assert.ErrorContains(t, err, "400 Bad Request")
+	assert.Equal(t, 1, r.attempts, "client errors are not retried")
+
+	r = &receiver{statuses: []int{500, 500, 500}}
+	e = newEmitter(t, r, Options{Retries: 2})
+	err = e.Deliver(context.Background(), Event{ID: "2", Type: "order.new", Data: json.RawMessage(`{}`)})
+	assert.ErrorContains(t, err, "webhook: deliver order.new 2: 500")
+	assert.Equal(t, 3, r.attempts)
+}
+
+func TestEmitter_FiltersAndShutdown(t *testing.T) {
+	r := &receiver{}
+	e := newEmitter(t, r, Options{Types: []string{"order.filled"}, Buffer: 1})
+
+	assert.False(t, e.Emit("order.new", nil), "filtered")
+	assert.True(t, e.Emit("order.filled", nil))
+	assert.False(t, e.Emit("order.filled", nil), "queue full")
+	assert.Equal(t, uint64(1), e.Dropped())
+
+	require.NoError(t, e.Shutdown(context.Background()))
+	assert.Len(t, r.received(), 1, "queued events are delivered on shutdown")
+	assert.False(t, e.Emit("order.filled", nil), "closed")
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	sig := signals.Sign([]byte("secret"), body)
+	assert.True(t, Verify([]byte("secret"), body, sig))
+	assert.False(t, Verify([]byte("other"), body, sig))
+	assert.False(t, Verify([]byte("secret"), []byte(`{"id":"2"}`), sig))
+	assert.False(t, Verify([]byte("secret"), body, "sha256=zz"))
+}
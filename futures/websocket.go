@@ -7,8 +7,6 @@ import (
 	"fmt"
 	"github.com/khanbekov/go-bitget/common"
 	"github.com/khanbekov/go-bitget/ws"
-	"github.com/rs/zerolog"
-	"os"
 	"time"
 )
 
@@ -154,7 +152,7 @@ func (w *WebSocketClientAdapter) GetSubscriptionCount() int {
 type WebSocketManager struct {
 	client        *Client
 	wsClient      WebSocketClientInterface
-	logger        zerolog.Logger
+	logger        common.Logger
 	isPrivate     bool
 	isConnected   bool
 	isLoggedIn    bool
@@ -163,24 +161,24 @@ type WebSocketManager struct {
 
 // NewWebSocketManager creates a new WebSocket manager for the futures client
 func (c *Client) NewWebSocketManager() *WebSocketManager {
-	logger := zerolog.New(os.Stderr).With().Timestamp().Logger()
-
 	return &WebSocketManager{
 		client:        c,
-		logger:        logger,
+		logger:        c.Logger,
 		autoReconnect: true,
 	}
 }
 
 // ConnectPublic connects to public WebSocket channels for market data
 func (wm *WebSocketManager) ConnectPublic() error {
-	wm.logger.Info().Msg("Connecting to Bitget public WebSocket...")
+	wm.logger.Info("Connecting to Bitget public WebSocket...")
 
-	baseClient := ws.NewBitgetBaseWsClient(
+	baseClient := ws.NewBaseWsClient(
 		wm.logger,
-		"wss://ws.bitget.com/v2/ws/public",
+		wm.client.environment.PublicWSURL(),
 		"",
 	)
+	baseClient.SetClock(wm.client.clock)
+	baseClient.SetRetryBudget(wm.client.retryBudget)
 	wm.wsClient = &WebSocketClientAdapter{BaseWsClient: baseClient}
 
 	wm.wsClient.SetListener(wm.defaultMessageHandler, wm.errorHandler)
@@ -201,7 +199,7 @@ func (wm *WebSocketManager) ConnectPublic() error {
 			if wm.wsClient.IsConnected() {
 				wm.isConnected = true
 				wm.isPrivate = false
-				wm.logger.Info().Msg("Successfully connected to public WebSocket")
+				wm.logger.Info("Successfully connected to public WebSocket")
 				return nil
 			}
 		}
@@ -210,13 +208,15 @@ func (wm *WebSocketManager) ConnectPublic() error {
 
 // ConnectPrivate connects to private WebSocket channels for account updates
 func (wm *WebSocketManager) ConnectPrivate(apiKey, passphrase string) error {
-	wm.logger.Info().Msg("Connecting to Bitget private WebSocket...")
+	wm.logger.Info("Connecting to Bitget private WebSocket...")
 
-	baseClient := ws.NewBitgetBaseWsClient(
+	baseClient := ws.NewBaseWsClient(
 		wm.logger,
-		"wss://ws.bitget.com/v2/ws/private",
+		wm.client.environment.PrivateWSURL(),
 		wm.client.secretKey,
 	)
+	baseClient.SetClock(wm.client.clock)
+	baseClient.SetRetryBudget(wm.client.retryBudget)
 	wm.wsClient = &WebSocketClientAdapter{BaseWsClient: baseClient}
 
 	wm.wsClient.SetListener(wm.defaultMessageHandler, wm.errorHandler)
@@ -253,7 +253,7 @@ func (wm *WebSocketManager) ConnectPrivate(apiKey, passphrase string) error {
 					case <-loginTicker.C:
 						if wm.wsClient.IsLoggedIn() {
 							wm.isLoggedIn = true
-							wm.logger.Info().Msg("Successfully connected and authenticated to private WebSocket")
+							wm.logger.Info("Successfully connected and authenticated to private WebSocket")
 							return nil
 						}
 					}
@@ -272,7 +272,7 @@ func (wm *WebSocketManager) SubscribeToTicker(symbol string, handler ws.OnReceiv
 	}
 
 	wm.wsClient.SubscribeTicker(symbol, string(ProductTypeUSDTFutures), handler)
-	wm.logger.Info().Str("symbol", symbol).Msg("Subscribed to ticker updates")
+	wm.logger.Info("Subscribed to ticker updates", "symbol", symbol)
 	return nil
 }
 
@@ -283,7 +283,7 @@ func (wm *WebSocketManager) SubscribeToCandlesticks(symbol, timeframe string, ha
 	}
 
 	wm.wsClient.SubscribeCandles(symbol, string(ProductTypeUSDTFutures), timeframe, handler)
-	wm.logger.Info().Str("symbol", symbol).Str("timeframe", timeframe).Msg("Subscribed to candlestick updates")
+	wm.logger.Info("Subscribed to candlestick updates", "symbol", symbol, "timeframe", timeframe)
 	return nil
 }
 
@@ -302,7 +302,7 @@ func (wm *WebSocketManager) SubscribeToOrderBook(symbol string, levels int, hand
 		wm.wsClient.SubscribeOrderBook(symbol, string(ProductTypeUSDTFutures), handler)
 	}
 
-	wm.logger.Info().Str("symbol", symbol).Int("levels", levels).Msg("Subscribed to order book updates")
+	wm.logger.Info("Subscribed to order book updates", "symbol", symbol, "levels", levels)
 	return nil
 }
 
@@ -313,7 +313,7 @@ func (wm *WebSocketManager) SubscribeToTrades(symbol string, handler ws.OnReceiv
 	}
 
 	wm.wsClient.SubscribeTrades(symbol, string(ProductTypeUSDTFutures), handler)
-	wm.logger.Info().Str("symbol", symbol).Msg("Subscribed to trade updates")
+	wm.logger.Info("Subscribed to trade updates", "symbol", symbol)
 	return nil
 }
 
@@ -324,7 +324,7 @@ func (wm *WebSocketManager) SubscribeToMarkPrice(symbol string, handler ws.OnRec
 	}
 
 	wm.wsClient.SubscribeMarkPrice(symbol, string(ProductTypeUSDTFutures), handler)
-	wm.logger.Info().Str("symbol", symbol).Msg("Subscribed to mark price updates")
+	wm.logger.Info("Subscribed to mark price updates", "symbol", symbol)
 	return nil
 }
 
@@ -335,7 +335,7 @@ func (wm *WebSocketManager) SubscribeToFunding(symbol string, handler ws.OnRecei
 	}
 
 	wm.wsClient.SubscribeFundingTime(symbol, string(ProductTypeUSDTFutures), handler)
-	wm.logger.Info().Str("symbol", symbol).Msg("Subscribed to funding updates")
+	wm.logger.Info("Subscribed to funding updates", "symbol", symbol)
 	return nil
 }
 
@@ -348,7 +348,7 @@ func (wm *WebSocketManager) SubscribeToOrders(handler ws.OnReceive) error {
 	}
 
 	wm.wsClient.SubscribeOrders(string(ProductTypeUSDTFutures), handler)
-	wm.logger.Info().Msg("Subscribed to order updates")
+	wm.logger.Info("Subscribed to order updates")
 	return nil
 }
 
@@ -359,7 +359,7 @@ func (wm *WebSocketManager) SubscribeToFills(handler ws.OnReceive) error {
 	}
 
 	wm.wsClient.SubscribeFills("default", string(ProductTypeUSDTFutures), handler)
-	wm.logger.Info().Msg("Subscribed to fill updates")
+	wm.logger.Info("Subscribed to fill updates")
 	return nil
 }
 
@@ -370,7 +370,7 @@ func (wm *WebSocketManager) SubscribeToPositions(handler ws.OnReceive) error {
 	}
 
 	wm.wsClient.SubscribePositions(string(ProductTypeUSDTFutures), handler)
-	wm.logger.Info().Msg("Subscribed to position updates")
+	wm.logger.Info("Subscribed to position updates")
 	return nil
 }
 
@@ -381,7 +381,7 @@ func (wm *WebSocketManager) SubscribeToAccount(handler ws.OnReceive) error {
 	}
 
 	wm.wsClient.SubscribeAccount("default", string(ProductTypeUSDTFutures), handler)
-	wm.logger.Info().Msg("Subscribed to account updates")
+	wm.logger.Info("Subscribed to account updates")
 	return nil
 }
 
@@ -411,13 +411,16 @@ func (wm *WebSocketManager) Close() error {
 		wm.wsClient.Close()
 		wm.isConnected = false
 		wm.isLoggedIn = false
-		wm.logger.Info().Msg("WebSocket connection closed")
+		wm.logger.Info("WebSocket connection closed")
 	}
 	return nil
 }
 
 // SetLogger allows custom logger configuration
-func (wm *WebSocketManager) SetLogger(logger zerolog.Logger) {
+func (wm *WebSocketManager) SetLogger(logger common.Logger) {
+	if logger == nil {
+		logger = common.NopLogger()
+	}
 	wm.logger = logger
 }
 
@@ -443,11 +446,11 @@ func (wm *WebSocketManager) SetConnectionCheckInterval(interval time.Duration) {
 // Message handlers
 
 func (wm *WebSocketManager) defaultMessageHandler(message string) {
-	wm.logger.Debug().Str("message", message).Msg("WebSocket message received")
+	wm.logger.Debug("WebSocket message received", "message", message)
 }
 
 func (wm *WebSocketManager) errorHandler(message string) {
-	wm.logger.Error().Str("error", message).Msg("WebSocket error received")
+	wm.logger.Error("WebSocket error received", "error", message)
 }
 
 // High-level convenience methods
@@ -484,7 +487,9 @@ func (wm *WebSocketManager) CreateMarketDataStream(ctx context.Context, symbols
 		}
 	}
 
-	wm.logger.Info().Int("symbols", len(symbols)).Int("subscriptions", wm.GetSubscriptionCount()).Msg("Market data stream created")
+	wm.logger.Info("Market data stream created",
+		"symbols", len(symbols),
+		"subscriptions", wm.GetSubscriptionCount())
 	return nil
 }
 
@@ -510,7 +515,7 @@ func (wm *WebSocketManager) CreateTradingStream(ctx context.Context, apiKey, pas
 		wm.SubscribeToAccount(config.AccountHandler)
 	}
 
-	wm.logger.Info().Int("subscriptions", wm.GetSubscriptionCount()).Msg("Trading stream created")
+	wm.logger.Info("Trading stream created", "subscriptions", wm.GetSubscriptionCount())
 	return nil
 }
 
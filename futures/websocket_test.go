@@ -6,7 +6,6 @@ import (
 
 	"github.com/khanbekov/go-bitget/common"
 	"github.com/khanbekov/go-bitget/ws"
-	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -153,7 +152,7 @@ func createTestWebSocketManager() *WebSocketManager {
 	
 	return &WebSocketManager{
 		client:  client,
-		logger:  zerolog.Nop(), // Silent logger for tests
+		logger:  common.NopLogger(), // Silent logger for tests
 		wsClient: mockClient,
 	}
 }
@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+)
+
+// Connection is implemented by ws.BaseWsClient.
+type Connection interface {
+	IsConnected() bool
+}
+
+// RESTCheck verifies the REST API is reachable using the unauthenticated server time
+// endpoint.
+func RESTCheck(client futures.ClientInterface) Check {
+	return Check{Name: "rest", Fn: func(ctx context.Context) error {
+		_, err := serverTime(ctx, client)
+		return err
+	}}
+}
+
+// AuthCheck verifies the API key, secret and passphrase with a signed read-only request.
+func AuthCheck(client futures.ClientInterface) Check {
+	return Check{Name: "auth", Fn: func(ctx context.Context) error {
+		params := url.Values{}
+		params.Set("productType", string(futures.ProductTypeUSDTFutures))
+		_, _, err := client.CallAPI(ctx, "GET", futures.EndpointAccountList, params, nil, true)
+		return err
+	}}
+}
+
+// WebSocketCheck reports whether a WebSocket connection is currently up.
+func WebSocketCheck(name string, conn Connection) Check {
+	return Check{Name: name, Fn: func(ctx context.Context) error {
+		if !conn.IsConnected() {
+			return fmt.Errorf("not connected")
+		}
+		return nil
+	}}
+}
+
+// ClockDriftCheck fails when the local clock differs from exchange time by more than
+// maxDrift. The request round trip is split evenly to estimate the server clock.
+// Signed requests are rejected when the drift grows too large, so this check catches
+// authentication failures before they happen.
+func ClockDriftCheck(client futures.ClientInterface, maxDrift time.Duration) Check {
+	return Check{Name: "clock_drift", Fn: func(ctx context.Context) error {
+		start := time.Now()
+		server, err := serverTime(ctx, client)
+		if err != nil {
+			return err
+		}
+		end := time.Now()
+
+		local := start.Add(end.Sub(start) / 2)
+		drift := server.Sub(local)
+		if drift < 0 {
+			drift = -drift
+		}
+		if drift > maxDrift {
+			return fmt.Errorf("clock drift %s exceeds %s", drift.Round(time.Millisecond), maxDrift)
+		}
+		return nil
+	}}
+}
+
+func serverTime(ctx context.Context, client futures.ClientInterface) (time.Time, error) {
+	return market.NewServerTimeService(client).Do(ctx)
+}
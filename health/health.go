@@ -0,0 +1,181 @@
+// Package health exposes liveness and readiness HTTP handlers for trading services.
+//
+// Readiness aggregates checks such as REST connectivity, WebSocket connection status,
+// API key validity and clock drift against the exchange. Handlers return JSON and can be
+// mounted on any mux:
+//
+//	h := health.New(health.Options{CacheTTL: 5 * time.Second})
+//	h.AddCheck(health.RESTCheck(client))
+//	h.AddCheck(health.AuthCheck(client))
+//	h.AddCheck(health.WebSocketCheck("public-ws", wsClient))
+//	h.AddCheck(health.ClockDriftCheck(client, time.Second))
+//	h.Register(mux) // GET /healthz and /readyz
+//
+// ProbeLatency measures REST round-trip statistics against the public server time
+// endpoint, for colocation decisions and clock sync:
+//
+//	stats, err := health.ProbeLatency(ctx, health.FuturesPing(client), health.ProbeOptions{Samples: 50})
+//	log.Println(stats) // samples=50 errors=0 min=... p50=... p99=... max=... offset=...
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// Check is a single readiness probe.
+type Check struct {
+	Name string
+	Fn   func(ctx context.Context) error
+}
+
+// Status values reported in responses.
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// CheckResult is the outcome of one check.
+type CheckResult struct {
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+// Report is the JSON body returned by the handlers.
+type Report struct {
+	Status    string                 `json:"status"`
+	Checks    map[string]CheckResult `json:"checks,omitempty"`
+	CheckedAt time.Time              `json:"checked_at"`
+}
+
+// Options configures a Health instance.
+type Options struct {
+	// Timeout bounds each check. Defaults to 5 seconds.
+	Timeout time.Duration
+	// CacheTTL reuses the last readiness report for this long so frequent probes do not
+	// hit the exchange. Zero disables caching.
+	CacheTTL time.Duration
+	// Clock stamps reports, times the checks and expires the cache. Defaults to the system clock.
+	Clock common.Clock
+}
+
+// Health runs readiness checks and serves health endpoints.
+type Health struct {
+	opts  Options
+	clock common.Clock
+
+	mu     sync.Mutex
+	checks []Check
+	last   *Report
+}
+
+// New creates a Health instance without checks.
+func New(opts Options) *Health {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	return &Health{opts: opts, clock: common.ClockOrSystem(opts.Clock)}
+}
+
+// AddCheck registers a readiness check.
+func (h *Health) AddCheck(c Check) {
+	h.mu.Lock()
+	h.checks = append(h.checks, c)
+	h.last = nil
+	h.mu.Unlock()
+}
+
+// Run executes all checks concurrently, or returns the cached report when still fresh.
+func (h *Health) Run(ctx context.Context) Report {
+	h.mu.Lock()
+	if h.last != nil && h.opts.CacheTTL > 0 && h.clock.Now().Sub(h.last.CheckedAt) < h.opts.CacheTTL {
+		report := *h.last
+		h.mu.Unlock()
+		return report
+	}
+	checks := append([]Check(nil), h.checks...)
+	h.mu.Unlock()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c Check) {
+			defer wg.Done()
+			cctx, cancel := context.WithTimeout(ctx, h.opts.Timeout)
+			defer cancel()
+
+			start := h.clock.Now()
+			err := c.Fn(cctx)
+			res := CheckResult{Status: StatusOK, LatencyMs: h.clock.Now().Sub(start).Milliseconds()}
+			if err != nil {
+				res.Status = StatusFail
+				res.Error = err.Error()
+			}
+			results[i] = res
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(checks)), CheckedAt: h.clock.Now()}
+	for i, c := range checks {
+		report.Checks[c.Name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+
+	h.mu.Lock()
+	h.last = &report
+	h.mu.Unlock()
+	return report
+}
+
+// Failing returns the names of failing checks in the report, sorted.
+func (r Report) Failing() []string {
+	var names []string
+	for name, res := range r.Checks {
+		if res.Status != StatusOK {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Liveness reports that the process is up and serving. It runs no checks, so an exchange
+// outage never gets the service restarted.
+func (h *Health) Liveness() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, Report{Status: StatusOK, CheckedAt: h.clock.Now()})
+	})
+}
+
+// Readiness runs the checks and responds 200 when all pass, 503 otherwise.
+func (h *Health) Readiness() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, h.Run(r.Context()))
+	})
+}
+
+// Register mounts Liveness at /healthz and Readiness at /readyz.
+func (h *Health) Register(mux *http.ServeMux) {
+	mux.Handle("/healthz", h.Liveness())
+	mux.Handle("/readyz", h.Readiness())
+}
+
+func writeReport(w http.ResponseWriter, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if report.Status != StatusOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(report)
+}
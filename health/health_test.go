@@ -0,0 +1,116 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeClient struct {
+	skew        time.Duration
+	authErr     error
+	authorities []string
+	calls       atomic.Int32
+}
+
+func (f *fakeClient) CallAPI(ctx context.Context, method, endpoint string, query url.Values, body []byte, sign bool) (*futures.ApiResponse, *fasthttp.ResponseHeader, error) {
+	f.calls.Add(1)
+	switch endpoint {
+	case futures.EndpointServerTime:
+		data, _ := json.Marshal(map[string]string{
+			"serverTime": strconv.FormatInt(time.Now().Add(f.skew).UnixMilli(), 10),
+		})
+		return &futures.ApiResponse{Code: "00000", Data: data}, &fasthttp.ResponseHeader{}, nil
+	case futures.EndpointAccountList:
+		if f.authErr != nil {
+			return nil, &fasthttp.ResponseHeader{}, f.authErr
+		}
+		return &futures.ApiResponse{Code: "00000", Data: []byte("[]")}, &fasthttp.ResponseHeader{}, nil
+	case futures.EndpointAPIKeyInfo:
+		if f.authErr != nil {
+			return nil, &fasthttp.ResponseHeader{}, f.authErr
+		}
+		data, _ := json.Marshal(map[string]interface{}{
+			"userId":      "42",
+			"ips":         "10.0.0.1, 10.0.0.2",
+			"authorities": f.authorities,
+		})
+		return &futures.ApiResponse{Code: "00000", Data: data}, &fasthttp.ResponseHeader{}, nil
+	}
+	return nil, nil, errors.New("unexpected endpoint")
+}
+
+type conn bool
+
+func (c conn) IsConnected() bool { return bool(c) }
+
+func TestReadiness_AllPass(t *testing.T) {
+	client := &fakeClient{}
+	h := New(Options{})
+	h.AddCheck(RESTCheck(client))
+	h.AddCheck(AuthCheck(client))
+	h.AddCheck(WebSocketCheck("ws", conn(true)))
+	h.AddCheck(ClockDriftCheck(client, time.Second))
+
+	mux := http.NewServeMux()
+	h.Register(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var report Report
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	assert.Equal(t, StatusOK, report.Status)
+	assert.Len(t, report.Checks, 4)
+}
+
+func TestReadiness_Failures(t *testing.T) {
+	client := &fakeClient{skew: 5 * time.Second, authErr: errors.New("invalid sign")}
+	h := New(Options{})
+	h.AddCheck(AuthCheck(client))
+	h.AddCheck(WebSocketCheck("ws", conn(false)))
+	h.AddCheck(ClockDriftCheck(client, time.Second))
+	h.AddCheck(RESTCheck(client))
+
+	rec := httptest.NewRecorder()
+	h.Readiness().ServeHTTP(rec, httptest.NewRequest("GET", "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var report Report
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	assert.Equal(t, []string{"auth", "clock_drift", "ws"}, report.Failing())
+	assert.Contains(t, report.Checks["clock_drift"].Error, "exceeds")
+	assert.Equal(t, "invalid sign", report.Checks["auth"].Error)
+}
+
+func TestLiveness_IgnoresChecks(t *testing.T) {
+	h := New(Options{})
+	h.AddCheck(WebSocketCheck("ws", conn(false)))
+
+	rec := httptest.NewRecorder()
+	h.Liveness().ServeHTTP(rec, httptest.NewRequest("GET", "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRun_Cache(t *testing.T) {
+	client := &fakeClient{}
+	h := New(Options{CacheTTL: time.Minute})
+	h.AddCheck(RESTCheck(client))
+
+	h.Run(context.Background())
+	h.Run(context.Background())
+	assert.Equal(t, int32(1), client.calls.Load())
+}
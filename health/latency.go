@@ -0,0 +1,122 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/khanbekov/go-bitget/futures/market"
+)
+
+// PingFunc performs one round trip and returns the exchange server time. Both
+// market.ServerTimeService.Do and uta.GetServerTimeService.Do fit.
+type PingFunc func(ctx context.Context) (time.Time, error)
+
+// FuturesPing pings the futures REST API with the public server time endpoint.
+func FuturesPing(client futures.ClientInterface) PingFunc {
+	return market.NewServerTimeService(client).Do
+}
+
+// ProbeOptions configures ProbeLatency.
+type ProbeOptions struct {
+	// Samples is the number of round trips. Defaults to 20.
+	Samples int
+	// Interval is the pause between round trips. Zero sends them back to back.
+	Interval time.Duration
+}
+
+// LatencyStats summarizes REST round trips.
+type LatencyStats struct {
+	Samples int // Successful round trips
+	Errors  int // Failed round trips
+
+	Min  time.Duration
+	P50  time.Duration
+	P99  time.Duration
+	Max  time.Duration
+	Mean time.Duration
+
+	// ClockOffset is the median of server time minus the local time at the middle of
+	// each round trip. Positive means the exchange clock is ahead.
+	ClockOffset time.Duration
+}
+
+// String formats the stats for logs.
+func (s LatencyStats) String() string {
+	return fmt.Sprintf("samples=%d errors=%d min=%s p50=%s p99=%s max=%s offset=%s",
+		s.Samples, s.Errors, s.Min, s.P50, s.P99, s.Max, s.ClockOffset)
+}
+
+// ProbeLatency measures round-trip statistics by calling ping repeatedly. It fails only
+// when no round trip succeeds or ctx is done before the first success; partial failures
+// are counted in Errors.
+func ProbeLatency(ctx context.Context, ping PingFunc, opts ProbeOptions) (*LatencyStats, error) {
+	samples := opts.Samples
+	if samples <= 0 {
+		samples = 20
+	}
+
+	var (
+		rtts    []time.Duration
+		offsets []time.Duration
+		lastErr error
+		errs    int
+	)
+	for i := 0; i < samples; i++ {
+		if i > 0 && opts.Interval > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(opts.Interval):
+			}
+		}
+		if ctx.Err() != nil {
+			lastErr = ctx.Err()
+			break
+		}
+
+		start := time.Now()
+		server, err := ping(ctx)
+		end := time.Now()
+		if err != nil {
+			errs++
+			lastErr = err
+			continue
+		}
+		rtt := end.Sub(start)
+		rtts = append(rtts, rtt)
+		offsets = append(offsets, server.Sub(start.Add(rtt/2)))
+	}
+
+	if len(rtts) == 0 {
+		return nil, fmt.Errorf("latency probe: no successful round trips: %w", lastErr)
+	}
+
+	sort.Slice(rtts, func(i, j int) bool { return rtts[i] < rtts[j] })
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+	var total time.Duration
+	for _, rtt := range rtts {
+		total += rtt
+	}
+	return &LatencyStats{
+		Samples:     len(rtts),
+		Errors:      errs,
+		Min:         rtts[0],
+		P50:         percentile(rtts, 0.50),
+		P99:         percentile(rtts, 0.99),
+		Max:         rtts[len(rtts)-1],
+		Mean:        total / time.Duration(len(rtts)),
+		ClockOffset: percentile(offsets, 0.50),
+	}, nil
+}
+
+// percentile returns the nearest-rank percentile of sorted values.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
@@ -0,0 +1,112 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeLatency_Stats(t *testing.T) {
+	delays := []time.Duration{5, 1, 3, 2, 4}
+	i := 0
+	ping := func(ctx context.Context) (time.Time, error) {
+		d := delays[i%len(delays)] * time.Millisecond
+		i++
+		time.Sleep(d)
+		return time.Now().Add(time.Minute), nil
+	}
+
+	stats, err := ProbeLatency(context.Background(), ping, ProbeOptions{Samples: 5})
+	require.NoError(t, err)
+	assert.Equal(t, 5, stats.Samples)
+	assert.Zero(t, stats.Errors)
+	assert.GreaterOrEqual(t, stats.Min, time.Millisecond)
+	assert.LessOrEqual(t, stats.Min, stats.P50)
+	assert.LessOrEqual(t, stats.P50, stats.P99)
+	assert.Equal(t, stats.Max, stats.P99)
+	assert.GreaterOrEqual(t, stats.P50, 3*time.Millisecond)
+	assert.InDelta(t, float64(time.Minute), float64(stats.ClockOffset), float64(10*time.Millisecond))
+	assert.Contains(t, stats.String(), "samples=5 errors=0")
+}
+
+func TestProbeLatency_Errors(t *testing.T) {
+	calls := 0
+	flaky := func(ctx context.Context) (time.Time, error) {
+		calls++
+		if calls%2 == 0 {
+			return time.Time{}, errors.New("timeout")
+		}
+		return time.Now(), nil
+	}
+	stats, err := ProbeLatency(context.Background(), flaky, ProbeOptions{Samples: 4})
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Samples)
+	assert.Equal(t, 2, stats.Errors)
+
+	down := func(ctx context.Context) (time.Time, error) { return time.Time{}, errors.New("connection refused") }
+	_, err = ProbeLatency(context.Background(), down, ProbeOptions{Samples: 3})
+	assert.EqualError(t, err, "latency probe: no successful round trips: connection refused")
+}
+
+func TestProbeLatency_FuturesPing(t *testing.T) {
+	client := &fakeClient{skew: -2 * time.Second}
+
+	stats, err := ProbeLatency(context.Background(), FuturesPing(client), ProbeOptions{Samples: 3, Interval: time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), client.calls.Load())
+	assert.InDelta(t, float64(-2*time.Second), float64(stats.ClockOffset), float64(50*time.Millisecond))
+}
+
+func TestProbeLatency_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := ProbeLatency(ctx, FuturesPing(&fakeClient{}), ProbeOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestProbeLatency_WarmUp(t *testing.T) {
+	var mu sync.Mutex
+	conns := 0
+	arrived := make(chan struct{}, 16)
+	release := make(chan struct{})
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived <- struct{}{}
+		<-release
+		w.Write([]byte(`{"code":"00000","msg":"success","data":{"serverTime":"1700000000000"}}`))
+	}))
+	srv.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			conns++
+			mu.Unlock()
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	client := futures.NewClient("key", "secret", "passphrase", futures.WithIdleConnTimeout(time.Minute))
+	client.BaseURL = srv.URL
+	done := make(chan error)
+	go func() { done <- client.WarmUp(context.Background(), 4) }()
+	for i := 0; i < 4; i++ {
+		<-arrived // hold every request until all four are in flight on their own connection
+	}
+	close(release)
+	require.NoError(t, <-done)
+
+	stats, err := ProbeLatency(context.Background(), FuturesPing(client), ProbeOptions{Samples: 8})
+	require.NoError(t, err)
+	assert.Equal(t, 8, stats.Samples)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 4, conns, "the probe reuses warmed connections")
+}
@@ -0,0 +1,216 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+)
+
+// Permission is an API key permission scope.
+type Permission string
+
+// Permission scopes reported by Preflight.
+const (
+	PermissionRead     Permission = "read"
+	PermissionTrade    Permission = "trade"
+	PermissionTransfer Permission = "transfer"
+	PermissionWithdraw Permission = "withdraw"
+)
+
+// authorityScopes maps Bitget key authorities to permission scopes. Unknown authorities
+// are still listed in PreflightReport.Authorities.
+var authorityScopes = map[string]Permission{
+	"readonly": PermissionRead,
+	"read":     PermissionRead,
+	"coor":     PermissionRead,
+	"spotr":    PermissionRead,
+	"wr":       PermissionRead,
+	"trade":    PermissionTrade,
+	"coow":     PermissionTrade,
+	"spotw":    PermissionTrade,
+	"transfer": PermissionTransfer,
+	"wt":       PermissionTransfer,
+	"withdraw": PermissionWithdraw,
+	"ww":       PermissionWithdraw,
+}
+
+// errorHints explains the authentication errors users hit most often.
+var errorHints = map[int64]string{
+	40006: "the API key is invalid; check BITGET_API_KEY",
+	40008: "the request timestamp expired; the local clock is out of sync, enable NTP",
+	40009: "the signature is invalid; check the secret key",
+	40011: "the passphrase is wrong; it is the one chosen when the key was created, not the account password",
+	40012: "the API key or passphrase is wrong",
+	40014: "the API key lacks the permission required for this request",
+	40018: "the request IP is not in the API key's IP whitelist",
+	40037: "the API key does not exist in this environment; demo trading keys only work with the Demo environment",
+	40099: "the API key belongs to the other environment; switch between Production and Demo with futures.WithEnvironment",
+}
+
+// ErrorHint returns a human readable explanation and fix for common authentication
+// errors, or "" when the error is not recognized.
+func ErrorHint(err error) string {
+	var apiErr *types.APIError
+	if errors.As(err, &apiErr) {
+		return errorHints[apiErr.Code]
+	}
+	return ""
+}
+
+// PreflightOptions configures Preflight.
+type PreflightOptions struct {
+	// MaxDrift is the tolerated difference between local and exchange clocks. Defaults
+	// to one second.
+	MaxDrift time.Duration
+
+	// Require lists permissions the key must have, e.g. PermissionTrade for a trading bot.
+	Require []Permission
+}
+
+// Problem is a failed preflight check with a suggested fix. Warnings do not fail the
+// preflight.
+type Problem struct {
+	Check   string `json:"check"`
+	Error   string `json:"error"`
+	Hint    string `json:"hint,omitempty"`
+	Warning bool   `json:"warning,omitempty"`
+}
+
+// PreflightReport describes the credentials and connectivity of a client.
+type PreflightReport struct {
+	OK            bool          `json:"ok"`
+	Environment   string        `json:"environment"`
+	Reachable     bool          `json:"reachable"`
+	Authenticated bool          `json:"authenticated"`
+	UserID        string        `json:"user_id,omitempty"`
+	Permissions   []Permission  `json:"permissions,omitempty"`
+	Authorities   []string      `json:"authorities,omitempty"`
+	IPWhitelist   []string      `json:"ip_whitelist,omitempty"`
+	ClockDrift    time.Duration `json:"clock_drift"`
+	Problems      []Problem     `json:"problems,omitempty"`
+	CheckedAt     time.Time     `json:"checked_at"`
+}
+
+// Has reports whether the key has the given permission.
+func (r *PreflightReport) Has(p Permission) bool {
+	for _, have := range r.Permissions {
+		if have == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Preflight verifies connectivity, clock drift and credentials with a signed read-only
+// request, and reports the permission scopes of the API key. It never returns an error;
+// every failure is listed in the report with a hint on how to fix it.
+//
+//	report := health.Preflight(ctx, client, health.PreflightOptions{Require: []health.Permission{health.PermissionTrade}})
+//	if !report.OK {
+//		for _, p := range report.Problems {
+//			log.Printf("%s: %s (%s)", p.Check, p.Error, p.Hint)
+//		}
+//	}
+func Preflight(ctx context.Context, client futures.ClientInterface, opts PreflightOptions) *PreflightReport {
+	if opts.MaxDrift <= 0 {
+		opts.MaxDrift = time.Second
+	}
+	report := &PreflightReport{Environment: common.Production.String(), CheckedAt: time.Now()}
+	if e, ok := client.(interface{ Environment() common.Environment }); ok {
+		report.Environment = e.Environment().String()
+	}
+	fail := func(check string, err error, hint string) {
+		if hint == "" {
+			hint = ErrorHint(err)
+		}
+		report.Problems = append(report.Problems, Problem{Check: check, Error: err.Error(), Hint: hint})
+	}
+
+	// Connectivity and clock drift
+	start := time.Now()
+	server, err := serverTime(ctx, client)
+	if err != nil {
+		fail("connectivity", err, "the REST API is unreachable; check network access and the base URL")
+	} else {
+		report.Reachable = true
+		report.ClockDrift = server.Sub(start.Add(time.Since(start) / 2))
+		if drift := report.ClockDrift.Abs(); drift > opts.MaxDrift {
+			fail("clock_drift",
+				fmt.Errorf("clock drift %s exceeds %s", drift.Round(time.Millisecond), opts.MaxDrift),
+				"signed requests will be rejected; sync the local clock with NTP")
+		}
+	}
+
+	// Credentials and permissions
+	res, _, err := client.CallAPI(ctx, "GET", futures.EndpointAPIKeyInfo, nil, nil, true)
+	if err != nil {
+		fail("auth", err, "")
+	} else {
+		var info struct {
+			UserID      string   `json:"userId"`
+			IPs         string   `json:"ips"`
+			Authorities []string `json:"authorities"`
+		}
+		if err := jsoniter.Unmarshal(res.Data, &info); err != nil {
+			fail("auth", fmt.Errorf("decode key info: %w", err), "")
+		} else {
+			report.Authenticated = true
+			report.UserID = info.UserID
+			report.Authorities = info.Authorities
+			report.Permissions = permissions(info.Authorities)
+			for _, ip := range strings.Split(info.IPs, ",") {
+				if ip = strings.TrimSpace(ip); ip != "" {
+					report.IPWhitelist = append(report.IPWhitelist, ip)
+				}
+			}
+		}
+	}
+
+	if report.Authenticated {
+		for _, p := range opts.Require {
+			if !report.Has(p) {
+				fail("permissions", fmt.Errorf("API key lacks %s permission", p),
+					"edit the key on Bitget and enable the missing permission")
+			}
+		}
+		if report.Has(PermissionWithdraw) {
+			report.Problems = append(report.Problems, Problem{
+				Check:   "permissions",
+				Error:   "API key has withdraw permission",
+				Hint:    "use a key without withdraw permission for automated trading",
+				Warning: true,
+			})
+		}
+	}
+
+	report.OK = report.Reachable && report.Authenticated
+	for _, p := range report.Problems {
+		if !p.Warning {
+			report.OK = false
+		}
+	}
+	return report
+}
+
+func permissions(authorities []string) []Permission {
+	seen := map[Permission]bool{}
+	for _, a := range authorities {
+		if p, ok := authorityScopes[strings.ToLower(strings.TrimSpace(a))]; ok {
+			seen[p] = true
+		}
+	}
+	out := make([]Permission, 0, len(seen))
+	for p := range seen {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
@@ -0,0 +1,59 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/common/types"
+	"github.com/khanbekov/go-bitget/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflight_OK(t *testing.T) {
+	client := &fakeClient{authorities: []string{"coor", "coow", "wt"}}
+	report := Preflight(context.Background(), client, PreflightOptions{Require: []Permission{PermissionTrade}})
+
+	assert.True(t, report.OK)
+	assert.True(t, report.Reachable)
+	assert.True(t, report.Authenticated)
+	assert.Equal(t, "production", report.Environment)
+	assert.Equal(t, "42", report.UserID)
+	assert.Equal(t, []Permission{PermissionRead, PermissionTrade, PermissionTransfer}, report.Permissions)
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, report.IPWhitelist)
+	assert.Empty(t, report.Problems)
+}
+
+func TestPreflight_Problems(t *testing.T) {
+	client := &fakeClient{skew: 3 * time.Second, authorities: []string{"readonly", "ww"}}
+	report := Preflight(context.Background(), client, PreflightOptions{Require: []Permission{PermissionTrade}})
+
+	assert.False(t, report.OK)
+	require.Len(t, report.Problems, 3)
+	assert.Equal(t, "clock_drift", report.Problems[0].Check)
+	assert.Equal(t, "API key lacks trade permission", report.Problems[1].Error)
+	assert.True(t, report.Problems[2].Warning, "withdraw permission is only a warning")
+}
+
+func TestPreflight_AuthFailureHint(t *testing.T) {
+	client := &fakeClient{authErr: &types.APIError{Code: 40099, Message: "exchange environment is incorrect"}}
+	report := Preflight(context.Background(), client, PreflightOptions{})
+
+	assert.False(t, report.OK)
+	assert.True(t, report.Reachable)
+	assert.False(t, report.Authenticated)
+	require.Len(t, report.Problems, 1)
+	assert.Equal(t, "auth", report.Problems[0].Check)
+	assert.Contains(t, report.Problems[0].Hint, "futures.WithEnvironment")
+}
+
+func TestPreflight_ReportsClientEnvironment(t *testing.T) {
+	client := futures.NewClient("k", "s", "p", futures.WithEnvironment(futures.Demo)).
+		SetApiEndpoint("http://127.0.0.1:1").
+		SetLogger(common.NopLogger())
+	report := Preflight(context.Background(), client, PreflightOptions{})
+	assert.Equal(t, "demo", report.Environment)
+	assert.False(t, report.Reachable)
+}
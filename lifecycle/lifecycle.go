@@ -0,0 +1,251 @@
+// Package lifecycle coordinates graceful shutdown of SDK-managed resources.
+//
+// Components (REST clients, WebSocket connections, trackers, executors) are registered
+// with their dependencies. On SIGINT/SIGTERM, or an explicit Shutdown, dependants are
+// stopped before the components they rely on, each within its own timeout, and registered
+// flush hooks (audit logs, exporters) run last:
+//
+//	lm := lifecycle.New(lifecycle.Options{DefaultTimeout: 5 * time.Second})
+//	lm.Register("ws", lifecycle.CloseFunc(wsClient.Close))
+//	lm.Register("tracker", tracker, lifecycle.DependsOn("ws"))
+//	lm.Register("executor", executor, lifecycle.DependsOn("tracker"), lifecycle.Timeout(30*time.Second))
+//	lm.OnFlush("audit", auditLog.Flush)
+//	if err := lm.Run(ctx); err != nil {
+//		log.Printf("shutdown: %v", err)
+//	}
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Component is a resource that can be shut down.
+type Component interface {
+	Shutdown(ctx context.Context) error
+}
+
+// Func adapts a shutdown function to Component.
+type Func func(ctx context.Context) error
+
+// Shutdown implements Component.
+func (f Func) Shutdown(ctx context.Context) error { return f(ctx) }
+
+// CloseFunc adapts a function without arguments, such as ws.BaseWsClient.Close.
+type CloseFunc func()
+
+// Shutdown implements Component.
+func (f CloseFunc) Shutdown(ctx context.Context) error {
+	f()
+	return nil
+}
+
+// Closer adapts an io.Closer.
+func Closer(c io.Closer) Component {
+	return Func(func(ctx context.Context) error { return c.Close() })
+}
+
+// Option configures a registered component.
+type Option func(*entry)
+
+// DependsOn declares components that must still be running while this one shuts down.
+func DependsOn(names ...string) Option {
+	return func(e *entry) { e.deps = append(e.deps, names...) }
+}
+
+// Timeout overrides the default shutdown timeout for a component.
+func Timeout(d time.Duration) Option {
+	return func(e *entry) { e.timeout = d }
+}
+
+// Options configures a Manager.
+type Options struct {
+	// DefaultTimeout bounds each component's shutdown. Defaults to 10 seconds.
+	DefaultTimeout time.Duration
+	// Signals that trigger shutdown in Run. Defaults to SIGINT and SIGTERM.
+	Signals []os.Signal
+	// OnStop is called after each component or flush hook finishes, with its error.
+	OnStop func(name string, err error)
+}
+
+type entry struct {
+	name    string
+	comp    Component
+	deps    []string
+	timeout time.Duration
+}
+
+type flush struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Manager tracks components and shuts them down in dependency order.
+// It is safe for concurrent use.
+type Manager struct {
+	opts Options
+
+	mu      sync.Mutex
+	entries []*entry
+	byName  map[string]*entry
+	flushes []flush
+
+	once sync.Once
+	err  error
+	done chan struct{}
+}
+
+// New creates a manager.
+func New(opts Options) *Manager {
+	if opts.DefaultTimeout <= 0 {
+		opts.DefaultTimeout = 10 * time.Second
+	}
+	if len(opts.Signals) == 0 {
+		opts.Signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	return &Manager{opts: opts, byName: make(map[string]*entry), done: make(chan struct{})}
+}
+
+// Register adds a component. Names must be unique.
+func (m *Manager) Register(name string, c Component, opts ...Option) error {
+	e := &entry{name: name, comp: c, timeout: m.opts.DefaultTimeout}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.byName[name]; ok {
+		return fmt.Errorf("component %q already registered", name)
+	}
+	m.entries = append(m.entries, e)
+	m.byName[name] = e
+	return nil
+}
+
+// OnFlush adds a hook run after every component has stopped, in registration order.
+func (m *Manager) OnFlush(name string, fn func(ctx context.Context) error) {
+	m.mu.Lock()
+	m.flushes = append(m.flushes, flush{name: name, fn: fn})
+	m.mu.Unlock()
+}
+
+// Run blocks until ctx is cancelled or a shutdown signal arrives, then shuts down.
+func (m *Manager) Run(ctx context.Context) error {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, m.opts.Signals...)
+	defer signal.Stop(sig)
+
+	select {
+	case <-ctx.Done():
+	case <-sig:
+	case <-m.done:
+		return m.err
+	}
+	return m.Shutdown(context.Background())
+}
+
+// Done is closed once shutdown has completed.
+func (m *Manager) Done() <-chan struct{} {
+	return m.done
+}
+
+// Shutdown stops all components and runs flush hooks. Only the first call does work;
+// later calls return the same result. ctx bounds the whole shutdown in addition to the
+// per-component timeouts.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.once.Do(func() {
+		defer close(m.done)
+
+		m.mu.Lock()
+		order, orderErr := m.order()
+		flushes := append([]flush(nil), m.flushes...)
+		m.mu.Unlock()
+
+		errs := []error{orderErr}
+		for _, e := range order {
+			errs = append(errs, m.stop(ctx, e.name, e.timeout, e.comp.Shutdown))
+		}
+		for _, f := range flushes {
+			errs = append(errs, m.stop(ctx, f.name, m.opts.DefaultTimeout, f.fn))
+		}
+		m.err = errors.Join(errs...)
+	})
+	<-m.done
+	return m.err
+}
+
+// stop runs fn with a timeout. A component that ignores its context is abandoned when the
+// timeout expires so one stuck resource cannot block the rest of the shutdown.
+func (m *Manager) stop(ctx context.Context, name string, timeout time.Duration, fn func(context.Context) error) error {
+	cctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() { result <- fn(cctx) }()
+
+	var err error
+	select {
+	case err = <-result:
+	case <-cctx.Done():
+		err = cctx.Err()
+	}
+	if err != nil {
+		err = fmt.Errorf("%s: %w", name, err)
+	}
+	if m.opts.OnStop != nil {
+		m.opts.OnStop(name, err)
+	}
+	return err
+}
+
+// order returns the shutdown order: dependants before their dependencies, otherwise
+// reverse registration order. Callers must hold the lock.
+func (m *Manager) order() ([]*entry, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(m.entries))
+	var startup []*entry
+	var err error
+
+	var visit func(e *entry)
+	visit = func(e *entry) {
+		switch state[e.name] {
+		case visited:
+			return
+		case visiting:
+			if err == nil {
+				err = fmt.Errorf("dependency cycle involving %q", e.name)
+			}
+			return
+		}
+		state[e.name] = visiting
+		for _, dep := range e.deps {
+			if d, ok := m.byName[dep]; ok {
+				visit(d)
+			}
+		}
+		state[e.name] = visited
+		startup = append(startup, e)
+	}
+	for _, e := range m.entries {
+		visit(e)
+	}
+
+	// Shutdown is the reverse of the implied startup order
+	out := make([]*entry, len(startup))
+	for i, e := range startup {
+		out[len(startup)-1-i] = e
+	}
+	return out, err
+}
@@ -0,0 +1,101 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	mu    sync.Mutex
+	order []string
+}
+
+func (r *recorder) component(name string, err error) Component {
+	return Func(func(ctx context.Context) error {
+		r.mu.Lock()
+		r.order = append(r.order, name)
+		r.mu.Unlock()
+		return err
+	})
+}
+
+func TestManager_DependencyOrder(t *testing.T) {
+	rec := &recorder{}
+	m := New(Options{})
+
+	require.NoError(t, m.Register("executor", rec.component("executor", nil), DependsOn("tracker", "rest")))
+	require.NoError(t, m.Register("rest", rec.component("rest", nil)))
+	require.NoError(t, m.Register("ws", rec.component("ws", nil)))
+	require.NoError(t, m.Register("tracker", rec.component("tracker", nil), DependsOn("ws")))
+	m.OnFlush("audit", func(ctx context.Context) error {
+		rec.order = append(rec.order, "audit")
+		return nil
+	})
+
+	require.NoError(t, m.Shutdown(context.Background()))
+	assert.Equal(t, []string{"executor", "rest", "tracker", "ws", "audit"}, rec.order)
+
+	// Second call is a no-op
+	require.NoError(t, m.Shutdown(context.Background()))
+	assert.Len(t, rec.order, 5)
+}
+
+func TestManager_TimeoutAndErrors(t *testing.T) {
+	rec := &recorder{}
+	var stopped []string
+	m := New(Options{
+		DefaultTimeout: 20 * time.Millisecond,
+		OnStop:         func(name string, err error) { stopped = append(stopped, name) },
+	})
+
+	block := make(chan struct{})
+	defer close(block)
+	require.NoError(t, m.Register("stuck", Func(func(ctx context.Context) error {
+		<-block
+		return nil
+	})))
+	require.NoError(t, m.Register("failing", rec.component("failing", errors.New("boom"))))
+	require.Error(t, m.Register("failing", rec.component("failing", nil)), "duplicate name")
+
+	err := m.Shutdown(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "failing: boom")
+	assert.Equal(t, []string{"failing", "stuck"}, stopped)
+}
+
+func TestManager_Cycle(t *testing.T) {
+	rec := &recorder{}
+	m := New(Options{})
+	require.NoError(t, m.Register("a", rec.component("a", nil), DependsOn("b")))
+	require.NoError(t, m.Register("b", rec.component("b", nil), DependsOn("a")))
+
+	err := m.Shutdown(context.Background())
+	assert.ErrorContains(t, err, "cycle")
+	assert.Len(t, rec.order, 2, "all components are still stopped")
+}
+
+func TestManager_RunUntilCancelled(t *testing.T) {
+	rec := &recorder{}
+	m := New(Options{})
+	require.NoError(t, m.Register("ws", CloseFunc(func() { rec.component("ws", nil).Shutdown(context.Background()) })))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() { result <- m.Run(ctx) }()
+	cancel()
+
+	select {
+	case err := <-result:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancellation")
+	}
+	assert.Equal(t, []string{"ws"}, rec.order)
+	<-m.Done()
+}
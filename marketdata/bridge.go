@@ -0,0 +1,114 @@
+package marketdata
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/khanbekov/go-bitget/ws"
+)
+
+// Subscriber is implemented by *ws.BaseWsClient.
+type Subscriber interface {
+	SubscribeArgs(args ws.SubscriptionArgs, handler ws.OnReceive)
+	UnsubscribeArgs(args ws.SubscriptionArgs)
+}
+
+// Bridge republishes selected public channels of a WebSocket client through a Fanout,
+// so processes in other languages can consume the normalized feed from Redis or NATS:
+//
+//	store, err := state.NewRedisStore(ctx, state.RedisOptions{Addr: "redis:6379"})
+//	bridge := marketdata.NewBridge(wsClient, store, marketdata.FanoutOptions{Encode: marketdata.EncodeJSON})
+//	err = bridge.Subscribe(
+//		ws.SubscriptionArgs{ProductType: "USDT-FUTURES", Channel: ws.ChannelTicker, Symbol: "BTCUSDT"},
+//		ws.SubscriptionArgs{ProductType: "USDT-FUTURES", Channel: "candle1m", Symbol: "BTCUSDT"},
+//	)
+//
+// A Python consumer then subscribes to "bitget.USDT-FUTURES.ticker.BTCUSDT". Bridge is
+// safe for concurrent use.
+type Bridge struct {
+	*Fanout
+	client Subscriber
+
+	mu     sync.Mutex
+	active map[ws.SubscriptionArgs]bool
+}
+
+// NewBridge creates a bridge publishing to pub.
+func NewBridge(client Subscriber, pub Publisher, opts FanoutOptions) *Bridge {
+	return &Bridge{
+		Fanout: NewFanout(pub, opts),
+		client: client,
+		active: make(map[ws.SubscriptionArgs]bool),
+	}
+}
+
+// Subscribe subscribes to the given channels. Only the ticker, candle, book and trade
+// channels can be bridged; if any entry is not one of them nothing is subscribed.
+func (b *Bridge) Subscribe(args ...ws.SubscriptionArgs) error {
+	for _, a := range args {
+		if !Supported(a.Channel) {
+			return fmt.Errorf("marketdata: channel %q cannot be bridged", a.Channel)
+		}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, a := range args {
+		if b.active[a] {
+			continue
+		}
+		b.client.SubscribeArgs(a, b.HandleMessage)
+		b.active[a] = true
+	}
+	return nil
+}
+
+// Unsubscribe removes subscriptions made by Subscribe.
+func (b *Bridge) Unsubscribe(args ...ws.SubscriptionArgs) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, a := range args {
+		if b.active[a] {
+			b.client.UnsubscribeArgs(a)
+			delete(b.active, a)
+		}
+	}
+}
+
+// Handler returns the fanout handler for any subscription. Pass it to manifest.NewSyncer
+// to drive the bridged channels from a subscription manifest instead of Subscribe.
+func (b *Bridge) Handler(ws.SubscriptionArgs) ws.OnReceive {
+	return b.HandleMessage
+}
+
+// Active returns the subscriptions made by Subscribe.
+func (b *Bridge) Active() []ws.SubscriptionArgs {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make([]ws.SubscriptionArgs, 0, len(b.active))
+	for a := range b.active {
+		out = append(out, a)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Channel != out[j].Channel {
+			return out[i].Channel < out[j].Channel
+		}
+		if out[i].Symbol != out[j].Symbol {
+			return out[i].Symbol < out[j].Symbol
+		}
+		return out[i].ProductType < out[j].ProductType
+	})
+	return out
+}
+
+// Close unsubscribes from every bridged channel. The publisher is left open.
+func (b *Bridge) Close() {
+	b.Unsubscribe(b.Active()...)
+}
+
+// Supported reports whether Decode normalizes messages of the channel.
+func Supported(channel string) bool {
+	return channel == ws.ChannelTicker || channel == ws.ChannelTrade ||
+		strings.HasPrefix(channel, ws.ChannelCandle) || strings.HasPrefix(channel, ws.ChannelBooks)
+}
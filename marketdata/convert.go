@@ -0,0 +1,161 @@
+package marketdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/khanbekov/go-bitget/ws"
+)
+
+// FromTicker normalizes a ticker channel entry.
+func FromTicker(t ws.TickerData) *Ticker {
+	symbol := t.InstId
+	if symbol == "" {
+		symbol = t.Symbol
+	}
+	return &Ticker{
+		Symbol:          symbol,
+		Last:            parseFloat(t.LastPrice),
+		Bid:             parseFloat(t.BidPrice),
+		Ask:             parseFloat(t.AskPrice),
+		BidSize:         parseFloat(t.BidSize),
+		AskSize:         parseFloat(t.AskSize),
+		Open24h:         parseFloat(t.Open24h),
+		High24h:         parseFloat(t.High24h),
+		Low24h:          parseFloat(t.Low24h),
+		Change24h:       parseFloat(t.Change24h),
+		BaseVolume:      parseFloat(t.BaseVolume),
+		QuoteVolume:     parseFloat(t.QuoteVolume),
+		MarkPrice:       parseFloat(t.MarkPrice),
+		IndexPrice:      parseFloat(t.IndexPrice),
+		FundingRate:     parseFloat(t.FundingRate),
+		NextFundingTime: parseInt(t.NextFundingTime),
+		OpenInterest:    parseFloat(t.HoldingAmount),
+		Ts:              parseInt(t.Timestamp),
+	}
+}
+
+// FromCandle normalizes a candle channel row; the symbol and interval come from the
+// subscription.
+func FromCandle(symbol, interval string, c ws.CandlestickData) *Candle {
+	return &Candle{
+		Symbol:      symbol,
+		Interval:    interval,
+		Start:       parseInt(c.Timestamp),
+		Open:        c.OpenFloat,
+		High:        c.HighFloat,
+		Low:         c.LowFloat,
+		Close:       c.CloseFloat,
+		BaseVolume:  c.BaseVolumeFloat,
+		QuoteVolume: c.QuoteVolumeFloat,
+	}
+}
+
+// FromOrderBook normalizes a book channel entry.
+func FromOrderBook(symbol string, o ws.OrderBookData, snapshot bool) *OrderBook {
+	book := &OrderBook{
+		Symbol:   symbol,
+		Bids:     make([]Level, len(o.Bids)),
+		Asks:     make([]Level, len(o.Asks)),
+		Seq:      o.Seq,
+		Checksum: o.Checksum,
+		Snapshot: snapshot,
+		Ts:       parseInt(o.TS),
+	}
+	for i, l := range o.Bids {
+		book.Bids[i] = Level{Price: l.PriceFloat, Size: l.AmountFloat}
+	}
+	for i, l := range o.Asks {
+		book.Asks[i] = Level{Price: l.PriceFloat, Size: l.AmountFloat}
+	}
+	return book
+}
+
+// FromTrade normalizes a trade channel entry.
+func FromTrade(symbol string, t ws.TradeData) *Trade {
+	return &Trade{
+		Symbol:  symbol,
+		TradeID: t.TradeId,
+		Price:   parseFloat(t.Price),
+		Size:    parseFloat(t.Size),
+		Side:    t.Side,
+		Ts:      parseInt(t.TS),
+	}
+}
+
+// Decode normalizes a raw WebSocket message from the ticker, candle, book or trade
+// channels into one envelope per entry. Other messages, including subscription
+// acknowledgements, return no envelopes and no error.
+func Decode(message string) ([]*Envelope, error) {
+	var msg ws.WebSocketMessage
+	if err := json.Unmarshal([]byte(message), &msg); err != nil {
+		return nil, fmt.Errorf("marketdata: %w", err)
+	}
+	if len(msg.Data) == 0 {
+		return nil, nil
+	}
+
+	arg := msg.Arg
+	envelope := func() *Envelope {
+		return &Envelope{ProductType: arg.ProductType, Channel: arg.Channel}
+	}
+	var out []*Envelope
+	switch {
+	case arg.Channel == ws.ChannelTicker:
+		var rows []ws.TickerData
+		if err := json.Unmarshal(msg.Data, &rows); err != nil {
+			return nil, fmt.Errorf("marketdata: ticker: %w", err)
+		}
+		for _, r := range rows {
+			e := envelope()
+			e.Ticker = FromTicker(r)
+			out = append(out, e)
+		}
+	case strings.HasPrefix(arg.Channel, ws.ChannelCandle):
+		var rows []ws.CandlestickData
+		if err := json.Unmarshal(msg.Data, &rows); err != nil {
+			return nil, fmt.Errorf("marketdata: candle: %w", err)
+		}
+		interval := strings.TrimPrefix(arg.Channel, ws.ChannelCandle)
+		for _, r := range rows {
+			e := envelope()
+			e.Candle = FromCandle(arg.Symbol, interval, r)
+			out = append(out, e)
+		}
+	case strings.HasPrefix(arg.Channel, ws.ChannelBooks):
+		var rows []ws.OrderBookData
+		if err := json.Unmarshal(msg.Data, &rows); err != nil {
+			return nil, fmt.Errorf("marketdata: books: %w", err)
+		}
+		// Only the full-depth channel sends incremental updates
+		snapshot := arg.Channel != ws.ChannelBooks || msg.Action == "snapshot"
+		for _, r := range rows {
+			e := envelope()
+			e.OrderBook = FromOrderBook(arg.Symbol, r, snapshot)
+			out = append(out, e)
+		}
+	case arg.Channel == ws.ChannelTrade:
+		var rows []ws.TradeData
+		if err := json.Unmarshal(msg.Data, &rows); err != nil {
+			return nil, fmt.Errorf("marketdata: trade: %w", err)
+		}
+		for _, r := range rows {
+			e := envelope()
+			e.Trade = FromTrade(arg.Symbol, r)
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func parseFloat(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+func parseInt(s string) int64 {
+	v, _ := strconv.ParseInt(s, 10, 64)
+	return v
+}
@@ -0,0 +1,133 @@
+package marketdata
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Publisher delivers encoded envelopes to a broker. *NATSPublisher and
+// *state.RedisStore implement it.
+type Publisher interface {
+	Publish(ctx context.Context, subject string, data []byte) error
+}
+
+// PublisherFunc adapts a function to Publisher.
+type PublisherFunc func(ctx context.Context, subject string, data []byte) error
+
+// Publish implements Publisher.
+func (f PublisherFunc) Publish(ctx context.Context, subject string, data []byte) error {
+	return f(ctx, subject, data)
+}
+
+// Encoder serializes an envelope for publishing.
+type Encoder func(e *Envelope) ([]byte, error)
+
+// EncodeProtobuf encodes envelopes in protocol buffer wire format, as defined by
+// marketdata.proto.
+func EncodeProtobuf(e *Envelope) ([]byte, error) {
+	return e.Marshal(), nil
+}
+
+// EncodeJSON encodes envelopes as JSON with lowerCamelCase field names, for consumers
+// without protobuf bindings.
+func EncodeJSON(e *Envelope) ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// FanoutOptions configures a Fanout.
+type FanoutOptions struct {
+	// Prefix is the first subject token. Defaults to "bitget".
+	Prefix string
+	// Encode serializes envelopes. Defaults to EncodeProtobuf.
+	Encode Encoder
+	// Timeout bounds each publish. Defaults to 2 seconds.
+	Timeout time.Duration
+	// OnError receives decode and publish errors. Optional.
+	OnError func(error)
+}
+
+// Fanout decodes WebSocket messages and publishes every entry as an encoded Envelope.
+// It is safe for concurrent use.
+type Fanout struct {
+	pub  Publisher
+	opts FanoutOptions
+
+	published atomic.Int64
+	failed    atomic.Int64
+}
+
+// NewFanout creates a fanout publishing to pub.
+func NewFanout(pub Publisher, opts FanoutOptions) *Fanout {
+	if opts.Prefix == "" {
+		opts.Prefix = "bitget"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 2 * time.Second
+	}
+	if opts.Encode == nil {
+		opts.Encode = EncodeProtobuf
+	}
+	return &Fanout{pub: pub, opts: opts}
+}
+
+// HandleMessage publishes a raw WebSocket message. Its signature matches ws.OnReceive so
+// it can be passed to the Subscribe methods directly, or chained after another handler.
+func (f *Fanout) HandleMessage(message string) {
+	envelopes, err := Decode(message)
+	if err != nil {
+		f.report(err)
+		return
+	}
+	for _, e := range envelopes {
+		ctx, cancel := context.WithTimeout(context.Background(), f.opts.Timeout)
+		f.report(f.Publish(ctx, e))
+		cancel()
+	}
+}
+
+// Publish encodes and publishes one envelope on its subject.
+func (f *Fanout) Publish(ctx context.Context, e *Envelope) error {
+	data, err := f.opts.Encode(e)
+	if err == nil {
+		err = f.pub.Publish(ctx, Subject(f.opts.Prefix, e), data)
+	}
+	if err != nil {
+		f.failed.Add(1)
+		return err
+	}
+	f.published.Add(1)
+	return nil
+}
+
+// Stats returns the number of envelopes published and failed so far.
+func (f *Fanout) Stats() (published, failed int64) {
+	return f.published.Load(), f.failed.Load()
+}
+
+func (f *Fanout) report(err error) {
+	if err != nil && f.opts.OnError != nil {
+		f.opts.OnError(err)
+	}
+}
+
+// Subject returns "<prefix>.<productType>.<channel>.<symbol>" for an envelope, with
+// characters that are not valid in subject tokens replaced by "_".
+func Subject(prefix string, e *Envelope) string {
+	return strings.Join([]string{prefix, token(e.ProductType), token(e.Channel), token(e.Symbol())}, ".")
+}
+
+func token(s string) string {
+	if s == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '*', '>', ' ', '\t', '\r', '\n':
+			return '_'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,382 @@
+// Package marketdata normalizes public WebSocket market data into compact protocol
+// buffer messages and fans them out to other services, so one SDK process can hold the
+// exchange connections for many consumers.
+//
+// The messages are defined in marketdata.proto. Encoding is hand-written, so the package
+// needs neither protoc nor a protobuf runtime; consumers in other languages generate
+// bindings from the .proto file.
+//
+//	pub, err := marketdata.NewNATSPublisher(ctx, marketdata.NATSOptions{Addr: "nats:4222"})
+//	fanout := marketdata.NewFanout(pub, marketdata.FanoutOptions{OnError: logError})
+//	wsClient.SubscribeTicker("BTCUSDT", "USDT-FUTURES", fanout.HandleMessage)
+//	wsClient.SubscribeTrades("BTCUSDT", "USDT-FUTURES", fanout.HandleMessage)
+//
+// Subscribers receive Envelope messages on subjects like
+// "bitget.USDT-FUTURES.ticker.BTCUSDT". *state.RedisStore publishes to Redis pub/sub
+// channels of the same names, EncodeJSON serves consumers without protobuf bindings, and
+// Bridge manages the subscriptions. Publisher is small enough to implement over a gRPC
+// stream, Kafka or an in-process channel.
+package marketdata
+
+import "fmt"
+
+// Ticker is a normalized ticker. Timestamps are Unix milliseconds.
+type Ticker struct {
+	Symbol          string  `json:"symbol"`
+	Last            float64 `json:"last"`
+	Bid             float64 `json:"bid"`
+	Ask             float64 `json:"ask"`
+	BidSize         float64 `json:"bidSize"`
+	AskSize         float64 `json:"askSize"`
+	Open24h         float64 `json:"open24h"`
+	High24h         float64 `json:"high24h"`
+	Low24h          float64 `json:"low24h"`
+	Change24h       float64 `json:"change24h"`
+	BaseVolume      float64 `json:"baseVolume"`
+	QuoteVolume     float64 `json:"quoteVolume"`
+	MarkPrice       float64 `json:"markPrice"`
+	IndexPrice      float64 `json:"indexPrice"`
+	FundingRate     float64 `json:"fundingRate"`
+	NextFundingTime int64   `json:"nextFundingTime"`
+	OpenInterest    float64 `json:"openInterest"`
+	Ts              int64   `json:"ts"`
+}
+
+// Candle is a normalized candlestick.
+type Candle struct {
+	Symbol      string  `json:"symbol"`
+	Interval    string  `json:"interval"`
+	Start       int64   `json:"start"`
+	Open        float64 `json:"open"`
+	High        float64 `json:"high"`
+	Low         float64 `json:"low"`
+	Close       float64 `json:"close"`
+	BaseVolume  float64 `json:"baseVolume"`
+	QuoteVolume float64 `json:"quoteVolume"`
+}
+
+// Level is an order book price level.
+type Level struct {
+	Price float64 `json:"price"`
+	Size  float64 `json:"size"`
+}
+
+// OrderBook is a normalized order book snapshot or update.
+type OrderBook struct {
+	Symbol   string  `json:"symbol"`
+	Bids     []Level `json:"bids"`
+	Asks     []Level `json:"asks"`
+	Seq      int64   `json:"seq"`
+	Checksum int64   `json:"checksum"`
+	Snapshot bool    `json:"snapshot"`
+	Ts       int64   `json:"ts"`
+}
+
+// Trade is a normalized public trade.
+type Trade struct {
+	Symbol  string  `json:"symbol"`
+	TradeID string  `json:"tradeId"`
+	Price   float64 `json:"price"`
+	Size    float64 `json:"size"`
+	Side    string  `json:"side"`
+	Ts      int64   `json:"ts"`
+}
+
+// Envelope wraps one payload with its origin. Exactly one payload field is set.
+type Envelope struct {
+	ProductType string `json:"productType"`
+	Channel     string `json:"channel"`
+
+	Ticker    *Ticker    `json:"ticker,omitempty"`
+	Candle    *Candle    `json:"candle,omitempty"`
+	OrderBook *OrderBook `json:"orderBook,omitempty"`
+	Trade     *Trade     `json:"trade,omitempty"`
+}
+
+// Symbol returns the symbol of the payload.
+func (e *Envelope) Symbol() string {
+	switch {
+	case e.Ticker != nil:
+		return e.Ticker.Symbol
+	case e.Candle != nil:
+		return e.Candle.Symbol
+	case e.OrderBook != nil:
+		return e.OrderBook.Symbol
+	case e.Trade != nil:
+		return e.Trade.Symbol
+	}
+	return ""
+}
+
+// Marshal encodes the ticker in protocol buffer wire format.
+func (t *Ticker) Marshal() []byte {
+	var b []byte
+	b = appendString(b, 1, t.Symbol)
+	b = appendDouble(b, 2, t.Last)
+	b = appendDouble(b, 3, t.Bid)
+	b = appendDouble(b, 4, t.Ask)
+	b = appendDouble(b, 5, t.BidSize)
+	b = appendDouble(b, 6, t.AskSize)
+	b = appendDouble(b, 7, t.Open24h)
+	b = appendDouble(b, 8, t.High24h)
+	b = appendDouble(b, 9, t.Low24h)
+	b = appendDouble(b, 10, t.Change24h)
+	b = appendDouble(b, 11, t.BaseVolume)
+	b = appendDouble(b, 12, t.QuoteVolume)
+	b = appendDouble(b, 13, t.MarkPrice)
+	b = appendDouble(b, 14, t.IndexPrice)
+	b = appendDouble(b, 15, t.FundingRate)
+	b = appendInt64(b, 16, t.NextFundingTime)
+	b = appendDouble(b, 17, t.OpenInterest)
+	b = appendInt64(b, 18, t.Ts)
+	return b
+}
+
+// Unmarshal decodes a ticker in protocol buffer wire format.
+func (t *Ticker) Unmarshal(b []byte) error {
+	*t = Ticker{}
+	doubles := map[int]*float64{
+		2: &t.Last, 3: &t.Bid, 4: &t.Ask, 5: &t.BidSize, 6: &t.AskSize, 7: &t.Open24h,
+		8: &t.High24h, 9: &t.Low24h, 10: &t.Change24h, 11: &t.BaseVolume, 12: &t.QuoteVolume,
+		13: &t.MarkPrice, 14: &t.IndexPrice, 15: &t.FundingRate, 17: &t.OpenInterest,
+	}
+	return eachField(b, func(f field) error {
+		if p, ok := doubles[f.num]; ok {
+			return setDouble(f, p)
+		}
+		switch f.num {
+		case 1:
+			return setString(f, &t.Symbol)
+		case 16:
+			return setInt64(f, &t.NextFundingTime)
+		case 18:
+			return setInt64(f, &t.Ts)
+		}
+		return nil
+	})
+}
+
+// Marshal encodes the candle in protocol buffer wire format.
+func (c *Candle) Marshal() []byte {
+	var b []byte
+	b = appendString(b, 1, c.Symbol)
+	b = appendString(b, 2, c.Interval)
+	b = appendInt64(b, 3, c.Start)
+	b = appendDouble(b, 4, c.Open)
+	b = appendDouble(b, 5, c.High)
+	b = appendDouble(b, 6, c.Low)
+	b = appendDouble(b, 7, c.Close)
+	b = appendDouble(b, 8, c.BaseVolume)
+	b = appendDouble(b, 9, c.QuoteVolume)
+	return b
+}
+
+// Unmarshal decodes a candle in protocol buffer wire format.
+func (c *Candle) Unmarshal(b []byte) error {
+	*c = Candle{}
+	doubles := map[int]*float64{4: &c.Open, 5: &c.High, 6: &c.Low, 7: &c.Close, 8: &c.BaseVolume, 9: &c.QuoteVolume}
+	return eachField(b, func(f field) error {
+		if p, ok := doubles[f.num]; ok {
+			return setDouble(f, p)
+		}
+		switch f.num {
+		case 1:
+			return setString(f, &c.Symbol)
+		case 2:
+			return setString(f, &c.Interval)
+		case 3:
+			return setInt64(f, &c.Start)
+		}
+		return nil
+	})
+}
+
+// Marshal encodes the level in protocol buffer wire format.
+func (l *Level) Marshal() []byte {
+	var b []byte
+	b = appendDouble(b, 1, l.Price)
+	b = appendDouble(b, 2, l.Size)
+	return b
+}
+
+// Unmarshal decodes a level in protocol buffer wire format.
+func (l *Level) Unmarshal(b []byte) error {
+	*l = Level{}
+	return eachField(b, func(f field) error {
+		switch f.num {
+		case 1:
+			return setDouble(f, &l.Price)
+		case 2:
+			return setDouble(f, &l.Size)
+		}
+		return nil
+	})
+}
+
+// Marshal encodes the order book in protocol buffer wire format.
+func (o *OrderBook) Marshal() []byte {
+	var b []byte
+	b = appendString(b, 1, o.Symbol)
+	for i := range o.Bids {
+		b = appendMessage(b, 2, o.Bids[i].Marshal())
+	}
+	for i := range o.Asks {
+		b = appendMessage(b, 3, o.Asks[i].Marshal())
+	}
+	b = appendInt64(b, 4, o.Seq)
+	b = appendInt64(b, 5, o.Checksum)
+	b = appendBool(b, 6, o.Snapshot)
+	b = appendInt64(b, 7, o.Ts)
+	return b
+}
+
+// Unmarshal decodes an order book in protocol buffer wire format.
+func (o *OrderBook) Unmarshal(b []byte) error {
+	*o = OrderBook{}
+	return eachField(b, func(f field) error {
+		switch f.num {
+		case 1:
+			return setString(f, &o.Symbol)
+		case 2, 3:
+			if f.wire != wireBytes {
+				return wireError(f)
+			}
+			var l Level
+			if err := l.Unmarshal(f.data); err != nil {
+				return err
+			}
+			if f.num == 2 {
+				o.Bids = append(o.Bids, l)
+			} else {
+				o.Asks = append(o.Asks, l)
+			}
+		case 4:
+			return setInt64(f, &o.Seq)
+		case 5:
+			return setInt64(f, &o.Checksum)
+		case 6:
+			if f.wire != wireVarint {
+				return wireError(f)
+			}
+			o.Snapshot = f.u != 0
+		case 7:
+			return setInt64(f, &o.Ts)
+		}
+		return nil
+	})
+}
+
+// Marshal encodes the trade in protocol buffer wire format.
+func (t *Trade) Marshal() []byte {
+	var b []byte
+	b = appendString(b, 1, t.Symbol)
+	b = appendString(b, 2, t.TradeID)
+	b = appendDouble(b, 3, t.Price)
+	b = appendDouble(b, 4, t.Size)
+	b = appendString(b, 5, t.Side)
+	b = appendInt64(b, 6, t.Ts)
+	return b
+}
+
+// Unmarshal decodes a trade in protocol buffer wire format.
+func (t *Trade) Unmarshal(b []byte) error {
+	*t = Trade{}
+	return eachField(b, func(f field) error {
+		switch f.num {
+		case 1:
+			return setString(f, &t.Symbol)
+		case 2:
+			return setString(f, &t.TradeID)
+		case 3:
+			return setDouble(f, &t.Price)
+		case 4:
+			return setDouble(f, &t.Size)
+		case 5:
+			return setString(f, &t.Side)
+		case 6:
+			return setInt64(f, &t.Ts)
+		}
+		return nil
+	})
+}
+
+// Marshal encodes the envelope in protocol buffer wire format.
+func (e *Envelope) Marshal() []byte {
+	var b []byte
+	b = appendString(b, 1, e.ProductType)
+	b = appendString(b, 2, e.Channel)
+	switch {
+	case e.Ticker != nil:
+		b = appendMessage(b, 10, e.Ticker.Marshal())
+	case e.Candle != nil:
+		b = appendMessage(b, 11, e.Candle.Marshal())
+	case e.OrderBook != nil:
+		b = appendMessage(b, 12, e.OrderBook.Marshal())
+	case e.Trade != nil:
+		b = appendMessage(b, 13, e.Trade.Marshal())
+	}
+	return b
+}
+
+// Unmarshal decodes an envelope in protocol buffer wire format.
+func (e *Envelope) Unmarshal(b []byte) error {
+	*e = Envelope{}
+	return eachField(b, func(f field) error {
+		switch f.num {
+		case 1:
+			return setString(f, &e.ProductType)
+		case 2:
+			return setString(f, &e.Channel)
+		}
+		if f.num < 10 || f.num > 13 {
+			return nil
+		}
+		if f.wire != wireBytes {
+			return wireError(f)
+		}
+		// A later member of the oneof replaces an earlier one
+		e.Ticker, e.Candle, e.OrderBook, e.Trade = nil, nil, nil, nil
+		switch f.num {
+		case 10:
+			e.Ticker = &Ticker{}
+			return e.Ticker.Unmarshal(f.data)
+		case 11:
+			e.Candle = &Candle{}
+			return e.Candle.Unmarshal(f.data)
+		case 12:
+			e.OrderBook = &OrderBook{}
+			return e.OrderBook.Unmarshal(f.data)
+		default:
+			e.Trade = &Trade{}
+			return e.Trade.Unmarshal(f.data)
+		}
+	})
+}
+
+func setString(f field, dst *string) error {
+	if f.wire != wireBytes {
+		return wireError(f)
+	}
+	*dst = f.str()
+	return nil
+}
+
+func setDouble(f field, dst *float64) error {
+	if f.wire != wireFixed64 {
+		return wireError(f)
+	}
+	*dst = f.double()
+	return nil
+}
+
+func setInt64(f field, dst *int64) error {
+	if f.wire != wireVarint {
+		return wireError(f)
+	}
+	*dst = f.int64()
+	return nil
+}
+
+func wireError(f field) error {
+	return fmt.Errorf("marketdata: field %d has wire type %d", f.num, f.wire)
+}
@@ -0,0 +1,201 @@
+package marketdata
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/khanbekov/go-bitget/state"
+	"github.com/khanbekov/go-bitget/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelWireFormat(t *testing.T) {
+	// Matches protoc output for Level{price: 1.5, size: 2}
+	want := []byte{
+		0x09, 0, 0, 0, 0, 0, 0, 0xf8, 0x3f,
+		0x11, 0, 0, 0, 0, 0, 0, 0x00, 0x40,
+	}
+	l := Level{Price: 1.5, Size: 2}
+	assert.Equal(t, want, l.Marshal())
+	assert.Empty(t, (&Level{}).Marshal(), "default values are omitted")
+}
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	envelopes := []*Envelope{
+		{ProductType: "USDT-FUTURES", Channel: "ticker", Ticker: &Ticker{
+			Symbol: "BTCUSDT", Last: 65000.5, Bid: 65000, Ask: 65001, BidSize: 1.2, AskSize: 0.8,
+			Open24h: 64000, High24h: 66000, Low24h: 63000, Change24h: 0.0156, BaseVolume: 1234,
+			QuoteVolume: 8e7, MarkPrice: 65000.2, IndexPrice: 64999.9, FundingRate: -0.0001,
+			NextFundingTime: 1700006400000, OpenInterest: 5000, Ts: 1700000000123,
+		}},
+		{ProductType: "USDT-FUTURES", Channel: "candle1m", Candle: &Candle{
+			Symbol: "ETHUSDT", Interval: "1m", Start: 1700000000000, Open: 3000, High: 3010,
+			Low: 2990, Close: 3005, BaseVolume: 12.5, QuoteVolume: 37500,
+		}},
+		{ProductType: "USDT-FUTURES", Channel: "books", OrderBook: &OrderBook{
+			Symbol: "BTCUSDT", Bids: []Level{{65000, 1}, {64999, 2}}, Asks: []Level{{65001, 0.5}},
+			Seq: 42, Checksum: -123456, Snapshot: true, Ts: 1700000000000,
+		}},
+		{ProductType: "COIN-FUTURES", Channel: "trade", Trade: &Trade{
+			Symbol: "BTCUSD", TradeID: "1111", Price: 65000, Size: 0.01, Side: "buy", Ts: 1700000000000,
+		}},
+	}
+	for _, e := range envelopes {
+		var got Envelope
+		require.NoError(t, got.Unmarshal(e.Marshal()))
+		assert.Equal(t, e, &got)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	var e Envelope
+	b := (&Envelope{Trade: &Trade{Symbol: "BTCUSDT"}}).Marshal()
+	assert.ErrorIs(t, e.Unmarshal(b[:len(b)-1]), errTruncated)
+
+	// Symbol sent as a varint
+	assert.Error(t, (&Trade{}).Unmarshal([]byte{0x08, 0x01}))
+
+	// Unknown fields are skipped
+	var tr Trade
+	require.NoError(t, tr.Unmarshal(append([]byte{0xf8, 0x01, 0x05}, (&Trade{Side: "sell"}).Marshal()...)))
+	assert.Equal(t, "sell", tr.Side)
+}
+
+func TestDecode(t *testing.T) {
+	ticker := `{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"ticker","instId":"BTCUSDT"},
+		"data":[{"instId":"BTCUSDT","lastPr":"65000.5","bidPr":"65000","askPr":"65001","markPrice":"65000.2",
+		"indexPrice":"64999.9","fundingRate":"0.0001","nextFundingTime":"1700006400000","holdingAmount":"5000","ts":"1700000000123"}],"ts":1700000000124}`
+	got, err := Decode(ticker)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "USDT-FUTURES", got[0].ProductType)
+	assert.Equal(t, &Ticker{
+		Symbol: "BTCUSDT", Last: 65000.5, Bid: 65000, Ask: 65001, MarkPrice: 65000.2, IndexPrice: 64999.9,
+		FundingRate: 0.0001, NextFundingTime: 1700006400000, OpenInterest: 5000, Ts: 1700000000123,
+	}, got[0].Ticker)
+
+	candle := `{"action":"update","arg":{"instType":"USDT-FUTURES","channel":"candle5m","instId":"ETHUSDT"},
+		"data":[["1700000000000","3000","3010","2990","3005","12.5","37500","37500"]],"ts":1}`
+	got, err = Decode(candle)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, &Candle{Symbol: "ETHUSDT", Interval: "5m", Start: 1700000000000, Open: 3000,
+		High: 3010, Low: 2990, Close: 3005, BaseVolume: 12.5, QuoteVolume: 37500}, got[0].Candle)
+
+	books := `{"action":"update","arg":{"instType":"USDT-FUTURES","channel":"books","instId":"BTCUSDT"},
+		"data":[{"asks":[["65001","0.5"]],"bids":[["65000","1"]],"checksum":-5,"seq":7,"ts":"1700000000000"}],"ts":1}`
+	got, err = Decode(books)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, &OrderBook{Symbol: "BTCUSDT", Bids: []Level{{65000, 1}}, Asks: []Level{{65001, 0.5}},
+		Seq: 7, Checksum: -5, Ts: 1700000000000}, got[0].OrderBook)
+
+	books5 := `{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"books5","instId":"BTCUSDT"},
+		"data":[{"asks":[],"bids":[],"ts":"1"}],"ts":1}`
+	got, err = Decode(books5)
+	require.NoError(t, err)
+	assert.True(t, got[0].OrderBook.Snapshot)
+
+	trades := `{"action":"update","arg":{"instType":"USDT-FUTURES","channel":"trade","instId":"BTCUSDT"},
+		"data":[{"ts":"1700000000000","price":"65000","size":"0.01","side":"buy","tradeId":"1"},
+		{"ts":"1700000000001","price":"65001","size":"0.02","side":"sell","tradeId":"2"}],"ts":1}`
+	got, err = Decode(trades)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, &Trade{Symbol: "BTCUSDT", TradeID: "2", Price: 65001, Size: 0.02, Side: "sell",
+		Ts: 1700000000001}, got[1].Trade)
+
+	got, err = Decode(`{"event":"subscribe","arg":{"instType":"USDT-FUTURES","channel":"ticker","instId":"BTCUSDT"}}`)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = Decode(`not json`)
+	assert.Error(t, err)
+}
+
+func TestFanout(t *testing.T) {
+	var mu sync.Mutex
+	published := map[string][]byte{}
+	pub := PublisherFunc(func(ctx context.Context, subject string, data []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if subject == "md.USDT-FUTURES.trade.ETHUSDT" {
+			return errors.New("broker down")
+		}
+		published[subject] = data
+		return nil
+	})
+	var errs []error
+	f := NewFanout(pub, FanoutOptions{Prefix: "md", OnError: func(err error) { errs = append(errs, err) }})
+
+	f.HandleMessage(`{"action":"update","arg":{"instType":"USDT-FUTURES","channel":"trade","instId":"BTCUSDT"},
+		"data":[{"ts":"1","price":"65000","size":"0.01","side":"buy","tradeId":"1"}],"ts":1}`)
+	f.HandleMessage(`{"action":"update","arg":{"instType":"USDT-FUTURES","channel":"trade","instId":"ETHUSDT"},
+		"data":[{"ts":"1","price":"3000","size":"1","side":"buy","tradeId":"1"}],"ts":1}`)
+	f.HandleMessage(`{`)
+
+	require.Contains(t, published, "md.USDT-FUTURES.trade.BTCUSDT")
+	var e Envelope
+	require.NoError(t, e.Unmarshal(published["md.USDT-FUTURES.trade.BTCUSDT"]))
+	assert.Equal(t, 65000.0, e.Trade.Price)
+	assert.Len(t, errs, 2)
+
+	ok, failed := f.Stats()
+	assert.Equal(t, int64(1), ok)
+	assert.Equal(t, int64(1), failed)
+}
+
+func TestSubject(t *testing.T) {
+	e := &Envelope{ProductType: "USDT-FUTURES", Channel: "ticker", Ticker: &Ticker{Symbol: "BTC.USDT"}}
+	assert.Equal(t, "bitget.USDT-FUTURES.ticker.BTC_USDT", Subject("bitget", e))
+	assert.Equal(t, "bitget._._._", Subject("bitget", &Envelope{}))
+}
+
+type fakeSubscriber struct {
+	handlers map[ws.SubscriptionArgs]ws.OnReceive
+}
+
+func (f *fakeSubscriber) SubscribeArgs(args ws.SubscriptionArgs, handler ws.OnReceive) {
+	f.handlers[args] = handler
+}
+
+func (f *fakeSubscriber) UnsubscribeArgs(args ws.SubscriptionArgs) {
+	delete(f.handlers, args)
+}
+
+var _ Publisher = (*state.RedisStore)(nil)
+
+func TestBridge(t *testing.T) {
+	client := &fakeSubscriber{handlers: map[ws.SubscriptionArgs]ws.OnReceive{}}
+	var subjects []string
+	var payloads []string
+	pub := PublisherFunc(func(ctx context.Context, subject string, data []byte) error {
+		subjects = append(subjects, subject)
+		payloads = append(payloads, string(data))
+		return nil
+	})
+	b := NewBridge(client, pub, FanoutOptions{Encode: EncodeJSON})
+
+	ticker := ws.SubscriptionArgs{ProductType: "USDT-FUTURES", Channel: ws.ChannelTicker, Symbol: "BTCUSDT"}
+	trades := ws.SubscriptionArgs{ProductType: "USDT-FUTURES", Channel: ws.ChannelTrade, Symbol: "BTCUSDT"}
+	orders := ws.SubscriptionArgs{ProductType: "USDT-FUTURES", Channel: "orders"}
+
+	assert.Error(t, b.Subscribe(ticker, orders))
+	assert.Empty(t, client.handlers)
+
+	require.NoError(t, b.Subscribe(ticker, trades))
+	assert.Equal(t, []ws.SubscriptionArgs{ticker, trades}, b.Active())
+
+	client.handlers[trades](`{"action":"update","arg":{"instType":"USDT-FUTURES","channel":"trade","instId":"BTCUSDT"},
+		"data":[{"ts":"1","price":"65000","size":"0.01","side":"buy","tradeId":"9"}],"ts":1}`)
+	assert.Equal(t, []string{"bitget.USDT-FUTURES.trade.BTCUSDT"}, subjects)
+	assert.JSONEq(t, `{"productType":"USDT-FUTURES","channel":"trade","trade":
+		{"symbol":"BTCUSDT","tradeId":"9","price":65000,"size":0.01,"side":"buy","ts":1}}`, payloads[0])
+
+	b.Close()
+	assert.Empty(t, client.handlers)
+	assert.Empty(t, b.Active())
+}
@@ -0,0 +1,242 @@
+package marketdata
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NATSOptions configures a NATSPublisher.
+type NATSOptions struct {
+	Addr string // host:port, defaults to "127.0.0.1:4222"
+	Name string // Client name shown by the server, defaults to "go-bitget"
+
+	// User and Password, or Token, authenticate the connection when set.
+	User     string
+	Password string
+	Token    string
+
+	// Timeout bounds dialing, the handshake, each publish and Flush. Defaults to 5 seconds.
+	Timeout time.Duration
+}
+
+// NATSPublisher publishes to a NATS server. It speaks the NATS client protocol directly
+// over a single plain TCP connection, so no NATS client dependency is required; the
+// connection is re-established transparently after network errors. TLS is not supported.
+type NATSPublisher struct {
+	opts NATSOptions
+
+	mu    sync.Mutex // Guards conn and w; held while writing
+	conn  net.Conn
+	w     *bufio.Writer
+	pongs chan struct{}
+	errs  chan error
+}
+
+// natsError is an -ERR reply from the server.
+type natsError string
+
+func (e natsError) Error() string { return "nats: " + string(e) }
+
+// NewNATSPublisher connects to the server and completes the handshake.
+func NewNATSPublisher(ctx context.Context, opts NATSOptions) (*NATSPublisher, error) {
+	if opts.Addr == "" {
+		opts.Addr = "127.0.0.1:4222"
+	}
+	if opts.Name == "" {
+		opts.Name = "go-bitget"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	p := &NATSPublisher{opts: opts}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.connect(ctx); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Publish implements Publisher. Messages are written without waiting for the server;
+// call Flush to confirm delivery.
+func (p *NATSPublisher) Publish(ctx context.Context, subject string, data []byte) error {
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n") {
+		return fmt.Errorf("nats: invalid subject %q", subject)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var lastErr error
+	for attempt := 0; attempt < 2; attempt++ {
+		if p.conn == nil {
+			if err := p.connect(ctx); err != nil {
+				return err
+			}
+		}
+		err := p.write(ctx, func(w *bufio.Writer) {
+			w.WriteString("PUB " + subject + " " + strconv.Itoa(len(data)) + "\r\n")
+			w.Write(data)
+			w.WriteString("\r\n")
+		})
+		if err == nil {
+			return nil
+		}
+		// Network error: drop the connection and retry once on a fresh one
+		p.drop()
+		lastErr = err
+	}
+	return lastErr
+}
+
+// Flush sends a PING and waits for the PONG, which the server sends after processing
+// everything published before it.
+func (p *NATSPublisher) Flush(ctx context.Context) error {
+	p.mu.Lock()
+	if p.conn == nil {
+		p.mu.Unlock()
+		return errors.New("nats: not connected")
+	}
+	pongs, errs := p.pongs, p.errs
+	err := p.write(ctx, func(w *bufio.Writer) { w.WriteString("PING\r\n") })
+	p.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return p.await(ctx, pongs, errs)
+}
+
+// Close closes the connection.
+func (p *NATSPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn = nil
+	return err
+}
+
+// connect dials, reads INFO, sends CONNECT and waits for the PONG of the first PING.
+// Callers hold p.mu.
+func (p *NATSPublisher) connect(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: p.opts.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.opts.Addr)
+	if err != nil {
+		return fmt.Errorf("nats: dial %s: %w", p.opts.Addr, err)
+	}
+	conn.SetDeadline(p.deadline(ctx))
+	rd := bufio.NewReader(conn)
+	line, err := rd.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "INFO ") {
+		conn.Close()
+		return fmt.Errorf("nats: handshake with %s: expected INFO, got %q (%v)", p.opts.Addr, line, err)
+	}
+
+	connect, _ := json.Marshal(map[string]interface{}{
+		"verbose": false, "pedantic": false, "name": p.opts.Name, "lang": "go",
+		"version": "1.0.0", "protocol": 1,
+		"user": p.opts.User, "pass": p.opts.Password, "auth_token": p.opts.Token,
+	})
+	if _, err := conn.Write([]byte("CONNECT " + string(connect) + "\r\nPING\r\n")); err != nil {
+		conn.Close()
+		return fmt.Errorf("nats: handshake: %w", err)
+	}
+	conn.SetDeadline(time.Time{})
+
+	p.conn, p.w = conn, bufio.NewWriter(conn)
+	p.pongs, p.errs = make(chan struct{}, 16), make(chan error, 1)
+	go p.read(conn, rd, p.pongs, p.errs)
+
+	if err := p.await(ctx, p.pongs, p.errs); err != nil {
+		p.drop()
+		return err
+	}
+	return nil
+}
+
+// read handles server messages until the connection fails: it answers PING, signals
+// PONG and forwards -ERR.
+func (p *NATSPublisher) read(conn net.Conn, rd *bufio.Reader, pongs chan<- struct{}, errs chan<- error) {
+	for {
+		line, err := rd.ReadString('\n')
+		if err != nil {
+			p.mu.Lock()
+			if p.conn == conn {
+				p.drop()
+			}
+			p.mu.Unlock()
+			select {
+			case errs <- fmt.Errorf("nats: connection lost: %w", err):
+			default:
+			}
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "PING":
+			p.mu.Lock()
+			if p.conn == conn {
+				p.w.WriteString("PONG\r\n")
+				p.w.Flush()
+			}
+			p.mu.Unlock()
+		case line == "PONG":
+			select {
+			case pongs <- struct{}{}:
+			default:
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			select {
+			case errs <- natsError(strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'")):
+			default:
+			}
+		}
+	}
+}
+
+func (p *NATSPublisher) await(ctx context.Context, pongs <-chan struct{}, errs <-chan error) error {
+	timer := time.NewTimer(p.opts.Timeout)
+	defer timer.Stop()
+	select {
+	case <-pongs:
+		return nil
+	case err := <-errs:
+		return err
+	case <-timer.C:
+		return errors.New("nats: timed out waiting for PONG")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// write buffers a command and flushes it within the deadline. Callers hold p.mu.
+func (p *NATSPublisher) write(ctx context.Context, fn func(w *bufio.Writer)) error {
+	p.conn.SetWriteDeadline(p.deadline(ctx))
+	fn(p.w)
+	return p.w.Flush()
+}
+
+// drop closes the current connection. Callers hold p.mu.
+func (p *NATSPublisher) drop() {
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn = nil
+	}
+}
+
+func (p *NATSPublisher) deadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(p.opts.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	return deadline
+}
@@ -0,0 +1,151 @@
+package marketdata
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type natsMsg struct {
+	subject string
+	data    string
+}
+
+// fakeNATS is a minimal NATS server that records published messages.
+type fakeNATS struct {
+	ln net.Listener
+
+	mu       sync.Mutex
+	msgs     []natsMsg
+	connects []string
+	conns    []net.Conn
+	reject   string // Sent as -ERR in response to CONNECT when set
+}
+
+func newFakeNATS(t *testing.T) *fakeNATS {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeNATS{ln: ln}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.conns = append(s.conns, conn)
+			s.mu.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeNATS) serve(conn net.Conn) {
+	defer conn.Close()
+	conn.Write([]byte(`INFO {"server_id":"fake","max_payload":1048576}` + "\r\n"))
+	rd := bufio.NewReader(conn)
+	for {
+		line, err := rd.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case strings.HasPrefix(line, "CONNECT "):
+			s.mu.Lock()
+			s.connects = append(s.connects, strings.TrimPrefix(line, "CONNECT "))
+			reject := s.reject
+			s.mu.Unlock()
+			if reject != "" {
+				conn.Write([]byte("-ERR '" + reject + "'\r\n"))
+				return
+			}
+		case line == "PING":
+			conn.Write([]byte("PONG\r\n"))
+		case strings.HasPrefix(line, "PUB "):
+			parts := strings.Fields(line)
+			n, _ := strconv.Atoi(parts[len(parts)-1])
+			buf := make([]byte, n+2)
+			if _, err := io.ReadFull(rd, buf); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.msgs = append(s.msgs, natsMsg{subject: parts[1], data: string(buf[:n])})
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *fakeNATS) messages() []natsMsg {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]natsMsg(nil), s.msgs...)
+}
+
+func (s *fakeNATS) dropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.conns {
+		c.Close()
+	}
+	s.conns = nil
+}
+
+func TestNATSPublisher(t *testing.T) {
+	s := newFakeNATS(t)
+	ctx := context.Background()
+	p, err := NewNATSPublisher(ctx, NATSOptions{Addr: s.ln.Addr().String(), Token: "secret", Timeout: time.Second})
+	require.NoError(t, err)
+	defer p.Close()
+
+	require.NoError(t, p.Publish(ctx, "bitget.USDT-FUTURES.trade.BTCUSDT", []byte("a\r\nb")))
+	require.NoError(t, p.Publish(ctx, "bitget.USDT-FUTURES.ticker.BTCUSDT", nil))
+	require.NoError(t, p.Flush(ctx))
+
+	assert.Equal(t, []natsMsg{
+		{subject: "bitget.USDT-FUTURES.trade.BTCUSDT", data: "a\r\nb"},
+		{subject: "bitget.USDT-FUTURES.ticker.BTCUSDT", data: ""},
+	}, s.messages())
+	assert.Contains(t, s.connects[0], `"auth_token":"secret"`)
+
+	assert.Error(t, p.Publish(ctx, "bad subject", nil))
+}
+
+func TestNATSPublisherReconnects(t *testing.T) {
+	s := newFakeNATS(t)
+	ctx := context.Background()
+	p, err := NewNATSPublisher(ctx, NATSOptions{Addr: s.ln.Addr().String(), Timeout: time.Second})
+	require.NoError(t, err)
+	defer p.Close()
+
+	s.dropConnections()
+	require.Eventually(t, func() bool {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.conn == nil
+	}, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, p.Publish(ctx, "subject", []byte("after")))
+	require.NoError(t, p.Flush(ctx))
+	assert.Equal(t, []natsMsg{{subject: "subject", data: "after"}}, s.messages())
+}
+
+func TestNATSPublisherRejected(t *testing.T) {
+	s := newFakeNATS(t)
+	s.reject = "Authorization Violation"
+	_, err := NewNATSPublisher(context.Background(), NATSOptions{Addr: s.ln.Addr().String(), Timeout: time.Second})
+	require.Error(t, err)
+	assert.Equal(t, "nats: Authorization Violation", err.Error())
+}
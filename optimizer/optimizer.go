@@ -0,0 +1,436 @@
+// Package optimizer provides walk-forward parameter optimization for trading strategies.
+// It searches a strategy's parameter space (grid or random sampling) on in-sample windows,
+// then measures the chosen parameters on the following out-of-sample window, which is the
+// only honest way to estimate how a tuned strategy will behave on unseen data.
+//
+// The SDK does not ship a strategy runtime, so evaluation is pluggable: callers implement
+// Evaluator by running their own backtest for a parameter set over a time range.
+//
+// Example usage:
+//
+//	windows, _ := optimizer.WalkForwardWindows(start, end, 30*24*time.Hour, 7*24*time.Hour, 7*24*time.Hour, false)
+//	opt := optimizer.New(evaluator, optimizer.Config{
+//		Ranges: []optimizer.ParamRange{
+//			{Name: "fast", Min: 5, Max: 20, Step: 5},
+//			{Name: "slow", Min: 30, Max: 90, Step: 10},
+//		},
+//		Method:  optimizer.SearchGrid,
+//		Workers: 8,
+//	})
+//	report, err := opt.Run(ctx, windows)
+package optimizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Params holds a single parameter combination, keyed by parameter name.
+type Params map[string]float64
+
+// Clone returns an independent copy of the parameter set.
+func (p Params) Clone() Params {
+	out := make(Params, len(p))
+	for k, v := range p {
+		out[k] = v
+	}
+	return out
+}
+
+// ParamRange describes the searchable domain of one strategy parameter.
+// Grid search visits Min, Min+Step, ... up to Max; random search samples uniformly
+// within [Min, Max] and snaps the value to Step when Step is positive.
+type ParamRange struct {
+	Name string
+	Min  float64
+	Max  float64
+	Step float64
+}
+
+// values expands the range into its grid points.
+func (r ParamRange) values() []float64 {
+	if r.Step <= 0 || r.Max <= r.Min {
+		return []float64{r.Min}
+	}
+	n := int(math.Floor((r.Max-r.Min)/r.Step+1e-9)) + 1
+	out := make([]float64, n)
+	for i := 0; i < n; i++ {
+		out[i] = r.Min + float64(i)*r.Step
+	}
+	return out
+}
+
+// sample draws a random value from the range.
+func (r ParamRange) sample(rng *rand.Rand) float64 {
+	if r.Max <= r.Min {
+		return r.Min
+	}
+	v := r.Min + rng.Float64()*(r.Max-r.Min)
+	if r.Step > 0 {
+		v = r.Min + math.Round((v-r.Min)/r.Step)*r.Step
+		if v > r.Max {
+			v -= r.Step
+		}
+	}
+	return v
+}
+
+// SearchMethod selects how candidate parameter sets are generated.
+type SearchMethod int
+
+const (
+	// SearchGrid evaluates every combination of the parameter grids.
+	SearchGrid SearchMethod = iota
+	// SearchRandom evaluates Config.Samples randomly drawn combinations.
+	SearchRandom
+)
+
+// Stats summarizes the performance of one evaluation.
+type Stats struct {
+	Return      float64 // Total return over the evaluated range (0.05 = +5%)
+	SharpeRatio float64 // Risk-adjusted return
+	MaxDrawdown float64 // Maximum peak-to-trough drawdown (0.1 = 10%)
+	Trades      int     // Number of round-trip trades
+}
+
+// Evaluator runs a strategy with the given parameters over [start, end) and reports its stats.
+// Implementations must be safe for concurrent use when Config.Workers is greater than one.
+type Evaluator interface {
+	Evaluate(ctx context.Context, params Params, start, end time.Time) (Stats, error)
+}
+
+// EvaluatorFunc adapts a plain function to the Evaluator interface.
+type EvaluatorFunc func(ctx context.Context, params Params, start, end time.Time) (Stats, error)
+
+// Evaluate calls f(ctx, params, start, end).
+func (f EvaluatorFunc) Evaluate(ctx context.Context, params Params, start, end time.Time) (Stats, error) {
+	return f(ctx, params, start, end)
+}
+
+// Objective scores Stats; the optimizer keeps the parameter set with the highest score.
+type Objective func(Stats) float64
+
+// MaximizeSharpe is the default objective.
+func MaximizeSharpe(s Stats) float64 { return s.SharpeRatio }
+
+// MaximizeReturn ranks candidates by total return.
+func MaximizeReturn(s Stats) float64 { return s.Return }
+
+// Window is one walk-forward step: the optimizer tunes on the train range
+// and reports the tuned parameters' performance on the test range.
+type Window struct {
+	TrainStart time.Time
+	TrainEnd   time.Time
+	TestStart  time.Time
+	TestEnd    time.Time
+}
+
+// WalkForwardWindows splits [start, end) into consecutive train/test windows.
+// Each window trains on `train` and tests on the following `test` duration, and the
+// next window starts `step` later. With anchored set, every train range begins at start
+// (expanding window) instead of rolling forward.
+func WalkForwardWindows(start, end time.Time, train, test, step time.Duration, anchored bool) ([]Window, error) {
+	if train <= 0 || test <= 0 || step <= 0 {
+		return nil, errors.New("train, test and step durations must be positive")
+	}
+	if !end.After(start) {
+		return nil, errors.New("end must be after start")
+	}
+
+	var windows []Window
+	for offset := time.Duration(0); ; offset += step {
+		trainStart := start.Add(offset)
+		if anchored {
+			trainStart = start
+		}
+		trainEnd := start.Add(offset + train)
+		testEnd := trainEnd.Add(test)
+		if testEnd.After(end) {
+			break
+		}
+		windows = append(windows, Window{
+			TrainStart: trainStart,
+			TrainEnd:   trainEnd,
+			TestStart:  trainEnd,
+			TestEnd:    testEnd,
+		})
+	}
+
+	if len(windows) == 0 {
+		return nil, fmt.Errorf("range %s is shorter than one train+test window", end.Sub(start))
+	}
+	return windows, nil
+}
+
+// Config controls the search.
+type Config struct {
+	Ranges    []ParamRange // Parameter domains (required)
+	Method    SearchMethod // Grid (default) or random search
+	Samples   int          // Number of random candidates per window (random search only)
+	Workers   int          // Parallel evaluations; defaults to GOMAXPROCS
+	Seed      int64        // Random search seed; zero uses the current time
+	Objective Objective    // Candidate ranking; defaults to MaximizeSharpe
+}
+
+// WindowResult reports the best in-sample candidate of one window and its out-of-sample stats.
+type WindowResult struct {
+	Window      Window
+	BestParams  Params
+	InSample    Stats
+	OutOfSample Stats
+	Evaluated   int // Number of candidates evaluated in-sample
+	Failed      int // Number of candidates whose evaluation returned an error
+}
+
+// Summary aggregates out-of-sample statistics over all windows.
+type Summary struct {
+	Windows          int
+	MeanReturn       float64
+	CompoundedReturn float64
+	MeanSharpe       float64
+	WorstDrawdown    float64
+	TotalTrades      int
+	ProfitableRatio  float64 // Share of windows with a positive out-of-sample return
+	Efficiency       float64 // Mean out-of-sample objective divided by mean in-sample objective
+}
+
+// Report is the result of a walk-forward run.
+type Report struct {
+	Windows []WindowResult
+	Summary Summary
+}
+
+// Optimizer performs walk-forward optimization using an Evaluator.
+type Optimizer struct {
+	evaluator Evaluator
+	cfg       Config
+}
+
+// New creates an optimizer for the given evaluator and configuration.
+func New(evaluator Evaluator, cfg Config) *Optimizer {
+	if cfg.Workers <= 0 {
+		cfg.Workers = runtime.GOMAXPROCS(0)
+	}
+	if cfg.Objective == nil {
+		cfg.Objective = MaximizeSharpe
+	}
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+	return &Optimizer{evaluator: evaluator, cfg: cfg}
+}
+
+// Candidates returns the parameter sets that will be evaluated for each window.
+func (o *Optimizer) Candidates() ([]Params, error) {
+	if len(o.cfg.Ranges) == 0 {
+		return nil, errors.New("at least one parameter range is required")
+	}
+	for _, r := range o.cfg.Ranges {
+		if r.Name == "" {
+			return nil, errors.New("parameter range name is required")
+		}
+	}
+
+	switch o.cfg.Method {
+	case SearchGrid:
+		return o.gridCandidates(), nil
+	case SearchRandom:
+		if o.cfg.Samples <= 0 {
+			return nil, errors.New("samples must be positive for random search")
+		}
+		return o.randomCandidates(), nil
+	default:
+		return nil, fmt.Errorf("unknown search method %d", o.cfg.Method)
+	}
+}
+
+func (o *Optimizer) gridCandidates() []Params {
+	candidates := []Params{{}}
+	for _, r := range o.cfg.Ranges {
+		values := r.values()
+		next := make([]Params, 0, len(candidates)*len(values))
+		for _, base := range candidates {
+			for _, v := range values {
+				p := base.Clone()
+				p[r.Name] = v
+				next = append(next, p)
+			}
+		}
+		candidates = next
+	}
+	return candidates
+}
+
+func (o *Optimizer) randomCandidates() []Params {
+	rng := rand.New(rand.NewSource(o.cfg.Seed))
+	candidates := make([]Params, o.cfg.Samples)
+	for i := range candidates {
+		p := make(Params, len(o.cfg.Ranges))
+		for _, r := range o.cfg.Ranges {
+			p[r.Name] = r.sample(rng)
+		}
+		candidates[i] = p
+	}
+	return candidates
+}
+
+// Run optimizes each window in turn and returns the aggregated report.
+// Evaluation errors for individual candidates are counted and skipped; a window fails
+// only when no candidate could be evaluated or the out-of-sample run errors.
+func (o *Optimizer) Run(ctx context.Context, windows []Window) (*Report, error) {
+	if o.evaluator == nil {
+		return nil, errors.New("evaluator is required")
+	}
+	if len(windows) == 0 {
+		return nil, errors.New("at least one window is required")
+	}
+
+	candidates, err := o.Candidates()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Windows: make([]WindowResult, 0, len(windows))}
+	for i, w := range windows {
+		result, err := o.runWindow(ctx, w, candidates)
+		if err != nil {
+			return nil, fmt.Errorf("window %d: %w", i+1, err)
+		}
+		report.Windows = append(report.Windows, *result)
+	}
+
+	report.Summary = o.summarize(report.Windows)
+	return report, nil
+}
+
+type evaluation struct {
+	params Params
+	stats  Stats
+	err    error
+}
+
+func (o *Optimizer) runWindow(ctx context.Context, w Window, candidates []Params) (*WindowResult, error) {
+	jobs := make(chan Params)
+	results := make(chan evaluation, len(candidates))
+
+	var wg sync.WaitGroup
+	for i := 0; i < o.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				stats, err := o.evaluator.Evaluate(ctx, p, w.TrainStart, w.TrainEnd)
+				results <- evaluation{params: p, stats: stats, err: err}
+			}
+		}()
+	}
+
+feed:
+	for _, p := range candidates {
+		select {
+		case <-ctx.Done():
+			break feed
+		case jobs <- p:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	close(results)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	evaluations := make([]evaluation, 0, len(candidates))
+	failed := 0
+	for r := range results {
+		if r.err != nil {
+			failed++
+			continue
+		}
+		evaluations = append(evaluations, r)
+	}
+	if len(evaluations) == 0 {
+		return nil, fmt.Errorf("all %d candidates failed in-sample evaluation", len(candidates))
+	}
+
+	// Sort for a deterministic winner when scores tie.
+	sort.SliceStable(evaluations, func(i, j int) bool {
+		si, sj := o.cfg.Objective(evaluations[i].stats), o.cfg.Objective(evaluations[j].stats)
+		if si != sj {
+			return si > sj
+		}
+		return paramsKey(evaluations[i].params) < paramsKey(evaluations[j].params)
+	})
+	best := evaluations[0]
+
+	oos, err := o.evaluator.Evaluate(ctx, best.params, w.TestStart, w.TestEnd)
+	if err != nil {
+		return nil, fmt.Errorf("out-of-sample evaluation: %w", err)
+	}
+
+	return &WindowResult{
+		Window:      w,
+		BestParams:  best.params,
+		InSample:    best.stats,
+		OutOfSample: oos,
+		Evaluated:   len(evaluations),
+		Failed:      failed,
+	}, nil
+}
+
+func (o *Optimizer) summarize(results []WindowResult) Summary {
+	s := Summary{Windows: len(results), CompoundedReturn: 1}
+	if len(results) == 0 {
+		s.CompoundedReturn = 0
+		return s
+	}
+
+	var inSampleScore, outOfSampleScore float64
+	profitable := 0
+	for _, r := range results {
+		s.MeanReturn += r.OutOfSample.Return
+		s.MeanSharpe += r.OutOfSample.SharpeRatio
+		s.CompoundedReturn *= 1 + r.OutOfSample.Return
+		s.TotalTrades += r.OutOfSample.Trades
+		if r.OutOfSample.MaxDrawdown > s.WorstDrawdown {
+			s.WorstDrawdown = r.OutOfSample.MaxDrawdown
+		}
+		if r.OutOfSample.Return > 0 {
+			profitable++
+		}
+		inSampleScore += o.cfg.Objective(r.InSample)
+		outOfSampleScore += o.cfg.Objective(r.OutOfSample)
+	}
+
+	n := float64(len(results))
+	s.MeanReturn /= n
+	s.MeanSharpe /= n
+	s.CompoundedReturn -= 1
+	s.ProfitableRatio = float64(profitable) / n
+	if inSampleScore != 0 {
+		s.Efficiency = outOfSampleScore / inSampleScore
+	}
+	return s
+}
+
+// paramsKey renders params in a stable order for tie-breaking.
+func paramsKey(p Params) string {
+	names := make([]string, 0, len(p))
+	for k := range p {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	key := ""
+	for _, k := range names {
+		key += fmt.Sprintf("%s=%g;", k, p[k])
+	}
+	return key
+}
@@ -0,0 +1,128 @@
+package optimizer
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkForwardWindows_Rolling(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(10 * 24 * time.Hour)
+
+	windows, err := WalkForwardWindows(start, end, 4*24*time.Hour, 2*24*time.Hour, 2*24*time.Hour, false)
+	require.NoError(t, err)
+	require.Len(t, windows, 3)
+
+	assert.Equal(t, start, windows[0].TrainStart)
+	assert.Equal(t, start.Add(4*24*time.Hour), windows[0].TrainEnd)
+	assert.Equal(t, windows[0].TrainEnd, windows[0].TestStart)
+	assert.Equal(t, start.Add(2*24*time.Hour), windows[1].TrainStart)
+	assert.Equal(t, end, windows[2].TestEnd)
+}
+
+func TestWalkForwardWindows_Anchored(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(10 * 24 * time.Hour)
+
+	windows, err := WalkForwardWindows(start, end, 4*24*time.Hour, 2*24*time.Hour, 2*24*time.Hour, true)
+	require.NoError(t, err)
+
+	for _, w := range windows {
+		assert.Equal(t, start, w.TrainStart)
+	}
+	assert.Equal(t, start.Add(8*24*time.Hour), windows[2].TrainEnd)
+}
+
+func TestWalkForwardWindows_TooShort(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := WalkForwardWindows(start, start.Add(time.Hour), 2*time.Hour, time.Hour, time.Hour, false)
+	assert.Error(t, err)
+}
+
+func TestOptimizer_GridCandidates(t *testing.T) {
+	opt := New(nil, Config{Ranges: []ParamRange{
+		{Name: "fast", Min: 5, Max: 15, Step: 5},
+		{Name: "slow", Min: 30, Max: 40, Step: 10},
+	}})
+
+	candidates, err := opt.Candidates()
+	require.NoError(t, err)
+	assert.Len(t, candidates, 6)
+	assert.Contains(t, candidates, Params{"fast": 15, "slow": 40})
+}
+
+func TestOptimizer_RandomCandidatesWithinRange(t *testing.T) {
+	opt := New(nil, Config{
+		Ranges:  []ParamRange{{Name: "threshold", Min: 0.1, Max: 0.5, Step: 0.1}},
+		Method:  SearchRandom,
+		Samples: 50,
+		Seed:    42,
+	})
+
+	candidates, err := opt.Candidates()
+	require.NoError(t, err)
+	require.Len(t, candidates, 50)
+	for _, c := range candidates {
+		assert.GreaterOrEqual(t, c["threshold"], 0.1-1e-9)
+		assert.LessOrEqual(t, c["threshold"], 0.5+1e-9)
+	}
+}
+
+func TestOptimizer_Run(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	windows, err := WalkForwardWindows(start, start.Add(6*time.Hour), 2*time.Hour, time.Hour, time.Hour, false)
+	require.NoError(t, err)
+
+	var calls int64
+	// Score peaks at x=3 in-sample; out-of-sample returns a fixed result
+	evaluator := EvaluatorFunc(func(ctx context.Context, p Params, from, to time.Time) (Stats, error) {
+		atomic.AddInt64(&calls, 1)
+		x := p["x"]
+		if x == 5 {
+			return Stats{}, errors.New("boom")
+		}
+		return Stats{SharpeRatio: -(x - 3) * (x - 3), Return: 0.01, Trades: 2, MaxDrawdown: 0.02}, nil
+	})
+
+	opt := New(evaluator, Config{
+		Ranges:  []ParamRange{{Name: "x", Min: 1, Max: 5, Step: 1}},
+		Workers: 3,
+	})
+
+	report, err := opt.Run(context.Background(), windows)
+	require.NoError(t, err)
+	require.Len(t, report.Windows, len(windows))
+
+	for _, w := range report.Windows {
+		assert.Equal(t, Params{"x": 3}, w.BestParams)
+		assert.Equal(t, 4, w.Evaluated)
+		assert.Equal(t, 1, w.Failed)
+	}
+	assert.Equal(t, int64(len(windows)*6), atomic.LoadInt64(&calls))
+	assert.Equal(t, len(windows), report.Summary.Windows)
+	assert.InDelta(t, 0.01, report.Summary.MeanReturn, 1e-9)
+	assert.Equal(t, 1.0, report.Summary.ProfitableRatio)
+	assert.Equal(t, 2*len(windows), report.Summary.TotalTrades)
+}
+
+func TestOptimizer_RunCancelled(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	windows, _ := WalkForwardWindows(start, start.Add(3*time.Hour), 2*time.Hour, time.Hour, time.Hour, false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	opt := New(EvaluatorFunc(func(ctx context.Context, p Params, from, to time.Time) (Stats, error) {
+		return Stats{}, nil
+	}), Config{Ranges: []ParamRange{{Name: "x", Min: 1, Max: 3, Step: 1}}})
+
+	_, err := opt.Run(ctx, windows)
+	assert.ErrorIs(t, err, context.Canceled)
+}
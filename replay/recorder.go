@@ -0,0 +1,162 @@
+// Package replay records raw WebSocket frames from a live Bitget connection and serves them
+// back over a local WebSocket endpoint, so bots can be rehearsed against historical market
+// bursts (flash crashes, liquidation cascades) without touching the exchange.
+//
+// Recording uses the ws.BaseWsClient raw tap:
+//
+//	rec, _ := replay.NewFileRecorder("btc-crash.jsonl")
+//	defer rec.Close()
+//	wsClient.SetRawTap(rec.Tap)
+//
+// Replaying serves the recording at an adjustable speed:
+//
+//	frames, _ := replay.LoadFile("btc-crash.jsonl")
+//	server := replay.NewServer(frames, replay.ServerOptions{Speed: 10})
+//	http.ListenAndServe("127.0.0.1:8765", server)
+//	// point the bot at ws://127.0.0.1:8765
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// Frame is a single recorded WebSocket message with its receive time.
+type Frame struct {
+	Time time.Time `json:"t"`
+	Data string    `json:"d"`
+}
+
+// Recorder appends frames to an io.Writer as JSON lines.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu     sync.Mutex
+	w      *bufio.Writer
+	closer io.Closer
+	clock  common.Clock
+	err    error
+	count  int
+}
+
+// NewRecorder creates a recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	r := &Recorder{w: bufio.NewWriter(w), clock: common.SystemClock()}
+	if c, ok := w.(io.Closer); ok {
+		r.closer = c
+	}
+	return r
+}
+
+// SetClock sets the clock that stamps recorded frames.
+func (r *Recorder) SetClock(clock common.Clock) {
+	r.clock = common.ClockOrSystem(clock)
+}
+
+// NewFileRecorder creates (or appends to) the file at path and records into it.
+func NewFileRecorder(path string) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open recording file: %w", err)
+	}
+	return NewRecorder(f), nil
+}
+
+// Tap records a frame; its signature matches ws.RawTap.
+// Write errors are retained and reported by Err and Close.
+func (r *Recorder) Tap(frame []byte) {
+	r.Record(Frame{Time: r.clock.Now(), Data: string(frame)})
+}
+
+// Record appends an already timestamped frame.
+func (r *Recorder) Record(f Frame) {
+	line, err := json.Marshal(f)
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	if _, err := r.w.Write(append(line, '\n')); err != nil {
+		r.err = err
+		return
+	}
+	r.count++
+}
+
+// Count returns the number of frames recorded so far.
+func (r *Recorder) Count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count
+}
+
+// Flush writes buffered frames to the underlying writer.
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	return r.w.Flush()
+}
+
+// Err returns the first write error encountered, if any.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Close flushes pending frames and closes the underlying writer when it is closable.
+func (r *Recorder) Close() error {
+	err := r.Flush()
+	if r.closer != nil {
+		if cerr := r.closer.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Load reads JSON-line frames from rd in recorded order.
+func Load(rd io.Reader) ([]Frame, error) {
+	var frames []Frame
+	scanner := bufio.NewScanner(rd)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var f Frame
+		if err := json.Unmarshal(scanner.Bytes(), &f); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		frames = append(frames, f)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return frames, nil
+}
+
+// LoadFile reads a recording produced by NewFileRecorder.
+func LoadFile(path string) ([]Frame, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
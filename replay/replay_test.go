@@ -0,0 +1,71 @@
+package replay
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_LoadRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rec.Record(Frame{Time: base, Data: `{"a":1}`})
+	rec.SetClock(common.NewFakeClock(base.Add(time.Second)))
+	rec.Tap([]byte(`{"a":2}`))
+	require.NoError(t, rec.Close())
+	assert.Equal(t, 2, rec.Count())
+
+	frames, err := Load(&buf)
+	require.NoError(t, err)
+	require.Len(t, frames, 2)
+	assert.Equal(t, `{"a":2}`, frames[1].Data)
+	assert.True(t, frames[1].Time.Equal(base.Add(time.Second)))
+}
+
+func TestLoad_InvalidLine(t *testing.T) {
+	_, err := Load(strings.NewReader("{\"t\":\"2024-01-01T00:00:00Z\",\"d\":\"x\"}\nnot-json\n"))
+	assert.ErrorContains(t, err, "line 2")
+}
+
+func TestServer_ReplaysSubscribedFrames(t *testing.T) {
+	base := time.Now()
+	frames := []Frame{
+		{Time: base, Data: `{"arg":{"instType":"USDT-FUTURES","channel":"ticker","instId":"ETHUSDT"},"data":[1]}`},
+		{Time: base.Add(time.Millisecond), Data: `{"arg":{"instType":"USDT-FUTURES","channel":"ticker","instId":"BTCUSDT"},"data":[2]}`},
+		{Time: base.Add(2 * time.Millisecond), Data: `{"arg":{"instType":"USDT-FUTURES","channel":"ticker","instId":"BTCUSDT"},"data":[3]}`},
+	}
+	srv := httptest.NewServer(NewServer(frames, ServerOptions{}))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("ping")))
+	_, msg, err := conn.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, "pong", string(msg))
+
+	sub := `{"op":"subscribe","args":[{"instType":"USDT-FUTURES","channel":"ticker","instId":"BTCUSDT"}]}`
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(sub)))
+
+	var got []string
+	for len(got) < 3 {
+		_, msg, err := conn.ReadMessage()
+		require.NoError(t, err)
+		got = append(got, string(msg))
+	}
+	assert.Contains(t, got[0], `"event":"subscribe"`)
+	assert.Equal(t, frames[1].Data, got[1])
+	assert.Equal(t, frames[2].Data, got[2])
+}
@@ -0,0 +1,198 @@
+package replay
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/ws"
+)
+
+// ServerOptions controls playback.
+type ServerOptions struct {
+	// Speed scales the recorded inter-frame gaps: 1 replays in real time, 10 is ten times
+	// faster, and zero or negative values send frames back-to-back without delay.
+	Speed float64
+
+	// Loop restarts playback from the first frame once the recording is exhausted.
+	Loop bool
+
+	// Broadcast sends every frame regardless of subscriptions and starts playback as soon
+	// as a client connects. By default only frames matching the connection's subscriptions
+	// are forwarded and playback starts with the first subscribe request.
+	Broadcast bool
+}
+
+// Server replays recorded frames to WebSocket clients. It speaks enough of the Bitget
+// protocol (ping/pong, subscribe/unsubscribe and login acknowledgements) for an unmodified
+// ws.BaseWsClient to connect to it. Every connection gets its own independent playback.
+type Server struct {
+	frames   []Frame
+	opts     ServerOptions
+	upgrader websocket.Upgrader
+}
+
+// NewServer creates a replay server for the given frames.
+func NewServer(frames []Frame, opts ServerOptions) *Server {
+	return &Server{
+		frames: frames,
+		opts:   opts,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection and starts playback.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	sess := &session{
+		server:        s,
+		conn:          conn,
+		subscriptions: make(map[ws.SubscriptionArgs]bool),
+		start:         make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go sess.play()
+	sess.readLoop()
+}
+
+// session is the state of one replay connection.
+type session struct {
+	server        *Server
+	conn          *websocket.Conn
+	writeMu       sync.Mutex
+	subMu         sync.RWMutex
+	subscriptions map[ws.SubscriptionArgs]bool
+	startOnce     sync.Once
+	start         chan struct{}
+	done          chan struct{}
+}
+
+func (s *session) write(data []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// readLoop answers protocol requests until the client disconnects.
+func (s *session) readLoop() {
+	defer func() {
+		close(s.done)
+		s.conn.Close()
+	}()
+
+	if s.server.opts.Broadcast {
+		s.startOnce.Do(func() { close(s.start) })
+	}
+
+	for {
+		_, msg, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if string(msg) == "ping" {
+			if err := s.write([]byte("pong")); err != nil {
+				return
+			}
+			continue
+		}
+
+		var req struct {
+			Op   string                `json:"op"`
+			Args []ws.SubscriptionArgs `json:"args"`
+		}
+		if err := jsoniter.Unmarshal(msg, &req); err != nil {
+			continue
+		}
+
+		switch req.Op {
+		case common.WsOpLogin:
+			ack, _ := jsoniter.Marshal(map[string]interface{}{"event": "login", "code": 0})
+			if err := s.write(ack); err != nil {
+				return
+			}
+		case common.WsOpSubscribe, common.WsOpUnsubscribe:
+			s.subMu.Lock()
+			for _, arg := range req.Args {
+				if req.Op == common.WsOpSubscribe {
+					s.subscriptions[arg] = true
+				} else {
+					delete(s.subscriptions, arg)
+				}
+			}
+			s.subMu.Unlock()
+
+			for _, arg := range req.Args {
+				ack, _ := jsoniter.Marshal(map[string]interface{}{"event": req.Op, "arg": arg})
+				if err := s.write(ack); err != nil {
+					return
+				}
+			}
+			if req.Op == common.WsOpSubscribe {
+				s.startOnce.Do(func() { close(s.start) })
+			}
+		}
+	}
+}
+
+// play streams frames with the configured time scaling.
+func (s *session) play() {
+	select {
+	case <-s.start:
+	case <-s.done:
+		return
+	}
+
+	for {
+		var prev time.Time
+		for i, f := range s.server.frames {
+			if i > 0 && s.server.opts.Speed > 0 {
+				gap := time.Duration(float64(f.Time.Sub(prev)) / s.server.opts.Speed)
+				if gap > 0 {
+					select {
+					case <-time.After(gap):
+					case <-s.done:
+						return
+					}
+				}
+			}
+			prev = f.Time
+
+			if !s.wants(f) {
+				continue
+			}
+			if err := s.write([]byte(f.Data)); err != nil {
+				return
+			}
+		}
+		if !s.server.opts.Loop || len(s.server.frames) == 0 {
+			return
+		}
+	}
+}
+
+// wants reports whether the frame matches one of the session's subscriptions.
+// Frames without a channel argument (events, errors) are always forwarded.
+func (s *session) wants(f Frame) bool {
+	if s.server.opts.Broadcast {
+		return true
+	}
+
+	var msg struct {
+		Arg *ws.SubscriptionArgs `json:"arg"`
+	}
+	if err := jsoniter.UnmarshalFromString(f.Data, &msg); err != nil || msg.Arg == nil {
+		return true
+	}
+
+	s.subMu.RLock()
+	defer s.subMu.RUnlock()
+	return s.subscriptions[*msg.Arg]
+}
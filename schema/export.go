@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// OpenAPI returns an OpenAPI 3.1 document with the schemas of types, generated by
+// Default, under components/schemas. It has no paths; tools consuming exported data
+// reference the components.
+func OpenAPI(title, version string, types []Type) map[string]interface{} {
+	return map[string]interface{}{
+		"openapi": "3.1.0",
+		"info":    map[string]string{"title": title, "version": version},
+		"paths":   map[string]interface{}{},
+		"components": map[string]interface{}{
+			"schemas": Default().Components(types),
+		},
+	}
+}
+
+// WriteDir writes a standalone schema for each of types, generated by Default, to
+// dir/<Name>.schema.json, creating dir when needed.
+func WriteDir(dir string, types []Type) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("schema: %w", err)
+	}
+	g := Default()
+	for _, t := range types {
+		s, err := g.Generate(t.Value)
+		if err != nil {
+			return fmt.Errorf("schema: %s: %w", t.Name, err)
+		}
+		s.ID = t.Name + ".schema.json"
+		if err := WriteJSON(filepath.Join(dir, s.ID), s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes v as indented JSON to path.
+func WriteJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("schema: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("schema: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+// Package schema generates JSON Schemas (draft 2020-12) and OpenAPI 3.1 components for
+// the SDK's response types, so services consuming exported orders, positions or candles
+// in other languages can validate payloads.
+//
+// Schemas describe what encoding/json produces for a value, following json tags:
+// fields without omitempty are required, pointers are nullable and named structs become
+// reusable definitions. Types whose MarshalJSON changes the shape are described with
+// Override and Extend, as Default does for the SDK types:
+//
+//	doc, err := schema.OpenAPI("Bitget SDK exports", "1.0.0", schema.Types())
+//	err = schema.WriteDir("schemas", schema.Types()) // One <Name>.schema.json per type
+//
+// The schemagen command in cmd/schemagen wraps both.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect of generated documents.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document or subschema.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 interface{}        `json:"type,omitempty"` // A type name or a list of them
+	Format               string             `json:"format,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	PrefixItems          []*Schema          `json:"prefixItems,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Type is a named type to generate a schema for.
+type Type struct {
+	Name  string
+	Value interface{} // A value of the type, usually its zero value
+}
+
+// Generator builds schemas by reflection. Configure it before use; generating is safe
+// for concurrent use.
+type Generator struct {
+	names     map[reflect.Type]string
+	overrides map[reflect.Type]*Schema
+	extra     map[reflect.Type][]extraField
+}
+
+type extraField struct {
+	name   string
+	schema *Schema
+}
+
+// NewGenerator creates a generator without SDK-specific configuration; see Default.
+func NewGenerator() *Generator {
+	return &Generator{
+		names:     make(map[reflect.Type]string),
+		overrides: make(map[reflect.Type]*Schema),
+		extra:     make(map[reflect.Type][]extraField),
+	}
+}
+
+// Name sets the definition name of the type of v. Unnamed definitions are called
+// <package>.<Type>, e.g. "trading.OrderDetail".
+func (g *Generator) Name(v interface{}, name string) {
+	g.names[typeOf(v)] = name
+}
+
+// Override replaces the reflected schema of the type of v, for types with custom
+// MarshalJSON.
+func (g *Generator) Override(v interface{}, s *Schema) {
+	g.overrides[typeOf(v)] = s
+}
+
+// Extend adds an optional property to the object schema of the type of v, for fields
+// appended by MarshalJSON.
+func (g *Generator) Extend(v interface{}, property string, s *Schema) {
+	t := typeOf(v)
+	g.extra[t] = append(g.extra[t], extraField{name: property, schema: s})
+}
+
+// Generate returns a standalone schema for v with nested named structs in $defs.
+func (g *Generator) Generate(v interface{}) (*Schema, error) {
+	t := typeOf(v)
+	if t == nil {
+		return nil, fmt.Errorf("schema: nil value")
+	}
+	b := &builder{g: g, refPrefix: "#/$defs/", defs: make(map[string]*Schema)}
+	root := b.inline(t)
+	root.Schema = Draft
+	root.Title = b.name(t)
+	if len(b.defs) > 0 {
+		root.Defs = b.defs
+	}
+	return root, nil
+}
+
+// Components returns the schemas of types keyed by name, with references between them
+// in OpenAPI form (#/components/schemas/<Name>).
+func (g *Generator) Components(types []Type) map[string]*Schema {
+	for _, tp := range types {
+		if _, ok := g.names[typeOf(tp.Value)]; !ok {
+			g.Name(tp.Value, tp.Name)
+		}
+	}
+	b := &builder{g: g, refPrefix: "#/components/schemas/", defs: make(map[string]*Schema)}
+	for _, tp := range types {
+		t := typeOf(tp.Value)
+		b.defs[b.name(t)] = b.inline(t)
+	}
+	return b.defs
+}
+
+// builder holds the state of one generation.
+type builder struct {
+	g         *Generator
+	refPrefix string
+	defs      map[string]*Schema
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	marshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// schema returns the schema of t, referencing named structs.
+func (b *builder) schema(t reflect.Type) *Schema {
+	if s, ok := b.g.overrides[t]; ok {
+		return clone(s)
+	}
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := b.schema(t.Elem())
+		if typ, ok := s.Type.(string); ok {
+			s.Type = []string{typ, "null"}
+			return s
+		}
+		return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.inline(t)
+		}
+		name := b.name(t)
+		if _, ok := b.defs[name]; !ok {
+			b.defs[name] = nil // Placeholder for recursive types
+			b.defs[name] = b.inline(t)
+		}
+		return &Schema{Ref: b.refPrefix + name}
+	}
+	return b.inline(t)
+}
+
+// inline returns the schema of t without a reference at the top level.
+func (b *builder) inline(t reflect.Type) *Schema {
+	if s, ok := b.g.overrides[t]; ok {
+		return clone(s)
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return b.inline(t.Elem())
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: b.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schema(t.Elem())}
+	case reflect.Struct:
+		if t == timeType {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		return b.object(t)
+	}
+	// Interfaces, channels and functions: anything goes
+	return &Schema{}
+}
+
+// object builds the schema of a struct from its exported fields.
+func (b *builder) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	b.fields(t, s)
+	for _, e := range b.g.extra[t] {
+		s.Properties[e.name] = clone(e.schema)
+	}
+	sort.Strings(s.Required)
+	return s
+}
+
+func (b *builder) fields(t reflect.Type, s *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			// Embedded structs are flattened like encoding/json does
+			if ft.Kind() == reflect.Struct && !ft.Implements(marshalerType) {
+				b.fields(ft, s)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		var fs *Schema
+		if strings.Contains(","+opts+",", ",string,") && isScalar(f.Type) {
+			fs = &Schema{Type: "string"}
+		} else {
+			fs = b.schema(f.Type)
+		}
+		s.Properties[name] = fs
+		if !strings.Contains(","+opts+",", ",omitempty,") && !strings.Contains(","+opts+",", ",omitzero,") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// name returns the definition name of t.
+func (b *builder) name(t reflect.Type) string {
+	if name, ok := b.g.names[t]; ok {
+		return name
+	}
+	if t.Name() == "" {
+		return "Value"
+	}
+	return path.Base(t.PkgPath()) + "." + t.Name()
+}
+
+func isScalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func typeOf(v interface{}) reflect.Type {
+	if t, ok := v.(reflect.Type); ok {
+		return t
+	}
+	return reflect.TypeOf(v)
+}
+
+// clone deep-copies s so callers cannot change configured schemas.
+func clone(s *Schema) *Schema {
+	data, _ := json.Marshal(s)
+	var out Schema
+	_ = json.Unmarshal(data, &out)
+	return &out
+}
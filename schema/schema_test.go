@@ -0,0 +1,131 @@
+package schema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testLeg struct {
+	Symbol string  `json:"symbol"`
+	Size   float64 `json:"size,string"`
+}
+
+type testTrade struct {
+	ID       string            `json:"id"`
+	Note     string            `json:"note,omitempty"`
+	Legs     []testLeg         `json:"legs"`
+	Main     *testLeg          `json:"main"`
+	Price    *float64          `json:"price,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty"`
+	At       time.Time         `json:"at"`
+	Raw      json.RawMessage   `json:"raw,omitempty"`
+	Ignored  string            `json:"-"`
+	internal string
+	testEmbedded
+}
+
+type testEmbedded struct {
+	Venue string `json:"venue"`
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	s, err := NewGenerator().Generate(testTrade{})
+	require.NoError(t, err)
+
+	assert.Equal(t, Draft, s.Schema)
+	assert.Equal(t, "schema.testTrade", s.Title)
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, []string{"at", "id", "legs", "main", "venue"}, s.Required)
+	assert.NotContains(t, s.Properties, "Ignored")
+	assert.NotContains(t, s.Properties, "internal")
+
+	assert.Equal(t, "array", s.Properties["legs"].Type)
+	assert.Equal(t, "#/$defs/schema.testLeg", s.Properties["legs"].Items.Ref)
+	assert.Equal(t, "#/$defs/schema.testLeg", s.Properties["main"].AnyOf[0].Ref)
+	assert.Equal(t, []string{"number", "null"}, s.Properties["price"].Type)
+	assert.Equal(t, "string", s.Properties["tags"].AdditionalProperties.Type)
+	assert.Equal(t, "date-time", s.Properties["at"].Format)
+	assert.Equal(t, &Schema{}, s.Properties["raw"])
+	assert.Equal(t, "string", s.Properties["venue"].Type)
+
+	leg := s.Defs["schema.testLeg"]
+	require.NotNil(t, leg)
+	assert.Equal(t, "string", leg.Properties["size"].Type, ",string fields are encoded as strings")
+}
+
+func TestGenerator_OverrideAndExtend(t *testing.T) {
+	g := NewGenerator()
+	g.Name(testLeg{}, "Leg")
+	g.Override(time.Time{}, &Schema{Type: "integer", Description: "Unix milliseconds"})
+	g.Extend(testLeg{}, "notional", &Schema{Type: "number"})
+
+	s, err := g.Generate(testTrade{})
+	require.NoError(t, err)
+	assert.Equal(t, "integer", s.Properties["at"].Type)
+	assert.Equal(t, "#/$defs/Leg", s.Properties["legs"].Items.Ref)
+	assert.Contains(t, s.Defs["Leg"].Properties, "notional")
+	assert.NotContains(t, s.Defs["Leg"].Required, "notional")
+}
+
+// The SDK types must produce exactly the documented keys when marshaled.
+func TestDefault_MatchesMarshaledOutput(t *testing.T) {
+	components := Default().Components(Types())
+	for _, tp := range Types() {
+		s := components[tp.Name]
+		require.NotNil(t, s, tp.Name)
+
+		data, err := json.Marshal(tp.Value)
+		require.NoError(t, err, tp.Name)
+		if s.Type == "array" {
+			var arr []interface{}
+			require.NoError(t, json.Unmarshal(data, &arr), tp.Name)
+			assert.GreaterOrEqual(t, len(arr), *s.MinItems, tp.Name)
+			continue
+		}
+
+		var obj map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &obj), tp.Name)
+		for key := range obj {
+			assert.Contains(t, s.Properties, key, "%s: marshaled key missing from schema", tp.Name)
+		}
+		for _, key := range s.Required {
+			assert.Contains(t, obj, key, "%s: required key not marshaled", tp.Name)
+		}
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	doc := OpenAPI("test", "1.0.0", Types())
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	var parsed struct {
+		OpenAPI    string `json:"openapi"`
+		Components struct {
+			Schemas map[string]*Schema `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(data, &parsed))
+	assert.Equal(t, "3.1.0", parsed.OpenAPI)
+	assert.Contains(t, parsed.Components.Schemas, "FuturesOrder")
+	assert.Contains(t, parsed.Components.Schemas, "UTAPosition")
+	assert.Contains(t, parsed.Components.Schemas["FuturesOrder"].Properties, "createdAt")
+}
+
+func TestWriteDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, WriteDir(dir, Types()[:2]))
+
+	var s Schema
+	data, err := os.ReadFile(filepath.Join(dir, "FuturesAccount.schema.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &s))
+	assert.Equal(t, "FuturesAccount.schema.json", s.ID)
+	assert.Equal(t, "FuturesAccount", s.Title)
+}
@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/khanbekov/go-bitget/futures/account"
+	"github.com/khanbekov/go-bitget/futures/market"
+	"github.com/khanbekov/go-bitget/futures/position"
+	"github.com/khanbekov/go-bitget/futures/trading"
+	"github.com/khanbekov/go-bitget/uta"
+)
+
+// Types lists the exported SDK response types with their schema names.
+func Types() []Type {
+	return []Type{
+		{"FuturesAccount", account.Account{}},
+		{"FuturesPosition", position.Position{}},
+		{"FuturesHistoryPosition", position.HistoryPosition{}},
+		{"FuturesOrder", trading.OrderDetail{}},
+		{"FuturesPendingOrder", trading.PendingOrder{}},
+		{"FuturesHistoricalOrder", trading.HistoricalOrder{}},
+		{"FuturesPlanOrder", trading.PendingPlanOrder{}},
+		{"FuturesFill", trading.FillRecord{}},
+		{"FuturesTicker", market.Ticker{}},
+		{"FuturesContract", market.Contract{}},
+		{"Candle", common.Candle{}},
+		{"UTAOrder", uta.Order{}},
+		{"UTAFill", uta.Fill{}},
+		{"UTAPosition", uta.Position{}},
+	}
+}
+
+// Default returns a generator configured for the SDK types: names from Types, the
+// candle wire array, and the timestamps added by the MarshalJSON methods of response
+// types.
+func Default() *Generator {
+	g := NewGenerator()
+	for _, t := range Types() {
+		g.Name(t.Value, t.Name)
+	}
+
+	minItems := 7
+	decimal := &Schema{Type: "string", Description: "Decimal in plain notation"}
+	g.Override(common.Candle{}, &Schema{
+		Type:        "array",
+		Description: "[timestamp (ms), open, high, low, close, base volume, quote volume]",
+		PrefixItems: []*Schema{
+			{Type: "string", Description: "Period start in milliseconds"},
+			decimal, decimal, decimal, decimal, decimal, decimal,
+		},
+		MinItems: &minItems,
+		Items:    &Schema{Type: "string"},
+	})
+
+	timestamp := func(field string) *Schema {
+		return &Schema{Type: "string", Format: "date-time", Description: "Parsed from " + field + "; omitted when unset"}
+	}
+	for _, v := range []interface{}{trading.OrderDetail{}, trading.PendingOrder{}, trading.HistoricalOrder{}} {
+		g.Extend(v, "createdAt", timestamp("cTime"))
+		g.Extend(v, "updatedAt", timestamp("uTime"))
+	}
+	g.Extend(trading.FillRecord{}, "createdAt", timestamp("cTime"))
+	g.Extend(position.Position{}, "createdAt", timestamp("ctime"))
+	g.Extend(position.Position{}, "updatedAt", timestamp("utime"))
+	for _, v := range []interface{}{uta.Order{}, uta.Position{}} {
+		g.Extend(v, "createdAt", timestamp("createdTime"))
+		g.Extend(v, "updatedAt", timestamp("updatedTime"))
+	}
+	g.Extend(uta.Fill{}, "time", timestamp("timestamp"))
+	return g
+}
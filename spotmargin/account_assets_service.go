@@ -0,0 +1,75 @@
+package spotmargin
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// AccountAssetsService retrieves the balances and debt of the margin account.
+type AccountAssetsService struct {
+	c ClientInterface
+
+	mode   MarginMode
+	symbol string
+	coin   string
+}
+
+// MarginMode selects the cross (default) or isolated margin account.
+func (s *AccountAssetsService) MarginMode(mode MarginMode) *AccountAssetsService {
+	s.mode = mode
+	return s
+}
+
+// Symbol filters isolated accounts by pair, e.g. "BTCUSDT".
+func (s *AccountAssetsService) Symbol(symbol string) *AccountAssetsService {
+	s.symbol = symbol
+	return s
+}
+
+// Coin filters cross margin assets by coin, e.g. "USDT".
+func (s *AccountAssetsService) Coin(coin string) *AccountAssetsService {
+	s.coin = coin
+	return s
+}
+
+// Asset is the balance of one coin in the margin account. Net is TotalAmount less Borrow
+// and Interest.
+type Asset struct {
+	Symbol      string `json:"symbol"` // Isolated margin only
+	Coin        string `json:"coin"`
+	TotalAmount string `json:"totalAmount"`
+	Available   string `json:"available"`
+	Frozen      string `json:"frozen"`
+	Borrow      string `json:"borrow"`
+	Interest    string `json:"interest"`
+	Net         string `json:"net"`
+	Coupon      string `json:"coupon"`
+	CTime       string `json:"cTime"`
+	UTime       string `json:"uTime"`
+}
+
+// Do sends the request.
+func (s *AccountAssetsService) Do(ctx context.Context) ([]*Asset, error) {
+	if err := checkAccount(s.mode, s.symbol, false); err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	if s.mode == Isolated {
+		if s.symbol != "" {
+			params.Set("symbol", s.symbol)
+		}
+	} else if s.coin != "" {
+		params.Set("coin", s.coin)
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", endpoint(s.mode, EndpointCrossedAssets, EndpointIsolatedAssets), params, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	var assets []*Asset
+	if err := json.Unmarshal(res.Data, &assets); err != nil {
+		return nil, err
+	}
+	return assets, nil
+}
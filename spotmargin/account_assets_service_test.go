@@ -0,0 +1,32 @@
+package spotmargin
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestAccountAssetsService_Do(t *testing.T) {
+	client := &MockClient{}
+	client.On("CallAPI", mock.Anything, "GET", EndpointCrossedAssets, url.Values{}, []byte(nil), true).
+		Return(okResponse(`[{"coin":"USDT","totalAmount":"1000","available":"900","frozen":"0","borrow":"100","interest":"0.5","net":"899.5"}]`), &fasthttp.ResponseHeader{}, nil)
+	client.On("CallAPI", mock.Anything, "GET", EndpointIsolatedAssets, url.Values{"symbol": {"BTCUSDT"}}, []byte(nil), true).
+		Return(okResponse(`[{"symbol":"BTCUSDT","coin":"BTC","totalAmount":"0.1"},{"symbol":"BTCUSDT","coin":"USDT","totalAmount":"500"}]`), &fasthttp.ResponseHeader{}, nil)
+
+	assets, err := NewAccountAssetsService(client).Do(context.Background())
+	require.NoError(t, err)
+	require.Len(t, assets, 1)
+	assert.Equal(t, "100", assets[0].Borrow)
+	assert.Equal(t, "899.5", assets[0].Net)
+
+	assets, err = NewAccountAssetsService(client).MarginMode(Isolated).Symbol("BTCUSDT").Do(context.Background())
+	require.NoError(t, err)
+	require.Len(t, assets, 2)
+	assert.Equal(t, "BTC", assets[0].Coin)
+	client.AssertExpectations(t)
+}
@@ -0,0 +1,94 @@
+package spotmargin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// BorrowService borrows a coin in the cross or an isolated margin account.
+type BorrowService struct {
+	c ClientInterface
+
+	mode      MarginMode
+	symbol    string
+	coin      string
+	amount    string
+	clientOid string
+}
+
+// MarginMode selects the cross (default) or isolated margin account.
+func (s *BorrowService) MarginMode(mode MarginMode) *BorrowService {
+	s.mode = mode
+	return s
+}
+
+// Symbol sets the isolated margin pair, e.g. "BTCUSDT" (required for Isolated).
+func (s *BorrowService) Symbol(symbol string) *BorrowService {
+	s.symbol = symbol
+	return s
+}
+
+// Coin sets the coin to borrow, e.g. "USDT" (required).
+func (s *BorrowService) Coin(coin string) *BorrowService {
+	s.coin = coin
+	return s
+}
+
+// Amount sets the amount to borrow (required).
+func (s *BorrowService) Amount(amount string) *BorrowService {
+	s.amount = amount
+	return s
+}
+
+// ClientOid sets a client-defined ID for the loan.
+func (s *BorrowService) ClientOid(clientOid string) *BorrowService {
+	s.clientOid = clientOid
+	return s
+}
+
+// BorrowResponse is the result of a loan.
+type BorrowResponse struct {
+	LoanId       string `json:"loanId"`
+	Symbol       string `json:"symbol"` // Isolated margin only
+	Coin         string `json:"coin"`
+	BorrowAmount string `json:"borrowAmount"`
+}
+
+// Do sends the borrow request.
+func (s *BorrowService) Do(ctx context.Context) (*BorrowResponse, error) {
+	if err := checkAccount(s.mode, s.symbol, true); err != nil {
+		return nil, err
+	}
+	if s.coin == "" {
+		return nil, fmt.Errorf("coin is required")
+	}
+	if s.amount == "" {
+		return nil, fmt.Errorf("amount is required")
+	}
+
+	params := map[string]interface{}{
+		"loanCoin":     s.coin,
+		"borrowAmount": s.amount,
+	}
+	if s.mode == Isolated {
+		params["symbol"] = s.symbol
+	}
+	if s.clientOid != "" {
+		params["clientOid"] = s.clientOid
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "POST", endpoint(s.mode, EndpointCrossedBorrow, EndpointIsolatedBorrow), nil, body, true)
+	if err != nil {
+		return nil, err
+	}
+	var result BorrowResponse
+	if err := json.Unmarshal(res.Data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
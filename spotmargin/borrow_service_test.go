@@ -0,0 +1,48 @@
+package spotmargin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestBorrowService_Crossed(t *testing.T) {
+	client := &MockClient{}
+	client.On("CallAPI", mock.Anything, "POST", EndpointCrossedBorrow, mock.Anything,
+		bodyEquals(map[string]interface{}{"loanCoin": "USDT", "borrowAmount": "100", "clientOid": "b1"}), true).
+		Return(okResponse(`{"loanId":"1","coin":"USDT","borrowAmount":"100"}`), &fasthttp.ResponseHeader{}, nil)
+
+	res, err := NewBorrowService(client).Coin("USDT").Amount("100").ClientOid("b1").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "1", res.LoanId)
+	assert.Equal(t, "100", res.BorrowAmount)
+	client.AssertExpectations(t)
+}
+
+func TestBorrowService_Isolated(t *testing.T) {
+	client := &MockClient{}
+	client.On("CallAPI", mock.Anything, "POST", EndpointIsolatedBorrow, mock.Anything,
+		bodyEquals(map[string]interface{}{"symbol": "BTCUSDT", "loanCoin": "BTC", "borrowAmount": "0.1"}), true).
+		Return(okResponse(`{"loanId":"2","symbol":"BTCUSDT","coin":"BTC","borrowAmount":"0.1"}`), &fasthttp.ResponseHeader{}, nil)
+
+	res, err := NewBorrowService(client).MarginMode(Isolated).Symbol("BTCUSDT").Coin("BTC").Amount("0.1").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "BTCUSDT", res.Symbol)
+	client.AssertExpectations(t)
+}
+
+func TestBorrowService_Validation(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewBorrowService(nil).Amount("1").Do(ctx)
+	assert.EqualError(t, err, "coin is required")
+	_, err = NewBorrowService(nil).Coin("USDT").Do(ctx)
+	assert.EqualError(t, err, "amount is required")
+	_, err = NewBorrowService(nil).MarginMode(Isolated).Coin("USDT").Amount("1").Do(ctx)
+	assert.EqualError(t, err, "symbol is required for isolated margin")
+	_, err = NewBorrowService(nil).MarginMode("portfolio").Coin("USDT").Amount("1").Do(ctx)
+	assert.EqualError(t, err, `invalid margin mode "portfolio"`)
+}
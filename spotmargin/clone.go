@@ -0,0 +1,43 @@
+package spotmargin
+
+// Clone returns an independent copy of s and its parameters.
+func (s *AccountAssetsService) Clone() *AccountAssetsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *BorrowService) Clone() *BorrowService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *InterestHistoryService) Clone() *InterestHistoryService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *LiquidationHistoryService) Clone() *LiquidationHistoryService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *MaxBorrowableService) Clone() *MaxBorrowableService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *PlaceOrderService) Clone() *PlaceOrderService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *RepayService) Clone() *RepayService {
+	c := *s
+	return &c
+}
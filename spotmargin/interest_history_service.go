@@ -0,0 +1,145 @@
+package spotmargin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// InterestHistoryService retrieves the interest charged on margin loans.
+type InterestHistoryService struct {
+	c ClientInterface
+
+	mode       MarginMode
+	symbol     string
+	coin       string
+	startTime  string
+	endTime    string
+	limit      string
+	idLessThan string
+}
+
+// MarginMode selects the cross (default) or isolated margin account.
+func (s *InterestHistoryService) MarginMode(mode MarginMode) *InterestHistoryService {
+	s.mode = mode
+	return s
+}
+
+// Symbol sets the isolated margin pair, e.g. "BTCUSDT" (required for Isolated).
+func (s *InterestHistoryService) Symbol(symbol string) *InterestHistoryService {
+	s.symbol = symbol
+	return s
+}
+
+// Coin filters records by loan coin, e.g. "USDT".
+func (s *InterestHistoryService) Coin(coin string) *InterestHistoryService {
+	s.coin = coin
+	return s
+}
+
+// StartTime sets the start of the query range in Unix milliseconds (required).
+func (s *InterestHistoryService) StartTime(startTime string) *InterestHistoryService {
+	s.startTime = startTime
+	return s
+}
+
+// EndTime sets the end of the query range in Unix milliseconds.
+func (s *InterestHistoryService) EndTime(endTime string) *InterestHistoryService {
+	s.endTime = endTime
+	return s
+}
+
+// Limit sets the page size, at most 100.
+func (s *InterestHistoryService) Limit(limit string) *InterestHistoryService {
+	s.limit = limit
+	return s
+}
+
+// IdLessThan requests the page of records older than the given MinId.
+func (s *InterestHistoryService) IdLessThan(idLessThan string) *InterestHistoryService {
+	s.idLessThan = idLessThan
+	return s
+}
+
+// InterestRecord is one interest charge.
+type InterestRecord struct {
+	InterestId        string `json:"interestId"`
+	Symbol            string `json:"symbol"` // Isolated margin only
+	InterestCoin      string `json:"interestCoin"`
+	LoanCoin          string `json:"loanCoin"`
+	DailyInterestRate string `json:"dailyInterestRate"`
+	InterestAmount    string `json:"interestAmount"`
+	InterestType      string `json:"interstType"` // Misspelled by the exchange
+	CTime             string `json:"cTime"`
+	UTime             string `json:"uTime"`
+}
+
+// Time returns the time the interest was charged.
+func (r *InterestRecord) Time() time.Time {
+	ms, _ := strconv.ParseInt(r.CTime, 10, 64)
+	return time.UnixMilli(ms)
+}
+
+// InterestHistoryResponse is one page of interest records.
+type InterestHistoryResponse struct {
+	ResultList []*InterestRecord `json:"resultList"`
+	MaxId      string            `json:"maxId"`
+	MinId      string            `json:"minId"`
+}
+
+// Do sends the request.
+func (s *InterestHistoryService) Do(ctx context.Context) (*InterestHistoryResponse, error) {
+	if err := checkAccount(s.mode, s.symbol, true); err != nil {
+		return nil, err
+	}
+	if s.startTime == "" {
+		return nil, fmt.Errorf("startTime is required")
+	}
+	params := url.Values{}
+	params.Set("startTime", s.startTime)
+	if s.mode == Isolated {
+		params.Set("symbol", s.symbol)
+	}
+	if s.coin != "" {
+		params.Set("coin", s.coin)
+	}
+	if s.endTime != "" {
+		params.Set("endTime", s.endTime)
+	}
+	if s.limit != "" {
+		params.Set("limit", s.limit)
+	}
+	if s.idLessThan != "" {
+		params.Set("idLessThan", s.idLessThan)
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", endpoint(s.mode, EndpointCrossedInterestHistory, EndpointIsolatedInterestHistory), params, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	var response InterestHistoryResponse
+	if err := json.Unmarshal(res.Data, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Iter iterates over all matching records, newest first, following MinId across pages.
+func (s *InterestHistoryService) Iter(ctx context.Context) *common.Iter[InterestRecord] {
+	return common.NewIter(ctx, func(ctx context.Context, cursor string) (common.Page[InterestRecord], error) {
+		page := *s
+		if cursor != "" {
+			page.idLessThan = cursor
+		}
+		res, err := page.Do(ctx)
+		if err != nil || res == nil {
+			return common.Page[InterestRecord]{}, err
+		}
+		return common.Page[InterestRecord]{Items: common.Values(res.ResultList), Cursor: res.MinId}, nil
+	})
+}
@@ -0,0 +1,44 @@
+package spotmargin
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestInterestHistoryService_Iter(t *testing.T) {
+	client := &MockClient{}
+	first := url.Values{"symbol": {"BTCUSDT"}, "startTime": {"1700000000000"}, "limit": {"2"}}
+	second := url.Values{"symbol": {"BTCUSDT"}, "startTime": {"1700000000000"}, "limit": {"2"}, "idLessThan": {"2"}}
+	client.On("CallAPI", mock.Anything, "GET", EndpointIsolatedInterestHistory, first, []byte(nil), true).
+		Return(okResponse(`{"resultList":[{"interestId":"3","loanCoin":"USDT","interestAmount":"0.01","interstType":"first","cTime":"1700000300000"},{"interestId":"2"}],"maxId":"3","minId":"2"}`), &fasthttp.ResponseHeader{}, nil)
+	client.On("CallAPI", mock.Anything, "GET", EndpointIsolatedInterestHistory, second, []byte(nil), true).
+		Return(okResponse(`{"resultList":[{"interestId":"1"}],"maxId":"1","minId":"1"}`), &fasthttp.ResponseHeader{}, nil)
+	client.On("CallAPI", mock.Anything, "GET", EndpointIsolatedInterestHistory, mock.Anything, []byte(nil), true).
+		Return(okResponse(`{"resultList":[],"maxId":"","minId":""}`), &fasthttp.ResponseHeader{}, nil)
+
+	it := NewInterestHistoryService(client).MarginMode(Isolated).Symbol("BTCUSDT").StartTime("1700000000000").Limit("2").Iter(context.Background())
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Item().InterestId)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"3", "2", "1"}, ids)
+
+	res, err := NewInterestHistoryService(client).MarginMode(Isolated).Symbol("BTCUSDT").StartTime("1700000000000").Limit("2").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "first", res.ResultList[0].InterestType)
+	assert.Equal(t, int64(1700000300000), res.ResultList[0].Time().UnixMilli())
+}
+
+func TestInterestHistoryService_Validation(t *testing.T) {
+	_, err := NewInterestHistoryService(nil).Do(context.Background())
+	assert.EqualError(t, err, "startTime is required")
+	_, err = NewInterestHistoryService(nil).MarginMode(Isolated).StartTime("1").Do(context.Background())
+	assert.EqualError(t, err, "symbol is required for isolated margin")
+}
@@ -0,0 +1,136 @@
+package spotmargin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// LiquidationHistoryService retrieves the liquidations of the margin account.
+type LiquidationHistoryService struct {
+	c ClientInterface
+
+	mode       MarginMode
+	symbol     string
+	startTime  string
+	endTime    string
+	limit      string
+	idLessThan string
+}
+
+// MarginMode selects the cross (default) or isolated margin account.
+func (s *LiquidationHistoryService) MarginMode(mode MarginMode) *LiquidationHistoryService {
+	s.mode = mode
+	return s
+}
+
+// Symbol filters isolated liquidations by pair, e.g. "BTCUSDT".
+func (s *LiquidationHistoryService) Symbol(symbol string) *LiquidationHistoryService {
+	s.symbol = symbol
+	return s
+}
+
+// StartTime sets the start of the query range in Unix milliseconds (required).
+func (s *LiquidationHistoryService) StartTime(startTime string) *LiquidationHistoryService {
+	s.startTime = startTime
+	return s
+}
+
+// EndTime sets the end of the query range in Unix milliseconds.
+func (s *LiquidationHistoryService) EndTime(endTime string) *LiquidationHistoryService {
+	s.endTime = endTime
+	return s
+}
+
+// Limit sets the page size, at most 100.
+func (s *LiquidationHistoryService) Limit(limit string) *LiquidationHistoryService {
+	s.limit = limit
+	return s
+}
+
+// IdLessThan requests the page of records older than the given MinId.
+func (s *LiquidationHistoryService) IdLessThan(idLessThan string) *LiquidationHistoryService {
+	s.idLessThan = idLessThan
+	return s
+}
+
+// LiquidationRecord is one liquidation of the margin account.
+type LiquidationRecord struct {
+	LiqId        string `json:"liqId"`
+	Symbol       string `json:"symbol"` // Isolated margin only
+	LiqStartTime string `json:"liqStartTime"`
+	LiqEndTime   string `json:"liqEndTime"`
+	LiqRiskRatio string `json:"liqRiskRatio"`
+	TotalAssets  string `json:"totalAssets"`
+	TotalDebt    string `json:"totalDebt"`
+	LiqFee       string `json:"liqFee"`
+	CTime        string `json:"cTime"`
+	UTime        string `json:"uTime"`
+}
+
+// Time returns the time the liquidation started.
+func (r *LiquidationRecord) Time() time.Time {
+	ms, _ := strconv.ParseInt(r.LiqStartTime, 10, 64)
+	return time.UnixMilli(ms)
+}
+
+// LiquidationHistoryResponse is one page of liquidation records.
+type LiquidationHistoryResponse struct {
+	ResultList []*LiquidationRecord `json:"resultList"`
+	MaxId      string               `json:"maxId"`
+	MinId      string               `json:"minId"`
+}
+
+// Do sends the request.
+func (s *LiquidationHistoryService) Do(ctx context.Context) (*LiquidationHistoryResponse, error) {
+	if err := checkAccount(s.mode, s.symbol, false); err != nil {
+		return nil, err
+	}
+	if s.startTime == "" {
+		return nil, fmt.Errorf("startTime is required")
+	}
+	params := url.Values{}
+	params.Set("startTime", s.startTime)
+	if s.mode == Isolated && s.symbol != "" {
+		params.Set("symbol", s.symbol)
+	}
+	if s.endTime != "" {
+		params.Set("endTime", s.endTime)
+	}
+	if s.limit != "" {
+		params.Set("limit", s.limit)
+	}
+	if s.idLessThan != "" {
+		params.Set("idLessThan", s.idLessThan)
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", endpoint(s.mode, EndpointCrossedLiquidationHistory, EndpointIsolatedLiquidationHistory), params, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	var response LiquidationHistoryResponse
+	if err := json.Unmarshal(res.Data, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Iter iterates over all matching records, newest first, following MinId across pages.
+func (s *LiquidationHistoryService) Iter(ctx context.Context) *common.Iter[LiquidationRecord] {
+	return common.NewIter(ctx, func(ctx context.Context, cursor string) (common.Page[LiquidationRecord], error) {
+		page := *s
+		if cursor != "" {
+			page.idLessThan = cursor
+		}
+		res, err := page.Do(ctx)
+		if err != nil || res == nil {
+			return common.Page[LiquidationRecord]{}, err
+		}
+		return common.Page[LiquidationRecord]{Items: common.Values(res.ResultList), Cursor: res.MinId}, nil
+	})
+}
@@ -0,0 +1,29 @@
+package spotmargin
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestLiquidationHistoryService_Do(t *testing.T) {
+	client := &MockClient{}
+	query := url.Values{"startTime": {"1700000000000"}, "endTime": {"1700086400000"}}
+	client.On("CallAPI", mock.Anything, "GET", EndpointCrossedLiquidationHistory, query, []byte(nil), true).
+		Return(okResponse(`{"resultList":[{"liqId":"9","liqStartTime":"1700000100000","liqRiskRatio":"1.01","totalAssets":"100","totalDebt":"99","liqFee":"1"}],"maxId":"9","minId":"9"}`), &fasthttp.ResponseHeader{}, nil)
+
+	res, err := NewLiquidationHistoryService(client).StartTime("1700000000000").EndTime("1700086400000").Do(context.Background())
+	require.NoError(t, err)
+	require.Len(t, res.ResultList, 1)
+	assert.Equal(t, "1.01", res.ResultList[0].LiqRiskRatio)
+	assert.Equal(t, int64(1700000100000), res.ResultList[0].Time().UnixMilli())
+	client.AssertExpectations(t)
+
+	_, err = NewLiquidationHistoryService(nil).MarginMode(Isolated).Do(context.Background())
+	assert.EqualError(t, err, "startTime is required", "symbol is optional for isolated liquidations")
+}
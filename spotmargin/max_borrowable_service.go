@@ -0,0 +1,73 @@
+package spotmargin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// MaxBorrowableService retrieves how much of a coin can currently be borrowed.
+type MaxBorrowableService struct {
+	c ClientInterface
+
+	mode   MarginMode
+	symbol string
+	coin   string
+}
+
+// MarginMode selects the cross (default) or isolated margin account.
+func (s *MaxBorrowableService) MarginMode(mode MarginMode) *MaxBorrowableService {
+	s.mode = mode
+	return s
+}
+
+// Symbol sets the isolated margin pair, e.g. "BTCUSDT" (required for Isolated).
+func (s *MaxBorrowableService) Symbol(symbol string) *MaxBorrowableService {
+	s.symbol = symbol
+	return s
+}
+
+// Coin sets the coin, e.g. "USDT" (required for Crossed).
+func (s *MaxBorrowableService) Coin(coin string) *MaxBorrowableService {
+	s.coin = coin
+	return s
+}
+
+// MaxBorrowable is the maximum borrowable amount. The cross account reports Coin and
+// MaxBorrowableAmount; an isolated account reports both coins of Symbol.
+type MaxBorrowable struct {
+	Coin                     string `json:"coin"`
+	MaxBorrowableAmount      string `json:"maxBorrowableAmount"`
+	Symbol                   string `json:"symbol"`
+	BaseCoin                 string `json:"baseCoin"`
+	BaseCoinMaxBorrowAmount  string `json:"baseCoinMaxBorrowAmount"`
+	QuoteCoin                string `json:"quoteCoin"`
+	QuoteCoinMaxBorrowAmount string `json:"quoteCoinMaxBorrowAmount"`
+}
+
+// Do sends the request.
+func (s *MaxBorrowableService) Do(ctx context.Context) (*MaxBorrowable, error) {
+	if err := checkAccount(s.mode, s.symbol, true); err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	if s.mode == Isolated {
+		params.Set("symbol", s.symbol)
+	} else if s.coin == "" {
+		return nil, fmt.Errorf("coin is required")
+	}
+	if s.coin != "" {
+		params.Set("coin", s.coin)
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "GET", endpoint(s.mode, EndpointCrossedMaxBorrowable, EndpointIsolatedMaxBorrowable), params, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	var result MaxBorrowable
+	if err := json.Unmarshal(res.Data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,33 @@
+package spotmargin
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestMaxBorrowableService_Do(t *testing.T) {
+	client := &MockClient{}
+	client.On("CallAPI", mock.Anything, "GET", EndpointCrossedMaxBorrowable, url.Values{"coin": {"USDT"}}, []byte(nil), true).
+		Return(okResponse(`{"coin":"USDT","maxBorrowableAmount":"2500"}`), &fasthttp.ResponseHeader{}, nil)
+	client.On("CallAPI", mock.Anything, "GET", EndpointIsolatedMaxBorrowable, url.Values{"symbol": {"BTCUSDT"}}, []byte(nil), true).
+		Return(okResponse(`{"symbol":"BTCUSDT","baseCoin":"BTC","baseCoinMaxBorrowAmount":"0.5","quoteCoin":"USDT","quoteCoinMaxBorrowAmount":"30000"}`), &fasthttp.ResponseHeader{}, nil)
+
+	res, err := NewMaxBorrowableService(client).Coin("USDT").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "2500", res.MaxBorrowableAmount)
+
+	res, err = NewMaxBorrowableService(client).MarginMode(Isolated).Symbol("BTCUSDT").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "0.5", res.BaseCoinMaxBorrowAmount)
+	assert.Equal(t, "30000", res.QuoteCoinMaxBorrowAmount)
+	client.AssertExpectations(t)
+
+	_, err = NewMaxBorrowableService(nil).Do(context.Background())
+	assert.EqualError(t, err, "coin is required")
+}
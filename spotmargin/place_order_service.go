@@ -0,0 +1,181 @@
+package spotmargin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// PlaceOrderService places a spot order in the cross or an isolated margin account,
+// optionally borrowing what it needs or repaying debt with its proceeds.
+type PlaceOrderService struct {
+	c ClientInterface
+
+	mode      MarginMode
+	symbol    string
+	side      string
+	orderType string
+	loanType  string
+	force     string
+	price     string
+	baseSize  string
+	quoteSize string
+	clientOid string
+	stpMode   string
+}
+
+// MarginMode selects the cross (default) or isolated margin account.
+func (s *PlaceOrderService) MarginMode(mode MarginMode) *PlaceOrderService {
+	s.mode = mode
+	return s
+}
+
+// Symbol sets the trading pair, e.g. "BTCUSDT" (required).
+func (s *PlaceOrderService) Symbol(symbol string) *PlaceOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side sets SideBuy or SideSell (required).
+func (s *PlaceOrderService) Side(side string) *PlaceOrderService {
+	s.side = side
+	return s
+}
+
+// OrderType sets OrderTypeLimit or OrderTypeMarket (required).
+func (s *PlaceOrderService) OrderType(orderType string) *PlaceOrderService {
+	s.orderType = orderType
+	return s
+}
+
+// LoanType sets the borrowing behaviour, e.g. LoanAutoLoan. Defaults to LoanNormal.
+func (s *PlaceOrderService) LoanType(loanType string) *PlaceOrderService {
+	s.loanType = loanType
+	return s
+}
+
+// Force sets the time in force of limit orders. Defaults to ForceGTC.
+func (s *PlaceOrderService) Force(force string) *PlaceOrderService {
+	s.force = force
+	return s
+}
+
+// Price sets the limit price (required for limit orders).
+func (s *PlaceOrderService) Price(price string) *PlaceOrderService {
+	s.price = price
+	return s
+}
+
+// BaseSize sets the order size in the base coin (required except for market buys).
+func (s *PlaceOrderService) BaseSize(baseSize string) *PlaceOrderService {
+	s.baseSize = baseSize
+	return s
+}
+
+// QuoteSize sets the amount of the quote coin to spend (required for market buys).
+func (s *PlaceOrderService) QuoteSize(quoteSize string) *PlaceOrderService {
+	s.quoteSize = quoteSize
+	return s
+}
+
+// ClientOid sets a client-defined order ID.
+func (s *PlaceOrderService) ClientOid(clientOid string) *PlaceOrderService {
+	s.clientOid = clientOid
+	return s
+}
+
+// StpMode sets the self-trade prevention mode, e.g. "cancel_taker".
+func (s *PlaceOrderService) StpMode(stpMode string) *PlaceOrderService {
+	s.stpMode = stpMode
+	return s
+}
+
+// PlaceOrderResponse identifies the placed order.
+type PlaceOrderResponse struct {
+	OrderId   string `json:"orderId"`
+	ClientOid string `json:"clientOid"`
+}
+
+// checkRequiredParams validates required parameters
+func (s *PlaceOrderService) checkRequiredParams() error {
+	if err := checkAccount(s.mode, s.symbol, true); err != nil {
+		return err
+	}
+	if s.symbol == "" {
+		return fmt.Errorf("symbol is required")
+	}
+	if s.side != SideBuy && s.side != SideSell {
+		return fmt.Errorf("side must be buy or sell")
+	}
+	switch s.orderType {
+	case OrderTypeLimit:
+		if s.price == "" {
+			return fmt.Errorf("price is required for limit orders")
+		}
+		if s.baseSize == "" {
+			return fmt.Errorf("baseSize is required for limit orders")
+		}
+	case OrderTypeMarket:
+		if s.side == SideBuy && s.quoteSize == "" {
+			return fmt.Errorf("quoteSize is required for market buys")
+		}
+		if s.side == SideSell && s.baseSize == "" {
+			return fmt.Errorf("baseSize is required for market sells")
+		}
+	default:
+		return fmt.Errorf("orderType must be limit or market")
+	}
+	return nil
+}
+
+// Do sends the order.
+func (s *PlaceOrderService) Do(ctx context.Context) (*PlaceOrderResponse, error) {
+	if err := s.checkRequiredParams(); err != nil {
+		return nil, err
+	}
+
+	loanType := s.loanType
+	if loanType == "" {
+		loanType = LoanNormal
+	}
+	params := map[string]interface{}{
+		"symbol":    s.symbol,
+		"side":      s.side,
+		"orderType": s.orderType,
+		"loanType":  loanType,
+	}
+	if s.orderType == OrderTypeLimit {
+		force := s.force
+		if force == "" {
+			force = ForceGTC
+		}
+		params["force"] = force
+		params["price"] = s.price
+	}
+	if s.baseSize != "" {
+		params["baseSize"] = s.baseSize
+	}
+	if s.quoteSize != "" {
+		params["quoteSize"] = s.quoteSize
+	}
+	if s.clientOid != "" {
+		params["clientOid"] = s.clientOid
+	}
+	if s.stpMode != "" {
+		params["stpMode"] = s.stpMode
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "POST", endpoint(s.mode, EndpointCrossedPlaceOrder, EndpointIsolatedPlaceOrder), nil, body, true)
+	if err != nil {
+		return nil, err
+	}
+	var result PlaceOrderResponse
+	if err := json.Unmarshal(res.Data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,69 @@
+package spotmargin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestPlaceOrderService_Limit(t *testing.T) {
+	client := &MockClient{}
+	client.On("CallAPI", mock.Anything, "POST", EndpointIsolatedPlaceOrder, mock.Anything,
+		bodyEquals(map[string]interface{}{
+			"symbol": "BTCUSDT", "side": "buy", "orderType": "limit", "loanType": "autoLoan",
+			"force": "gtc", "price": "60000", "baseSize": "0.01", "clientOid": "m1",
+		}), true).
+		Return(okResponse(`{"orderId":"123","clientOid":"m1"}`), &fasthttp.ResponseHeader{}, nil)
+
+	res, err := NewPlaceOrderService(client).MarginMode(Isolated).Symbol("BTCUSDT").Side(SideBuy).
+		OrderType(OrderTypeLimit).LoanType(LoanAutoLoan).Price("60000").BaseSize("0.01").ClientOid("m1").
+		Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "123", res.OrderId)
+	client.AssertExpectations(t)
+}
+
+func TestPlaceOrderService_MarketBuy(t *testing.T) {
+	client := &MockClient{}
+	client.On("CallAPI", mock.Anything, "POST", EndpointCrossedPlaceOrder, mock.Anything,
+		bodyEquals(map[string]interface{}{
+			"symbol": "BTCUSDT", "side": "buy", "orderType": "market", "loanType": "normal", "quoteSize": "100",
+		}), true).
+		Return(okResponse(`{"orderId":"124"}`), &fasthttp.ResponseHeader{}, nil)
+
+	res, err := NewPlaceOrderService(client).Symbol("BTCUSDT").Side(SideBuy).OrderType(OrderTypeMarket).
+		QuoteSize("100").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "124", res.OrderId)
+	client.AssertExpectations(t)
+}
+
+func TestPlaceOrderService_Validation(t *testing.T) {
+	base := func() *PlaceOrderService {
+		return NewPlaceOrderService(nil).Symbol("BTCUSDT").Side(SideSell)
+	}
+	ctx := context.Background()
+	_, err := NewPlaceOrderService(nil).Side(SideBuy).OrderType(OrderTypeMarket).Do(ctx)
+	assert.EqualError(t, err, "symbol is required")
+	_, err = base().Side("hold").Do(ctx)
+	assert.EqualError(t, err, "side must be buy or sell")
+	_, err = base().OrderType(OrderTypeLimit).BaseSize("1").Do(ctx)
+	assert.EqualError(t, err, "price is required for limit orders")
+	_, err = base().OrderType(OrderTypeMarket).QuoteSize("100").Do(ctx)
+	assert.EqualError(t, err, "baseSize is required for market sells")
+	_, err = base().Side(SideBuy).OrderType(OrderTypeMarket).BaseSize("1").Do(ctx)
+	assert.EqualError(t, err, "quoteSize is required for market buys")
+	_, err = base().Do(ctx)
+	assert.EqualError(t, err, "orderType must be limit or market")
+}
+
+func TestClone_Independent(t *testing.T) {
+	order := NewPlaceOrderService(nil).Symbol("BTCUSDT")
+	clone := order.Clone().Symbol("ETHUSDT")
+	assert.Equal(t, "BTCUSDT", order.symbol)
+	assert.Equal(t, "ETHUSDT", clone.symbol)
+}
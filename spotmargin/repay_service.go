@@ -0,0 +1,86 @@
+package spotmargin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RepayService repays borrowed coins and their interest in the cross or an isolated
+// margin account.
+type RepayService struct {
+	c ClientInterface
+
+	mode   MarginMode
+	symbol string
+	coin   string
+	amount string
+}
+
+// MarginMode selects the cross (default) or isolated margin account.
+func (s *RepayService) MarginMode(mode MarginMode) *RepayService {
+	s.mode = mode
+	return s
+}
+
+// Symbol sets the isolated margin pair, e.g. "BTCUSDT" (required for Isolated).
+func (s *RepayService) Symbol(symbol string) *RepayService {
+	s.symbol = symbol
+	return s
+}
+
+// Coin sets the coin to repay, e.g. "USDT" (required).
+func (s *RepayService) Coin(coin string) *RepayService {
+	s.coin = coin
+	return s
+}
+
+// Amount sets the amount to repay (required).
+func (s *RepayService) Amount(amount string) *RepayService {
+	s.amount = amount
+	return s
+}
+
+// RepayResponse is the result of a repayment.
+type RepayResponse struct {
+	RepayId          string `json:"repayId"`
+	Symbol           string `json:"symbol"` // Isolated margin only
+	Coin             string `json:"coin"`
+	RepayAmount      string `json:"repayAmount"`
+	RemainDebtAmount string `json:"remainDebtAmount"`
+}
+
+// Do sends the repay request.
+func (s *RepayService) Do(ctx context.Context) (*RepayResponse, error) {
+	if err := checkAccount(s.mode, s.symbol, true); err != nil {
+		return nil, err
+	}
+	if s.coin == "" {
+		return nil, fmt.Errorf("coin is required")
+	}
+	if s.amount == "" {
+		return nil, fmt.Errorf("amount is required")
+	}
+
+	params := map[string]interface{}{
+		"coin":        s.coin,
+		"repayAmount": s.amount,
+	}
+	if s.mode == Isolated {
+		params["symbol"] = s.symbol
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := s.c.CallAPI(ctx, "POST", endpoint(s.mode, EndpointCrossedRepay, EndpointIsolatedRepay), nil, body, true)
+	if err != nil {
+		return nil, err
+	}
+	var result RepayResponse
+	if err := json.Unmarshal(res.Data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
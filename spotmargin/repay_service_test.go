@@ -0,0 +1,33 @@
+package spotmargin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestRepayService_Do(t *testing.T) {
+	client := &MockClient{}
+	client.On("CallAPI", mock.Anything, "POST", EndpointCrossedRepay, mock.Anything,
+		bodyEquals(map[string]interface{}{"coin": "USDT", "repayAmount": "50"}), true).
+		Return(okResponse(`{"repayId":"r1","coin":"USDT","repayAmount":"50","remainDebtAmount":"50.01"}`), &fasthttp.ResponseHeader{}, nil)
+	client.On("CallAPI", mock.Anything, "POST", EndpointIsolatedRepay, mock.Anything,
+		bodyEquals(map[string]interface{}{"symbol": "BTCUSDT", "coin": "USDT", "repayAmount": "10"}), true).
+		Return(okResponse(`{"repayId":"r2","symbol":"BTCUSDT","coin":"USDT","repayAmount":"10","remainDebtAmount":"0"}`), &fasthttp.ResponseHeader{}, nil)
+
+	res, err := NewRepayService(client).Coin("USDT").Amount("50").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "50.01", res.RemainDebtAmount)
+
+	res, err = NewRepayService(client).MarginMode(Isolated).Symbol("BTCUSDT").Coin("USDT").Amount("10").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "r2", res.RepayId)
+	client.AssertExpectations(t)
+
+	_, err = NewRepayService(nil).Coin("USDT").Do(context.Background())
+	assert.EqualError(t, err, "amount is required")
+}
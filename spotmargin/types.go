@@ -0,0 +1,138 @@
+// Package spotmargin provides services for Bitget spot margin trading, in both the cross
+// and the isolated margin account: borrowing and repaying, interest and liquidation
+// records, maximum borrowable amounts, account assets and margin order placement.
+//
+// Services follow the fluent API of the futures packages and accept any
+// ClientInterface; a futures.Client signs margin requests as well:
+//
+//	client := futures.NewClient(apiKey, secretKey, passphrase)
+//	loan, err := spotmargin.NewBorrowService(client).
+//		MarginMode(spotmargin.Isolated).
+//		Symbol("BTCUSDT").
+//		Coin("USDT").
+//		Amount("100").
+//		Do(ctx)
+//
+// Services default to the cross margin account; isolated requests also need Symbol.
+package spotmargin
+
+import (
+	"fmt"
+
+	"github.com/khanbekov/go-bitget/common/client"
+)
+
+// Re-export common types to avoid importing the futures package
+type (
+	ClientInterface = client.ClientInterface
+	ApiResponse     = client.ApiResponse
+)
+
+// MarginMode selects the cross or isolated margin account.
+type MarginMode string
+
+const (
+	Crossed  MarginMode = "crossed"
+	Isolated MarginMode = "isolated"
+)
+
+// Order sides, types and time in force values of PlaceOrderService.
+const (
+	SideBuy  = "buy"
+	SideSell = "sell"
+
+	OrderTypeLimit  = "limit"
+	OrderTypeMarket = "market"
+
+	ForceGTC      = "gtc"
+	ForcePostOnly = "post_only"
+	ForceFOK      = "fok"
+	ForceIOC      = "ioc"
+)
+
+// Loan types of PlaceOrderService: whether the order borrows or repays automatically.
+const (
+	LoanNormal        = "normal"           // Neither borrow nor repay
+	LoanAutoLoan      = "autoLoan"         // Borrow what the order needs
+	LoanAutoRepay     = "autoRepay"        // Repay debt with the proceeds
+	LoanAutoLoanRepay = "autoLoanAndRepay" // Both
+)
+
+// API Endpoints for margin operations
+const (
+	EndpointCrossedBorrow             = "/api/v2/margin/crossed/account/borrow"
+	EndpointCrossedRepay              = "/api/v2/margin/crossed/account/repay"
+	EndpointCrossedInterestHistory    = "/api/v2/margin/crossed/interest-history"
+	EndpointCrossedMaxBorrowable      = "/api/v2/margin/crossed/account/max-borrowable-amount"
+	EndpointCrossedAssets             = "/api/v2/margin/crossed/account/assets"
+	EndpointCrossedLiquidationHistory = "/api/v2/margin/crossed/liquidation-history"
+	EndpointCrossedPlaceOrder         = "/api/v2/margin/crossed/place-order"
+
+	EndpointIsolatedBorrow             = "/api/v2/margin/isolated/account/borrow"
+	EndpointIsolatedRepay              = "/api/v2/margin/isolated/account/repay"
+	EndpointIsolatedInterestHistory    = "/api/v2/margin/isolated/interest-history"
+	EndpointIsolatedMaxBorrowable      = "/api/v2/margin/isolated/account/max-borrowable-amount"
+	EndpointIsolatedAssets             = "/api/v2/margin/isolated/account/assets"
+	EndpointIsolatedLiquidationHistory = "/api/v2/margin/isolated/liquidation-history"
+	EndpointIsolatedPlaceOrder         = "/api/v2/margin/isolated/place-order"
+)
+
+// endpoint returns the isolated endpoint for Isolated and the crossed one otherwise.
+func endpoint(mode MarginMode, crossed, isolated string) string {
+	if mode == Isolated {
+		return isolated
+	}
+	return crossed
+}
+
+// checkAccount validates the margin mode and requires a symbol for isolated requests
+// that are scoped to one isolated account.
+func checkAccount(mode MarginMode, symbol string, symbolRequired bool) error {
+	switch mode {
+	case "", Crossed:
+	case Isolated:
+		if symbolRequired && symbol == "" {
+			return fmt.Errorf("symbol is required for isolated margin")
+		}
+	default:
+		return fmt.Errorf("invalid margin mode %q", mode)
+	}
+	return nil
+}
+
+// Service Constructor Functions
+
+// NewBorrowService creates a new borrow service.
+func NewBorrowService(client ClientInterface) *BorrowService {
+	return &BorrowService{c: client}
+}
+
+// NewRepayService creates a new repay service.
+func NewRepayService(client ClientInterface) *RepayService {
+	return &RepayService{c: client}
+}
+
+// NewInterestHistoryService creates a new interest record service.
+func NewInterestHistoryService(client ClientInterface) *InterestHistoryService {
+	return &InterestHistoryService{c: client}
+}
+
+// NewMaxBorrowableService creates a new maximum borrowable amount service.
+func NewMaxBorrowableService(client ClientInterface) *MaxBorrowableService {
+	return &MaxBorrowableService{c: client}
+}
+
+// NewAccountAssetsService creates a new margin account assets service.
+func NewAccountAssetsService(client ClientInterface) *AccountAssetsService {
+	return &AccountAssetsService{c: client}
+}
+
+// NewLiquidationHistoryService creates a new liquidation record service.
+func NewLiquidationHistoryService(client ClientInterface) *LiquidationHistoryService {
+	return &LiquidationHistoryService{c: client}
+}
+
+// NewPlaceOrderService creates a new margin order placement service.
+func NewPlaceOrderService(client ClientInterface) *PlaceOrderService {
+	return &PlaceOrderService{c: client}
+}
@@ -0,0 +1,134 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// tmpPrefix marks in-progress writes. A bare "%" followed by non-hex characters never
+// appears in an escaped key, so temporary files cannot collide with stored keys.
+const tmpPrefix = "%tmp-"
+
+// FileStore keeps one file per key in a directory per namespace. Writes go to a temporary
+// file that is renamed into place, so a crash never leaves a partially written value.
+type FileStore struct {
+	dir string
+	mu  sync.RWMutex
+}
+
+// NewFileStore creates a store rooted at dir, creating it if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create state directory: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// escape maps arbitrary namespaces and keys to safe file names.
+func escape(name string) string {
+	escaped := url.PathEscape(name)
+	// Hidden files and the "." and ".." entries are avoided by escaping a leading dot
+	if strings.HasPrefix(escaped, ".") {
+		escaped = "%2E" + escaped[1:]
+	}
+	return escaped
+}
+
+func (f *FileStore) nsDir(namespace string) string {
+	return filepath.Join(f.dir, escape(namespace))
+}
+
+func (f *FileStore) path(namespace, key string) string {
+	return filepath.Join(f.nsDir(namespace), escape(key))
+}
+
+// Get implements Store.
+func (f *FileStore) Get(ctx context.Context, namespace, key string) ([]byte, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	data, err := os.ReadFile(f.path(namespace, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Put implements Store.
+func (f *FileStore) Put(ctx context.Context, namespace, key string, value []byte) error {
+	if namespace == "" || key == "" {
+		return fmt.Errorf("namespace and key are required")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := os.MkdirAll(f.nsDir(namespace), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(f.nsDir(namespace), tmpPrefix+"*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(value); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path(namespace, key))
+}
+
+// Delete implements Store.
+func (f *FileStore) Delete(ctx context.Context, namespace, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	err := os.Remove(f.path(namespace, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// List implements Store.
+func (f *FileStore) List(ctx context.Context, namespace string) (map[string][]byte, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	entries, err := os.ReadDir(f.nsDir(namespace))
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string][]byte{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string][]byte, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), tmpPrefix) {
+			continue
+		}
+		key, err := url.PathUnescape(e.Name())
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(f.nsDir(namespace), e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		out[key] = data
+	}
+	return out, nil
+}
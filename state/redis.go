@@ -0,0 +1,251 @@
+package state
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RedisOptions configures a RedisStore.
+type RedisOptions struct {
+	Addr     string // host:port, defaults to "127.0.0.1:6379"
+	Password string // Sent with AUTH when set
+	DB       int    // Selected with SELECT when non-zero
+
+	// Prefix is prepended to namespaces to form hash keys. Defaults to "bitget:state:".
+	Prefix string
+
+	// Timeout bounds dialing and each command. Defaults to 5 seconds.
+	Timeout time.Duration
+}
+
+// RedisStore stores each namespace as a Redis hash. It speaks the RESP protocol directly
+// over a single connection, so no Redis client dependency is required; the connection is
+// re-established transparently after network errors.
+type RedisStore struct {
+	opts RedisOptions
+
+	mu   sync.Mutex
+	conn net.Conn
+	rd   *bufio.Reader
+}
+
+// redisError is an error reply from the server.
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+// NewRedisStore creates a store and verifies connectivity.
+func NewRedisStore(ctx context.Context, opts RedisOptions) (*RedisStore, error) {
+	if opts.Addr == "" {
+		opts.Addr = "127.0.0.1:6379"
+	}
+	if opts.Prefix == "" {
+		opts.Prefix = "bitget:state:"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	s := &RedisStore{opts: opts}
+	if _, err := s.do(ctx, "PING"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Get implements Store.
+func (s *RedisStore) Get(ctx context.Context, namespace, key string) ([]byte, error) {
+	reply, err := s.do(ctx, "HGET", s.opts.Prefix+namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, ErrNotFound
+	}
+	b, ok := reply.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("redis: unexpected HGET reply %T", reply)
+	}
+	return b, nil
+}
+
+// Put implements Store.
+func (s *RedisStore) Put(ctx context.Context, namespace, key string, value []byte) error {
+	_, err := s.do(ctx, "HSET", s.opts.Prefix+namespace, key, string(value))
+	return err
+}
+
+// Delete implements Store.
+func (s *RedisStore) Delete(ctx context.Context, namespace, key string) error {
+	_, err := s.do(ctx, "HDEL", s.opts.Prefix+namespace, key)
+	return err
+}
+
+// List implements Store.
+func (s *RedisStore) List(ctx context.Context, namespace string) (map[string][]byte, error) {
+	reply, err := s.do(ctx, "HGETALL", s.opts.Prefix+namespace)
+	if err != nil {
+		return nil, err
+	}
+	items, ok := reply.([]interface{})
+	if !ok || len(items)%2 != 0 {
+		return nil, fmt.Errorf("redis: unexpected HGETALL reply")
+	}
+	out := make(map[string][]byte, len(items)/2)
+	for i := 0; i < len(items); i += 2 {
+		k, _ := items[i].([]byte)
+		v, _ := items[i+1].([]byte)
+		out[string(k)] = v
+	}
+	return out, nil
+}
+
+// Publish sends data to a pub/sub channel. The channel is used as is, without Prefix. It
+// lets the store's connection double as a marketdata.Publisher.
+func (s *RedisStore) Publish(ctx context.Context, channel string, data []byte) error {
+	_, err := s.do(ctx, "PUBLISH", channel, string(data))
+	return err
+}
+
+// Close closes the connection.
+func (s *RedisStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// do sends a command and reads its reply, reconnecting once on a network error.
+func (s *RedisStore) do(ctx context.Context, args ...string) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var lastErr error
+	for attempt := 0; attempt < 2; attempt++ {
+		if s.conn == nil {
+			if err := s.connect(ctx); err != nil {
+				return nil, err
+			}
+		}
+		reply, err := s.roundTrip(ctx, args)
+		var rerr redisError
+		if err == nil || errors.As(err, &rerr) {
+			return reply, err
+		}
+		// Network error: drop the connection and retry once on a fresh one
+		s.conn.Close()
+		s.conn = nil
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func (s *RedisStore) connect(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: s.opts.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.opts.Addr)
+	if err != nil {
+		return fmt.Errorf("redis: dial %s: %w", s.opts.Addr, err)
+	}
+	s.conn = conn
+	s.rd = bufio.NewReader(conn)
+
+	if s.opts.Password != "" {
+		if _, err := s.roundTrip(ctx, []string{"AUTH", s.opts.Password}); err != nil {
+			s.conn.Close()
+			s.conn = nil
+			return err
+		}
+	}
+	if s.opts.DB != 0 {
+		if _, err := s.roundTrip(ctx, []string{"SELECT", strconv.Itoa(s.opts.DB)}); err != nil {
+			s.conn.Close()
+			s.conn = nil
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *RedisStore) roundTrip(ctx context.Context, args []string) (interface{}, error) {
+	deadline := time.Now().Add(s.opts.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := s.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	buf := []byte("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, a := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(a)), 10)
+		buf = append(buf, "\r\n"...)
+		buf = append(buf, a...)
+		buf = append(buf, "\r\n"...)
+	}
+	if _, err := s.conn.Write(buf); err != nil {
+		return nil, err
+	}
+	return readReply(s.rd)
+}
+
+// readReply parses one RESP2 reply. Bulk strings are returned as []byte, nil bulk
+// strings and arrays as nil.
+func readReply(rd *bufio.Reader) (interface{}, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+	body := line[1 : len(line)-2]
+
+	switch line[0] {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, redisError(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(rd, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			if items[i], err = readReply(rd); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("redis: unknown reply type %q", line[0])
+}
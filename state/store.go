@@ -0,0 +1,118 @@
+// Package state provides a small persistent key/value interface used by stateful SDK
+// components (order trackers, trailing stops, OCO groups) to recover after a restart.
+//
+// Values are opaque bytes grouped in namespaces; GetJSON and PutJSON cover the common case
+// of storing structs. Implementations are provided for process memory, the local file
+// system and Redis.
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrNotFound is returned by Get when the key does not exist.
+var ErrNotFound = errors.New("state: key not found")
+
+// Store persists values by namespace and key. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Get returns the value for key in namespace, or ErrNotFound.
+	Get(ctx context.Context, namespace, key string) ([]byte, error)
+	// Put stores value under key in namespace, replacing any previous value.
+	Put(ctx context.Context, namespace, key string, value []byte) error
+	// Delete removes key from namespace. Deleting a missing key is not an error.
+	Delete(ctx context.Context, namespace, key string) error
+	// List returns all values in namespace keyed by key.
+	List(ctx context.Context, namespace string) (map[string][]byte, error)
+}
+
+// GetJSON loads and decodes a JSON value.
+func GetJSON(ctx context.Context, s Store, namespace, key string, v interface{}) error {
+	data, err := s.Get(ctx, namespace, key)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// PutJSON encodes and stores a JSON value.
+func PutJSON(ctx context.Context, s Store, namespace, key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.Put(ctx, namespace, key, data)
+}
+
+// Keys returns the sorted keys of a namespace.
+func Keys(ctx context.Context, s Store, namespace string) ([]string, error) {
+	values, err := s.List(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// MemoryStore keeps values in process memory. Useful for tests and as a default when
+// persistence is not required.
+type MemoryStore struct {
+	mu   sync.RWMutex
+	data map[string]map[string][]byte
+}
+
+// NewMemoryStore creates an empty memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{data: make(map[string]map[string][]byte)}
+}
+
+// Get implements Store.
+func (m *MemoryStore) Get(ctx context.Context, namespace, key string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.data[namespace][key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), v...), nil
+}
+
+// Put implements Store.
+func (m *MemoryStore) Put(ctx context.Context, namespace, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ns, ok := m.data[namespace]
+	if !ok {
+		ns = make(map[string][]byte)
+		m.data[namespace] = ns
+	}
+	ns[key] = append([]byte(nil), value...)
+	return nil
+}
+
+// Delete implements Store.
+func (m *MemoryStore) Delete(ctx context.Context, namespace, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.data[namespace], key)
+	return nil
+}
+
+// List implements Store.
+func (m *MemoryStore) List(ctx context.Context, namespace string) (map[string][]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make(map[string][]byte, len(m.data[namespace]))
+	for k, v := range m.data[namespace] {
+		out[k] = append([]byte(nil), v...)
+	}
+	return out, nil
+}
@@ -0,0 +1,204 @@
+package state
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type order struct {
+	ID    string  `json:"id"`
+	Price float64 `json:"price"`
+}
+
+// exerciseStore runs the behaviour every Store implementation must share.
+func exerciseStore(t *testing.T, s Store) {
+	ctx := context.Background()
+
+	_, err := s.Get(ctx, "orders", "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, PutJSON(ctx, s, "orders", "a/1", order{ID: "a/1", Price: 100}))
+	require.NoError(t, PutJSON(ctx, s, "orders", ".hidden", order{ID: ".hidden", Price: 1}))
+	require.NoError(t, s.Put(ctx, "stops", "b", []byte("raw")))
+
+	var got order
+	require.NoError(t, GetJSON(ctx, s, "orders", "a/1", &got))
+	assert.Equal(t, order{ID: "a/1", Price: 100}, got)
+
+	// Overwrite
+	require.NoError(t, PutJSON(ctx, s, "orders", "a/1", order{ID: "a/1", Price: 101}))
+	require.NoError(t, GetJSON(ctx, s, "orders", "a/1", &got))
+	assert.Equal(t, 101.0, got.Price)
+
+	keys, err := Keys(ctx, s, "orders")
+	require.NoError(t, err)
+	assert.Equal(t, []string{".hidden", "a/1"}, keys)
+
+	require.NoError(t, s.Delete(ctx, "orders", "a/1"))
+	require.NoError(t, s.Delete(ctx, "orders", "a/1"), "deleting twice is fine")
+	values, err := s.List(ctx, "orders")
+	require.NoError(t, err)
+	assert.Len(t, values, 1)
+
+	values, err = s.List(ctx, "empty")
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	raw, err := s.Get(ctx, "stops", "b")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("raw"), raw)
+}
+
+func TestMemoryStore(t *testing.T) {
+	exerciseStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileStore(dir)
+	require.NoError(t, err)
+	exerciseStore(t, s)
+
+	// Values survive a new store instance on the same directory
+	reopened, err := NewFileStore(dir)
+	require.NoError(t, err)
+	raw, err := reopened.Get(context.Background(), "stops", "b")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("raw"), raw)
+}
+
+func TestRedisStore(t *testing.T) {
+	addr := startFakeRedis(t, "secret")
+
+	_, err := NewRedisStore(context.Background(), RedisOptions{Addr: addr, Password: "wrong"})
+	assert.ErrorContains(t, err, "WRONGPASS")
+
+	s, err := NewRedisStore(context.Background(), RedisOptions{Addr: addr, Password: "secret"})
+	require.NoError(t, err)
+	defer s.Close()
+	exerciseStore(t, s)
+
+	// The store reconnects after the connection drops
+	s.conn.Close()
+	raw, err := s.Get(context.Background(), "stops", "b")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("raw"), raw)
+
+	require.NoError(t, s.Publish(context.Background(), "bitget.ticker", []byte("payload")))
+	reply, err := s.do(context.Background(), "HGET", "published", "bitget.ticker")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("payload"), reply)
+}
+
+// startFakeRedis serves the handful of hash and pub/sub commands RedisStore uses.
+func startFakeRedis(t *testing.T, password string) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	var mu sync.Mutex
+	hashes := map[string]map[string]string{}
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				rd := bufio.NewReader(conn)
+				for {
+					req, err := readReply(rd)
+					if err != nil {
+						return
+					}
+					items := req.([]interface{})
+					args := make([]string, len(items))
+					for i, it := range items {
+						args[i] = string(it.([]byte))
+					}
+
+					mu.Lock()
+					var resp string
+					switch strings.ToUpper(args[0]) {
+					case "PING":
+						resp = "+PONG\r\n"
+					case "AUTH":
+						if args[1] == password {
+							resp = "+OK\r\n"
+						} else {
+							resp = "-WRONGPASS invalid password\r\n"
+						}
+					case "HSET":
+						if hashes[args[1]] == nil {
+							hashes[args[1]] = map[string]string{}
+						}
+						hashes[args[1]][args[2]] = args[3]
+						resp = ":1\r\n"
+					case "HGET":
+						if v, ok := hashes[args[1]][args[2]]; ok {
+							resp = fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+						} else {
+							resp = "$-1\r\n"
+						}
+					case "HDEL":
+						delete(hashes[args[1]], args[2])
+						resp = ":1\r\n"
+					case "PUBLISH":
+						// Recorded as a hash so tests can read it back
+						if hashes["published"] == nil {
+							hashes["published"] = map[string]string{}
+						}
+						hashes["published"][args[1]] = args[2]
+						resp = ":0\r\n"
+					case "HGETALL":
+						h := hashes[args[1]]
+						resp = fmt.Sprintf("*%d\r\n", len(h)*2)
+						for k, v := range h {
+							resp += fmt.Sprintf("$%d\r\n%s\r\n$%d\r\n%s\r\n", len(k), k, len(v), v)
+						}
+					default:
+						resp = "-ERR unknown command\r\n"
+					}
+					mu.Unlock()
+
+					if _, err := conn.Write([]byte(resp)); err != nil {
+						return
+					}
+				}
+			}(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestTenantStore(t *testing.T) {
+	shared := NewMemoryStore()
+	exerciseStore(t, ForTenant(shared, "alice"))
+	assert.Same(t, shared, ForTenant(shared, ""), "no tenant")
+
+	ctx := context.Background()
+	alice, bob := ForTenant(shared, "alice"), ForTenant(shared, "bob")
+	require.NoError(t, bob.Put(ctx, "stops", "b", []byte("bob")))
+	raw, err := alice.Get(ctx, "stops", "b")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("raw"), raw, "tenants do not see each other's keys")
+
+	values, err := shared.List(ctx, "alice/stops")
+	require.NoError(t, err)
+	assert.Len(t, values, 1)
+	_, err = shared.Get(ctx, "stops", "b")
+	assert.ErrorIs(t, err, ErrNotFound, "nothing is written outside the tenant namespaces")
+
+	assert.Equal(t, "orders", TenantNamespace("", "orders"))
+	assert.NotEqual(t, TenantNamespace("a/b", "c"), TenantNamespace("a", "b/c"))
+}
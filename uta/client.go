@@ -24,11 +24,18 @@ type Client struct {
 	Passphrase  string
 	BaseURL     string
 	HTTPClient  *fasthttp.Client
-	Logger      zerolog.Logger
+	Logger      common.Logger
 	json        jsoniter.API
-	DemoTrading bool // Enable demo trading mode
+	DemoTrading bool          // Enable demo trading mode
+	Locale      common.Locale // Language of API error messages, defaults to English
+	UserAgent   string        // User-Agent header, defaults to DefaultUserAgent
+	ChannelCode string        // Broker or channel code for fee rebates
+	ReadOnly    bool          // Reject mutating requests, see SetReadOnly
 }
 
+// DefaultUserAgent is the User-Agent header sent by a new client.
+const DefaultUserAgent = "go-bitget-uta/1.0"
+
 // NewClient creates a new UTA API client
 func NewClient(apiKey, secretKey, passphrase string) *Client {
 	return &Client{
@@ -37,22 +44,25 @@ func NewClient(apiKey, secretKey, passphrase string) *Client {
 		Passphrase: passphrase,
 		BaseURL:    BaseURL,
 		HTTPClient: &fasthttp.Client{},
-		Logger:     zerolog.Nop(),
+		Logger:     common.NopLogger(),
+		UserAgent:  DefaultUserAgent,
 		json:       jsoniter.ConfigCompatibleWithStandardLibrary,
 	}
 }
 
 // NewClientWithLogger creates a new UTA API client with custom logger
 func NewClientWithLogger(apiKey, secretKey, passphrase string, logger zerolog.Logger) *Client {
-	return &Client{
-		APIKey:     apiKey,
-		SecretKey:  secretKey,
-		Passphrase: passphrase,
-		BaseURL:    BaseURL,
-		HTTPClient: &fasthttp.Client{},
-		Logger:     logger,
-		json:       jsoniter.ConfigCompatibleWithStandardLibrary,
+	return NewClient(apiKey, secretKey, passphrase).SetLogger(common.NewZerologLogger(logger))
+}
+
+// SetLogger sets the client logger; use the common adapters for zerolog, slog or zap.
+// Passing nil disables logging.
+func (c *Client) SetLogger(logger common.Logger) *Client {
+	if logger == nil {
+		logger = common.NopLogger()
 	}
+	c.Logger = logger
+	return c
 }
 
 // SetBaseURL sets a custom base URL for the client
@@ -73,8 +83,48 @@ func (c *Client) SetDemoTrading(demoTrading bool) *Client {
 	return c
 }
 
+// SetLocale requests API error messages in the given language, e.g.
+// common.LocaleChinese. APIError.Locale reports it back.
+func (c *Client) SetLocale(locale common.Locale) *Client {
+	c.Locale = locale
+	return c
+}
+
+// SetUserAgentSuffix appends suffix, e.g. "mybot/2.1", to the default User-Agent.
+func (c *Client) SetUserAgentSuffix(suffix string) *Client {
+	c.UserAgent = common.UserAgent(DefaultUserAgent, suffix)
+	return c
+}
+
+// SetChannelCode sends the broker or channel code with every request, as required
+// for partner fee rebates.
+func (c *Client) SetChannelCode(code string) *Client {
+	c.ChannelCode = code
+	return c
+}
+
+// SetEnvironment selects production or demo trading, setting the base URL and demo
+// trading mode together.
+func (c *Client) SetEnvironment(env common.Environment) *Client {
+	c.BaseURL = env.RESTURL()
+	c.DemoTrading = env.IsDemo()
+	return c
+}
+
+// SetReadOnly makes the client reject every mutating request, i.e. anything but GET,
+// with a *common.ReadOnlyError before it is signed or sent, so a reporting deployment
+// cannot trade or move funds even with over-permissioned keys.
+func (c *Client) SetReadOnly(readOnly bool) *Client {
+	c.ReadOnly = readOnly
+	return c
+}
+
 // CallAPI makes an API call to the UTA API
 func (c *Client) CallAPI(ctx context.Context, method string, endpoint string, queryParams url.Values, body []byte, sign bool) (*ApiResponse, *fasthttp.ResponseHeader, error) {
+	if err := common.CheckReadOnly(c.ReadOnly, method, endpoint); err != nil {
+		return nil, nil, err
+	}
+
 	// Build URL
 	fullURL := c.BaseURL + endpoint
 	if queryParams != nil && len(queryParams) > 0 {
@@ -91,7 +141,11 @@ func (c *Client) CallAPI(ctx context.Context, method string, endpoint string, qu
 	req.SetRequestURI(fullURL)
 	req.Header.SetMethod(method)
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "go-bitget-uta/1.0")
+	req.Header.SetUserAgent(c.UserAgent)
+	if c.ChannelCode != "" {
+		req.Header.Set(common.ChannelCodeHeader, c.ChannelCode)
+	}
+	req.Header.Set(common.LocaleHeader, string(c.Locale.OrDefault()))
 
 	if body != nil {
 		req.SetBody(body)
@@ -126,59 +180,59 @@ func (c *Client) CallAPI(ctx context.Context, method string, endpoint string, qu
 
 	// Add demo trading header if enabled
 	if c.DemoTrading {
-		req.Header.Set("paptrading", "1")
+		req.Header.Set(common.DemoTradingHeader, "1")
 	}
 
-	c.Logger.Debug().
-		Str("method", method).
-		Str("url", fullURL).
-		Str("body", string(body)).
-		Bool("signed", sign).
-		Msg("Making UTA API request")
+	logDebug := common.DebugEnabled(c.Logger)
+	if logDebug {
+		c.Logger.Debug("Making UTA API request",
+			"method", method,
+			"endpoint", endpoint,
+			"query", common.RedactQuery(queryParams),
+			"body", common.RedactBody(body),
+			"signed", sign)
+	}
 
 	// Make request with context
 	err := c.HTTPClient.DoTimeout(req, resp, 30*time.Second)
 	if err != nil {
-		c.Logger.Error().Err(err).Msg("HTTP request failed")
+		c.Logger.Error("HTTP request failed", "error", err)
 		return nil, nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
 
 	// Check status code
 	statusCode := resp.StatusCode()
 	if statusCode != fasthttp.StatusOK {
-		c.Logger.Error().
-			Int("status_code", statusCode).
-			Str("response", string(resp.Body())).
-			Msg("API request failed with non-200 status")
-		return nil, nil, fmt.Errorf("API request failed with status %d: %s", statusCode, string(resp.Body()))
+		c.Logger.Error("API request failed with non-200 status",
+			"status_code", statusCode,
+			"response", common.RedactBody(resp.Body()))
+		return nil, nil, fmt.Errorf("API request failed with status %d: %s", statusCode, common.RedactBody(resp.Body()))
 	}
 
 	// Parse response
 	var apiResp ApiResponse
 	if err := c.json.Unmarshal(resp.Body(), &apiResp); err != nil {
-		c.Logger.Error().
-			Err(err).
-			Str("response_body", string(resp.Body())).
-			Msg("Failed to unmarshal API response")
+		c.Logger.Error("Failed to unmarshal API response",
+			"error", err,
+			"response_body", common.RedactBody(resp.Body()))
 		return nil, nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	c.Logger.Debug().
-		Str("code", apiResp.Code).
-		Str("msg", apiResp.Msg).
-		Int64("request_time", apiResp.RequestTime).
-		Msg("Received UTA API response")
+	if logDebug {
+		c.Logger.Debug("Received UTA API response",
+			"code", apiResp.Code,
+			"msg", apiResp.Msg,
+			"request_time", apiResp.RequestTime)
+	}
 
 	// Check for API errors
 	if apiResp.Code != "00000" {
 		apiError := &common.APIError{
 			Code:    apiResp.Code,
 			Message: apiResp.Msg,
+			Locale:  string(c.Locale.OrDefault()),
 		}
-		c.Logger.Error().
-			Str("error_code", apiError.Code).
-			Str("error_message", apiError.Message).
-			Msg("API returned error")
+		c.Logger.Error("API returned error", "error_code", apiError.Code, "error_message", apiError.Message)
 		return &apiResp, &resp.Header, apiError
 	}
 
@@ -418,6 +472,10 @@ func (c *Client) NewGetPositionHistoryService() *GetPositionHistoryService {
 	return &GetPositionHistoryService{c: c}
 }
 
+func (c *Client) NewPositionPnLService() *PositionPnLService {
+	return &PositionPnLService{c: c}
+}
+
 func (c *Client) NewGetMaxOpenAvailableService() *GetMaxOpenAvailableService {
 	return &GetMaxOpenAvailableService{c: c}
 }
@@ -488,5 +546,10 @@ func (c *Client) NewGetRecentPublicFillsService() *GetRecentPublicFillsService {
 	return &GetRecentPublicFillsService{c: c}
 }
 
+// Public services
+func (c *Client) NewGetServerTimeService() *GetServerTimeService {
+	return &GetServerTimeService{c: c}
+}
+
 // Ensure Client implements ClientInterface
 var _ ClientInterface = (*Client)(nil)
@@ -81,6 +81,7 @@ type ClientInterface interface {
 	NewGetFillHistoryService() *GetFillHistoryService
 	NewGetCurrentPositionsService() *GetCurrentPositionsService
 	NewGetPositionHistoryService() *GetPositionHistoryService
+	NewPositionPnLService() *PositionPnLService
 	NewGetMaxOpenAvailableService() *GetMaxOpenAvailableService
 	NewGetLoanOrdersService() *GetLoanOrdersService
 
@@ -102,4 +103,7 @@ type ClientInterface interface {
 	NewGetRiskReserveService() *GetRiskReserveService
 	NewGetPositionTierService() *GetPositionTierService
 	NewGetRecentPublicFillsService() *GetRecentPublicFillsService
+
+	// Public services
+	NewGetServerTimeService() *GetServerTimeService
 }
@@ -2,11 +2,15 @@ package uta
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 
+	"github.com/khanbekov/go-bitget/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
 )
 
@@ -211,6 +215,12 @@ func (m *MockClient) NewGetPositionTierService() *GetPositionTierService {
 func (m *MockClient) NewGetRecentPublicFillsService() *GetRecentPublicFillsService {
 	return &GetRecentPublicFillsService{c: m}
 }
+func (m *MockClient) NewGetServerTimeService() *GetServerTimeService {
+	return &GetServerTimeService{c: m}
+}
+func (m *MockClient) NewPositionPnLService() *PositionPnLService {
+	return &PositionPnLService{c: m}
+}
 
 // Ensure MockClient implements ClientInterface
 var _ ClientInterface = (*MockClient)(nil)
@@ -261,3 +271,82 @@ func TestClient_CreateSignature(t *testing.T) {
 	// Should be base64 encoded
 	assert.Regexp(t, `^[A-Za-z0-9+/]+=*$`, signature)
 }
+
+func TestClient_Locale(t *testing.T) {
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get(common.LocaleHeader)
+		w.Write([]byte(`{"code":"40762","msg":"订单数量超过余额","requestTime":1700000000000,"data":null}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test", "test", "test").SetBaseURL(server.URL)
+	_, _, err := client.CallAPI(context.Background(), "GET", "/api/v3/account/assets", nil, nil, true)
+	require.Error(t, err)
+	assert.Equal(t, "en-US", header, "English by default")
+
+	client.SetLocale(common.LocaleChinese)
+	_, _, err = client.CallAPI(context.Background(), "GET", "/api/v3/account/assets", nil, nil, true)
+	var apiErr *common.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "zh-CN", header)
+	assert.Equal(t, "zh-CN", apiErr.Locale)
+	assert.Equal(t, "订单数量超过余额", apiErr.Message)
+}
+
+func TestClient_UserAgentAndChannelCode(t *testing.T) {
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		w.Write([]byte(`{"code":"00000","msg":"success","requestTime":1700000000000,"data":null}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test", "test", "test").SetBaseURL(server.URL)
+	_, _, err := client.CallAPI(context.Background(), "GET", "/api/v3/market/tickers", nil, nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultUserAgent, headers.Get("User-Agent"))
+	assert.Empty(t, headers.Get(common.ChannelCodeHeader))
+
+	client.SetUserAgentSuffix("mybot/2.1").SetChannelCode("partner42")
+	_, _, err = client.CallAPI(context.Background(), "GET", "/api/v3/market/tickers", nil, nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, "go-bitget-uta/1.0 mybot/2.1", headers.Get("User-Agent"))
+	assert.Equal(t, "partner42", headers.Get(common.ChannelCodeHeader))
+}
+
+func TestClient_ReadOnly(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"code":"00000","msg":"success","requestTime":1700000000000,"data":null}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test", "test", "test").SetBaseURL(server.URL).SetReadOnly(true)
+	_, _, err := client.CallAPI(context.Background(), "GET", "/api/v3/market/tickers", nil, nil, false)
+	require.NoError(t, err)
+
+	_, err = client.NewPlaceOrderService().Symbol("BTCUSDT").Category(CategoryUSDTFutures).Side(SideBuy).
+		OrderType(OrderTypeMarket).Size("0.01").Do(context.Background())
+	assert.ErrorIs(t, err, common.ErrReadOnly)
+	var roErr *common.ReadOnlyError
+	require.ErrorAs(t, err, &roErr)
+	assert.Equal(t, EndpointTradePlaceOrder, roErr.Endpoint)
+	assert.Equal(t, 1, requests, "the order never reaches the exchange")
+}
+
+func TestClient_ErrorBodyRedacted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`{"passphrase":"hunter2","msg":"bad gateway"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test", "test", "test").SetBaseURL(server.URL)
+	_, _, err := client.CallAPI(context.Background(), "GET", "/api/v3/account/assets", nil, nil, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 502")
+	assert.Contains(t, err.Error(), "bad gateway")
+	assert.NotContains(t, err.Error(), "hunter2")
+}
@@ -0,0 +1,439 @@
+package uta
+
+// Clone returns an independent copy of s and its parameters.
+func (s *AccountAssetsService) Clone() *AccountAssetsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *AccountFeeRateService) Clone() *AccountFeeRateService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *AccountFundingAssetsService) Clone() *AccountFundingAssetsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *AccountInfoService) Clone() *AccountInfoService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *BatchCancelOrdersService) Clone() *BatchCancelOrdersService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *BatchModifyOrdersService) Clone() *BatchModifyOrdersService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *BatchPlaceOrdersService) Clone() *BatchPlaceOrdersService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CancelAllOrdersService) Clone() *CancelAllOrdersService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CancelOrderService) Clone() *CancelOrderService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CancelStrategyOrderService) Clone() *CancelStrategyOrderService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CloseAllPositionsService) Clone() *CloseAllPositionsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CountdownCancelAllService) Clone() *CountdownCancelAllService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CreateSubAccountAPIKeyService) Clone() *CreateSubAccountAPIKeyService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *CreateSubAccountService) Clone() *CreateSubAccountService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *DeleteSubAccountAPIKeyService) Clone() *DeleteSubAccountAPIKeyService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *FreezeSubAccountService) Clone() *FreezeSubAccountService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetCandlesticksService) Clone() *GetCandlesticksService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetConvertRecordsService) Clone() *GetConvertRecordsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetCurrentFundingRateService) Clone() *GetCurrentFundingRateService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetCurrentPositionsService) Clone() *GetCurrentPositionsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetDeductInfoService) Clone() *GetDeductInfoService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetDepositAddressService) Clone() *GetDepositAddressService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetDepositRecordsService) Clone() *GetDepositRecordsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetDiscountRateService) Clone() *GetDiscountRateService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetFillHistoryService) Clone() *GetFillHistoryService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetFinancialRecordsService) Clone() *GetFinancialRecordsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetFundingRateHistoryService) Clone() *GetFundingRateHistoryService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetHistoryCandlesticksService) Clone() *GetHistoryCandlesticksService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetInstrumentsService) Clone() *GetInstrumentsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetLoanOrdersService) Clone() *GetLoanOrdersService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetMarginLoansService) Clone() *GetMarginLoansService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetMaxOpenAvailableService) Clone() *GetMaxOpenAvailableService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetOILimitService) Clone() *GetOILimitService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetOpenInterestService) Clone() *GetOpenInterestService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetOpenOrdersService) Clone() *GetOpenOrdersService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetOrderBookService) Clone() *GetOrderBookService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetOrderDetailsService) Clone() *GetOrderDetailsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetOrderHistoryService) Clone() *GetOrderHistoryService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetPaymentCoinsService) Clone() *GetPaymentCoinsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetPositionHistoryService) Clone() *GetPositionHistoryService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetPositionTierService) Clone() *GetPositionTierService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetProofOfReservesService) Clone() *GetProofOfReservesService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetRecentPublicFillsService) Clone() *GetRecentPublicFillsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetRepayableCoinsService) Clone() *GetRepayableCoinsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetRiskReserveService) Clone() *GetRiskReserveService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetServerTimeService) Clone() *GetServerTimeService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetStrategyOrderHistoryService) Clone() *GetStrategyOrderHistoryService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetSubAccountAPIKeysService) Clone() *GetSubAccountAPIKeysService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetSubAccountAssetsService) Clone() *GetSubAccountAssetsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetSubAccountListService) Clone() *GetSubAccountListService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetSubDepositAddressService) Clone() *GetSubDepositAddressService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetSubDepositRecordsService) Clone() *GetSubDepositRecordsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetSwitchStatusService) Clone() *GetSwitchStatusService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetTickersService) Clone() *GetTickersService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetTransferRecordsService) Clone() *GetTransferRecordsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetTransferableCoinsService) Clone() *GetTransferableCoinsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetUnfilledStrategyOrdersService) Clone() *GetUnfilledStrategyOrdersService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *GetWithdrawalRecordsService) Clone() *GetWithdrawalRecordsService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *ModifyOrderService) Clone() *ModifyOrderService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *ModifyStrategyOrderService) Clone() *ModifyStrategyOrderService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *ModifySubAccountAPIKeyService) Clone() *ModifySubAccountAPIKeyService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *PlaceOrderService) Clone() *PlaceOrderService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *PlaceStrategyOrderService) Clone() *PlaceStrategyOrderService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *PositionPnLService) Clone() *PositionPnLService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *RepayService) Clone() *RepayService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *SetDepositAccountService) Clone() *SetDepositAccountService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *SetHoldingModeService) Clone() *SetHoldingModeService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *SetLeverageService) Clone() *SetLeverageService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *SubTransferService) Clone() *SubTransferService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *SwitchAccountService) Clone() *SwitchAccountService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *SwitchDeductService) Clone() *SwitchDeductService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *TransferService) Clone() *TransferService {
+	c := *s
+	return &c
+}
+
+// Clone returns an independent copy of s and its parameters.
+func (s *WithdrawalService) Clone() *WithdrawalService {
+	c := *s
+	return &c
+}
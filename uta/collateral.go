@@ -0,0 +1,103 @@
+package uta
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CollateralValue is the margin value of one coin.
+type CollateralValue struct {
+	Coin           string
+	Value          float64 // USD value of the net assets
+	EffectiveValue float64 // USD value counted as margin after the discount
+	Haircut        float64 // Value - EffectiveValue
+}
+
+// CollateralSummary is the margin value of an account's assets.
+type CollateralSummary struct {
+	Coins               []CollateralValue
+	TotalValue          float64
+	TotalEffectiveValue float64
+}
+
+type discountTier struct {
+	start float64
+	rate  float64
+}
+
+// CollateralCalculator applies discount tiers to asset values the way the exchange
+// computes margin: the value of a coin is split into tiers by TierStartValue and each
+// slice is multiplied by its tier's rate, so large holdings get a bigger haircut.
+//
+// Coins without discount tiers do not count as collateral. Negative net assets (debt)
+// are counted in full, since liabilities are not discounted.
+type CollateralCalculator struct {
+	tiers map[string][]discountTier
+}
+
+// NewCollateralCalculator parses the discount tiers returned by GetDiscountRateService.
+func NewCollateralCalculator(rates []DiscountRate) (*CollateralCalculator, error) {
+	c := &CollateralCalculator{tiers: make(map[string][]discountTier, len(rates))}
+	for _, r := range rates {
+		coin := strings.ToUpper(r.Coin)
+		tiers := make([]discountTier, 0, len(r.Tiers))
+		for _, t := range r.Tiers {
+			start, err := strconv.ParseFloat(t.TierStartValue, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid tierStartValue %q", coin, t.TierStartValue)
+			}
+			rate, err := strconv.ParseFloat(t.DiscountRate, 64)
+			if err != nil || rate < 0 || rate > 1 {
+				return nil, fmt.Errorf("%s: invalid discountRate %q", coin, t.DiscountRate)
+			}
+			tiers = append(tiers, discountTier{start: start, rate: rate})
+		}
+		sort.Slice(tiers, func(i, j int) bool { return tiers[i].start < tiers[j].start })
+		c.tiers[coin] = tiers
+	}
+	return c, nil
+}
+
+// EffectiveValue returns the margin value of usdValue worth of coin.
+func (c *CollateralCalculator) EffectiveValue(coin string, usdValue float64) float64 {
+	if usdValue <= 0 {
+		return usdValue
+	}
+	tiers := c.tiers[strings.ToUpper(coin)]
+	var effective float64
+	for i, t := range tiers {
+		if usdValue <= t.start {
+			break
+		}
+		end := usdValue
+		if i+1 < len(tiers) && tiers[i+1].start < end {
+			end = tiers[i+1].start
+		}
+		effective += (end - t.start) * t.rate
+	}
+	return effective
+}
+
+// Evaluate values each asset by its NetAssetsUSD and sums the result. Coins are returned
+// in the order of assets.
+func (c *CollateralCalculator) Evaluate(assets []AssetInfo) (*CollateralSummary, error) {
+	summary := &CollateralSummary{Coins: make([]CollateralValue, 0, len(assets))}
+	for _, a := range assets {
+		value, err := strconv.ParseFloat(a.NetAssetsUSD, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid netAssetsUSD %q", a.Coin, a.NetAssetsUSD)
+		}
+		effective := c.EffectiveValue(a.Coin, value)
+		summary.Coins = append(summary.Coins, CollateralValue{
+			Coin:           a.Coin,
+			Value:          value,
+			EffectiveValue: effective,
+			Haircut:        value - effective,
+		})
+		summary.TotalValue += value
+		summary.TotalEffectiveValue += effective
+	}
+	return summary, nil
+}
@@ -0,0 +1,76 @@
+package uta
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+var testDiscountRates = []DiscountRate{
+	{Coin: "BTC", Tiers: []DiscountTier{
+		{TierStartValue: "1000000", DiscountRate: "0.9"},
+		{TierStartValue: "0", DiscountRate: "0.95"},
+		{TierStartValue: "5000000", DiscountRate: "0.5"},
+	}},
+	{Coin: "USDT", Tiers: []DiscountTier{{TierStartValue: "0", DiscountRate: "1"}}},
+}
+
+func TestCollateralCalculator_EffectiveValue(t *testing.T) {
+	calc, err := NewCollateralCalculator(testDiscountRates)
+	require.NoError(t, err)
+
+	assert.InDelta(t, 95000, calc.EffectiveValue("BTC", 100000), 1e-6)
+	// 1M at 0.95 + 1M at 0.9
+	assert.InDelta(t, 1850000, calc.EffectiveValue("btc", 2000000), 1e-6)
+	// 1M at 0.95 + 4M at 0.9 + 1M at 0.5
+	assert.InDelta(t, 5050000, calc.EffectiveValue("BTC", 6000000), 1e-6)
+	assert.InDelta(t, 1234.5, calc.EffectiveValue("USDT", 1234.5), 1e-9)
+
+	assert.Zero(t, calc.EffectiveValue("DOGE", 500), "coins without tiers are not collateral")
+	assert.Equal(t, -300.0, calc.EffectiveValue("BTC", -300), "debt is not discounted")
+}
+
+func TestCollateralCalculator_Evaluate(t *testing.T) {
+	calc, err := NewCollateralCalculator(testDiscountRates)
+	require.NoError(t, err)
+
+	summary, err := calc.Evaluate([]AssetInfo{
+		{Coin: "BTC", NetAssetsUSD: "2000000"},
+		{Coin: "USDT", NetAssetsUSD: "-1000"},
+	})
+	require.NoError(t, err)
+	require.Len(t, summary.Coins, 2)
+	assert.InDelta(t, 150000, summary.Coins[0].Haircut, 1e-6)
+	assert.InDelta(t, 1999000, summary.TotalValue, 1e-6)
+	assert.InDelta(t, 1849000, summary.TotalEffectiveValue, 1e-6)
+
+	_, err = calc.Evaluate([]AssetInfo{{Coin: "BTC", NetAssetsUSD: ""}})
+	assert.EqualError(t, err, `BTC: invalid netAssetsUSD ""`)
+}
+
+func TestNewCollateralCalculator_InvalidTiers(t *testing.T) {
+	_, err := NewCollateralCalculator([]DiscountRate{{Coin: "eth", Tiers: []DiscountTier{{TierStartValue: "0", DiscountRate: "1.5"}}}})
+	assert.EqualError(t, err, `ETH: invalid discountRate "1.5"`)
+
+	_, err = NewCollateralCalculator([]DiscountRate{{Coin: "ETH", Tiers: []DiscountTier{{TierStartValue: "x", DiscountRate: "1"}}}})
+	assert.EqualError(t, err, `ETH: invalid tierStartValue "x"`)
+}
+
+func TestGetDiscountRateService_Do(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointMarketDiscountRate, url.Values(nil), []byte(nil), false).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`[{"coin":"BTC","userLimit":"100","totalLimit":"1000",
+			"list":[{"tierStartValue":"0","discountRate":"0.95"}]}]`)}, &fasthttp.ResponseHeader{}, nil)
+
+	rates, err := mockClient.NewGetDiscountRateService().Do(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	assert.Equal(t, "BTC", rates[0].Coin)
+	assert.Equal(t, "0.95", rates[0].Tiers[0].DiscountRate)
+	mockClient.AssertExpectations(t)
+}
@@ -66,15 +66,43 @@ const (
 	StrategyOrderTypeMarket = "market" // Market order execution
 )
 
+// OrderStatus is the state of an order.
+type OrderStatus string
+
 // Order status values
 const (
-	OrderStatusLive            = "live"
-	OrderStatusNew             = "new"
-	OrderStatusPartiallyFilled = "partially_filled"
-	OrderStatusFilled          = "filled"
-	OrderStatusCancelled       = "cancelled"
+	OrderStatusLive            OrderStatus = "live"
+	OrderStatusNew             OrderStatus = "new"
+	OrderStatusPartiallyFilled OrderStatus = "partially_filled"
+	OrderStatusFilled          OrderStatus = "filled"
+	OrderStatusCancelled       OrderStatus = "cancelled"
 )
 
+// IsLive reports whether the order is still open.
+func (s OrderStatus) IsLive() bool {
+	return s == OrderStatusLive || s == OrderStatusNew || s == OrderStatusPartiallyFilled
+}
+
+// IsTerminal reports whether the order can no longer change.
+func (s OrderStatus) IsTerminal() bool {
+	return s == OrderStatusFilled || s == OrderStatusCancelled
+}
+
+// TransferStatus is the state of a transfer.
+type TransferStatus string
+
+// Transfer status values
+const (
+	TransferStatusProcessing TransferStatus = "Processing"
+	TransferStatusSuccessful TransferStatus = "Successful"
+	TransferStatusFailed     TransferStatus = "Failed"
+)
+
+// IsTerminal reports whether the transfer has completed or failed.
+func (s TransferStatus) IsTerminal() bool {
+	return s == TransferStatusSuccessful || s == TransferStatusFailed
+}
+
 // Account types for transfers
 const (
 	AccountTypeSpot           = "spot"
@@ -220,6 +248,9 @@ const (
 	EndpointMarketPositionTier    = "/api/v3/market/position-tier"
 	EndpointMarketFills           = "/api/v3/market/fills"
 
+	// Public endpoints
+	EndpointPublicTime = "/api/v3/public/time"
+
 	// Institutional loan endpoints
 	EndpointInsLoanTransfered    = "/api/v3/ins-loan/transfered"
 	EndpointInsLoanBindUID       = "/api/v3/ins-loan/bind-uid"
@@ -0,0 +1,77 @@
+package uta
+
+import (
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// sizeAtPrice formats "size@price", or just the size for market orders.
+func sizeAtPrice(size, price string) string {
+	if price == "" || price == "0" {
+		return size
+	}
+	return size + "@" + price
+}
+
+// String returns a compact one-line summary, e.g.
+// Order{usdt-futures BTCUSDT buy limit 0.01@65000 status=filled filled=0.01 avg=64990 id=1}.
+func (o Order) String() string {
+	return common.Describe("Order", o.Category, o.Symbol, o.Side, o.PositionSide, o.OrderType,
+		sizeAtPrice(o.Size, o.Price), common.KV("status", string(o.Status)), common.KV("filled", o.FilledSize),
+		common.KV("avg", o.AvgPrice), common.KV("id", o.OrderID), common.KV("clientOid", o.ClientOid))
+}
+
+// MarshalJSON encodes the wire fields plus createdAt/updatedAt parsed from the
+// millisecond timestamps.
+func (o Order) MarshalJSON() ([]byte, error) {
+	return common.MarshalFields(o, common.TimeField("createdAt", o.CreatedTime), common.TimeField("updatedAt", o.UpdatedTime))
+}
+
+// String returns a compact one-line summary, e.g.
+// Fill{BTCUSDT buy 0.01@65000 fee=0.39 USDT role=taker order=1 fill=2}.
+func (f Fill) String() string {
+	fee := f.Fee
+	if fee != "" && f.FeeCoin != "" {
+		fee += " " + f.FeeCoin
+	}
+	return common.Describe("Fill", f.Symbol, f.Side, sizeAtPrice(f.FillSize, f.FillPrice),
+		common.KV("fee", fee), common.KV("role", f.TradeRole), common.KV("order", f.OrderID),
+		common.KV("fill", f.FillID))
+}
+
+// MarshalJSON encodes the wire fields plus time parsed from timestamp.
+func (f Fill) MarshalJSON() ([]byte, error) {
+	return common.MarshalFields(f, common.TimeField("time", f.Timestamp))
+}
+
+// String returns a compact one-line summary, e.g.
+// Position{BTCUSDT long 0.01@65000 mark=65500 upl=5 lev=10x liq=59000 crossed}.
+func (p Position) String() string {
+	var lev string
+	if p.Leverage != "" {
+		lev = "lev=" + p.Leverage + "x"
+	}
+	return common.Describe("Position", p.Symbol, p.Side, sizeAtPrice(p.Size, p.AvgPrice),
+		common.KV("mark", p.MarkPrice), common.KV("upl", p.UnrealizedPNL), lev,
+		common.KV("liq", p.LiquidationPrice), p.MarginMode)
+}
+
+// MarshalJSON encodes the wire fields plus createdAt/updatedAt parsed from the
+// millisecond timestamps.
+func (p Position) MarshalJSON() ([]byte, error) {
+	return common.MarshalFields(p, common.TimeField("createdAt", p.CreatedTime), common.TimeField("updatedAt", p.UpdatedTime))
+}
+
+// String returns a compact one-line summary, e.g.
+// AccountAssets{equity=1050.5 upl=50.5 effective=1040 assets=USDT:1000,BTC:0.01}.
+func (a AccountAssets) String() string {
+	var assets string
+	for i, asset := range a.Assets {
+		if i > 0 {
+			assets += ","
+		}
+		assets += asset.Coin + ":" + asset.Balance
+	}
+	return common.Describe("AccountAssets", common.KV("equity", a.AccountEquity),
+		common.KV("upl", a.UnrealizedPNL), common.KV("effective", a.EffectiveEquity),
+		common.KV("assets", assets))
+}
@@ -0,0 +1,39 @@
+package uta
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrder_StringAndJSON(t *testing.T) {
+	order := Order{
+		OrderID: "1", Symbol: "BTCUSDT", Category: "USDT-FUTURES", Side: "buy", OrderType: "limit",
+		Price: "65000", Size: "0.01", FilledSize: "0.005", AvgPrice: "64990", Status: "partially_filled",
+		CreatedTime: "1700000000000",
+	}
+	assert.Equal(t, "Order{USDT-FUTURES BTCUSDT buy limit 0.01@65000 status=partially_filled filled=0.005 avg=64990 id=1}", order.String())
+	assert.Equal(t, order.String(), fmt.Sprint(order))
+
+	data, err := json.Marshal(order)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"createdTime":"1700000000000","updatedTime":"","createdAt":"2023-11-14T22:13:20.000Z"}`)
+
+	var decoded Order
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, order, decoded)
+}
+
+func TestPositionAndFill_String(t *testing.T) {
+	pos := Position{Symbol: "ETHUSDT", Side: "short", Size: "2", AvgPrice: "3000", MarkPrice: "2990", UnrealizedPNL: "20", Leverage: "5", MarginMode: "crossed"}
+	assert.Equal(t, "Position{ETHUSDT short 2@3000 mark=2990 upl=20 lev=5x crossed}", pos.String())
+
+	fill := Fill{FillID: "9", OrderID: "1", Symbol: "ETHUSDT", Side: "sell", FillPrice: "3000", FillSize: "2", Fee: "1.2", FeeCoin: "USDT", TradeRole: "taker"}
+	assert.Equal(t, "Fill{ETHUSDT sell 2@3000 fee=1.2 USDT role=taker order=1 fill=9}", fill.String())
+
+	assets := AccountAssets{AccountEquity: "100", Assets: []AssetInfo{{Coin: "USDT", Balance: "90"}, {Coin: "BTC", Balance: "0.001"}}}
+	assert.Equal(t, "AccountAssets{equity=100 assets=USDT:90,BTC:0.001}", assets.String())
+}
@@ -0,0 +1,33 @@
+package uta
+
+import (
+	"context"
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// GetDiscountRateService retrieves the collateral discount tiers per coin. Feed the result
+// to NewCollateralCalculator to value account assets as margin.
+type GetDiscountRateService struct {
+	c ClientInterface
+}
+
+// NewGetDiscountRateService creates a new GetDiscountRateService instance
+func NewGetDiscountRateService(c ClientInterface) *GetDiscountRateService {
+	return &GetDiscountRateService{c: c}
+}
+
+// Do executes the discount rate request
+func (s *GetDiscountRateService) Do(ctx context.Context) ([]DiscountRate, error) {
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointMarketDiscountRate, nil, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var rates []DiscountRate
+	if err := jsoniter.Unmarshal(res.Data, &rates); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal discount rate data: %w", err)
+	}
+	return rates, nil
+}
@@ -0,0 +1,106 @@
+package uta
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// GetFillHistoryService retrieves the account's trade fills
+type GetFillHistoryService struct {
+	c         ClientInterface
+	category  *string
+	symbol    *string
+	orderId   *string
+	startTime *string
+	endTime   *string
+	limit     *string
+	cursor    *string
+}
+
+// Category sets the product category (required)
+func (s *GetFillHistoryService) Category(category string) *GetFillHistoryService {
+	s.category = &category
+	return s
+}
+
+// Symbol filters by trading symbol (optional)
+func (s *GetFillHistoryService) Symbol(symbol string) *GetFillHistoryService {
+	s.symbol = &symbol
+	return s
+}
+
+// OrderId filters by order (optional)
+func (s *GetFillHistoryService) OrderId(orderId string) *GetFillHistoryService {
+	s.orderId = &orderId
+	return s
+}
+
+// StartTime sets the start of the range in milliseconds (optional)
+func (s *GetFillHistoryService) StartTime(startTime string) *GetFillHistoryService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime sets the end of the range in milliseconds (optional)
+func (s *GetFillHistoryService) EndTime(endTime string) *GetFillHistoryService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit sets the page size (optional, maximum 100)
+func (s *GetFillHistoryService) Limit(limit string) *GetFillHistoryService {
+	s.limit = &limit
+	return s
+}
+
+// Cursor continues from the cursor returned with the previous page (optional)
+func (s *GetFillHistoryService) Cursor(cursor string) *GetFillHistoryService {
+	s.cursor = &cursor
+	return s
+}
+
+// Do executes the request and returns one page of fills
+func (s *GetFillHistoryService) Do(ctx context.Context) ([]Fill, error) {
+	fills, _, err := s.page(ctx, s.cursor)
+	return fills, err
+}
+
+// All follows the cursor and returns up to max fills (max <= 0 returns all)
+func (s *GetFillHistoryService) All(ctx context.Context, max int) ([]Fill, error) {
+	return collectPages(func(cursor string) ([]Fill, string, error) {
+		if cursor == "" {
+			return s.page(ctx, s.cursor)
+		}
+		return s.page(ctx, &cursor)
+	}, max)
+}
+
+func (s *GetFillHistoryService) page(ctx context.Context, cursor *string) ([]Fill, string, error) {
+	if s.category == nil {
+		return nil, "", common.NewMissingParameterError("category")
+	}
+
+	params := url.Values{}
+	params.Set("category", *s.category)
+	setOptional(params, "symbol", s.symbol)
+	setOptional(params, "orderId", s.orderId)
+	setOptional(params, "startTime", s.startTime)
+	setOptional(params, "endTime", s.endTime)
+	setOptional(params, "limit", s.limit)
+	setOptional(params, "cursor", cursor)
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointTradeFills, params, nil, true)
+	if err != nil {
+		return nil, "", err
+	}
+	return decodeList[Fill](res.Data)
+}
+
+// setOptional sets key when value is non-nil and non-empty
+func setOptional(params url.Values, key string, value *string) {
+	if value != nil && *value != "" {
+		params.Set(key, *value)
+	}
+}
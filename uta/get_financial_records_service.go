@@ -0,0 +1,107 @@
+package uta
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// GetFinancialRecordsService retrieves the account's financial records (fees, funding, transfers)
+type GetFinancialRecordsService struct {
+	c         ClientInterface
+	category  *string
+	symbol    *string
+	coin      *string
+	recType   *string
+	startTime *string
+	endTime   *string
+	limit     *string
+	cursor    *string
+}
+
+// Category sets the product category (required)
+func (s *GetFinancialRecordsService) Category(category string) *GetFinancialRecordsService {
+	s.category = &category
+	return s
+}
+
+// Symbol filters by trading symbol (optional)
+func (s *GetFinancialRecordsService) Symbol(symbol string) *GetFinancialRecordsService {
+	s.symbol = &symbol
+	return s
+}
+
+// Coin filters by coin (optional)
+func (s *GetFinancialRecordsService) Coin(coin string) *GetFinancialRecordsService {
+	s.coin = &coin
+	return s
+}
+
+// Type filters by record type, e.g. "funding_fee" (optional)
+func (s *GetFinancialRecordsService) Type(recordType string) *GetFinancialRecordsService {
+	s.recType = &recordType
+	return s
+}
+
+// StartTime sets the start of the range in milliseconds (optional)
+func (s *GetFinancialRecordsService) StartTime(startTime string) *GetFinancialRecordsService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime sets the end of the range in milliseconds (optional)
+func (s *GetFinancialRecordsService) EndTime(endTime string) *GetFinancialRecordsService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit sets the page size (optional, maximum 100)
+func (s *GetFinancialRecordsService) Limit(limit string) *GetFinancialRecordsService {
+	s.limit = &limit
+	return s
+}
+
+// Cursor continues from the cursor returned with the previous page (optional)
+func (s *GetFinancialRecordsService) Cursor(cursor string) *GetFinancialRecordsService {
+	s.cursor = &cursor
+	return s
+}
+
+// Do executes the request and returns one page of records
+func (s *GetFinancialRecordsService) Do(ctx context.Context) ([]FinancialRecord, error) {
+	records, _, err := s.page(ctx, s.cursor)
+	return records, err
+}
+
+// All follows the cursor and returns up to max records (max <= 0 returns all)
+func (s *GetFinancialRecordsService) All(ctx context.Context, max int) ([]FinancialRecord, error) {
+	return collectPages(func(cursor string) ([]FinancialRecord, string, error) {
+		if cursor == "" {
+			return s.page(ctx, s.cursor)
+		}
+		return s.page(ctx, &cursor)
+	}, max)
+}
+
+func (s *GetFinancialRecordsService) page(ctx context.Context, cursor *string) ([]FinancialRecord, string, error) {
+	if s.category == nil {
+		return nil, "", common.NewMissingParameterError("category")
+	}
+
+	params := url.Values{}
+	params.Set("category", *s.category)
+	setOptional(params, "symbol", s.symbol)
+	setOptional(params, "coin", s.coin)
+	setOptional(params, "type", s.recType)
+	setOptional(params, "startTime", s.startTime)
+	setOptional(params, "endTime", s.endTime)
+	setOptional(params, "limit", s.limit)
+	setOptional(params, "cursor", cursor)
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointAccountFinancialRecords, params, nil, true)
+	if err != nil {
+		return nil, "", err
+	}
+	return decodeList[FinancialRecord](res.Data)
+}
@@ -0,0 +1,50 @@
+package uta
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// GetOrderDetailsService retrieves a single order by orderId or clientOid
+type GetOrderDetailsService struct {
+	c         ClientInterface
+	orderId   *string
+	clientOid *string
+}
+
+// OrderId sets the order ID (either orderId or clientOid is required)
+func (s *GetOrderDetailsService) OrderId(orderId string) *GetOrderDetailsService {
+	s.orderId = &orderId
+	return s
+}
+
+// ClientOid sets the client order ID (either orderId or clientOid is required)
+func (s *GetOrderDetailsService) ClientOid(clientOid string) *GetOrderDetailsService {
+	s.clientOid = &clientOid
+	return s
+}
+
+// Do executes the request and returns the order, including its average fill price
+func (s *GetOrderDetailsService) Do(ctx context.Context) (*Order, error) {
+	if (s.orderId == nil || *s.orderId == "") && (s.clientOid == nil || *s.clientOid == "") {
+		return nil, common.NewMissingParameterError("orderId or clientOid")
+	}
+
+	params := url.Values{}
+	setOptional(params, "orderId", s.orderId)
+	setOptional(params, "clientOid", s.clientOid)
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointTradeOrderInfo, params, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var order Order
+	if err := common.UnmarshalJSON(res.Data, &order); err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
@@ -0,0 +1,29 @@
+package uta
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestGetOrderDetailsService_Do_Success(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointTradeOrderInfo, url.Values{"orderId": {"123"}}, []byte(nil), true).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`{"orderId":"123","symbol":"BTCUSDT","category":"SPOT","side":"buy","orderType":"market","avgPrice":"50012.5","status":"filled"}`)}, &fasthttp.ResponseHeader{}, nil)
+
+	order, err := mockClient.NewGetOrderDetailsService().OrderId("123").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "50012.5", order.AvgPrice)
+	assert.Equal(t, "BTCUSDT", order.Symbol)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetOrderDetailsService_Do_MissingID(t *testing.T) {
+	_, err := (&MockClient{}).NewGetOrderDetailsService().Do(context.Background())
+	assert.Error(t, err)
+}
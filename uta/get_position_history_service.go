@@ -0,0 +1,92 @@
+package uta
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// GetPositionHistoryService retrieves closed positions. Use PositionPnLService for a
+// realized PnL breakdown built from fills and financial records
+type GetPositionHistoryService struct {
+	c         ClientInterface
+	category  *string
+	symbol    *string
+	startTime *string
+	endTime   *string
+	limit     *string
+	cursor    *string
+}
+
+// Category sets the product category (required)
+func (s *GetPositionHistoryService) Category(category string) *GetPositionHistoryService {
+	s.category = &category
+	return s
+}
+
+// Symbol filters by trading symbol (optional)
+func (s *GetPositionHistoryService) Symbol(symbol string) *GetPositionHistoryService {
+	s.symbol = &symbol
+	return s
+}
+
+// StartTime sets the start of the range in milliseconds (optional)
+func (s *GetPositionHistoryService) StartTime(startTime string) *GetPositionHistoryService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime sets the end of the range in milliseconds (optional)
+func (s *GetPositionHistoryService) EndTime(endTime string) *GetPositionHistoryService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit sets the page size (optional, maximum 100)
+func (s *GetPositionHistoryService) Limit(limit string) *GetPositionHistoryService {
+	s.limit = &limit
+	return s
+}
+
+// Cursor continues from the cursor returned with the previous page (optional)
+func (s *GetPositionHistoryService) Cursor(cursor string) *GetPositionHistoryService {
+	s.cursor = &cursor
+	return s
+}
+
+// Do executes the request and returns one page of closed positions
+func (s *GetPositionHistoryService) Do(ctx context.Context) ([]HistoryPosition, error) {
+	positions, _, err := s.page(ctx, s.cursor)
+	return positions, err
+}
+
+// All follows the cursor and returns up to max positions (max <= 0 returns all)
+func (s *GetPositionHistoryService) All(ctx context.Context, max int) ([]HistoryPosition, error) {
+	return collectPages(func(cursor string) ([]HistoryPosition, string, error) {
+		if cursor == "" {
+			return s.page(ctx, s.cursor)
+		}
+		return s.page(ctx, &cursor)
+	}, max)
+}
+
+func (s *GetPositionHistoryService) page(ctx context.Context, cursor *string) ([]HistoryPosition, string, error) {
+	if s.category == nil {
+		return nil, "", common.NewMissingParameterError("category")
+	}
+
+	params := url.Values{}
+	params.Set("category", *s.category)
+	setOptional(params, "symbol", s.symbol)
+	setOptional(params, "startTime", s.startTime)
+	setOptional(params, "endTime", s.endTime)
+	setOptional(params, "limit", s.limit)
+	setOptional(params, "cursor", cursor)
+
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointPositionHistoryPosition, params, nil, true)
+	if err != nil {
+		return nil, "", err
+	}
+	return decodeList[HistoryPosition](res.Data)
+}
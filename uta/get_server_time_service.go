@@ -0,0 +1,41 @@
+package uta
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// GetServerTimeService retrieves the exchange server time. The endpoint is public and
+// cheap, which also makes it suitable for latency probes and clock sync.
+type GetServerTimeService struct {
+	c ClientInterface
+}
+
+// NewGetServerTimeService creates a new GetServerTimeService instance
+func NewGetServerTimeService(c ClientInterface) *GetServerTimeService {
+	return &GetServerTimeService{c: c}
+}
+
+// Do executes the request and returns the server time
+func (s *GetServerTimeService) Do(ctx context.Context) (time.Time, error) {
+	res, _, err := s.c.CallAPI(ctx, "GET", EndpointPublicTime, nil, nil, false)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var data struct {
+		ServerTime string `json:"serverTime"`
+	}
+	if err := jsoniter.Unmarshal(res.Data, &data); err != nil {
+		return time.Time{}, err
+	}
+	ms, err := strconv.ParseInt(data.ServerTime, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid server time %q", data.ServerTime)
+	}
+	return time.UnixMilli(ms), nil
+}
@@ -0,0 +1,33 @@
+package uta
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestGetServerTimeService_Do_Success(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointPublicTime, url.Values(nil), []byte(nil), false).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`{"serverTime":"1700000000123"}`)}, &fasthttp.ResponseHeader{}, nil)
+
+	ts, err := mockClient.NewGetServerTimeService().Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000123), ts.UnixMilli())
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetServerTimeService_Do_Error(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointPublicTime, url.Values(nil), []byte(nil), false).
+		Return(nil, &fasthttp.ResponseHeader{}, errors.New("network error"))
+
+	_, err := NewGetServerTimeService(mockClient).Do(context.Background())
+	assert.EqualError(t, err, "network error")
+}
@@ -165,15 +165,15 @@ func TestIntegration_GetCandlesticks_USDT_FUTURES(t *testing.T) {
 
 	// Validate candlestick structure
 	candle := candlesticks[0]
-	assert.NotEmpty(t, candle.Timestamp, "Timestamp should not be empty")
+	assert.NotEmpty(t, candle.CloseTime, "Timestamp should not be empty")
 	assert.NotEmpty(t, candle.Open, "Open price should not be empty")
 	assert.NotEmpty(t, candle.High, "High price should not be empty")
 	assert.NotEmpty(t, candle.Low, "Low price should not be empty")
 	assert.NotEmpty(t, candle.Close, "Close price should not be empty")
 	assert.NotEmpty(t, candle.Volume, "Volume should not be empty")
-	assert.NotEmpty(t, candle.Turnover, "Turnover should not be empty")
+	assert.NotEmpty(t, candle.QuoteAssetVolume, "Turnover should not be empty")
 
-	t.Logf("Latest candle - OHLC: %s/%s/%s/%s, Volume: %s",
+	t.Logf("Latest candle - OHLC: %v/%v/%v/%v, Volume: %v",
 		candle.Open, candle.High, candle.Low, candle.Close, candle.Volume)
 }
 
@@ -201,11 +201,11 @@ func TestIntegration_GetCandlesticks_SPOT(t *testing.T) {
 
 	// Validate first candlestick
 	candle := candlesticks[0]
-	assert.NotEmpty(t, candle.Timestamp)
+	assert.NotEmpty(t, candle.CloseTime)
 	assert.NotEmpty(t, candle.Open)
 	assert.NotEmpty(t, candle.Close)
 
-	t.Logf("SPOT candle - Open: %s, Close: %s", candle.Open, candle.Close)
+	t.Logf("SPOT candle - Open: %v, Close: %v", candle.Open, candle.Close)
 }
 
 func TestIntegration_AccountInfo(t *testing.T) {
@@ -0,0 +1,57 @@
+package uta
+
+import (
+	"bytes"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// listPage is the paginated list envelope used by history endpoints
+type listPage[T any] struct {
+	List   []T    `json:"list"`
+	Cursor string `json:"cursor"`
+}
+
+// decodeList decodes a history response, which is either a bare array or a list page,
+// and returns the items and the cursor of the next page
+func decodeList[T any](data []byte) ([]T, string, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, "", nil
+	}
+	if data[0] == '[' {
+		var items []T
+		err := common.UnmarshalJSON(data, &items)
+		return items, "", err
+	}
+	var page listPage[T]
+	if err := common.UnmarshalJSON(data, &page); err != nil {
+		return nil, "", err
+	}
+	return page.List, page.Cursor, nil
+}
+
+// collectPages calls fetch with the cursor of the previous page until the cursor is empty
+// or repeats, or max items are collected (max <= 0 means no limit)
+func collectPages[T any](fetch func(cursor string) ([]T, string, error), max int) ([]T, error) {
+	var (
+		all    []T
+		cursor string
+		seen   = make(map[string]bool)
+	)
+	for {
+		items, next, err := fetch(cursor)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if max > 0 && len(all) >= max {
+			return all[:max], nil
+		}
+		if len(items) == 0 || next == "" || seen[next] {
+			return all, nil
+		}
+		seen[next] = true
+		cursor = next
+	}
+}
@@ -0,0 +1,160 @@
+package uta
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/khanbekov/go-bitget/common"
+)
+
+// PositionPnL is the realized PnL of a closed position, rebuilt from its fills and the
+// funding records booked while it was open
+type PositionPnL struct {
+	Position HistoryPosition
+
+	GrossPnL    float64 // Sell minus buy fill value
+	TradingFees float64 // Fees paid on the fills, as a positive number
+	Funding     float64 // Funding received (positive) or paid (negative)
+	NetPnL      float64 // GrossPnL - TradingFees + Funding
+
+	Fills          []Fill
+	FundingRecords []FinancialRecord
+}
+
+// EnrichPosition joins fills and financial records to a closed position. Fills of the
+// position's symbol and funding records between its created and updated times are
+// attributed to it; when long and short positions of a symbol overlap in hedge mode,
+// their fills cannot be told apart and are attributed to both.
+func EnrichPosition(pos HistoryPosition, fills []Fill, records []FinancialRecord) PositionPnL {
+	from, _ := strconv.ParseInt(pos.CreatedTime, 10, 64)
+	to, _ := strconv.ParseInt(pos.UpdatedTime, 10, 64)
+	within := func(ts string) bool {
+		t, err := strconv.ParseInt(ts, 10, 64)
+		return err == nil && t >= from && (to == 0 || t <= to)
+	}
+
+	out := PositionPnL{Position: pos}
+	for _, f := range fills {
+		if f.Symbol != pos.Symbol || !within(f.Timestamp) {
+			continue
+		}
+		amount := parseFloat(f.FillAmount)
+		if amount == 0 {
+			amount = parseFloat(f.FillPrice) * parseFloat(f.FillSize)
+		}
+		if strings.EqualFold(f.Side, SideSell) {
+			out.GrossPnL += amount
+		} else {
+			out.GrossPnL -= amount
+		}
+		out.TradingFees += math.Abs(parseFloat(f.Fee))
+		out.Fills = append(out.Fills, f)
+	}
+	for _, r := range records {
+		if r.Symbol != pos.Symbol || !strings.Contains(strings.ToLower(r.Type), "fund") || !within(r.Timestamp) {
+			continue
+		}
+		out.Funding += parseFloat(r.Amount)
+		out.FundingRecords = append(out.FundingRecords, r)
+	}
+	out.NetPnL = out.GrossPnL - out.TradingFees + out.Funding
+	return out
+}
+
+func parseFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
+
+// PositionPnLService fetches closed positions and enriches each with a realized PnL
+// breakdown net of fees and funding. Fills and financial records are fetched once for
+// the time span of all returned positions and joined locally.
+type PositionPnLService struct {
+	c         ClientInterface
+	category  *string
+	symbol    *string
+	startTime *string
+	endTime   *string
+	limit     *string
+}
+
+// NewPositionPnLService creates a new PositionPnLService instance
+func NewPositionPnLService(c ClientInterface) *PositionPnLService {
+	return &PositionPnLService{c: c}
+}
+
+// Category sets the product category (required)
+func (s *PositionPnLService) Category(category string) *PositionPnLService {
+	s.category = &category
+	return s
+}
+
+// Symbol filters by trading symbol (optional)
+func (s *PositionPnLService) Symbol(symbol string) *PositionPnLService {
+	s.symbol = &symbol
+	return s
+}
+
+// StartTime sets the start of the range in milliseconds (optional)
+func (s *PositionPnLService) StartTime(startTime string) *PositionPnLService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime sets the end of the range in milliseconds (optional)
+func (s *PositionPnLService) EndTime(endTime string) *PositionPnLService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit sets the number of positions (optional, maximum 100)
+func (s *PositionPnLService) Limit(limit string) *PositionPnLService {
+	s.limit = &limit
+	return s
+}
+
+// Do executes the requests and returns the enriched positions
+func (s *PositionPnLService) Do(ctx context.Context) ([]PositionPnL, error) {
+	if s.category == nil {
+		return nil, common.NewMissingParameterError("category")
+	}
+
+	posSvc := &GetPositionHistoryService{c: s.c, category: s.category, symbol: s.symbol,
+		startTime: s.startTime, endTime: s.endTime, limit: s.limit}
+	positions, err := posSvc.Do(ctx)
+	if err != nil || len(positions) == 0 {
+		return nil, err
+	}
+
+	var from, to int64
+	for i, p := range positions {
+		created, _ := strconv.ParseInt(p.CreatedTime, 10, 64)
+		updated, _ := strconv.ParseInt(p.UpdatedTime, 10, 64)
+		if i == 0 || created < from {
+			from = created
+		}
+		if updated > to {
+			to = updated
+		}
+	}
+	start, end := strconv.FormatInt(from, 10), strconv.FormatInt(to, 10)
+
+	fills, err := (&GetFillHistoryService{c: s.c, category: s.category, symbol: s.symbol,
+		startTime: &start, endTime: &end}).All(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	records, err := (&GetFinancialRecordsService{c: s.c, category: s.category,
+		startTime: &start, endTime: &end}).All(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]PositionPnL, 0, len(positions))
+	for _, p := range positions {
+		out = append(out, EnrichPosition(p, fills, records))
+	}
+	return out, nil
+}
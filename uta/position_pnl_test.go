@@ -0,0 +1,93 @@
+package uta
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestEnrichPosition(t *testing.T) {
+	pos := HistoryPosition{Symbol: "BTCUSDT", PosSide: "long", CreatedTime: "1000", UpdatedTime: "5000"}
+	fills := []Fill{
+		{Symbol: "BTCUSDT", Side: "buy", FillAmount: "1000", Fee: "-0.6", Timestamp: "1000"},
+		{Symbol: "BTCUSDT", Side: "sell", FillPrice: "110", FillSize: "10", Fee: "-0.66", Timestamp: "5000"},
+		{Symbol: "ETHUSDT", Side: "sell", FillAmount: "500", Fee: "-0.3", Timestamp: "3000"},
+		{Symbol: "BTCUSDT", Side: "buy", FillAmount: "900", Fee: "-0.5", Timestamp: "6000"},
+	}
+	records := []FinancialRecord{
+		{Symbol: "BTCUSDT", Type: "contract_main_settle_fee", Amount: "-1.5", Timestamp: "2000"},
+		{Symbol: "BTCUSDT", Type: "FUNDING_FEE", Amount: "0.5", Timestamp: "4000"},
+		{Symbol: "ETHUSDT", Type: "funding_fee", Amount: "-9", Timestamp: "4000"},
+		{Symbol: "BTCUSDT", Type: "trade", Amount: "-100", Timestamp: "3000"},
+		{Symbol: "BTCUSDT", Type: "funding_fee", Amount: "-2", Timestamp: "7000"},
+	}
+
+	pnl := EnrichPosition(pos, fills, records)
+	assert.InDelta(t, 100, pnl.GrossPnL, 1e-9)
+	assert.InDelta(t, 1.26, pnl.TradingFees, 1e-9)
+	assert.InDelta(t, 0.5, pnl.Funding, 1e-9)
+	assert.InDelta(t, 99.24, pnl.NetPnL, 1e-9)
+	assert.Len(t, pnl.Fills, 2)
+	assert.Len(t, pnl.FundingRecords, 1)
+}
+
+func TestEnrichPosition_Short(t *testing.T) {
+	pos := HistoryPosition{Symbol: "BTCUSDT", PosSide: "short", CreatedTime: "1000", UpdatedTime: "2000"}
+	fills := []Fill{
+		{Symbol: "BTCUSDT", Side: "sell", FillAmount: "1000", Fee: "0.6", Timestamp: "1000"},
+		{Symbol: "BTCUSDT", Side: "buy", FillAmount: "1050", Fee: "0.6", Timestamp: "2000"},
+	}
+
+	pnl := EnrichPosition(pos, fills, nil)
+	assert.InDelta(t, -50, pnl.GrossPnL, 1e-9)
+	assert.InDelta(t, -51.2, pnl.NetPnL, 1e-9)
+}
+
+func TestPositionPnLService_Do(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointPositionHistoryPosition, mock.Anything, []byte(nil), true).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`{"list":[{"symbol":"BTCUSDT","posSide":"long","createdTime":"1000","updatedTime":"3000"}],"cursor":""}`)}, &fasthttp.ResponseHeader{}, nil)
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointTradeFills, mock.MatchedBy(func(v url.Values) bool {
+		return v.Get("startTime") == "1000" && v.Get("endTime") == "3000"
+	}), []byte(nil), true).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`{"list":[
+			{"symbol":"BTCUSDT","side":"buy","fillAmount":"100","fee":"-0.1","timestamp":"1000"},
+			{"symbol":"BTCUSDT","side":"sell","fillAmount":"120","fee":"-0.1","timestamp":"3000"}],"cursor":""}`)}, &fasthttp.ResponseHeader{}, nil)
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointAccountFinancialRecords, mock.Anything, []byte(nil), true).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`[{"symbol":"BTCUSDT","type":"funding_fee","amount":"-0.3","timestamp":"2000"}]`)}, &fasthttp.ResponseHeader{}, nil)
+
+	out, err := mockClient.NewPositionPnLService().Category(CategoryUSDTFutures).Do(context.Background())
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.InDelta(t, 20, out[0].GrossPnL, 1e-9)
+	assert.InDelta(t, 19.5, out[0].NetPnL, 1e-9)
+	mockClient.AssertExpectations(t)
+}
+
+func TestPositionPnLService_MissingCategory(t *testing.T) {
+	_, err := NewPositionPnLService(&MockClient{}).Do(context.Background())
+	assert.Error(t, err)
+}
+
+func TestGetFillHistoryService_All_FollowsCursor(t *testing.T) {
+	mockClient := &MockClient{}
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointTradeFills, mock.MatchedBy(func(v url.Values) bool {
+		return v.Get("cursor") == ""
+	}), []byte(nil), true).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`{"list":[{"fillId":"1"},{"fillId":"2"}],"cursor":"2"}`)}, &fasthttp.ResponseHeader{}, nil)
+	mockClient.On("CallAPI", mock.Anything, "GET", EndpointTradeFills, mock.MatchedBy(func(v url.Values) bool {
+		return v.Get("cursor") == "2"
+	}), []byte(nil), true).
+		Return(&ApiResponse{Code: "00000", Data: []byte(`{"list":[{"fillId":"3"}],"cursor":""}`)}, &fasthttp.ResponseHeader{}, nil)
+
+	fills, err := mockClient.NewGetFillHistoryService().Category(CategoryUSDTFutures).All(context.Background(), 0)
+	require.NoError(t, err)
+	require.Len(t, fills, 3)
+	assert.Equal(t, "3", fills[2].FillID)
+	mockClient.AssertExpectations(t)
+}
@@ -3,6 +3,8 @@ package uta
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/khanbekov/go-bitget/common"
 )
 
 // ApiResponse represents the standard UTA API response structure
@@ -66,6 +68,20 @@ type AssetInfo struct {
 	NetAssetsUSD      string `json:"netAssetsUSD"`
 }
 
+// DiscountRate represents the collateral discount tiers of a coin
+type DiscountRate struct {
+	Coin       string         `json:"coin"`
+	UserLimit  string         `json:"userLimit"`
+	TotalLimit string         `json:"totalLimit"`
+	Tiers      []DiscountTier `json:"list"`
+}
+
+// DiscountTier is the discount applied to the part of a coin's value above TierStartValue (USD)
+type DiscountTier struct {
+	TierStartValue string `json:"tierStartValue"`
+	DiscountRate   string `json:"discountRate"`
+}
+
 // FundingAssets represents funding account assets
 type FundingAssets struct {
 	Coin      string `json:"coin"`
@@ -92,17 +108,17 @@ type TransferResult struct {
 
 // TransferRecord represents transfer history record
 type TransferRecord struct {
-	TransferID string `json:"transferId"`
-	ClientOid  string `json:"clientOid"`
-	FromType   string `json:"fromType"`
-	ToType     string `json:"toType"`
-	Amount     string `json:"amount"`
-	Coin       string `json:"coin"`
-	Symbol     string `json:"symbol,omitempty"`
-	FromUserId string `json:"fromUserId,omitempty"`
-	ToUserId   string `json:"toUserId,omitempty"`
-	Status     string `json:"status"`
-	Timestamp  string `json:"timestamp"`
+	TransferID string         `json:"transferId"`
+	ClientOid  string         `json:"clientOid"`
+	FromType   string         `json:"fromType"`
+	ToType     string         `json:"toType"`
+	Amount     string         `json:"amount"`
+	Coin       string         `json:"coin"`
+	Symbol     string         `json:"symbol,omitempty"`
+	FromUserId string         `json:"fromUserId,omitempty"`
+	ToUserId   string         `json:"toUserId,omitempty"`
+	Status     TransferStatus `json:"status"`
+	Timestamp  string         `json:"timestamp"`
 }
 
 // Deposit and withdrawal structures
@@ -186,24 +202,24 @@ type SubAccountAPIKey struct {
 
 // Order represents order information
 type Order struct {
-	OrderID      string `json:"orderId"`
-	ClientOid    string `json:"clientOid"`
-	Symbol       string `json:"symbol"`
-	Category     string `json:"category"`
-	Side         string `json:"side"`
-	OrderType    string `json:"orderType"`
-	Price        string `json:"price,omitempty"`
-	Size         string `json:"size"`
-	FilledSize   string `json:"filledSize"`
-	FilledAmount string `json:"filledAmount"`
-	AvgPrice     string `json:"avgPrice"`
-	Status       string `json:"status"`
-	TimeInForce  string `json:"timeInForce,omitempty"`
-	ReduceOnly   string `json:"reduceOnly,omitempty"`
-	PositionSide string `json:"positionSide,omitempty"`
-	STP          string `json:"stp,omitempty"`
-	CreatedTime  string `json:"createdTime"`
-	UpdatedTime  string `json:"updatedTime"`
+	OrderID      string      `json:"orderId"`
+	ClientOid    string      `json:"clientOid"`
+	Symbol       string      `json:"symbol"`
+	Category     string      `json:"category"`
+	Side         string      `json:"side"`
+	OrderType    string      `json:"orderType"`
+	Price        string      `json:"price,omitempty"`
+	Size         string      `json:"size"`
+	FilledSize   string      `json:"filledSize"`
+	FilledAmount string      `json:"filledAmount"`
+	AvgPrice     string      `json:"avgPrice"`
+	Status       OrderStatus `json:"status"`
+	TimeInForce  string      `json:"timeInForce,omitempty"`
+	ReduceOnly   string      `json:"reduceOnly,omitempty"`
+	PositionSide string      `json:"positionSide,omitempty"`
+	STP          string      `json:"stp,omitempty"`
+	CreatedTime  string      `json:"createdTime"`
+	UpdatedTime  string      `json:"updatedTime"`
 }
 
 // BatchOrderResult represents batch order operation result
@@ -248,6 +264,27 @@ type Position struct {
 	UpdatedTime      string `json:"updatedTime"`
 }
 
+// HistoryPosition represents a closed position
+type HistoryPosition struct {
+	PositionID    string `json:"positionId"`
+	Symbol        string `json:"symbol"`
+	Category      string `json:"category"`
+	MarginCoin    string `json:"marginCoin"`
+	PosSide       string `json:"posSide"`
+	MarginMode    string `json:"marginMode"`
+	OpenPriceAvg  string `json:"openPriceAvg"`
+	ClosePriceAvg string `json:"closePriceAvg"`
+	OpenTotalPos  string `json:"openTotalPos"`
+	CloseTotalPos string `json:"closeTotalPos"`
+	RealisedPNL   string `json:"cumRealisedPnl"`
+	NetProfit     string `json:"netProfit"`
+	TotalFunding  string `json:"totalFunding"`
+	OpenFee       string `json:"openFee"`
+	CloseFee      string `json:"closeFee"`
+	CreatedTime   string `json:"createdTime"`
+	UpdatedTime   string `json:"updatedTime"`
+}
+
 // StrategyOrder represents strategy order information
 type StrategyOrder struct {
 	OrderID      string `json:"orderId"`
@@ -294,38 +331,9 @@ type Ticker struct {
 	Timestamp         string `json:"ts"`
 }
 
-// Candlestick represents OHLCV data
-type Candlestick struct {
-	Timestamp string
-	Open      string
-	High      string
-	Low       string
-	Close     string
-	Volume    string
-	Turnover  string
-}
-
-// UnmarshalJSON implements custom JSON unmarshaling for Candlestick
-func (c *Candlestick) UnmarshalJSON(data []byte) error {
-	var arr []string
-	if err := json.Unmarshal(data, &arr); err != nil {
-		return err
-	}
-
-	if len(arr) < 7 {
-		return nil
-	}
-
-	c.Timestamp = arr[0]
-	c.Open = arr[1]
-	c.High = arr[2]
-	c.Low = arr[3]
-	c.Close = arr[4]
-	c.Volume = arr[5]
-	c.Turnover = arr[6]
-
-	return nil
-}
+// Candlestick represents OHLCV data. It is an alias of common.Candle, shared with the
+// futures market package. Candle.Strings returns the values as decimal strings.
+type Candlestick = common.Candle
 
 // OrderBook represents order book data
 type OrderBook struct {
@@ -341,6 +349,8 @@ type OrderBook struct {
 // FinancialRecord represents financial transaction record
 type FinancialRecord struct {
 	RecordID  string `json:"recordId"`
+	Category  string `json:"category,omitempty"`
+	Symbol    string `json:"symbol,omitempty"`
 	Coin      string `json:"coin"`
 	Type      string `json:"type"`
 	Amount    string `json:"amount"`
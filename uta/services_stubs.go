@@ -66,13 +66,6 @@ type SetDepositAccountService struct{ c ClientInterface }
 
 func (s *SetDepositAccountService) Do(ctx context.Context) error { return nil }
 
-// Financial records service stubs
-type GetFinancialRecordsService struct{ c ClientInterface }
-
-func (s *GetFinancialRecordsService) Do(ctx context.Context) ([]FinancialRecord, error) {
-	return nil, nil
-}
-
 type GetConvertRecordsService struct{ c ClientInterface }
 
 func (s *GetConvertRecordsService) Do(ctx context.Context) ([]ConvertRecord, error) { return nil, nil }
@@ -199,26 +192,14 @@ type GetOpenOrdersService struct{ c ClientInterface }
 
 func (s *GetOpenOrdersService) Do(ctx context.Context) ([]Order, error) { return nil, nil }
 
-type GetOrderDetailsService struct{ c ClientInterface }
-
-func (s *GetOrderDetailsService) Do(ctx context.Context) (*Order, error) { return nil, nil }
-
 type GetOrderHistoryService struct{ c ClientInterface }
 
 func (s *GetOrderHistoryService) Do(ctx context.Context) ([]Order, error) { return nil, nil }
 
-type GetFillHistoryService struct{ c ClientInterface }
-
-func (s *GetFillHistoryService) Do(ctx context.Context) ([]Fill, error) { return nil, nil }
-
 type GetCurrentPositionsService struct{ c ClientInterface }
 
 func (s *GetCurrentPositionsService) Do(ctx context.Context) ([]Position, error) { return nil, nil }
 
-type GetPositionHistoryService struct{ c ClientInterface }
-
-func (s *GetPositionHistoryService) Do(ctx context.Context) ([]Position, error) { return nil, nil }
-
 type GetMaxOpenAvailableService struct{ c ClientInterface }
 
 func (s *GetMaxOpenAvailableService) Do(ctx context.Context) (*MaxOpenAvailable, error) {
@@ -251,10 +232,6 @@ type GetInstrumentsService struct{ c ClientInterface }
 
 func (s *GetInstrumentsService) Do(ctx context.Context) (interface{}, error) { return nil, nil }
 
-type GetDiscountRateService struct{ c ClientInterface }
-
-func (s *GetDiscountRateService) Do(ctx context.Context) (interface{}, error) { return nil, nil }
-
 type GetMarginLoansService struct{ c ClientInterface }
 
 func (s *GetMarginLoansService) Do(ctx context.Context) (interface{}, error) { return nil, nil }
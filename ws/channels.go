@@ -36,6 +36,7 @@ const (
 	ChannelTrade       = "trade"        // Real-time trade executions
 	ChannelMarkPrice   = "mark-price"   // Mark price updates
 	ChannelFundingTime = "funding-time" // Funding rate and time
+	ChannelLiquidation = "liquidation"  // Public forced (liquidation) orders
 
 	// Private channels (require authentication)
 	ChannelOrders    = "orders"     // Real-time order updates
@@ -192,6 +193,31 @@ func (c *BaseWsClient) SubscribeTrades(symbol, productType string, handler OnRec
 	c.subscribe(args)
 }
 
+// SubscribeLiquidations subscribes to public forced (liquidation) orders for a specific symbol.
+// Each push lists the liquidation orders executed since the previous one.
+//
+// Parameters:
+//   - symbol: Trading pair symbol (e.g., "BTCUSDT")
+//   - productType: Product type ("USDT-FUTURES", "COIN-FUTURES", etc.)
+//   - handler: Callback function to handle incoming liquidation messages
+//
+// Example:
+//
+//	client.SubscribeLiquidations("BTCUSDT", "USDT-FUTURES", func(message string) {
+//	    events, _ := market.ParseLiquidationMessage(message)
+//	    fmt.Println("Liquidations:", events)
+//	})
+func (c *BaseWsClient) SubscribeLiquidations(symbol, productType string, handler OnReceive) {
+	args := SubscriptionArgs{
+		ProductType: productType,
+		Channel:     ChannelLiquidation,
+		Symbol:      symbol,
+	}
+
+	c.subscriptions[args] = handler
+	c.subscribe(args)
+}
+
 // SubscribeMarkPrice subscribes to real-time mark price updates for a specific symbol.
 // Mark price is used for PnL calculations and liquidations in futures trading.
 //
@@ -264,6 +290,19 @@ func (c *BaseWsClient) Unsubscribe(channel, symbol, productType string) {
 	c.unsubscribe(args)
 }
 
+// SubscribeArgs subscribes to the channel described by args. It is the generic form of
+// the Subscribe helpers, for channels selected at runtime such as subscription manifests.
+func (c *BaseWsClient) SubscribeArgs(args SubscriptionArgs, handler OnReceive) {
+	c.subscriptions[args] = handler
+	c.subscribe(args)
+}
+
+// UnsubscribeArgs removes the subscription described by args, including its coin.
+func (c *BaseWsClient) UnsubscribeArgs(args SubscriptionArgs) {
+	delete(c.subscriptions, args)
+	c.unsubscribe(args)
+}
+
 // UnsubscribeTicker removes ticker subscription for a specific symbol and product type.
 func (c *BaseWsClient) UnsubscribeTicker(symbol, productType string) {
 	c.Unsubscribe(ChannelTicker, symbol, productType)
@@ -285,6 +324,11 @@ func (c *BaseWsClient) UnsubscribeOrderBook5(symbol, productType string) {
 	c.Unsubscribe(ChannelBooks5, symbol, productType)
 }
 
+// UnsubscribeLiquidations removes liquidation order subscription for a specific symbol and product type.
+func (c *BaseWsClient) UnsubscribeLiquidations(symbol, productType string) {
+	c.Unsubscribe(ChannelLiquidation, symbol, productType)
+}
+
 // UnsubscribeOrderBook15 removes top 15 order book subscription for a specific symbol and product type.
 func (c *BaseWsClient) UnsubscribeOrderBook15(symbol, productType string) {
 	c.Unsubscribe(ChannelBooks15, symbol, productType)
@@ -10,12 +10,14 @@
 package ws
 
 import (
+	"context"
 	"fmt"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/khanbekov/go-bitget/common"
 	"github.com/khanbekov/go-bitget/common/types"
 	"github.com/rs/zerolog"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -26,6 +28,10 @@ import (
 // It receives the raw message string from the WebSocket connection.
 type OnReceive func(message string)
 
+// RawTap is a callback invoked with every raw frame read from the WebSocket connection,
+// before any parsing or routing. It is intended for recording and auditing feeds.
+type RawTap func(frame []byte)
+
 // loginCredentials stores authentication details for re-authentication after reconnection
 type loginCredentials struct {
 	apiKey     string
@@ -48,13 +54,15 @@ type BaseWsClient struct {
 	connected             bool                           // Current connection status
 	loginStatus           bool                           // Authentication status
 	url                   string                         // WebSocket endpoint URL
-	logger                zerolog.Logger                 // Logger for debugging and monitoring
+	logger                common.Logger                  // Logger for debugging and monitoring
 	listener              OnReceive                      // Default message handler
 	errorListener         OnReceive                      // Error message handler
-	checkConnectionTicker *time.Ticker                   // Timer for connection health checks
+	checkConnectionTicker common.Ticker                  // Timer for connection health checks
+	checkInterval         time.Duration                  // Interval of checkConnectionTicker
 	reconnectionTimeout   time.Duration                  // Timeout before attempting reconnection
 	sendMutex             *sync.Mutex                    // Mutex for thread-safe message sending
-	webSocketClient       *websocket.Conn                // Underlying WebSocket connection
+	webSocketClient       *websocket.Conn                // Underlying WebSocket connection, guarded by connMu
+	connMu                sync.Mutex                     // Guards webSocketClient
 	lastReceivedTime      time.Time                      // Timestamp of last received message
 	connectionStartTime   time.Time                      // Timestamp when connection was established
 	subscribeRequests     *types.Set                     // Set of active subscription requests
@@ -63,9 +71,15 @@ type BaseWsClient struct {
 	rateLimiter           *rateLimiter                   // Rate limiter for message sending
 	reconnecting          bool                           // Flag to prevent multiple concurrent reconnection attempts
 	reconnectMutex        sync.Mutex                     // Mutex for thread-safe reconnection
-	maxReconnectAttempts  int                            // Maximum number of reconnection attempts
+	reconnectPolicy       ReconnectPolicy                // Delays and attempts of reconnection
 	reconnectAttempts     int                            // Current number of reconnection attempts
+	reconnects            atomic.Int64                   // Successful reconnections since creation
 	storedLoginCreds      *loginCredentials              // Stored login credentials for re-authentication
+	rawTap                RawTap                         // Optional tap receiving every raw frame
+	retryBudget           *common.RetryBudget            // Optional budget shared with other clients
+	clock                 common.Clock                   // Time source of timeouts, backoffs and pacing
+	onDisconnect          func(reason error)             // Optional callback when the connection is dropped
+	onReconnect           func(attempts int)             // Optional callback after a successful reconnection
 }
 
 // NewBitgetBaseWsClient creates a new WebSocket client for Bitget's real-time API.
@@ -77,18 +91,30 @@ type BaseWsClient struct {
 //
 // Returns a configured BaseWsClient ready for connection.
 func NewBitgetBaseWsClient(logger zerolog.Logger, url, secretKey string) *BaseWsClient {
+	return NewBaseWsClient(common.NewZerologLogger(logger), url, secretKey)
+}
+
+// NewBaseWsClient is like NewBitgetBaseWsClient but accepts any common.Logger, e.g. one
+// created with common.NewSlogLogger or common.NewZapLogger.
+func NewBaseWsClient(logger common.Logger, url, secretKey string) *BaseWsClient {
+	if logger == nil {
+		logger = common.NopLogger()
+	}
+	clock := common.SystemClock()
 	return &BaseWsClient{
 		logger:                logger,
+		clock:                 clock,
 		url:                   url,
 		subscribeRequests:     types.NewSet(),
 		signer:                common.NewSigner(secretKey),
 		subscriptions:         make(map[SubscriptionArgs]OnReceive),
 		sendMutex:             &sync.Mutex{},
-		checkConnectionTicker: time.NewTicker(5 * time.Second),
+		checkConnectionTicker: clock.NewTicker(5 * time.Second),
+		checkInterval:         5 * time.Second,
 		reconnectionTimeout:   120 * time.Second, // Increased from 60s to 120s for better stability
-		lastReceivedTime:      time.Now(),
-		connectionStartTime:   time.Now(),
-		maxReconnectAttempts:  5, // Maximum 5 reconnection attempts before giving up
+		lastReceivedTime:      clock.Now(),
+		connectionStartTime:   clock.Now(),
+		reconnectPolicy:       DefaultReconnectPolicy(),
 		rateLimiter: &rateLimiter{
 			minInterval: 100 * time.Millisecond, // 10 messages per second max
 		},
@@ -98,7 +124,19 @@ func NewBitgetBaseWsClient(logger zerolog.Logger, url, secretKey string) *BaseWs
 // SetCheckConnectionInterval configures how often the client checks connection health.
 // Default is 5 seconds. Lower values provide faster reconnection but more overhead.
 func (c *BaseWsClient) SetCheckConnectionInterval(interval time.Duration) {
-	c.checkConnectionTicker = time.NewTicker(interval)
+	c.checkConnectionTicker.Stop()
+	c.checkInterval = interval
+	c.checkConnectionTicker = c.clock.NewTicker(interval)
+}
+
+// SetClock replaces the time source of connection health checks, reconnection backoff
+// and send pacing, e.g. with a common.FakeClock in tests. Call it before Connect.
+func (c *BaseWsClient) SetClock(clock common.Clock) {
+	c.clock = common.ClockOrSystem(clock)
+	c.checkConnectionTicker.Stop()
+	c.checkConnectionTicker = c.clock.NewTicker(c.checkInterval)
+	c.lastReceivedTime = c.clock.Now()
+	c.connectionStartTime = c.clock.Now()
 }
 
 // SetReconnectionTimeout sets how long to wait without receiving messages before reconnecting.
@@ -110,7 +148,14 @@ func (c *BaseWsClient) SetReconnectionTimeout(timeout time.Duration) {
 // SetMaxReconnectAttempts sets the maximum number of reconnection attempts before giving up.
 // Default is 5 attempts. Set to 0 for unlimited attempts.
 func (c *BaseWsClient) SetMaxReconnectAttempts(maxAttempts int) {
-	c.maxReconnectAttempts = maxAttempts
+	c.reconnectPolicy.MaxAttempts = maxAttempts
+}
+
+// SetRetryBudget makes every reconnection attempt, with its login and resubscriptions,
+// take a token from budget, which can be shared with REST clients and other WebSocket
+// connections. When the budget is exhausted reconnection waits for a token.
+func (c *BaseWsClient) SetRetryBudget(budget *common.RetryBudget) {
+	c.retryBudget = budget
 }
 
 // SetListener sets the default message and error handlers for the WebSocket client.
@@ -123,36 +168,60 @@ func (c *BaseWsClient) SetListener(msgListener OnReceive, errorListener OnReceiv
 	c.errorListener = errorListener
 }
 
+// SetRawTap installs a tap that receives a copy of every frame read from the connection,
+// excluding keep-alive "pong" replies. Pass nil to remove the tap.
+func (c *BaseWsClient) SetRawTap(tap RawTap) {
+	c.rawTap = tap
+}
+
+// SetLogger replaces the client logger. Passing nil disables logging.
+func (c *BaseWsClient) SetLogger(logger common.Logger) {
+	if logger == nil {
+		logger = common.NopLogger()
+	}
+	c.logger = logger
+}
+
 // Connect initiates the WebSocket connection and starts the monitoring loop.
 // This method starts the connection health checker and ping mechanism.
 func (c *BaseWsClient) Connect() {
+	c.ConnectContext(context.Background())
+}
 
-	go c.tickerLoop() // Run ticker loop in background goroutine
-	err := c.startPing()
+// ConnectContext is like Connect, but stops the connection health checker and the ping
+// mechanism when ctx is cancelled. Pair it with ReadLoopContext to stop every goroutine
+// of the client with one context.
+func (c *BaseWsClient) ConnectContext(ctx context.Context) {
+	go c.tickerLoop(ctx) // Run ticker loop in background goroutine
+	cr, err := c.startPing()
 	if err != nil {
-		c.logger.Error().Err(err).Msg("fail to start ping")
+		c.logger.Error("fail to start ping", "error", err)
 		return
 	}
+	context.AfterFunc(ctx, func() {
+		<-cr.Stop().Done()
+		c.logger.Debug("WebSocket monitoring stopped")
+	})
 }
 
 // ConnectWebSocket establishes the actual WebSocket connection to the Bitget server.
 // This method is called internally by Connect() and during reconnection attempts.
 func (c *BaseWsClient) ConnectWebSocket() {
-	var err error
-	c.logger.Info().Msg("WebSocket connecting...")
-	c.webSocketClient, _, err = websocket.DefaultDialer.Dial(c.url, nil)
+	c.logger.Info("WebSocket connecting...")
+	conn, _, err := websocket.DefaultDialer.Dial(c.url, nil)
 	if err != nil {
-		fmt.Printf("WebSocket connected error: %s\n", err)
+		c.logger.Error("WebSocket connection failed", "error", err)
 		return
 	}
-	c.logger.Info().Msg("WebSocket connected")
+	c.setConn(conn)
+	c.logger.Info("WebSocket connected")
 	c.connected = true
-	c.connectionStartTime = time.Now() // Reset connection start time
-	c.lastReceivedTime = time.Now()    // Reset last received time to prevent immediate timeout
+	c.connectionStartTime = c.clock.Now() // Reset connection start time
+	c.lastReceivedTime = c.clock.Now()    // Reset last received time to prevent immediate timeout
 
 	// Restore subscriptions after reconnection
 	if len(c.subscriptions) > 0 {
-		c.logger.Info().Int("subscription_count", len(c.subscriptions)).Msg("Restoring subscriptions after reconnection")
+		c.logger.Info("Restoring subscriptions after reconnection", "subscription_count", len(c.subscriptions))
 		c.restoreSubscriptions()
 	}
 }
@@ -180,7 +249,7 @@ func (c *BaseWsClient) Login(apiKey, passphrase string, signType common.SignType
 // performLogin executes the actual login process
 func (c *BaseWsClient) performLogin() {
 	if c.storedLoginCreds == nil {
-		c.logger.Error().Msg("No stored login credentials available")
+		c.logger.Error("No stored login credentials available")
 		return
 	}
 
@@ -213,14 +282,19 @@ func (c *BaseWsClient) StartReadLoop() {
 	go c.ReadLoop()
 }
 
-func (c *BaseWsClient) startPing() error {
+// StartReadLoopContext runs ReadLoopContext in a new goroutine.
+func (c *BaseWsClient) StartReadLoopContext(ctx context.Context) {
+	go c.ReadLoopContext(ctx)
+}
+
+func (c *BaseWsClient) startPing() (*cron.Cron, error) {
 	cr := cron.New(cron.WithSeconds()) // Enable seconds field
 	_, err := cr.AddFunc("*/30 * * * * *", c.ping)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	cr.Start()
-	return nil
+	return cr, nil
 }
 func (c *BaseWsClient) ping() {
 	c.Send("ping")
@@ -232,52 +306,56 @@ func (c *BaseWsClient) SendByType(req WsBaseReq) {
 }
 
 func (c *BaseWsClient) Send(data string) {
-	if c.webSocketClient == nil {
-		c.logger.Error().Msg("WebSocket sent error: no connection available")
+	conn := c.conn()
+	if conn == nil {
+		c.logger.Error("WebSocket sent error: no connection available")
 		return
 	}
 
 	// Apply rate limiting (max 10 messages per second)
 	c.rateLimiter.mutex.Lock()
-	timeSinceLastSend := time.Since(c.rateLimiter.lastSend)
+	timeSinceLastSend := c.clock.Now().Sub(c.rateLimiter.lastSend)
 	if timeSinceLastSend < c.rateLimiter.minInterval {
 		sleepDuration := c.rateLimiter.minInterval - timeSinceLastSend
 		c.rateLimiter.mutex.Unlock()
-		c.logger.Debug().Dur("sleep", sleepDuration).Msg("Rate limiting: sleeping before send")
-		time.Sleep(sleepDuration)
+		c.logger.Debug("Rate limiting: sleeping before send", "sleep", sleepDuration)
+		c.clock.Sleep(sleepDuration)
 		c.rateLimiter.mutex.Lock()
 	}
-	c.rateLimiter.lastSend = time.Now()
+	c.rateLimiter.lastSend = c.clock.Now()
 	c.rateLimiter.mutex.Unlock()
 
-	c.logger.Debug().Str("message", data).Msg("send message")
+	c.logger.Debug("send message", "message", data)
 	c.sendMutex.Lock()
-	err := c.webSocketClient.WriteMessage(websocket.TextMessage, []byte(data))
+	err := conn.WriteMessage(websocket.TextMessage, []byte(data))
 	c.sendMutex.Unlock()
 	if err != nil {
-		c.logger.Error().Err(err).Str("message", data).Msg("failed to send message to websocket")
+		c.logger.Error("failed to send message to websocket", "error", err, "message", data)
 	}
 }
 
-func (c *BaseWsClient) tickerLoop() {
-	c.logger.Info().Msg("tickerLoop started")
+func (c *BaseWsClient) tickerLoop(ctx context.Context) {
+	c.logger.Info("tickerLoop started")
 	for {
 		select {
-		case <-c.checkConnectionTicker.C:
+		case <-ctx.Done():
+			c.logger.Info("tickerLoop stopped")
+			return
+		case <-c.checkConnectionTicker.C():
 			// Skip checks if already reconnecting
 			if c.reconnecting {
 				continue
 			}
 
-			elapsedSecond := time.Now().Sub(c.lastReceivedTime)
-			connectionAge := time.Now().Sub(c.connectionStartTime)
+			elapsedSecond := c.clock.Now().Sub(c.lastReceivedTime)
+			connectionAge := c.clock.Now().Sub(c.connectionStartTime)
 
 			// Check for 24-hour force disconnect (as per WebSocket spec)
 			if connectionAge > 24*time.Hour {
-				c.logger.Info().Msg("24-hour limit reached, forcing WebSocket reconnection")
+				c.logger.Info("24-hour limit reached, forcing WebSocket reconnection")
 				go func() {
-					if err := c.performReconnection(); err != nil {
-						c.logger.Error().Err(err).Msg("Failed to perform 24-hour reconnection")
+					if err := c.performReconnection(ctx, ErrConnectionExpired); err != nil {
+						c.logger.Error("Failed to perform 24-hour reconnection", "error", err)
 					}
 				}()
 				continue
@@ -285,10 +363,10 @@ func (c *BaseWsClient) tickerLoop() {
 
 			// Check for message timeout
 			if elapsedSecond > c.reconnectionTimeout {
-				c.logger.Warn().Dur("elapsed", elapsedSecond).Msg("WebSocket reconnect due to timeout...")
+				c.logger.Warn("WebSocket reconnect due to timeout...", "elapsed", elapsedSecond)
 				go func() {
-					if err := c.performReconnection(); err != nil {
-						c.logger.Error().Err(err).Msg("Failed to perform timeout reconnection")
+					if err := c.performReconnection(ctx, ErrReadTimeout); err != nil {
+						c.logger.Error("Failed to perform timeout reconnection", "error", err)
 					}
 				}()
 			}
@@ -304,16 +382,18 @@ func (c *BaseWsClient) Reconnect() error {
 	defer c.reconnectMutex.Unlock()
 
 	if c.reconnecting {
-		c.logger.Debug().Msg("Reconnection already in progress, skipping")
+		c.logger.Debug("Reconnection already in progress, skipping")
 		return nil
 	}
 
-	c.logger.Info().Msg("Manual reconnection triggered")
-	return c.performReconnection()
+	c.logger.Info("Manual reconnection triggered")
+	return c.performReconnection(context.Background(), ErrManualReconnect)
 }
 
-// performReconnection handles the actual reconnection logic with exponential backoff
-func (c *BaseWsClient) performReconnection() error {
+// performReconnection handles the actual reconnection logic with the backoff of the
+// reconnection policy. It gives up when ctx is cancelled, so no connection is dialed
+// after the loops of a cancelled client have stopped.
+func (c *BaseWsClient) performReconnection(ctx context.Context, reason error) error {
 	c.reconnecting = true
 	defer func() {
 		c.reconnecting = false
@@ -323,82 +403,131 @@ func (c *BaseWsClient) performReconnection() error {
 	c.disconnectWebSocket()
 	c.connected = false
 	c.loginStatus = false
+	if c.onDisconnect != nil {
+		c.onDisconnect(reason)
+	}
 
 	// Reset connection state
 	c.reconnectAttempts = 0
 
 	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		// Check if we've exceeded max attempts (0 means unlimited)
-		if c.maxReconnectAttempts > 0 && c.reconnectAttempts >= c.maxReconnectAttempts {
-			c.logger.Error().
-				Int("max_attempts", c.maxReconnectAttempts).
-				Msg("Maximum reconnection attempts reached, giving up")
-			return fmt.Errorf("maximum reconnection attempts (%d) exceeded", c.maxReconnectAttempts)
+		maxAttempts := c.reconnectPolicy.MaxAttempts
+		if maxAttempts > 0 && c.reconnectAttempts >= maxAttempts {
+			c.logger.Error("Maximum reconnection attempts reached, giving up", "max_attempts", maxAttempts)
+			// Wait a full reconnection timeout before the health check starts over
+			c.lastReceivedTime = c.clock.Now()
+			return fmt.Errorf("maximum reconnection attempts (%d) exceeded", maxAttempts)
+		}
+
+		waitStart := c.clock.Now()
+		if err := c.retryBudget.Wait(ctx); err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if waited := c.clock.Now().Sub(waitStart); waited > time.Second {
+			c.logger.Warn("Reconnection delayed by retry budget", "waited", waited)
 		}
 
 		c.reconnectAttempts++
-		c.logger.Info().
-			Int("attempt", c.reconnectAttempts).
-			Int("max_attempts", c.maxReconnectAttempts).
-			Msg("Attempting to reconnect WebSocket")
+		c.logger.Info("Attempting to reconnect WebSocket",
+			"attempt", c.reconnectAttempts,
+			"max_attempts", maxAttempts)
 
 		// Try to reconnect
-		err := c.attemptConnection()
+		err := c.attemptConnection(ctx)
 		if err == nil {
-			c.logger.Info().
-				Int("attempts_used", c.reconnectAttempts).
-				Msg("WebSocket reconnection successful")
+			c.logger.Info("WebSocket reconnection successful", "attempts_used", c.reconnectAttempts)
 
 			// Reset attempts counter on success
+			attempts := c.reconnectAttempts
 			c.reconnectAttempts = 0
+			c.reconnects.Add(1)
+			if c.onReconnect != nil {
+				c.onReconnect(attempts)
+			}
 			return nil
 		}
 
-		c.logger.Warn().
-			Err(err).
-			Int("attempt", c.reconnectAttempts).
-			Msg("Reconnection attempt failed")
-
-		// Exponential backoff: wait 2^attempt seconds (max 30 seconds)
-		backoffDuration := time.Duration(1<<uint(c.reconnectAttempts)) * time.Second
-		if backoffDuration > 30*time.Second {
-			backoffDuration = 30 * time.Second
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		c.logger.Warn("Reconnection attempt failed", "error", err, "attempt", c.reconnectAttempts)
+		if maxAttempts > 0 && c.reconnectAttempts >= maxAttempts {
+			continue // Give up without waiting
 		}
 
-		c.logger.Debug().
-			Dur("backoff", backoffDuration).
-			Msg("Waiting before next reconnection attempt")
+		backoffDuration := c.reconnectPolicy.Delay(c.reconnectAttempts)
+		c.logger.Debug("Waiting before next reconnection attempt", "backoff", backoffDuration)
 
-		time.Sleep(backoffDuration)
+		if !c.sleep(ctx, backoffDuration) {
+			return ctx.Err()
+		}
 	}
 }
 
-// attemptConnection tries to establish a new WebSocket connection
-func (c *BaseWsClient) attemptConnection() error {
-	c.logger.Debug().Str("url", c.url).Msg("Attempting WebSocket connection")
+// sleep waits for d and reports whether ctx is still live.
+func (c *BaseWsClient) sleep(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-c.clock.After(d):
+		return true
+	}
+}
 
-	var err error
-	c.webSocketClient, _, err = websocket.DefaultDialer.Dial(c.url, nil)
+// conn returns the current connection, or nil.
+func (c *BaseWsClient) conn() *websocket.Conn {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	return c.webSocketClient
+}
+
+func (c *BaseWsClient) setConn(conn *websocket.Conn) {
+	c.connMu.Lock()
+	c.webSocketClient = conn
+	c.connMu.Unlock()
+}
+
+// attemptConnection tries to establish a new WebSocket connection. A connection dialed
+// after ctx was cancelled is closed again.
+func (c *BaseWsClient) attemptConnection(ctx context.Context) error {
+	c.logger.Debug("Attempting WebSocket connection", "url", c.url)
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, c.url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to dial WebSocket: %w", err)
 	}
+	c.connMu.Lock()
+	if ctx.Err() != nil {
+		c.connMu.Unlock()
+		conn.Close()
+		return ctx.Err()
+	}
+	c.webSocketClient = conn
+	c.connMu.Unlock()
 
 	c.connected = true
-	c.connectionStartTime = time.Now()
-	c.lastReceivedTime = time.Now()
+	c.connectionStartTime = c.clock.Now()
+	c.lastReceivedTime = c.clock.Now()
 
 	// Re-authenticate if needed
 	if c.needLogin && c.storedLoginCreds != nil {
-		c.logger.Info().Msg("Re-authenticating after reconnection")
+		c.logger.Info("Re-authenticating after reconnection")
 		c.performLogin()
 
 		// Wait a bit for authentication to complete
-		time.Sleep(1 * time.Second)
+		if !c.sleep(ctx, 1*time.Second) {
+			return ctx.Err()
+		}
 	}
 
 	// Restore subscriptions
 	if len(c.subscriptions) > 0 {
-		c.logger.Info().Int("subscription_count", len(c.subscriptions)).Msg("Restoring subscriptions after reconnection")
+		c.logger.Info("Restoring subscriptions after reconnection", "subscription_count", len(c.subscriptions))
 		c.restoreSubscriptions()
 	}
 
@@ -408,42 +537,78 @@ func (c *BaseWsClient) attemptConnection() error {
 func (c *BaseWsClient) disconnectWebSocket() {
 	defer func() {
 		if r := recover(); r != nil {
-			c.logger.Error().Interface("panic", r).Msg("Panic recovered during WebSocket disconnection")
+			c.logger.Error("Panic recovered during WebSocket disconnection", "panic", r)
 		}
 		// Always ensure these are set regardless of panic
 		c.connected = false
-		c.webSocketClient = nil
+		c.setConn(nil)
 	}()
 
-	if c.webSocketClient == nil {
+	conn := c.conn()
+	if conn == nil {
 		return
 	}
 
-	c.logger.Debug().Msg("WebSocket disconnecting...")
+	c.logger.Debug("WebSocket disconnecting...")
 	c.connected = false
 
-	err := c.webSocketClient.Close()
+	err := conn.Close()
 	if err != nil {
-		c.logger.Warn().Err(err).Msg("WebSocket disconnect error")
+		c.logger.Warn("WebSocket disconnect error", "error", err)
 	} else {
-		c.logger.Debug().Msg("WebSocket disconnected successfully")
+		c.logger.Debug("WebSocket disconnected successfully")
 	}
-
-	c.webSocketClient = nil
 }
 
 func (c *BaseWsClient) ReadLoop() {
+	c.ReadLoopContext(context.Background())
+}
+
+// ReadLoopContext is like ReadLoop, but returns when ctx is cancelled. Cancelling ctx
+// closes the connection, and no reconnection is attempted.
+func (c *BaseWsClient) ReadLoopContext(ctx context.Context) {
+	// Closing the connection unblocks ReadMessage; the loop then cleans up
+	closed := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		if conn := c.conn(); conn != nil {
+			conn.Close()
+		}
+		close(closed)
+	})
+	defer stop()
+	shutdown := func() {
+		if !stop() {
+			<-closed
+		}
+		c.disconnectWebSocket()
+		c.logger.Info("WebSocket read loop stopped")
+	}
+
 	for {
+		if ctx.Err() != nil {
+			shutdown()
+			return
+		}
 
-		if c.webSocketClient == nil {
-			c.logger.Error().Msg("error on message read: no connection available")
-			time.Sleep(100 * time.Millisecond)
+		conn := c.conn()
+		if conn == nil {
+			c.logger.Error("error on message read: no connection available")
+			select {
+			case <-ctx.Done():
+				shutdown()
+				return
+			case <-c.clock.After(100 * time.Millisecond):
+			}
 			continue
 		}
 
-		_, buf, err := c.webSocketClient.ReadMessage()
+		_, buf, err := conn.ReadMessage()
 		if err != nil {
-			c.logger.Warn().Err(err).Str("msg", string(buf)).Msg("error on message read")
+			if ctx.Err() != nil {
+				shutdown()
+				return
+			}
+			c.logger.Warn("error on message read", "error", err, "msg", string(buf))
 
 			// Handle different types of connection errors
 			if websocket.IsCloseError(err,
@@ -456,28 +621,31 @@ func (c *BaseWsClient) ReadLoop() {
 				websocket.CloseInternalServerErr,
 				websocket.CloseServiceRestart,
 			) {
-				c.logger.Info().Msg("WebSocket closed, attempting reconnection")
+				c.logger.Info("WebSocket closed, attempting reconnection")
 
 				// Use improved reconnection logic
-				if err := c.performReconnection(); err != nil {
-					c.logger.Error().Err(err).Msg("Failed to reconnect after close error")
+				if err := c.performReconnection(ctx, err); err != nil {
+					c.logger.Error("Failed to reconnect after close error", "error", err)
 				}
 			}
 			continue
 		}
-		c.lastReceivedTime = time.Now()
+		c.lastReceivedTime = c.clock.Now()
 		message := string(buf)
 
 		if message == "pong" {
-			c.logger.Debug().Str("message", message).Msg("keep connected")
+			c.logger.Debug("keep connected", "message", message)
 			continue
 		}
-		c.logger.Debug().Str("message", message).Msg("read message from websocket")
+		if c.rawTap != nil {
+			c.rawTap(buf)
+		}
+		c.logger.Debug("read message from websocket", "message", message)
 
 		jsonMap := make(map[string]interface{})
 		err = jsoniter.Unmarshal(buf, &jsonMap)
 		if err != nil {
-			c.logger.Warn().Err(err).Msg("error on umarshalling message")
+			c.logger.Warn("error on umarshalling message", "error", err)
 			continue
 		}
 
@@ -493,7 +661,7 @@ func (c *BaseWsClient) ReadLoop() {
 
 		v, e = jsonMap["event"]
 		if e && v == "login" {
-			c.logger.Debug().Str("message", message).Msg("login")
+			c.logger.Debug("login", "message", message)
 			c.loginStatus = true
 			continue
 		}
@@ -528,7 +696,13 @@ func (c *BaseWsClient) GetListener(argJson interface{}) OnReceive {
 
 // IsConnected returns true if the WebSocket connection is established and active
 func (c *BaseWsClient) IsConnected() bool {
-	return c.connected && c.webSocketClient != nil
+	return c.connected && c.conn() != nil
+}
+
+// Reconnects returns the number of successful reconnections since the client was
+// created.
+func (c *BaseWsClient) Reconnects() int64 {
+	return c.reconnects.Load()
 }
 
 // IsLoggedIn returns true if the WebSocket is authenticated (for private channels)
@@ -543,7 +717,7 @@ func (c *BaseWsClient) GetSubscriptionCount() int {
 
 // restoreSubscriptions resubscribes to all previously active subscriptions after reconnection
 func (c *BaseWsClient) restoreSubscriptions() {
-	c.logger.Info().Msg("Starting subscription restoration after reconnection")
+	c.logger.Info("Starting subscription restoration after reconnection")
 
 	// Create a copy of current subscriptions to avoid map iteration issues
 	var subscriptionsToRestore []SubscriptionArgs
@@ -552,47 +726,45 @@ func (c *BaseWsClient) restoreSubscriptions() {
 	}
 
 	// Wait a bit for the connection to stabilize
-	time.Sleep(500 * time.Millisecond)
+	c.clock.Sleep(500 * time.Millisecond)
 
 	// Re-authenticate if this is a private WebSocket
 	if c.needLogin && c.storedLoginCreds != nil {
-		c.logger.Info().Msg("Re-authenticating private WebSocket after reconnection")
+		c.logger.Info("Re-authenticating private WebSocket after reconnection")
 		c.performLogin()
 
 		// Wait for authentication to complete
-		time.Sleep(500 * time.Millisecond)
+		c.clock.Sleep(500 * time.Millisecond)
 	}
 
 	// Restore each subscription
 	restoredCount := 0
 	for _, args := range subscriptionsToRestore {
-		c.logger.Debug().
-			Str("channel", args.Channel).
-			Str("symbol", args.Symbol).
-			Str("coin", args.Coin).
-			Str("productType", args.ProductType).
-			Msg("Restoring subscription")
+		c.logger.Debug("Restoring subscription",
+			"channel", args.Channel,
+			"symbol", args.Symbol,
+			"coin", args.Coin,
+			"productType", args.ProductType)
 
 		// Use subscribe method to restore the subscription
 		c.subscribe(args)
 		restoredCount++
 
 		// Small delay between subscriptions to avoid rate limiting
-		time.Sleep(100 * time.Millisecond)
+		c.clock.Sleep(100 * time.Millisecond)
 	}
 
-	c.logger.Info().
-		Int("restored_subscriptions", restoredCount).
-		Int("total_subscriptions", len(c.subscriptions)).
-		Msg("Subscription restoration completed")
+	c.logger.Info("Subscription restoration completed",
+		"restored_subscriptions", restoredCount,
+		"total_subscriptions", len(c.subscriptions))
 }
 
 func (c *BaseWsClient) Close() {
-	if c.connected {
+	if conn := c.conn(); c.connected && conn != nil {
 		cm := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "close")
 
-		if err := c.webSocketClient.WriteMessage(websocket.CloseMessage, cm); err != nil {
-			c.logger.Error().Err(err).Msg("WebSocket disconnection error")
+		if err := conn.WriteMessage(websocket.CloseMessage, cm); err != nil {
+			c.logger.Error("WebSocket disconnection error", "error", err)
 		}
 		c.disconnectWebSocket()
 	}
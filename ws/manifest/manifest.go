@@ -0,0 +1,259 @@
+// Package manifest keeps WebSocket subscriptions in line with a declarative manifest,
+// a YAML or JSON file listing channels, product types and symbols:
+//
+//	subscriptions:
+//	  - channel: ticker
+//	    productType: USDT-FUTURES
+//	    symbols: [BTCUSDT, ETHUSDT]
+//	  - channel: candle1m
+//	    productType: USDT-FUTURES
+//	    symbols: [BTCUSDT]
+//	  - channel: orders
+//	    productType: USDT-FUTURES
+//
+// A Syncer subscribes to what the manifest lists and, on reload, subscribes to added and
+// unsubscribes from removed entries only:
+//
+//	syncer := manifest.NewSyncer(wsClient, func(args ws.SubscriptionArgs) ws.OnReceive {
+//		return pipeline.Handler(args.Channel)
+//	})
+//	go syncer.Watch(ctx, "subscriptions.yaml", 10*time.Second, onError)
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khanbekov/go-bitget/ws"
+	"gopkg.in/yaml.v3"
+)
+
+// Entry selects one channel for a product type and a set of symbols.
+type Entry struct {
+	// Channel is the wire channel name, e.g. "ticker", "books5", "candle1m" or "orders".
+	Channel     string `json:"channel" yaml:"channel"`
+	ProductType string `json:"productType" yaml:"productType"`
+	// Symbols are required for public channels and optional for the fill channel.
+	Symbols []string `json:"symbols,omitempty" yaml:"symbols,omitempty"`
+	// Coin selects the account channel coin, "default" for all coins.
+	Coin string `json:"coin,omitempty" yaml:"coin,omitempty"`
+}
+
+// Manifest is the desired set of subscriptions.
+type Manifest struct {
+	Subscriptions []Entry `json:"subscriptions" yaml:"subscriptions"`
+}
+
+// Parse decodes a YAML or JSON manifest.
+func Parse(data []byte) (*Manifest, error) {
+	var m Manifest
+	// JSON is a subset of YAML, so one decoder reads both
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("manifest: %w", err)
+	}
+	if _, err := m.Args(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Load reads the manifest file at path.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("manifest: %w", err)
+	}
+	return Parse(data)
+}
+
+// publicChannels need a symbol.
+var publicChannels = map[string]bool{
+	ws.ChannelTicker: true, ws.ChannelBooks: true, ws.ChannelBooks5: true, ws.ChannelBooks15: true,
+	ws.ChannelTrade: true, ws.ChannelMarkPrice: true, ws.ChannelFundingTime: true, ws.ChannelLiquidation: true,
+}
+
+// Args expands the manifest into subscription arguments, in the form used by the ws
+// Subscribe helpers, without duplicates.
+func (m *Manifest) Args() ([]ws.SubscriptionArgs, error) {
+	seen := make(map[ws.SubscriptionArgs]bool)
+	var out []ws.SubscriptionArgs
+	add := func(args ws.SubscriptionArgs) {
+		if !seen[args] {
+			seen[args] = true
+			out = append(out, args)
+		}
+	}
+	for i, e := range m.Subscriptions {
+		if e.ProductType == "" {
+			return nil, fmt.Errorf("manifest: subscription %d (%s): productType is required", i, e.Channel)
+		}
+		base := ws.SubscriptionArgs{ProductType: e.ProductType, Channel: e.Channel}
+		switch {
+		case publicChannels[e.Channel] || strings.HasPrefix(e.Channel, ws.ChannelCandle):
+			if len(e.Symbols) == 0 {
+				return nil, fmt.Errorf("manifest: subscription %d (%s): symbols are required", i, e.Channel)
+			}
+			for _, symbol := range e.Symbols {
+				args := base
+				args.Symbol = symbol
+				add(args)
+			}
+		case e.Channel == ws.ChannelFill && len(e.Symbols) > 0:
+			for _, symbol := range e.Symbols {
+				args := base
+				args.Symbol = symbol
+				add(args)
+			}
+		case e.Channel == ws.ChannelOrders, e.Channel == ws.ChannelPlanOrder, e.Channel == ws.ChannelFill:
+			add(base)
+		case e.Channel == ws.ChannelPositions:
+			base.Symbol = "default"
+			add(base)
+		case e.Channel == ws.ChannelAccount:
+			base.Coin = e.Coin
+			if base.Coin == "" {
+				base.Coin = "default"
+			}
+			add(base)
+		default:
+			return nil, fmt.Errorf("manifest: subscription %d: unknown channel %q", i, e.Channel)
+		}
+	}
+	return out, nil
+}
+
+// Subscriber is implemented by *ws.BaseWsClient.
+type Subscriber interface {
+	SubscribeArgs(args ws.SubscriptionArgs, handler ws.OnReceive)
+	UnsubscribeArgs(args ws.SubscriptionArgs)
+}
+
+// Diff lists the subscriptions changed by Apply.
+type Diff struct {
+	Added   []ws.SubscriptionArgs
+	Removed []ws.SubscriptionArgs
+}
+
+// Empty reports whether nothing changed.
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// Syncer applies manifests to a Subscriber. It only tracks subscriptions it made itself,
+// so subscriptions made directly on the client are left alone. It is safe for concurrent
+// use.
+type Syncer struct {
+	client  Subscriber
+	handler func(ws.SubscriptionArgs) ws.OnReceive
+
+	mu     sync.Mutex
+	active map[ws.SubscriptionArgs]bool
+}
+
+// NewSyncer creates a syncer; handler returns the message handler for a subscription.
+func NewSyncer(client Subscriber, handler func(ws.SubscriptionArgs) ws.OnReceive) *Syncer {
+	return &Syncer{client: client, handler: handler, active: make(map[ws.SubscriptionArgs]bool)}
+}
+
+// Apply subscribes to the entries of m not yet active and unsubscribes from active ones
+// m no longer lists. An invalid manifest changes nothing.
+func (s *Syncer) Apply(m *Manifest) (Diff, error) {
+	want, err := m.Args()
+	if err != nil {
+		return Diff{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var diff Diff
+	keep := make(map[ws.SubscriptionArgs]bool, len(want))
+	for _, args := range want {
+		keep[args] = true
+		if !s.active[args] {
+			diff.Added = append(diff.Added, args)
+		}
+	}
+	for args := range s.active {
+		if !keep[args] {
+			diff.Removed = append(diff.Removed, args)
+		}
+	}
+	// Map iteration order is random; keep unsubscribes reproducible
+	sort.Slice(diff.Removed, func(i, j int) bool { return argsLess(diff.Removed[i], diff.Removed[j]) })
+
+	for _, args := range diff.Removed {
+		s.client.UnsubscribeArgs(args)
+		delete(s.active, args)
+	}
+	for _, args := range diff.Added {
+		s.client.SubscribeArgs(args, s.handler(args))
+		s.active[args] = true
+	}
+	return diff, nil
+}
+
+// Active returns the subscriptions made by the syncer.
+func (s *Syncer) Active() []ws.SubscriptionArgs {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]ws.SubscriptionArgs, 0, len(s.active))
+	for args := range s.active {
+		out = append(out, args)
+	}
+	sort.Slice(out, func(i, j int) bool { return argsLess(out[i], out[j]) })
+	return out
+}
+
+// Watch applies the manifest file at path, then reloads it every interval when its
+// modification time changes, until ctx is cancelled. An invalid manifest leaves the
+// current subscriptions in place and is reported to onError.
+func (s *Syncer) Watch(ctx context.Context, path string, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var applied time.Time
+	for {
+		if info, err := os.Stat(path); err != nil {
+			report(onError, fmt.Errorf("manifest: %w", err))
+		} else if !info.ModTime().Equal(applied) {
+			// A broken edit is reported once, not on every tick
+			applied = info.ModTime()
+			m, err := Load(path)
+			if err == nil {
+				_, err = s.Apply(m)
+			}
+			if err != nil {
+				report(onError, err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func report(onError func(error), err error) {
+	if onError != nil {
+		onError(err)
+	}
+}
+
+func argsLess(a, b ws.SubscriptionArgs) bool {
+	if a.ProductType != b.ProductType {
+		return a.ProductType < b.ProductType
+	}
+	if a.Channel != b.Channel {
+		return a.Channel < b.Channel
+	}
+	if a.Symbol != b.Symbol {
+		return a.Symbol < b.Symbol
+	}
+	return a.Coin < b.Coin
+}
@@ -0,0 +1,132 @@
+package manifest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khanbekov/go-bitget/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSubscriber struct {
+	mu     sync.Mutex
+	subs   map[ws.SubscriptionArgs]ws.OnReceive
+	events []string
+}
+
+func (f *fakeSubscriber) SubscribeArgs(args ws.SubscriptionArgs, handler ws.OnReceive) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subs[args] = handler
+	f.events = append(f.events, "+"+args.Channel+":"+args.Symbol)
+}
+
+func (f *fakeSubscriber) UnsubscribeArgs(args ws.SubscriptionArgs) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.subs, args)
+	f.events = append(f.events, "-"+args.Channel+":"+args.Symbol)
+}
+
+const yamlManifest = `
+subscriptions:
+  - channel: ticker
+    productType: USDT-FUTURES
+    symbols: [BTCUSDT, ETHUSDT]
+  - channel: candle1m
+    productType: USDT-FUTURES
+    symbols: [BTCUSDT]
+  - channel: positions
+    productType: USDT-FUTURES
+  - channel: account
+    productType: USDT-FUTURES
+`
+
+func TestParse(t *testing.T) {
+	m, err := Parse([]byte(yamlManifest))
+	require.NoError(t, err)
+	args, err := m.Args()
+	require.NoError(t, err)
+	assert.Equal(t, []ws.SubscriptionArgs{
+		{ProductType: "USDT-FUTURES", Channel: "ticker", Symbol: "BTCUSDT"},
+		{ProductType: "USDT-FUTURES", Channel: "ticker", Symbol: "ETHUSDT"},
+		{ProductType: "USDT-FUTURES", Channel: "candle1m", Symbol: "BTCUSDT"},
+		{ProductType: "USDT-FUTURES", Channel: "positions", Symbol: "default"},
+		{ProductType: "USDT-FUTURES", Channel: "account", Coin: "default"},
+	}, args)
+
+	fromJSON, err := Parse([]byte(`{"subscriptions":[{"channel":"books5","productType":"USDT-FUTURES","symbols":["BTCUSDT"]}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, "books5", fromJSON.Subscriptions[0].Channel)
+
+	_, err = Parse([]byte(`{"subscriptions":[{"channel":"ticker","productType":"USDT-FUTURES"}]}`))
+	assert.ErrorContains(t, err, "symbols are required")
+	_, err = Parse([]byte(`{"subscriptions":[{"channel":"tickers","productType":"USDT-FUTURES","symbols":["BTCUSDT"]}]}`))
+	assert.ErrorContains(t, err, "unknown channel")
+}
+
+func TestSyncer_ApplyDiffs(t *testing.T) {
+	client := &fakeSubscriber{subs: make(map[ws.SubscriptionArgs]ws.OnReceive)}
+	var routed []string
+	s := NewSyncer(client, func(args ws.SubscriptionArgs) ws.OnReceive {
+		return func(string) { routed = append(routed, args.Channel) }
+	})
+
+	m, err := Parse([]byte(yamlManifest))
+	require.NoError(t, err)
+	diff, err := s.Apply(m)
+	require.NoError(t, err)
+	assert.Len(t, diff.Added, 5)
+	assert.Len(t, client.subs, 5)
+	client.subs[ws.SubscriptionArgs{ProductType: "USDT-FUTURES", Channel: "ticker", Symbol: "ETHUSDT"}]("{}")
+	assert.Equal(t, []string{"ticker"}, routed)
+
+	diff, err = s.Apply(m)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "reapplying is a no-op")
+
+	m.Subscriptions[0].Symbols = []string{"BTCUSDT", "SOLUSDT"}
+	m.Subscriptions = m.Subscriptions[:3]
+	client.events = nil
+	diff, err = s.Apply(m)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-account:", "-ticker:ETHUSDT", "+ticker:SOLUSDT"}, client.events)
+	assert.Len(t, s.Active(), 4)
+
+	_, err = s.Apply(&Manifest{Subscriptions: []Entry{{Channel: "ticker"}}})
+	assert.Error(t, err)
+	assert.Len(t, s.Active(), 4, "an invalid manifest changes nothing")
+}
+
+func TestSyncer_Watch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "subscriptions.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(yamlManifest), 0o644))
+
+	client := &fakeSubscriber{subs: make(map[ws.SubscriptionArgs]ws.OnReceive)}
+	s := NewSyncer(client, func(ws.SubscriptionArgs) ws.OnReceive { return func(string) {} })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var errs []error
+	var mu sync.Mutex
+	go s.Watch(ctx, path, 5*time.Millisecond, func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	})
+
+	require.Eventually(t, func() bool { return len(s.Active()) == 5 }, time.Second, 5*time.Millisecond)
+
+	later := time.Now().Add(time.Second)
+	require.NoError(t, os.WriteFile(path, []byte("subscriptions:\n  - channel: trade\n    productType: USDT-FUTURES\n    symbols: [BTCUSDT]\n"), 0o644))
+	require.NoError(t, os.Chtimes(path, later, later))
+	require.Eventually(t, func() bool { return len(s.Active()) == 1 }, time.Second, 5*time.Millisecond)
+
+	mu.Lock()
+	assert.Empty(t, errs)
+	mu.Unlock()
+}
@@ -0,0 +1,277 @@
+package ws
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// checksumLevels is the number of levels per side covered by the book checksum.
+const checksumLevels = 25
+
+var (
+	// ErrChecksumMismatch is passed to the resync handler when the local book does not
+	// match the checksum of an update.
+	ErrChecksumMismatch = errors.New("order book checksum mismatch")
+	// ErrNoSnapshot is passed to the resync handler when an update arrives for a book
+	// without a snapshot.
+	ErrNoSnapshot = errors.New("order book update before snapshot")
+)
+
+// localBook is the book of one symbol. Bids are sorted from highest to lowest price,
+// asks from lowest to highest.
+type localBook struct {
+	bids []OrderBookLevel
+	asks []OrderBookLevel
+	seq  int64
+}
+
+// OrderBookManager maintains local order books from the books, books5 and books15
+// channels. Snapshots replace a book and updates are merged into it; when a push carries
+// a checksum, the merged book is validated against it. A book that fails validation, or
+// receives an update before its snapshot, is dropped and resubscribed, which makes the
+// server send a fresh snapshot.
+//
+// Example:
+//
+//	books := ws.NewOrderBookManager(client)
+//	books.Subscribe("BTCUSDT", "USDT-FUTURES", ws.ChannelBooks)
+//
+//	if bid, ok := books.BestBid("BTCUSDT"); ok {
+//	    fmt.Println("Best bid:", bid.PriceFloat)
+//	}
+//	bids, asks := books.Depth("BTCUSDT", 10)
+//
+// Books are keyed by symbol, so a manager follows one book channel per symbol. It is
+// safe for concurrent use.
+type OrderBookManager struct {
+	client *BaseWsClient
+
+	mu        sync.RWMutex
+	books     map[string]*localBook
+	resyncing map[string]bool
+	onResync  func(symbol string, reason error)
+}
+
+// NewOrderBookManager creates a manager that subscribes through client. client may be
+// nil when messages are fed to HandleMessage directly; books are then dropped on a
+// checksum mismatch but not resubscribed.
+func NewOrderBookManager(client *BaseWsClient) *OrderBookManager {
+	return &OrderBookManager{
+		client:    client,
+		books:     make(map[string]*localBook),
+		resyncing: make(map[string]bool),
+	}
+}
+
+// SetResyncHandler sets a callback invoked whenever a book is dropped for resync, with
+// ErrChecksumMismatch or ErrNoSnapshot as the reason.
+func (m *OrderBookManager) SetResyncHandler(handler func(symbol string, reason error)) {
+	m.mu.Lock()
+	m.onResync = handler
+	m.mu.Unlock()
+}
+
+// Subscribe subscribes to channel (ChannelBooks, ChannelBooks5 or ChannelBooks15) for
+// symbol and maintains its book.
+func (m *OrderBookManager) Subscribe(symbol, productType, channel string) {
+	m.client.SubscribeArgs(SubscriptionArgs{ProductType: productType, Channel: channel, Symbol: symbol}, m.HandleMessage)
+}
+
+// Unsubscribe removes the subscription of symbol and drops its book.
+func (m *OrderBookManager) Unsubscribe(symbol, productType, channel string) {
+	m.client.UnsubscribeArgs(SubscriptionArgs{ProductType: productType, Channel: channel, Symbol: symbol})
+	m.mu.Lock()
+	delete(m.books, symbol)
+	m.mu.Unlock()
+}
+
+// HandleMessage applies an order book push. It is an OnReceive, so it can also be
+// passed to SubscribeOrderBook and the other raw helpers.
+func (m *OrderBookManager) HandleMessage(message string) {
+	var msg struct {
+		Action string           `json:"action"`
+		Arg    SubscriptionArgs `json:"arg"`
+		Data   []OrderBookData  `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(message), &msg); err != nil {
+		if m.client != nil {
+			m.client.decodeFailed(message, msg.Arg, err)
+		}
+		return
+	}
+	symbol := msg.Arg.Symbol
+	if symbol == "" {
+		return
+	}
+
+	for _, data := range msg.Data {
+		if err := m.apply(msg.Action, msg.Arg, data); err != nil {
+			m.resync(msg.Arg, err)
+			return
+		}
+	}
+}
+
+// apply merges data into the book of args.Symbol and validates the checksum.
+func (m *OrderBookManager) apply(action string, args SubscriptionArgs, data OrderBookData) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	book, ok := m.books[args.Symbol]
+	switch {
+	case action != ActionUpdate:
+		book = &localBook{bids: sortLevels(data.Bids, true), asks: sortLevels(data.Asks, false)}
+		m.books[args.Symbol] = book
+		delete(m.resyncing, args.Symbol)
+	case !ok && m.resyncing[args.Symbol]:
+		return nil // Left over from the previous subscription
+	case !ok:
+		return ErrNoSnapshot
+	case data.Seq != 0 && data.Seq <= book.seq:
+		return nil // Stale or duplicate update
+	default:
+		book.bids = mergeLevels(book.bids, data.Bids, true)
+		book.asks = mergeLevels(book.asks, data.Asks, false)
+	}
+	book.seq = data.Seq
+
+	if data.Checksum != 0 && bookChecksum(book.bids, book.asks) != int32(data.Checksum) {
+		return fmt.Errorf("%w for %s at seq %d", ErrChecksumMismatch, args.Symbol, data.Seq)
+	}
+	return nil
+}
+
+// resync drops the book of args.Symbol and resubscribes to receive a new snapshot.
+// Updates are ignored until the snapshot arrives.
+func (m *OrderBookManager) resync(args SubscriptionArgs, reason error) {
+	m.mu.Lock()
+	delete(m.books, args.Symbol)
+	m.resyncing[args.Symbol] = true
+	handler := m.onResync
+	m.mu.Unlock()
+
+	if m.client != nil {
+		m.client.logger.Warn("resyncing order book", "channel", args.Channel, "symbol", args.Symbol, "error", reason)
+		m.client.UnsubscribeArgs(args)
+		m.client.SubscribeArgs(args, m.HandleMessage)
+	}
+	if handler != nil {
+		handler(args.Symbol, reason)
+	}
+}
+
+// Synced reports whether the book of symbol holds a validated snapshot.
+func (m *OrderBookManager) Synced(symbol string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.books[symbol]
+	return ok
+}
+
+// BestBid returns the highest bid of symbol.
+func (m *OrderBookManager) BestBid(symbol string) (OrderBookLevel, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	book, ok := m.books[symbol]
+	if !ok || len(book.bids) == 0 {
+		return OrderBookLevel{}, false
+	}
+	return book.bids[0], true
+}
+
+// BestAsk returns the lowest ask of symbol.
+func (m *OrderBookManager) BestAsk(symbol string) (OrderBookLevel, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	book, ok := m.books[symbol]
+	if !ok || len(book.asks) == 0 {
+		return OrderBookLevel{}, false
+	}
+	return book.asks[0], true
+}
+
+// Depth returns copies of the best n bids and asks of symbol; all levels when n <= 0.
+func (m *OrderBookManager) Depth(symbol string, n int) (bids, asks []OrderBookLevel) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	book, ok := m.books[symbol]
+	if !ok {
+		return nil, nil
+	}
+	return topLevels(book.bids, n), topLevels(book.asks, n)
+}
+
+func topLevels(levels []OrderBookLevel, n int) []OrderBookLevel {
+	if n <= 0 || n > len(levels) {
+		n = len(levels)
+	}
+	return append([]OrderBookLevel(nil), levels[:n]...)
+}
+
+// sortLevels returns the non-empty levels sorted best first.
+func sortLevels(levels []OrderBookLevel, desc bool) []OrderBookLevel {
+	out := make([]OrderBookLevel, 0, len(levels))
+	for _, l := range levels {
+		if l.AmountFloat > 0 {
+			out = append(out, l)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if desc {
+			return out[i].PriceFloat > out[j].PriceFloat
+		}
+		return out[i].PriceFloat < out[j].PriceFloat
+	})
+	return out
+}
+
+// mergeLevels applies changed levels to a sorted side. A zero amount removes the level.
+func mergeLevels(levels, changes []OrderBookLevel, desc bool) []OrderBookLevel {
+	for _, c := range changes {
+		i := sort.Search(len(levels), func(i int) bool {
+			if desc {
+				return levels[i].PriceFloat <= c.PriceFloat
+			}
+			return levels[i].PriceFloat >= c.PriceFloat
+		})
+		exists := i < len(levels) && levels[i].PriceFloat == c.PriceFloat
+		switch {
+		case c.AmountFloat == 0 && exists:
+			levels = append(levels[:i], levels[i+1:]...)
+		case c.AmountFloat == 0:
+		case exists:
+			levels[i] = c
+		default:
+			levels = append(levels, OrderBookLevel{})
+			copy(levels[i+1:], levels[i:])
+			levels[i] = c
+		}
+	}
+	return levels
+}
+
+// bookChecksum computes the Bitget book checksum: the signed CRC32 of the first 25 bids
+// and asks interleaved as "bidPrice:bidAmount:askPrice:askAmount:...", using the price
+// and amount strings as pushed.
+func bookChecksum(bids, asks []OrderBookLevel) int32 {
+	var b strings.Builder
+	for i := 0; i < checksumLevels; i++ {
+		for _, side := range [][]OrderBookLevel{bids, asks} {
+			if i >= len(side) {
+				continue
+			}
+			if b.Len() > 0 {
+				b.WriteByte(':')
+			}
+			b.WriteString(side[i].Price)
+			b.WriteByte(':')
+			b.WriteString(side[i].Amount)
+		}
+	}
+	return int32(crc32.ChecksumIEEE([]byte(b.String())))
+}
@@ -0,0 +1,78 @@
+package ws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bookMessage(action, bids, asks string, checksum int64, seq int) string {
+	return fmt.Sprintf(`{"action":%q,"arg":{"instType":"USDT-FUTURES","channel":"books","instId":"BTCUSDT"},"data":[{"asks":%s,"bids":%s,"checksum":%d,"seq":%d,"ts":"1700000000000"}]}`,
+		action, asks, bids, checksum, seq)
+}
+
+func TestOrderBookManager_AppliesUpdates(t *testing.T) {
+	m := NewOrderBookManager(nil)
+	m.HandleMessage(bookMessage(ActionSnapshot, `[["50000","1"]]`, `[["50001","2"]]`, -1921676086, 1))
+	m.HandleMessage(bookMessage(ActionUpdate, `[["49999","3"]]`, `[]`, 686717052, 2))
+	m.HandleMessage(bookMessage(ActionUpdate, `[]`, `[["50001","0"],["50002","1"]]`, -2004802888, 3))
+	require.True(t, m.Synced("BTCUSDT"))
+
+	bid, ok := m.BestBid("BTCUSDT")
+	require.True(t, ok)
+	assert.Equal(t, 50000.0, bid.PriceFloat)
+	ask, ok := m.BestAsk("BTCUSDT")
+	require.True(t, ok)
+	assert.Equal(t, 50002.0, ask.PriceFloat, "the emptied level is removed")
+
+	bids, asks := m.Depth("BTCUSDT", 5)
+	require.Len(t, bids, 2)
+	assert.Equal(t, 49999.0, bids[1].PriceFloat)
+	assert.Len(t, asks, 1)
+	bids, _ = m.Depth("BTCUSDT", 1)
+	assert.Len(t, bids, 1)
+
+	m.HandleMessage(bookMessage(ActionUpdate, `[["49998","1"]]`, `[]`, 0, 3))
+	bids, _ = m.Depth("BTCUSDT", 0)
+	assert.Len(t, bids, 2, "a stale update is ignored")
+}
+
+func TestOrderBookManager_ResyncsOnChecksumMismatch(t *testing.T) {
+	m := NewOrderBookManager(nil)
+	var reasons []error
+	m.SetResyncHandler(func(symbol string, reason error) { reasons = append(reasons, reason) })
+
+	m.HandleMessage(bookMessage(ActionSnapshot, `[["50000","1"]]`, `[["50001","2"]]`, -1921676086, 1))
+	m.HandleMessage(bookMessage(ActionUpdate, `[["49999","3"]]`, `[]`, 12345, 2))
+	require.Len(t, reasons, 1)
+	assert.ErrorIs(t, reasons[0], ErrChecksumMismatch)
+	assert.False(t, m.Synced("BTCUSDT"))
+	_, ok := m.BestBid("BTCUSDT")
+	assert.False(t, ok)
+
+	m.HandleMessage(bookMessage(ActionUpdate, `[["49998","1"]]`, `[]`, 0, 3))
+	assert.Len(t, reasons, 1, "updates before the new snapshot are ignored")
+
+	m.HandleMessage(bookMessage(ActionSnapshot, `[["50000","1"]]`, `[["50001","2"]]`, -1921676086, 4))
+	assert.True(t, m.Synced("BTCUSDT"))
+}
+
+func TestOrderBookManager_UpdateBeforeSnapshot(t *testing.T) {
+	m := NewOrderBookManager(nil)
+	var reasons []error
+	m.SetResyncHandler(func(symbol string, reason error) { reasons = append(reasons, reason) })
+
+	m.HandleMessage(bookMessage(ActionUpdate, `[["49999","3"]]`, `[]`, 0, 2))
+	require.Len(t, reasons, 1)
+	assert.ErrorIs(t, reasons[0], ErrNoSnapshot)
+	assert.False(t, m.Synced("BTCUSDT"))
+}
+
+func TestBookChecksum(t *testing.T) {
+	level := func(price, amount string) OrderBookLevel { return OrderBookLevel{Price: price, Amount: amount} }
+	assert.Equal(t, int32(686717052), bookChecksum(
+		[]OrderBookLevel{level("50000", "1"), level("49999", "3")},
+		[]OrderBookLevel{level("50001", "2")}))
+}
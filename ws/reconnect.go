@@ -0,0 +1,83 @@
+package ws
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Reasons passed to the OnDisconnect callback besides read errors.
+var (
+	ErrReadTimeout       = errors.New("no message within the reconnection timeout")
+	ErrConnectionExpired = errors.New("24-hour connection limit reached")
+	ErrManualReconnect   = errors.New("reconnection requested")
+)
+
+// ReconnectPolicy controls the delays between reconnection attempts. The delay after
+// the n-th failed attempt is InitialDelay * Multiplier^(n-1), capped at MaxDelay and
+// spread by Jitter so that many clients dropped together do not reconnect in lockstep.
+type ReconnectPolicy struct {
+	// InitialDelay is the delay after the first failed attempt.
+	InitialDelay time.Duration
+	// MaxDelay caps the delay.
+	MaxDelay time.Duration
+	// Multiplier grows the delay after each failed attempt. Values below 1 are treated
+	// as 1.
+	Multiplier float64
+	// Jitter randomizes each delay by up to this fraction in either direction, e.g. 0.2
+	// for ±20%. Zero disables it.
+	Jitter float64
+	// MaxAttempts is the number of attempts before giving up. Zero retries forever.
+	MaxAttempts int
+}
+
+// DefaultReconnectPolicy returns the policy of new clients: 2s doubling up to 30s with
+// ±20% jitter, giving up after 5 attempts.
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		InitialDelay: 2 * time.Second,
+		MaxDelay:     30 * time.Second,
+		Multiplier:   2,
+		Jitter:       0.2,
+		MaxAttempts:  5,
+	}
+}
+
+// Delay returns the delay after the given failed attempt, counted from 1.
+func (p ReconnectPolicy) Delay(attempt int) time.Duration {
+	return p.delay(attempt, rand.Float64())
+}
+
+// delay computes Delay with r, uniform in [0, 1), as the source of jitter.
+func (p ReconnectPolicy) delay(attempt int, r float64) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := float64(p.InitialDelay) * math.Pow(math.Max(p.Multiplier, 1), float64(attempt-1))
+	if p.MaxDelay > 0 {
+		d = math.Min(d, float64(p.MaxDelay))
+	}
+	if p.Jitter > 0 {
+		d *= 1 + p.Jitter*(2*r-1)
+	}
+	return time.Duration(d)
+}
+
+// SetReconnectPolicy replaces the reconnection policy, see DefaultReconnectPolicy.
+func (c *BaseWsClient) SetReconnectPolicy(policy ReconnectPolicy) {
+	c.reconnectPolicy = policy
+}
+
+// OnDisconnect sets a callback invoked when the connection is dropped for reconnection,
+// with the reason: the read error, ErrReadTimeout, ErrConnectionExpired or
+// ErrManualReconnect.
+func (c *BaseWsClient) OnDisconnect(handler func(reason error)) {
+	c.onDisconnect = handler
+}
+
+// OnReconnect sets a callback invoked after a successful reconnection with the number of
+// attempts it took.
+func (c *BaseWsClient) OnReconnect(handler func(attempts int)) {
+	c.onReconnect = handler
+}
@@ -1,10 +1,19 @@
 package ws
 
 import (
-	"github.com/khanbekov/go-bitget/common"
-	"github.com/rs/zerolog"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/khanbekov/go-bitget/common"
+	"github.com/rs/zerolog"
 )
 
 // TestReconnectFunctionality tests the manual reconnection feature
@@ -101,3 +110,142 @@ func TestConnectionStateManagement(t *testing.T) {
 		t.Error("Login status should be false after failed reconnection")
 	}
 }
+
+// TestReconnectPolicyDelay tests backoff growth, the cap and jitter bounds
+func TestReconnectPolicyDelay(t *testing.T) {
+	policy := DefaultReconnectPolicy()
+
+	expected := []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second, 30 * time.Second}
+	for i, want := range expected {
+		if got := policy.delay(i+1, 0.5); got != want {
+			t.Errorf("attempt %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+
+	if got := policy.delay(1, 0); got != 1600*time.Millisecond {
+		t.Errorf("Expected the lowest jitter to give 1.6s, got %v", got)
+	}
+	if got := policy.delay(5, 0.999999); got > 36*time.Second || got < 35*time.Second {
+		t.Errorf("Expected the highest jitter to give about 36s, got %v", got)
+	}
+	for i := 0; i < 100; i++ {
+		if got := policy.Delay(1); got < 1600*time.Millisecond || got > 2400*time.Millisecond {
+			t.Fatalf("Delay %v outside the jitter range", got)
+		}
+	}
+
+	constant := ReconnectPolicy{InitialDelay: time.Second}
+	if got := constant.delay(4, 0.5); got != time.Second {
+		t.Errorf("Expected a constant delay without a multiplier, got %v", got)
+	}
+}
+
+// TestReconnectCallbacks tests the disconnect and reconnect callbacks
+func TestReconnectCallbacks(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err == nil {
+			defer conn.Close()
+			conn.ReadMessage()
+		}
+	}))
+	defer server.Close()
+
+	client := NewBitgetBaseWsClient(zerolog.Nop(), "ws"+strings.TrimPrefix(server.URL, "http"), "")
+	var reasons []error
+	var reconnected []int
+	client.OnDisconnect(func(reason error) { reasons = append(reasons, reason) })
+	client.OnReconnect(func(attempts int) { reconnected = append(reconnected, attempts) })
+
+	if err := client.Reconnect(); err != nil {
+		t.Fatalf("Expected reconnection to succeed, got %v", err)
+	}
+	if len(reasons) != 1 || !errors.Is(reasons[0], ErrManualReconnect) {
+		t.Errorf("Expected one manual disconnect, got %v", reasons)
+	}
+	if len(reconnected) != 1 || reconnected[0] != 1 {
+		t.Errorf("Expected a reconnection after one attempt, got %v", reconnected)
+	}
+	client.Close()
+
+	// A failing endpoint gives up after MaxAttempts without the reconnect callback
+	client.url = "ws://127.0.0.1:1"
+	client.SetReconnectPolicy(ReconnectPolicy{InitialDelay: time.Millisecond, MaxAttempts: 2})
+	if err := client.Reconnect(); err == nil || err.Error() != "maximum reconnection attempts (2) exceeded" {
+		t.Errorf("Expected reconnection to give up, got %v", err)
+	}
+	if len(reasons) != 2 || len(reconnected) != 1 {
+		t.Errorf("Expected a second disconnect and no reconnection, got %v and %v", reasons, reconnected)
+	}
+}
+
+// TestContextLifecycle tests that cancelling the context stops the client goroutines
+func TestContextLifecycle(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err == nil {
+			defer conn.Close()
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	defer server.Close()
+
+	baseline := runtime.NumGoroutine()
+	client := NewBitgetBaseWsClient(zerolog.Nop(), "ws"+strings.TrimPrefix(server.URL, "http"), "")
+	ctx, cancel := context.WithCancel(context.Background())
+	client.ConnectContext(ctx)
+	client.ConnectWebSocket()
+	if !client.IsConnected() {
+		t.Fatal("Expected the client to connect")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		client.ReadLoopContext(ctx)
+		close(done)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Read loop did not stop after cancellation")
+	}
+	if client.IsConnected() {
+		t.Error("Expected the connection to be closed")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > baseline {
+		t.Errorf("Expected client goroutines to stop, %d left over %d", n, baseline)
+	}
+}
+
+// TestReconnectStopsAfterCancel tests that a reconnection racing a cancelled context never dials
+func TestReconnectStopsAfterCancel(t *testing.T) {
+	var dials atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dials.Add(1)
+	}))
+	defer server.Close()
+
+	client := NewBitgetBaseWsClient(zerolog.Nop(), "ws"+strings.TrimPrefix(server.URL, "http"), "")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := client.performReconnection(ctx, ErrReadTimeout); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if dials.Load() != 0 || client.conn() != nil {
+		t.Error("Expected no connection after cancellation")
+	}
+}
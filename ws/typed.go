@@ -0,0 +1,152 @@
+package ws
+
+import "encoding/json"
+
+// Typed subscription helpers. Each decodes the data array of a push into the types of
+// this package, parses their numeric fields and calls the handler once per entry. The
+// subscriptions are tracked like those of the raw helpers, so they are restored after a
+// reconnection and removed by the matching Unsubscribe method. A message that cannot be
+// decoded is logged and passed to the error listener set with SetListener.
+
+// SubscribeTickerData subscribes to ticker updates for a symbol with a typed handler.
+//
+// Example:
+//
+//	client.SubscribeTickerData("BTCUSDT", "USDT-FUTURES", func(t ws.TickerData) {
+//	    fmt.Println("Last price:", t.LastPriceFloat)
+//	})
+func (c *BaseWsClient) SubscribeTickerData(symbol, productType string, handler func(TickerData)) {
+	c.SubscribeTicker(symbol, productType, typedHandler(c, func(_ string, t TickerData) error {
+		if err := t.ParseFloats(); err != nil {
+			return err
+		}
+		if err := t.ParseTimestamps(); err != nil {
+			return err
+		}
+		handler(t)
+		return nil
+	}))
+}
+
+// SubscribeCandleData subscribes to candlesticks of a timeframe for a symbol with a typed
+// handler. The last candlestick of each push is the one still forming.
+//
+// Example:
+//
+//	client.SubscribeCandleData("BTCUSDT", "USDT-FUTURES", ws.Timeframe1m, func(k ws.CandlestickData) {
+//	    fmt.Println("Close:", k.CloseFloat)
+//	})
+func (c *BaseWsClient) SubscribeCandleData(symbol, productType, timeframe string, handler func(CandlestickData)) {
+	c.SubscribeCandles(symbol, productType, timeframe, typedHandler(c, func(_ string, k CandlestickData) error {
+		handler(k) // Parsed by UnmarshalJSON
+		return nil
+	}))
+}
+
+// SubscribeOrderBookData subscribes to full order book depth for a symbol with a typed
+// handler. action is ActionSnapshot for the first push and ActionUpdate for the changes
+// that follow, which the caller merges into the snapshot.
+//
+// Example:
+//
+//	client.SubscribeOrderBookData("BTCUSDT", "USDT-FUTURES", func(action string, book ws.OrderBookData) {
+//	    bid, _ := book.BestBid()
+//	    fmt.Println(action, "best bid:", bid)
+//	})
+func (c *BaseWsClient) SubscribeOrderBookData(symbol, productType string, handler func(action string, book OrderBookData)) {
+	c.SubscribeOrderBook(symbol, productType, typedHandler(c, func(action string, book OrderBookData) error {
+		if err := book.ParseTimestamp(); err != nil {
+			return err
+		}
+		handler(action, book)
+		return nil
+	}))
+}
+
+// SubscribeTradeData subscribes to public trades for a symbol with a typed handler.
+//
+// Example:
+//
+//	client.SubscribeTradeData("BTCUSDT", "USDT-FUTURES", func(t ws.TradeData) {
+//	    fmt.Println(t.Side, t.SizeFloat, "@", t.PriceFloat)
+//	})
+func (c *BaseWsClient) SubscribeTradeData(symbol, productType string, handler func(TradeData)) {
+	c.SubscribeTrades(symbol, productType, typedHandler(c, func(_ string, t TradeData) error {
+		if err := t.ParseAll(); err != nil {
+			return err
+		}
+		handler(t)
+		return nil
+	}))
+}
+
+// SubscribeMarkPriceData subscribes to mark price updates for a symbol with a typed
+// handler. Like SubscribeMarkPrice it uses the ticker channel, so it replaces a ticker
+// subscription of the same symbol.
+//
+// Example:
+//
+//	client.SubscribeMarkPriceData("BTCUSDT", "USDT-FUTURES", func(m ws.MarkPriceData) {
+//	    fmt.Println("Mark price:", m.MarkPriceFloat)
+//	})
+func (c *BaseWsClient) SubscribeMarkPriceData(symbol, productType string, handler func(MarkPriceData)) {
+	c.SubscribeMarkPrice(symbol, productType, typedHandler(c, func(_ string, m MarkPriceData) error {
+		if err := m.ParseAll(); err != nil {
+			return err
+		}
+		handler(m)
+		return nil
+	}))
+}
+
+// SubscribeFundingTimeData subscribes to funding rate and funding time updates for a
+// symbol with a typed handler.
+//
+// Example:
+//
+//	client.SubscribeFundingTimeData("BTCUSDT", "USDT-FUTURES", func(f ws.FundingTimeData) {
+//	    fmt.Println("Funding rate:", f.FundingRateFloat, "next:", f.NextFundingTimeDate)
+//	})
+func (c *BaseWsClient) SubscribeFundingTimeData(symbol, productType string, handler func(FundingTimeData)) {
+	c.SubscribeFundingTime(symbol, productType, typedHandler(c, func(_ string, f FundingTimeData) error {
+		if err := f.ParseAll(); err != nil {
+			return err
+		}
+		handler(f)
+		return nil
+	}))
+}
+
+// typedHandler returns an OnReceive that decodes the data array of a push into T and
+// calls handle for every entry with the action of the push.
+func typedHandler[T any](c *BaseWsClient, handle func(action string, item T) error) OnReceive {
+	return func(message string) {
+		var msg struct {
+			Action string            `json:"action"`
+			Arg    SubscriptionArgs  `json:"arg"`
+			Data   []json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(message), &msg); err != nil {
+			c.decodeFailed(message, msg.Arg, err)
+			return
+		}
+		for _, raw := range msg.Data {
+			var item T
+			if err := json.Unmarshal(raw, &item); err != nil {
+				c.decodeFailed(message, msg.Arg, err)
+				return
+			}
+			if err := handle(msg.Action, item); err != nil {
+				c.decodeFailed(message, msg.Arg, err)
+				return
+			}
+		}
+	}
+}
+
+func (c *BaseWsClient) decodeFailed(message string, args SubscriptionArgs, err error) {
+	c.logger.Warn("failed to decode push", "channel", args.Channel, "symbol", args.Symbol, "error", err)
+	if c.errorListener != nil {
+		c.errorListener(message)
+	}
+}
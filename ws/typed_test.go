@@ -0,0 +1,104 @@
+package ws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTypedTestClient returns a client that is never connected; the tests drive its
+// handlers directly.
+func newTypedTestClient() *BaseWsClient {
+	return NewBitgetBaseWsClient(zerolog.Nop(), "wss://test.example.com", "")
+}
+
+func TestSubscribeTickerData(t *testing.T) {
+	client := newTypedTestClient()
+	var got []TickerData
+	client.SubscribeTickerData("BTCUSDT", "USDT-FUTURES", func(t TickerData) { got = append(got, t) })
+
+	assert.True(t, client.IsSubscribed(ChannelTicker, "BTCUSDT", "USDT-FUTURES"), "tracked for restoration")
+	listener := client.GetListener(map[string]interface{}{"instType": "USDT-FUTURES", "channel": "ticker", "instId": "BTCUSDT"})
+	listener(`{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"ticker","instId":"BTCUSDT"},"data":[{"instId":"BTCUSDT","lastPr":"50000.5","bidPr":"50000","askPr":"50001","nextFundingTime":"1700006400000","ts":"1700000000000"}],"ts":1700000000001}`)
+
+	require.Len(t, got, 1)
+	assert.Equal(t, 50000.5, got[0].LastPriceFloat)
+	assert.Equal(t, 1.0, got[0].Spread())
+	assert.Equal(t, time.UnixMilli(1700006400000), got[0].NextFundingTimeDate)
+}
+
+func TestSubscribeOrderBookData(t *testing.T) {
+	client := newTypedTestClient()
+	var actions []string
+	var books []OrderBookData
+	client.SubscribeOrderBookData("BTCUSDT", "USDT-FUTURES", func(action string, book OrderBookData) {
+		actions = append(actions, action)
+		books = append(books, book)
+	})
+
+	handler := client.GetActiveSubscriptions()[SubscriptionArgs{ProductType: "USDT-FUTURES", Channel: ChannelBooks, Symbol: "BTCUSDT"}]
+	require.NotNil(t, handler)
+	handler(`{"action":"snapshot","arg":{"instType":"USDT-FUTURES","channel":"books","instId":"BTCUSDT"},"data":[{"asks":[["50001","2"]],"bids":[["50000","1.5"]],"checksum":0,"seq":1,"ts":"1700000000000"}]}`)
+	handler(`{"action":"update","arg":{"instType":"USDT-FUTURES","channel":"books","instId":"BTCUSDT"},"data":[{"asks":[],"bids":[["49999","3"]],"checksum":0,"seq":2,"ts":"1700000000100"}]}`)
+
+	assert.Equal(t, []string{ActionSnapshot, ActionUpdate}, actions)
+	price, size := books[0].BestBid()
+	assert.Equal(t, 50000.0, price)
+	assert.Equal(t, 1.5, size)
+	assert.Equal(t, int64(2), books[1].Seq)
+}
+
+func TestSubscribeCandleAndTradeData(t *testing.T) {
+	client := newTypedTestClient()
+	var candles []CandlestickData
+	var trades []TradeData
+	client.SubscribeCandleData("BTCUSDT", "USDT-FUTURES", Timeframe1m, func(k CandlestickData) { candles = append(candles, k) })
+	client.SubscribeTradeData("BTCUSDT", "USDT-FUTURES", func(t TradeData) { trades = append(trades, t) })
+	subs := client.GetActiveSubscriptions()
+
+	subs[SubscriptionArgs{ProductType: "USDT-FUTURES", Channel: "candle1m", Symbol: "BTCUSDT"}](`{"action":"update","arg":{"instType":"USDT-FUTURES","channel":"candle1m","instId":"BTCUSDT"},"data":[["1700000000000","50000","50100","49900","50050","12","600000","600000"]]}`)
+	subs[SubscriptionArgs{ProductType: "USDT-FUTURES", Channel: ChannelTrade, Symbol: "BTCUSDT"}](`{"action":"update","arg":{"instType":"USDT-FUTURES","channel":"trade","instId":"BTCUSDT"},"data":[{"ts":"1700000000000","price":"50000","size":"0.1","side":"buy","tradeId":"1"},{"ts":"1700000000001","price":"50001","size":"0.2","side":"sell","tradeId":"2"}]}`)
+
+	require.Len(t, candles, 1)
+	assert.Equal(t, 50050.0, candles[0].CloseFloat)
+	assert.True(t, candles[0].IsBullish())
+	require.Len(t, trades, 2, "one call per entry")
+	assert.True(t, trades[0].IsBuy)
+	assert.Equal(t, 0.2, trades[1].SizeFloat)
+}
+
+func TestSubscribeMarkPriceAndFundingTimeData(t *testing.T) {
+	client := newTypedTestClient()
+	var marks []MarkPriceData
+	var funding []FundingTimeData
+	client.SubscribeMarkPriceData("BTCUSDT", "USDT-FUTURES", func(m MarkPriceData) { marks = append(marks, m) })
+	client.SubscribeFundingTimeData("BTCUSDT", "USDT-FUTURES", func(f FundingTimeData) { funding = append(funding, f) })
+	subs := client.GetActiveSubscriptions()
+
+	subs[SubscriptionArgs{ProductType: "USDT-FUTURES", Channel: ChannelTicker, Symbol: "BTCUSDT"}](`{"arg":{"instType":"USDT-FUTURES","channel":"ticker","instId":"BTCUSDT"},"data":[{"instId":"BTCUSDT","lastPr":"50000","markPrice":"50002.1","indexPrice":"49998","ts":"1700000000000"}]}`)
+	subs[SubscriptionArgs{ProductType: "USDT-FUTURES", Channel: ChannelFundingTime, Symbol: "BTCUSDT"}](`{"arg":{"instType":"USDT-FUTURES","channel":"funding-time","instId":"BTCUSDT"},"data":[{"instId":"BTCUSDT","fundingRate":"0.0001","nextFundingTime":"1700006400000"}]}`)
+
+	require.Len(t, marks, 1)
+	assert.Equal(t, 50002.1, marks[0].MarkPriceFloat)
+	assert.Equal(t, 49998.0, marks[0].IndexPriceFloat)
+	require.Len(t, funding, 1)
+	assert.Equal(t, 0.0001, funding[0].FundingRateFloat)
+	assert.Equal(t, time.UnixMilli(1700006400000), funding[0].NextFundingTimeDate)
+}
+
+func TestTypedHandler_DecodeErrors(t *testing.T) {
+	client := newTypedTestClient()
+	var errs []string
+	client.SetListener(func(string) {}, func(message string) { errs = append(errs, message) })
+	called := false
+	client.SubscribeTradeData("BTCUSDT", "USDT-FUTURES", func(TradeData) { called = true })
+
+	bad := `{"arg":{"instType":"USDT-FUTURES","channel":"trade","instId":"BTCUSDT"},"data":[{"price":"abc","size":"1"}]}`
+	client.GetActiveSubscriptions()[SubscriptionArgs{ProductType: "USDT-FUTURES", Channel: ChannelTrade, Symbol: "BTCUSDT"}](bad)
+
+	assert.False(t, called)
+	assert.Equal(t, []string{bad}, errs)
+}
@@ -497,6 +497,77 @@ func (t *TradeData) Value() float64 {
 	return t.PriceFloat * t.SizeFloat
 }
 
+// =============================================================================
+// MARK PRICE AND FUNDING DATA ABSTRACTION
+// =============================================================================
+
+// MarkPriceData holds the mark and index prices of a symbol. Bitget pushes them on the
+// ticker channel, from which SubscribeMarkPriceData extracts them.
+type MarkPriceData struct {
+	InstId     string `json:"instId"`     // Product ID, e.g., BTCUSDT
+	MarkPrice  string `json:"markPrice"`  // Mark price
+	IndexPrice string `json:"indexPrice"` // Index price
+	Timestamp  string `json:"ts"`         // System timestamp
+
+	// Parsed fields
+	MarkPriceFloat  float64   `json:"-"`
+	IndexPriceFloat float64   `json:"-"`
+	TimestampDate   time.Time `json:"-"`
+}
+
+// ParseAll parses all string fields to appropriate types
+func (m *MarkPriceData) ParseAll() error {
+	var err error
+	if m.MarkPrice != "" {
+		if m.MarkPriceFloat, err = strconv.ParseFloat(m.MarkPrice, 64); err != nil {
+			return fmt.Errorf("failed to parse markPrice: %w", err)
+		}
+	}
+	if m.IndexPrice != "" {
+		if m.IndexPriceFloat, err = strconv.ParseFloat(m.IndexPrice, 64); err != nil {
+			return fmt.Errorf("failed to parse indexPrice: %w", err)
+		}
+	}
+	if m.Timestamp != "" {
+		timestamp, err := strconv.ParseInt(m.Timestamp, 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse timestamp: %w", err)
+		}
+		m.TimestampDate = time.UnixMilli(timestamp)
+	}
+	return nil
+}
+
+// FundingTimeData represents funding rate and settlement time data
+type FundingTimeData struct {
+	InstId          string `json:"instId"`          // Product ID, e.g., BTCUSDT
+	FundingRate     string `json:"fundingRate"`     // Current funding rate
+	NextFundingTime string `json:"nextFundingTime"` // Next settlement time (timestamp)
+	Timestamp       string `json:"ts"`              // System timestamp
+
+	// Parsed fields
+	FundingRateFloat    float64   `json:"-"`
+	NextFundingTimeDate time.Time `json:"-"`
+}
+
+// ParseAll parses all string fields to appropriate types
+func (f *FundingTimeData) ParseAll() error {
+	var err error
+	if f.FundingRate != "" {
+		if f.FundingRateFloat, err = strconv.ParseFloat(f.FundingRate, 64); err != nil {
+			return fmt.Errorf("failed to parse fundingRate: %w", err)
+		}
+	}
+	if f.NextFundingTime != "" {
+		timestamp, err := strconv.ParseInt(f.NextFundingTime, 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse nextFundingTime: %w", err)
+		}
+		f.NextFundingTimeDate = time.UnixMilli(timestamp)
+	}
+	return nil
+}
+
 // =============================================================================
 // HELPER FUNCTIONS
 // =============================================================================